@@ -3,14 +3,209 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // 環境変数から読み込む
 var JwtKey = []byte(os.Getenv("JWT_SECRET_KEY"))
 var IsProduction = os.Getenv("ENV") == "production"
 
+// データベースドライバ
+const (
+	DbDriverSupabase = "supabase"
+	DbDriverMemory   = "memory"
+)
+
 func init() {
 	if len(JwtKey) == 0 {
 		log.Fatal("JWT_SECRET_KEY is not set in the environment")
 	}
 }
+
+// 使用するデータベースドライバを取得する
+// .envの読み込み後に評価されるよう、呼び出し時に環境変数 DB_DRIVER を参照する。
+// 未設定の場合は supabase を返す。
+func DbDriver() string {
+	if driver := os.Getenv("DB_DRIVER"); driver != "" {
+		return driver
+	}
+	return DbDriverSupabase
+}
+
+// インメモリドライバを使用するか判定する
+func IsMemoryDriver() bool {
+	return DbDriver() == DbDriverMemory
+}
+
+// クエリ単位のタイムアウトを取得する
+// 環境変数 DB_QUERY_TIMEOUT (例: "5s") を参照し、未設定の場合は5秒を返す。
+func QueryTimeout() time.Duration {
+	return durationFromEnv("DB_QUERY_TIMEOUT", 5*time.Second)
+}
+
+// リクエスト単位のタイムアウトを取得する
+// 環境変数 REQUEST_TIMEOUT (例: "10s") を参照し、未設定の場合は10秒を返す。
+func RequestTimeout() time.Duration {
+	return durationFromEnv("REQUEST_TIMEOUT", 10*time.Second)
+}
+
+// ゴミ箱内のブログの保持期間を取得する
+// 環境変数 BLOG_TRASH_RETENTION (例: "720h") を参照し、未設定の場合は30日を返す。
+func BlogTrashRetention() time.Duration {
+	return durationFromEnv("BLOG_TRASH_RETENTION", 30*24*time.Hour)
+}
+
+// ゴミ箱内のブログを完全削除する間隔を取得する
+// 環境変数 BLOG_PURGE_INTERVAL (例: "1h") を参照し、未設定の場合は1時間を返す。
+func BlogPurgeInterval() time.Duration {
+	return durationFromEnv("BLOG_PURGE_INTERVAL", time.Hour)
+}
+
+// 予約中のブログを公開する間隔を取得する
+// 環境変数 BLOG_PUBLISH_INTERVAL (例: "1m") を参照し、未設定の場合は1分を返す。
+func BlogPublishInterval() time.Duration {
+	return durationFromEnv("BLOG_PUBLISH_INTERVAL", time.Minute)
+}
+
+// 読み取り結果をキャッシュする期間を取得する
+// 環境変数 CACHE_TTL (例: "1m") を参照し、未設定の場合は1分を返す。
+func CacheTTL() time.Duration {
+	return durationFromEnv("CACHE_TTL", time.Minute)
+}
+
+// 他のインスタンスからの変更通知を受信できない間のキャッシュの有効期間を取得する
+// 環境変数 CACHE_FALLBACK_TTL (例: "5s") を参照し、未設定の場合は5秒を返す。
+func CacheFallbackTTL() time.Duration {
+	return durationFromEnv("CACHE_FALLBACK_TTL", 5*time.Second)
+}
+
+// アクセストークン(JWT)の有効期間を取得する
+// 環境変数 ACCESS_TOKEN_TTL (例: "15m") を参照し、未設定の場合は15分を返す。
+func AccessTokenTTL() time.Duration {
+	return durationFromEnv("ACCESS_TOKEN_TTL", 15*time.Minute)
+}
+
+// リフレッシュトークンの有効期間を取得する
+// 環境変数 REFRESH_TOKEN_TTL (例: "720h") を参照し、未設定の場合は30日を返す。
+// 期間はログインした時点から数え、トークンを交換しても延長されない(期限を過ぎると再ログインが必要)。
+func RefreshTokenTTL() time.Duration {
+	return durationFromEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour)
+}
+
+// 確認メールのリンクの有効期間を取得する
+// 環境変数 EMAIL_VERIFICATION_TTL (例: "24h") を参照し、未設定の場合は24時間を返す。
+func EmailVerificationTTL() time.Duration {
+	return durationFromEnv("EMAIL_VERIFICATION_TTL", 24*time.Hour)
+}
+
+// パスワード再設定のリンクの有効期間を取得する
+// 環境変数 PASSWORD_RESET_TTL (例: "1h") を参照し、未設定の場合は1時間を返す。
+func PasswordResetTTL() time.Duration {
+	return durationFromEnv("PASSWORD_RESET_TTL", time.Hour)
+}
+
+// メールアドレスごとのパスワード再設定の申請の上限を取得する
+// 環境変数 PASSWORD_RESET_MAX_PER_EMAIL を参照し、未設定の場合は3回を返す。
+// 申請はログインの失敗と同じ期間(LOGIN_THROTTLE_WINDOW)で数える。
+func PasswordResetMaxRequestsPerEmail() int {
+	return intFromEnv("PASSWORD_RESET_MAX_PER_EMAIL", 3)
+}
+
+// IPアドレスごとのパスワード再設定の申請の上限を取得する
+// 環境変数 PASSWORD_RESET_MAX_PER_IP を参照し、未設定の場合は20回を返す。
+func PasswordResetMaxRequestsPerIP() int {
+	return intFromEnv("PASSWORD_RESET_MAX_PER_IP", 20)
+}
+
+// ログインの失敗を数える期間を取得する
+// 環境変数 LOGIN_THROTTLE_WINDOW (例: "15m") を参照し、未設定の場合は15分を返す。
+// 直近のこの期間の失敗回数に応じて、ログインの待ち時間・ロックを判定する。
+func LoginThrottleWindow() time.Duration {
+	return durationFromEnv("LOGIN_THROTTLE_WINDOW", 15*time.Minute)
+}
+
+// ログインに失敗した後の待ち時間の初期値を取得する
+// 環境変数 LOGIN_THROTTLE_DELAY (例: "1s") を参照し、未設定の場合は1秒を返す。
+// 待ち時間は失敗するたびに倍になる。
+func LoginThrottleDelay() time.Duration {
+	return durationFromEnv("LOGIN_THROTTLE_DELAY", time.Second)
+}
+
+// メールアドレスごとのログインの失敗の上限を取得する
+// 環境変数 LOGIN_MAX_FAILURES_PER_EMAIL を参照し、未設定の場合は10回を返す。
+func LoginMaxFailuresPerEmail() int {
+	return intFromEnv("LOGIN_MAX_FAILURES_PER_EMAIL", 10)
+}
+
+// IPアドレスごとのログインの失敗の上限を取得する
+// 環境変数 LOGIN_MAX_FAILURES_PER_IP を参照し、未設定の場合は50回を返す。
+// 同じIPアドレスを共有する利用者を考慮し、メールアドレスごとの上限より大きくする。
+func LoginMaxFailuresPerIP() int {
+	return intFromEnv("LOGIN_MAX_FAILURES_PER_IP", 50)
+}
+
+// 2要素認証のコードを待つログインのチャレンジの有効期間を取得する
+// 環境変数 TWO_FACTOR_CHALLENGE_TTL (例: "5m") を参照し、未設定の場合は5分を返す。
+func TwoFactorChallengeTTL() time.Duration {
+	return durationFromEnv("TWO_FACTOR_CHALLENGE_TTL", 5*time.Minute)
+}
+
+// 認証アプリに表示する発行者名を取得する
+// 環境変数 TOTP_ISSUER を参照し、未設定の場合は "Blog" を返す。
+func TOTPIssuer() string {
+	if issuer := os.Getenv("TOTP_ISSUER"); issuer != "" {
+		return issuer
+	}
+	return "Blog"
+}
+
+// ログインの失敗を記録するストアを取得する
+// 環境変数 LOGIN_THROTTLE_STORE (memory または supabase) を参照し、未設定の場合は DB_DRIVER と同じものを返す。
+// 複数のインスタンスで運用する場合は、失敗回数を共有するため supabase とする。
+func LoginThrottleStore() string {
+	if store := os.Getenv("LOGIN_THROTTLE_STORE"); store != "" {
+		return store
+	}
+	return DbDriver()
+}
+
+// メールに記載するリンクのベースURL(フロントエンドのURL)を取得する
+// 環境変数 APP_BASE_URL を参照し、未設定の場合は http://localhost:3000 を返す。
+func AppBaseURL() string {
+	if url := os.Getenv("APP_BASE_URL"); url != "" {
+		return strings.TrimRight(url, "/")
+	}
+	return "http://localhost:3000"
+}
+
+// 環境変数から時間を読み込む
+// 未設定または不正な値の場合は既定値を返す。
+func durationFromEnv(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid %s: %q, using default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return d
+}
+
+// 環境変数から正の整数を読み込む
+// 未設定または不正な値の場合は既定値を返す。
+func intFromEnv(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("Invalid %s: %q, using default %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
@@ -1,19 +1,22 @@
 package handlers_auth
 
 import (
-	"backend/config"
 	"backend/models"
+	services_auth "backend/services/auth"
+	utils_auth "backend/utils/auth"
 	utils_cookie "backend/utils/cookie"
 	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
 
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
-	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 )
 
-// ログインエンドポイント（JWTトークンの発行）
+// ログインエンドポイント（アクセストークン(JWT)・リフレッシュトークンの発行）
 func (h *AuthHandler) Login(c echo.Context) error {
 	utils.LogInfo(c, "Logging in...")
 
@@ -51,90 +54,174 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		}
 	}
 
+	ctx := c.Request().Context()
+	ip := c.RealIP()
+
+	// 試行を失敗として先に記録し、失敗が続いている場合は待ち時間・ロックが解けるまでパスワードを検証しない
+	// 同時に試行しても、検証の前に記録するため待ち時間・ロックを回避できない。
+	attempt, retryAfter, err := h.AuthService.ReserveLoginAttempt(ctx, ip, reqBody.Email)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		utils.LogError(c, "Login throttled: "+err.Error())
+		return tooManyLoginAttemptsResponse(c, retryAfter)
+	}
+
 	// サービス層からユーザーデータを取得
-	user, err := h.UserService.FetchUserByEmailAndPassword(reqBody.Email, reqBody.Password)
+	user, err := h.UserService.FetchUserByEmailAndPassword(ctx, reqBody.Email, reqBody.Password)
 	if err != nil {
 		utils.LogError(c, "Error fetching user: "+err.Error())
-		return c.JSON(http.StatusNotFound, map[string]string{
-			"error": "User not found",
+		switch err.Error() {
+		case "user not found", "failed to verify password":
+			// アカウントが存在しない場合とパスワードの誤りを区別せず、先に記録した試行を失敗として残す
+			return c.JSON(http.StatusUnauthorized, map[string]string{
+				"error": "Invalid email or password",
+			})
+		}
+		// パスワードを検証できなかった試行は失敗として数えない
+		h.releaseLoginAttempt(c, attempt)
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "An error occurred",
 		})
 	}
 
 	// 認証成功
 	utils.LogInfo(c, "User authenticated successfully:"+user.Email)
 
-	// JWTトークンの作成
-	expirationTime := time.Now().Add(1 * time.Hour) // トークンの有効期限を1時間に設定
-	claims := &models.Claims{
-		UserID:   user.ID,
-		Email:    user.Email,
-		Username: user.Name,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: expirationTime.Unix(),
-		},
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(config.JwtKey)
+	// パスワードが正しいため、先に記録した試行を取り消す
+	h.releaseLoginAttempt(c, attempt)
+
+	// 2要素認証が有効な場合は、トークンを発行せずにコードの確認を待つチャレンジを返す
+	challenge, err := h.AuthService.CreateTwoFactorChallenge(ctx, user.ID)
 	if err != nil {
-		utils.LogError(c, "Could not create JWT token: "+err.Error())
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		utils.LogError(c, "Could not create two-factor challenge: "+err.Error())
 		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Could not create token",
+			"error": "Could not start two-factor authentication",
+		})
+	}
+	if challenge != nil {
+		utils.LogInfo(c, "Two-factor authentication required")
+		return c.JSON(http.StatusAccepted, twoFactorRequiredResponse{
+			Message:            "Two-factor authentication required",
+			TwoFactorChallenge: challenge,
 		})
 	}
-	utils.LogInfo(c, "JWT token created successfully")
 
-	// HTTPS-onlyクッキーにトークンをセット
-	utils_cookie.AddAuthCookie(c, tokenString, expirationTime)
+	// メールアドレスの失敗の記録を削除(失敗してもログインは続ける)
+	if err := h.AuthService.ResetLoginFailures(ctx, reqBody.Email); err != nil {
+		utils.LogError(c, "Error resetting login failures: "+err.Error())
+	}
 
-	utils.LogInfo(c, "JWT token set in HTTPS-only cookie")
-	return c.JSON(http.StatusOK, map[string]string{"message": "Login successful"})
+	return h.issueLoginTokens(c, user)
 }
 
-// 認証確認エンドポイント
-func (h *AuthHandler) CheckAuth(c echo.Context) error {
-	utils.LogInfo(c, "Checking authentication...")
+// 先に記録したログインの試行を取り消す(失敗してもレスポンスは変えない)
+func (h *AuthHandler) releaseLoginAttempt(c echo.Context, attempt *services_auth.LoginAttempt) {
+	if err := h.AuthService.ReleaseLoginAttempt(c.Request().Context(), attempt); err != nil {
+		utils.LogError(c, "Error releasing login attempt: "+err.Error())
+	}
+}
 
-	// // リクエストヘッダーの内容をログに出力
-	// for name, values := range c.Request().Header {
-	// 	for _, value := range values {
-	// 		utils.LogInfo(c, "Request header: "+name+" = "+value)
-	// 	}
-	// }
-
-	// // リクエストボディの内容をログに出力（ボディがある場合）
-	// if c.Request().ContentLength > 0 {
-	// 	bodyBytes, err := io.ReadAll(c.Request().Body)
-	// 	if err != nil {
-	// 		utils.LogError(c, "Failed to read request body: "+err.Error())
-	// 		return c.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to read request body"})
-	// 	}
-	// 	// ボディの読み込み後、再度リクエストボディにセットする
-	// 	c.Request().Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-
-	// 	utils.LogInfo(c, "Request body: "+string(bodyBytes))
-	// }
-
-	// クッキーからJWTトークンを取得
-	cookie, err := c.Cookie("token")
+// 2要素認証のコードを待つ場合のレスポンス
+type twoFactorRequiredResponse struct {
+	Message string `json:"message"`
+	*models.TwoFactorChallenge
+}
+
+// ログインしたユーザーにアクセストークン(JWT)とリフレッシュトークンを発行し、クッキーにセットする
+func (h *AuthHandler) issueLoginTokens(c echo.Context, user *models.UserData) error {
+	tokens, err := h.AuthService.IssueTokens(c.Request().Context(), user)
 	if err != nil {
-		utils.LogError(c, "Token not found in cookies")
-		return c.JSON(http.StatusUnauthorized, map[string]string{"message": "Token not found"})
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		utils.LogError(c, "Could not create tokens: "+err.Error())
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Could not create token",
+		})
 	}
-	tokenString := cookie.Value
+	utils.LogInfo(c, "Tokens created successfully")
+
+	// HTTPS-onlyクッキーにトークンをセット
+	setTokenCookies(c, tokens)
 
-	claims := &models.Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return config.JwtKey, nil
+	utils.LogInfo(c, "Tokens set in HTTPS-only cookies")
+	return c.JSON(http.StatusOK, map[string]string{"message": "Login successful"})
+}
+
+// ログインの試行が多すぎる場合のレスポンスを返す
+// Retry-After ヘッダーに、次に試せるまでの秒数(切り上げ)を設定する。
+func tooManyLoginAttemptsResponse(c echo.Context, retryAfter time.Duration) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+	return c.JSON(http.StatusTooManyRequests, map[string]string{
+		"error": "Too many login attempts",
 	})
+}
+
+// トークン更新エンドポイント(リフレッシュトークンのローテーション)
+// クッキーのリフレッシュトークンを新しいトークンと交換し、アクセストークンを発行し直す。
+func (h *AuthHandler) Refresh(c echo.Context) error {
+	utils.LogInfo(c, "Refreshing tokens...")
+
+	var refreshToken string
+	if cookie, err := c.Cookie(utils_cookie.RefreshCookieName); err == nil {
+		refreshToken = cookie.Value
+	}
 
+	tokens, err := h.AuthService.RefreshTokens(c.Request().Context(), refreshToken)
 	if err != nil {
-		utils.LogError(c, "Failed to parse token: "+err.Error())
-		return c.JSON(http.StatusUnauthorized, map[string]string{"message": "Invalid token"})
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "refresh token is required", "invalid refresh token":
+			// 使用できないトークンは削除し、再ログインを求める
+			utils.LogError(c, "Invalid refresh token: "+err.Error())
+			utils_cookie.DelAuthCookie(c)
+			utils_cookie.DelRefreshCookie(c)
+			return c.JSON(http.StatusUnauthorized, map[string]string{
+				"error": "Invalid refresh token",
+			})
+		default:
+			utils.LogError(c, "Error refreshing tokens: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to refresh token",
+			})
+		}
 	}
 
-	if !token.Valid {
-		utils.LogError(c, "Invalid token")
-		return c.JSON(http.StatusUnauthorized, map[string]string{"message": "Invalid token"})
+	setTokenCookies(c, tokens)
+
+	utils.LogInfo(c, "Tokens refreshed successfully")
+	return c.JSON(http.StatusOK, map[string]string{"message": "Token refreshed"})
+}
+
+// アクセストークンとリフレッシュトークンをクッキーにセットする
+func setTokenCookies(c echo.Context, tokens *models.AuthTokens) {
+	utils_cookie.AddAuthCookie(c, tokens.AccessToken, tokens.AccessTokenExpiresAt)
+	utils_cookie.AddRefreshCookie(c, tokens.RefreshToken, tokens.RefreshTokenExpiresAt)
+}
+
+// 認証確認エンドポイント
+// ログインが必要なルートグループに登録し、認証ミドルウェアで検証済みのユーザー情報を返す。
+func (h *AuthHandler) CheckAuth(c echo.Context) error {
+	utils.LogInfo(c, "Checking authentication...")
+
+	// 認証ミドルウェアで検証済みのクレームを取得
+	claims, ok := utils_auth.Principal(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
 	}
 
 	// 認証成功
@@ -148,12 +235,30 @@ func (h *AuthHandler) CheckAuth(c echo.Context) error {
 }
 
 // ログアウトエンドポイント
+// リフレッシュトークンのセッションをサーバー側で失効させ、クッキーを削除する。
 func (h *AuthHandler) Logout(c echo.Context) error {
 	utils.LogInfo(c, "Logging out...")
 
+	var refreshToken string
+	if cookie, err := c.Cookie(utils_cookie.RefreshCookieName); err == nil {
+		refreshToken = cookie.Value
+	}
+
 	// クッキーを削除するために、空のトークンと過去の有効期限を設定
+	// セッションの失効に失敗した場合も、このブラウザからはログアウトさせる
 	utils_cookie.DelAuthCookie(c)
+	utils_cookie.DelRefreshCookie(c)
+
+	if err := h.AuthService.Logout(c.Request().Context(), refreshToken); err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		utils.LogError(c, "Error revoking session: "+err.Error())
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to revoke session",
+		})
+	}
 
-	utils.LogInfo(c, "User logged out and token removed from cookie")
+	utils.LogInfo(c, "User logged out, session revoked and tokens removed from cookies")
 	return c.JSON(http.StatusOK, map[string]string{"message": "Logout successful"})
 }
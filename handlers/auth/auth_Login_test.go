@@ -0,0 +1,100 @@
+package handlers_auth
+
+import (
+	services_auth "backend/services/auth"
+	services_users "backend/services/users"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Login_Failure(t *testing.T) {
+	tests := []struct {
+		name           string
+		retryAfter     time.Duration
+		throttleErr    error
+		fetchErr       error
+		expectedStatus int
+		expectedBody   string
+		expectedRetry  string
+		recorded       bool
+	}{
+		{
+			name:           "パスワードの誤り・存在しないアカウント",
+			fetchErr:       errors.New("user not found"),
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"Invalid email or password"}`,
+			recorded:       true,
+		},
+		{
+			name:           "パスワードの検証に失敗",
+			fetchErr:       errors.New("failed to verify password"),
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"Invalid email or password"}`,
+			recorded:       true,
+		},
+		{
+			name:           "ユーザーの取得に失敗",
+			fetchErr:       errors.New("db error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"An error occurred"}`,
+		},
+		{
+			name:           "失敗が続いている",
+			retryAfter:     2500 * time.Millisecond,
+			throttleErr:    errors.New("too many login attempts"),
+			expectedStatus: http.StatusTooManyRequests,
+			expectedBody:   `{"error":"Too many login attempts"}`,
+			expectedRetry:  "3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/login", strings.NewReader(`{"email":"test@example.com","password":"wrongpassword"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockAuthService := new(services_auth.MockAuthService)
+			mockUserService := new(services_users.MockUserService)
+			mockAuthService.On("Login", "test@example.com", "wrongpassword").Return(nil)
+			attempt := &services_auth.LoginAttempt{}
+			if tt.throttleErr == nil {
+				mockAuthService.On("ReserveLoginAttempt", "192.0.2.1", "test@example.com").Return(attempt, time.Duration(0), nil)
+				mockUserService.On("FetchUserByEmailAndPassword", "test@example.com", "wrongpassword").Return(nil, tt.fetchErr)
+			} else {
+				mockAuthService.On("ReserveLoginAttempt", "192.0.2.1", "test@example.com").Return(nil, tt.retryAfter, tt.throttleErr)
+			}
+			if !tt.recorded {
+				mockAuthService.On("ReleaseLoginAttempt", attempt).Return(nil).Maybe()
+			}
+
+			handler := NewAuthHandler(mockUserService, mockAuthService)
+			err := handler.Login(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			assert.Equal(t, tt.expectedRetry, rec.Header().Get(echo.HeaderRetryAfter))
+			// 失敗ではログインできない
+			assert.Nil(t, findCookie(rec, "token"))
+			mockAuthService.AssertExpectations(t)
+			mockUserService.AssertExpectations(t)
+			if tt.recorded {
+				// パスワードの誤りは、先に記録した試行をそのまま失敗として残す
+				mockAuthService.AssertNotCalled(t, "ReleaseLoginAttempt", attempt)
+			} else if tt.throttleErr == nil {
+				// パスワードを検証できなかった試行は取り消す
+				mockAuthService.AssertCalled(t, "ReleaseLoginAttempt", attempt)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package handlers_auth
+
+import (
+	"backend/models"
+	services_auth "backend/services/auth"
+	services_users "backend/services/users"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Refresh(t *testing.T) {
+	tests := []struct {
+		name           string
+		cookie         string
+		tokens         *models.AuthTokens
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+		cleared        bool
+	}{
+		{
+			name:   "トークンを交換",
+			cookie: "old-refresh-token",
+			tokens: &models.AuthTokens{
+				AccessToken:           "new-access-token",
+				AccessTokenExpiresAt:  time.Now().Add(15 * time.Minute),
+				RefreshToken:          "new-refresh-token",
+				RefreshTokenExpiresAt: time.Now().Add(30 * 24 * time.Hour),
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"message":"Token refreshed"}`,
+		},
+		{
+			name:           "クッキーがない",
+			serviceErr:     errors.New("refresh token is required"),
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"Invalid refresh token"}`,
+			cleared:        true,
+		},
+		{
+			name:           "無効・再利用されたトークン",
+			cookie:         "reused-refresh-token",
+			serviceErr:     errors.New("invalid refresh token"),
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"Invalid refresh token"}`,
+			cleared:        true,
+		},
+		{
+			name:           "サービスエラー",
+			cookie:         "old-refresh-token",
+			serviceErr:     errors.New("failed to refresh tokens"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"Failed to refresh token"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/refresh", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "refresh_token", Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockAuthService := new(services_auth.MockAuthService)
+			mockUserService := new(services_users.MockUserService)
+			mockAuthService.On("RefreshTokens", tt.cookie).Return(tt.tokens, tt.serviceErr)
+			handler := NewAuthHandler(mockUserService, mockAuthService)
+
+			assert.NoError(t, handler.Refresh(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+
+			if tt.tokens != nil {
+				assert.Equal(t, "new-access-token", findCookie(rec, "token").Value)
+				assert.Equal(t, "new-refresh-token", findCookie(rec, "refresh_token").Value)
+			}
+			if tt.cleared {
+				assert.Empty(t, findCookie(rec, "token").Value)
+				assert.Empty(t, findCookie(rec, "refresh_token").Value)
+			}
+			mockAuthService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_Logout(t *testing.T) {
+	tests := []struct {
+		name           string
+		cookie         string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"セッションを失効", "refresh-token", nil, http.StatusOK},
+		{"クッキーがない", "", nil, http.StatusOK},
+		{"失効に失敗", "refresh-token", errors.New("failed to revoke session"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/logout", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "refresh_token", Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockAuthService := new(services_auth.MockAuthService)
+			mockUserService := new(services_users.MockUserService)
+			mockAuthService.On("Logout", tt.cookie).Return(tt.serviceErr)
+			handler := NewAuthHandler(mockUserService, mockAuthService)
+
+			assert.NoError(t, handler.Logout(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			// 失効の成否にかかわらずクッキーは削除する
+			assert.Empty(t, findCookie(rec, "token").Value)
+			assert.Empty(t, findCookie(rec, "refresh_token").Value)
+			mockAuthService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package handlers_auth
+
+import (
+	"backend/middlewares"
+	"backend/models"
+	services_auth "backend/services/auth"
+	services_users "backend/services/users"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Login_TwoFactorRequired(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/users/login", strings.NewReader(`{"email":"test@example.com","password":"password123"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthService := new(services_auth.MockAuthService)
+	mockUserService := new(services_users.MockUserService)
+	user := &models.UserData{ID: "user123", Email: "test@example.com"}
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockAuthService.On("Login", "test@example.com", "password123").Return(nil)
+	attempt := &services_auth.LoginAttempt{}
+	mockAuthService.On("ReserveLoginAttempt", "192.0.2.1", "test@example.com").Return(attempt, time.Duration(0), nil)
+	mockAuthService.On("ReleaseLoginAttempt", attempt).Return(nil)
+	mockUserService.On("FetchUserByEmailAndPassword", "test@example.com", "password123").Return(user, nil)
+	mockAuthService.On("CreateTwoFactorChallenge", "user123").Return(&models.TwoFactorChallenge{Token: "challenge-token", ExpiresAt: expiresAt}, nil)
+
+	handler := NewAuthHandler(mockUserService, mockAuthService)
+	err := handler.Login(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.JSONEq(t, `{"message":"Two-factor authentication required","challenge_token":"challenge-token","expires_at":"2030-01-01T00:00:00Z"}`, rec.Body.String())
+	// コードを確認するまではトークンを発行せず、失敗の記録も残す
+	assert.Empty(t, rec.Result().Cookies())
+	mockAuthService.AssertNotCalled(t, "IssueTokens", user)
+	mockAuthService.AssertNotCalled(t, "ResetLoginFailures", "test@example.com")
+	mockAuthService.AssertExpectations(t)
+}
+
+func TestHandler_LoginTwoFactor(t *testing.T) {
+	tests := []struct {
+		name           string
+		user           *models.UserData
+		retryAfter     time.Duration
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+		expectedRetry  string
+	}{
+		{name: "ログイン成功", user: &models.UserData{ID: "user123", Email: "test@example.com"}, expectedStatus: http.StatusOK, expectedBody: `{"message":"Login successful"}`},
+		{name: "入力が空", serviceErr: errors.New("challenge token and code are required"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Challenge token and code are required"}`},
+		{name: "無効なチャレンジ", serviceErr: errors.New("invalid challenge token"), expectedStatus: http.StatusUnauthorized, expectedBody: `{"error":"Invalid or expired challenge"}`},
+		{name: "コードが誤り", serviceErr: errors.New("invalid code"), expectedStatus: http.StatusUnauthorized, expectedBody: `{"error":"Invalid code"}`},
+		{
+			name:           "失敗が続いている",
+			retryAfter:     4 * time.Second,
+			serviceErr:     errors.New("too many login attempts"),
+			expectedStatus: http.StatusTooManyRequests,
+			expectedBody:   `{"error":"Too many login attempts"}`,
+			expectedRetry:  "4",
+		},
+		{name: "サービスのエラー", serviceErr: errors.New("failed to complete login"), expectedStatus: http.StatusInternalServerError, expectedBody: `{"error":"Failed to complete login"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/login/2fa", strings.NewReader(`{"challengeToken":"challenge-token","code":"123456"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockAuthService := new(services_auth.MockAuthService)
+			if tt.user != nil {
+				mockAuthService.On("CompleteTwoFactorLogin", "192.0.2.1", "challenge-token", "123456").Return(tt.user, time.Duration(0), nil)
+				mockAuthService.On("IssueTokens", tt.user).Return(&models.AuthTokens{
+					AccessToken:           "access-token",
+					AccessTokenExpiresAt:  time.Now().Add(15 * time.Minute),
+					RefreshToken:          "refresh-token",
+					RefreshTokenExpiresAt: time.Now().Add(30 * 24 * time.Hour),
+				}, nil)
+			} else {
+				mockAuthService.On("CompleteTwoFactorLogin", "192.0.2.1", "challenge-token", "123456").Return(nil, tt.retryAfter, tt.serviceErr)
+			}
+
+			handler := NewAuthHandler(new(services_users.MockUserService), mockAuthService)
+			err := handler.LoginTwoFactor(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			assert.Equal(t, tt.expectedRetry, rec.Header().Get(echo.HeaderRetryAfter))
+			if tt.user != nil {
+				assert.NotNil(t, findCookie(rec, "token"))
+				assert.NotNil(t, findCookie(rec, "refresh_token"))
+			} else {
+				assert.Nil(t, findCookie(rec, "token"))
+			}
+			mockAuthService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_SetupTwoFactor(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "登録を開始",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"secret":"SECRET","otpauth_uri":"otpauth://totp/Blog:test@example.com?secret=SECRET"}`,
+		},
+		{name: "パスワードが誤り", serviceErr: errors.New("invalid current password"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Invalid current password"}`},
+		{name: "有効にしている", serviceErr: errors.New("two factor already enabled"), expectedStatus: http.StatusConflict, expectedBody: `{"error":"Two-factor authentication is already enabled"}`},
+		{name: "サービスのエラー", serviceErr: errors.New("failed to set up two factor"), expectedStatus: http.StatusInternalServerError, expectedBody: `{"error":"Failed to set up two-factor authentication"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/2fa/setup", strings.NewReader(`{"password":"password123"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockAuthService := new(services_auth.MockAuthService)
+			if tt.serviceErr != nil {
+				mockAuthService.On("SetupTwoFactor", "valid-user-id", "password123").Return(nil, tt.serviceErr)
+			} else {
+				mockAuthService.On("SetupTwoFactor", "valid-user-id", "password123").Return(&models.TOTPSetup{
+					Secret: "SECRET",
+					URI:    "otpauth://totp/Blog:test@example.com?secret=SECRET",
+				}, nil)
+			}
+
+			// モッククッキーを設定
+			SetMockPrincipal(c)
+
+			handler := NewAuthHandler(new(services_users.MockUserService), mockAuthService)
+			err := handler.SetupTwoFactor(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			mockAuthService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_EnableTwoFactor(t *testing.T) {
+	tests := []struct {
+		name           string
+		codes          []string
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "有効にする", codes: []string{"aaaa-bbbb-cccc-dddd"}, expectedStatus: http.StatusOK, expectedBody: `{"recovery_codes":["aaaa-bbbb-cccc-dddd"]}`},
+		{name: "コードが誤り", serviceErr: errors.New("invalid code"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Invalid code"}`},
+		{name: "登録を開始していない", serviceErr: errors.New("two factor not set up"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Two-factor authentication is not set up"}`},
+		{name: "有効にしている", serviceErr: errors.New("two factor already enabled"), expectedStatus: http.StatusConflict, expectedBody: `{"error":"Two-factor authentication is already enabled"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/2fa/enable", strings.NewReader(`{"code":"123456"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockAuthService := new(services_auth.MockAuthService)
+			if tt.serviceErr != nil {
+				mockAuthService.On("EnableTwoFactor", "valid-user-id", "123456").Return(nil, tt.serviceErr)
+			} else {
+				mockAuthService.On("EnableTwoFactor", "valid-user-id", "123456").Return(tt.codes, nil)
+			}
+
+			// モッククッキーを設定
+			SetMockPrincipal(c)
+
+			handler := NewAuthHandler(new(services_users.MockUserService), mockAuthService)
+			err := handler.EnableTwoFactor(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			mockAuthService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_DisableTwoFactor(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "無効にする", expectedStatus: http.StatusNoContent},
+		{name: "パスワードが誤り", serviceErr: errors.New("invalid current password"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Invalid current password"}`},
+		{name: "コードが誤り", serviceErr: errors.New("invalid code"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Invalid code"}`},
+		{name: "有効にしていない", serviceErr: errors.New("two factor not enabled"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Two-factor authentication is not enabled"}`},
+		{name: "サービスのエラー", serviceErr: errors.New("failed to disable two factor"), expectedStatus: http.StatusInternalServerError, expectedBody: `{"error":"Failed to disable two-factor authentication"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/2fa/disable", strings.NewReader(`{"password":"password123","code":"123456"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockAuthService := new(services_auth.MockAuthService)
+			mockAuthService.On("DisableTwoFactor", "valid-user-id", "password123", "123456").Return(tt.serviceErr)
+
+			// モッククッキーを設定
+			SetMockPrincipal(c)
+
+			handler := NewAuthHandler(new(services_users.MockUserService), mockAuthService)
+			err := handler.DisableTwoFactor(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			} else {
+				assert.Empty(t, rec.Body.String())
+			}
+			mockAuthService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_FetchTwoFactorStatus(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/users/2fa", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthService := new(services_auth.MockAuthService)
+	mockAuthService.On("FetchTwoFactorStatus", "valid-user-id").Return(&models.TwoFactorStatus{Enabled: true, RecoveryCodesRemaining: 8}, nil)
+
+	// モッククッキーを設定
+	SetMockPrincipal(c)
+
+	handler := NewAuthHandler(new(services_users.MockUserService), mockAuthService)
+	err := handler.FetchTwoFactorStatus(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"enabled":true,"recovery_codes_remaining":8}`, rec.Body.String())
+	mockAuthService.AssertExpectations(t)
+}
+
+func TestHandler_TwoFactor_Unauthorized(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/users/2fa/setup", strings.NewReader(`{"password":"password123"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockAuthService := new(services_auth.MockAuthService)
+	handler := NewAuthHandler(new(services_users.MockUserService), mockAuthService)
+
+	// トークンがない場合は認証ミドルウェアで拒否される
+	err := middlewares.RequireAuth()(handler.SetupTwoFactor)(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockAuthService.AssertNotCalled(t, "SetupTwoFactor", "valid-user-id", "password123")
+}
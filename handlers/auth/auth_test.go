@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
@@ -52,11 +53,23 @@ func TestHandler_Login_Success(t *testing.T) {
 	mockUserService := new(services_users.MockUserService)
 
 	mockAuthService.On("Login", "test@example.com", "password123").Return(nil)
+	attempt := &services_auth.LoginAttempt{}
+	mockAuthService.On("ReserveLoginAttempt", "192.0.2.1", "test@example.com").Return(attempt, time.Duration(0), nil)
+	mockAuthService.On("ReleaseLoginAttempt", attempt).Return(nil)
+	mockAuthService.On("ResetLoginFailures", "test@example.com").Return(nil)
 
-	mockUserService.On("FetchUserByEmailAndPassword", "test@example.com", "password123").Return(&models.UserData{
+	user := &models.UserData{
 		ID:    "user123",
 		Email: "test@example.com",
 		Name:  "Test User",
+	}
+	mockUserService.On("FetchUserByEmailAndPassword", "test@example.com", "password123").Return(user, nil)
+	mockAuthService.On("CreateTwoFactorChallenge", "user123").Return(nil, nil)
+	mockAuthService.On("IssueTokens", user).Return(&models.AuthTokens{
+		AccessToken:           "access-token",
+		AccessTokenExpiresAt:  time.Now().Add(15 * time.Minute),
+		RefreshToken:          "refresh-token",
+		RefreshTokenExpiresAt: time.Now().Add(30 * 24 * time.Hour),
 	}, nil)
 
 	// AuthHandler の作成
@@ -85,9 +98,29 @@ func TestHandler_Login_Success(t *testing.T) {
 		}
 	}
 	assert.NotNil(t, tokenCookie)
-	assert.NotEmpty(t, tokenCookie.Value)
+	assert.Equal(t, "access-token", tokenCookie.Value)
+
+	// リフレッシュトークンはトークンの更新・ログアウトのパスにのみ送られる
+	refreshCookie := findCookie(rec, "refresh_token")
+	assert.NotNil(t, refreshCookie)
+	assert.Equal(t, "refresh-token", refreshCookie.Value)
+	assert.Equal(t, "/api/users", refreshCookie.Path)
+	assert.True(t, refreshCookie.HttpOnly)
+
+	// トークンはレスポンスボディに含めない
+	assert.NotContains(t, rec.Body.String(), "refresh-token")
 
 	// 期待値のアサーション
 	mockAuthService.AssertExpectations(t)
 	mockUserService.AssertExpectations(t)
 }
+
+// レスポンスから指定された名前のクッキーを取得する
+func findCookie(rec *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
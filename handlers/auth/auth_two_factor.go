@@ -0,0 +1,274 @@
+package handlers_auth
+
+import (
+	utils_auth "backend/utils/auth"
+	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
+
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 2要素認証によるログインの完了エンドポイント
+// パスワードの確認で発行したチャレンジトークンと、認証アプリのコード(またはリカバリーコード)でログインする。
+func (h *AuthHandler) LoginTwoFactor(c echo.Context) error {
+	utils.LogInfo(c, "Completing two-factor login...")
+
+	// JSONのリクエストボディからchallengeToken, codeを取得
+	type RequestBody struct {
+		ChallengeToken string `json:"challengeToken"`
+		Code           string `json:"code"`
+	}
+
+	// リクエストボディをバインド
+	var reqBody RequestBody
+	if err := c.Bind(&reqBody); err != nil {
+		utils.LogError(c, "Failed to bind request body: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層でコードを確認
+	user, retryAfter, err := h.AuthService.CompleteTwoFactorLogin(c.Request().Context(), c.RealIP(), reqBody.ChallengeToken, reqBody.Code)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		utils.LogError(c, "Error completing two-factor login: "+err.Error())
+		switch err.Error() {
+		case "challenge token and code are required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Challenge token and code are required",
+			})
+		case "invalid challenge token":
+			return c.JSON(http.StatusUnauthorized, map[string]string{
+				"error": "Invalid or expired challenge",
+			})
+		case "invalid code":
+			return c.JSON(http.StatusUnauthorized, map[string]string{
+				"error": "Invalid code",
+			})
+		case "too many login attempts":
+			return tooManyLoginAttemptsResponse(c, retryAfter)
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to complete login",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Two-factor authentication successful: "+user.Email)
+	return h.issueLoginTokens(c, user)
+}
+
+// 2要素認証の状態の取得エンドポイント
+func (h *AuthHandler) FetchTwoFactorStatus(c echo.Context) error {
+	utils.LogInfo(c, "Fetching two-factor status...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	status, err := h.AuthService.FetchTwoFactorStatus(c.Request().Context(), userId)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		utils.LogError(c, "Error fetching two-factor status: "+err.Error())
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch two-factor status",
+		})
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
+// 2要素認証の登録開始エンドポイント
+// 現在のパスワードを確認し、認証アプリに登録するシークレットと otpauth URI を返す。
+func (h *AuthHandler) SetupTwoFactor(c echo.Context) error {
+	utils.LogInfo(c, "Setting up two-factor authentication...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// JSONのリクエストボディからpasswordを取得
+	type RequestBody struct {
+		Password string `json:"password"`
+	}
+
+	// リクエストボディをバインド
+	var reqBody RequestBody
+	if err := c.Bind(&reqBody); err != nil {
+		utils.LogError(c, "Failed to bind request body: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	setup, err := h.AuthService.SetupTwoFactor(c.Request().Context(), userId, reqBody.Password)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		utils.LogError(c, "Error setting up two-factor authentication: "+err.Error())
+		switch err.Error() {
+		case "password is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Password is required",
+			})
+		case "invalid current password":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid current password",
+			})
+		case "user not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "User not found",
+			})
+		case "two factor already enabled":
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "Two-factor authentication is already enabled",
+			})
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to set up two-factor authentication",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Two-factor setup started")
+	return c.JSON(http.StatusOK, setup)
+}
+
+// 2要素認証の有効化エンドポイント
+// 認証アプリの最初のコードで登録を確認し、リカバリーコードを返す(リカバリーコードはこの時にのみ返す)。
+func (h *AuthHandler) EnableTwoFactor(c echo.Context) error {
+	utils.LogInfo(c, "Enabling two-factor authentication...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// JSONのリクエストボディからcodeを取得
+	type RequestBody struct {
+		Code string `json:"code"`
+	}
+
+	// リクエストボディをバインド
+	var reqBody RequestBody
+	if err := c.Bind(&reqBody); err != nil {
+		utils.LogError(c, "Failed to bind request body: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	codes, err := h.AuthService.EnableTwoFactor(c.Request().Context(), userId, reqBody.Code)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		utils.LogError(c, "Error enabling two-factor authentication: "+err.Error())
+		switch err.Error() {
+		case "code is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Code is required",
+			})
+		case "invalid code":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid code",
+			})
+		case "two factor not set up":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Two-factor authentication is not set up",
+			})
+		case "two factor already enabled":
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "Two-factor authentication is already enabled",
+			})
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to enable two-factor authentication",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Two-factor authentication enabled")
+	return c.JSON(http.StatusOK, map[string][]string{"recovery_codes": codes})
+}
+
+// 2要素認証の無効化エンドポイント
+// 現在のパスワードと、認証アプリのコード(またはリカバリーコード)を確認する。
+func (h *AuthHandler) DisableTwoFactor(c echo.Context) error {
+	utils.LogInfo(c, "Disabling two-factor authentication...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// JSONのリクエストボディからpassword, codeを取得
+	type RequestBody struct {
+		Password string `json:"password"`
+		Code     string `json:"code"`
+	}
+
+	// リクエストボディをバインド
+	var reqBody RequestBody
+	if err := c.Bind(&reqBody); err != nil {
+		utils.LogError(c, "Failed to bind request body: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	err := h.AuthService.DisableTwoFactor(c.Request().Context(), userId, reqBody.Password, reqBody.Code)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		utils.LogError(c, "Error disabling two-factor authentication: "+err.Error())
+		switch err.Error() {
+		case "password is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Password is required",
+			})
+		case "code is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Code is required",
+			})
+		case "invalid current password":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid current password",
+			})
+		case "invalid code":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid code",
+			})
+		case "two factor not enabled":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Two-factor authentication is not enabled",
+			})
+		case "user not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "User not found",
+			})
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to disable two-factor authentication",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Two-factor authentication disabled")
+	return c.NoContent(http.StatusNoContent)
+}
@@ -1,7 +1,11 @@
 package handlers_blogs
 
 import (
+	"backend/models"
+	utils_auth "backend/utils/auth"
+	utils_etag "backend/utils/etag"
 	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
 	"net/http"
 	"strconv"
 	"strings"
@@ -9,24 +13,101 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-// 全ブログデータを取得する
+// ブログ一覧を取得する
+// クエリパラメータ limit, cursor, category, tag, user_id, from, to, sort, include_total で絞り込み・並び替えを行う。
 func (h *BlogHandler) FetchBlogs(c echo.Context) error {
 	utils.LogInfo(c, "Fetching blogs...")
 
-	// サービス層から全ブログデータを取得
-	blogs, err := h.BlogService.FetchBlogs()
+	// クエリパラメータから取得条件を組み立てる
+	params := models.BlogListParams{
+		Cursor:   c.QueryParam("cursor"),
+		Category: c.QueryParam("category"),
+		Tag:      c.QueryParam("tag"),
+		UserId:   c.QueryParam("user_id"),
+		From:     c.QueryParam("from"),
+		To:       c.QueryParam("to"),
+		Sort:     c.QueryParam("sort"),
+	}
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil {
+			utils.LogError(c, "Invalid limit: "+err.Error())
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid limit",
+			})
+		}
+		params.Limit = limit
+	}
+	if includeTotalStr := c.QueryParam("include_total"); includeTotalStr != "" {
+		includeTotal, err := strconv.ParseBool(includeTotalStr)
+		if err != nil {
+			utils.LogError(c, "Invalid include_total: "+err.Error())
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid include_total",
+			})
+		}
+		params.IncludeTotal = includeTotal
+	}
+
+	// 取得前に最終更新日時を控えておく(取得中に更新された場合に新しい日時を返さないため)
+	lastModified := h.BlogService.LastModified()
+
+	// サービス層からブログ一覧を取得
+	page, err := h.BlogService.FetchBlogs(c.Request().Context(), params)
 	if err != nil {
-		utils.LogError(c, "Error fetching blogs: "+err.Error())
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Error fetching blogs",
-		})
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "invalid limit":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid limit",
+			})
+		case "invalid sort":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid sort",
+			})
+		case "invalid userId":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid user_id",
+			})
+		case "invalid from":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid from",
+			})
+		case "invalid to":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid to",
+			})
+		case "invalid date range":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid date range",
+			})
+		case "invalid cursor":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid cursor",
+			})
+		default:
+			utils.LogError(c, "Error fetching blogs: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error fetching blogs",
+			})
+		}
 	}
 
 	utils.LogInfo(c, "Fetched blogs successfully")
-	return c.JSON(http.StatusOK, blogs)
+	return utils_etag.JSON(c, http.StatusOK, page, lastModified)
+}
+
+// ログイン中であれば閲覧者のユーザーIDを返す
+// 未ログインまたはトークンが不正な場合は空文字を返す(公開済みのブログのみ閲覧できる)。
+func (h *BlogHandler) viewerId(c echo.Context) string {
+	userId, _ := utils_auth.UserId(c)
+	return userId
 }
 
 // ユーザーIDでブログデータを取得する
+// 本人がログインしている場合は下書き・予約中・非公開のブログも含める。
 func (h *BlogHandler) FetchBlogsByUserId(c echo.Context) error {
 	utils.LogInfo(c, "Fetching blogs by userId...")
 
@@ -34,8 +115,11 @@ func (h *BlogHandler) FetchBlogsByUserId(c echo.Context) error {
 	userId := c.Param("userId")
 
 	// サービス層からユーザーIDでブログデータを取得
-	blogs, err := h.BlogService.FetchBlogsByUserId(userId)
+	blogs, err := h.BlogService.FetchBlogsByUserId(c.Request().Context(), userId, h.viewerId(c))
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		switch err.Error() {
 		case "invalid userId":
 			return c.JSON(http.StatusBadRequest, map[string]string{
@@ -58,6 +142,7 @@ func (h *BlogHandler) FetchBlogsByUserId(c echo.Context) error {
 }
 
 // ブログIDでブログデータを取得する
+// 公開済み以外のブログは投稿者本人がログインしている場合のみ取得できる。
 func (h *BlogHandler) FetchBlogById(c echo.Context) error {
 	utils.LogInfo(c, "Fetching blog by id...")
 
@@ -65,8 +150,11 @@ func (h *BlogHandler) FetchBlogById(c echo.Context) error {
 	id := c.Param("id")
 
 	// サービス層からIDでブログデータを取得
-	blog, err := h.BlogService.FetchBlogById(id)
+	blog, err := h.BlogService.FetchBlogById(c.Request().Context(), id, h.viewerId(c))
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		switch err.Error() {
 		case "invalid id":
 			return c.JSON(http.StatusBadRequest, map[string]string{
@@ -92,31 +180,20 @@ func (h *BlogHandler) FetchBlogById(c echo.Context) error {
 func (h *BlogHandler) CreateBlog(c echo.Context) error {
 	utils.LogInfo(c, "Creating blog...")
 
-	// クッキーからJWTトークンを取得
-	cookieValue, err := h.CookieUtils.GetAuthCookieValue(c, "token")
-	if err != nil {
-		utils.LogError(c, "Error getting cookie: "+err.Error())
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Error getting cookie",
-		})
-	}
-
-	// JWTトークンを解析してユーザーIDを取得
-	userId, err := h.CookieUtils.GetUserIdFromToken(c, cookieValue)
-	if err != nil {
-		utils.LogError(c, "Error getting userId from token: "+err.Error())
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Error getting userId from token",
-		})
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
 	}
 
 	// JSONボディのバインド
 	type CreateBlogRequest struct {
-		Title       string `json:"title"`
-		GitHubURL   string `json:"githubUrl"`
-		Category    string `json:"category"`
-		Description string `json:"description"`
-		Tags        string `json:"tags"`
+		Title        string `json:"title"`
+		GitHubURL    string `json:"githubUrl"`
+		Category     string `json:"category"`
+		Description  string `json:"description"`
+		Tags         string `json:"tags"`
+		BodyMarkdown string `json:"bodyMarkdown"`
 	}
 
 	var req CreateBlogRequest
@@ -128,8 +205,11 @@ func (h *BlogHandler) CreateBlog(c echo.Context) error {
 	}
 
 	// サービス層からブログデータを作成
-	blog, err := h.BlogService.CreateBlog(userId, req.Title, req.GitHubURL, req.Category, req.Description, req.Tags)
+	blog, err := h.BlogService.CreateBlog(c.Request().Context(), userId, req.Title, req.GitHubURL, req.Category, req.Description, req.Tags, req.BodyMarkdown)
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		switch err.Error() {
 		case "invalid userId":
 			return c.JSON(http.StatusBadRequest, map[string]string{
@@ -147,6 +227,10 @@ func (h *BlogHandler) CreateBlog(c echo.Context) error {
 			return c.JSON(http.StatusBadRequest, map[string]string{
 				"error": "Invalid category",
 			})
+		case "unknown category":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Unknown category",
+			})
 		case "invalid description":
 			return c.JSON(http.StatusBadRequest, map[string]string{
 				"error": "Invalid description",
@@ -155,6 +239,18 @@ func (h *BlogHandler) CreateBlog(c echo.Context) error {
 			return c.JSON(http.StatusBadRequest, map[string]string{
 				"error": "Invalid tags",
 			})
+		case "invalid bodyMarkdown":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid bodyMarkdown",
+			})
+		case "email not verified":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Email not verified",
+			})
+		case "forbidden":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Forbidden",
+			})
 		case "failed to create blog":
 			return c.JSON(http.StatusInternalServerError, map[string]string{
 				"error": "Failed to create blog",
@@ -175,22 +271,10 @@ func (h *BlogHandler) CreateBlog(c echo.Context) error {
 func (h *BlogHandler) UpdateBlog(c echo.Context) error {
 	utils.LogInfo(c, "Updating blog...")
 
-	// クッキーからJWTトークンを取得
-	cookieValue, err := h.CookieUtils.GetAuthCookieValue(c, "token")
-	if err != nil {
-		utils.LogError(c, "Error getting cookie: "+err.Error())
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Error getting cookie",
-		})
-	}
-
-	// JWTトークンを解析してユーザーIDを取得
-	_, err = h.CookieUtils.GetUserIdFromToken(c, cookieValue)
-	if err != nil {
-		utils.LogError(c, "Error getting userId from token: "+err.Error())
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Error getting userId from token",
-		})
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
 	}
 
 	// パスパラメータからidを取得
@@ -198,11 +282,12 @@ func (h *BlogHandler) UpdateBlog(c echo.Context) error {
 
 	// JSONボディのバインド
 	type UpdateBlogRequest struct {
-		Title       string `json:"title"`
-		GitHubURL   string `json:"githubUrl"`
-		Category    string `json:"category"`
-		Description string `json:"description"`
-		Tags        string `json:"tags"`
+		Title        string `json:"title"`
+		GitHubURL    string `json:"githubUrl"`
+		Category     string `json:"category"`
+		Description  string `json:"description"`
+		Tags         string `json:"tags"`
+		BodyMarkdown string `json:"bodyMarkdown"`
 	}
 
 	var req UpdateBlogRequest
@@ -214,13 +299,20 @@ func (h *BlogHandler) UpdateBlog(c echo.Context) error {
 	}
 
 	// サービス層からブログデータを更新
-	blog, err := h.BlogService.UpdateBlog(id, req.Title, req.GitHubURL, req.Category, req.Description, req.Tags)
+	blog, err := h.BlogService.UpdateBlog(c.Request().Context(), id, userId, req.Title, req.GitHubURL, req.Category, req.Description, req.Tags, req.BodyMarkdown)
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		switch err.Error() {
 		case "invalid id":
 			return c.JSON(http.StatusBadRequest, map[string]string{
 				"error": "Invalid id",
 			})
+		case "invalid userId":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid userId",
+			})
 		case "invalid title":
 			return c.JSON(http.StatusBadRequest, map[string]string{
 				"error": "Invalid title",
@@ -233,6 +325,10 @@ func (h *BlogHandler) UpdateBlog(c echo.Context) error {
 			return c.JSON(http.StatusBadRequest, map[string]string{
 				"error": "Invalid category",
 			})
+		case "unknown category":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Unknown category",
+			})
 		case "invalid description":
 			return c.JSON(http.StatusBadRequest, map[string]string{
 				"error": "Invalid description",
@@ -241,6 +337,18 @@ func (h *BlogHandler) UpdateBlog(c echo.Context) error {
 			return c.JSON(http.StatusBadRequest, map[string]string{
 				"error": "Invalid tags",
 			})
+		case "invalid bodyMarkdown":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid bodyMarkdown",
+			})
+		case "blog not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Blog not found",
+			})
+		case "forbidden":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Forbidden",
+			})
 		case "failed to update blog":
 			return c.JSON(http.StatusInternalServerError, map[string]string{
 				"error": "Failed to update blog",
@@ -261,35 +369,38 @@ func (h *BlogHandler) UpdateBlog(c echo.Context) error {
 func (h *BlogHandler) DeleteBlog(c echo.Context) error {
 	utils.LogInfo(c, "Deleting blog...")
 
-	// クッキーからJWTトークンを取得
-	cookieValue, err := h.CookieUtils.GetAuthCookieValue(c, "token")
-	if err != nil {
-		utils.LogError(c, "Error getting cookie: "+err.Error())
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Error getting cookie",
-		})
-	}
-
-	// JWTトークンを解析してユーザーIDを取得
-	_, err = h.CookieUtils.GetUserIdFromToken(c, cookieValue)
-	if err != nil {
-		utils.LogError(c, "Error getting userId from token: "+err.Error())
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Error getting userId from token",
-		})
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
 	}
 
 	// パスパラメータからidを取得
 	id := c.Param("id")
 
 	// サービス層からブログデータを削除
-	err = h.BlogService.DeleteBlog(id)
+	err := h.BlogService.DeleteBlog(c.Request().Context(), id, userId)
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		switch err.Error() {
 		case "invalid id":
 			return c.JSON(http.StatusBadRequest, map[string]string{
 				"error": "Invalid id",
 			})
+		case "invalid userId":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid userId",
+			})
+		case "blog not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Blog not found",
+			})
+		case "forbidden":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Forbidden",
+			})
 		case "failed to delete blog":
 			return c.JSON(http.StatusInternalServerError, map[string]string{
 				"error": "Failed to delete blog",
@@ -310,9 +421,15 @@ func (h *BlogHandler) DeleteBlog(c echo.Context) error {
 func (h *BlogHandler) FetchBlogCategories(c echo.Context) error {
 	utils.LogInfo(c, "Fetching categories...")
 
+	// 取得前に最終更新日時を控えておく
+	lastModified := h.BlogService.LastModified()
+
 	// サービス層からカテゴリーを取得
-	categories, err := h.BlogService.FetchBlogCategories()
+	categories, err := h.BlogService.FetchBlogCategories(c.Request().Context())
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		utils.LogError(c, "Error fetching categories: "+err.Error())
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Error fetching categories",
@@ -320,16 +437,22 @@ func (h *BlogHandler) FetchBlogCategories(c echo.Context) error {
 	}
 
 	utils.LogInfo(c, "Fetched categories successfully")
-	return c.JSON(http.StatusOK, categories)
+	return utils_etag.JSON(c, http.StatusOK, categories, lastModified)
 }
 
 // ブログタグの取得
 func (h *BlogHandler) FetchBlogTags(c echo.Context) error {
 	utils.LogInfo(c, "Fetching tags...")
 
+	// 取得前に最終更新日時を控えておく
+	lastModified := h.BlogService.LastModified()
+
 	// サービス層からタグを取得
-	tags, err := h.BlogService.FetchBlogTags()
+	tags, err := h.BlogService.FetchBlogTags(c.Request().Context())
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		utils.LogError(c, "Error fetching tags: "+err.Error())
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Error fetching tags",
@@ -337,7 +460,7 @@ func (h *BlogHandler) FetchBlogTags(c echo.Context) error {
 	}
 
 	utils.LogInfo(c, "Fetched tags successfully")
-	return c.JSON(http.StatusOK, tags)
+	return utils_etag.JSON(c, http.StatusOK, tags, lastModified)
 }
 
 // 人気のあるブログの取得
@@ -354,11 +477,24 @@ func (h *BlogHandler) FetchBlogPopular(c echo.Context) error {
 		})
 	}
 
+	// 取得前に最終更新日時を控えておく
+	lastModified := h.BlogService.LastModified()
+
 	// サービス層から人気のあるブログを取得
-	blogs, err := h.BlogService.FetchBlogPopular(countInt)
+	blogs, err := h.BlogService.FetchBlogPopular(c.Request().Context(), countInt)
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		utils.LogError(c, "Error fetching popular blogs: "+err.Error())
 
+		// 件数が範囲外の場合は `400 Bad Request` を返す
+		if err.Error() == "invalid count" {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid count",
+			})
+		}
+
 		// ✅ `"blog not found"` の場合は `404 Not Found` を返す
 		if strings.Contains(err.Error(), "blog not found") {
 			return c.JSON(http.StatusNotFound, map[string]string{
@@ -373,5 +509,137 @@ func (h *BlogHandler) FetchBlogPopular(c echo.Context) error {
 	}
 
 	utils.LogInfo(c, "Fetched popular blogs successfully")
+	return utils_etag.JSON(c, http.StatusOK, blogs, lastModified)
+}
+
+// ブログを検索する
+// クエリパラメータ q に検索語(空白区切り)、limit に取得件数を指定する。
+func (h *BlogHandler) SearchBlogs(c echo.Context) error {
+	utils.LogInfo(c, "Searching blogs...")
+
+	// クエリパラメータから検索条件を取得
+	q := c.QueryParam("q")
+	limit := 0
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			utils.LogError(c, "Invalid limit: "+err.Error())
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid limit",
+			})
+		}
+	}
+
+	// サービス層からブログを検索
+	page, err := h.BlogService.SearchBlogs(c.Request().Context(), q, limit)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "invalid query":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid query",
+			})
+		case "invalid limit":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid limit",
+			})
+		default:
+			utils.LogError(c, "Error searching blogs: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error searching blogs",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Searched blogs successfully")
+	return c.JSON(http.StatusOK, page)
+}
+
+// ゴミ箱内のブログ一覧を取得する
+// author には自分のブログのみ、editor・admin にはすべてのユーザーのブログを返す。
+func (h *BlogHandler) FetchTrash(c echo.Context) error {
+	utils.LogInfo(c, "Fetching trash...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// サービス層からゴミ箱内のブログデータを取得
+	blogs, err := h.BlogService.FetchDeletedBlogs(c.Request().Context(), userId)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "invalid userId":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid userId",
+			})
+		case "forbidden":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Forbidden",
+			})
+		default:
+			utils.LogError(c, "Error fetching trash: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error fetching trash",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Fetched trash successfully")
 	return c.JSON(http.StatusOK, blogs)
 }
+
+// ゴミ箱内のブログを復元する
+func (h *BlogHandler) RestoreBlog(c echo.Context) error {
+	utils.LogInfo(c, "Restoring blog...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// パスパラメータからidを取得
+	id := c.Param("id")
+
+	// サービス層からブログデータを復元
+	blog, err := h.BlogService.RestoreBlog(c.Request().Context(), id, userId)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "invalid id":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid id",
+			})
+		case "invalid userId":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid userId",
+			})
+		case "blog not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Blog not found",
+			})
+		case "forbidden":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Forbidden",
+			})
+		default:
+			utils.LogError(c, "Error restoring blog: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error restoring blog",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Restored blog successfully")
+	return c.JSON(http.StatusOK, blog)
+}
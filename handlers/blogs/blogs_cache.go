@@ -0,0 +1,43 @@
+package handlers_blogs
+
+import (
+	utils_auth "backend/utils/auth"
+	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// キャッシュのヒット・ミスなどの統計を取得する
+// admin・editor のみ取得できる。
+func (h *BlogHandler) FetchCacheStats(c echo.Context) error {
+	utils.LogInfo(c, "Fetching cache stats...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	stats, err := h.BlogService.FetchCacheStats(c.Request().Context(), userId)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "forbidden":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Forbidden",
+			})
+		default:
+			utils.LogError(c, "Error fetching cache stats: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error fetching cache stats",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Fetched cache stats successfully")
+	return c.JSON(http.StatusOK, stats)
+}
@@ -1,19 +1,14 @@
 package handlers_blogs
 
-import (
-	services_blogs "backend/services/blogs"
-	utils_cookie "backend/utils/cookie"
-)
+import services_blogs "backend/services/blogs"
 
 type BlogHandler struct {
 	BlogService services_blogs.BlogService
-	CookieUtils utils_cookie.CookieUtils
 }
 
 // コンストラクタ
-func NewBlogHandler(blogService services_blogs.BlogService, cookieUtils utils_cookie.CookieUtils) *BlogHandler {
+func NewBlogHandler(blogService services_blogs.BlogService) *BlogHandler {
 	return &BlogHandler{
 		BlogService: blogService,
-		CookieUtils: cookieUtils,
 	}
 }
@@ -0,0 +1,168 @@
+package handlers_blogs
+
+import (
+	utils_auth "backend/utils/auth"
+	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 版履歴関連のサービスエラーをレスポンスに変換する
+func revisionErrorResponse(c echo.Context, err error, message string) error {
+	if utils_timeout.IsTimeout(err) {
+		return utils_timeout.TimeoutResponse(c, err)
+	}
+	switch err.Error() {
+	case "invalid id":
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid id",
+		})
+	case "invalid revision":
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid revision",
+		})
+	case "invalid userId":
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid userId",
+		})
+	case "unknown category":
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Unknown category",
+		})
+	case "blog not found":
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Blog not found",
+		})
+	case "revision not found":
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Revision not found",
+		})
+	case "forbidden":
+		return c.JSON(http.StatusForbidden, map[string]string{
+			"error": "Forbidden",
+		})
+	default:
+		utils.LogError(c, message+": "+err.Error())
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": message,
+		})
+	}
+}
+
+// ブログの版履歴を新しい順に取得する
+func (h *BlogHandler) FetchBlogRevisions(c echo.Context) error {
+	utils.LogInfo(c, "Fetching blog revisions...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// パスパラメータからidを取得
+	id := c.Param("id")
+
+	// サービス層から版履歴を取得
+	revisions, err := h.BlogService.FetchBlogRevisions(c.Request().Context(), id, userId)
+	if err != nil {
+		return revisionErrorResponse(c, err, "Error fetching revisions")
+	}
+
+	utils.LogInfo(c, "Fetched blog revisions successfully")
+	return c.JSON(http.StatusOK, revisions)
+}
+
+// ブログの指定された版を取得する
+func (h *BlogHandler) FetchBlogRevision(c echo.Context) error {
+	utils.LogInfo(c, "Fetching blog revision...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// パスパラメータからidと版番号を取得
+	id := c.Param("id")
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		utils.LogError(c, "Error converting revision to int: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid revision",
+		})
+	}
+
+	// サービス層から版を取得
+	result, err := h.BlogService.FetchBlogRevision(c.Request().Context(), id, revision, userId)
+	if err != nil {
+		return revisionErrorResponse(c, err, "Error fetching revision")
+	}
+
+	utils.LogInfo(c, "Fetched blog revision successfully")
+	return c.JSON(http.StatusOK, result)
+}
+
+// ブログの2つの版のフィールド単位の差分を取得する
+// クエリパラメータ from, to で比較する版番号を指定する。
+func (h *BlogHandler) DiffBlogRevisions(c echo.Context) error {
+	utils.LogInfo(c, "Diffing blog revisions...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// パスパラメータからid、クエリパラメータから比較する版番号を取得
+	id := c.Param("id")
+	from, fromErr := strconv.Atoi(c.QueryParam("from"))
+	to, toErr := strconv.Atoi(c.QueryParam("to"))
+	if fromErr != nil || toErr != nil {
+		utils.LogError(c, "Error converting revisions to int")
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid revision",
+		})
+	}
+
+	// サービス層から差分を取得
+	diff, err := h.BlogService.DiffBlogRevisions(c.Request().Context(), id, from, to, userId)
+	if err != nil {
+		return revisionErrorResponse(c, err, "Error diffing revisions")
+	}
+
+	utils.LogInfo(c, "Diffed blog revisions successfully")
+	return c.JSON(http.StatusOK, diff)
+}
+
+// ブログを指定された版の内容に戻す
+func (h *BlogHandler) RevertBlog(c echo.Context) error {
+	utils.LogInfo(c, "Reverting blog...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// パスパラメータからidと版番号を取得
+	id := c.Param("id")
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		utils.LogError(c, "Error converting revision to int: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid revision",
+		})
+	}
+
+	// サービス層からブログを指定された版に戻す
+	blog, err := h.BlogService.RevertBlog(c.Request().Context(), id, revision, userId)
+	if err != nil {
+		return revisionErrorResponse(c, err, "Error reverting blog")
+	}
+
+	utils.LogInfo(c, "Reverted blog successfully")
+	return c.JSON(http.StatusOK, blog)
+}
@@ -0,0 +1,124 @@
+package handlers_blogs
+
+import (
+	utils_auth "backend/utils/auth"
+	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// スラッグでブログデータを取得する
+// 変更前のスラッグが指定された場合は、現在のスラッグのURLへ 301 Moved Permanently でリダイレクトする。
+func (h *BlogHandler) FetchBlogBySlug(c echo.Context) error {
+	utils.LogInfo(c, "Fetching blog by slug...")
+
+	// パスパラメータからslugを取得
+	slug := c.Param("slug")
+
+	// サービス層からスラッグでブログデータを取得
+	blog, err := h.BlogService.FetchBlogBySlug(c.Request().Context(), slug, h.viewerId(c))
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "invalid slug":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid slug",
+			})
+		case "blog not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Blog not found",
+			})
+		default:
+			utils.LogError(c, "Error fetching blog: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error fetching blog",
+			})
+		}
+	}
+
+	// 変更前のスラッグの場合は現在のスラッグへリダイレクト
+	if blog.Slug != slug {
+		path := c.Request().URL.Path
+		location := path[:strings.LastIndex(path, "/")+1] + blog.Slug
+		utils.LogInfo(c, "Redirecting to current slug: "+location)
+		return c.Redirect(http.StatusMovedPermanently, location)
+	}
+
+	utils.LogInfo(c, "Fetched blog successfully")
+	return c.JSON(http.StatusOK, blog)
+}
+
+// ブログのスラッグを変更する
+// 変更前のスラッグは古いリンクからのリダイレクト用に保持される。
+func (h *BlogHandler) UpdateBlogSlug(c echo.Context) error {
+	utils.LogInfo(c, "Updating blog slug...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// パスパラメータからidを取得
+	id := c.Param("id")
+
+	// JSONボディのバインド
+	type UpdateBlogSlugRequest struct {
+		Slug string `json:"slug"`
+	}
+
+	var req UpdateBlogSlugRequest
+	if err := c.Bind(&req); err != nil {
+		utils.LogError(c, "Error binding request: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層でスラッグを変更
+	blog, err := h.BlogService.UpdateBlogSlug(c.Request().Context(), id, userId, req.Slug)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "invalid id":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid id",
+			})
+		case "invalid userId":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid userId",
+			})
+		case "invalid slug":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid slug",
+			})
+		case "blog not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Blog not found",
+			})
+		case "forbidden":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Forbidden",
+			})
+		case "slug conflict":
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "Slug already exists",
+			})
+		default:
+			utils.LogError(c, "Error updating blog slug: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error updating blog slug",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Updated blog slug successfully")
+	return c.JSON(http.StatusOK, blog)
+}
@@ -0,0 +1,87 @@
+package handlers_blogs
+
+import (
+	utils_auth "backend/utils/auth"
+	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ブログの公開状態を変更する
+// status に draft, scheduled, published, unpublished のいずれかを、
+// publishedAt に公開(予定)日時を RFC3339 形式で指定する(予約時は必須)。
+func (h *BlogHandler) UpdateBlogStatus(c echo.Context) error {
+	utils.LogInfo(c, "Updating blog status...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// パスパラメータからidを取得
+	id := c.Param("id")
+
+	// JSONボディのバインド
+	type UpdateBlogStatusRequest struct {
+		Status      string     `json:"status"`
+		PublishedAt *time.Time `json:"publishedAt"`
+	}
+
+	var req UpdateBlogStatusRequest
+	if err := c.Bind(&req); err != nil {
+		utils.LogError(c, "Error binding request: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層でブログの公開状態を変更
+	blog, err := h.BlogService.UpdateBlogStatus(c.Request().Context(), id, userId, req.Status, req.PublishedAt)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "invalid id":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid id",
+			})
+		case "invalid userId":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid userId",
+			})
+		case "invalid status":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid status",
+			})
+		case "invalid publishedAt":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid publishedAt",
+			})
+		case "blog not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Blog not found",
+			})
+		case "forbidden":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Forbidden",
+			})
+		case "invalid status transition":
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "Invalid status transition",
+			})
+		default:
+			utils.LogError(c, "Error updating blog status: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error updating blog status",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Updated blog status successfully")
+	return c.JSON(http.StatusOK, blog)
+}
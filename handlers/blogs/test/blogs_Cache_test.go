@@ -0,0 +1,112 @@
+package handlers_blogs_test
+
+import (
+	handlers_blogs "backend/handlers/blogs"
+	"backend/middlewares"
+	"backend/models"
+	service_blogs "backend/services/blogs"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_FetchTags_NotModified(t *testing.T) {
+	e := echo.New()
+
+	// モックサービスをインスタンス化
+	mockService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockService)
+
+	lastModified := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockService.On("LastModified").Return(lastModified)
+	mockService.On("FetchBlogTags").Return([]models.TagCount{{Name: "Go", Count: 1}}, nil)
+
+	// 1回目は内容とETag・Last-Modifiedを返す
+	rec := httptest.NewRecorder()
+	err := handler.FetchBlogTags(e.NewContext(httptest.NewRequest(http.MethodGet, "/api/blogs/tags", nil), rec))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "Thu, 02 Jan 2025 03:04:05 GMT", rec.Header().Get("Last-Modified"))
+	etag := rec.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	// ETagが一致する場合は 304 を返す
+	req := httptest.NewRequest(http.MethodGet, "/api/blogs/tags", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	err = handler.FetchBlogTags(e.NewContext(req, rec))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	mockService.AssertExpectations(t)
+}
+
+func TestHandler_FetchCacheStats(t *testing.T) {
+	tests := []struct {
+		name           string
+		loggedIn       bool
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "管理権限がある場合は統計を返す",
+			loggedIn:       true,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"tags":{"hits":3,"misses":1,"invalidations":0,"evictions":0,"entries":1,"hit_rate":0.75}}`,
+		},
+		{
+			name:           "管理権限がない場合は 403",
+			loggedIn:       true,
+			serviceErr:     errors.New("forbidden"),
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"Forbidden"}`,
+		},
+		{
+			name:           "未ログインの場合は 401",
+			loggedIn:       false,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"Unauthorized"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/blogs/cache-stats", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// モックサービスをインスタンス化
+			mockService := new(service_blogs.MockBlogService)
+			handler := handlers_blogs.NewBlogHandler(mockService)
+
+			if tt.loggedIn {
+				handlers_blogs.SetMockPrincipal(c)
+				if tt.serviceErr != nil {
+					mockService.On("FetchCacheStats", "valid-user-id").Return(nil, tt.serviceErr)
+				} else {
+					mockService.On("FetchCacheStats", "valid-user-id").Return(map[string]models.CacheStats{
+						"tags": {Hits: 3, Misses: 1, Entries: 1, HitRate: 0.75},
+					}, nil)
+				}
+			}
+
+			// 認証ミドルウェアを通してハンドラーを実行
+			err := middlewares.RequireAuth()(handler.FetchCacheStats)(c)
+
+			// ステータスコードとレスポンス内容の確認
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			mockService.AssertExpectations(t)
+		})
+	}
+}
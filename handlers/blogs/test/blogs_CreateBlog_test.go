@@ -4,9 +4,9 @@ import (
 	handlers_blogs "backend/handlers/blogs"
 	"backend/models"
 	service_blogs "backend/services/blogs"
-	utils_cookie "backend/utils/cookie"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -43,9 +43,8 @@ func TestHandler_CreateBlog(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// サービスとハンドラーをモックする
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockBlogService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockBlogService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
 
 	// モックデータの生成
 	mockBlogData := models.BlogData{
@@ -57,10 +56,10 @@ func TestHandler_CreateBlog(t *testing.T) {
 	}
 
 	// モックの振る舞いを設定
-	mockBlogService.On("CreateBlog", validUserId, "Test Title", "https://github.com", "Tech", "This is a test blog", "Go").Return(&mockBlogData, nil)
+	mockBlogService.On("CreateBlog", validUserId, "Test Title", "https://github.com", "Tech", "This is a test blog", "Go", "").Return(&mockBlogData, nil)
 
 	// モッククッキーを設定
-	handlers_blogs.SetMockBlogCookies(c, req, mockCookieUtils)
+	handlers_blogs.SetMockPrincipal(c)
 
 	// テストを実行
 	err = handler.CreateBlog(c)
@@ -69,6 +68,114 @@ func TestHandler_CreateBlog(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "Test Title")
 
 	// モックの呼び出しを確認
-	mockCookieUtils.AssertExpectations(t)
 	mockBlogService.AssertExpectations(t)
 }
+
+func TestHandler_CreateBlog_UnknownCategory(t *testing.T) {
+	e := echo.New()
+
+	// 未登録のカテゴリを指定したリクエストを作成
+	body := `{"title":"Test Title","githubUrl":"https://github.com","category":"Unknown","description":"This is a test blog","tags":"Go"}`
+	req := httptest.NewRequest(http.MethodPost, "/blogs/create", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// サービスとハンドラーをモックする
+	mockBlogService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+	// モックの振る舞いを設定
+	mockBlogService.On("CreateBlog", "valid-user-id", "Test Title", "https://github.com", "Unknown", "This is a test blog", "Go", "").Return(nil, errors.New("unknown category"))
+
+	// モッククッキーを設定
+	handlers_blogs.SetMockPrincipal(c)
+
+	// テストを実行
+	err := handler.CreateBlog(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Unknown category")
+
+	// モックの呼び出しを確認
+	mockBlogService.AssertExpectations(t)
+}
+
+func TestHandler_CreateBlog_EmailNotVerified(t *testing.T) {
+	e := echo.New()
+
+	// メールアドレスを確認していないユーザーのリクエストを作成
+	body := `{"title":"Test Title","githubUrl":"https://github.com","category":"Tech","description":"This is a test blog","tags":"Go"}`
+	req := httptest.NewRequest(http.MethodPost, "/blogs/create", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// サービスとハンドラーをモックする
+	mockBlogService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+	// モックの振る舞いを設定
+	mockBlogService.On("CreateBlog", "valid-user-id", "Test Title", "https://github.com", "Tech", "This is a test blog", "Go", "").Return(nil, errors.New("email not verified"))
+
+	// モッククッキーを設定
+	handlers_blogs.SetMockPrincipal(c)
+
+	// テストを実行
+	err := handler.CreateBlog(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.JSONEq(t, `{"error":"Email not verified"}`, rec.Body.String())
+
+	// モックの呼び出しを確認
+	mockBlogService.AssertExpectations(t)
+}
+
+func TestHandler_CreateBlog_BodyMarkdown(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "success", wantStatus: http.StatusCreated, wantBody: `"body_markdown":"# Hello\n\n本文"`},
+		{name: "invalid bodyMarkdown", serviceErr: errors.New("invalid bodyMarkdown"), wantStatus: http.StatusBadRequest, wantBody: "Invalid bodyMarkdown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+
+			// 本文を含むリクエストを作成
+			body := `{"title":"Test Title","githubUrl":"https://github.com","category":"Tech","description":"This is a test blog","tags":"Go","bodyMarkdown":"# Hello\n\n本文"}`
+			req := httptest.NewRequest(http.MethodPost, "/blogs/create", bytes.NewReader([]byte(body)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// サービスとハンドラーをモックする
+			mockBlogService := new(service_blogs.MockBlogService)
+			handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+			// モックの振る舞いを設定
+			call := mockBlogService.On("CreateBlog", "valid-user-id", "Test Title", "https://github.com", "Tech", "This is a test blog", "Go", "# Hello\n\n本文")
+			if tt.serviceErr != nil {
+				call.Return(nil, tt.serviceErr)
+			} else {
+				call.Return(&models.BlogData{Title: "Test Title", BodyMarkdown: "# Hello\n\n本文"}, nil)
+			}
+
+			// モッククッキーを設定
+			handlers_blogs.SetMockPrincipal(c)
+
+			// テストを実行
+			err := handler.CreateBlog(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+
+			// モックの呼び出しを確認
+			mockBlogService.AssertExpectations(t)
+		})
+	}
+}
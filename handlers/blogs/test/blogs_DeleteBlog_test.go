@@ -3,7 +3,6 @@ package handlers_blogs_test
 import (
 	handlers_blogs "backend/handlers/blogs"
 	service_blogs "backend/services/blogs"
-	utils_cookie "backend/utils/cookie"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -25,15 +24,14 @@ func TestHandler_DeleteBlog(t *testing.T) {
 	c.SetParamValues("123")
 
 	// サービスとハンドラーをモックする
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockBlogService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockBlogService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
 
 	// モックの振る舞いを設定
-	mockBlogService.On("DeleteBlog", "123").Return(nil, nil)
+	mockBlogService.On("DeleteBlog", "123", "valid-user-id").Return(nil, nil)
 
 	// モッククッキーを設定
-	handlers_blogs.SetMockBlogCookies(c, req, mockCookieUtils)
+	handlers_blogs.SetMockPrincipal(c)
 
 	// テストを実行
 	err := handler.DeleteBlog(c)
@@ -57,15 +55,14 @@ func TestHandler_DeleteBlog_InvalidId(t *testing.T) {
 	c.SetParamValues("")
 
 	// サービスとハンドラーをモックする
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockBlogService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockBlogService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
 
 	// モックの振る舞いを設定
-	mockBlogService.On("DeleteBlog", "").Return(errors.New("invalid id"))
+	mockBlogService.On("DeleteBlog", "", "valid-user-id").Return(errors.New("invalid id"))
 
 	// モッククッキーを設定
-	handlers_blogs.SetMockBlogCookies(c, req, mockCookieUtils)
+	handlers_blogs.SetMockPrincipal(c)
 
 	// テストを実行
 	err := handler.DeleteBlog(c)
@@ -89,15 +86,14 @@ func TestHandler_DeleteBlog_NoDelete(t *testing.T) {
 	c.SetParamValues("123")
 
 	// サービスとハンドラーをモックする
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockBlogService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockBlogService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
 
 	// モックの振る舞いを設定
-	mockBlogService.On("DeleteBlog", "123").Return(errors.New("failed to delete blog"))
+	mockBlogService.On("DeleteBlog", "123", "valid-user-id").Return(errors.New("failed to delete blog"))
 
 	// モッククッキーを設定
-	handlers_blogs.SetMockBlogCookies(c, req, mockCookieUtils)
+	handlers_blogs.SetMockPrincipal(c)
 
 	// テストを実行
 	err := handler.DeleteBlog(c)
@@ -121,15 +117,14 @@ func TestHandler_DeleteBlog_ServerError(t *testing.T) {
 	c.SetParamValues("123")
 
 	// サービスとハンドラーをモックする
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockBlogService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockBlogService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
 
 	// モックの振る舞いを設定
-	mockBlogService.On("DeleteBlog", "123").Return(errors.New("server error"))
+	mockBlogService.On("DeleteBlog", "123", "valid-user-id").Return(errors.New("server error"))
 
 	// モッククッキーを設定
-	handlers_blogs.SetMockBlogCookies(c, req, mockCookieUtils)
+	handlers_blogs.SetMockPrincipal(c)
 
 	// テストを実行
 	err := handler.DeleteBlog(c)
@@ -140,3 +135,34 @@ func TestHandler_DeleteBlog_ServerError(t *testing.T) {
 	// モックの呼び出しを確認
 	mockBlogService.AssertExpectations(t)
 }
+
+func TestHandler_DeleteBlog_Forbidden(t *testing.T) {
+	e := echo.New()
+
+	// リクエストを作成
+	req := httptest.NewRequest(http.MethodDelete, "/blogs/delete/123", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	// パスパラメータを設定
+	c.SetParamNames("id")
+	c.SetParamValues("123")
+
+	// サービスとハンドラーをモックする
+	mockBlogService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+	// モックの振る舞いを設定: 削除の権限がない
+	mockBlogService.On("DeleteBlog", "123", "valid-user-id").Return(errors.New("forbidden"))
+
+	// モッククッキーを設定
+	handlers_blogs.SetMockPrincipal(c)
+
+	// テストを実行
+	err := handler.DeleteBlog(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.JSONEq(t, `{"error":"Forbidden"}`, rec.Body.String())
+
+	// モックの呼び出しを確認
+	mockBlogService.AssertExpectations(t)
+}
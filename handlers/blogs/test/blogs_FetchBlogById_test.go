@@ -4,7 +4,6 @@ import (
 	handlers_blogs "backend/handlers/blogs"
 	"backend/models"
 	service_blogs "backend/services/blogs"
-	utils_cookie "backend/utils/cookie"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -27,22 +26,28 @@ func TestHandler_FetchBlogById(t *testing.T) {
 	c.SetParamValues("1")
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックデータの設定
-	mockBlog := &models.BlogData{
-		ID:        "1",
-		UserId:    "1",
-		Title:     "title1",
-		GithubUrl: "",
-		Category:  "Category1",
-		Tags:      "Tag1",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	mockBlog := &models.BlogDetail{
+		BlogData: models.BlogData{
+			ID:           "1",
+			UserId:       "1",
+			Title:        "title1",
+			GithubUrl:    "",
+			Category:     "Category1",
+			Tags:         "Tag1",
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+			BodyMarkdown: "# Hello",
+		},
+		BodyHTML:    `<h1 id="hello">Hello</h1>`,
+		Toc:         []models.BlogTocEntry{{Level: 1, ID: "hello", Text: "Hello"}},
+		WordCount:   1,
+		ReadingTime: 1,
 	}
-	mockService.On("FetchBlogById", "1").Return(mockBlog, nil)
+	mockService.On("FetchBlogById", "1", "").Return(mockBlog, nil)
 
 	// ハンドラーを実行
 	err := handler.FetchBlogById(c)
@@ -51,6 +56,9 @@ func TestHandler_FetchBlogById(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
 	assert.Contains(t, rec.Body.String(), "title1")
+	assert.Contains(t, rec.Body.String(), `"body_markdown":"# Hello"`)
+	assert.Contains(t, rec.Body.String(), `"toc":[{"level":1,"id":"hello","text":"Hello"}]`)
+	assert.Contains(t, rec.Body.String(), `"reading_time":1`)
 
 	// サービス層のメソッドが呼ばれたことを確認
 	mockService.AssertExpectations(t)
@@ -68,12 +76,11 @@ func TestHandler_FetchBlogById_InvalidId(t *testing.T) {
 	c.SetParamValues("")
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックデータの設定
-	mockService.On("FetchBlogById", "").Return(nil, errors.New("invalid id"))
+	mockService.On("FetchBlogById", "", "").Return(nil, errors.New("invalid id"))
 
 	// ハンドラーを実行
 	err := handler.FetchBlogById(c)
@@ -99,13 +106,12 @@ func TestHandler_FetchBlogById_InvalidNotBlog(t *testing.T) {
 	c.SetParamValues("1")
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックデータの設定
 
-	mockService.On("FetchBlogById", "1").Return(nil, errors.New("blog not found"))
+	mockService.On("FetchBlogById", "1", "").Return(nil, errors.New("blog not found"))
 
 	// ハンドラーを実行
 	err := handler.FetchBlogById(c)
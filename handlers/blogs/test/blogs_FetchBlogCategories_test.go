@@ -2,12 +2,13 @@ package handlers_blogs_test
 
 import (
 	handlers_blogs "backend/handlers/blogs"
+	"backend/models"
 	service_blogs "backend/services/blogs"
-	utils_cookie "backend/utils/cookie"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -21,12 +22,15 @@ func TestHandler_FetchCategories(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックデータの設定
-	mockCategories := []string{"Category1", "Category2", "Category3"}
+	mockCategories := []models.CategoryData{
+		{ID: "1", Name: "Category1", Slug: "category1", Description: "説明", DisplayOrder: 0, PostCount: 2},
+		{ID: "2", Name: "Category2", Slug: "category2", DisplayOrder: 1, PostCount: 0},
+	}
+	mockService.On("LastModified").Return(time.Time{})
 	mockService.On("FetchBlogCategories").Return(mockCategories, nil)
 
 	// ハンドラーを実行
@@ -35,7 +39,8 @@ func TestHandler_FetchCategories(t *testing.T) {
 	// ステータスコードとレスポンス内容の確認
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
-	assert.JSONEq(t, `["Category1","Category2","Category3"]`, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), `"name":"Category1","slug":"category1","description":"説明","display_order":0,"post_count":2`)
+	assert.Contains(t, rec.Body.String(), `"name":"Category2"`)
 
 	// モックの呼び出しを検証
 	mockService.AssertExpectations(t)
@@ -49,12 +54,12 @@ func TestHandler_FetchCategories_NoData(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックデータの設定
-	mockCategories := []string{}
+	mockCategories := []models.CategoryData{}
+	mockService.On("LastModified").Return(time.Time{})
 	mockService.On("FetchBlogCategories").Return(mockCategories, nil)
 
 	// ハンドラーを実行
@@ -77,11 +82,11 @@ func TestHandler_FetchCategories_ErrorCase(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックデータの設定
+	mockService.On("LastModified").Return(time.Time{})
 	mockService.On("FetchBlogCategories").Return(nil, errors.New("some error occurred"))
 
 	// ハンドラーを実行
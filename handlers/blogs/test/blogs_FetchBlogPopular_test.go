@@ -4,7 +4,6 @@ import (
 	handlers_blogs "backend/handlers/blogs"
 	"backend/models"
 	service_blogs "backend/services/blogs"
-	utils_cookie "backend/utils/cookie"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -28,9 +27,8 @@ func TestHandler_FetchBlogPopular(t *testing.T) {
 	c.SetParamValues("2")
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックデータの設定
 	mockBlogs := []models.BlogData{
@@ -55,6 +53,7 @@ func TestHandler_FetchBlogPopular(t *testing.T) {
 			UpdatedAt: time.Now(),
 		},
 	}
+	mockService.On("LastModified").Return(time.Time{})
 	mockService.On("FetchBlogPopular", 2).Return(mockBlogs, nil)
 
 	// ハンドラーを実行
@@ -83,11 +82,11 @@ func TestHandler_FetchBlogPopular_EmptyCount(t *testing.T) {
 	c.SetParamValues("")
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックサービスの設定（ブログが見つからない場合）
+	mockService.On("LastModified").Return(time.Time{})
 	mockService.On("FetchBlogPopular", 0).Return(nil, errors.New("invalid count"))
 
 	// ハンドラーを実行
@@ -102,6 +101,39 @@ func TestHandler_FetchBlogPopular_EmptyCount(t *testing.T) {
 	mockService.AssertNotCalled(t, "FetchBlogPopular", mock.Anything)
 }
 
+// 件数が上限を超える場合の異常系
+func TestHandler_FetchBlogPopular_CountOverLimit(t *testing.T) {
+	e := echo.New()
+
+	// パスパラメータとして上限を超える count を指定する
+	req := httptest.NewRequest(http.MethodGet, "/api/blog/popular/101", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// パスパラメータを設定
+	c.SetParamNames("count")
+	c.SetParamValues("101")
+
+	// モックサービスをインスタンス化
+	mockService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockService)
+
+	// モックサービスの設定（件数が範囲外の場合）
+	mockService.On("LastModified").Return(time.Time{})
+	mockService.On("FetchBlogPopular", 101).Return(nil, errors.New("invalid count"))
+
+	// ハンドラーを実行
+	err := handler.FetchBlogPopular(c)
+
+	// ステータスコードとレスポンスの確認
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Invalid count")
+
+	// モックの呼び出しを検証
+	mockService.AssertExpectations(t)
+}
+
 // ブログが見つからない場合の異常系
 func TestHandler_FetchBlogPopular_BlogNotFound(t *testing.T) {
 	e := echo.New()
@@ -116,11 +148,11 @@ func TestHandler_FetchBlogPopular_BlogNotFound(t *testing.T) {
 	c.SetParamValues("1")
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックサービスの設定（ブログが見つからない場合）
+	mockService.On("LastModified").Return(time.Time{})
 	mockService.On("FetchBlogPopular", 1).Return(nil, errors.New("blog not found"))
 
 	// ハンドラーを実行
@@ -148,11 +180,11 @@ func TestHandler_FetchBlogPopular_ServiceError(t *testing.T) {
 	c.SetParamValues("1")
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックサービスの設定（一般的なエラーが発生した場合）
+	mockService.On("LastModified").Return(time.Time{})
 	mockService.On("FetchBlogPopular", 1).Return(nil, errors.New("Error fetching popular blogs"))
 
 	// ハンドラーを実行
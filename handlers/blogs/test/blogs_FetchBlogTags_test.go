@@ -2,12 +2,13 @@ package handlers_blogs_test
 
 import (
 	handlers_blogs "backend/handlers/blogs"
+	"backend/models"
 	service_blogs "backend/services/blogs"
-	utils_cookie "backend/utils/cookie"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -21,12 +22,16 @@ func TestHandler_FetchTags(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックデータの設定
-	mockTags := []string{"Tag1", "Tag2", "Tag3"}
+	mockTags := []models.TagCount{
+		{Name: "Tag1", Count: 3},
+		{Name: "Tag2", Count: 2},
+		{Name: "Tag3", Count: 1},
+	}
+	mockService.On("LastModified").Return(time.Time{})
 	mockService.On("FetchBlogTags").Return(mockTags, nil)
 
 	// ハンドラーを実行
@@ -35,7 +40,7 @@ func TestHandler_FetchTags(t *testing.T) {
 	// ステータスコードとレスポンス内容の確認
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
-	assert.JSONEq(t, `["Tag1","Tag2","Tag3"]`, rec.Body.String())
+	assert.JSONEq(t, `[{"name":"Tag1","count":3},{"name":"Tag2","count":2},{"name":"Tag3","count":1}]`, rec.Body.String())
 
 	// モックの呼び出しを検証
 	mockService.AssertExpectations(t)
@@ -49,12 +54,12 @@ func TestHandler_FetchTags_NoData(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックデータの設定
-	mockTags := []string{}
+	mockTags := []models.TagCount{}
+	mockService.On("LastModified").Return(time.Time{})
 	mockService.On("FetchBlogTags").Return(mockTags, nil)
 
 	// ハンドラーを実行
@@ -77,11 +82,11 @@ func TestHandler_FetchTags_ErrorCase(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックデータの設定
+	mockService.On("LastModified").Return(time.Time{})
 	mockService.On("FetchBlogTags").Return(nil, errors.New("some error occurred"))
 
 	// ハンドラーを実行
@@ -4,7 +4,6 @@ import (
 	handlers_blogs "backend/handlers/blogs"
 	"backend/models"
 	service_blogs "backend/services/blogs"
-	utils_cookie "backend/utils/cookie"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -26,9 +25,8 @@ func TestHandler_FetchBlogsByUserId(t *testing.T) {
 	c.SetParamValues("1")
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックデータの設定
 	mockBlogs := []models.BlogData{
@@ -53,7 +51,7 @@ func TestHandler_FetchBlogsByUserId(t *testing.T) {
 			UpdatedAt: time.Now(),
 		},
 	}
-	mockService.On("FetchBlogsByUserId", "1").Return(mockBlogs, nil)
+	mockService.On("FetchBlogsByUserId", "1", "").Return(mockBlogs, nil)
 
 	// ハンドラーを実行
 	err := handler.FetchBlogsByUserId(c)
@@ -80,12 +78,11 @@ func TestHandler_FetchBlogsByUserId_EmptyUserId(t *testing.T) {
 	c.SetParamValues("")
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックサービスの設定（ブログが見つからない場合）
-	mockService.On("FetchBlogsByUserId", "").Return(nil, errors.New("invalid userId"))
+	mockService.On("FetchBlogsByUserId", "", "").Return(nil, errors.New("invalid userId"))
 
 	// ハンドラーを実行
 	err := handler.FetchBlogsByUserId(c)
@@ -111,12 +108,11 @@ func TestHandler_FetchBlogsByUserId_BlogNotFound(t *testing.T) {
 	c.SetParamValues("1")
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックサービスの設定（ブログが見つからない場合）
-	mockService.On("FetchBlogsByUserId", "1").Return(nil, errors.New("blog not found"))
+	mockService.On("FetchBlogsByUserId", "1", "").Return(nil, errors.New("blog not found"))
 
 	// ハンドラーを実行
 	err := handler.FetchBlogsByUserId(c)
@@ -142,12 +138,11 @@ func TestHandler_FetchBlogsByUserId_ServiceError(t *testing.T) {
 	c.SetParamValues("1")
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックサービスの設定（一般的なエラーが発生した場合）
-	mockService.On("FetchBlogsByUserId", "1").Return(nil, errors.New("some internal error"))
+	mockService.On("FetchBlogsByUserId", "1", "").Return(nil, errors.New("some internal error"))
 
 	// ハンドラーを実行
 	err := handler.FetchBlogsByUserId(c)
@@ -3,7 +3,7 @@ package handlers_blogs_test
 import (
 	handlers_blogs "backend/handlers/blogs"
 	service_blogs "backend/services/blogs"
-	utils_cookie "backend/utils/cookie"
+	"context"
 	"errors"
 	"time"
 
@@ -14,6 +14,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestHandler_FetchBlogs(t *testing.T) {
@@ -24,9 +25,8 @@ func TestHandler_FetchBlogs(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// モックデータの設定
 	mockBlog := []models.BlogData{
@@ -51,7 +51,8 @@ func TestHandler_FetchBlogs(t *testing.T) {
 			UpdatedAt: time.Now(),
 		},
 	}
-	mockService.On("FetchBlogs").Return(mockBlog, nil)
+	mockService.On("LastModified").Return(time.Time{})
+	mockService.On("FetchBlogs", models.BlogListParams{}).Return(&models.BlogPage{Items: mockBlog}, nil)
 
 	// ハンドラーを実行
 	err := handler.FetchBlogs(c)
@@ -74,12 +75,12 @@ func TestHandler_FetchBlogs_Error(t *testing.T) {
 
 	// モックサービスをインスタンス化
 
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// サービス層がエラーを返すように設定
-	mockService.On("FetchBlogs").Return(nil, errors.New("some error occurred"))
+	mockService.On("LastModified").Return(time.Time{})
+	mockService.On("FetchBlogs", models.BlogListParams{}).Return(nil, errors.New("some error occurred"))
 
 	// ハンドラーを実行
 	err := handler.FetchBlogs(c)
@@ -101,12 +102,12 @@ func TestHandler_FetchBlogs_Empty(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// モックサービスをインスタンス化
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockService)
 
 	// サービス層が空のブログリストを返すように設定
-	mockService.On("FetchBlogs").Return([]models.BlogData{}, nil)
+	mockService.On("LastModified").Return(time.Time{})
+	mockService.On("FetchBlogs", models.BlogListParams{}).Return(&models.BlogPage{Items: []models.BlogData{}}, nil)
 
 	// ハンドラーを実行
 	err := handler.FetchBlogs(c)
@@ -114,7 +115,129 @@ func TestHandler_FetchBlogs_Empty(t *testing.T) {
 
 	// ステータスコードとレスポンス内容の確認
 	assert.Equal(t, http.StatusOK, rec.Code)
-	assert.Contains(t, rec.Body.String(), "[]")
+	assert.Contains(t, rec.Body.String(), `"items":[]`)
+	assert.Contains(t, rec.Body.String(), `"next_cursor":null`)
+
+	// モックが期待通りに呼び出されたかを確認
+	mockService.AssertExpectations(t)
+}
+
+func TestHandler_FetchBlogs_Timeout(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/blogs", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// モックサービスをインスタンス化
+	mockService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockService)
+
+	// サービス層がタイムアウトエラーを返すように設定
+	mockService.On("LastModified").Return(time.Time{})
+	mockService.On("FetchBlogs", models.BlogListParams{}).Return(nil, context.DeadlineExceeded)
+
+	// ハンドラーを実行
+	err := handler.FetchBlogs(c)
+	assert.NoError(t, err)
+
+	// ステータスコードとレスポンス内容の確認
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Request timed out")
+
+	// モックが期待通りに呼び出されたかを確認
+	mockService.AssertExpectations(t)
+}
+
+func TestHandler_FetchBlogs_QueryParams(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/blogs?limit=2&cursor=abc&category=Go&tag=echo&user_id=1&from=2024-01-01&to=2024-12-31&sort=most-liked&include_total=true", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// モックサービスをインスタンス化
+	mockService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockService)
+
+	// クエリパラメータがサービス層に渡されることを確認
+	nextCursor := "next"
+	total := 3
+	mockService.On("LastModified").Return(time.Time{})
+	mockService.On("FetchBlogs", models.BlogListParams{
+		Limit:        2,
+		Cursor:       "abc",
+		Category:     "Go",
+		Tag:          "echo",
+		UserId:       "1",
+		From:         "2024-01-01",
+		To:           "2024-12-31",
+		Sort:         "most-liked",
+		IncludeTotal: true,
+	}).Return(&models.BlogPage{
+		Items:      []models.BlogData{{ID: "1", Title: "title1"}},
+		NextCursor: &nextCursor,
+		Total:      &total,
+	}, nil)
+
+	// ハンドラーを実行
+	err := handler.FetchBlogs(c)
+	assert.NoError(t, err)
+
+	// ステータスコードとレスポンス内容の確認
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"next_cursor":"next"`)
+	assert.Contains(t, rec.Body.String(), `"total":3`)
+
+	// モックが期待通りに呼び出されたかを確認
+	mockService.AssertExpectations(t)
+}
+
+func TestHandler_FetchBlogs_InvalidLimit(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/blogs?limit=abc", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// モックサービスをインスタンス化
+	mockService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockService)
+
+	// ハンドラーを実行
+	err := handler.FetchBlogs(c)
+	assert.NoError(t, err)
+
+	// ステータスコードとレスポンス内容の確認
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Invalid limit")
+
+	// サービス層が呼び出されないことを確認
+	mockService.AssertNotCalled(t, "FetchBlogs", mock.Anything)
+}
+
+func TestHandler_FetchBlogs_InvalidCursor(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/blogs?cursor=invalid", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// モックサービスをインスタンス化
+	mockService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockService)
+
+	// サービス層がカーソル不正のエラーを返すように設定
+	mockService.On("LastModified").Return(time.Time{})
+	mockService.On("FetchBlogs", models.BlogListParams{Cursor: "invalid"}).Return(nil, errors.New("invalid cursor"))
+
+	// ハンドラーを実行
+	err := handler.FetchBlogs(c)
+	assert.NoError(t, err)
+
+	// ステータスコードとレスポンス内容の確認
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Invalid cursor")
 
 	// モックが期待通りに呼び出されたかを確認
 	mockService.AssertExpectations(t)
@@ -0,0 +1,305 @@
+package handlers_blogs_test
+
+import (
+	handlers_blogs "backend/handlers/blogs"
+	"backend/models"
+	service_blogs "backend/services/blogs"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_FetchBlogRevisions(t *testing.T) {
+	tests := []struct {
+		name          string
+		serviceResult []models.BlogRevisionData
+		serviceErr    error
+		expectedCode  int
+		expectedBody  string
+	}{
+		{
+			name:          "取得成功",
+			serviceResult: []models.BlogRevisionData{},
+			expectedCode:  http.StatusOK,
+			expectedBody:  `[]`,
+		},
+		{
+			name:         "不正なID",
+			serviceErr:   errors.New("invalid id"),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"Invalid id"}`,
+		},
+		{
+			name:         "ブログが存在しない",
+			serviceErr:   errors.New("blog not found"),
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"Blog not found"}`,
+		},
+		{
+			name:         "他人のブログ",
+			serviceErr:   errors.New("forbidden"),
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"error":"Forbidden"}`,
+		},
+		{
+			name:         "サーバーエラー",
+			serviceErr:   errors.New("failed to fetch revisions"),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"error":"Error fetching revisions"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+
+			// リクエストを作成
+			req := httptest.NewRequest(http.MethodGet, "/api/blogs/revisions/123", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			// パスパラメータを設定
+			c.SetParamNames("id")
+			c.SetParamValues("123")
+
+			// サービスとハンドラーをモックする
+			mockBlogService := new(service_blogs.MockBlogService)
+			handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+			// モックの振る舞いを設定
+			mockBlogService.On("FetchBlogRevisions", "123", "valid-user-id").Return(tt.serviceResult, tt.serviceErr)
+
+			// モッククッキーを設定
+			handlers_blogs.SetMockPrincipal(c)
+
+			// テストを実行
+			err := handler.FetchBlogRevisions(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+
+			// モックの呼び出しを確認
+			mockBlogService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_FetchBlogRevision(t *testing.T) {
+	tests := []struct {
+		name         string
+		revision     string
+		callService  bool
+		serviceErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "取得成功",
+			revision:     "2",
+			callService:  true,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "数値でない版番号",
+			revision:     "abc",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"Invalid revision"}`,
+		},
+		{
+			name:         "版が存在しない",
+			revision:     "2",
+			callService:  true,
+			serviceErr:   errors.New("revision not found"),
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"Revision not found"}`,
+		},
+		{
+			name:         "他人のブログ",
+			revision:     "2",
+			callService:  true,
+			serviceErr:   errors.New("forbidden"),
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"error":"Forbidden"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+
+			// リクエストを作成
+			req := httptest.NewRequest(http.MethodGet, "/api/blogs/revisions/123/"+tt.revision, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			// パスパラメータを設定
+			c.SetParamNames("id", "revision")
+			c.SetParamValues("123", tt.revision)
+
+			// サービスとハンドラーをモックする
+			mockBlogService := new(service_blogs.MockBlogService)
+			handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+			// モックの振る舞いを設定
+			if tt.serviceErr != nil {
+				mockBlogService.On("FetchBlogRevision", "123", 2, "valid-user-id").Return(nil, tt.serviceErr)
+			} else {
+				mockBlogService.On("FetchBlogRevision", "123", 2, "valid-user-id").Return(&models.BlogRevisionData{BlogId: "123", Revision: 2}, nil)
+			}
+
+			// モッククッキーを設定
+			handlers_blogs.SetMockPrincipal(c)
+
+			// テストを実行
+			err := handler.FetchBlogRevision(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+
+			// モックの呼び出しを確認
+			if tt.callService {
+				mockBlogService.AssertExpectations(t)
+			} else {
+				mockBlogService.AssertNotCalled(t, "FetchBlogRevision", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestHandler_DiffBlogRevisions(t *testing.T) {
+	e := echo.New()
+
+	// リクエストを作成
+	req := httptest.NewRequest(http.MethodGet, "/api/blogs/revisions/123/diff?from=1&to=2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	// パスパラメータを設定
+	c.SetParamNames("id")
+	c.SetParamValues("123")
+
+	// サービスとハンドラーをモックする
+	mockBlogService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+	// モックの振る舞いを設定
+	mockBlogService.On("DiffBlogRevisions", "123", 1, 2, "valid-user-id").Return(&models.BlogRevisionDiff{
+		BlogId:  "123",
+		From:    1,
+		To:      2,
+		Changes: []models.BlogFieldChange{{Field: "title", From: "old", To: "new"}},
+	}, nil)
+
+	// モッククッキーを設定
+	handlers_blogs.SetMockPrincipal(c)
+
+	// テストを実行
+	err := handler.DiffBlogRevisions(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"blog_id":"123","from":1,"to":2,"changes":[{"field":"title","from":"old","to":"new"}]}`, rec.Body.String())
+
+	// モックの呼び出しを確認
+	mockBlogService.AssertExpectations(t)
+}
+
+func TestHandler_DiffBlogRevisions_MissingRevision(t *testing.T) {
+	e := echo.New()
+
+	// リクエストを作成(to を指定しない)
+	req := httptest.NewRequest(http.MethodGet, "/api/blogs/revisions/123/diff?from=1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	// パスパラメータを設定
+	c.SetParamNames("id")
+	c.SetParamValues("123")
+
+	// サービスとハンドラーをモックする
+	mockBlogService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+	// モッククッキーを設定
+	handlers_blogs.SetMockPrincipal(c)
+
+	// テストを実行
+	err := handler.DiffBlogRevisions(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t, `{"error":"Invalid revision"}`, rec.Body.String())
+
+	// モックの呼び出しを確認
+	mockBlogService.AssertNotCalled(t, "DiffBlogRevisions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandler_RevertBlog(t *testing.T) {
+	tests := []struct {
+		name         string
+		serviceBlog  *models.BlogData
+		serviceErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "復元成功",
+			serviceBlog:  &models.BlogData{ID: "123", Title: "old"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "カテゴリが削除済み",
+			serviceErr:   errors.New("unknown category"),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"Unknown category"}`,
+		},
+		{
+			name:         "版が存在しない",
+			serviceErr:   errors.New("revision not found"),
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"Revision not found"}`,
+		},
+		{
+			name:         "サーバーエラー",
+			serviceErr:   errors.New("failed to revert blog"),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"error":"Error reverting blog"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+
+			// リクエストを作成
+			req := httptest.NewRequest(http.MethodPost, "/api/blogs/revisions/123/revert/1", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			// パスパラメータを設定
+			c.SetParamNames("id", "revision")
+			c.SetParamValues("123", "1")
+
+			// サービスとハンドラーをモックする
+			mockBlogService := new(service_blogs.MockBlogService)
+			handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+			// モックの振る舞いを設定
+			mockBlogService.On("RevertBlog", "123", 1, "valid-user-id").Return(tt.serviceBlog, tt.serviceErr)
+
+			// モッククッキーを設定
+			handlers_blogs.SetMockPrincipal(c)
+
+			// テストを実行
+			err := handler.RevertBlog(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+
+			// モックの呼び出しを確認
+			mockBlogService.AssertExpectations(t)
+		})
+	}
+}
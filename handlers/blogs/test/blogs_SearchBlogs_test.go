@@ -0,0 +1,105 @@
+package handlers_blogs_test
+
+import (
+	handlers_blogs "backend/handlers/blogs"
+	"backend/models"
+	service_blogs "backend/services/blogs"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_SearchBlogs(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/blogs/search?q="+url.QueryEscape("ブログ go")+"&limit=5", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// モックサービスをインスタンス化
+	mockService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockService)
+
+	// モックデータの設定
+	mockService.On("SearchBlogs", "ブログ go", 5).Return(&models.BlogSearchPage{
+		Items: []models.BlogSearchResult{
+			{
+				BlogData: models.BlogData{ID: "1", Title: "Goのブログ"},
+				Score:    6,
+				Highlights: models.BlogSearchHighlights{
+					Title: "<mark>Go</mark>の<mark>ブログ</mark>",
+				},
+			},
+		},
+	}, nil)
+
+	// ハンドラーを実行
+	err := handler.SearchBlogs(c)
+	assert.NoError(t, err)
+
+	// ステータスコードとレスポンス内容の確認
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"title":"Goのブログ"`)
+	assert.Contains(t, rec.Body.String(), `"score":6`)
+	assert.Contains(t, rec.Body.String(), `"highlights"`)
+
+	// モックが期待通りに呼び出されたかを確認
+	mockService.AssertExpectations(t)
+}
+
+func TestHandler_SearchBlogs_InvalidQuery(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/blogs/search?q=", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// モックサービスをインスタンス化
+	mockService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockService)
+
+	// サービス層がクエリ不正のエラーを返すように設定
+	mockService.On("SearchBlogs", "", 0).Return(nil, errors.New("invalid query"))
+
+	// ハンドラーを実行
+	err := handler.SearchBlogs(c)
+	assert.NoError(t, err)
+
+	// ステータスコードとレスポンス内容の確認
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Invalid query")
+
+	// モックが期待通りに呼び出されたかを確認
+	mockService.AssertExpectations(t)
+}
+
+func TestHandler_SearchBlogs_Error(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/blogs/search?q=go", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// モックサービスをインスタンス化
+	mockService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockService)
+
+	// サービス層がエラーを返すように設定
+	mockService.On("SearchBlogs", "go", 0).Return(nil, errors.New("failed to search blogs"))
+
+	// ハンドラーを実行
+	err := handler.SearchBlogs(c)
+	assert.NoError(t, err)
+
+	// ステータスコードとレスポンス内容の確認
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Error searching blogs")
+
+	// モックが期待通りに呼び出されたかを確認
+	mockService.AssertExpectations(t)
+}
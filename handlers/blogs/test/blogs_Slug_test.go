@@ -0,0 +1,177 @@
+package handlers_blogs_test
+
+import (
+	handlers_blogs "backend/handlers/blogs"
+	"backend/models"
+	service_blogs "backend/services/blogs"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_FetchBlogBySlug(t *testing.T) {
+	e := echo.New()
+
+	// リクエストを作成
+	req := httptest.NewRequest(http.MethodGet, "/api/blogs/by-slug/hello", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("hello")
+
+	// サービスとハンドラーをモックする
+	mockBlogService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+	// モックの振る舞いを設定
+	mockBlogService.On("FetchBlogBySlug", "hello", "").Return(&models.BlogDetail{BlogData: models.BlogData{ID: "1", Title: "title1", Slug: "hello"}}, nil)
+
+	// テストを実行
+	err := handler.FetchBlogBySlug(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"slug":"hello"`)
+
+	// モックの呼び出しを確認
+	mockBlogService.AssertExpectations(t)
+}
+
+func TestHandler_FetchBlogBySlug_Redirect(t *testing.T) {
+	e := echo.New()
+
+	// 変更前のスラッグでリクエストを作成
+	req := httptest.NewRequest(http.MethodGet, "/api/blogs/by-slug/old-slug", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("slug")
+	c.SetParamValues("old-slug")
+
+	// サービスとハンドラーをモックする
+	mockBlogService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+	// モックの振る舞いを設定
+	mockBlogService.On("FetchBlogBySlug", "old-slug", "").Return(&models.BlogDetail{BlogData: models.BlogData{ID: "1", Slug: "new-slug"}}, nil)
+
+	// 現在のスラッグへリダイレクトすること
+	err := handler.FetchBlogBySlug(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/api/blogs/by-slug/new-slug", rec.Header().Get(echo.HeaderLocation))
+}
+
+func TestHandler_FetchBlogBySlug_ErrorCases(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "invalid slug", serviceErr: errors.New("invalid slug"), wantStatus: http.StatusBadRequest, wantBody: `{"error":"Invalid slug"}`},
+		{name: "blog not found", serviceErr: errors.New("blog not found"), wantStatus: http.StatusNotFound, wantBody: `{"error":"Blog not found"}`},
+		{name: "internal error", serviceErr: errors.New("some internal error"), wantStatus: http.StatusInternalServerError, wantBody: `{"error":"Error fetching blog"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+
+			// リクエストを作成
+			req := httptest.NewRequest(http.MethodGet, "/api/blogs/by-slug/hello", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("slug")
+			c.SetParamValues("hello")
+
+			// サービスとハンドラーをモックする
+			mockBlogService := new(service_blogs.MockBlogService)
+			handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+			// モックの振る舞いを設定
+			mockBlogService.On("FetchBlogBySlug", "hello", "").Return(nil, tt.serviceErr)
+
+			// テストを実行
+			err := handler.FetchBlogBySlug(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_UpdateBlogSlug(t *testing.T) {
+	e := echo.New()
+
+	// リクエストを作成
+	req := httptest.NewRequest(http.MethodPut, "/api/blogs/slug/1", strings.NewReader(`{"slug":"new-slug"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// サービスとハンドラーをモックする
+	mockBlogService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+	// モックの振る舞いを設定
+	mockBlogService.On("UpdateBlogSlug", "1", "valid-user-id", "new-slug").Return(&models.BlogData{ID: "1", Slug: "new-slug"}, nil)
+	handlers_blogs.SetMockPrincipal(c)
+
+	// テストを実行
+	err := handler.UpdateBlogSlug(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"slug":"new-slug"`)
+
+	// モックの呼び出しを確認
+	mockBlogService.AssertExpectations(t)
+}
+
+func TestHandler_UpdateBlogSlug_ErrorCases(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "invalid slug", serviceErr: errors.New("invalid slug"), wantStatus: http.StatusBadRequest, wantBody: `{"error":"Invalid slug"}`},
+		{name: "blog not found", serviceErr: errors.New("blog not found"), wantStatus: http.StatusNotFound, wantBody: `{"error":"Blog not found"}`},
+		{name: "slug conflict", serviceErr: errors.New("slug conflict"), wantStatus: http.StatusConflict, wantBody: `{"error":"Slug already exists"}`},
+		{name: "internal error", serviceErr: errors.New("failed to update blog slug"), wantStatus: http.StatusInternalServerError, wantBody: `{"error":"Error updating blog slug"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+
+			// リクエストを作成
+			req := httptest.NewRequest(http.MethodPut, "/api/blogs/slug/1", strings.NewReader(`{"slug":"slug"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			// サービスとハンドラーをモックする
+			mockBlogService := new(service_blogs.MockBlogService)
+			handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+			// モックの振る舞いを設定
+			mockBlogService.On("UpdateBlogSlug", "1", "valid-user-id", mock.Anything).Return(nil, tt.serviceErr)
+			handlers_blogs.SetMockPrincipal(c)
+
+			// テストを実行
+			err := handler.UpdateBlogSlug(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package handlers_blogs_test
+
+import (
+	handlers_blogs "backend/handlers/blogs"
+	"backend/middlewares"
+	"backend/models"
+	service_blogs "backend/services/blogs"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_UpdateBlogStatus(t *testing.T) {
+	e := echo.New()
+
+	// リクエストを作成
+	body := `{"status":"scheduled","publishedAt":"2030-01-02T03:04:05Z"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/blogs/status/1", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// サービスとハンドラーをモックする
+	mockBlogService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+	// モックの振る舞いを設定
+	publishedAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockBlogService.On("UpdateBlogStatus", "1", "valid-user-id", models.BlogStatusScheduled, mock.MatchedBy(func(t *time.Time) bool {
+		return t != nil && t.Equal(publishedAt)
+	})).Return(&models.BlogData{ID: "1", Status: models.BlogStatusScheduled, PublishedAt: &publishedAt}, nil)
+
+	// モッククッキーを設定
+	handlers_blogs.SetMockPrincipal(c)
+
+	// テストを実行
+	err := handler.UpdateBlogStatus(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"scheduled"`)
+
+	// モックの呼び出しを確認
+	mockBlogService.AssertExpectations(t)
+}
+
+func TestHandler_UpdateBlogStatus_ErrorCases(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		serviceErr error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "invalid body", body: `{"publishedAt":"tomorrow"}`, wantStatus: http.StatusBadRequest, wantBody: `{"error":"Invalid request body"}`},
+		{name: "invalid status", body: `{"status":"archived"}`, serviceErr: errors.New("invalid status"), wantStatus: http.StatusBadRequest, wantBody: `{"error":"Invalid status"}`},
+		{name: "invalid publishedAt", body: `{"status":"scheduled"}`, serviceErr: errors.New("invalid publishedAt"), wantStatus: http.StatusBadRequest, wantBody: `{"error":"Invalid publishedAt"}`},
+		{name: "blog not found", body: `{"status":"published"}`, serviceErr: errors.New("blog not found"), wantStatus: http.StatusNotFound, wantBody: `{"error":"Blog not found"}`},
+		{name: "invalid transition", body: `{"status":"draft"}`, serviceErr: errors.New("invalid status transition"), wantStatus: http.StatusConflict, wantBody: `{"error":"Invalid status transition"}`},
+		{name: "internal error", body: `{"status":"published"}`, serviceErr: errors.New("failed to update blog status"), wantStatus: http.StatusInternalServerError, wantBody: `{"error":"Error updating blog status"}`},
+		{name: "timeout", body: `{"status":"published"}`, serviceErr: context.DeadlineExceeded, wantStatus: http.StatusGatewayTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+
+			// リクエストを作成
+			req := httptest.NewRequest(http.MethodPut, "/api/blogs/status/1", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			// サービスとハンドラーをモックする
+			mockBlogService := new(service_blogs.MockBlogService)
+			handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+			// モックの振る舞いを設定
+			mockBlogService.On("UpdateBlogStatus", "1", "valid-user-id", mock.Anything, mock.Anything).Return(nil, tt.serviceErr)
+			handlers_blogs.SetMockPrincipal(c)
+
+			// テストを実行
+			err := handler.UpdateBlogStatus(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_UpdateBlogStatus_Unauthorized(t *testing.T) {
+	e := echo.New()
+
+	// リクエストを作成（クッキーなし）
+	req := httptest.NewRequest(http.MethodPut, "/api/blogs/status/1", strings.NewReader(`{"status":"published"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// サービスとハンドラーをモックする
+	mockBlogService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+	// 認証ミドルウェアを通してテストを実行
+	err := middlewares.RequireAuth()(handler.UpdateBlogStatus)(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"error":"Unauthorized"}`, rec.Body.String())
+
+	// モックの呼び出しを確認
+	mockBlogService.AssertNotCalled(t, "UpdateBlogStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandler_FetchBlogById_Owner(t *testing.T) {
+	e := echo.New()
+
+	// リクエストを作成
+	req := httptest.NewRequest(http.MethodGet, "/api/blogs/detail/1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	// サービスとハンドラーをモックする
+	mockBlogService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+	// ログイン中の場合は閲覧者のユーザーIDを渡すこと
+	mockBlogService.On("FetchBlogById", "1", "valid-user-id").Return(&models.BlogDetail{BlogData: models.BlogData{ID: "1", Status: models.BlogStatusDraft}}, nil)
+	handlers_blogs.SetMockPrincipal(c)
+
+	// テストを実行
+	err := handler.FetchBlogById(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"draft"`)
+
+	// モックの呼び出しを確認
+	mockBlogService.AssertExpectations(t)
+}
@@ -0,0 +1,149 @@
+package handlers_blogs_test
+
+import (
+	handlers_blogs "backend/handlers/blogs"
+	"backend/middlewares"
+	"backend/models"
+	service_blogs "backend/services/blogs"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_FetchTrash(t *testing.T) {
+	e := echo.New()
+
+	// リクエストを作成
+	req := httptest.NewRequest(http.MethodGet, "/api/blogs/trash", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// サービスとハンドラーをモックする
+	mockBlogService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+	// モックの振る舞いを設定
+	deletedAt := time.Now()
+	mockBlogs := []models.BlogData{{ID: "1", UserId: "valid-user-id", Title: "title", DeletedAt: &deletedAt}}
+	mockBlogService.On("FetchDeletedBlogs", "valid-user-id").Return(mockBlogs, nil)
+
+	// モッククッキーを設定
+	handlers_blogs.SetMockPrincipal(c)
+
+	// テストを実行
+	err := handler.FetchTrash(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var blogs []models.BlogData
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &blogs))
+	assert.Len(t, blogs, 1)
+	assert.NotNil(t, blogs[0].DeletedAt)
+
+	// モックの呼び出しを確認
+	mockBlogService.AssertExpectations(t)
+}
+
+func TestHandler_FetchTrash_Unauthorized(t *testing.T) {
+	e := echo.New()
+
+	// リクエストを作成（クッキーなし）
+	req := httptest.NewRequest(http.MethodGet, "/api/blogs/trash", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// サービスとハンドラーをモックする
+	mockBlogService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+	// 認証ミドルウェアを通してテストを実行
+	err := middlewares.RequireAuth()(handler.FetchTrash)(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"error":"Unauthorized"}`, rec.Body.String())
+
+	// モックの呼び出しを確認
+	mockBlogService.AssertNotCalled(t, "FetchDeletedBlogs", mock.Anything)
+}
+
+func TestHandler_RestoreBlog(t *testing.T) {
+	tests := []struct {
+		name         string
+		serviceBlog  *models.BlogData
+		serviceErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "復元成功",
+			serviceBlog:  &models.BlogData{ID: "123", UserId: "valid-user-id", Title: "title"},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "不正なID",
+			serviceErr:   errors.New("invalid id"),
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"Invalid id"}`,
+		},
+		{
+			name:         "ゴミ箱に存在しない",
+			serviceErr:   errors.New("blog not found"),
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"error":"Blog not found"}`,
+		},
+		{
+			name:         "権限がない",
+			serviceErr:   errors.New("forbidden"),
+			expectedCode: http.StatusForbidden,
+			expectedBody: `{"error":"Forbidden"}`,
+		},
+		{
+			name:         "サーバーエラー",
+			serviceErr:   errors.New("failed to restore blog"),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"error":"Error restoring blog"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+
+			// リクエストを作成
+			req := httptest.NewRequest(http.MethodPost, "/api/blogs/restore/123", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			// パスパラメータを設定
+			c.SetParamNames("id")
+			c.SetParamValues("123")
+
+			// サービスとハンドラーをモックする
+			mockBlogService := new(service_blogs.MockBlogService)
+			handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+			// モックの振る舞いを設定
+			mockBlogService.On("RestoreBlog", "123", "valid-user-id").Return(tt.serviceBlog, tt.serviceErr)
+
+			// モッククッキーを設定
+			handlers_blogs.SetMockPrincipal(c)
+
+			// テストを実行
+			err := handler.RestoreBlog(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+
+			// モックの呼び出しを確認
+			mockBlogService.AssertExpectations(t)
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	handlers_blogs "backend/handlers/blogs"
 	"backend/models"
 	service_blogs "backend/services/blogs"
-	utils_cookie "backend/utils/cookie"
 	"bytes"
 	"encoding/json"
 	"errors"
@@ -44,17 +43,16 @@ func TestHandler_UpdateBlog(t *testing.T) {
 	c.SetParamValues("123")
 
 	// サービスとハンドラーをモックする
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockBlogService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockBlogService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
 
 	// モックの振る舞いを設定
-	mockBlogService.On("UpdateBlog", "123", "Test Title", "https://github.com", "Tech", "This is a test blog", "Go").Return(&models.BlogData{
+	mockBlogService.On("UpdateBlog", "123", "valid-user-id", "Test Title", "https://github.com", "Tech", "This is a test blog", "Go", "").Return(&models.BlogData{
 		Title: "Test Title",
 	}, nil)
 
 	// モッククッキーを設定
-	handlers_blogs.SetMockBlogCookies(c, req, mockCookieUtils)
+	handlers_blogs.SetMockPrincipal(c)
 
 	// テストを実行
 	err = handler.UpdateBlog(c)
@@ -94,15 +92,14 @@ func TestHandler_UpdateBlog_InvalidId(t *testing.T) {
 	c.SetParamValues("")
 
 	// サービスとハンドラーをモックする
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockBlogService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockBlogService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
 
 	// モックの振る舞いを設定
-	mockBlogService.On("UpdateBlog", "", "Test Title", "https://github.com", "Tech", "This is a test blog", "Go").Return(nil, errors.New("invalid id"))
+	mockBlogService.On("UpdateBlog", "", "valid-user-id", "Test Title", "https://github.com", "Tech", "This is a test blog", "Go", "").Return(nil, errors.New("invalid id"))
 
 	// モッククッキーを設定
-	handlers_blogs.SetMockBlogCookies(c, req, mockCookieUtils)
+	handlers_blogs.SetMockPrincipal(c)
 
 	// テストを実行
 	err = handler.UpdateBlog(c)
@@ -142,15 +139,14 @@ func TestHandler_UpdateBlog_InvalidTitle(t *testing.T) {
 	c.SetParamValues("123")
 
 	// サービスとハンドラーをモックする
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockBlogService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockBlogService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
 
 	// モックの振る舞いを設定
-	mockBlogService.On("UpdateBlog", "123", "", "https://github.com", "Tech", "This is a test blog", "Go").Return(nil, errors.New("invalid title"))
+	mockBlogService.On("UpdateBlog", "123", "valid-user-id", "", "https://github.com", "Tech", "This is a test blog", "Go", "").Return(nil, errors.New("invalid title"))
 
 	// モッククッキーを設定
-	handlers_blogs.SetMockBlogCookies(c, req, mockCookieUtils)
+	handlers_blogs.SetMockPrincipal(c)
 
 	// テストを実行
 	err = handler.UpdateBlog(c)
@@ -190,15 +186,14 @@ func TestHandler_UpdateBlog_InvalidGithubUrl(t *testing.T) {
 	c.SetParamValues("123")
 
 	// サービスとハンドラーをモックする
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockBlogService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockBlogService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
 
 	// モックの振る舞いを設定
-	mockBlogService.On("UpdateBlog", "123", "Test Title", "", "Tech", "This is a test blog", "Go").Return(nil, errors.New("invalid githubUrl"))
+	mockBlogService.On("UpdateBlog", "123", "valid-user-id", "Test Title", "", "Tech", "This is a test blog", "Go", "").Return(nil, errors.New("invalid githubUrl"))
 
 	// モッククッキーを設定
-	handlers_blogs.SetMockBlogCookies(c, req, mockCookieUtils)
+	handlers_blogs.SetMockPrincipal(c)
 
 	// テストを実行
 	err = handler.UpdateBlog(c)
@@ -238,15 +233,14 @@ func TestHandler_UpdateBlog_InvalidCategory(t *testing.T) {
 	c.SetParamValues("123")
 
 	// サービスとハンドラーをモックする
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockBlogService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockBlogService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
 
 	// モックの振る舞いを設定
-	mockBlogService.On("UpdateBlog", "123", "Test Title", "https://github.com", "", "This is a test blog", "Go").Return(nil, errors.New("invalid category"))
+	mockBlogService.On("UpdateBlog", "123", "valid-user-id", "Test Title", "https://github.com", "", "This is a test blog", "Go", "").Return(nil, errors.New("invalid category"))
 
 	// モッククッキーを設定
-	handlers_blogs.SetMockBlogCookies(c, req, mockCookieUtils)
+	handlers_blogs.SetMockPrincipal(c)
 
 	// テストを実行
 	err = handler.UpdateBlog(c)
@@ -286,15 +280,14 @@ func TestHandler_UpdateBlog_InvalidDescription(t *testing.T) {
 	c.SetParamValues("123")
 
 	// サービスとハンドラーをモックする
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockBlogService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockBlogService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
 
 	// モックの振る舞いを設定
-	mockBlogService.On("UpdateBlog", "123", "Test Title", "https://github.com", "Tech", "", "Go").Return(nil, errors.New("invalid description"))
+	mockBlogService.On("UpdateBlog", "123", "valid-user-id", "Test Title", "https://github.com", "Tech", "", "Go", "").Return(nil, errors.New("invalid description"))
 
 	// モッククッキーを設定
-	handlers_blogs.SetMockBlogCookies(c, req, mockCookieUtils)
+	handlers_blogs.SetMockPrincipal(c)
 
 	// テストを実行
 	err = handler.UpdateBlog(c)
@@ -334,15 +327,14 @@ func TestHandler_UpdateBlog_InvalidTags(t *testing.T) {
 	c.SetParamValues("123")
 
 	// サービスとハンドラーをモックする
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockBlogService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockBlogService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
 
 	// モックの振る舞いを設定
-	mockBlogService.On("UpdateBlog", "123", "Test Title", "https://github.com", "Tech", "This is a test blog", "").Return(nil, errors.New("invalid tags"))
+	mockBlogService.On("UpdateBlog", "123", "valid-user-id", "Test Title", "https://github.com", "Tech", "This is a test blog", "", "").Return(nil, errors.New("invalid tags"))
 
 	// モッククッキーを設定
-	handlers_blogs.SetMockBlogCookies(c, req, mockCookieUtils)
+	handlers_blogs.SetMockPrincipal(c)
 
 	// テストを実行
 	err = handler.UpdateBlog(c)
@@ -382,15 +374,14 @@ func TestHandler_UpdateBlog_NoUpdate(t *testing.T) {
 	c.SetParamValues("123")
 
 	// サービスとハンドラーをモックする
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockBlogService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockBlogService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
 
 	// モックの振る舞いを設定
-	mockBlogService.On("UpdateBlog", "123", "Test Title", "https://github.com", "Tech", "This is a test blog", "Go").Return(nil, errors.New("failed to update blog"))
+	mockBlogService.On("UpdateBlog", "123", "valid-user-id", "Test Title", "https://github.com", "Tech", "This is a test blog", "Go", "").Return(nil, errors.New("failed to update blog"))
 
 	// モッククッキーを設定
-	handlers_blogs.SetMockBlogCookies(c, req, mockCookieUtils)
+	handlers_blogs.SetMockPrincipal(c)
 
 	// テストを実行
 	err = handler.UpdateBlog(c)
@@ -430,15 +421,14 @@ func TestHandler_UpdateBlog_ServerError(t *testing.T) {
 	c.SetParamValues("123")
 
 	// サービスとハンドラーをモックする
-	mockCookieUtils := new(utils_cookie.MockCookieUtils)
 	mockBlogService := new(service_blogs.MockBlogService)
-	handler := handlers_blogs.NewBlogHandler(mockBlogService, mockCookieUtils)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
 
 	// モックの振る舞いを設定
-	mockBlogService.On("UpdateBlog", "123", "Test Title", "https://github.com", "Tech", "This is a test blog", "Go").Return(nil, errors.New("server error"))
+	mockBlogService.On("UpdateBlog", "123", "valid-user-id", "Test Title", "https://github.com", "Tech", "This is a test blog", "Go", "").Return(nil, errors.New("server error"))
 
 	// モッククッキーを設定
-	handlers_blogs.SetMockBlogCookies(c, req, mockCookieUtils)
+	handlers_blogs.SetMockPrincipal(c)
 
 	// テストを実行
 	err = handler.UpdateBlog(c)
@@ -449,3 +439,50 @@ func TestHandler_UpdateBlog_ServerError(t *testing.T) {
 	// モックの呼び出しを確認
 	mockBlogService.AssertExpectations(t)
 }
+
+func TestHandler_UpdateBlog_Forbidden(t *testing.T) {
+	e := echo.New()
+
+	// JSONデータを作成
+	requestBody := map[string]string{
+		"title":       "Test Title",
+		"githubUrl":   "https://github.com",
+		"category":    "Tech",
+		"description": "This is a test blog",
+		"tags":        "Go",
+	}
+
+	// JSONデータをエンコード
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		t.Fatalf("Failed to marshal JSON: %v", err)
+	}
+
+	// リクエストを作成
+	req := httptest.NewRequest(http.MethodPost, "/blogs/update/123", bytes.NewReader(jsonData))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	// パスパラメータを設定
+	c.SetParamNames("id")
+	c.SetParamValues("123")
+
+	// サービスとハンドラーをモックする
+	mockBlogService := new(service_blogs.MockBlogService)
+	handler := handlers_blogs.NewBlogHandler(mockBlogService)
+
+	// モックの振る舞いを設定: 他人のブログを変更する権限がない
+	mockBlogService.On("UpdateBlog", "123", "valid-user-id", "Test Title", "https://github.com", "Tech", "This is a test blog", "Go", "").Return(nil, errors.New("forbidden"))
+
+	// モッククッキーを設定
+	handlers_blogs.SetMockPrincipal(c)
+
+	// テストを実行
+	err = handler.UpdateBlog(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.JSONEq(t, `{"error":"Forbidden"}`, rec.Body.String())
+
+	// モックの呼び出しを確認
+	mockBlogService.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package handlers_blogs_likes
 
 import (
 	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -29,8 +30,11 @@ func (h *BlogLikeHandler) FetchBlogLikesByVisitId(c echo.Context) error {
 	}
 
 	// VisitIDに紐づくいいねデータを取得
-	blogLikesData, err := h.BlogLikeService.FetchBlogLikesByVisitId(visitId)
+	blogLikesData, err := h.BlogLikeService.FetchBlogLikesByVisitId(c.Request().Context(), visitId)
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		utils.LogError(c, "Error fetching blog likes by visit id: "+err.Error())
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Error fetching blog likes by visit id",
@@ -104,8 +108,11 @@ func (h *BlogLikeHandler) IsBlogLiked(c echo.Context) error {
 	blogId := c.Param("blogId")
 
 	// いいねデータが存在するか確認
-	isLiked, err := h.BlogLikeService.IsBlogLiked(blogId, visitId)
+	isLiked, err := h.BlogLikeService.IsBlogLiked(c.Request().Context(), blogId, visitId)
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		utils.LogInfo(c, "Blog like not found")
 		return c.JSON(http.StatusOK, map[string]bool{
 			"isLiked": isLiked,
@@ -143,8 +150,11 @@ func (h *BlogLikeHandler) CreateBlogLike(c echo.Context) error {
 	blogId := c.Param("blogId")
 
 	// いいねデータを作成
-	createdBlogLikeData, err := h.BlogLikeService.CreateBlogLike(blogId, visitId)
+	createdBlogLikeData, err := h.BlogLikeService.CreateBlogLike(c.Request().Context(), blogId, visitId)
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		switch err.Error() {
 		case "BlogId or VisitId is empty":
 			return c.JSON(http.StatusBadRequest, map[string]string{
@@ -154,6 +164,10 @@ func (h *BlogLikeHandler) CreateBlogLike(c echo.Context) error {
 			return c.JSON(http.StatusBadRequest, map[string]string{
 				"error": "Blog is already liked",
 			})
+		case "blog not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Blog not found",
+			})
 		default:
 			utils.LogError(c, "Error creating blog like: "+err.Error())
 			return c.JSON(http.StatusInternalServerError, map[string]string{
@@ -191,8 +205,11 @@ func (h *BlogLikeHandler) DeleteBlogLike(c echo.Context) error {
 	blogId := c.Param("blogId")
 
 	// いいねデータを削除
-	err = h.BlogLikeService.DeleteBlogLike(blogId, visitId)
+	err = h.BlogLikeService.DeleteBlogLike(c.Request().Context(), blogId, visitId)
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		utils.LogError(c, "Error deleting blog like: "+err.Error())
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Error deleting blog like",
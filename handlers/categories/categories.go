@@ -0,0 +1,241 @@
+package handlers_categories
+
+import (
+	utils_auth "backend/utils/auth"
+	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 指定されたIDに一致するカテゴリ情報を取得する
+func (h *CategoryHandler) FetchCategoryById(c echo.Context) error {
+	utils.LogInfo(c, "Fetching category by id...")
+
+	// パスパラメータからidを取得
+	id := c.Param("id")
+
+	// サービス層からカテゴリ情報を取得
+	category, err := h.CategoryService.FetchCategoryById(c.Request().Context(), id)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "invalid id":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid id",
+			})
+		case "category not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Category not found",
+			})
+		default:
+			utils.LogError(c, "Error fetching category: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error fetching category",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Fetched category successfully")
+	return c.JSON(http.StatusOK, category)
+}
+
+// カテゴリを作成する
+func (h *CategoryHandler) CreateCategory(c echo.Context) error {
+	utils.LogInfo(c, "Creating category...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// JSONボディのバインド
+	type CreateCategoryRequest struct {
+		Name         string `json:"name"`
+		Slug         string `json:"slug"`
+		Description  string `json:"description"`
+		DisplayOrder int    `json:"displayOrder"`
+	}
+
+	var req CreateCategoryRequest
+	if err := c.Bind(&req); err != nil {
+		utils.LogError(c, "Error binding request: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層からカテゴリを作成
+	category, err := h.CategoryService.CreateCategory(c.Request().Context(), userId, req.Name, req.Slug, req.Description, req.DisplayOrder)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "invalid name":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid name",
+			})
+		case "invalid slug":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid slug",
+			})
+		case "invalid description":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid description",
+			})
+		case "invalid displayOrder":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid displayOrder",
+			})
+		case "category already exists":
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "Category already exists",
+			})
+		case "forbidden":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Forbidden",
+			})
+		default:
+			utils.LogError(c, "Error creating category: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error creating category",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Created category successfully")
+	return c.JSON(http.StatusCreated, category)
+}
+
+// 指定されたIDに一致するカテゴリを更新する
+func (h *CategoryHandler) UpdateCategory(c echo.Context) error {
+	utils.LogInfo(c, "Updating category...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// パスパラメータからidを取得
+	id := c.Param("id")
+
+	// JSONボディのバインド
+	type UpdateCategoryRequest struct {
+		Name         string `json:"name"`
+		Slug         string `json:"slug"`
+		Description  string `json:"description"`
+		DisplayOrder int    `json:"displayOrder"`
+	}
+
+	var req UpdateCategoryRequest
+	if err := c.Bind(&req); err != nil {
+		utils.LogError(c, "Error binding request: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層からカテゴリを更新
+	category, err := h.CategoryService.UpdateCategory(c.Request().Context(), id, userId, req.Name, req.Slug, req.Description, req.DisplayOrder)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "invalid id":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid id",
+			})
+		case "invalid name":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid name",
+			})
+		case "invalid slug":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid slug",
+			})
+		case "invalid description":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid description",
+			})
+		case "invalid displayOrder":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid displayOrder",
+			})
+		case "category not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Category not found",
+			})
+		case "category already exists":
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "Category already exists",
+			})
+		case "forbidden":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Forbidden",
+			})
+		default:
+			utils.LogError(c, "Error updating category: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error updating category",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Updated category successfully")
+	return c.JSON(http.StatusOK, category)
+}
+
+// 指定されたIDに一致するカテゴリを削除する
+func (h *CategoryHandler) DeleteCategory(c echo.Context) error {
+	utils.LogInfo(c, "Deleting category...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// パスパラメータからidを取得
+	id := c.Param("id")
+
+	// サービス層からカテゴリを削除
+	err := h.CategoryService.DeleteCategory(c.Request().Context(), id, userId)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "invalid id":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid id",
+			})
+		case "category not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Category not found",
+			})
+		case "category in use":
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "Category in use",
+			})
+		case "forbidden":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Forbidden",
+			})
+		default:
+			utils.LogError(c, "Error deleting category: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error deleting category",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Deleted category successfully")
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,110 @@
+package handlers_categories
+
+import (
+	"backend/middlewares"
+	"backend/models"
+	services_categories "backend/services/categories"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// カテゴリ作成のリクエストを作成する
+func newCreateCategoryContext(body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/categories/create", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestHandler_CreateCategory(t *testing.T) {
+	// Echoのセットアップ
+	c, rec := newCreateCategoryContext(`{"name":"Backend","slug":"backend","description":"サーバーサイド","displayOrder":1}`)
+
+	// モックの生成
+	mockCategoryService := new(services_categories.MockCategoryService)
+	handler := NewCategoryHandler(mockCategoryService)
+	SetMockPrincipal(c)
+
+	// モックの振る舞いを設定
+	mockCategoryService.On("CreateCategory", "valid-user-id", "Backend", "backend", "サーバーサイド", 1).Return(&models.CategoryData{
+		ID:           "1",
+		Name:         "Backend",
+		Slug:         "backend",
+		Description:  "サーバーサイド",
+		DisplayOrder: 1,
+	}, nil)
+
+	// テストを実行
+	err := handler.CreateCategory(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"slug":"backend"`)
+	assert.Contains(t, rec.Body.String(), `"display_order":1`)
+
+	// モックの呼び出しを確認
+	mockCategoryService.AssertExpectations(t)
+}
+
+func TestHandler_CreateCategory_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		status     int
+		errMsg     string
+	}{
+		{"不正な名前", errors.New("invalid name"), http.StatusBadRequest, "Invalid name"},
+		{"不正なスラッグ", errors.New("invalid slug"), http.StatusBadRequest, "Invalid slug"},
+		{"名前・スラッグの重複", errors.New("category already exists"), http.StatusConflict, "Category already exists"},
+		{"管理権限がない", errors.New("forbidden"), http.StatusForbidden, "Forbidden"},
+		{"その他のエラー", errors.New("failed to create category"), http.StatusInternalServerError, "Error creating category"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			c, rec := newCreateCategoryContext(`{"name":"Backend","slug":"backend"}`)
+
+			// モックの生成
+			mockCategoryService := new(services_categories.MockCategoryService)
+			handler := NewCategoryHandler(mockCategoryService)
+			SetMockPrincipal(c)
+
+			// モックの振る舞いを設定
+			mockCategoryService.On("CreateCategory", "valid-user-id", "Backend", "backend", "", 0).Return(nil, tt.serviceErr)
+
+			// テストを実行
+			err := handler.CreateCategory(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.errMsg)
+
+			// モックの呼び出しを確認
+			mockCategoryService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_CreateCategory_Unauthorized(t *testing.T) {
+	// Echoのセットアップ
+	c, rec := newCreateCategoryContext(`{"name":"Backend","slug":"backend"}`)
+
+	// モックの生成
+	mockCategoryService := new(services_categories.MockCategoryService)
+	handler := NewCategoryHandler(mockCategoryService)
+
+	// クッキーが存在しない場合、認証ミドルウェアでハンドラーの前に拒否される
+	err := middlewares.RequireAuth()(handler.CreateCategory)(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"error":"Unauthorized"}`, rec.Body.String())
+
+	// サービス層が呼び出されないことを確認
+	mockCategoryService.AssertNotCalled(t, "CreateCategory")
+}
@@ -0,0 +1,54 @@
+package handlers_categories
+
+import (
+	services_categories "backend/services/categories"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_DeleteCategory(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		status     int
+	}{
+		{"削除成功", nil, http.StatusNoContent},
+		{"存在しないカテゴリ", errors.New("category not found"), http.StatusNotFound},
+		{"使用中のカテゴリ", errors.New("category in use"), http.StatusConflict},
+		{"管理権限がない", errors.New("forbidden"), http.StatusForbidden},
+		{"その他のエラー", errors.New("failed to delete category"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/categories/delete/1", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			// モックの生成
+			mockCategoryService := new(services_categories.MockCategoryService)
+			handler := NewCategoryHandler(mockCategoryService)
+			SetMockPrincipal(c)
+
+			// モックの振る舞いを設定
+			mockCategoryService.On("DeleteCategory", "1", "valid-user-id").Return(tt.serviceErr)
+
+			// テストを実行
+			err := handler.DeleteCategory(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, rec.Code)
+
+			// モックの呼び出しを確認
+			mockCategoryService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package handlers_categories
+
+import services_categories "backend/services/categories"
+
+type CategoryHandler struct {
+	CategoryService services_categories.CategoryService
+}
+
+// コンストラクタ
+func NewCategoryHandler(categoryService services_categories.CategoryService) *CategoryHandler {
+	return &CategoryHandler{
+		CategoryService: categoryService,
+	}
+}
@@ -2,6 +2,7 @@ package handlers_comments
 
 import (
 	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -15,8 +16,11 @@ func (h *CommentHandler) FetchCommentsByBlogId(c echo.Context) error {
 	blogId := c.Param("blogId")
 
 	// サービス層からブログIDでコメントデータを取得
-	comments, err := h.CommentService.FetchCommentsByBlogId(blogId)
+	comments, err := h.CommentService.FetchCommentsByBlogId(c.Request().Context(), blogId)
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		switch err.Error() {
 		case "invalid blogId":
 			return c.JSON(http.StatusBadRequest, map[string]string{
@@ -56,8 +60,11 @@ func (h *CommentHandler) CreateComment(c echo.Context) error {
 	}
 
 	// サービス層からコメントデータを新規作成
-	newComment, err := h.CommentService.CreateComment(req.BlogId, req.GuestUser, req.Comment)
+	newComment, err := h.CommentService.CreateComment(c.Request().Context(), req.BlogId, req.GuestUser, req.Comment)
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		switch err.Error() {
 		case "invalid blogId":
 			return c.JSON(http.StatusBadRequest, map[string]string{
@@ -71,6 +78,10 @@ func (h *CommentHandler) CreateComment(c echo.Context) error {
 			return c.JSON(http.StatusBadRequest, map[string]string{
 				"error": "Invalid comment",
 			})
+		case "blog not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Blog not found",
+			})
 		case "failed to create comment":
 			return c.JSON(http.StatusInternalServerError, map[string]string{
 				"error": "Failed to create comment",
@@ -227,6 +227,48 @@ func TestHandler_CreateComment_NotCreate(t *testing.T) {
 	mockCommentService.AssertExpectations(t)
 }
 
+func TestHandler_CreateComment_BlogNotFound(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+
+	// JSONデータを作成
+	requestBody := map[string]string{
+		"blogId":    "1",
+		"guestUser": "guestUser1",
+		"comment":   "comment1",
+	}
+
+	// JSONデータをエンコード
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		t.Fatalf("Failed to marshal JSON: %v", err)
+	}
+
+	// リクエストを作成
+	req := httptest.NewRequest(http.MethodPost, "/comments/create", bytes.NewReader(jsonData))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// モックサービスの生成
+	mockCommentService := new(services_comments.MockCommentService)
+	handler := NewCommentHandler(mockCommentService)
+
+	// モックの振る舞いを設定(ブログがゴミ箱に入っている)
+	mockCommentService.On("CreateComment", "1", "guestUser1", "comment1").Return(nil, errors.New("blog not found"))
+
+	// テストを実行
+	err = handler.CreateComment(c)
+	assert.NoError(t, err)
+
+	// ステータスコードとレスポンス内容の確認
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Blog not found")
+
+	// モックの呼び出しを確認
+	mockCommentService.AssertExpectations(t)
+}
+
 func TestHandler_CreateComment_ServerError(t *testing.T) {
 	// Echoのセットアップ
 	e := echo.New()
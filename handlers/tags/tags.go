@@ -0,0 +1,263 @@
+package handlers_tags
+
+import (
+	utils_auth "backend/utils/auth"
+	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 全タグ情報(使用ブログ数・別名を含む)を取得する
+func (h *TagHandler) FetchTags(c echo.Context) error {
+	utils.LogInfo(c, "Fetching tags...")
+
+	// サービス層から全タグ情報を取得
+	tags, err := h.TagService.FetchTags(c.Request().Context())
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		utils.LogError(c, "Error fetching tags: "+err.Error())
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Error fetching tags",
+		})
+	}
+
+	utils.LogInfo(c, "Fetched tags successfully")
+	return c.JSON(http.StatusOK, tags)
+}
+
+// タグ名を変更する
+func (h *TagHandler) RenameTag(c echo.Context) error {
+	utils.LogInfo(c, "Renaming tag...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// パスパラメータからidを取得
+	id := c.Param("id")
+
+	// JSONボディのバインド
+	type RenameTagRequest struct {
+		Name string `json:"name"`
+	}
+
+	var req RenameTagRequest
+	if err := c.Bind(&req); err != nil {
+		utils.LogError(c, "Error binding request: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層からタグ名を変更
+	tag, err := h.TagService.RenameTag(c.Request().Context(), id, userId, req.Name)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "invalid id":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid id",
+			})
+		case "invalid name":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid name",
+			})
+		case "tag not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Tag not found",
+			})
+		case "tag already exists":
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "Tag already exists",
+			})
+		case "forbidden":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Forbidden",
+			})
+		default:
+			utils.LogError(c, "Error renaming tag: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error renaming tag",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Renamed tag successfully")
+	return c.JSON(http.StatusOK, tag)
+}
+
+// タグを統合する
+func (h *TagHandler) MergeTags(c echo.Context) error {
+	utils.LogInfo(c, "Merging tags...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// JSONボディのバインド
+	type MergeTagsRequest struct {
+		SourceId string `json:"sourceId"`
+		TargetId string `json:"targetId"`
+	}
+
+	var req MergeTagsRequest
+	if err := c.Bind(&req); err != nil {
+		utils.LogError(c, "Error binding request: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層からタグを統合
+	tag, err := h.TagService.MergeTags(c.Request().Context(), userId, req.SourceId, req.TargetId)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "invalid id":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid id",
+			})
+		case "cannot merge same tag":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Cannot merge same tag",
+			})
+		case "tag not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Tag not found",
+			})
+		case "forbidden":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Forbidden",
+			})
+		default:
+			utils.LogError(c, "Error merging tags: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error merging tags",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Merged tags successfully")
+	return c.JSON(http.StatusOK, tag)
+}
+
+// タグに別名を追加する
+func (h *TagHandler) CreateTagAlias(c echo.Context) error {
+	utils.LogInfo(c, "Creating tag alias...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// パスパラメータからidを取得
+	id := c.Param("id")
+
+	// JSONボディのバインド
+	type CreateTagAliasRequest struct {
+		Alias string `json:"alias"`
+	}
+
+	var req CreateTagAliasRequest
+	if err := c.Bind(&req); err != nil {
+		utils.LogError(c, "Error binding request: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層から別名を追加
+	tag, err := h.TagService.CreateTagAlias(c.Request().Context(), id, userId, req.Alias)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "invalid id":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid id",
+			})
+		case "invalid alias":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid alias",
+			})
+		case "tag not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Tag not found",
+			})
+		case "tag already exists":
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "Tag already exists",
+			})
+		case "forbidden":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Forbidden",
+			})
+		default:
+			utils.LogError(c, "Error creating tag alias: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error creating tag alias",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Created tag alias successfully")
+	return c.JSON(http.StatusCreated, tag)
+}
+
+// タグの別名を削除する
+func (h *TagHandler) DeleteTagAlias(c echo.Context) error {
+	utils.LogInfo(c, "Deleting tag alias...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// パスパラメータからaliasを取得
+	alias := c.Param("alias")
+
+	// サービス層から別名を削除
+	err := h.TagService.DeleteTagAlias(c.Request().Context(), alias, userId)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "invalid alias":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid alias",
+			})
+		case "alias not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Alias not found",
+			})
+		case "forbidden":
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "Forbidden",
+			})
+		default:
+			utils.LogError(c, "Error deleting tag alias: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Error deleting tag alias",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Deleted tag alias successfully")
+	return c.NoContent(http.StatusNoContent)
+}
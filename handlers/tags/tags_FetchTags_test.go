@@ -0,0 +1,61 @@
+package handlers_tags
+
+import (
+	"backend/middlewares"
+	"backend/models"
+	services_tags "backend/services/tags"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_FetchTags(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/tags", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// モックの生成
+	mockTagService := new(services_tags.MockTagService)
+	handler := NewTagHandler(mockTagService)
+	SetMockPrincipal(c)
+
+	// モックの振る舞いを設定
+	mockTagService.On("FetchTags").Return([]models.TagData{
+		{ID: "1", Name: "Go", Count: 2, Aliases: []string{"golang"}},
+	}, nil)
+
+	// テストを実行
+	err := handler.FetchTags(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"aliases":["golang"]`)
+
+	// モックの呼び出しを確認
+	mockTagService.AssertExpectations(t)
+}
+
+func TestHandler_FetchTags_Unauthorized(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/tags", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// モックの生成
+	mockTagService := new(services_tags.MockTagService)
+	handler := NewTagHandler(mockTagService)
+
+	// クッキーが存在しない場合、認証ミドルウェアでハンドラーの前に拒否される
+	err := middlewares.RequireAuth()(handler.FetchTags)(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"error":"Unauthorized"}`, rec.Body.String())
+
+	// サービス層が呼び出されないことを確認
+	mockTagService.AssertNotCalled(t, "FetchTags")
+}
@@ -0,0 +1,70 @@
+package handlers_tags
+
+import (
+	"backend/models"
+	services_tags "backend/services/tags"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_MergeTags(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/tags/merge", bytes.NewReader([]byte(`{"sourceId":"1","targetId":"2"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// モックの生成
+	mockTagService := new(services_tags.MockTagService)
+	handler := NewTagHandler(mockTagService)
+	SetMockPrincipal(c)
+
+	// モックの振る舞いを設定
+	mockTagService.On("MergeTags", "valid-user-id", "1", "2").Return(&models.TagData{
+		ID:      "2",
+		Name:    "Go",
+		Aliases: []string{"golang"},
+	}, nil)
+
+	// テストを実行
+	err := handler.MergeTags(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"aliases":["golang"]`)
+
+	// モックの呼び出しを確認
+	mockTagService.AssertExpectations(t)
+}
+
+func TestHandler_MergeTags_SameTag(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/tags/merge", bytes.NewReader([]byte(`{"sourceId":"1","targetId":"1"}`)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// モックの生成
+	mockTagService := new(services_tags.MockTagService)
+	handler := NewTagHandler(mockTagService)
+	SetMockPrincipal(c)
+
+	// モックの振る舞いを設定
+	mockTagService.On("MergeTags", "valid-user-id", "1", "1").Return(nil, errors.New("cannot merge same tag"))
+
+	// テストを実行
+	err := handler.MergeTags(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Cannot merge same tag")
+
+	// モックの呼び出しを確認
+	mockTagService.AssertExpectations(t)
+}
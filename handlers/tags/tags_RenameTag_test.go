@@ -0,0 +1,88 @@
+package handlers_tags
+
+import (
+	"backend/models"
+	services_tags "backend/services/tags"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// タグ名変更のリクエストを作成する
+func newRenameTagContext(body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/tags/rename/1", bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	return c, rec
+}
+
+func TestHandler_RenameTag(t *testing.T) {
+	// Echoのセットアップ
+	c, rec := newRenameTagContext(`{"name":"Go"}`)
+
+	// モックの生成
+	mockTagService := new(services_tags.MockTagService)
+	handler := NewTagHandler(mockTagService)
+	SetMockPrincipal(c)
+
+	// モックの振る舞いを設定
+	mockTagService.On("RenameTag", "1", "valid-user-id", "Go").Return(&models.TagData{ID: "1", Name: "Go"}, nil)
+
+	// テストを実行
+	err := handler.RenameTag(c)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"name":"Go"`)
+
+	// モックの呼び出しを確認
+	mockTagService.AssertExpectations(t)
+}
+
+func TestHandler_RenameTag_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		status     int
+		errMsg     string
+	}{
+		{"不正なID", errors.New("invalid id"), http.StatusBadRequest, "Invalid id"},
+		{"不正な名前", errors.New("invalid name"), http.StatusBadRequest, "Invalid name"},
+		{"存在しないタグ", errors.New("tag not found"), http.StatusNotFound, "Tag not found"},
+		{"名前の重複", errors.New("tag already exists"), http.StatusConflict, "Tag already exists"},
+		{"管理権限がない", errors.New("forbidden"), http.StatusForbidden, "Forbidden"},
+		{"その他のエラー", errors.New("failed to rename tag"), http.StatusInternalServerError, "Error renaming tag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			c, rec := newRenameTagContext(`{"name":"Go"}`)
+
+			// モックの生成
+			mockTagService := new(services_tags.MockTagService)
+			handler := NewTagHandler(mockTagService)
+			SetMockPrincipal(c)
+
+			// モックの振る舞いを設定
+			mockTagService.On("RenameTag", "1", "valid-user-id", "Go").Return(nil, tt.serviceErr)
+
+			// テストを実行
+			err := handler.RenameTag(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.errMsg)
+
+			// モックの呼び出しを確認
+			mockTagService.AssertExpectations(t)
+		})
+	}
+}
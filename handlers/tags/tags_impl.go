@@ -0,0 +1,14 @@
+package handlers_tags
+
+import services_tags "backend/services/tags"
+
+type TagHandler struct {
+	TagService services_tags.TagService
+}
+
+// コンストラクタ
+func NewTagHandler(tagService services_tags.TagService) *TagHandler {
+	return &TagHandler{
+		TagService: tagService,
+	}
+}
@@ -0,0 +1,17 @@
+package handlers_tags
+
+import (
+	"backend/models"
+	utils_auth "backend/utils/auth"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SetMockPrincipal は、認証ミドルウェアで検証済みのログインユーザーを設定します
+func SetMockPrincipal(c echo.Context) {
+	utils_auth.SetPrincipal(c, &models.Claims{
+		UserID:   "valid-user-id",
+		Email:    "test@example.com",
+		Username: "Test User",
+	})
+}
@@ -1,7 +1,10 @@
 package handlers_users
 
 import (
+	"backend/models"
+	utils_auth "backend/utils/auth"
 	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -28,8 +31,11 @@ func (h *UserHandler) GetUserByEmailAndPassword(c echo.Context) error {
 	}
 
 	// サービス層からユーザーデータを取得
-	user, err := h.UserService.FetchUserByEmailAndPassword(reqBody.Email, reqBody.Password)
+	user, err := h.UserService.FetchUserByEmailAndPassword(c.Request().Context(), reqBody.Email, reqBody.Password)
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		switch err.Error() {
 		case "email and password are required":
 			return c.JSON(http.StatusBadRequest, map[string]string{
@@ -52,34 +58,25 @@ func (h *UserHandler) GetUserByEmailAndPassword(c echo.Context) error {
 	}
 
 	utils.LogInfo(c, "Fetched user successfully")
-	return c.JSON(http.StatusOK, user)
+	return c.JSON(http.StatusOK, models.NewUserProfile(user))
 }
 
 // ユーザーIDでユーザーデータを取得する
 func (h *UserHandler) FetchUser(c echo.Context) error {
 	utils.LogInfo(c, "Fetching user...")
 
-	// クッキーからJWTトークンを取得
-	cookieValue, err := h.CookieUtils.GetAuthCookieValue(c, "token")
-	if err != nil {
-		utils.LogError(c, "Error getting cookie: "+err.Error())
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Error getting cookie",
-		})
-	}
-
-	// JWTトークンを解析してユーザーIDを取得
-	userId, err := h.CookieUtils.GetUserIdFromToken(c, cookieValue)
-	if err != nil {
-		utils.LogError(c, "Error getting userId from token: "+err.Error())
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Error getting userId from token",
-		})
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
 	}
 
 	// サービス層からユーザーデータを取得
-	user, err := h.UserService.FetchUserById(userId)
+	user, err := h.UserService.FetchUserById(c.Request().Context(), userId)
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		switch err.Error() {
 		case "id is required":
 			return c.JSON(http.StatusBadRequest, map[string]string{
@@ -97,105 +94,33 @@ func (h *UserHandler) FetchUser(c echo.Context) error {
 		}
 	}
 
-	// パスワードだけ抜く
-	user.Password = ""
-
 	utils.LogInfo(c, "Fetched user successfully")
-	return c.JSON(http.StatusOK, user)
+	return c.JSON(http.StatusOK, models.NewUserProfile(user))
 }
 
-// ユーザーデータを更新する
-func (h *UserHandler) UpdateUser(c echo.Context) error {
-	utils.LogInfo(c, "Updating user...")
-
-	// クッキーからJWTトークンを取得
-	cookieValue, err := h.CookieUtils.GetAuthCookieValue(c, "token")
-	if err != nil {
-		utils.LogError(c, "Error getting cookie: "+err.Error())
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Error getting cookie",
-		})
-	}
-
-	// JWTトークンを解析してユーザーIDを取得
-	userId, err := h.CookieUtils.GetUserIdFromToken(c, cookieValue)
-	if err != nil {
-		utils.LogError(c, "Error getting userId from token: "+err.Error())
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "Error getting userId from token",
-		})
-	}
-
-	// JSONのリクエストボディからname, email, passwordを取得
-	type RequestBody struct {
-		Name        string `json:"name"`
-		Email       string `json:"email"`
-		Password    string `json:"password"`
-		NewPassword string `json:"newPassword"`
-	}
-
-	// リクエストボディをバインド
-	var reqBody RequestBody
-	if err := c.Bind(&reqBody); err != nil {
-		utils.LogError(c, "Failed to bind request body: "+err.Error())
-		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid request body",
-		})
-	}
+// 指定されたIDの投稿者の公開用プロフィールを取得する(ログイン不要)
+func (h *UserHandler) FetchAuthor(c echo.Context) error {
+	utils.LogInfo(c, "Fetching author...")
 
-	// サービス層からユーザーデータを更新
-	user, err := h.UserService.UpdateUser(userId, reqBody.Name, reqBody.Email, reqBody.Password, reqBody.NewPassword)
+	// サービス層から公開用プロフィールを取得
+	author, err := h.UserService.FetchAuthorProfile(c.Request().Context(), c.Param("id"))
 	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
 		switch err.Error() {
-		case "id is required":
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "id is required",
-			})
-		case "name is required":
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Name is required",
-			})
-		case "email is required":
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Email is required",
-			})
-		case "password is required":
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Password is required",
-			})
-		case "new password is required":
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "New password is required",
-			})
-		case "invalid email format":
-			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid email format",
-			})
 		case "user not found":
 			return c.JSON(http.StatusNotFound, map[string]string{
 				"error": "User not found",
 			})
 		default:
-			utils.LogError(c, "Error updating user: "+err.Error())
+			utils.LogError(c, "Error fetching author: "+err.Error())
 			return c.JSON(http.StatusInternalServerError, map[string]string{
-				"error": "Failed to update user",
+				"error": "Failed to fetch user",
 			})
 		}
 	}
 
-	// トークンの有効期限を1時間に設定
-	expirationTime := h.CookieUtils.GetAuthCookieExpirationTime()
-	// トークンの再作成
-	tokenString, err := h.CookieUtils.CreateToken(user)
-	if err != nil {
-		utils.LogError(c, "Error creating token: "+err.Error())
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to create token",
-		})
-	}
-	// トークンをクッキーに更新
-	h.CookieUtils.UpdateAuthCookie(c, tokenString, expirationTime)
-
-	utils.LogInfo(c, "Updated user successfully")
-	return c.JSON(http.StatusOK, user)
+	utils.LogInfo(c, "Fetched author successfully")
+	return c.JSON(http.StatusOK, author)
 }
@@ -0,0 +1,106 @@
+package handlers_users
+
+import (
+	"backend/models"
+	services_users "backend/services/users"
+	utils_cookie "backend/utils/cookie"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_RequestEmailChange(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "受付", expectedStatus: http.StatusAccepted, expectedBody: `{"message":"Confirmation mail has been sent to the new email"}`},
+		{name: "パスワードが誤り", serviceErr: errors.New("invalid current password"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Invalid current password"}`},
+		{name: "現在と同じメールアドレス", serviceErr: errors.New("email unchanged"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Email is unchanged"}`},
+		{name: "他のユーザーが登録済み", serviceErr: errors.New("email already registered"), expectedStatus: http.StatusConflict, expectedBody: `{"error":"Email already registered"}`},
+		{name: "送信に失敗", serviceErr: errors.New("failed to send confirmation mail"), expectedStatus: http.StatusInternalServerError, expectedBody: `{"error":"Failed to send confirmation mail"}`},
+		{name: "サービスのエラー", serviceErr: errors.New("failed to request email change"), expectedStatus: http.StatusInternalServerError, expectedBody: `{"error":"Failed to request email change"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/email", strings.NewReader(`{"email":"new@example.com","password":"password123"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// モックサービスをインスタンス化
+			mockUserService := new(services_users.MockUserService)
+			handler := NewUserHandler(mockUserService, new(utils_cookie.MockCookieUtils))
+			mockUserService.On("RequestEmailChange", "valid-user-id", "new@example.com", "password123").Return(tt.serviceErr)
+
+			// モッククッキーを設定
+			SetMockPrincipal(c)
+
+			// ハンドラーを実行
+			err := handler.RequestEmailChange(c)
+
+			// ステータスコードとレスポンス内容の確認
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			mockUserService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_ConfirmEmailChange(t *testing.T) {
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name           string
+		serviceUser    *models.UserData
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "変更成功", serviceUser: &models.UserData{ID: "1", Email: "new@example.com", EmailVerifiedAt: &verifiedAt}, expectedStatus: http.StatusOK},
+		{name: "無効なトークン", serviceErr: errors.New("invalid email change token"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Invalid or expired token"}`},
+		{name: "他のユーザーが登録済み", serviceErr: errors.New("email already registered"), expectedStatus: http.StatusConflict, expectedBody: `{"error":"Email already registered"}`},
+		{name: "サービスのエラー", serviceErr: errors.New("failed to change email"), expectedStatus: http.StatusInternalServerError, expectedBody: `{"error":"Failed to change email"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/email/confirm", strings.NewReader(`{"token":"token"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// モックサービスをインスタンス化
+			mockUserService := new(services_users.MockUserService)
+			handler := NewUserHandler(mockUserService, new(utils_cookie.MockCookieUtils))
+			mockUserService.On("ConfirmEmailChange", "token").Return(tt.serviceUser, tt.serviceErr)
+
+			// ハンドラーを実行
+			err := handler.ConfirmEmailChange(c)
+
+			// ステータスコードとレスポンス内容の確認
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			} else {
+				assert.Contains(t, rec.Body.String(), `"email":"new@example.com"`)
+			}
+			mockUserService.AssertExpectations(t)
+		})
+	}
+}
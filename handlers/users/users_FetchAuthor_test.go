@@ -0,0 +1,71 @@
+package handlers_users
+
+import (
+	"backend/models"
+	services_users "backend/services/users"
+	utils_cookie "backend/utils/cookie"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_FetchAuthor(t *testing.T) {
+	tests := []struct {
+		name           string
+		author         *models.AuthorProfile
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "取得成功",
+			author:         &models.AuthorProfile{ID: "author-id", Name: "John Doe"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"author-id","name":"John Doe","bio":"","avatar_url":""}`,
+		},
+		{
+			name:           "存在しないユーザー",
+			serviceErr:     errors.New("user not found"),
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"User not found"}`,
+		},
+		{
+			name:           "サービスエラー",
+			serviceErr:     errors.New("failed to fetch user"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"Failed to fetch user"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/users/authors/author-id", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("author-id")
+
+			// モックサービスをインスタンス化
+			mockCookieUtils := new(utils_cookie.MockCookieUtils)
+			mockUserService := new(services_users.MockUserService)
+			handler := NewUserHandler(mockUserService, mockCookieUtils)
+			mockUserService.On("FetchAuthorProfile", "author-id").Return(tt.author, tt.serviceErr)
+
+			// ハンドラーを実行
+			err := handler.FetchAuthor(c)
+
+			// ステータスコードとレスポンス内容の確認(ログイン不要)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			mockUserService.AssertExpectations(t)
+			mockCookieUtils.AssertNotCalled(t, "GetAuthCookieValue", c, "token")
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package handlers_users
 
 import (
+	"backend/middlewares"
 	"backend/models"
 	services_users "backend/services/users"
 	utils_cookie "backend/utils/cookie"
@@ -35,7 +36,7 @@ func TestHandler_FetchUser(t *testing.T) {
 	mockUserService.On("FetchUserById", "valid-user-id").Return(mockUser, nil)
 
 	// モッククッキーを設定
-	SetMockBlogCookies(c, req, mockCookieUtils)
+	SetMockPrincipal(c)
 
 	// ハンドラーを実行
 	handler.FetchUser(c)
@@ -43,6 +44,8 @@ func TestHandler_FetchUser(t *testing.T) {
 	// ステータスコードとレスポンス内容の確認
 	assert.Equal(t, http.StatusOK, rec.Code)
 	assert.Contains(t, rec.Body.String(), "John Doe")
+	// 資格情報の項目を含まない
+	assert.NotContains(t, rec.Body.String(), "password")
 
 	// モックが期待通りに呼び出されたかを確認
 	mockUserService.AssertExpectations(t)
@@ -61,23 +64,20 @@ func TestHandler_FetchUser_NotToken(t *testing.T) {
 	mockUserService := new(services_users.MockUserService)
 	handler := NewUserHandler(mockUserService, mockCookieUtils)
 
-	// モックの振る舞いを設定
-	mockCookieUtils.On("GetAuthCookieValue", c, "token").Return("", errors.New("no cookie"))
-
-	// ハンドラーを実行
-	err := handler.FetchUser(c)
+	// 認証ミドルウェアを通してハンドラーを実行(クッキーなし)
+	err := middlewares.RequireAuth()(handler.FetchUser)(c)
 
 	// ステータスコードとレスポンス内容の確認
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
-	assert.Contains(t, rec.Body.String(), "Error getting cookie")
+	assert.JSONEq(t, `{"error":"Unauthorized"}`, rec.Body.String())
 
 	// モックが期待通りに呼び出されたかを確認
 	mockCookieUtils.AssertExpectations(t)
 	mockUserService.AssertNotCalled(t, "FetchUserById", "valid-user-id")
 }
 
-func TestHandler_FetchUser_NotUserId(t *testing.T) {
+func TestHandler_FetchUser_InvalidToken(t *testing.T) {
 	// Echoのセットアップ
 	e := echo.New()
 
@@ -90,17 +90,16 @@ func TestHandler_FetchUser_NotUserId(t *testing.T) {
 	mockUserService := new(services_users.MockUserService)
 	handler := NewUserHandler(mockUserService, mockCookieUtils)
 
-	// モックの振る舞いを設定
-	mockCookieUtils.On("GetAuthCookieValue", c, "token").Return("mocked-token", nil)
-	mockCookieUtils.On("GetUserIdFromToken", c, "mocked-token").Return("", errors.New("no user id"))
+	// 署名が不正なトークンをクッキーに設定
+	req.AddCookie(&http.Cookie{Name: "token", Value: "mocked-token"})
 
-	// ハンドラーを実行
-	err := handler.FetchUser(c)
+	// 認証ミドルウェアを通してハンドラーを実行
+	err := middlewares.RequireAuth()(handler.FetchUser)(c)
 
 	// ステータスコードとレスポンス内容の確認
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
-	assert.Contains(t, rec.Body.String(), "Error getting userId from token")
+	assert.JSONEq(t, `{"error":"Unauthorized"}`, rec.Body.String())
 
 	// モックが期待通りに呼び出されたかを確認
 	mockCookieUtils.AssertExpectations(t)
@@ -124,7 +123,7 @@ func TestHandler_FetchUser_NotUser(t *testing.T) {
 	mockUserService.On("FetchUserById", "valid-user-id").Return(nil, errors.New("user not found"))
 
 	// モッククッキーを設定
-	SetMockBlogCookies(c, req, mockCookieUtils)
+	SetMockPrincipal(c)
 
 	// ハンドラーを実行
 	err := handler.FetchUser(c)
@@ -155,7 +154,7 @@ func TestHandler_FetchUser_ServerError(t *testing.T) {
 	mockUserService.On("FetchUserById", "valid-user-id").Return(nil, errors.New("server error"))
 
 	// モッククッキーを設定
-	SetMockBlogCookies(c, req, mockCookieUtils)
+	SetMockPrincipal(c)
 
 	// ハンドラーを実行
 	err := handler.FetchUser(c)
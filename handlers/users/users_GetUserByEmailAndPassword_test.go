@@ -43,6 +43,8 @@ func TestHandler_GetUserByEmailAndPassword(t *testing.T) {
 	// ステータスコードとレスポンス内容の確認
 	assert.Equal(t, http.StatusOK, rec.Code)
 	assert.Contains(t, rec.Body.String(), "John Doe")
+	// 資格情報の項目を含まない
+	assert.NotContains(t, rec.Body.String(), "password")
 
 	// モックが期待通りに呼び出されたかを確認
 	mockUserService.AssertExpectations(t)
@@ -0,0 +1,103 @@
+package handlers_users
+
+import (
+	services_users "backend/services/users"
+	utils_cookie "backend/utils/cookie"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_ForgotPassword(t *testing.T) {
+	accepted := `{"message":"If the email is registered, a password reset link has been sent"}`
+
+	tests := []struct {
+		name           string
+		retryAfter     time.Duration
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+		expectedRetry  string
+	}{
+		// 未登録のメールアドレスでもサービスはエラーを返さないため、同じレスポンスになる
+		{name: "受付", expectedStatus: http.StatusAccepted, expectedBody: accepted},
+		{name: "メールアドレスの形式が不正", serviceErr: errors.New("invalid email format"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Invalid email format"}`},
+		{name: "申請が多すぎる", retryAfter: 90*time.Second + time.Millisecond, serviceErr: errors.New("too many requests"), expectedStatus: http.StatusTooManyRequests, expectedBody: `{"error":"Too many requests"}`, expectedRetry: "91"},
+		{name: "サービスのエラー", serviceErr: errors.New("failed to request password reset"), expectedStatus: http.StatusInternalServerError, expectedBody: `{"error":"Failed to request password reset"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/password/forgot", strings.NewReader(`{"email":"john@example.com"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// モックサービスをインスタンス化
+			mockUserService := new(services_users.MockUserService)
+			handler := NewUserHandler(mockUserService, new(utils_cookie.MockCookieUtils))
+			mockUserService.On("ForgotPassword", "192.0.2.1", "john@example.com").Return(tt.retryAfter, tt.serviceErr)
+
+			// ハンドラーを実行
+			err := handler.ForgotPassword(c)
+
+			// ステータスコードとレスポンス内容の確認
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			assert.Equal(t, tt.expectedRetry, rec.Header().Get(echo.HeaderRetryAfter))
+			mockUserService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_ResetPassword(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "再設定成功", expectedStatus: http.StatusNoContent},
+		{name: "無効なトークン", serviceErr: errors.New("invalid reset token"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Invalid or expired token"}`},
+		{name: "パスワードが短い", serviceErr: errors.New("invalid password length"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Password must be between 8 and 128 characters"}`},
+		{name: "サービスのエラー", serviceErr: errors.New("failed to reset password"), expectedStatus: http.StatusInternalServerError, expectedBody: `{"error":"Failed to reset password"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/password/reset", strings.NewReader(`{"token":"token","newPassword":"newpassword123"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// モックサービスをインスタンス化
+			mockUserService := new(services_users.MockUserService)
+			handler := NewUserHandler(mockUserService, new(utils_cookie.MockCookieUtils))
+			mockUserService.On("ResetPassword", "token", "newpassword123").Return(tt.serviceErr)
+
+			// ハンドラーを実行
+			err := handler.ResetPassword(c)
+
+			// ステータスコードとレスポンス内容の確認
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			} else {
+				assert.Empty(t, rec.Body.String())
+			}
+			mockUserService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package handlers_users
+
+import (
+	"backend/middlewares"
+	"backend/models"
+	services_users "backend/services/users"
+	utils_cookie "backend/utils/cookie"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_Register(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceUser    *models.UserData
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "登録成功",
+			serviceUser:    &models.UserData{ID: "1", Name: "John Doe", Email: "john@example.com", Role: models.UserRoleAuthor},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "パスワードが短い",
+			serviceErr:     errors.New("invalid password length"),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"Password must be between 8 and 128 characters"}`,
+		},
+		{
+			name:           "メールアドレスの形式が不正",
+			serviceErr:     errors.New("invalid email format"),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"Invalid email format"}`,
+		},
+		{
+			name:           "メールアドレスが登録済み",
+			serviceErr:     errors.New("email already registered"),
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"Email already registered"}`,
+		},
+		{
+			name:           "サービスのエラー",
+			serviceErr:     errors.New("failed to register user"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"Failed to register user"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/register", strings.NewReader(`{"name":"John Doe","email":"john@example.com","password":"password123"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// モックサービスをインスタンス化
+			mockUserService := new(services_users.MockUserService)
+			handler := NewUserHandler(mockUserService, new(utils_cookie.MockCookieUtils))
+			mockUserService.On("Register", "John Doe", "john@example.com", "password123").Return(tt.serviceUser, tt.serviceErr)
+
+			// ハンドラーを実行
+			err := handler.Register(c)
+
+			// ステータスコードとレスポンス内容の確認
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			} else {
+				assert.JSONEq(t, `{"id":"1","name":"John Doe","email":"john@example.com","role":"author","bio":"","avatar_url":"","email_verified":false,"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}`, rec.Body.String())
+			}
+			mockUserService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_VerifyEmail(t *testing.T) {
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name           string
+		serviceUser    *models.UserData
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "確認成功",
+			serviceUser:    &models.UserData{ID: "1", Email: "john@example.com", EmailVerifiedAt: &verifiedAt},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "無効なトークン",
+			serviceErr:     errors.New("invalid verification token"),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"Invalid or expired token"}`,
+		},
+		{
+			name:           "サービスのエラー",
+			serviceErr:     errors.New("failed to verify email"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"Failed to verify email"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/verify-email", strings.NewReader(`{"token":"token"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// モックサービスをインスタンス化
+			mockUserService := new(services_users.MockUserService)
+			handler := NewUserHandler(mockUserService, new(utils_cookie.MockCookieUtils))
+			mockUserService.On("VerifyEmail", "token").Return(tt.serviceUser, tt.serviceErr)
+
+			// ハンドラーを実行
+			err := handler.VerifyEmail(c)
+
+			// ステータスコードとレスポンス内容の確認
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			} else {
+				assert.Contains(t, rec.Body.String(), `"email_verified":true`)
+			}
+			mockUserService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_ResendVerification(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "再送成功", expectedStatus: http.StatusNoContent},
+		{name: "確認済み", serviceErr: errors.New("email already verified"), expectedStatus: http.StatusConflict, expectedBody: `{"error":"Email already verified"}`},
+		{name: "送信の失敗", serviceErr: errors.New("failed to send verification mail"), expectedStatus: http.StatusInternalServerError, expectedBody: `{"error":"Failed to send verification mail"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/verify-email/resend", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// モックサービスをインスタンス化
+			mockUserService := new(services_users.MockUserService)
+			handler := NewUserHandler(mockUserService, new(utils_cookie.MockCookieUtils))
+			mockUserService.On("ResendVerification", "valid-user-id").Return(tt.serviceErr)
+
+			// モッククッキーを設定
+			SetMockPrincipal(c)
+
+			// ハンドラーを実行
+			err := handler.ResendVerification(c)
+
+			// ステータスコードとレスポンス内容の確認
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			} else {
+				assert.Empty(t, rec.Body.String())
+			}
+			mockUserService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_ResendVerification_Unauthorized(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/users/verify-email/resend", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// モックサービスをインスタンス化
+	mockUserService := new(services_users.MockUserService)
+	handler := NewUserHandler(mockUserService, new(utils_cookie.MockCookieUtils))
+
+	// 認証ミドルウェアを通して実行(トークンなし)
+	err := middlewares.RequireAuth()(handler.ResendVerification)(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	mockUserService.AssertNotCalled(t, "ResendVerification", "valid-user-id")
+}
@@ -0,0 +1,199 @@
+package handlers_users
+
+import (
+	"backend/middlewares"
+	"backend/models"
+	services_users "backend/services/users"
+	utils_cookie "backend/utils/cookie"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_UpdateProfile(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+
+	// 自己紹介のみ省略したリクエストを作成
+	req := httptest.NewRequest(http.MethodPatch, "/api/users/profile", strings.NewReader(`{"name":"John Doe","avatarUrl":"https://example.com/avatar.png"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// モックサービスをインスタンス化
+	mockCookieUtils := new(utils_cookie.MockCookieUtils)
+	mockUserService := new(services_users.MockUserService)
+	handler := NewUserHandler(mockUserService, mockCookieUtils)
+
+	// 省略した項目は nil で渡す
+	name := "John Doe"
+	avatarURL := "https://example.com/avatar.png"
+	mockUser := &models.UserData{
+		ID:        "valid-user-id",
+		Name:      "John Doe",
+		Email:     "john@example.com",
+		Bio:       "Gopher",
+		AvatarURL: avatarURL,
+	}
+	mockUserService.On("UpdateProfile", "valid-user-id", &name, (*string)(nil), &avatarURL).Return(mockUser, nil)
+
+	// モッククッキーを設定
+	SetMockPrincipal(c)
+
+	// クレームのユーザー名を更新するため、トークンを作成し直す
+	mockCookieUtils.On("GetAuthCookieExpirationTime").Return(time.Now().Add(1 * time.Hour))
+	mockCookieUtils.On("CreateToken", mockUser).Return("new-mocked-token", nil)
+	mockCookieUtils.On("UpdateAuthCookie", c, "new-mocked-token", mock.Anything).Return(nil)
+
+	// ハンドラーを実行
+	err := handler.UpdateProfile(c)
+
+	// ステータスコードとレスポンス内容の確認
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"bio":"Gopher"`)
+	assert.Contains(t, rec.Body.String(), `"avatar_url":"https://example.com/avatar.png"`)
+
+	// モックが期待通りに呼び出されたかを確認
+	mockUserService.AssertExpectations(t)
+	mockCookieUtils.AssertExpectations(t)
+}
+
+func TestHandler_UpdateProfile_Unauthorized(t *testing.T) {
+	tests := []struct {
+		name   string
+		cookie *http.Cookie
+	}{
+		{name: "クッキーなし"},
+		{name: "署名が不正なトークン", cookie: &http.Cookie{Name: "token", Value: "mocked-token"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPatch, "/api/users/profile", strings.NewReader(`{"name":"John Doe"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.cookie != nil {
+				req.AddCookie(tt.cookie)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// モックサービスをインスタンス化
+			mockCookieUtils := new(utils_cookie.MockCookieUtils)
+			mockUserService := new(services_users.MockUserService)
+			handler := NewUserHandler(mockUserService, mockCookieUtils)
+
+			// 認証ミドルウェアを通してハンドラーを実行
+			err := middlewares.RequireAuth()(handler.UpdateProfile)(c)
+
+			// ステータスコードとレスポンス内容の確認
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.JSONEq(t, `{"error":"Unauthorized"}`, rec.Body.String())
+			mockUserService.AssertNotCalled(t, "UpdateProfile", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestHandler_UpdateProfile_Error(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "項目の指定がない", serviceErr: errors.New("no fields to update"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"No fields to update"}`},
+		{name: "名前が空", serviceErr: errors.New("name is required"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Name is required"}`},
+		{name: "自己紹介が長い", serviceErr: errors.New("bio is too long"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Bio is too long"}`},
+		{name: "アバターのURLが不正", serviceErr: errors.New("invalid avatar url"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Invalid avatar URL"}`},
+		{name: "ユーザーが存在しない", serviceErr: errors.New("user not found"), expectedStatus: http.StatusNotFound, expectedBody: `{"error":"User not found"}`},
+		{name: "サービスのエラー", serviceErr: errors.New("failed to update profile"), expectedStatus: http.StatusInternalServerError, expectedBody: `{"error":"Failed to update profile"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPatch, "/api/users/profile", strings.NewReader(`{"bio":"Gopher"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// モックサービスをインスタンス化
+			mockCookieUtils := new(utils_cookie.MockCookieUtils)
+			mockUserService := new(services_users.MockUserService)
+			handler := NewUserHandler(mockUserService, mockCookieUtils)
+			bio := "Gopher"
+			mockUserService.On("UpdateProfile", "valid-user-id", (*string)(nil), &bio, (*string)(nil)).Return(nil, tt.serviceErr)
+
+			// モッククッキーを設定
+			SetMockPrincipal(c)
+
+			// ハンドラーを実行
+			err := handler.UpdateProfile(c)
+
+			// ステータスコードとレスポンス内容の確認(失敗時はトークンを作成し直さない)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			mockUserService.AssertExpectations(t)
+			mockCookieUtils.AssertNotCalled(t, "CreateToken", mock.Anything)
+		})
+	}
+}
+
+func TestHandler_ChangePassword(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "変更成功", expectedStatus: http.StatusNoContent},
+		{name: "現在のパスワードが誤り", serviceErr: errors.New("invalid current password"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Invalid current password"}`},
+		{name: "新しいパスワードが空", serviceErr: errors.New("new password is required"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"New password is required"}`},
+		{name: "新しいパスワードが短い", serviceErr: errors.New("invalid password length"), expectedStatus: http.StatusBadRequest, expectedBody: `{"error":"Password must be between 8 and 128 characters"}`},
+		{name: "サービスのエラー", serviceErr: errors.New("failed to change password"), expectedStatus: http.StatusInternalServerError, expectedBody: `{"error":"Failed to change password"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/password", strings.NewReader(`{"password":"password123","newPassword":"newpassword123"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			// モックサービスをインスタンス化
+			mockUserService := new(services_users.MockUserService)
+			handler := NewUserHandler(mockUserService, new(utils_cookie.MockCookieUtils))
+			mockUserService.On("ChangePassword", "valid-user-id", "password123", "newpassword123").Return(tt.serviceErr)
+
+			// モッククッキーを設定
+			SetMockPrincipal(c)
+
+			// ハンドラーを実行
+			err := handler.ChangePassword(c)
+
+			// ステータスコードとレスポンス内容の確認
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			} else {
+				assert.Empty(t, rec.Body.String())
+			}
+			mockUserService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package handlers_users
+
+import (
+	"backend/models"
+	utils_auth "backend/utils/auth"
+	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 現在のパスワードを確認し、新しいメールアドレスに変更の確認メールを送信する
+// メールアドレスは確認メールのリンクを開くまで変更しない。
+func (h *UserHandler) RequestEmailChange(c echo.Context) error {
+	utils.LogInfo(c, "Requesting email change...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// JSONのリクエストボディからemail, passwordを取得
+	type RequestBody struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+
+	// リクエストボディをバインド
+	var reqBody RequestBody
+	if err := c.Bind(&reqBody); err != nil {
+		utils.LogError(c, "Failed to bind request body: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層で確認メールを送信
+	err := h.UserService.RequestEmailChange(c.Request().Context(), userId, reqBody.Email, reqBody.Password)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "email is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Email is required",
+			})
+		case "password is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Password is required",
+			})
+		case "invalid email format":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid email format",
+			})
+		case "invalid current password":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid current password",
+			})
+		case "email unchanged":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Email is unchanged",
+			})
+		case "email already registered":
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "Email already registered",
+			})
+		case "user not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "User not found",
+			})
+		case "failed to send confirmation mail":
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to send confirmation mail",
+			})
+		default:
+			utils.LogError(c, "Error requesting email change: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to request email change",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Requested email change successfully")
+	return c.JSON(http.StatusAccepted, map[string]string{
+		"message": "Confirmation mail has been sent to the new email",
+	})
+}
+
+// メールアドレス変更のトークンでメールアドレスを変更する(ログイン不要)
+func (h *UserHandler) ConfirmEmailChange(c echo.Context) error {
+	utils.LogInfo(c, "Confirming email change...")
+
+	// JSONのリクエストボディからtokenを取得
+	type RequestBody struct {
+		Token string `json:"token"`
+	}
+
+	// リクエストボディをバインド
+	var reqBody RequestBody
+	if err := c.Bind(&reqBody); err != nil {
+		utils.LogError(c, "Failed to bind request body: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層でメールアドレスを変更
+	user, err := h.UserService.ConfirmEmailChange(c.Request().Context(), reqBody.Token)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "token is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Token is required",
+			})
+		case "invalid email change token":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid or expired token",
+			})
+		case "email already registered":
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "Email already registered",
+			})
+		default:
+			utils.LogError(c, "Error confirming email change: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to change email",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Confirmed email change successfully")
+	return c.JSON(http.StatusOK, models.NewUserProfile(user))
+}
@@ -0,0 +1,130 @@
+package handlers_users
+
+import (
+	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// パスワード再設定のリンクをメールで送信する(ログイン不要)
+// 登録済みのメールアドレスかどうかを判別できないよう、未登録の場合も同じレスポンスを返す。
+func (h *UserHandler) ForgotPassword(c echo.Context) error {
+	utils.LogInfo(c, "Requesting password reset...")
+
+	// JSONのリクエストボディからemailを取得
+	type RequestBody struct {
+		Email string `json:"email"`
+	}
+
+	// リクエストボディをバインド
+	var reqBody RequestBody
+	if err := c.Bind(&reqBody); err != nil {
+		utils.LogError(c, "Failed to bind request body: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層で再設定のリンクを送信
+	retryAfter, err := h.UserService.ForgotPassword(c.Request().Context(), c.RealIP(), reqBody.Email)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "email is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Email is required",
+			})
+		case "invalid email format":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid email format",
+			})
+		case "too many requests":
+			return tooManyResetRequestsResponse(c, retryAfter)
+		default:
+			utils.LogError(c, "Error requesting password reset: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to request password reset",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Accepted password reset request")
+	return c.JSON(http.StatusAccepted, map[string]string{
+		"message": "If the email is registered, a password reset link has been sent",
+	})
+}
+
+// パスワード再設定の申請が多すぎる場合のレスポンスを返す
+// Retry-After ヘッダーに、次に申請できるまでの秒数(切り上げ)を設定する。
+func tooManyResetRequestsResponse(c echo.Context, retryAfter time.Duration) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+	return c.JSON(http.StatusTooManyRequests, map[string]string{
+		"error": "Too many requests",
+	})
+}
+
+// パスワード再設定のトークンで新しいパスワードを設定する(ログイン不要)
+// 再設定すると、すべての端末のログインセッションが失効する。
+func (h *UserHandler) ResetPassword(c echo.Context) error {
+	utils.LogInfo(c, "Resetting password...")
+
+	// JSONのリクエストボディからtoken, newPasswordを取得
+	type RequestBody struct {
+		Token       string `json:"token"`
+		NewPassword string `json:"newPassword"`
+	}
+
+	// リクエストボディをバインド
+	var reqBody RequestBody
+	if err := c.Bind(&reqBody); err != nil {
+		utils.LogError(c, "Failed to bind request body: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層でパスワードを再設定
+	err := h.UserService.ResetPassword(c.Request().Context(), reqBody.Token, reqBody.NewPassword)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "token is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Token is required",
+			})
+		case "password is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Password is required",
+			})
+		case "invalid password length":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Password must be between 8 and 128 characters",
+			})
+		case "invalid reset token":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid or expired token",
+			})
+		default:
+			utils.LogError(c, "Error resetting password: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to reset password",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Reset password successfully")
+	return c.NoContent(http.StatusNoContent)
+}
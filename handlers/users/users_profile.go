@@ -0,0 +1,158 @@
+package handlers_users
+
+import (
+	"backend/models"
+	utils_auth "backend/utils/auth"
+	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ログイン中のユーザーのプロフィール(名前・自己紹介・アバター画像のURL)を更新する
+// リクエストボディで省略した項目は変更しない。メールアドレス・パスワードは別のエンドポイントで変更する。
+func (h *UserHandler) UpdateProfile(c echo.Context) error {
+	utils.LogInfo(c, "Updating user profile...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// JSONのリクエストボディからname, bio, avatarUrlを取得(省略した項目はnil)
+	type RequestBody struct {
+		Name      *string `json:"name"`
+		Bio       *string `json:"bio"`
+		AvatarURL *string `json:"avatarUrl"`
+	}
+
+	// リクエストボディをバインド
+	var reqBody RequestBody
+	if err := c.Bind(&reqBody); err != nil {
+		utils.LogError(c, "Failed to bind request body: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層でプロフィールを更新
+	user, err := h.UserService.UpdateProfile(c.Request().Context(), userId, reqBody.Name, reqBody.Bio, reqBody.AvatarURL)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "no fields to update":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "No fields to update",
+			})
+		case "name is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Name is required",
+			})
+		case "name is too long":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Name is too long",
+			})
+		case "bio is too long":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Bio is too long",
+			})
+		case "invalid avatar url":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid avatar URL",
+			})
+		case "user not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "User not found",
+			})
+		default:
+			utils.LogError(c, "Error updating user profile: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to update profile",
+			})
+		}
+	}
+
+	// アクセストークンの有効期限を設定
+	expirationTime := h.CookieUtils.GetAuthCookieExpirationTime()
+	// トークンの再作成(クレームのユーザー名を更新する)
+	tokenString, err := h.CookieUtils.CreateToken(user)
+	if err != nil {
+		utils.LogError(c, "Error creating token: "+err.Error())
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to create token",
+		})
+	}
+	// トークンをクッキーに更新
+	h.CookieUtils.UpdateAuthCookie(c, tokenString, expirationTime)
+
+	utils.LogInfo(c, "Updated user profile successfully")
+	return c.JSON(http.StatusOK, models.NewUserProfile(user))
+}
+
+// 現在のパスワードを確認し、ログイン中のユーザーのパスワードを変更する
+func (h *UserHandler) ChangePassword(c echo.Context) error {
+	utils.LogInfo(c, "Changing password...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// JSONのリクエストボディからpassword, newPasswordを取得
+	type RequestBody struct {
+		Password    string `json:"password"`
+		NewPassword string `json:"newPassword"`
+	}
+
+	// リクエストボディをバインド
+	var reqBody RequestBody
+	if err := c.Bind(&reqBody); err != nil {
+		utils.LogError(c, "Failed to bind request body: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層でパスワードを変更
+	err := h.UserService.ChangePassword(c.Request().Context(), userId, reqBody.Password, reqBody.NewPassword)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "password is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Password is required",
+			})
+		case "new password is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "New password is required",
+			})
+		case "invalid password length":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Password must be between 8 and 128 characters",
+			})
+		case "invalid current password":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid current password",
+			})
+		case "user not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "User not found",
+			})
+		default:
+			utils.LogError(c, "Error changing password: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to change password",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Changed password successfully")
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,161 @@
+package handlers_users
+
+import (
+	"backend/models"
+	utils_auth "backend/utils/auth"
+	utils "backend/utils/log"
+	utils_timeout "backend/utils/timeout"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ユーザーを登録し、メールアドレスの確認メールを送信する(ログイン不要)
+// 登録後はログインできるが、メールアドレスを確認するまでブログは投稿できない。
+func (h *UserHandler) Register(c echo.Context) error {
+	utils.LogInfo(c, "Registering user...")
+
+	// JSONのリクエストボディからname, email, passwordを取得
+	type RequestBody struct {
+		Name     string `json:"name"`
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+
+	// リクエストボディをバインド
+	var reqBody RequestBody
+	if err := c.Bind(&reqBody); err != nil {
+		utils.LogError(c, "Failed to bind request body: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層でユーザーを登録
+	user, err := h.UserService.Register(c.Request().Context(), reqBody.Name, reqBody.Email, reqBody.Password)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "name is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Name is required",
+			})
+		case "name is too long":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Name is too long",
+			})
+		case "email is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Email is required",
+			})
+		case "password is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Password is required",
+			})
+		case "invalid email format":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid email format",
+			})
+		case "invalid password length":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Password must be between 8 and 128 characters",
+			})
+		case "email already registered":
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "Email already registered",
+			})
+		default:
+			utils.LogError(c, "Error registering user: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to register user",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Registered user successfully")
+	return c.JSON(http.StatusCreated, models.NewUserProfile(user))
+}
+
+// 確認メールのトークンでメールアドレスを確認する(ログイン不要)
+func (h *UserHandler) VerifyEmail(c echo.Context) error {
+	utils.LogInfo(c, "Verifying email...")
+
+	// JSONのリクエストボディからtokenを取得
+	type RequestBody struct {
+		Token string `json:"token"`
+	}
+
+	// リクエストボディをバインド
+	var reqBody RequestBody
+	if err := c.Bind(&reqBody); err != nil {
+		utils.LogError(c, "Failed to bind request body: "+err.Error())
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	// サービス層でメールアドレスを確認
+	user, err := h.UserService.VerifyEmail(c.Request().Context(), reqBody.Token)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "token is required":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Token is required",
+			})
+		case "invalid verification token":
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid or expired token",
+			})
+		default:
+			utils.LogError(c, "Error verifying email: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to verify email",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Verified email successfully")
+	return c.JSON(http.StatusOK, models.NewUserProfile(user))
+}
+
+// ログイン中のユーザーに確認メールを再送する
+func (h *UserHandler) ResendVerification(c echo.Context) error {
+	utils.LogInfo(c, "Resending verification mail...")
+
+	// ログイン中のユーザーIDを取得(認証ミドルウェアで検証済み)
+	userId, ok := utils_auth.UserId(c)
+	if !ok {
+		return utils_auth.UnauthorizedResponse(c)
+	}
+
+	// サービス層で確認メールを再送
+	err := h.UserService.ResendVerification(c.Request().Context(), userId)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return utils_timeout.TimeoutResponse(c, err)
+		}
+		switch err.Error() {
+		case "user not found":
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "User not found",
+			})
+		case "email already verified":
+			return c.JSON(http.StatusConflict, map[string]string{
+				"error": "Email already verified",
+			})
+		default:
+			utils.LogError(c, "Error resending verification mail: "+err.Error())
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to send verification mail",
+			})
+		}
+	}
+
+	utils.LogInfo(c, "Resent verification mail successfully")
+	return c.NoContent(http.StatusNoContent)
+}
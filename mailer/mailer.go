@@ -0,0 +1,83 @@
+package mailer
+
+import (
+	"backend/logger"
+	"bytes"
+	"context"
+	"errors"
+	"mime"
+	"mime/quotedprintable"
+	"os"
+	"strings"
+	"time"
+)
+
+// 送信するメール(本文はプレーンテキスト)
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// メールの送信方法を切り替えるためのインターフェース
+// 本番ではSMTPで送信し、開発・テストでは送信せずにファイル・メモリ上の送信箱に保存する。
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// ヘッダーに改行が含まれる場合のエラー(ヘッダーの挿入を防ぐ)
+var ErrInvalidHeader = errors.New("mail header must not contain line breaks")
+
+// 環境変数に応じてメールの送信方法を初期化する
+// SMTP_HOST が設定されている場合はSMTPで送信し、それ以外は MAIL_OUTBOX_DIR(既定値は tmp/mail)にファイルとして保存する。
+func NewFromEnv() Mailer {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "no-reply@localhost"
+	}
+
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		port := os.Getenv("SMTP_PORT")
+		if port == "" {
+			port = "587"
+		}
+		logger.InfoLog.Printf("Using SMTP mailer: %s:%s", host, port)
+		return NewSMTPMailer(host, port, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), from)
+	}
+
+	dir := os.Getenv("MAIL_OUTBOX_DIR")
+	if dir == "" {
+		dir = "tmp/mail"
+	}
+	logger.WarnLog.Printf("SMTP_HOST is not set, mails are saved to %s instead of being sent", dir)
+	return NewOutbox(dir, from)
+}
+
+// メールをRFC 5322の形式に変換する
+// 件名はMIMEエンコードし、本文はUTF-8のquoted-printableとする。
+func buildMessage(from string, msg Message, date time.Time) ([]byte, error) {
+	for _, header := range []string{from, msg.To, msg.Subject} {
+		if strings.ContainsAny(header, "\r\n") {
+			return nil, ErrInvalidHeader
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("From: " + from + "\r\n")
+	buf.WriteString("To: " + msg.To + "\r\n")
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	buf.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(strings.ReplaceAll(msg.Body, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// メールを送信せずに保存する送信箱(開発・テスト用)
+// 送信したメールはメモリ上に保持し、Dir が指定されている場合は .eml ファイルとしても保存する。
+type Outbox struct {
+	Dir  string
+	From string
+
+	mu       sync.Mutex
+	messages []Message
+}
+
+// Mailerインターフェースを送信箱で実装したOutboxのポインタを返す
+// dir が空の場合はファイルに保存せず、メモリ上にのみ保持する。
+func NewOutbox(dir, from string) *Outbox {
+	return &Outbox{
+		Dir:  dir,
+		From: from,
+	}
+}
+
+// メールを送信箱に保存する
+func (o *Outbox) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	data, err := buildMessage(o.From, msg, now)
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.Dir != "" {
+		if err := os.MkdirAll(o.Dir, 0o755); err != nil {
+			return err
+		}
+		name := fmt.Sprintf("%s-%03d.eml", now.Format("20060102T150405.000000000"), len(o.messages))
+		if err := os.WriteFile(filepath.Join(o.Dir, name), data, 0o600); err != nil {
+			return err
+		}
+	}
+	o.messages = append(o.messages, msg)
+	return nil
+}
+
+// 保存したメールを送信順に返す
+func (o *Outbox) Messages() []Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	messages := make([]Message, len(o.messages))
+	copy(messages, o.messages)
+	return messages
+}
@@ -0,0 +1,74 @@
+package mailer
+
+import (
+	"context"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMessage(t *testing.T) {
+	date := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	data, err := buildMessage("no-reply@example.com", Message{
+		To:      "user@example.com",
+		Subject: "メールアドレスの確認",
+		Body:    "こんにちは\nhttps://example.com/verify-email?token=abc",
+	}, date)
+	assert.NoError(t, err)
+
+	// 標準ライブラリで読み取れる形式であること
+	parsed, err := mail.ReadMessage(strings.NewReader(string(data)))
+	assert.NoError(t, err)
+	assert.Equal(t, "user@example.com", parsed.Header.Get("To"))
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	assert.NoError(t, err)
+	assert.Equal(t, "メールアドレスの確認", subject)
+	assert.Equal(t, "quoted-printable", parsed.Header.Get("Content-Transfer-Encoding"))
+}
+
+func TestBuildMessage_HeaderInjection(t *testing.T) {
+	// 改行を含むヘッダーは受け付けない
+	for _, msg := range []Message{
+		{To: "user@example.com\r\nBcc: other@example.com", Subject: "subject"},
+		{To: "user@example.com", Subject: "subject\nBcc: other@example.com"},
+	} {
+		_, err := buildMessage("no-reply@example.com", msg, time.Now())
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	}
+}
+
+func TestOutbox(t *testing.T) {
+	dir := t.TempDir()
+	outbox := NewOutbox(dir, "no-reply@example.com")
+
+	msg := Message{To: "user@example.com", Subject: "subject", Body: "body"}
+	assert.NoError(t, outbox.Send(context.Background(), msg))
+	assert.NoError(t, outbox.Send(context.Background(), msg))
+
+	// メモリ上とディレクトリの両方に保存されること
+	assert.Equal(t, []Message{msg, msg}, outbox.Messages())
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+	data, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "To: user@example.com")
+
+	// キャンセル済みのコンテキストでは保存しない
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, outbox.Send(ctx, msg))
+	assert.Len(t, outbox.Messages(), 2)
+}
+
+func TestOutbox_MemoryOnly(t *testing.T) {
+	outbox := NewOutbox("", "no-reply@example.com")
+	assert.NoError(t, outbox.Send(context.Background(), Message{To: "user@example.com"}))
+	assert.Len(t, outbox.Messages(), 1)
+}
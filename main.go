@@ -1,11 +1,14 @@
 package main
 
 import (
+	"backend/config"
 	"backend/logger"
 	"backend/middlewares"
+	"backend/migrations"
 	"backend/routes"
 	"backend/supabase"
 
+	"context"
 	"log"
 	"net/http"
 	"os"
@@ -16,8 +19,8 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-// セットアップ
-func firstSetup() {
+// 環境変数とログ設定の読み込み
+func loadEnv() {
 	// 環境変数の読み込み
 	err := godotenv.Load()
 	if err != nil {
@@ -26,9 +29,21 @@ func firstSetup() {
 
 	// ログ設定の初期化
 	logger.InitLogger()
+}
+
+// セットアップ
+func firstSetup() {
+	// 環境変数の読み込み
+	loadEnv()
+
+	// インメモリドライバの場合はデータベースに接続しない
+	if config.IsMemoryDriver() {
+		logger.InfoLog.Println("DB_DRIVER=memory: skipping Supabase initialization")
+		return
+	}
 
 	// Supabaseクライアントの初期化
-	err = supabase.InitSupabase()
+	err := supabase.InitSupabase()
 	if err != nil {
 		logger.ErrorLog.Fatalf("Supabase initialization failed: %v", err)
 	}
@@ -38,10 +53,36 @@ func firstSetup() {
 		logger.ErrorLog.Fatalf("Test query failed: %v", err)
 	}
 
+	// 未適用のマイグレーションがある場合は起動しない
+	ctx, cancel := supabase.WithQueryTimeout(context.Background())
+	defer cancel()
+	pending, err := migrations.Pending(ctx, supabase.Pool)
+	if err != nil {
+		logger.ErrorLog.Fatalf("Failed to check migrations: %v", err)
+	}
+	if len(pending) > 0 {
+		logger.ErrorLog.Fatalf("%d pending migrations found. Run `migrate up` before starting the server", len(pending))
+	}
 }
 
 // Mainプロセス
 func main() {
+	// サブコマンドの実行
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		loadEnv()
+		if err := runMigrate(os.Args[2:]); err != nil {
+			logger.ErrorLog.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		loadEnv()
+		if err := runReconcile(os.Args[2:]); err != nil {
+			logger.ErrorLog.Fatalf("Reconciliation failed: %v", err)
+		}
+		return
+	}
+
 	// セットアップ
 	firstSetup()
 
@@ -51,7 +92,10 @@ func main() {
 	// ミドルウェアの設定
 	middlewares.SetupMiddlewares(e)
 	// ルーティングの設定
-	routes.SetupRoutes(e)
+	// バックグラウンドジョブはシャットダウン時にキャンセルする
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	routes.SetupRoutes(ctx, e)
 
 	// シグナルハンドラーの設定
 	quit := make(chan os.Signal, 1)
@@ -61,6 +105,9 @@ func main() {
 		<-quit
 		logger.InfoLog.Println("Shutting down server...")
 
+		// バックグラウンドジョブの停止
+		cancel()
+
 		// Echoサーバーのシャットダウン
 		if err := e.Close(); err != nil {
 			logger.ErrorLog.Printf("Echo shutdown failed: %v", err)
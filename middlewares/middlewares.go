@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"backend/config"
 	"os"
 	"strings"
 
@@ -10,17 +11,25 @@ import (
 
 // ミドルウェアの設定
 func SetupMiddlewares(e *echo.Echo) {
+	// クライアントのIPアドレスの取得方法(ログインの試行の制限に使用する)
+	// X-Forwarded-For ヘッダーは、ループバック・プライベートネットワーク上のプロキシから付与されたもののみ信頼する。
+	e.IPExtractor = echo.ExtractIPFromXFFHeader()
+
 	// ロガーとリカバリーミドルウェアを使用
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 
+	// リクエスト単位のタイムアウトを設定
+	// ハンドラ以下の各層にはリクエストのコンテキストが渡され、期限を過ぎるとクエリは中断される。
+	e.Use(middleware.ContextTimeout(config.RequestTimeout()))
+
 	allowedOrigins := os.Getenv("ALLOWED_ORIGINS")
 
 	// CORSを有効化
 	// AllowCredentialsをtrueに設定すると、クライアント側でwithCredentialsをtrueに設定する必要がある
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: strings.Split(allowedOrigins, ","),
-		AllowMethods: []string{echo.GET, echo.POST, echo.PUT, echo.DELETE},
+		AllowMethods: []string{echo.GET, echo.POST, echo.PUT, echo.PATCH, echo.DELETE},
 		AllowHeaders: []string{
 			echo.HeaderOrigin,
 			echo.HeaderContentType,
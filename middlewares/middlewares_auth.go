@@ -0,0 +1,59 @@
+package middlewares
+
+import (
+	utils_auth "backend/utils/auth"
+	utils "backend/utils/log"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ログインが必要なルートグループに適用するミドルウェア
+// クッキーまたは Authorization: Bearer ヘッダーのアクセストークンを検証し、クレームをコンテキストに保存する。
+// トークンがない・不正・期限切れの場合は、ハンドラを呼ばずに401を返す。
+func RequireAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// 上位のグループで検証済みの場合はそのまま使用する
+			if _, ok := utils_auth.Principal(c); ok {
+				return next(c)
+			}
+
+			tokenString := utils_auth.TokenFromRequest(c)
+			if tokenString == "" {
+				utils.LogError(c, "Access token not found")
+				return utils_auth.UnauthorizedResponse(c)
+			}
+
+			claims, err := utils_auth.ParseToken(tokenString)
+			if err != nil {
+				utils.LogError(c, "Invalid access token: "+err.Error())
+				return utils_auth.UnauthorizedResponse(c)
+			}
+
+			utils_auth.SetPrincipal(c, claims)
+			return next(c)
+		}
+	}
+}
+
+// ログインしていなくても利用できるルートグループに適用するミドルウェア
+// 有効なアクセストークンがあればクレームをコンテキストに保存し、なければ未ログインとして扱う。
+func OptionalAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			tokenString := utils_auth.TokenFromRequest(c)
+			if tokenString == "" {
+				return next(c)
+			}
+
+			claims, err := utils_auth.ParseToken(tokenString)
+			if err != nil {
+				utils.LogError(c, "Ignoring invalid access token: "+err.Error())
+				return next(c)
+			}
+
+			utils_auth.SetPrincipal(c, claims)
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package middlewares
+
+import (
+	"backend/config"
+	"backend/models"
+	utils_auth "backend/utils/auth"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// テスト用のアクセストークンを作成する
+func signToken(t *testing.T, expiresAt time.Time) string {
+	claims := &models.Claims{
+		UserID: "user-1",
+		Email:  "test@example.com",
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: expiresAt.Unix(),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(config.JwtKey)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return token
+}
+
+// ミドルウェアを通してハンドラーを実行し、ハンドラーが受け取ったユーザーIDを返す
+func serve(t *testing.T, m echo.MiddlewareFunc, req *http.Request) (*httptest.ResponseRecorder, bool, string) {
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+
+	called := false
+	var userId string
+	handler := func(c echo.Context) error {
+		called = true
+		userId, _ = utils_auth.UserId(c)
+		return c.NoContent(http.StatusOK)
+	}
+
+	assert.NoError(t, m(handler)(c))
+	return rec, called, userId
+}
+
+func TestRequireAuth(t *testing.T) {
+	tests := []struct {
+		name   string
+		cookie string
+		header string
+		ok     bool
+	}{
+		{name: "クッキーのトークン", cookie: signToken(t, time.Now().Add(time.Minute)), ok: true},
+		{name: "Bearerヘッダーのトークン", header: "Bearer " + signToken(t, time.Now().Add(time.Minute)), ok: true},
+		{name: "トークンなし"},
+		{name: "不正なトークン", cookie: "invalid-token"},
+		{name: "期限切れのトークン", header: "Bearer " + signToken(t, time.Now().Add(-time.Minute))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: utils_auth.TokenCookieName, Value: tt.cookie})
+			}
+			if tt.header != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.header)
+			}
+
+			rec, called, userId := serve(t, RequireAuth(), req)
+
+			if tt.ok {
+				assert.True(t, called)
+				assert.Equal(t, "user-1", userId)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				return
+			}
+			// 理由にかかわらず同じ401を返し、ハンドラーは呼ばれない
+			assert.False(t, called)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.JSONEq(t, `{"error":"Unauthorized"}`, rec.Body.String())
+		})
+	}
+}
+
+func TestOptionalAuth(t *testing.T) {
+	tests := []struct {
+		name   string
+		cookie string
+		userId string
+	}{
+		{name: "有効なトークン", cookie: signToken(t, time.Now().Add(time.Minute)), userId: "user-1"},
+		{name: "トークンなし"},
+		{name: "期限切れのトークン", cookie: signToken(t, time.Now().Add(-time.Minute))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: utils_auth.TokenCookieName, Value: tt.cookie})
+			}
+
+			// 未ログインとして扱い、ハンドラーは常に呼ばれる
+			rec, called, userId := serve(t, OptionalAuth(), req)
+			assert.True(t, called)
+			assert.Equal(t, tt.userId, userId)
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"backend/config"
+	"backend/migrations"
+	"backend/supabase"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// マイグレーションのサブコマンドを実行する
+// migrate up      : 未適用のマイグレーションをすべて適用
+// migrate down N  : 適用済みのマイグレーションを新しいものからN件ロールバック
+// migrate status  : 各マイグレーションの適用状況を表示
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: migrate up | migrate down N | migrate status")
+	}
+	if config.IsMemoryDriver() {
+		return errors.New("migrate is not available with DB_DRIVER=memory")
+	}
+
+	// Supabaseクライアントの初期化
+	if err := supabase.InitSupabase(); err != nil {
+		return err
+	}
+	defer supabase.ClosePool()
+
+	// マイグレーションは長時間かかる可能性があるため、クエリ単位のタイムアウトは設定しない
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrations.Up(ctx, supabase.Pool)
+		for _, m := range applied {
+			fmt.Printf("applied  %04d_%s\n", m.Version, m.Name)
+		}
+		return err
+	case "down":
+		if len(args) < 2 {
+			return errors.New("usage: migrate down N")
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid number of migrations: %s", args[1])
+		}
+		rolledBack, err := migrations.Down(ctx, supabase.Pool, n)
+		for _, m := range rolledBack {
+			fmt.Printf("reverted %04d_%s\n", m.Version, m.Name)
+		}
+		return err
+	case "status":
+		statuses, err := migrations.StatusList(ctx, supabase.Pool)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			if s.Applied {
+				fmt.Printf("applied  %04d_%s (%s)\n", s.Version, s.Name, s.AppliedAt.Format("2006-01-02 15:04:05"))
+			} else {
+				fmt.Printf("pending  %04d_%s\n", s.Version, s.Name)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate command: %s", args[0])
+	}
+}
@@ -0,0 +1,307 @@
+package migrations
+
+import (
+	"backend/logger"
+	"backend/supabase"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// バイナリに埋め込むマイグレーションファイル
+// ファイル名は "<バージョン>_<名前>.up.sql" / "<バージョン>_<名前>.down.sql" とする。
+//
+//go:embed sql/*.sql
+var files embed.FS
+
+// 複数インスタンスから同時に実行された場合に直列化するためのアドバイザリロックキー
+const advisoryLockKey = 7_426_151_001
+
+// マイグレーションの情報
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// マイグレーションの適用状況
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// 埋め込まれたマイグレーションをバージョンの昇順で読み込む
+func Load() ([]Migration, error) {
+	entries, err := files.ReadDir("sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		version, name, direction, err := parseFileName(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := files.ReadFile(path.Join("sql", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("migration %d has conflicting names: %s, %s", version, m.Name, name)
+		}
+
+		switch direction {
+		case "up":
+			m.Up = string(body)
+		case "down":
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// ファイル名からバージョン・名前・方向(up/down)を取得する
+func parseFileName(fileName string) (int64, string, string, error) {
+	base := strings.TrimSuffix(fileName, ".sql")
+	if base == fileName {
+		return 0, "", "", fmt.Errorf("invalid migration file name: %s", fileName)
+	}
+
+	dot := strings.LastIndex(base, ".")
+	if dot < 0 {
+		return 0, "", "", fmt.Errorf("invalid migration file name: %s", fileName)
+	}
+	direction := base[dot+1:]
+	if direction != "up" && direction != "down" {
+		return 0, "", "", fmt.Errorf("invalid migration direction: %s", fileName)
+	}
+
+	versionAndName := strings.SplitN(base[:dot], "_", 2)
+	if len(versionAndName) != 2 || versionAndName[1] == "" {
+		return 0, "", "", fmt.Errorf("invalid migration file name: %s", fileName)
+	}
+	version, err := strconv.ParseInt(versionAndName[0], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, "", "", fmt.Errorf("invalid migration version: %s", fileName)
+	}
+
+	return version, versionAndName[1], direction, nil
+}
+
+// schema_migrations テーブルを作成する
+func ensureTable(ctx context.Context, db supabase.DB) error {
+	_, err := db.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    BIGINT PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)
+	`)
+	return err
+}
+
+// 適用済みのバージョンと適用日時を取得する
+// schema_migrations テーブルが存在しない場合は空の結果を返す。
+func appliedVersions(ctx context.Context, db supabase.DB) (map[int64]time.Time, error) {
+	var exists bool
+	err := db.QueryRow(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]time.Time)
+	if !exists {
+		return applied, nil
+	}
+
+	rows, err := db.Query(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+// 各マイグレーションの適用状況を取得する
+func StatusList(ctx context.Context, db supabase.DB) ([]Status, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+	for _, m := range migrations {
+		appliedAt, ok := applied[m.Version]
+		statuses = append(statuses, Status{
+			Migration: m,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		})
+	}
+	return statuses, nil
+}
+
+// 未適用のマイグレーションを取得する
+func Pending(ctx context.Context, db supabase.DB) ([]Migration, error) {
+	statuses, err := StatusList(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, s := range statuses {
+		if !s.Applied {
+			pending = append(pending, s.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// 未適用のマイグレーションをすべて適用する
+// 各マイグレーションは個別のトランザクションで実行し、適用済みの記録も同じトランザクションで行う。
+func Up(ctx context.Context, db supabase.DB) ([]Migration, error) {
+	if err := ensureTable(ctx, db); err != nil {
+		return nil, err
+	}
+
+	pending, err := Pending(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, m := range pending {
+		logger.InfoLog.Printf("Applying migration %d_%s...", m.Version, m.Name)
+		applied, err := runInTx(ctx, db, m.Version, true, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, m.Up); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.Version, m.Name)
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d_%s failed: %w", m.Version, m.Name, err)
+		}
+		if applied {
+			done = append(done, m)
+		}
+	}
+
+	logger.InfoLog.Printf("Applied %d migrations", len(done))
+	return done, nil
+}
+
+// 適用済みのマイグレーションを新しいものから n 件ロールバックする
+func Down(ctx context.Context, db supabase.DB, n int) ([]Migration, error) {
+	if n <= 0 {
+		return nil, errors.New("number of migrations to roll back must be positive")
+	}
+
+	statuses, err := StatusList(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []Migration
+	for i := len(statuses) - 1; i >= 0 && len(targets) < n; i-- {
+		if statuses[i].Applied {
+			targets = append(targets, statuses[i].Migration)
+		}
+	}
+
+	var done []Migration
+	for _, m := range targets {
+		logger.InfoLog.Printf("Rolling back migration %d_%s...", m.Version, m.Name)
+		rolledBack, err := runInTx(ctx, db, m.Version, false, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, m.Down); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, m.Version)
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("rollback %d_%s failed: %w", m.Version, m.Name, err)
+		}
+		if rolledBack {
+			done = append(done, m)
+		}
+	}
+
+	logger.InfoLog.Printf("Rolled back %d migrations", len(done))
+	return done, nil
+}
+
+// アドバイザリロックを取得したトランザクション内で処理を実行する
+// ロック取得後に適用状況を再確認し、他のインスタンスが既に処理済みの場合は何もせずfalseを返す。
+func runInTx(ctx context.Context, db supabase.DB, version int64, wantPending bool, fn func(tx pgx.Tx) error) (bool, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, advisoryLockKey); err != nil {
+		return false, err
+	}
+
+	var applied bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, version).Scan(&applied)
+	if err != nil {
+		return false, err
+	}
+	if applied == wantPending {
+		return false, nil
+	}
+
+	if err := fn(tx); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	// 埋め込まれたマイグレーションを読み込む
+	migrations, err := Load()
+
+	// エラーチェックとデータ確認
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+	for i, m := range migrations {
+		assert.NotEmpty(t, m.Up)
+		assert.NotEmpty(t, m.Down)
+		if i > 0 {
+			assert.Greater(t, m.Version, migrations[i-1].Version)
+		}
+	}
+}
+
+func TestParseFileName(t *testing.T) {
+	// 正常なファイル名
+	version, name, direction, err := parseFileName("0002_create_blogs.up.sql")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), version)
+	assert.Equal(t, "create_blogs", name)
+	assert.Equal(t, "up", direction)
+
+	version, name, direction, err = parseFileName("0010_add_index.down.sql")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), version)
+	assert.Equal(t, "add_index", name)
+	assert.Equal(t, "down", direction)
+}
+
+func TestParseFileName_Invalid(t *testing.T) {
+	// 不正なファイル名
+	invalid := []string{
+		"create_blogs.up.sql",
+		"0001_create_blogs.sql",
+		"0001_create_blogs.sideways.sql",
+		"0001.up.sql",
+		"abcd_create_blogs.up.sql",
+		"0001_create_blogs.up.txt",
+	}
+	for _, fileName := range invalid {
+		_, _, _, err := parseFileName(fileName)
+		assert.Error(t, err, fileName)
+	}
+}
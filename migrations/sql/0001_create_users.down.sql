@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS users_set_updated_at ON users;
+DROP TABLE IF EXISTS users;
+DROP FUNCTION IF EXISTS set_updated_at();
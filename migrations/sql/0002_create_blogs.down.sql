@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS blogs_set_updated_at ON blogs;
+DROP TABLE IF EXISTS blogs;
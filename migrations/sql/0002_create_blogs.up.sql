@@ -0,0 +1,22 @@
+-- ブログテーブル
+CREATE TABLE IF NOT EXISTS blogs (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     UUID NOT NULL REFERENCES users (id),
+    title       TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    github_url  TEXT NOT NULL DEFAULT '',
+    category    TEXT NOT NULL DEFAULT '',
+    tags        TEXT NOT NULL DEFAULT '',
+    likes       INTEGER NOT NULL DEFAULT 0,
+    comment_cnt INTEGER NOT NULL DEFAULT 0,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS blogs_user_id_idx ON blogs (user_id);
+CREATE INDEX IF NOT EXISTS blogs_created_at_idx ON blogs (created_at DESC);
+
+DROP TRIGGER IF EXISTS blogs_set_updated_at ON blogs;
+CREATE TRIGGER blogs_set_updated_at
+    BEFORE UPDATE ON blogs
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS blogs_likes_set_updated_at ON blogs_likes;
+DROP TABLE IF EXISTS blogs_likes;
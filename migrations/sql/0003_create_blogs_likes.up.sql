@@ -0,0 +1,16 @@
+-- ブログいいねテーブル
+CREATE TABLE IF NOT EXISTS blogs_likes (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    blog_id    UUID NOT NULL REFERENCES blogs (id) ON DELETE CASCADE,
+    visit_id   UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (blog_id, visit_id)
+);
+
+CREATE INDEX IF NOT EXISTS blogs_likes_visit_id_idx ON blogs_likes (visit_id);
+
+DROP TRIGGER IF EXISTS blogs_likes_set_updated_at ON blogs_likes;
+CREATE TRIGGER blogs_likes_set_updated_at
+    BEFORE UPDATE ON blogs_likes
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
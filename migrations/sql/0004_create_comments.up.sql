@@ -0,0 +1,10 @@
+-- コメントテーブル
+CREATE TABLE IF NOT EXISTS comments (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    blog_id    UUID NOT NULL REFERENCES blogs (id) ON DELETE CASCADE,
+    guest_user TEXT NOT NULL,
+    comment    TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS comments_blog_id_idx ON comments (blog_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS blogs_category_created_at_idx;
+DROP INDEX IF EXISTS blogs_created_at_id_idx;
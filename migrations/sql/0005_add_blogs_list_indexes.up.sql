@@ -0,0 +1,3 @@
+-- ブログ一覧のカーソルページング・絞り込み用インデックス
+CREATE INDEX IF NOT EXISTS blogs_created_at_id_idx ON blogs (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS blogs_category_created_at_idx ON blogs (category, created_at DESC);
@@ -0,0 +1,4 @@
+-- pg_trgm 拡張は他で利用されている可能性があるため削除しない
+DROP INDEX IF EXISTS blogs_description_trgm_idx;
+DROP INDEX IF EXISTS blogs_tags_trgm_idx;
+DROP INDEX IF EXISTS blogs_title_trgm_idx;
@@ -0,0 +1,7 @@
+-- ブログ検索用のトライグラムインデックス
+-- 日本語は空白で単語が区切られないため、全文検索の辞書ではなく pg_trgm による部分一致で検索する。
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS blogs_title_trgm_idx ON blogs USING gin (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS blogs_tags_trgm_idx ON blogs USING gin (tags gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS blogs_description_trgm_idx ON blogs USING gin (description gin_trgm_ops);
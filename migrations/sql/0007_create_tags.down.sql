@@ -0,0 +1,4 @@
+-- blogs.tags の文字列は維持しているため、テーブルの削除のみ行う
+DROP TABLE IF EXISTS blog_tags;
+DROP TABLE IF EXISTS tag_aliases;
+DROP TABLE IF EXISTS tags;
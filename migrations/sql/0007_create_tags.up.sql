@@ -0,0 +1,55 @@
+-- タグテーブル
+CREATE TABLE IF NOT EXISTS tags (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- タグ名は大文字小文字を区別せずに一意とする
+CREATE UNIQUE INDEX IF NOT EXISTS tags_name_lower_idx ON tags (lower(name));
+
+DROP TRIGGER IF EXISTS tags_set_updated_at ON tags;
+CREATE TRIGGER tags_set_updated_at
+    BEFORE UPDATE ON tags
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- タグの別名テーブル(例: golang → Go)
+CREATE TABLE IF NOT EXISTS tag_aliases (
+    alias      TEXT NOT NULL,
+    tag_id     UUID NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tag_aliases_alias_lower_idx ON tag_aliases (lower(alias));
+CREATE INDEX IF NOT EXISTS tag_aliases_tag_id_idx ON tag_aliases (tag_id);
+
+-- ブログとタグの中間テーブル
+CREATE TABLE IF NOT EXISTS blog_tags (
+    blog_id  UUID NOT NULL REFERENCES blogs (id) ON DELETE CASCADE,
+    tag_id   UUID NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (blog_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS blog_tags_tag_id_idx ON blog_tags (tag_id);
+
+-- blogs.tags のカンマ区切りの文字列からタグを移行する
+INSERT INTO tags (name)
+SELECT DISTINCT ON (lower(s.name)) s.name
+FROM (
+    SELECT btrim(t.name) AS name
+    FROM blogs b
+    CROSS JOIN LATERAL unnest(string_to_array(b.tags, ',')) AS t(name)
+) s
+WHERE s.name <> ''
+ORDER BY lower(s.name), s.name
+ON CONFLICT DO NOTHING;
+
+INSERT INTO blog_tags (blog_id, tag_id, position)
+SELECT DISTINCT ON (b.id, tg.id) b.id, tg.id, x.ord
+FROM blogs b
+CROSS JOIN LATERAL unnest(string_to_array(b.tags, ',')) WITH ORDINALITY AS x(name, ord)
+JOIN tags tg ON lower(tg.name) = lower(btrim(x.name))
+ORDER BY b.id, tg.id, x.ord
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+-- blogs.category の文字列は維持しているため、制約とテーブルの削除のみ行う
+ALTER TABLE blogs DROP CONSTRAINT IF EXISTS blogs_category_fkey;
+ALTER TABLE blogs ALTER COLUMN category SET DEFAULT '';
+DROP TABLE IF EXISTS categories;
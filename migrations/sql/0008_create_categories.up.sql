@@ -0,0 +1,59 @@
+-- カテゴリテーブル
+CREATE TABLE IF NOT EXISTS categories (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name          TEXT NOT NULL UNIQUE,
+    slug          TEXT NOT NULL UNIQUE,
+    description   TEXT NOT NULL DEFAULT '',
+    display_order INTEGER NOT NULL DEFAULT 0,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- カテゴリ名は大文字小文字を区別せずに一意とする
+CREATE UNIQUE INDEX IF NOT EXISTS categories_name_lower_idx ON categories (lower(name));
+CREATE INDEX IF NOT EXISTS categories_display_order_idx ON categories (display_order, lower(name));
+
+DROP TRIGGER IF EXISTS categories_set_updated_at ON categories;
+CREATE TRIGGER categories_set_updated_at
+    BEFORE UPDATE ON categories
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- カテゴリが空のブログは「未分類」に移す
+UPDATE blogs SET category = '未分類' WHERE btrim(category) = '';
+
+-- blogs.category の文字列からカテゴリを移行する
+-- 大文字小文字のみ異なるカテゴリは1つにまとめ、スラッグが重複する場合は連番を付ける
+WITH names AS (
+    SELECT DISTINCT ON (lower(btrim(category))) btrim(category) AS name
+    FROM blogs
+    ORDER BY lower(btrim(category)), btrim(category)
+), slugs AS (
+    SELECT name,
+           COALESCE(
+               NULLIF(btrim(regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g'), '-'), ''),
+               'category'
+           ) AS base
+    FROM names
+), numbered AS (
+    SELECT name, base,
+           row_number() OVER (PARTITION BY base ORDER BY lower(name)) AS n,
+           row_number() OVER (ORDER BY lower(name)) AS ord
+    FROM slugs
+)
+INSERT INTO categories (name, slug, display_order)
+SELECT name, CASE WHEN n = 1 THEN base ELSE base || '-' || n END, ord
+FROM numbered
+ON CONFLICT DO NOTHING;
+
+UPDATE blogs b
+SET category = c.name
+FROM categories c
+WHERE lower(btrim(b.category)) = lower(c.name) AND b.category <> c.name;
+
+-- ブログのカテゴリは登録済みのカテゴリのみ許可する
+-- カテゴリ名を変更した場合は blogs.category も追従し、使用中のカテゴリは削除できない
+ALTER TABLE blogs ALTER COLUMN category DROP DEFAULT;
+ALTER TABLE blogs DROP CONSTRAINT IF EXISTS blogs_category_fkey;
+ALTER TABLE blogs
+    ADD CONSTRAINT blogs_category_fkey FOREIGN KEY (category)
+    REFERENCES categories (name) ON UPDATE CASCADE ON DELETE RESTRICT;
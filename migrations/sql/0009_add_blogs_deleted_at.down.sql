@@ -0,0 +1,5 @@
+-- ゴミ箱内のブログは復元できなくなるため、ロールバック前に完全削除する
+DELETE FROM blogs WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS blogs_deleted_at_idx;
+ALTER TABLE blogs DROP COLUMN IF EXISTS deleted_at;
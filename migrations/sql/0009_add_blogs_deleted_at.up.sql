@@ -0,0 +1,5 @@
+-- ブログの論理削除(ゴミ箱)
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- ゴミ箱の一覧と保持期間を過ぎたブログの完全削除に使用する
+CREATE INDEX IF NOT EXISTS blogs_deleted_at_idx ON blogs (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,15 @@
+-- ブログの版履歴テーブル
+-- 更新の直前の内容を保存する。ブログの完全削除時に合わせて削除する。
+CREATE TABLE IF NOT EXISTS blog_revisions (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    blog_id     UUID NOT NULL REFERENCES blogs (id) ON DELETE CASCADE,
+    revision    INTEGER NOT NULL,
+    title       TEXT NOT NULL,
+    description TEXT NOT NULL,
+    github_url  TEXT NOT NULL,
+    category    TEXT NOT NULL,
+    tags        TEXT NOT NULL,
+    author_id   UUID NOT NULL REFERENCES users (id),
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (blog_id, revision)
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS blogs_scheduled_published_at_idx;
+ALTER TABLE blogs DROP CONSTRAINT IF EXISTS blogs_published_at_check;
+ALTER TABLE blogs DROP CONSTRAINT IF EXISTS blogs_status_check;
+ALTER TABLE blogs DROP COLUMN IF EXISTS published_at;
+ALTER TABLE blogs DROP COLUMN IF EXISTS status;
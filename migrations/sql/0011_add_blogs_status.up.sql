@@ -0,0 +1,17 @@
+-- ブログの公開状態と公開日時
+-- 既存のブログは公開済みとし、作成日時を公開日時とする。新しいブログは下書きとして作成する。
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'published';
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS published_at TIMESTAMPTZ;
+
+UPDATE blogs SET published_at = created_at WHERE status = 'published' AND published_at IS NULL;
+
+ALTER TABLE blogs ALTER COLUMN status SET DEFAULT 'draft';
+
+-- 予約・公開済み・非公開のブログは公開日時を持つ
+ALTER TABLE blogs ADD CONSTRAINT blogs_status_check
+    CHECK (status IN ('draft', 'scheduled', 'published', 'unpublished'));
+ALTER TABLE blogs ADD CONSTRAINT blogs_published_at_check
+    CHECK (status = 'draft' OR published_at IS NOT NULL);
+
+-- 公開予約の定期実行で公開日時を過ぎたブログを探すために使用する
+CREATE INDEX IF NOT EXISTS blogs_scheduled_published_at_idx ON blogs (published_at) WHERE status = 'scheduled';
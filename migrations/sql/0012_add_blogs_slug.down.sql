@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS blog_slug_redirects;
+DROP INDEX IF EXISTS blogs_slug_key;
+ALTER TABLE blogs DROP CONSTRAINT IF EXISTS blogs_slug_check;
+ALTER TABLE blogs DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,31 @@
+-- ブログのパーマリンク用スラッグ
+-- 既存のブログはタイトルの英数字から生成し、英数字を含まない場合は 'post' とする。重複する場合は2件目以降にIDの先頭を付ける。
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS slug TEXT;
+
+WITH base AS (
+    SELECT id, created_at,
+           COALESCE(NULLIF(trim(BOTH '-' FROM left(regexp_replace(lower(title), '[^a-z0-9]+', '-', 'g'), 80)), ''), 'post') AS base
+    FROM blogs
+    WHERE slug IS NULL
+), numbered AS (
+    SELECT id, base, ROW_NUMBER() OVER (PARTITION BY base ORDER BY created_at, id) AS n
+    FROM base
+)
+UPDATE blogs b
+SET slug = CASE WHEN numbered.n = 1 THEN numbered.base ELSE numbered.base || '-' || left(replace(b.id::text, '-', ''), 8) END
+FROM numbered
+WHERE b.id = numbered.id;
+
+ALTER TABLE blogs ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE blogs ADD CONSTRAINT blogs_slug_check CHECK (slug ~ '^[a-z0-9]+(-[a-z0-9]+)*$');
+CREATE UNIQUE INDEX IF NOT EXISTS blogs_slug_key ON blogs (slug);
+
+-- 変更前のスラッグ
+-- 古いリンクを現在のスラッグへリダイレクトするために保持する。ブログの完全削除時に合わせて削除する。
+CREATE TABLE IF NOT EXISTS blog_slug_redirects (
+    slug       TEXT PRIMARY KEY,
+    blog_id    UUID NOT NULL REFERENCES blogs (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS blog_slug_redirects_blog_id_idx ON blog_slug_redirects (blog_id);
@@ -0,0 +1,2 @@
+ALTER TABLE blog_revisions DROP COLUMN IF EXISTS body_markdown;
+ALTER TABLE blogs DROP COLUMN IF EXISTS body_markdown;
@@ -0,0 +1,6 @@
+-- ブログの本文(Markdown)
+-- HTMLへの変換結果は保存せず、取得時にサーバー側で変換してキャッシュする。
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS body_markdown TEXT NOT NULL DEFAULT '';
+
+-- 版履歴にも本文を保存し、差分の表示と版の復元の対象にする
+ALTER TABLE blog_revisions ADD COLUMN IF NOT EXISTS body_markdown TEXT NOT NULL DEFAULT '';
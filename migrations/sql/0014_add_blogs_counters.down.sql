@@ -0,0 +1,19 @@
+DROP INDEX IF EXISTS blogs_comment_count_idx;
+DROP INDEX IF EXISTS blogs_like_count_idx;
+
+DROP TRIGGER IF EXISTS comments_update_comment_count ON comments;
+DROP FUNCTION IF EXISTS comments_update_comment_count();
+DROP TRIGGER IF EXISTS blogs_likes_update_like_count ON blogs_likes;
+DROP FUNCTION IF EXISTS blogs_likes_update_like_count();
+
+DROP TRIGGER IF EXISTS blogs_set_updated_at ON blogs;
+CREATE TRIGGER blogs_set_updated_at
+    BEFORE UPDATE ON blogs
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+ALTER TABLE blogs DROP CONSTRAINT IF EXISTS blogs_comment_count_check;
+ALTER TABLE blogs DROP CONSTRAINT IF EXISTS blogs_like_count_check;
+ALTER TABLE blogs ALTER COLUMN comment_count TYPE INTEGER;
+ALTER TABLE blogs ALTER COLUMN like_count TYPE INTEGER;
+ALTER TABLE blogs RENAME COLUMN comment_count TO comment_cnt;
+ALTER TABLE blogs RENAME COLUMN like_count TO likes;
@@ -0,0 +1,62 @@
+-- ブログのいいね数・コメント数
+-- 未使用だった likes, comment_cnt を集計値の列に置き換え、いいね・コメントの追加・削除時にトリガーで更新する。
+ALTER TABLE blogs RENAME COLUMN likes TO like_count;
+ALTER TABLE blogs RENAME COLUMN comment_cnt TO comment_count;
+ALTER TABLE blogs ALTER COLUMN like_count TYPE BIGINT;
+ALTER TABLE blogs ALTER COLUMN comment_count TYPE BIGINT;
+
+-- 既存のいいね・コメントから集計値を設定する
+UPDATE blogs b
+SET like_count = (SELECT COUNT(*) FROM blogs_likes l WHERE l.blog_id = b.id),
+    comment_count = (SELECT COUNT(*) FROM comments c WHERE c.blog_id = b.id);
+
+ALTER TABLE blogs ADD CONSTRAINT blogs_like_count_check CHECK (like_count >= 0);
+ALTER TABLE blogs ADD CONSTRAINT blogs_comment_count_check CHECK (comment_count >= 0);
+
+-- 集計値だけの更新では updated_at を更新しない
+DROP TRIGGER IF EXISTS blogs_set_updated_at ON blogs;
+CREATE TRIGGER blogs_set_updated_at
+    BEFORE UPDATE ON blogs
+    FOR EACH ROW
+    WHEN (OLD.like_count = NEW.like_count AND OLD.comment_count = NEW.comment_count)
+    EXECUTE FUNCTION set_updated_at();
+
+-- いいねの追加・削除に合わせて like_count を増減するトリガー関数
+CREATE OR REPLACE FUNCTION blogs_likes_update_like_count() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE blogs SET like_count = like_count + 1 WHERE id = NEW.blog_id;
+    ELSE
+        UPDATE blogs SET like_count = like_count - 1 WHERE id = OLD.blog_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS blogs_likes_update_like_count ON blogs_likes;
+CREATE TRIGGER blogs_likes_update_like_count
+    AFTER INSERT OR DELETE ON blogs_likes
+    FOR EACH ROW EXECUTE FUNCTION blogs_likes_update_like_count();
+
+-- コメントの追加・削除に合わせて comment_count を増減するトリガー関数
+CREATE OR REPLACE FUNCTION comments_update_comment_count() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE blogs SET comment_count = comment_count + 1 WHERE id = NEW.blog_id;
+    ELSE
+        UPDATE blogs SET comment_count = comment_count - 1 WHERE id = OLD.blog_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS comments_update_comment_count ON comments;
+CREATE TRIGGER comments_update_comment_count
+    AFTER INSERT OR DELETE ON comments
+    FOR EACH ROW EXECUTE FUNCTION comments_update_comment_count();
+
+-- いいね数順・コメント数順のブログ一覧用インデックス
+CREATE INDEX IF NOT EXISTS blogs_like_count_idx ON blogs (like_count DESC, created_at DESC, id DESC)
+    WHERE deleted_at IS NULL AND status = 'published';
+CREATE INDEX IF NOT EXISTS blogs_comment_count_idx ON blogs (comment_count DESC, created_at DESC, id DESC)
+    WHERE deleted_at IS NULL AND status = 'published';
@@ -0,0 +1,16 @@
+-- ログインセッション(リフレッシュトークン)テーブル
+-- リフレッシュトークン1つにつき1行とし、ローテーションで発行し直したトークンは同じ family_id を引き継ぐ。
+-- トークンはSHA-256のハッシュのみを保存する。
+CREATE TABLE IF NOT EXISTS sessions (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    family_id  UUID NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    rotated_at TIMESTAMPTZ, -- 新しいトークンと交換した日時(交換済みのトークンの再利用は盗用とみなす)
+    revoked_at TIMESTAMPTZ, -- 失効させた日時
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS sessions_family_id_idx ON sessions (family_id);
+CREATE INDEX IF NOT EXISTS sessions_user_id_idx ON sessions (user_id) WHERE revoked_at IS NULL;
@@ -0,0 +1,7 @@
+-- ユーザーの権限
+-- admin: すべての操作、editor: すべてのブログの編集、author: 自分のブログの編集・削除のみ
+-- 既存のユーザーは author とする(管理者は手動で変更すること)。
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'author';
+
+ALTER TABLE users ADD CONSTRAINT users_role_check
+    CHECK (role IN ('admin', 'editor', 'author'));
@@ -0,0 +1,19 @@
+-- メールアドレスの確認
+-- 登録直後のユーザーは未確認(email_verified_at が NULL)とし、確認するまでブログを投稿できない。
+-- 既存のユーザーは登録日時に確認済みとする。
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
+
+UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL;
+
+-- 確認メールのトークン
+-- トークンはSHA-256のハッシュのみを保存し、一度使用したトークンは使えない。
+CREATE TABLE IF NOT EXISTS email_verifications (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ, -- 確認に使用した日時
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS email_verifications_user_id_idx ON email_verifications (user_id);
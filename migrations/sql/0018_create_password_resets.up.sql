@@ -0,0 +1,13 @@
+-- パスワード再設定のトークン
+-- トークンはSHA-256のハッシュのみを保存し、一度使用したトークンは使えない。
+-- 再設定した時点で、同じユーザーの未使用のトークンもすべて使用済みにする。
+CREATE TABLE IF NOT EXISTS password_resets (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ, -- 再設定に使用した(または無効にした)日時
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS password_resets_user_id_idx ON password_resets (user_id) WHERE used_at IS NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS email_changes;
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_url;
+ALTER TABLE users DROP COLUMN IF EXISTS bio;
@@ -0,0 +1,18 @@
+-- プロフィール(自己紹介・アバター画像のURL)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS bio TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_url TEXT NOT NULL DEFAULT '';
+
+-- メールアドレスの変更
+-- 新しいメールアドレスに送ったリンクで確認するまで、users.email は変更しない。
+-- トークンはSHA-256のハッシュのみを保存し、一度使用したトークンは使えない。
+CREATE TABLE IF NOT EXISTS email_changes (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    new_email  TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ, -- 変更に使用した(または無効にした)日時
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS email_changes_user_id_idx ON email_changes (user_id) WHERE used_at IS NULL;
@@ -0,0 +1,11 @@
+-- ログインの失敗の記録(総当たり攻撃の対策)
+-- メールアドレス・IPアドレスはSHA-256のハッシュのみを保存し、ログインに成功した時点でメールアドレスの記録を削除する。
+-- 失敗を数える期間を過ぎた記録は、失敗を記録する際にまとめて削除する。
+CREATE TABLE IF NOT EXISTS login_failures (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    key_hash   TEXT NOT NULL, -- 制限の単位("email:" または "ip:" に続けて、値のハッシュ)
+    failed_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS login_failures_key_hash_failed_at_idx ON login_failures (key_hash, failed_at);
+CREATE INDEX IF NOT EXISTS login_failures_failed_at_idx ON login_failures (failed_at);
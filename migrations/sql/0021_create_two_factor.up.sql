@@ -0,0 +1,36 @@
+-- TOTPによる2要素認証の設定
+-- 登録を開始した時点で作成し、最初のコードで確認した時点で有効(enabled_at)にする。
+-- 同じコードを再び使えないよう、最後に使用したコードの時間ステップを記録する。
+CREATE TABLE IF NOT EXISTS user_totp (
+    user_id        UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    secret         TEXT NOT NULL,
+    enabled_at     TIMESTAMPTZ,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- 2要素認証のリカバリーコード
+-- コードはSHA-256のハッシュのみを保存し、それぞれ一度だけ使用できる。
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    code_hash  TEXT NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (user_id, code_hash)
+);
+
+-- パスワードの確認後、2段階目のコードを待つログインのチャレンジ
+-- トークンはSHA-256のハッシュのみを保存し、コードの試行回数を制限する。
+CREATE TABLE IF NOT EXISTS two_factor_challenges (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    attempts   INTEGER NOT NULL DEFAULT 0,
+    used_at    TIMESTAMPTZ, -- ログインを完了した日時
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS two_factor_challenges_user_id_idx ON two_factor_challenges (user_id);
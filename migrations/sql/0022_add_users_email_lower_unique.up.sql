@@ -0,0 +1,7 @@
+-- メールアドレスを大文字・小文字を区別せずに一意にする
+-- アプリケーションは小文字に揃えて保存・検索するため、既存のメールアドレスも小文字に揃える。
+-- 大文字・小文字のみが異なるメールアドレスが既に登録されている場合は失敗するため、先に一方を変更すること。
+UPDATE users SET email = lower(email) WHERE email <> lower(email);
+UPDATE email_changes SET new_email = lower(new_email) WHERE new_email <> lower(new_email) AND used_at IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_lower_key ON users (lower(email));
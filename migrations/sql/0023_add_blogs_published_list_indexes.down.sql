@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS blogs_comment_count_idx;
+DROP INDEX IF EXISTS blogs_like_count_idx;
+CREATE INDEX IF NOT EXISTS blogs_like_count_idx ON blogs (like_count DESC, created_at DESC, id DESC)
+    WHERE deleted_at IS NULL AND status = 'published';
+CREATE INDEX IF NOT EXISTS blogs_comment_count_idx ON blogs (comment_count DESC, created_at DESC, id DESC)
+    WHERE deleted_at IS NULL AND status = 'published';
+
+DROP INDEX IF EXISTS blogs_category_published_at_idx;
+DROP INDEX IF EXISTS blogs_published_at_id_idx;
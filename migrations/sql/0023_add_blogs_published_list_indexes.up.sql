@@ -0,0 +1,14 @@
+-- 公開日時順のブログ一覧のカーソルページング・絞り込み用インデックス
+-- 一覧は公開済みのブログのみを公開日時で並べるため、公開済みのブログに限定する。
+CREATE INDEX IF NOT EXISTS blogs_published_at_id_idx ON blogs (published_at DESC, id DESC)
+    WHERE deleted_at IS NULL AND status = 'published';
+CREATE INDEX IF NOT EXISTS blogs_category_published_at_idx ON blogs (category, published_at DESC)
+    WHERE deleted_at IS NULL AND status = 'published';
+
+-- いいね数順・コメント数順の第2キーを公開日時に変更する
+DROP INDEX IF EXISTS blogs_like_count_idx;
+DROP INDEX IF EXISTS blogs_comment_count_idx;
+CREATE INDEX IF NOT EXISTS blogs_like_count_idx ON blogs (like_count DESC, published_at DESC, id DESC)
+    WHERE deleted_at IS NULL AND status = 'published';
+CREATE INDEX IF NOT EXISTS blogs_comment_count_idx ON blogs (comment_count DESC, published_at DESC, id DESC)
+    WHERE deleted_at IS NULL AND status = 'published';
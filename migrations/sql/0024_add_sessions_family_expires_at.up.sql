@@ -0,0 +1,16 @@
+-- リフレッシュトークンの系列の有効期限(ログインからの期限)を保持する
+-- トークンを交換しても系列の有効期限は延長しない。既存の系列は最初のセッションの有効期限を引き継ぐ。
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS family_expires_at TIMESTAMPTZ;
+
+UPDATE sessions s
+SET family_expires_at = f.expires_at
+FROM (
+	SELECT DISTINCT ON (family_id) family_id, expires_at
+	FROM sessions
+	ORDER BY family_id, created_at, id
+) f
+WHERE s.family_id = f.family_id AND s.family_expires_at IS NULL;
+
+UPDATE sessions SET expires_at = family_expires_at WHERE expires_at > family_expires_at;
+
+ALTER TABLE sessions ALTER COLUMN family_expires_at SET NOT NULL;
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// ブログの過去の版を表すデータ構造
+// 更新の直前の内容を保存する。各フィールドには、JSONおよびデータベースのタグを指定。
+type BlogRevisionData struct {
+	ID           string    `json:"id" db:"id"`                       // UUID型
+	BlogId       string    `json:"blog_id" db:"blog_id"`             // ブログID
+	Revision     int       `json:"revision" db:"revision"`           // ブログごとの版番号(1から連番)
+	Title        string    `json:"title" db:"title"`                 // タイトル
+	Description  string    `json:"description" db:"description"`     // 説明
+	GithubUrl    string    `json:"github_url" db:"github_url"`       // GitHubリポジトリのURL
+	Category     string    `json:"category" db:"category"`           // カテゴリ
+	Tags         string    `json:"tags" db:"tags"`                   // タグ
+	BodyMarkdown string    `json:"body_markdown" db:"body_markdown"` // 本文(Markdown)
+	AuthorId     string    `json:"author_id" db:"author_id"`         // この版を置き換える更新を行ったユーザーID
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`       // 保存日時
+}
+
+// 2つの版の差分
+type BlogRevisionDiff struct {
+	BlogId  string            `json:"blog_id"` // ブログID
+	From    int               `json:"from"`    // 比較元の版番号
+	To      int               `json:"to"`      // 比較先の版番号
+	Changes []BlogFieldChange `json:"changes"` // 変更されたフィールド
+}
+
+// フィールド単位の変更内容
+type BlogFieldChange struct {
+	Field string `json:"field"` // フィールド名(JSONのキー名)
+	From  string `json:"from"`  // 比較元の値
+	To    string `json:"to"`    // 比較先の値
+}
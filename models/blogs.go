@@ -7,15 +7,121 @@ import (
 // ブログの情報を表すデータ構造
 // 各フィールドには、JSONおよびデータベースのタグを指定。
 type BlogData struct {
-	ID          string    `json:"id" db:"id"`                   // UUID型
-	UserId      string    `json:"user_id" db:"user_id"`         // ユーザーID
-	Title       string    `json:"title" db:"title"`             // タイトル
-	Description string    `json:"description" db:"description"` // 説明
-	GithubUrl   string    `json:"github_url" db:"github_url"`   // GitHubリポジトリのURL
-	Category    string    `json:"category" db:"category"`       // カテゴリ
-	Tags        string    `json:"tags" db:"tags"`               // タグ
-	Likes       int8      `json:"likes" db:"likes"`             // いいね数
-	CommentCnt  int8      `json:"comment_cnt" db:"comment_cnt"` // コメント数
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`   // タイムスタンプ
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`   // タイムスタンプ
+	ID           string     `json:"id" db:"id"`                                 // UUID型
+	UserId       string     `json:"user_id" db:"user_id"`                       // ユーザーID
+	Title        string     `json:"title" db:"title"`                           // タイトル
+	Description  string     `json:"description" db:"description"`               // 説明
+	GithubUrl    string     `json:"github_url" db:"github_url"`                 // GitHubリポジトリのURL
+	Category     string     `json:"category" db:"category"`                     // カテゴリ
+	Tags         string     `json:"tags" db:"tags"`                             // タグ
+	Likes        int64      `json:"likes" db:"like_count"`                      // いいね数
+	CommentCnt   int64      `json:"comment_cnt" db:"comment_count"`             // コメント数
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`                 // タイムスタンプ
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`                 // タイムスタンプ
+	DeletedAt    *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`       // 削除日時(ゴミ箱内のブログのみ)
+	Status       string     `json:"status" db:"status"`                         // 公開状態
+	PublishedAt  *time.Time `json:"published_at" db:"published_at"`             // 公開日時(予約中の場合は公開予定日時)
+	Slug         string     `json:"slug" db:"slug"`                             // パーマリンク用のスラッグ
+	BodyMarkdown string     `json:"body_markdown,omitempty" db:"body_markdown"` // 本文(Markdown、一覧では取得しない)
+}
+
+// ブログの詳細
+// 本文をサニタイズ済みのHTMLに変換した結果と、本文から求めた目次・単語数・読了時間を含む。
+type BlogDetail struct {
+	BlogData
+	BodyHTML    string         `json:"body_html"`    // 本文のHTML
+	Toc         []BlogTocEntry `json:"toc"`          // 見出しの目次
+	WordCount   int            `json:"word_count"`   // 単語数(日本語は1文字を1語とする)
+	ReadingTime int            `json:"reading_time"` // 読了時間(分)
+}
+
+// 目次の項目
+type BlogTocEntry struct {
+	Level int    `json:"level"` // 見出しのレベル(1〜6)
+	ID    string `json:"id"`    // 見出しのアンカーID
+	Text  string `json:"text"`  // 見出しのテキスト
+}
+
+// ブログの公開状態
+const (
+	BlogStatusDraft       = "draft"       // 下書き
+	BlogStatusScheduled   = "scheduled"   // 公開予約
+	BlogStatusPublished   = "published"   // 公開済み
+	BlogStatusUnpublished = "unpublished" // 公開後に非公開にしたもの
+)
+
+// ブログ一覧の並び順
+const (
+	BlogSortNewest        = "newest"         // 公開日時の新しい順
+	BlogSortOldest        = "oldest"         // 公開日時の古い順
+	BlogSortMostLiked     = "most-liked"     // いいね数の多い順
+	BlogSortMostCommented = "most-commented" // コメント数の多い順
+)
+
+// ブログ一覧の取得条件(リクエストの値)
+type BlogListParams struct {
+	Limit        int    // 取得件数(0の場合は既定値)
+	Cursor       string // 前ページのnext_cursor
+	Category     string // カテゴリ
+	Tag          string // タグ
+	UserId       string // ユーザーID
+	From         string // 公開日時の下限(RFC3339またはYYYY-MM-DD)
+	To           string // 公開日時の上限(RFC3339またはYYYY-MM-DD)
+	Sort         string // 並び順
+	IncludeTotal bool   // 総件数を含めるか
+}
+
+// ブログ一覧のカーソル
+// 直前のページの最後の要素の並び替えキーを保持する。
+type BlogCursor struct {
+	Sort        string    `json:"s"`           // 並び順
+	Count       int64     `json:"n,omitempty"` // いいね数またはコメント数
+	PublishedAt time.Time `json:"p"`           // 公開日時
+	ID          string    `json:"id"`          // ブログID
+}
+
+// リポジトリに渡すブログ一覧の検索条件
+type BlogListFilter struct {
+	Limit    int
+	Category string
+	Tag      string
+	UserId   string
+	From     *time.Time
+	To       *time.Time
+	Sort     string
+	After    *BlogCursor
+}
+
+// ブログ一覧のレスポンス
+type BlogPage struct {
+	Items      []BlogData `json:"items"`           // ブログデータ
+	NextCursor *string    `json:"next_cursor"`     // 次ページのカーソル(最終ページの場合はnull)
+	Total      *int       `json:"total,omitempty"` // 条件に一致する総件数
+}
+
+// ブログ検索の重み
+const (
+	BlogSearchWeightTitle       = 3 // タイトル
+	BlogSearchWeightTags        = 2 // タグ
+	BlogSearchWeightDescription = 1 // 説明
+)
+
+// ブログ検索の結果
+// スコアはタイトル > タグ > 説明 の重みで一致した検索語ごとに加算する。
+type BlogSearchResult struct {
+	BlogData
+	Score      int                  `json:"score"`      // 検索スコア
+	Highlights BlogSearchHighlights `json:"highlights"` // ハイライト済みの抜粋
+}
+
+// 検索語を<mark>タグで囲んだ抜粋(HTMLエスケープ済み)
+type BlogSearchHighlights struct {
+	Title       string `json:"title"`       // タイトル
+	Tags        string `json:"tags"`        // タグ
+	Description string `json:"description"` // 説明の抜粋
+}
+
+// ブログ検索のレスポンス
+type BlogSearchPage struct {
+	Items []BlogSearchResult `json:"items"` // 検索結果
 }
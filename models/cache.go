@@ -0,0 +1,11 @@
+package models
+
+// キャッシュの統計(名前空間ごと)
+type CacheStats struct {
+	Hits          uint64  `json:"hits"`          // キャッシュから返した回数
+	Misses        uint64  `json:"misses"`        // データベースから読み込んだ回数
+	Invalidations uint64  `json:"invalidations"` // 書き込みによる無効化の回数
+	Evictions     uint64  `json:"evictions"`     // 上限を超えたため削除したエントリ数
+	Entries       int     `json:"entries"`       // 現在のエントリ数
+	HitRate       float64 `json:"hit_rate"`      // ヒット率(0〜1)
+}
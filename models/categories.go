@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// カテゴリの情報を表すデータ構造
+// 各フィールドには、JSONおよびデータベースのタグを指定。
+type CategoryData struct {
+	ID           string    `json:"id" db:"id"`                       // UUID型
+	Name         string    `json:"name" db:"name"`                   // カテゴリ名
+	Slug         string    `json:"slug" db:"slug"`                   // URL用の識別子
+	Description  string    `json:"description" db:"description"`     // 説明
+	DisplayOrder int       `json:"display_order" db:"display_order"` // 表示順(昇順)
+	PostCount    int       `json:"post_count" db:"post_count"`       // 投稿数
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`       // タイムスタンプ
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`       // タイムスタンプ
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// ログインセッション(リフレッシュトークン)を表すデータ構造
+// リフレッシュトークン1つにつき1件とし、ローテーションで発行し直したトークンは同じ FamilyId と FamilyExpiresAt を引き継ぐ。
+type SessionData struct {
+	ID              string     `json:"id" db:"id"`                               // UUID型
+	UserId          string     `json:"user_id" db:"user_id"`                     // ユーザーID
+	FamilyId        string     `json:"family_id" db:"family_id"`                 // ログインごとのトークンの系列
+	TokenHash       string     `json:"-" db:"token_hash"`                        // リフレッシュトークンのハッシュ
+	ExpiresAt       time.Time  `json:"expires_at" db:"expires_at"`               // 有効期限
+	FamilyExpiresAt time.Time  `json:"family_expires_at" db:"family_expires_at"` // 系列の有効期限(ログインから数える)
+	RotatedAt       *time.Time `json:"rotated_at" db:"rotated_at"`               // 新しいトークンと交換した日時
+	RevokedAt       *time.Time `json:"revoked_at" db:"revoked_at"`               // 失効させた日時
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`               // タイムスタンプ
+}
+
+// ログイン・トークンの更新で発行するトークン
+// クッキーにのみ設定し、レスポンスボディには含めない。
+type AuthTokens struct {
+	AccessToken           string    `json:"-"` // アクセストークン(JWT)
+	AccessTokenExpiresAt  time.Time `json:"-"` // アクセストークンの有効期限
+	RefreshToken          string    `json:"-"` // リフレッシュトークン
+	RefreshTokenExpiresAt time.Time `json:"-"` // リフレッシュトークンの有効期限
+}
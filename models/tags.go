@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// タグの情報を表すデータ構造
+// 各フィールドには、JSONおよびデータベースのタグを指定。
+type TagData struct {
+	ID        string    `json:"id" db:"id"`                 // UUID型
+	Name      string    `json:"name" db:"name"`             // タグ名
+	Count     int       `json:"count" db:"count"`           // 使用しているブログ数
+	Aliases   []string  `json:"aliases" db:"aliases"`       // 別名
+	CreatedAt time.Time `json:"created_at" db:"created_at"` // タイムスタンプ
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"` // タイムスタンプ
+}
+
+// タグごとのブログ数
+type TagCount struct {
+	Name  string `json:"name"`  // タグ名
+	Count int    `json:"count"` // 使用しているブログ数
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// TOTPによる2要素認証の設定を表すデータ構造
+// 登録を開始した時点で作成し、最初のコードで確認するまでは無効(EnabledAt が nil)とする。
+type TOTPData struct {
+	UserId       string     `json:"user_id" db:"user_id"`       // ユーザーID
+	Secret       string     `json:"-" db:"secret"`              // シークレット(Base32)
+	EnabledAt    *time.Time `json:"enabled_at" db:"enabled_at"` // 有効にした日時(確認前はnil)
+	LastUsedStep int64      `json:"-" db:"last_used_step"`      // 最後に使用したコードの時間ステップ(再利用の防止)
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"` // タイムスタンプ
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"` // タイムスタンプ
+}
+
+// 2要素認証が有効か判定する
+func (t *TOTPData) Enabled() bool {
+	return t.EnabledAt != nil
+}
+
+// 2要素認証の登録を開始した際に返す情報
+// シークレットはこの時にのみ返し、認証アプリに登録してもらう。
+type TOTPSetup struct {
+	Secret string `json:"secret"`      // シークレット(Base32、手入力用)
+	URI    string `json:"otpauth_uri"` // QRコード用の otpauth URI
+}
+
+// 2要素認証の状態
+type TwoFactorStatus struct {
+	Enabled                bool `json:"enabled"`                  // 有効か
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"` // 未使用のリカバリーコードの数
+}
+
+// パスワードの確認後、2段階目のコードを待つログインのチャレンジ
+type TwoFactorChallenge struct {
+	Token     string    `json:"challenge_token"` // チャレンジトークン(コードの送信時に使用する)
+	ExpiresAt time.Time `json:"expires_at"`      // 有効期限
+}
@@ -1,14 +1,121 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"strings"
+	"time"
+)
 
 // ユーザーの情報を表すデータ構造
 // 各フィールドには、JSONおよびデータベースのタグを指定。
+// パスワードは含めない(認証には UserCredentials を使用する)。
 type UserData struct {
-	ID        string    `json:"id" db:"id"`                 // UUID型
-	Name      string    `json:"name" db:"name"`             // ユーザー名
-	Email     string    `json:"email" db:"email"`           // メールアドレス
-	Password  string    `json:"password" db:"password"`     // パスワード
-	CreatedAt time.Time `json:"created_at" db:"created_at"` // タイムスタンプ
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"` // タイムスタンプ
+	ID              string     `json:"id" db:"id"`                               // UUID型
+	Name            string     `json:"name" db:"name"`                           // ユーザー名
+	Email           string     `json:"email" db:"email"`                         // メールアドレス
+	Role            string     `json:"role" db:"role"`                           // 権限(admin, editor, author)
+	Bio             string     `json:"bio" db:"bio"`                             // 自己紹介
+	AvatarURL       string     `json:"avatar_url" db:"avatar_url"`               // アバター画像のURL
+	EmailVerifiedAt *time.Time `json:"email_verified_at" db:"email_verified_at"` // メールアドレスを確認した日時(未確認の場合はnil)
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`               // タイムスタンプ
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`               // タイムスタンプ
+}
+
+// メールアドレスを比較・保存する形式に揃える(前後の空白を除き、小文字にする)
+// メールアドレスは大文字・小文字を区別せずに一意とする。
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// メールアドレスを確認済みか判定する
+func (u *UserData) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// ユーザーの権限
+const (
+	UserRoleAdmin  = "admin"  // 管理者(すべての操作が可能)
+	UserRoleEditor = "editor" // 編集者(すべてのブログを編集・削除可能)
+	UserRoleAuthor = "author" // 投稿者(自分のブログのみ編集・削除可能)
+)
+
+// 権限の値が正しいか判定する
+func ValidUserRole(role string) bool {
+	switch role {
+	case UserRoleAdmin, UserRoleEditor, UserRoleAuthor:
+		return true
+	}
+	return false
+}
+
+// タグ・カテゴリの管理やキャッシュの統計など、サイト全体に関わる操作を許可するか判定する
+// admin と editor のみ許可し、author には許可しない。
+func CanManageSite(role string) bool {
+	return role == UserRoleAdmin || role == UserRoleEditor
+}
+
+// UserCredentials をJSONへ変換しようとした場合のエラー
+var ErrCredentialsSerialization = errors.New("user credentials must not be serialized")
+
+// 認証に使用するユーザーの資格情報(リポジトリ・サービスの内部でのみ使用する)
+// パスワードのハッシュを含むため、JSONへの変換は常にエラーとし、ログ出力ではハッシュを伏せる。
+type UserCredentials struct {
+	User         UserData `json:"-"`
+	PasswordHash string   `json:"-" db:"password"` // パスワードのハッシュ
+}
+
+// JSONへの変換を拒否する
+func (UserCredentials) MarshalJSON() ([]byte, error) {
+	return nil, ErrCredentialsSerialization
+}
+
+// ログ出力用の文字列(パスワードのハッシュは伏せる)
+func (c UserCredentials) String() string {
+	return "{User:" + c.User.ID + " PasswordHash:[REDACTED]}"
+}
+
+// ログイン中のユーザー本人に返すプロフィール
+type UserProfile struct {
+	ID            string    `json:"id"`             // UUID型
+	Name          string    `json:"name"`           // ユーザー名
+	Email         string    `json:"email"`          // メールアドレス
+	Role          string    `json:"role"`           // 権限
+	Bio           string    `json:"bio"`            // 自己紹介
+	AvatarURL     string    `json:"avatar_url"`     // アバター画像のURL
+	EmailVerified bool      `json:"email_verified"` // メールアドレスを確認済みか
+	CreatedAt     time.Time `json:"created_at"`     // タイムスタンプ
+	UpdatedAt     time.Time `json:"updated_at"`     // タイムスタンプ
+}
+
+// 誰でも参照できる投稿者のプロフィール
+type AuthorProfile struct {
+	ID        string `json:"id"`         // UUID型
+	Name      string `json:"name"`       // ユーザー名
+	Bio       string `json:"bio"`        // 自己紹介
+	AvatarURL string `json:"avatar_url"` // アバター画像のURL
+}
+
+// ユーザー情報から本人向けのプロフィールを生成する
+func NewUserProfile(user *UserData) *UserProfile {
+	return &UserProfile{
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		Role:          user.Role,
+		Bio:           user.Bio,
+		AvatarURL:     user.AvatarURL,
+		EmailVerified: user.EmailVerified(),
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+	}
+}
+
+// ユーザー情報から公開用の投稿者プロフィールを生成する
+func NewAuthorProfile(user *UserData) *AuthorProfile {
+	return &AuthorProfile{
+		ID:        user.ID,
+		Name:      user.Name,
+		Bio:       user.Bio,
+		AvatarURL: user.AvatarURL,
+	}
 }
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testUser = UserData{
+	ID:        "11111111-1111-1111-1111-111111111111",
+	Name:      "John Doe",
+	Email:     "john@example.com",
+	Role:      UserRoleAuthor,
+	Bio:       "Gopher",
+	AvatarURL: "https://example.com/avatar.png",
+	CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	UpdatedAt: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+}
+
+// JSONのキーを取得する
+func jsonKeys(t *testing.T, v interface{}) []string {
+	data, err := json.Marshal(v)
+	assert.NoError(t, err)
+	var m map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &m))
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestUserCredentials_MarshalJSON(t *testing.T) {
+	credentials := UserCredentials{User: testUser, PasswordHash: "$argon2id$secret"}
+
+	// 値・ポインタ・他の構造体やマップに含めた場合のいずれもJSONへ変換できない
+	for name, v := range map[string]interface{}{
+		"値":        credentials,
+		"ポインタ":     &credentials,
+		"構造体":      struct{ User UserCredentials }{credentials},
+		"マップ":      map[string]interface{}{"user": credentials},
+		"スライス":     []*UserCredentials{&credentials},
+		"インターフェース": interface{}(credentials),
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(v)
+			assert.ErrorIs(t, err, ErrCredentialsSerialization)
+			assert.Nil(t, data)
+		})
+	}
+}
+
+func TestUserCredentials_String(t *testing.T) {
+	credentials := UserCredentials{User: testUser, PasswordHash: "$argon2id$secret"}
+
+	// ログに出力してもハッシュは含まれない
+	for _, s := range []string{
+		fmt.Sprintf("%v", credentials),
+		fmt.Sprintf("%v", &credentials),
+		fmt.Sprintf("%s", credentials),
+	} {
+		assert.NotContains(t, s, "secret")
+		assert.Contains(t, s, testUser.ID)
+	}
+}
+
+func TestUserProfile(t *testing.T) {
+	profile := NewUserProfile(&testUser)
+	assert.Equal(t, testUser.ID, profile.ID)
+	assert.Equal(t, testUser.Email, profile.Email)
+	assert.Equal(t, testUser.Role, profile.Role)
+	assert.False(t, profile.EmailVerified)
+	assert.ElementsMatch(t, []string{"id", "name", "email", "role", "bio", "avatar_url", "email_verified", "created_at", "updated_at"}, jsonKeys(t, profile))
+
+	// 確認日時は返さず、確認済みかどうかのみを返す
+	verifiedAt := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+	verified := testUser
+	verified.EmailVerifiedAt = &verifiedAt
+	assert.True(t, NewUserProfile(&verified).EmailVerified)
+}
+
+func TestAuthorProfile(t *testing.T) {
+	// 公開用のプロフィールにはメールアドレスも含めない
+	profile := NewAuthorProfile(&testUser)
+	assert.Equal(t, &AuthorProfile{ID: testUser.ID, Name: testUser.Name, Bio: testUser.Bio, AvatarURL: testUser.AvatarURL}, profile)
+	assert.ElementsMatch(t, []string{"id", "name", "bio", "avatar_url"}, jsonKeys(t, profile))
+}
+
+func TestUserData_NoCredentials(t *testing.T) {
+	// ユーザー情報自体にもパスワードの項目を持たせない
+	assert.ElementsMatch(t, []string{"id", "name", "email", "role", "bio", "avatar_url", "email_verified_at", "created_at", "updated_at"}, jsonKeys(t, testUser))
+}
+
+func TestValidUserRole(t *testing.T) {
+	for _, role := range []string{UserRoleAdmin, UserRoleEditor, UserRoleAuthor} {
+		assert.True(t, ValidUserRole(role), role)
+	}
+	for _, role := range []string{"", "Admin", "owner"} {
+		assert.False(t, ValidUserRole(role), role)
+	}
+}
+
+func TestCanManageSite(t *testing.T) {
+	for _, role := range []string{UserRoleAdmin, UserRoleEditor} {
+		assert.True(t, CanManageSite(role), role)
+	}
+	for _, role := range []string{UserRoleAuthor, "", "Admin"} {
+		assert.False(t, CanManageSite(role), role)
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, "john@example.com", NormalizeEmail(" John@Example.COM "))
+	assert.Equal(t, "", NormalizeEmail("  "))
+}
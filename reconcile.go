@@ -0,0 +1,41 @@
+package main
+
+import (
+	"backend/config"
+	repositories_blogs "backend/repositories/blogs"
+	"backend/supabase"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// 集計値の再計算のサブコマンドを実行する
+// reconcile counters : ブログのいいね数・コメント数を blogs_likes・comments から再計算
+func runReconcile(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: reconcile counters")
+	}
+	if config.IsMemoryDriver() {
+		return errors.New("reconcile is not available with DB_DRIVER=memory")
+	}
+
+	// Supabaseクライアントの初期化
+	if err := supabase.InitSupabase(); err != nil {
+		return err
+	}
+	defer supabase.ClosePool()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "counters":
+		count, err := repositories_blogs.NewBlogRepository(supabase.Pool).ReconcileBlogCounters(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("reconciled counters of %d blogs\n", count)
+		return nil
+	default:
+		return fmt.Errorf("unknown reconcile command: %s", args[0])
+	}
+}
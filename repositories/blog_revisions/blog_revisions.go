@@ -0,0 +1,127 @@
+package repositories_blog_revisions
+
+import (
+	"backend/logger"
+	"backend/models"
+	"backend/supabase"
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// 版履歴を取得するクエリ
+// ゴミ箱内のブログの版履歴は取得しない。
+const selectRevisionsQuery = `
+	SELECT r.id, r.blog_id, r.revision, r.title, r.description, r.github_url, r.category, r.tags, r.body_markdown, r.author_id, r.created_at
+	FROM blog_revisions r
+	JOIN blogs b ON b.id = r.blog_id AND b.deleted_at IS NULL
+`
+
+// 版履歴をスキャンする
+func scanRevision(row pgx.Row) (*models.BlogRevisionData, error) {
+	var revision models.BlogRevisionData
+	err := row.Scan(
+		&revision.ID,
+		&revision.BlogId,
+		&revision.Revision,
+		&revision.Title,
+		&revision.Description,
+		&revision.GithubUrl,
+		&revision.Category,
+		&revision.Tags,
+		&revision.BodyMarkdown,
+		&revision.AuthorId,
+		&revision.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// ブログの現在の内容を新しい版として保存する
+// 対象のブログ行をロックしてから版番号を採番するため、同時に更新されても版番号は重複しない。
+// ブログが存在しない(ゴミ箱内を含む)場合は pgx.ErrNoRows を返す。
+// 呼び出し側でトランザクションを開始し、tx を渡すこと。
+func SnapshotBlog(ctx context.Context, tx supabase.DB, blogId, authorId string) error {
+	var lockedId string
+	err := tx.QueryRow(ctx, `
+		SELECT id FROM blogs WHERE id = $1 AND deleted_at IS NULL FOR UPDATE
+	`, blogId).Scan(&lockedId)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO blog_revisions (blog_id, revision, title, description, github_url, category, tags, body_markdown, author_id)
+		SELECT b.id,
+		       COALESCE((SELECT MAX(r.revision) FROM blog_revisions r WHERE r.blog_id = b.id), 0) + 1,
+		       b.title, b.description, b.github_url, b.category, b.tags, b.body_markdown, $2
+		FROM blogs b
+		WHERE b.id = $1
+	`, blogId, authorId)
+	return err
+}
+
+// 指定されたブログの版履歴を新しい順に取得する
+func (r *BlogRevisionRepositoryImpl) FetchRevisionsByBlogId(ctx context.Context, blogId string) ([]models.BlogRevisionData, error) {
+	logger.InfoLog.Printf("FetchRevisionsByBlogId start...")
+
+	query := selectRevisionsQuery + `
+		WHERE r.blog_id = $1
+		ORDER BY r.revision DESC
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、条件に一致するデータを取得
+	rows, err := r.DB.Query(ctx, query, blogId)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch revisions: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	// 結果をスライスに格納
+	var revisions []models.BlogRevisionData
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			logger.ErrorLog.Printf("Failed to scan revision: %v", err)
+			return nil, err
+		}
+		revisions = append(revisions, *revision)
+	}
+	if err := rows.Err(); err != nil {
+		logger.ErrorLog.Printf("Failed to iterate revisions: %v", err)
+		return nil, err
+	}
+
+	logger.InfoLog.Printf("Fetched %d revisions", len(revisions))
+	return revisions, nil
+}
+
+// 指定されたブログの指定された版を取得する
+func (r *BlogRevisionRepositoryImpl) FetchRevision(ctx context.Context, blogId string, revision int) (*models.BlogRevisionData, error) {
+	logger.InfoLog.Printf("FetchRevision start...")
+
+	query := selectRevisionsQuery + `
+		WHERE r.blog_id = $1 AND r.revision = $2
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、条件に一致するデータを取得
+	result, err := scanRevision(r.DB.QueryRow(ctx, query, blogId, revision))
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch revision: %v", err)
+		return nil, err
+	}
+
+	logger.InfoLog.Printf("Fetched revision: %v", result)
+	return result, nil
+}
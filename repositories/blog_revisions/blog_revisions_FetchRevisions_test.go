@@ -0,0 +1,40 @@
+package repositories_blog_revisions
+
+import (
+	"backend/supabase"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_FetchRevisionsByBlogId_NotFound(t *testing.T) {
+	// Supabaseクライアントの初期化
+	setupSupabase(t)
+
+	// リポジトリのインスタンスを作成
+	repo := NewBlogRevisionRepository(supabase.Pool)
+
+	// 存在しないブログIDで取得
+	revisions, err := repo.FetchRevisionsByBlogId(context.Background(), "00000000-0000-0000-0000-000000000000")
+
+	// エラーチェックとデータ確認
+	assert.NoError(t, err)
+	assert.Empty(t, revisions)
+}
+
+func TestRepository_FetchRevision_NotFound(t *testing.T) {
+	// Supabaseクライアントの初期化
+	setupSupabase(t)
+
+	// リポジトリのインスタンスを作成
+	repo := NewBlogRevisionRepository(supabase.Pool)
+
+	// 存在しない版を取得
+	revision, err := repo.FetchRevision(context.Background(), "00000000-0000-0000-0000-000000000000", 1)
+
+	// エラーチェックとデータ確認
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Nil(t, revision)
+}
@@ -0,0 +1,24 @@
+package repositories_blog_revisions
+
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+)
+
+// BlogRevisionRepositoryインターフェース
+type BlogRevisionRepository interface {
+	FetchRevisionsByBlogId(ctx context.Context, blogId string) ([]models.BlogRevisionData, error)
+	FetchRevision(ctx context.Context, blogId string, revision int) (*models.BlogRevisionData, error)
+}
+
+type BlogRevisionRepositoryImpl struct {
+	DB supabase.DB
+}
+
+// BlogRevisionRepositoryインターフェースを実装したBlogRevisionRepositoryImplのポインタを返す
+func NewBlogRevisionRepository(db supabase.DB) BlogRevisionRepository {
+	return &BlogRevisionRepositoryImpl{
+		DB: db,
+	}
+}
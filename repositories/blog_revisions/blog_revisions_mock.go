@@ -0,0 +1,28 @@
+package repositories_blog_revisions
+
+import (
+	"backend/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBlogRevisionRepository struct {
+	mock.Mock
+}
+
+func (m *MockBlogRevisionRepository) FetchRevisionsByBlogId(ctx context.Context, blogId string) ([]models.BlogRevisionData, error) {
+	args := m.Called(blogId)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.BlogRevisionData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBlogRevisionRepository) FetchRevision(ctx context.Context, blogId string, revision int) (*models.BlogRevisionData, error) {
+	args := m.Called(blogId, revision)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.BlogRevisionData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -3,38 +3,30 @@ package repositories_blogs
 import (
 	"backend/logger"
 	"backend/models"
+	repositories_blog_revisions "backend/repositories/blog_revisions"
+	repositories_tags "backend/repositories/tags"
 	"backend/supabase"
+	utils_slug "backend/utils/slug"
+	"context"
 	"errors"
 
 	"github.com/google/uuid"
 )
 
-// 全ブログデータを取得する
-func (r *BlogRepositoryImpl) FetchBlogs() ([]models.BlogData, error) {
+// 検索条件に一致するブログデータを取得する
+// filter.After が指定された場合は、そのカーソルより後ろのデータを filter.Limit 件まで取得する。
+func (r *BlogRepositoryImpl) FetchBlogs(ctx context.Context, filter models.BlogListFilter) ([]models.BlogData, error) {
 	logger.InfoLog.Printf("FetchBlogs start...")
 
-	// ※ blogs と blogs_likes テーブルを結合し、いいね数を集計して取得すること
-	query := `
-		SELECT b.id, b.user_id, b.title, b.description, b.github_url, b.category, b.tags,
-				COALESCE(l.like_count, 0) AS likes,
-				COALESCE(c.comment_count, 0) AS comment_cnt,
-				b.created_at, b.updated_at
-		FROM blogs b
-		LEFT JOIN (
-			SELECT blog_id, COUNT(*) AS like_count
-			FROM blogs_likes
-			GROUP BY blog_id
-		) l ON b.id = l.blog_id
-		LEFT JOIN (
-			SELECT blog_id, COUNT(*) AS comment_count
-			FROM comments
-			GROUP BY blog_id
-		) c ON b.id = c.blog_id 
-		ORDER BY b.created_at DESC
-    `
+	// ※ いいね数・コメント数は blogs の集計列(like_count, comment_count)から取得すること
+	query, args := buildFetchBlogsQuery(filter)
 
-	// Supabaseからクエリを実行し、全データ取得
-	rows, err := supabase.Pool.Query(supabase.Ctx, query)
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、条件に一致するデータを取得
+	rows, err := r.DB.Query(ctx, query, args...)
 	if err != nil {
 		logger.ErrorLog.Printf("Failed to fetch blogs: %v", err)
 		return nil, err
@@ -47,8 +39,6 @@ func (r *BlogRepositoryImpl) FetchBlogs() ([]models.BlogData, error) {
 	// 結果をスキャンしてブログデータをリストに追加
 	for rows.Next() {
 		var blog models.BlogData
-		var likeCount int
-		var commentCnt int
 
 		err := rows.Scan(
 			&blog.ID,
@@ -58,19 +48,19 @@ func (r *BlogRepositoryImpl) FetchBlogs() ([]models.BlogData, error) {
 			&blog.GithubUrl,
 			&blog.Category,
 			&blog.Tags,
-			&likeCount,
-			&commentCnt,
+			&blog.Likes,
+			&blog.CommentCnt,
 			&blog.CreatedAt,
 			&blog.UpdatedAt,
+			&blog.Status,
+			&blog.PublishedAt,
+			&blog.Slug,
 		)
 		if err != nil {
 			logger.ErrorLog.Printf("Failed to scan blog: %v", err)
 			return nil, err
 		}
 
-		// `Likes` フィールドにキャストして代入
-		blog.Likes = int8(likeCount)
-		blog.CommentCnt = int8(commentCnt)
 		blogs = append(blogs, blog)
 	}
 
@@ -83,33 +73,51 @@ func (r *BlogRepositoryImpl) FetchBlogs() ([]models.BlogData, error) {
 	return blogs, nil
 }
 
+// 検索条件に一致するブログの総件数を取得する
+// カーソルと取得件数は無視する。
+func (r *BlogRepositoryImpl) CountBlogs(ctx context.Context, filter models.BlogListFilter) (int, error) {
+	logger.InfoLog.Printf("CountBlogs start...")
+
+	query, args := buildCountBlogsQuery(filter)
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、件数を取得
+	var total int
+	err := r.DB.QueryRow(ctx, query, args...).Scan(&total)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to count blogs: %v", err)
+		return 0, err
+	}
+
+	logger.InfoLog.Printf("Counted %d blogs", total)
+	return total, nil
+}
+
 // 指定されたユーザーIDに一致するブログデータを取得する
-func (r *BlogRepositoryImpl) FetchBlogsByUserId(userId string) ([]models.BlogData, error) {
+// includeUnpublished が false の場合は公開済みのブログのみ取得する。
+func (r *BlogRepositoryImpl) FetchBlogsByUserId(ctx context.Context, userId string, includeUnpublished bool) ([]models.BlogData, error) {
 	logger.InfoLog.Printf("FetchBlogsByUserId start...")
 
-	// ※ blogs と blogs_likes テーブルを結合し、いいね数を集計して取得すること
+	// ※ いいね数・コメント数は blogs の集計列(like_count, comment_count)から取得すること
 	query := `
 		SELECT b.id, b.user_id, b.title, b.description, b.github_url, b.category, b.tags, 
-				COALESCE(l.like_count, 0) AS likes,
-				COALESCE(c.comment_count, 0) AS comment_cnt,
-				b.created_at, b.updated_at
-		FROM blogs b
-		LEFT JOIN (
-			SELECT blog_id, COUNT(*) AS like_count
-			FROM blogs_likes
-			GROUP BY blog_id
-		) l ON b.id = l.blog_id
-		LEFT JOIN (
-			SELECT blog_id, COUNT(*) AS comment_count
-			FROM comments
-			GROUP BY blog_id
-		) c ON b.id = c.blog_id 
-		WHERE b.user_id = $1
+				b.like_count,
+				b.comment_count,
+				b.created_at, b.updated_at, b.status, b.published_at, b.slug
+		FROM blogs b 
+		WHERE b.user_id = $1 AND b.deleted_at IS NULL AND ($2 OR b.status = 'published')
 		ORDER BY b.created_at DESC
 	`
 
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
 	// Supabaseからクエリを実行し、条件に一致するデータを取得
-	rows, err := supabase.Pool.Query(supabase.Ctx, query, userId)
+	rows, err := r.DB.Query(ctx, query, userId, includeUnpublished)
 	if err != nil {
 		logger.ErrorLog.Printf("Failed to fetch blogs: %v", err)
 		return nil, err
@@ -122,8 +130,6 @@ func (r *BlogRepositoryImpl) FetchBlogsByUserId(userId string) ([]models.BlogDat
 	// 結果をスキャンしてブログデータをリストに追加
 	for rows.Next() {
 		var blog models.BlogData
-		var likeCount int
-		var commentCnt int
 
 		err := rows.Scan(
 			&blog.ID,
@@ -133,20 +139,19 @@ func (r *BlogRepositoryImpl) FetchBlogsByUserId(userId string) ([]models.BlogDat
 			&blog.GithubUrl,
 			&blog.Category,
 			&blog.Tags,
-			&likeCount,
-			&commentCnt,
+			&blog.Likes,
+			&blog.CommentCnt,
 			&blog.CreatedAt,
 			&blog.UpdatedAt,
+			&blog.Status,
+			&blog.PublishedAt,
+			&blog.Slug,
 		)
 		if err != nil {
 			logger.ErrorLog.Printf("Failed to scan blog: %v", err)
 			return nil, err
 		}
 
-		// `Likes` フィールドにキャストして代入
-		blog.Likes = int8(likeCount)
-		blog.CommentCnt = int8(commentCnt)
-
 		blogs = append(blogs, blog)
 	}
 
@@ -159,34 +164,27 @@ func (r *BlogRepositoryImpl) FetchBlogsByUserId(userId string) ([]models.BlogDat
 	return blogs, nil
 }
 
-// 指定されたIDに一致するブログデータを取得する
-func (r *BlogRepositoryImpl) FetchBlogById(id string) (*models.BlogData, error) {
+// 指定されたIDに一致するブログデータを本文とともに取得する
+// 公開状態にかかわらず取得するため、公開範囲の判定は呼び出し側で行うこと。
+func (r *BlogRepositoryImpl) FetchBlogById(ctx context.Context, id string) (*models.BlogData, error) {
 	logger.InfoLog.Printf("FetchBlogById start...")
 
-	// ※ blogs と blogs_likes テーブルを結合し、いいね数を集計して取得すること
+	// ※ いいね数・コメント数は blogs の集計列(like_count, comment_count)から取得すること
 	query := `
         SELECT b.id, b.user_id, b.title, b.description, b.github_url, b.category, b.tags,
-				COALESCE(l.like_count, 0) AS likes,
-				COALESCE(c.comment_count, 0) AS comment_cnt,
-				b.created_at, b.updated_at
+				b.like_count,
+				b.comment_count,
+				b.created_at, b.updated_at, b.status, b.published_at, b.slug, b.body_markdown
         FROM blogs b
-		LEFT JOIN (
-			SELECT blog_id, COUNT(*) AS like_count
-			FROM blogs_likes
-			GROUP BY blog_id
-		) l ON b.id = l.blog_id
-		LEFT JOIN (
-			SELECT blog_id, COUNT(*) AS comment_count
-			FROM comments
-			GROUP BY blog_id
-		) c ON b.id = c.blog_id
-        WHERE b.id = $1
+        WHERE b.id = $1 AND b.deleted_at IS NULL
     `
 
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
 	// Supabaseからクエリを実行し、条件に一致するデータを取得
-	row := supabase.Pool.QueryRow(supabase.Ctx, query, id)
-	var likeCount int
-	var commentCnt int
+	row := r.DB.QueryRow(ctx, query, id)
 
 	var blog models.BlogData
 	err := row.Scan(
@@ -197,10 +195,14 @@ func (r *BlogRepositoryImpl) FetchBlogById(id string) (*models.BlogData, error)
 		&blog.GithubUrl,
 		&blog.Category,
 		&blog.Tags,
-		&likeCount,
-		&commentCnt,
+		&blog.Likes,
+		&blog.CommentCnt,
 		&blog.CreatedAt,
 		&blog.UpdatedAt,
+		&blog.Status,
+		&blog.PublishedAt,
+		&blog.Slug,
+		&blog.BodyMarkdown,
 	)
 
 	if err != nil {
@@ -208,16 +210,13 @@ func (r *BlogRepositoryImpl) FetchBlogById(id string) (*models.BlogData, error)
 		return nil, err
 	}
 
-	// `Likes` フィールドにキャストして代入
-	blog.Likes = int8(likeCount)
-	blog.CommentCnt = int8(commentCnt)
-
 	logger.InfoLog.Printf("Fetched blog: %v", blog)
 	return &blog, nil
 }
 
 // ブログデータの作成
-func (r *BlogRepositoryImpl) CreateBlog(userId, title, githubUrl, category, description, tags string) (*models.BlogData, error) {
+// スラッグはタイトルから生成し、他のブログと重複する場合は連番を付ける。
+func (r *BlogRepositoryImpl) CreateBlog(ctx context.Context, userId, title, githubUrl, category, description, tags, bodyMarkdown string) (*models.BlogData, error) {
 	logger.InfoLog.Printf("CreateBlog start...")
 
 	if userId == "" {
@@ -228,16 +227,42 @@ func (r *BlogRepositoryImpl) CreateBlog(userId, title, githubUrl, category, desc
 	}
 
 	query := `
-		INSERT INTO blogs (user_id, title, github_url, category, description, tags)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, user_id, title, description, github_url, category, tags, likes, comment_cnt, created_at, updated_at
+		INSERT INTO blogs (user_id, title, github_url, category, description, tags, slug, body_markdown)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, user_id, title, description, github_url, category, tags, like_count, comment_count, created_at, updated_at, status, published_at, slug, body_markdown
 	`
 
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// ブログとタグの紐付けを同一トランザクションで登録する
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// タグを正規のタグに解決(別名は置き換え、未登録のタグは作成)
+	resolvedTags, err := repositories_tags.ResolveTags(ctx, tx, repositories_tags.SplitTagNames(tags))
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to resolve tags: %v", err)
+		return nil, err
+	}
+
+	// タイトルから他のブログと重複しないスラッグを生成
+	slug, err := uniqueBlogSlug(ctx, tx, utils_slug.Generate(title))
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to generate blog slug: %v", err)
+		return nil, err
+	}
+
 	// Supabaseからクエリを実行し、新しいブログデータを作成
-	row := supabase.Pool.QueryRow(supabase.Ctx, query, userId, title, githubUrl, category, description, tags)
+	row := tx.QueryRow(ctx, query, userId, title, githubUrl, category, description, repositories_tags.JoinTags(resolvedTags), slug, bodyMarkdown)
 	// 結果をスキャンして新しいブログデータを返す
 	var blog models.BlogData
-	err := row.Scan(
+	err = row.Scan(
 		&blog.ID,
 		&blog.UserId,
 		&blog.Title,
@@ -249,54 +274,85 @@ func (r *BlogRepositoryImpl) CreateBlog(userId, title, githubUrl, category, desc
 		&blog.CommentCnt,
 		&blog.CreatedAt,
 		&blog.UpdatedAt,
+		&blog.Status,
+		&blog.PublishedAt,
+		&blog.Slug,
+		&blog.BodyMarkdown,
 	)
 	if err != nil {
 		logger.ErrorLog.Printf("Failed to create blog: %v", err)
 		return nil, err
 	}
 
+	if err := repositories_tags.ReplaceBlogTags(ctx, tx, blog.ID, resolvedTags); err != nil {
+		logger.ErrorLog.Printf("Failed to create blog tags: %v", err)
+		return nil, err
+	}
+
+	// コミット時に他のインスタンスへ変更を通知する
+	supabase.NotifyChange(ctx, tx, supabase.ChangeBlogs)
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return nil, err
+	}
+
 	logger.InfoLog.Printf("Created blog: %v", blog)
 	return &blog, nil
 }
 
 // ブログデータの更新
-func (r *BlogRepositoryImpl) UpdateBlog(id, title, githubUrl, category, description, tags string) (*models.BlogData, error) {
+// 更新前の内容を userId を作成者とする版として blog_revisions に保存する。
+func (r *BlogRepositoryImpl) UpdateBlog(ctx context.Context, id, userId, title, githubUrl, category, description, tags, bodyMarkdown string) (*models.BlogData, error) {
 	logger.InfoLog.Printf("UpdateBlog start...")
 
-	// ※ blogs と blogs_likes テーブルを結合し、いいね数を集計して取得すること
+	// ※ いいね数・コメント数は blogs の集計列(like_count, comment_count)から取得すること
 	query := `
         WITH updated_blog AS (
             UPDATE blogs
-            SET title = $2, github_url = $3, category = $4, description = $5, tags = $6
-            WHERE id = $1
-            RETURNING id, user_id, title, description, github_url, category, tags, created_at, updated_at
+            SET title = $2, github_url = $3, category = $4, description = $5, tags = $6, body_markdown = $7
+            WHERE id = $1 AND deleted_at IS NULL
+            RETURNING id, user_id, title, description, github_url, category, tags, like_count, comment_count, created_at, updated_at, status, published_at, slug, body_markdown
         )
         SELECT ub.id, ub.user_id, ub.title, ub.description, ub.github_url, ub.category, ub.tags, 
-               COALESCE(l.like_count, 0) AS likes,
-			   COALESCE(c.comment_count, 0) AS comment_cnt,
-			   ub.created_at, ub.updated_at
+               ub.like_count,
+			   ub.comment_count,
+			   ub.created_at, ub.updated_at, ub.status, ub.published_at, ub.slug, ub.body_markdown
         FROM updated_blog ub
-        LEFT JOIN (
-			SELECT blog_id, COUNT(*) AS like_count
-			FROM blogs_likes
-			GROUP BY blog_id
-		) l ON ub.id = l.blog_id
-		LEFT JOIN (
-			SELECT blog_id, COUNT(*) AS comment_count
-			FROM comments
-			GROUP BY blog_id
-		) c ON ub.id = c.blog_id
     `
 
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// ブログ・版履歴・タグの紐付けを同一トランザクションで更新する
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// 更新前の内容を版履歴に保存(ブログ行のロックも兼ねる)
+	if err := repositories_blog_revisions.SnapshotBlog(ctx, tx, id, userId); err != nil {
+		logger.ErrorLog.Printf("Failed to snapshot blog: %v", err)
+		return nil, err
+	}
+
+	// タグを正規のタグに解決(別名は置き換え、未登録のタグは作成)
+	resolvedTags, err := repositories_tags.ResolveTags(ctx, tx, repositories_tags.SplitTagNames(tags))
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to resolve tags: %v", err)
+		return nil, err
+	}
+
 	// Supabaseからクエリを実行し、指定されたブログデータを更新
-	row := supabase.Pool.QueryRow(supabase.Ctx, query, id, title, githubUrl, category, description, tags)
+	row := tx.QueryRow(ctx, query, id, title, githubUrl, category, description, repositories_tags.JoinTags(resolvedTags), bodyMarkdown)
 
 	// 結果をスキャンして更新されたブログデータを返す
-	var likeCount int
-	var commentCnt int
 	var blog models.BlogData
 
-	err := row.Scan(
+	err = row.Scan(
 		&blog.ID,
 		&blog.UserId,
 		&blog.Title,
@@ -304,26 +360,40 @@ func (r *BlogRepositoryImpl) UpdateBlog(id, title, githubUrl, category, descript
 		&blog.GithubUrl,
 		&blog.Category,
 		&blog.Tags,
-		&likeCount,
-		&commentCnt,
+		&blog.Likes,
+		&blog.CommentCnt,
 		&blog.CreatedAt,
 		&blog.UpdatedAt,
+		&blog.Status,
+		&blog.PublishedAt,
+		&blog.Slug,
+		&blog.BodyMarkdown,
 	)
 
-	// キャストして代入
-	blog.Likes = int8(likeCount)
-	blog.CommentCnt = int8(commentCnt)
 	if err != nil {
 		logger.ErrorLog.Printf("Failed to update blog: %v", err)
 		return nil, err
 	}
 
+	if err := repositories_tags.ReplaceBlogTags(ctx, tx, blog.ID, resolvedTags); err != nil {
+		logger.ErrorLog.Printf("Failed to update blog tags: %v", err)
+		return nil, err
+	}
+
+	// コミット時に他のインスタンスへ変更を通知する
+	supabase.NotifyChange(ctx, tx, supabase.ChangeBlogs)
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return nil, err
+	}
+
 	logger.InfoLog.Printf("Updated blog: %v", blog)
 	return &blog, nil
 }
 
-// ブログデータの削除
-func (r *BlogRepositoryImpl) DeleteBlog(id string) error {
+// ブログデータの削除(ゴミ箱へ移動)
+func (r *BlogRepositoryImpl) DeleteBlog(ctx context.Context, id string) error {
 	logger.InfoLog.Printf("DeleteBlog start...")
 
 	if id == "" {
@@ -333,77 +403,48 @@ func (r *BlogRepositoryImpl) DeleteBlog(id string) error {
 		return errors.New("invalid id format: must be a valid UUID")
 	}
 
+	// ゴミ箱へ移動する(保持期間を過ぎると PurgeDeletedBlogs で完全に削除される)
 	query := `
-		DELETE FROM blogs
-		WHERE id = $1
+		UPDATE blogs
+		SET deleted_at = now()
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
 	// Supabaseからクエリを実行し、指定されたブログデータを削除
-	_, err := supabase.Pool.Exec(supabase.Ctx, query, id)
+	_, err := r.DB.Exec(ctx, query, id)
 	if err != nil {
 		logger.ErrorLog.Printf("Failed to delete blog: %v", err)
 		return err
 	}
+	supabase.NotifyChange(ctx, r.DB, supabase.ChangeBlogs)
 
 	logger.InfoLog.Println("Deleted blog successfully")
 	return nil
 }
 
-// ブログカテゴリ一覧を取得する
-func (r *BlogRepositoryImpl) FetchBlogCategories() ([]string, error) {
-	logger.InfoLog.Printf("FetchBlogCategories start...")
-
-	query := `
-		SELECT DISTINCT category
-		FROM blogs
-		ORDER BY category
-	`
-
-	// Supabaseからクエリを実行し、全カテゴリデータを取得
-	rows, err := supabase.Pool.Query(supabase.Ctx, query)
-	if err != nil {
-		logger.ErrorLog.Printf("Failed to fetch blog categories: %v", err)
-		return nil, err
-	}
-	logger.InfoLog.Println("Fetched blog categories successfully")
-	defer rows.Close()
-
-	var categories []string
-
-	// 結果をスキャンしてカテゴリデータをリストに追加
-	for rows.Next() {
-		var category string
-
-		err := rows.Scan(&category)
-		if err != nil {
-			logger.ErrorLog.Printf("Failed to scan category: %v", err)
-			return nil, err
-		}
-
-		categories = append(categories, category)
-	}
-
-	if rows.Err() != nil {
-		logger.ErrorLog.Printf("Failed to fetch blog categories: %v", rows.Err())
-		return nil, rows.Err()
-	}
-
-	logger.InfoLog.Printf("Fetched %d blog categories", len(categories))
-	return categories, nil
-}
-
-// ブログタグ一覧を取得する
-func (r *BlogRepositoryImpl) FetchBlogTags() ([]string, error) {
+// ブログタグ一覧を使用しているブログ数とともに取得する
+func (r *BlogRepositoryImpl) FetchBlogTags(ctx context.Context) ([]models.TagCount, error) {
 	logger.InfoLog.Printf("FetchBlogTags start...")
 
 	query := `
-		SELECT DISTINCT tags
-		FROM blogs
-		ORDER BY tags
+		SELECT t.name, COUNT(*) AS count
+		FROM tags t
+		JOIN blog_tags bt ON bt.tag_id = t.id
+		JOIN blogs b ON b.id = bt.blog_id AND b.deleted_at IS NULL AND b.status = 'published'
+		GROUP BY t.id, t.name
+		ORDER BY lower(t.name)
 	`
 
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
 	// Supabaseからクエリを実行し、全タグデータを取得
-	rows, err := supabase.Pool.Query(supabase.Ctx, query)
+	rows, err := r.DB.Query(ctx, query)
 	if err != nil {
 		logger.ErrorLog.Printf("Failed to fetch blog tags: %v", err)
 		return nil, err
@@ -411,13 +452,13 @@ func (r *BlogRepositoryImpl) FetchBlogTags() ([]string, error) {
 	logger.InfoLog.Println("Fetched blog tags successfully")
 	defer rows.Close()
 
-	var tags []string
+	var tags []models.TagCount
 
 	// 結果をスキャンしてタグデータをリストに追加
 	for rows.Next() {
-		var tag string
+		var tag models.TagCount
 
-		err := rows.Scan(&tag)
+		err := rows.Scan(&tag.Name, &tag.Count)
 		if err != nil {
 			logger.ErrorLog.Printf("Failed to scan tag: %v", err)
 			return nil, err
@@ -436,25 +477,25 @@ func (r *BlogRepositoryImpl) FetchBlogTags() ([]string, error) {
 }
 
 // 人気のあるブログを取得する
-func (r *BlogRepositoryImpl) FetchBlogPopular(count int) ([]models.BlogData, error) {
+func (r *BlogRepositoryImpl) FetchBlogPopular(ctx context.Context, count int) ([]models.BlogData, error) {
 	logger.InfoLog.Printf("FetchBlogPopular start...")
 
 	query := `
 		SELECT b.id, b.user_id, b.title, 
-			   COALESCE(l.like_count, 0) AS likes,
-			   b.created_at, b.updated_at
+			   b.like_count,
+			   b.created_at, b.updated_at, b.status, b.published_at, b.slug
 		FROM blogs b
-		LEFT JOIN (
-			SELECT blog_id, COUNT(*) AS like_count
-			FROM blogs_likes
-			GROUP BY blog_id
-		) l ON b.id = l.blog_id
-		ORDER BY likes DESC
+		WHERE b.deleted_at IS NULL AND b.status = 'published'
+		ORDER BY b.like_count DESC, b.published_at DESC, b.id DESC
 		LIMIT $1
 	`
 
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
 	// Supabaseからクエリを実行し、人気のあるブログデータを取得
-	rows, err := supabase.Pool.Query(supabase.Ctx, query, count)
+	rows, err := r.DB.Query(ctx, query, count)
 	if err != nil {
 		logger.ErrorLog.Printf("Failed to fetch popular blogs: %v", err)
 		return nil, err
@@ -467,24 +508,23 @@ func (r *BlogRepositoryImpl) FetchBlogPopular(count int) ([]models.BlogData, err
 	// 結果をスキャンしてブログデータをリストに追加
 	for rows.Next() {
 		var blog models.BlogData
-		var likeCount int
 
 		err := rows.Scan(
 			&blog.ID,
 			&blog.UserId,
 			&blog.Title,
-			&likeCount,
+			&blog.Likes,
 			&blog.CreatedAt,
 			&blog.UpdatedAt,
+			&blog.Status,
+			&blog.PublishedAt,
+			&blog.Slug,
 		)
 		if err != nil {
 			logger.ErrorLog.Printf("Failed to scan blog: %v", err)
 			return nil, err
 		}
 
-		// `Likes` フィールドにキャストして代入
-		blog.Likes = int8(likeCount)
-
 		// 空のものも設定しておく
 		blog.Description = ""
 		blog.GithubUrl = ""
@@ -502,3 +542,66 @@ func (r *BlogRepositoryImpl) FetchBlogPopular(count int) ([]models.BlogData, err
 	logger.InfoLog.Printf("Fetched %d popular blogs", len(blogs))
 	return blogs, nil
 }
+
+// 検索語に一致するブログをスコア順に取得する
+func (r *BlogRepositoryImpl) SearchBlogs(ctx context.Context, terms []string, limit int) ([]models.BlogSearchResult, error) {
+	logger.InfoLog.Printf("SearchBlogs start...")
+
+	if len(terms) == 0 {
+		return nil, errors.New("terms cannot be empty")
+	}
+
+	query, args := buildSearchBlogsQuery(terms, limit)
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、検索語に一致するデータを取得
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to search blogs: %v", err)
+		return nil, err
+	}
+	logger.InfoLog.Println("Searched blogs successfully")
+	defer rows.Close()
+
+	var results []models.BlogSearchResult
+
+	// 結果をスキャンして検索結果をリストに追加
+	for rows.Next() {
+		var result models.BlogSearchResult
+
+		err := rows.Scan(
+			&result.ID,
+			&result.UserId,
+			&result.Title,
+			&result.Description,
+			&result.GithubUrl,
+			&result.Category,
+			&result.Tags,
+			&result.Likes,
+			&result.CommentCnt,
+			&result.CreatedAt,
+			&result.UpdatedAt,
+			&result.Status,
+			&result.PublishedAt,
+			&result.Slug,
+			&result.Score,
+		)
+		if err != nil {
+			logger.ErrorLog.Printf("Failed to scan blog: %v", err)
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	if rows.Err() != nil {
+		logger.ErrorLog.Printf("Failed to search blogs: %v", rows.Err())
+		return nil, rows.Err()
+	}
+
+	logger.InfoLog.Printf("Searched %d blogs", len(results))
+	return results, nil
+}
@@ -0,0 +1,45 @@
+package repositories_blogs
+
+import (
+	"backend/logger"
+	"backend/supabase"
+	"context"
+)
+
+// いいね数・コメント数の集計値を blogs_likes・comments から再計算する
+// 集計値はトリガーで更新されるが、トリガー導入前のデータや手動の修正でずれた場合に使用する。
+// 集計値が食い違っていたブログの件数を返す。
+func (r *BlogRepositoryImpl) ReconcileBlogCounters(ctx context.Context) (int, error) {
+	logger.InfoLog.Printf("ReconcileBlogCounters start...")
+
+	query := `
+		WITH counts AS (
+			SELECT b.id,
+				(SELECT COUNT(*) FROM blogs_likes l WHERE l.blog_id = b.id) AS like_count,
+				(SELECT COUNT(*) FROM comments c WHERE c.blog_id = b.id) AS comment_count
+			FROM blogs b
+		)
+		UPDATE blogs b
+		SET like_count = counts.like_count, comment_count = counts.comment_count
+		FROM counts
+		WHERE b.id = counts.id
+			AND (b.like_count <> counts.like_count OR b.comment_count <> counts.comment_count)
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tag, err := r.DB.Exec(ctx, query)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to reconcile blog counters: %v", err)
+		return 0, err
+	}
+
+	count := int(tag.RowsAffected())
+	if count > 0 {
+		supabase.NotifyChange(ctx, r.DB, supabase.ChangeBlogs)
+	}
+	logger.InfoLog.Printf("Reconciled counters of %d blogs", count)
+	return count, nil
+}
@@ -1,25 +1,49 @@
 package repositories_blogs
 
-import "backend/models"
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"time"
+)
 
 // BlogRepositoryインターフェース
 type BlogRepository interface {
-	FetchBlogs() ([]models.BlogData, error)
-	FetchBlogsByUserId(userId string) ([]models.BlogData, error)
-	FetchBlogById(id string) (*models.BlogData, error)
+	FetchBlogs(ctx context.Context, filter models.BlogListFilter) ([]models.BlogData, error)
+	CountBlogs(ctx context.Context, filter models.BlogListFilter) (int, error)
+	FetchBlogsByUserId(ctx context.Context, userId string, includeUnpublished bool) ([]models.BlogData, error)
+	FetchBlogById(ctx context.Context, id string) (*models.BlogData, error)
+	FetchBlogBySlug(ctx context.Context, slug string) (*models.BlogData, error)
 
-	CreateBlog(userId, title, githubUrl, category, description, tags string) (*models.BlogData, error)
-	UpdateBlog(id, title, githubUrl, category, description, tags string) (*models.BlogData, error)
-	DeleteBlog(id string) error
+	CreateBlog(ctx context.Context, userId, title, githubUrl, category, description, tags, bodyMarkdown string) (*models.BlogData, error)
+	UpdateBlog(ctx context.Context, id, userId, title, githubUrl, category, description, tags, bodyMarkdown string) (*models.BlogData, error)
+	DeleteBlog(ctx context.Context, id string) error
 
-	FetchBlogCategories() ([]string, error)
-	FetchBlogTags() ([]string, error)
-	FetchBlogPopular(count int) ([]models.BlogData, error)
+	FetchDeletedBlogs(ctx context.Context) ([]models.BlogData, error)
+	FetchDeletedBlogsByUserId(ctx context.Context, userId string) ([]models.BlogData, error)
+	FetchDeletedBlogById(ctx context.Context, id string) (*models.BlogData, error)
+	RestoreBlog(ctx context.Context, id string) (*models.BlogData, error)
+	PurgeDeletedBlogs(ctx context.Context, before time.Time) (int, error)
+
+	UpdateBlogStatus(ctx context.Context, id, currentStatus, status string, publishedAt *time.Time) (*models.BlogData, error)
+	PublishScheduledBlogs(ctx context.Context, now time.Time) (int, error)
+
+	UpdateBlogSlug(ctx context.Context, id, slug string) (*models.BlogData, error)
+
+	ReconcileBlogCounters(ctx context.Context) (int, error)
+
+	FetchBlogTags(ctx context.Context) ([]models.TagCount, error)
+	FetchBlogPopular(ctx context.Context, count int) ([]models.BlogData, error)
+	SearchBlogs(ctx context.Context, terms []string, limit int) ([]models.BlogSearchResult, error)
 }
 
-type BlogRepositoryImpl struct{}
+type BlogRepositoryImpl struct {
+	DB supabase.DB
+}
 
 // BlogRepositoryインターフェースを実装したBlogRepositoryImplのポインタを返す
-func NewBlogRepository() BlogRepository {
-	return &BlogRepositoryImpl{}
+func NewBlogRepository(db supabase.DB) BlogRepository {
+	return &BlogRepositoryImpl{
+		DB: db,
+	}
 }
@@ -0,0 +1,109 @@
+package repositories_blogs
+
+import (
+	"backend/models"
+	"fmt"
+	"strings"
+)
+
+// ブログ一覧の絞り込み条件を組み立てる
+// 条件はWHERE句(先頭の "WHERE" を含む)として返し、プレースホルダの値は args に追加する。
+func buildBlogListConditions(filter models.BlogListFilter, args []interface{}) (string, []interface{}) {
+	// ゴミ箱内・未公開のブログは常に除外する
+	conditions := []string{"b.deleted_at IS NULL", "b.status = 'published'"}
+
+	// プレースホルダを追加して番号を返す
+	bind := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Category != "" {
+		conditions = append(conditions, "b.category = "+bind(filter.Category))
+	}
+	if filter.Tag != "" {
+		// タグ名または別名に一致するタグが付いたブログに絞り込む(大文字小文字を区別しない)
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+				SELECT 1
+				FROM blog_tags bt
+				JOIN tags t ON t.id = bt.tag_id
+				WHERE bt.blog_id = b.id
+				AND (
+					lower(t.name) = lower(%[1]s)
+					OR t.id IN (SELECT tag_id FROM tag_aliases WHERE lower(alias) = lower(%[1]s))
+				)
+			)`,
+			bind(filter.Tag),
+		))
+	}
+	if filter.UserId != "" {
+		conditions = append(conditions, "b.user_id = "+bind(filter.UserId)+"::uuid")
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "b.published_at >= "+bind(*filter.From))
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "b.published_at <= "+bind(*filter.To))
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// 並び順に対応するORDER BY句とカーソル比較の演算子・比較キーを返す
+// 同値の場合に順序が揺れないよう、公開日時とIDを第2・第3キーにする。
+func blogListOrder(sort string) (orderBy string, op string, keys string) {
+	switch sort {
+	case models.BlogSortOldest:
+		return "b.published_at ASC, b.id ASC", ">", "(b.published_at, b.id)"
+	case models.BlogSortMostLiked:
+		return "b.like_count DESC, b.published_at DESC, b.id DESC", "<", "(b.like_count, b.published_at, b.id)"
+	case models.BlogSortMostCommented:
+		return "b.comment_count DESC, b.published_at DESC, b.id DESC", "<", "(b.comment_count, b.published_at, b.id)"
+	default:
+		return "b.published_at DESC, b.id DESC", "<", "(b.published_at, b.id)"
+	}
+}
+
+// ブログ一覧を取得するクエリを組み立てる
+func buildFetchBlogsQuery(filter models.BlogListFilter) (string, []interface{}) {
+	where, args := buildBlogListConditions(filter, nil)
+	orderBy, op, keys := blogListOrder(filter.Sort)
+
+	// カーソルが指定された場合は、カーソル位置より後ろの行のみ対象にする
+	after := ""
+	if filter.After != nil {
+		switch filter.Sort {
+		case models.BlogSortMostLiked, models.BlogSortMostCommented:
+			args = append(args, filter.After.Count, filter.After.PublishedAt, filter.After.ID)
+			after = fmt.Sprintf("AND %s %s ($%d, $%d, $%d::uuid)", keys, op, len(args)-2, len(args)-1, len(args))
+		default:
+			args = append(args, filter.After.PublishedAt, filter.After.ID)
+			after = fmt.Sprintf("AND %s %s ($%d, $%d::uuid)", keys, op, len(args)-1, len(args))
+		}
+	}
+
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf(`
+		SELECT b.id, b.user_id, b.title, b.description, b.github_url, b.category, b.tags,
+				b.like_count, b.comment_count, b.created_at, b.updated_at, b.status, b.published_at, b.slug
+		FROM blogs b
+		%s
+		%s
+		ORDER BY %s
+		LIMIT $%d
+	`, where, after, orderBy, len(args))
+
+	return query, args
+}
+
+// ブログの総件数を取得するクエリを組み立てる
+func buildCountBlogsQuery(filter models.BlogListFilter) (string, []interface{}) {
+	where, args := buildBlogListConditions(filter, nil)
+	query := fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM blogs b
+		%s
+	`, where)
+
+	return query, args
+}
@@ -2,6 +2,8 @@ package repositories_blogs
 
 import (
 	"backend/models"
+	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,23 +12,28 @@ type MockBlogRepository struct {
 	mock.Mock
 }
 
-func (m *MockBlogRepository) FetchBlogs() ([]models.BlogData, error) {
-	args := m.Called()
+func (m *MockBlogRepository) FetchBlogs(ctx context.Context, filter models.BlogListFilter) ([]models.BlogData, error) {
+	args := m.Called(filter)
 	if args.Get(0) != nil {
 		return args.Get(0).([]models.BlogData), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockBlogRepository) FetchBlogsByUserId(userId string) ([]models.BlogData, error) {
-	args := m.Called(userId)
+func (m *MockBlogRepository) CountBlogs(ctx context.Context, filter models.BlogListFilter) (int, error) {
+	args := m.Called(filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBlogRepository) FetchBlogsByUserId(ctx context.Context, userId string, includeUnpublished bool) ([]models.BlogData, error) {
+	args := m.Called(userId, includeUnpublished)
 	if args.Get(0) != nil {
 		return args.Get(0).([]models.BlogData), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockBlogRepository) FetchBlogById(id string) (*models.BlogData, error) {
+func (m *MockBlogRepository) FetchBlogById(ctx context.Context, id string) (*models.BlogData, error) {
 	args := m.Called(id)
 	if args.Get(0) != nil {
 		return args.Get(0).(*models.BlogData), args.Error(1)
@@ -34,47 +41,118 @@ func (m *MockBlogRepository) FetchBlogById(id string) (*models.BlogData, error)
 	return nil, args.Error(1)
 }
 
-func (m *MockBlogRepository) CreateBlog(userId, title, githubUrl, category, description, tags string) (*models.BlogData, error) {
-	args := m.Called(userId, title, githubUrl, category, description, tags)
+func (m *MockBlogRepository) CreateBlog(ctx context.Context, userId, title, githubUrl, category, description, tags, bodyMarkdown string) (*models.BlogData, error) {
+	args := m.Called(userId, title, githubUrl, category, description, tags, bodyMarkdown)
 	if args.Get(0) != nil {
 		return args.Get(0).(*models.BlogData), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockBlogRepository) UpdateBlog(id, title, githubUrl, category, description, tags string) (*models.BlogData, error) {
-	args := m.Called(id, title, githubUrl, category, description, tags)
+func (m *MockBlogRepository) UpdateBlog(ctx context.Context, id, userId, title, githubUrl, category, description, tags, bodyMarkdown string) (*models.BlogData, error) {
+	args := m.Called(id, userId, title, githubUrl, category, description, tags, bodyMarkdown)
 	if args.Get(0) != nil {
 		return args.Get(0).(*models.BlogData), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockBlogRepository) DeleteBlog(id string) error {
+func (m *MockBlogRepository) DeleteBlog(ctx context.Context, id string) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
-func (m *MockBlogRepository) FetchBlogCategories() ([]string, error) {
+func (m *MockBlogRepository) FetchBlogTags(ctx context.Context) ([]models.TagCount, error) {
 	args := m.Called()
 	if args.Get(0) != nil {
-		return args.Get(0).([]string), args.Error(1)
+		return args.Get(0).([]models.TagCount), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockBlogRepository) FetchBlogTags() ([]string, error) {
+func (m *MockBlogRepository) FetchBlogPopular(ctx context.Context, count int) ([]models.BlogData, error) {
+	args := m.Called(count)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.BlogData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBlogRepository) SearchBlogs(ctx context.Context, terms []string, limit int) ([]models.BlogSearchResult, error) {
+	args := m.Called(terms, limit)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.BlogSearchResult), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBlogRepository) FetchDeletedBlogs(ctx context.Context) ([]models.BlogData, error) {
 	args := m.Called()
 	if args.Get(0) != nil {
-		return args.Get(0).([]string), args.Error(1)
+		return args.Get(0).([]models.BlogData), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockBlogRepository) FetchBlogPopular(count int) ([]models.BlogData, error) {
-	args := m.Called(count)
+func (m *MockBlogRepository) FetchDeletedBlogsByUserId(ctx context.Context, userId string) ([]models.BlogData, error) {
+	args := m.Called(userId)
 	if args.Get(0) != nil {
 		return args.Get(0).([]models.BlogData), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
+
+func (m *MockBlogRepository) FetchDeletedBlogById(ctx context.Context, id string) (*models.BlogData, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.BlogData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBlogRepository) RestoreBlog(ctx context.Context, id string) (*models.BlogData, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.BlogData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBlogRepository) PurgeDeletedBlogs(ctx context.Context, before time.Time) (int, error) {
+	args := m.Called(before)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBlogRepository) UpdateBlogStatus(ctx context.Context, id, currentStatus, status string, publishedAt *time.Time) (*models.BlogData, error) {
+	args := m.Called(id, currentStatus, status, publishedAt)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.BlogData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBlogRepository) PublishScheduledBlogs(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(now)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBlogRepository) FetchBlogBySlug(ctx context.Context, slug string) (*models.BlogData, error) {
+	args := m.Called(slug)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.BlogData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBlogRepository) UpdateBlogSlug(ctx context.Context, id, slug string) (*models.BlogData, error) {
+	args := m.Called(id, slug)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.BlogData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBlogRepository) ReconcileBlogCounters(ctx context.Context) (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,51 @@
+package repositories_blogs
+
+import (
+	"backend/models"
+	"fmt"
+	"strings"
+)
+
+// ILIKEのワイルドカードをエスケープする
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ブログを検索するクエリを組み立てる
+// すべての検索語がタイトル・タグ・説明のいずれかに部分一致(大文字小文字を区別しない)するブログを対象とし、
+// 検索語ごとに一致した項目の重みを合計したスコアの降順で並べる。
+func buildSearchBlogsQuery(terms []string, limit int) (string, []interface{}) {
+	var args []interface{}
+	var scores []string
+
+	// ゴミ箱内・未公開のブログは常に除外する
+	conditions := []string{"b.deleted_at IS NULL", "b.status = 'published'"}
+
+	for _, term := range terms {
+		args = append(args, "%"+likeEscaper.Replace(term)+"%")
+		p := fmt.Sprintf("$%d", len(args))
+
+		conditions = append(conditions, fmt.Sprintf(
+			"(b.title ILIKE %[1]s OR b.tags ILIKE %[1]s OR b.description ILIKE %[1]s)", p,
+		))
+		scores = append(scores, fmt.Sprintf(
+			"CASE WHEN b.title ILIKE %[1]s THEN %[2]d ELSE 0 END + "+
+				"CASE WHEN b.tags ILIKE %[1]s THEN %[3]d ELSE 0 END + "+
+				"CASE WHEN b.description ILIKE %[1]s THEN %[4]d ELSE 0 END",
+			p, models.BlogSearchWeightTitle, models.BlogSearchWeightTags, models.BlogSearchWeightDescription,
+		))
+	}
+
+	args = append(args, limit)
+	query := fmt.Sprintf(`
+		SELECT b.id, b.user_id, b.title, b.description, b.github_url, b.category, b.tags,
+				b.like_count,
+				b.comment_count,
+				b.created_at, b.updated_at, b.status, b.published_at, b.slug,
+				(%s) AS score
+		FROM blogs b
+		WHERE %s
+		ORDER BY score DESC, b.published_at DESC, b.id DESC
+		LIMIT $%d
+	`, strings.Join(scores, " + "), strings.Join(conditions, " AND "), len(args))
+
+	return query, args
+}
@@ -0,0 +1,205 @@
+package repositories_blogs
+
+import (
+	"backend/logger"
+	"backend/models"
+	"backend/supabase"
+	utils_slug "backend/utils/slug"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgconn"
+)
+
+// スラッグが他のブログ(変更前のスラッグを含む)と重複する場合のエラー
+var ErrBlogSlugConflict = errors.New("blog slug already exists")
+
+// 一意制約違反をスラッグの重複エラーに変換する
+func slugConflictError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrBlogSlugConflict
+	}
+	return err
+}
+
+// 同じスラッグを同時に採番・変更しないようにロックを取得する
+// ロックはトランザクションの終了時に解放される。
+func lockBlogSlug(ctx context.Context, tx supabase.DB, slug string) error {
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, slug)
+	return err
+}
+
+// 他のブログと重複しないスラッグを返す
+// base が現在のスラッグまたは変更前のスラッグとして使用済みの場合は連番を付ける(例: "hello-2")。
+func uniqueBlogSlug(ctx context.Context, tx supabase.DB, base string) (string, error) {
+	if err := lockBlogSlug(ctx, tx, base); err != nil {
+		return "", err
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT slug FROM blogs WHERE slug = $1 OR slug LIKE $1 || '-%'
+		UNION
+		SELECT slug FROM blog_slug_redirects WHERE slug = $1 OR slug LIKE $1 || '-%'
+	`, base)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	used := make(map[string]bool)
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return "", err
+		}
+		used[slug] = true
+	}
+	if rows.Err() != nil {
+		return "", rows.Err()
+	}
+
+	if !used[base] {
+		return base, nil
+	}
+	for n := 2; ; n++ {
+		if slug := utils_slug.WithSuffix(base, n); !used[slug] {
+			return slug, nil
+		}
+	}
+}
+
+// 指定されたスラッグに一致するブログデータを本文とともに取得する
+// 変更前のスラッグの場合は現在のブログデータを返すため、呼び出し側でスラッグを比較してリダイレクトすること。
+// 公開状態にかかわらず取得するため、公開範囲の判定は呼び出し側で行うこと。
+func (r *BlogRepositoryImpl) FetchBlogBySlug(ctx context.Context, slug string) (*models.BlogData, error) {
+	logger.InfoLog.Printf("FetchBlogBySlug start...")
+
+	query := `
+		SELECT b.id, b.user_id, b.title, b.description, b.github_url, b.category, b.tags,
+				b.like_count,
+				b.comment_count,
+				b.created_at, b.updated_at, b.status, b.published_at, b.slug, b.body_markdown
+		FROM blogs b
+		WHERE (b.slug = $1 OR b.id = (SELECT blog_id FROM blog_slug_redirects WHERE slug = $1))
+			AND b.deleted_at IS NULL
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、条件に一致するデータを取得
+	row := r.DB.QueryRow(ctx, query, slug)
+
+	var blog models.BlogData
+	err := row.Scan(
+		&blog.ID,
+		&blog.UserId,
+		&blog.Title,
+		&blog.Description,
+		&blog.GithubUrl,
+		&blog.Category,
+		&blog.Tags,
+		&blog.Likes,
+		&blog.CommentCnt,
+		&blog.CreatedAt,
+		&blog.UpdatedAt,
+		&blog.Status,
+		&blog.PublishedAt,
+		&blog.Slug,
+		&blog.BodyMarkdown,
+	)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch blog: %v", err)
+		return nil, err
+	}
+
+	logger.InfoLog.Printf("Fetched blog: %v", blog)
+	return &blog, nil
+}
+
+// 指定されたブログのスラッグを変更する
+// 変更前のスラッグは blog_slug_redirects に保存し、古いリンクからリダイレクトできるようにする。
+// 他のブログと重複する場合は ErrBlogSlugConflict を、ブログがない場合は pgx.ErrNoRows を返す。
+func (r *BlogRepositoryImpl) UpdateBlogSlug(ctx context.Context, id, slug string) (*models.BlogData, error) {
+	logger.InfoLog.Printf("UpdateBlogSlug start...")
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockBlogSlug(ctx, tx, slug); err != nil {
+		logger.ErrorLog.Printf("Failed to lock blog slug: %v", err)
+		return nil, err
+	}
+
+	// ブログをロックして変更前のスラッグを取得
+	var oldSlug string
+	err = tx.QueryRow(ctx, `
+		SELECT slug
+		FROM blogs
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	`, id).Scan(&oldSlug)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch blog: %v", err)
+		return nil, err
+	}
+
+	if oldSlug != slug {
+		// 他のブログの現在または変更前のスラッグと重複しないか確認
+		var exists bool
+		err = tx.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM blogs WHERE slug = $1 AND id <> $2)
+				OR EXISTS (SELECT 1 FROM blog_slug_redirects WHERE slug = $1 AND blog_id <> $2)
+		`, slug, id).Scan(&exists)
+		if err != nil {
+			logger.ErrorLog.Printf("Failed to check blog slug: %v", err)
+			return nil, err
+		}
+		if exists {
+			logger.ErrorLog.Printf("Failed to update blog slug: %v", ErrBlogSlugConflict)
+			return nil, ErrBlogSlugConflict
+		}
+
+		// 自身の変更前のスラッグに戻す場合は、そのリダイレクトを削除する
+		if _, err := tx.Exec(ctx, `DELETE FROM blog_slug_redirects WHERE slug = $1`, slug); err != nil {
+			logger.ErrorLog.Printf("Failed to delete blog slug redirect: %v", err)
+			return nil, err
+		}
+
+		// 変更前のスラッグをリダイレクトとして保存し、スラッグを変更する
+		if _, err := tx.Exec(ctx, `INSERT INTO blog_slug_redirects (slug, blog_id) VALUES ($1, $2)`, oldSlug, id); err != nil {
+			logger.ErrorLog.Printf("Failed to create blog slug redirect: %v", err)
+			return nil, slugConflictError(err)
+		}
+		if _, err := tx.Exec(ctx, `UPDATE blogs SET slug = $2 WHERE id = $1`, id, slug); err != nil {
+			logger.ErrorLog.Printf("Failed to update blog slug: %v", err)
+			return nil, slugConflictError(err)
+		}
+
+		// コミット時に他のインスタンスへ変更を通知する
+		supabase.NotifyChange(ctx, tx, supabase.ChangeBlogs)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	blog, err := r.FetchBlogById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog.Printf("Updated blog slug: %v", blog)
+	return blog, nil
+}
@@ -0,0 +1,73 @@
+package repositories_blogs
+
+import (
+	"backend/logger"
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"time"
+)
+
+// 指定されたブログの公開状態と公開日時を更新する
+// 現在の状態が currentStatus のときだけ更新し、一致するブログがない場合は pgx.ErrNoRows を返す。
+func (r *BlogRepositoryImpl) UpdateBlogStatus(ctx context.Context, id, currentStatus, status string, publishedAt *time.Time) (*models.BlogData, error) {
+	logger.InfoLog.Printf("UpdateBlogStatus start...")
+
+	query := `
+		WITH updated_blog AS (
+			UPDATE blogs
+			SET status = $3, published_at = $4
+			WHERE id = $1 AND status = $2 AND deleted_at IS NULL
+			RETURNING id, user_id, title, description, github_url, category, tags, like_count, comment_count, created_at, updated_at, status, published_at, slug
+		)
+		SELECT ub.id, ub.user_id, ub.title, ub.description, ub.github_url, ub.category, ub.tags,
+				ub.like_count,
+				ub.comment_count,
+				ub.created_at, ub.updated_at, ub.status, ub.published_at, ub.slug, NULL::timestamptz AS deleted_at
+		FROM updated_blog ub
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、指定されたブログの公開状態を更新
+	blog, err := scanDeletedBlog(r.DB.QueryRow(ctx, query, id, currentStatus, status, publishedAt))
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to update blog status: %v", err)
+		return nil, err
+	}
+	supabase.NotifyChange(ctx, r.DB, supabase.ChangeBlogs)
+
+	logger.InfoLog.Printf("Updated blog status: %v", blog)
+	return blog, nil
+}
+
+// 公開予定日時が now 以前になった予約中のブログを公開し、公開した件数を返す
+// 条件付きの UPDATE 1文で行うため、複数のインスタンスから同時に実行しても同じブログを二重に公開することはない。
+func (r *BlogRepositoryImpl) PublishScheduledBlogs(ctx context.Context, now time.Time) (int, error) {
+	logger.InfoLog.Printf("PublishScheduledBlogs start...")
+
+	query := `
+		UPDATE blogs
+		SET status = 'published'
+		WHERE status = 'scheduled' AND published_at <= $1 AND deleted_at IS NULL
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tag, err := r.DB.Exec(ctx, query, now)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to publish scheduled blogs: %v", err)
+		return 0, err
+	}
+
+	count := int(tag.RowsAffected())
+	if count > 0 {
+		supabase.NotifyChange(ctx, r.DB, supabase.ChangeBlogs)
+	}
+	logger.InfoLog.Printf("Published %d scheduled blogs", count)
+	return count, nil
+}
@@ -0,0 +1,243 @@
+package repositories_blogs
+
+import (
+	"backend/logger"
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// ゴミ箱内のブログデータをすべて削除日時の新しい順に取得する
+func (r *BlogRepositoryImpl) FetchDeletedBlogs(ctx context.Context) ([]models.BlogData, error) {
+	logger.InfoLog.Printf("FetchDeletedBlogs start...")
+
+	query := `
+		SELECT b.id, b.user_id, b.title, b.description, b.github_url, b.category, b.tags,
+				b.like_count,
+				b.comment_count,
+				b.created_at, b.updated_at, b.status, b.published_at, b.slug, b.deleted_at
+		FROM blogs b
+		WHERE b.deleted_at IS NOT NULL
+		ORDER BY b.deleted_at DESC, b.id DESC
+	`
+	return r.queryDeletedBlogs(ctx, query)
+}
+
+// 指定されたユーザーのゴミ箱内のブログデータを削除日時の新しい順に取得する
+func (r *BlogRepositoryImpl) FetchDeletedBlogsByUserId(ctx context.Context, userId string) ([]models.BlogData, error) {
+	logger.InfoLog.Printf("FetchDeletedBlogsByUserId start...")
+
+	query := `
+		SELECT b.id, b.user_id, b.title, b.description, b.github_url, b.category, b.tags,
+				b.like_count,
+				b.comment_count,
+				b.created_at, b.updated_at, b.status, b.published_at, b.slug, b.deleted_at
+		FROM blogs b
+		WHERE b.user_id = $1 AND b.deleted_at IS NOT NULL
+		ORDER BY b.deleted_at DESC, b.id DESC
+	`
+	return r.queryDeletedBlogs(ctx, query, userId)
+}
+
+// ゴミ箱内のブログデータを取得するクエリを実行する
+func (r *BlogRepositoryImpl) queryDeletedBlogs(ctx context.Context, query string, args ...interface{}) ([]models.BlogData, error) {
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、条件に一致するデータを取得
+	rows, err := r.DB.Query(ctx, query, args...)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch deleted blogs: %v", err)
+		return nil, err
+	}
+	logger.InfoLog.Println("Fetched deleted blogs successfully")
+	defer rows.Close()
+
+	var blogs []models.BlogData
+
+	// 結果をスキャンしてブログデータをリストに追加
+	for rows.Next() {
+		blog, err := scanDeletedBlog(rows)
+		if err != nil {
+			logger.ErrorLog.Printf("Failed to scan blog: %v", err)
+			return nil, err
+		}
+		blogs = append(blogs, *blog)
+	}
+
+	if rows.Err() != nil {
+		logger.ErrorLog.Printf("Failed to fetch deleted blogs: %v", rows.Err())
+		return nil, rows.Err()
+	}
+
+	logger.InfoLog.Printf("Fetched %d deleted blogs", len(blogs))
+	return blogs, nil
+}
+
+// ゴミ箱内のブログデータをIDで取得する
+// ゴミ箱内に一致するブログがない場合は pgx.ErrNoRows を返す。
+func (r *BlogRepositoryImpl) FetchDeletedBlogById(ctx context.Context, id string) (*models.BlogData, error) {
+	logger.InfoLog.Printf("FetchDeletedBlogById start...")
+
+	query := `
+		SELECT b.id, b.user_id, b.title, b.description, b.github_url, b.category, b.tags,
+				b.like_count,
+				b.comment_count,
+				b.created_at, b.updated_at, b.status, b.published_at, b.slug, b.deleted_at
+		FROM blogs b
+		WHERE b.id = $1 AND b.deleted_at IS NOT NULL
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、指定されたブログデータを取得
+	blog, err := scanDeletedBlog(r.DB.QueryRow(ctx, query, id))
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch deleted blog: %v", err)
+		return nil, err
+	}
+
+	logger.InfoLog.Printf("Fetched deleted blog: %v", blog)
+	return blog, nil
+}
+
+// ゴミ箱内のブログデータを復元する
+// ゴミ箱内に一致するブログがない場合は pgx.ErrNoRows を返す。
+// 復元できるかどうかは呼び出し側で確認すること。
+func (r *BlogRepositoryImpl) RestoreBlog(ctx context.Context, id string) (*models.BlogData, error) {
+	logger.InfoLog.Printf("RestoreBlog start...")
+
+	query := `
+		WITH restored_blog AS (
+			UPDATE blogs
+			SET deleted_at = NULL
+			WHERE id = $1 AND deleted_at IS NOT NULL
+			RETURNING id, user_id, title, description, github_url, category, tags, like_count, comment_count, created_at, updated_at, status, published_at, slug
+		)
+		SELECT rb.id, rb.user_id, rb.title, rb.description, rb.github_url, rb.category, rb.tags,
+				rb.like_count,
+				rb.comment_count,
+				rb.created_at, rb.updated_at, rb.status, rb.published_at, rb.slug, NULL::timestamptz AS deleted_at
+		FROM restored_blog rb
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、指定されたブログデータを復元
+	blog, err := scanDeletedBlog(r.DB.QueryRow(ctx, query, id))
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to restore blog: %v", err)
+		return nil, err
+	}
+	supabase.NotifyChange(ctx, r.DB, supabase.ChangeBlogs)
+
+	logger.InfoLog.Printf("Restored blog: %v", blog)
+	return blog, nil
+}
+
+// 削除日時が before より前のブログを、コメント・いいねとともに完全に削除する
+// 削除は1つのトランザクションで行い、削除したブログの件数を返す。
+func (r *BlogRepositoryImpl) PurgeDeletedBlogs(ctx context.Context, before time.Time) (int, error) {
+	logger.InfoLog.Printf("PurgeDeletedBlogs start...")
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to begin transaction: %v", err)
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// 削除対象のブログをロックしてIDを取得
+	rows, err := tx.Query(ctx, `
+		SELECT id
+		FROM blogs
+		WHERE deleted_at < $1
+		FOR UPDATE
+	`, before)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch blogs to purge: %v", err)
+		return 0, err
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			logger.ErrorLog.Printf("Failed to scan blog id: %v", err)
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		logger.ErrorLog.Printf("Failed to fetch blogs to purge: %v", rows.Err())
+		return 0, rows.Err()
+	}
+	if len(ids) == 0 {
+		logger.InfoLog.Println("No blogs to purge")
+		return 0, nil
+	}
+
+	// コメント・いいねを削除してからブログを削除する(blog_tags・blog_revisions・blog_slug_redirects はカスケードで削除される)
+	for _, query := range []string{
+		`DELETE FROM comments WHERE blog_id = ANY($1::uuid[])`,
+		`DELETE FROM blogs_likes WHERE blog_id = ANY($1::uuid[])`,
+		`DELETE FROM blogs WHERE id = ANY($1::uuid[])`,
+	} {
+		if _, err := tx.Exec(ctx, query, ids); err != nil {
+			logger.ErrorLog.Printf("Failed to purge blogs: %v", err)
+			return 0, err
+		}
+	}
+
+	// コミット時に他のインスタンスへ変更を通知する
+	supabase.NotifyChange(ctx, tx, supabase.ChangeBlogs)
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.ErrorLog.Printf("Failed to commit transaction: %v", err)
+		return 0, err
+	}
+
+	logger.InfoLog.Printf("Purged %d blogs", len(ids))
+	return len(ids), nil
+}
+
+// 削除日時を含むブログデータをスキャンする
+func scanDeletedBlog(row pgx.Row) (*models.BlogData, error) {
+	var blog models.BlogData
+
+	err := row.Scan(
+		&blog.ID,
+		&blog.UserId,
+		&blog.Title,
+		&blog.Description,
+		&blog.GithubUrl,
+		&blog.Category,
+		&blog.Tags,
+		&blog.Likes,
+		&blog.CommentCnt,
+		&blog.CreatedAt,
+		&blog.UpdatedAt,
+		&blog.Status,
+		&blog.PublishedAt,
+		&blog.Slug,
+		&blog.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blog, nil
+}
@@ -0,0 +1,41 @@
+package repositories_blogs_test
+
+import (
+	"backend/models"
+	repositories_blogs "backend/repositories/blogs"
+	"backend/supabase"
+	"context"
+
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_CountBlogs(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
+
+	// メソッドを実行
+	total, err := repo.CountBlogs(context.Background(), models.BlogListFilter{})
+	if err != nil {
+		t.Fatalf("Failed to count blogs: %v", err)
+	}
+
+	// エラーチェックとデータ確認
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, total, 2)
+}
+
+func TestRepository_CountBlogs_NotFound(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
+
+	// 存在しないカテゴリで絞り込み
+	total, err := repo.CountBlogs(context.Background(), models.BlogListFilter{
+		Category: "not-exist-category",
+	})
+
+	// エラーチェックとデータ確認
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+}
@@ -0,0 +1,50 @@
+package repositories_blogs_test
+
+import (
+	repositories_blogs "backend/repositories/blogs"
+	repositories_blogs_likes "backend/repositories/blogs_likes"
+	"backend/supabase"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_BlogCounters(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
+	likeRepo := repositories_blogs_likes.NewBlogLikeRepository(supabase.Pool)
+
+	// 環境変数から取得
+	userId := os.Getenv("TEST_USER_ID")
+
+	blog, err := repo.CreateBlog(context.Background(), userId, "counter_title", "", "test_category", "", "", "")
+	if err != nil {
+		t.Fatalf("Failed to create blog: %v", err)
+	}
+
+	// いいねの追加・削除でトリガーにより集計値が更新される
+	visitId := uuid.New().String()
+	_, err = likeRepo.CreateBlogLike(context.Background(), blog.ID, visitId)
+	assert.NoError(t, err)
+
+	fetched, err := repo.FetchBlogById(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), fetched.Likes)
+	assert.Equal(t, blog.UpdatedAt, fetched.UpdatedAt)
+
+	assert.NoError(t, likeRepo.DeleteBlogLike(context.Background(), blog.ID, visitId))
+
+	fetched, err = repo.FetchBlogById(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), fetched.Likes)
+
+	// トリガーで更新されていれば再計算で修正するブログはない
+	count, err := repo.ReconcileBlogCounters(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	assert.NoError(t, repo.DeleteBlog(context.Background(), blog.ID))
+}
@@ -2,6 +2,8 @@ package repositories_blogs_test
 
 import (
 	repositories_blogs "backend/repositories/blogs"
+	"backend/supabase"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,10 +11,10 @@ import (
 
 func TestRepository_DeleteBlog_Error(t *testing.T) {
 	// リポジトリのインスタンスを作成
-	repo := repositories_blogs.NewBlogRepository()
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
 
 	// 異常系テスト
-	err := repo.DeleteBlog("")
+	err := repo.DeleteBlog(context.Background(), "")
 
 	// エラーチェックとデータ確認
 	assert.Error(t, err)
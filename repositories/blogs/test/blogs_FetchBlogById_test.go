@@ -2,6 +2,8 @@ package repositories_blogs_test
 
 import (
 	repositories_blogs "backend/repositories/blogs"
+	"backend/supabase"
+	"context"
 	"os"
 	"testing"
 
@@ -10,13 +12,13 @@ import (
 
 func TestRepository_FetchBlogById(t *testing.T) {
 	// リポジトリのインスタンスを作成
-	repo := repositories_blogs.NewBlogRepository()
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
 
 	// 環境変数から取得
 	id := os.Getenv("TEST_BLOG_ID")
 
 	// メソッドを実行
-	blog, err := repo.FetchBlogById(id)
+	blog, err := repo.FetchBlogById(context.Background(), id)
 
 	// エラーチェックとデータ確認
 	assert.NoError(t, err)
@@ -25,10 +27,10 @@ func TestRepository_FetchBlogById(t *testing.T) {
 
 func TestRepository_FetchBlogById_ErrorCase(t *testing.T) {
 	// リポジトリのインスタンスを作成
-	repo := repositories_blogs.NewBlogRepository()
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
 
 	// メソッドを実行
-	blog, err := repo.FetchBlogById("2")
+	blog, err := repo.FetchBlogById(context.Background(), "2")
 
 	// エラーチェックとデータ確認
 	assert.Error(t, err)
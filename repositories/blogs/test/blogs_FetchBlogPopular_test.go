@@ -2,6 +2,8 @@ package repositories_blogs_test
 
 import (
 	repositories_blogs "backend/repositories/blogs"
+	"backend/supabase"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,10 +11,10 @@ import (
 
 func TestRepository_FetchBlogPopular(t *testing.T) {
 	// リポジトリのインスタンスを作成
-	repo := repositories_blogs.NewBlogRepository()
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
 
 	// メソッドを実行
-	blogs, err := repo.FetchBlogPopular(10)
+	blogs, err := repo.FetchBlogPopular(context.Background(), 10)
 
 	// エラーチェックとデータ確認
 	assert.NoError(t, err)
@@ -22,10 +24,10 @@ func TestRepository_FetchBlogPopular(t *testing.T) {
 
 func TestRepository_FetchBlogPopular_Empty(t *testing.T) {
 	// リポジトリのインスタンスを作成
-	repo := repositories_blogs.NewBlogRepository()
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
 
 	// メソッドを実行
-	blogs, err := repo.FetchBlogPopular(0)
+	blogs, err := repo.FetchBlogPopular(context.Background(), 0)
 
 	// エラーチェックとデータ確認
 	assert.NoError(t, err)
@@ -2,6 +2,8 @@ package repositories_blogs_test
 
 import (
 	repositories_blogs "backend/repositories/blogs"
+	"backend/supabase"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,10 +11,10 @@ import (
 
 func TestRepository_FetchBlogTags(t *testing.T) {
 	// リポジトリのインスタンスを作成
-	repo := repositories_blogs.NewBlogRepository()
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
 
 	// メソッドを実行
-	tags, err := repo.FetchBlogTags()
+	tags, err := repo.FetchBlogTags(context.Background())
 
 	// エラーチェックとデータ確認
 	assert.NoError(t, err)
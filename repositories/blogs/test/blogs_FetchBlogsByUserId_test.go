@@ -2,6 +2,8 @@ package repositories_blogs_test
 
 import (
 	repositories_blogs "backend/repositories/blogs"
+	"backend/supabase"
+	"context"
 	"os"
 	"testing"
 
@@ -10,13 +12,13 @@ import (
 
 func TestRepository_FetchBlogsByUserId(t *testing.T) {
 	// リポジトリのインスタンスを作成
-	repo := repositories_blogs.NewBlogRepository()
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
 
 	// 環境変数からユーザIDを取得
 	testUserId := os.Getenv("TEST_USER_ID")
 
 	// メソッドを実行
-	blogs, err := repo.FetchBlogsByUserId(testUserId)
+	blogs, err := repo.FetchBlogsByUserId(context.Background(), testUserId, true)
 	if err != nil {
 		t.Fatalf("Failed to fetch blog: %v", err)
 	}
@@ -29,10 +31,10 @@ func TestRepository_FetchBlogsByUserId(t *testing.T) {
 
 func TestRepository_FetchBlogsByUserId_ErrorCase(t *testing.T) {
 	// リポジトリのインスタンスを作成
-	repo := repositories_blogs.NewBlogRepository()
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
 
 	// メソッドを実行
-	blogs, err := repo.FetchBlogsByUserId("2")
+	blogs, err := repo.FetchBlogsByUserId(context.Background(), "2", true)
 
 	// エラーチェックとデータ確認
 	assert.Error(t, err)
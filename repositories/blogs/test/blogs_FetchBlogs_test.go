@@ -1,7 +1,10 @@
 package repositories_blogs_test
 
 import (
+	"backend/models"
 	repositories_blogs "backend/repositories/blogs"
+	"backend/supabase"
+	"context"
 
 	"testing"
 
@@ -10,10 +13,13 @@ import (
 
 func TestRepository_FetchBlogs(t *testing.T) {
 	// リポジトリのインスタンスを作成
-	repo := repositories_blogs.NewBlogRepository()
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
 
 	// メソッドを実行
-	blogs, err := repo.FetchBlogs()
+	blogs, err := repo.FetchBlogs(context.Background(), models.BlogListFilter{
+		Limit: 10,
+		Sort:  models.BlogSortNewest,
+	})
 	if err != nil {
 		t.Fatalf("Failed to fetch blogs: %v", err)
 	}
@@ -23,3 +29,46 @@ func TestRepository_FetchBlogs(t *testing.T) {
 	assert.NotNil(t, blogs)
 	assert.GreaterOrEqual(t, len(blogs), 2)
 }
+
+func TestRepository_FetchBlogs_Cursor(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
+
+	for _, sort := range []string{
+		models.BlogSortNewest,
+		models.BlogSortOldest,
+		models.BlogSortMostLiked,
+		models.BlogSortMostCommented,
+	} {
+		// 1件目を取得
+		first, err := repo.FetchBlogs(context.Background(), models.BlogListFilter{
+			Limit: 1,
+			Sort:  sort,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, first, 1)
+
+		// 1件目をカーソルにして次の1件を取得
+		cursor := models.BlogCursor{
+			Sort:        sort,
+			PublishedAt: *first[0].PublishedAt,
+			ID:          first[0].ID,
+		}
+		switch sort {
+		case models.BlogSortMostLiked:
+			cursor.Count = int64(first[0].Likes)
+		case models.BlogSortMostCommented:
+			cursor.Count = int64(first[0].CommentCnt)
+		}
+		second, err := repo.FetchBlogs(context.Background(), models.BlogListFilter{
+			Limit: 1,
+			Sort:  sort,
+			After: &cursor,
+		})
+
+		// エラーチェックとデータ確認
+		assert.NoError(t, err)
+		assert.Len(t, second, 1)
+		assert.NotEqual(t, first[0].ID, second[0].ID)
+	}
+}
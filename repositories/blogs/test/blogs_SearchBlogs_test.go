@@ -0,0 +1,43 @@
+package repositories_blogs_test
+
+import (
+	repositories_blogs "backend/repositories/blogs"
+	"backend/supabase"
+	"context"
+
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_SearchBlogs(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
+
+	// 既存データのタイトルで検索
+	blogs, err := repo.FetchBlogPopular(context.Background(), 1)
+	if err != nil || len(blogs) == 0 {
+		t.Fatalf("Failed to fetch blogs: %v", err)
+	}
+	title := blogs[0].Title
+
+	// メソッドを実行
+	results, err := repo.SearchBlogs(context.Background(), []string{title}, 10)
+
+	// エラーチェックとデータ確認
+	assert.NoError(t, err)
+	assert.NotEmpty(t, results)
+	assert.GreaterOrEqual(t, results[0].Score, 3)
+}
+
+func TestRepository_SearchBlogs_Wildcard(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
+
+	// ワイルドカード文字はエスケープされ、文字として検索されること
+	results, err := repo.SearchBlogs(context.Background(), []string{"%_%_%"}, 10)
+
+	// エラーチェックとデータ確認
+	assert.NoError(t, err)
+	assert.Len(t, results, 0)
+}
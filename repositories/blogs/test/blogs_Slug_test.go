@@ -0,0 +1,51 @@
+package repositories_blogs_test
+
+import (
+	repositories_blogs "backend/repositories/blogs"
+	"backend/supabase"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_BlogSlug_PipeLine(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
+
+	// 環境変数から取得
+	userId := os.Getenv("TEST_USER_ID")
+
+	// 同じタイトルのブログには連番付きのスラッグが付くこと
+	first, err := repo.CreateBlog(context.Background(), userId, "Slug Test", "test_github_url", "test_category", "test_description", "test_tags", "")
+	assert.NoError(t, err)
+	second, err := repo.CreateBlog(context.Background(), userId, "Slug Test", "test_github_url", "test_category", "test_description", "test_tags", "")
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.Slug, second.Slug)
+	defer repo.DeleteBlog(context.Background(), first.ID)
+	defer repo.DeleteBlog(context.Background(), second.ID)
+
+	// スラッグを変更すると、変更前のスラッグでも取得できること
+	newSlug := "slug-test-" + uuid.New().String()[:8]
+	updated, err := repo.UpdateBlogSlug(context.Background(), first.ID, newSlug)
+	assert.NoError(t, err)
+	assert.Equal(t, newSlug, updated.Slug)
+
+	fetched, err := repo.FetchBlogBySlug(context.Background(), first.Slug)
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, fetched.ID)
+	assert.Equal(t, newSlug, fetched.Slug)
+
+	// 他のブログの現在または変更前のスラッグには変更できないこと
+	_, err = repo.UpdateBlogSlug(context.Background(), second.ID, newSlug)
+	assert.ErrorIs(t, err, repositories_blogs.ErrBlogSlugConflict)
+	_, err = repo.UpdateBlogSlug(context.Background(), second.ID, first.Slug)
+	assert.ErrorIs(t, err, repositories_blogs.ErrBlogSlugConflict)
+
+	// 自身の変更前のスラッグには戻せること
+	reverted, err := repo.UpdateBlogSlug(context.Background(), first.ID, first.Slug)
+	assert.NoError(t, err)
+	assert.Equal(t, first.Slug, reverted.Slug)
+}
@@ -0,0 +1,56 @@
+package repositories_blogs_test
+
+import (
+	repositories_blogs "backend/repositories/blogs"
+	repositories_blogs_likes "backend/repositories/blogs_likes"
+	repositories_comments "backend/repositories/comments"
+	"backend/supabase"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_DeletedBlog_CommentsAndLikes(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
+	likeRepo := repositories_blogs_likes.NewBlogLikeRepository(supabase.Pool)
+	commentRepo := repositories_comments.NewCommentRepository(supabase.Pool)
+
+	// 環境変数から取得
+	userId := os.Getenv("TEST_USER_ID")
+
+	blog, err := repo.CreateBlog(context.Background(), userId, "trash_title", "", "test_category", "", "", "")
+	if err != nil {
+		t.Fatalf("Failed to create blog: %v", err)
+	}
+	visitId := uuid.New().String()
+	_, err = likeRepo.CreateBlogLike(context.Background(), blog.ID, visitId)
+	assert.NoError(t, err)
+	_, err = commentRepo.CreateComment(context.Background(), blog.ID, "guest", "comment")
+	assert.NoError(t, err)
+
+	assert.NoError(t, repo.DeleteBlog(context.Background(), blog.ID))
+
+	// ゴミ箱に入っているブログのコメント・いいねは取得できない
+	comments, err := commentRepo.FetchCommentsByBlogId(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+
+	likes, err := likeRepo.FetchBlogLikesByVisitId(context.Background(), visitId)
+	assert.NoError(t, err)
+	assert.Empty(t, likes)
+
+	liked, err := likeRepo.IsBlogLiked(context.Background(), blog.ID, visitId)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.False(t, liked)
+
+	// 新しいコメント・いいねは追加できない
+	_, err = commentRepo.CreateComment(context.Background(), blog.ID, "guest", "comment")
+	assert.ErrorIs(t, err, repositories_comments.ErrBlogNotFound)
+	_, err = likeRepo.CreateBlogLike(context.Background(), blog.ID, uuid.New().String())
+	assert.ErrorIs(t, err, repositories_blogs_likes.ErrBlogNotFound)
+}
@@ -2,6 +2,8 @@ package repositories_blogs_test
 
 import (
 	repositories_blogs "backend/repositories/blogs"
+	"backend/supabase"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,10 +11,10 @@ import (
 
 func TestRepository_UpdateBlog_Error(t *testing.T) {
 	// リポジトリのインスタンスを作成
-	repo := repositories_blogs.NewBlogRepository()
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
 
 	// 異常系テスト
-	updatedBlog, err := repo.UpdateBlog("", "updated_title", "updated_github_url", "updated_category", "updated_description", "updated_tags")
+	updatedBlog, err := repo.UpdateBlog(context.Background(), "", "00000000-0000-0000-0000-000000000000", "updated_title", "updated_github_url", "updated_category", "updated_description", "updated_tags", "")
 
 	// エラーチェックとデータ確認
 	assert.Error(t, err)
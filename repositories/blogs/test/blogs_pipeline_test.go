@@ -2,6 +2,8 @@ package repositories_blogs_test
 
 import (
 	repositories_blogs "backend/repositories/blogs"
+	"backend/supabase"
+	"context"
 	"os"
 	"testing"
 
@@ -10,7 +12,7 @@ import (
 
 func TestRepository_Blog_PipeLine(t *testing.T) {
 	// リポジトリのインスタンスを作成
-	repo := repositories_blogs.NewBlogRepository()
+	repo := repositories_blogs.NewBlogRepository(supabase.Pool)
 
 	// 環境変数から取得
 	userId := os.Getenv("TEST_USER_ID")
@@ -18,34 +20,37 @@ func TestRepository_Blog_PipeLine(t *testing.T) {
 	// ----------------------------------------------------------------------------------------------------------------------------
 	// 1. ブログ生成テスト
 	// ----------------------------------------------------------------------------------------------------------------------------
-	blog, err := repo.CreateBlog(userId, "test_title", "test_github_url", "test_category", "test_description", "test_tags")
+	blog, err := repo.CreateBlog(context.Background(), userId, "test_title", "test_github_url", "test_category", "test_description", "test_tags", "# test_body")
 
 	// エラーチェックとデータ確認
 	assert.NoError(t, err)
 	assert.NotNil(t, blog)
+	assert.Equal(t, "# test_body", blog.BodyMarkdown)
 
 	// ----------------------------------------------------------------------------------------------------------------------------
 	// 2. ブログ取得テスト
 	// ----------------------------------------------------------------------------------------------------------------------------
-	fetchedBlog, err := repo.FetchBlogById(blog.ID)
+	fetchedBlog, err := repo.FetchBlogById(context.Background(), blog.ID)
 
 	// エラーチェックとデータ確認
 	assert.NoError(t, err)
 	assert.NotNil(t, fetchedBlog)
+	assert.Equal(t, "# test_body", fetchedBlog.BodyMarkdown)
 
 	// ----------------------------------------------------------------------------------------------------------------------------
 	// 3. ブログ更新テスト
 	// ----------------------------------------------------------------------------------------------------------------------------
-	updatedBlog, err := repo.UpdateBlog(blog.ID, "updated_title", "updated_github_url", "updated_category", "updated_description", "updated_tags")
+	updatedBlog, err := repo.UpdateBlog(context.Background(), blog.ID, userId, "updated_title", "updated_github_url", "updated_category", "updated_description", "updated_tags", "# updated_body")
 
 	// エラーチェックとデータ確認
 	assert.NoError(t, err)
 	assert.NotNil(t, updatedBlog)
+	assert.Equal(t, "# updated_body", updatedBlog.BodyMarkdown)
 
 	// ----------------------------------------------------------------------------------------------------------------------------
 	// 4. ブログ削除テスト
 	// ----------------------------------------------------------------------------------------------------------------------------
-	err = repo.DeleteBlog(blog.ID)
+	err = repo.DeleteBlog(context.Background(), blog.ID)
 
 	// エラーチェック
 	assert.NoError(t, err)
@@ -53,7 +58,7 @@ func TestRepository_Blog_PipeLine(t *testing.T) {
 	// ----------------------------------------------------------------------------------------------------------------------------
 	// 5. ブログ取得エラーテスト
 	// ----------------------------------------------------------------------------------------------------------------------------
-	fetchedBlog, err = repo.FetchBlogById(blog.ID)
+	fetchedBlog, err = repo.FetchBlogById(context.Background(), blog.ID)
 
 	// エラーチェックとデータ確認
 	assert.Error(t, err)
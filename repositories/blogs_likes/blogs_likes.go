@@ -3,21 +3,31 @@ package repositories_blogs_likes
 import (
 	"backend/models"
 	"backend/supabase"
+	"context"
+	"errors"
 	"log"
+
+	"github.com/jackc/pgx/v4"
 )
 
 // VisitIdによっていいねデータを取得
-func (r *BlogLikeRepositoryImpl) FetchBlogLikesByVisitId(visitId string) ([]models.BlogLikeData, error) {
+// ゴミ箱に入っているブログへのいいねは取得しない。
+func (r *BlogLikeRepositoryImpl) FetchBlogLikesByVisitId(ctx context.Context, visitId string) ([]models.BlogLikeData, error) {
 	log.Println("FetchBlogLikesByVisitId start...")
 
 	// データベースからいいねデータを取得
 	query := `
-		SELECT id, blog_id, visit_id, created_at, updated_at
-		FROM blogs_likes
-		WHERE visit_id = $1
+		SELECT l.id, l.blog_id, l.visit_id, l.created_at, l.updated_at
+		FROM blogs_likes l
+		JOIN blogs b ON b.id = l.blog_id
+		WHERE l.visit_id = $1 AND b.deleted_at IS NULL
 	`
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
 	// クエリを実行し、いいねデータを取得
-	rows, err := supabase.Pool.Query(supabase.Ctx, query, visitId)
+	rows, err := r.DB.Query(ctx, query, visitId)
 	if err != nil {
 		log.Printf("Failed to fetch blog likes by visit id: %v", err)
 		return nil, err
@@ -43,17 +53,23 @@ func (r *BlogLikeRepositoryImpl) FetchBlogLikesByVisitId(visitId string) ([]mode
 }
 
 // いいね存在するか確認
-func (r *BlogLikeRepositoryImpl) IsBlogLiked(blogId, visitId string) (bool, error) {
+// ゴミ箱に入っているブログへのいいねは存在しないものとして扱う。
+func (r *BlogLikeRepositoryImpl) IsBlogLiked(ctx context.Context, blogId, visitId string) (bool, error) {
 	log.Println("IsBlogLiked start...")
 
 	// データベースからいいねデータを取得
 	query := `
-		SELECT id
-		FROM blogs_likes
-		WHERE blog_id = $1 AND visit_id = $2
+		SELECT l.id
+		FROM blogs_likes l
+		JOIN blogs b ON b.id = l.blog_id
+		WHERE l.blog_id = $1 AND l.visit_id = $2 AND b.deleted_at IS NULL
 	`
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
 	// クエリを実行し、いいねデータを取得
-	row := supabase.Pool.QueryRow(supabase.Ctx, query, blogId, visitId)
+	row := r.DB.QueryRow(ctx, query, blogId, visitId)
 	var id string
 
 	// スキャンしていいねデータが存在するか確認
@@ -68,32 +84,44 @@ func (r *BlogLikeRepositoryImpl) IsBlogLiked(blogId, visitId string) (bool, erro
 }
 
 // いいねデータの作成
-func (r *BlogLikeRepositoryImpl) CreateBlogLike(blogId, visitId string) (*models.BlogLikeData, error) {
+// ブログがない、またはゴミ箱に入っている場合は ErrBlogNotFound を返す。
+func (r *BlogLikeRepositoryImpl) CreateBlogLike(ctx context.Context, blogId, visitId string) (*models.BlogLikeData, error) {
 	log.Println("CreateBlogLike start...")
 
 	// データベースにいいねデータを挿入
 	query := `
 		INSERT INTO blogs_likes (blog_id, visit_id)
-		VALUES ($1, $2)
+		SELECT id, $2
+		FROM blogs
+		WHERE id = $1 AND deleted_at IS NULL
 		RETURNING id, created_at, updated_at
 	`
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
 	// クエリを実行し、新しいいいねデータを作成
-	row := supabase.Pool.QueryRow(supabase.Ctx, query, blogId, visitId)
+	row := r.DB.QueryRow(ctx, query, blogId, visitId)
 	var blogLike = &models.BlogLikeData{}
 
 	// スキャンしていいねデータを返す
 	err := row.Scan(&blogLike.ID, &blogLike.CreatedAt, &blogLike.UpdatedAt)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("Failed to create blog like: %v", ErrBlogNotFound)
+			return nil, ErrBlogNotFound
+		}
 		log.Printf("Failed to create blog like: %v", err)
 		return nil, err
 	}
+	supabase.NotifyChange(ctx, r.DB, supabase.ChangeBlogLikes)
 
 	log.Printf("Created blog like: %v", blogLike)
 	return blogLike, nil
 }
 
 // いいねデータの削除
-func (r *BlogLikeRepositoryImpl) DeleteBlogLike(blogId, visitId string) error {
+func (r *BlogLikeRepositoryImpl) DeleteBlogLike(ctx context.Context, blogId, visitId string) error {
 	log.Println("DeleteBlogLike start...")
 
 	// データベースからいいねデータを削除
@@ -101,12 +129,17 @@ func (r *BlogLikeRepositoryImpl) DeleteBlogLike(blogId, visitId string) error {
 		DELETE FROM blogs_likes
 		WHERE blog_id = $1 AND visit_id = $2
 	`
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
 	// クエリを実行し、いいねデータを削除
-	_, err := supabase.Pool.Exec(supabase.Ctx, query, blogId, visitId)
+	_, err := r.DB.Exec(ctx, query, blogId, visitId)
 	if err != nil {
 		log.Printf("Failed to delete blog like: %v", err)
 		return err
 	}
+	supabase.NotifyChange(ctx, r.DB, supabase.ChangeBlogLikes)
 
 	log.Println("Deleted blog like")
 	return nil
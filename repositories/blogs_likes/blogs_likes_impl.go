@@ -1,18 +1,30 @@
 package repositories_blogs_likes
 
-import "backend/models"
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"errors"
+)
+
+// いいね先のブログがない(ゴミ箱に入っている)場合のエラー
+var ErrBlogNotFound = errors.New("blog not found")
 
 // BlogLikeRepositoryインターフェース
 type BlogLikeRepository interface {
-	FetchBlogLikesByVisitId(visitId string) ([]models.BlogLikeData, error)
-	IsBlogLiked(blogId, visitId string) (bool, error)
-	CreateBlogLike(blogId, visitId string) (*models.BlogLikeData, error)
-	DeleteBlogLike(blogId, visitId string) error
+	FetchBlogLikesByVisitId(ctx context.Context, visitId string) ([]models.BlogLikeData, error)
+	IsBlogLiked(ctx context.Context, blogId, visitId string) (bool, error)
+	CreateBlogLike(ctx context.Context, blogId, visitId string) (*models.BlogLikeData, error)
+	DeleteBlogLike(ctx context.Context, blogId, visitId string) error
 }
 
-type BlogLikeRepositoryImpl struct{}
+type BlogLikeRepositoryImpl struct {
+	DB supabase.DB
+}
 
 // BlogLikeRepositoryインターフェースを実装したBlogLikeRepositoryImplのポインタを返す
-func NewBlogLikeRepository() BlogLikeRepository {
-	return &BlogLikeRepositoryImpl{}
+func NewBlogLikeRepository(db supabase.DB) BlogLikeRepository {
+	return &BlogLikeRepositoryImpl{
+		DB: db,
+	}
 }
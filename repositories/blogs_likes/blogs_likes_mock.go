@@ -2,6 +2,7 @@ package repositories_blogs_likes
 
 import (
 	"backend/models"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,7 +11,7 @@ type MockBlogLikeRepository struct {
 	mock.Mock
 }
 
-func (m *MockBlogLikeRepository) FetchBlogLikesByVisitId(visitId string) ([]models.BlogLikeData, error) {
+func (m *MockBlogLikeRepository) FetchBlogLikesByVisitId(ctx context.Context, visitId string) ([]models.BlogLikeData, error) {
 	args := m.Called(visitId)
 	if args.Get(0) != nil {
 		return args.Get(0).([]models.BlogLikeData), args.Error(1)
@@ -18,12 +19,12 @@ func (m *MockBlogLikeRepository) FetchBlogLikesByVisitId(visitId string) ([]mode
 	return nil, args.Error(1)
 }
 
-func (m *MockBlogLikeRepository) IsBlogLiked(blogId, visitId string) (bool, error) {
+func (m *MockBlogLikeRepository) IsBlogLiked(ctx context.Context, blogId, visitId string) (bool, error) {
 	args := m.Called(blogId, visitId)
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockBlogLikeRepository) CreateBlogLike(blogId, visitId string) (*models.BlogLikeData, error) {
+func (m *MockBlogLikeRepository) CreateBlogLike(ctx context.Context, blogId, visitId string) (*models.BlogLikeData, error) {
 	args := m.Called(blogId, visitId)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -31,7 +32,7 @@ func (m *MockBlogLikeRepository) CreateBlogLike(blogId, visitId string) (*models
 	return args.Get(0).(*models.BlogLikeData), args.Error(1)
 }
 
-func (m *MockBlogLikeRepository) DeleteBlogLike(blogId, visitId string) error {
+func (m *MockBlogLikeRepository) DeleteBlogLike(ctx context.Context, blogId, visitId string) error {
 	args := m.Called(blogId, visitId)
 	return args.Error(0)
 }
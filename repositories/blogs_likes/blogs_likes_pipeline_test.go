@@ -1,6 +1,8 @@
 package repositories_blogs_likes
 
 import (
+	"backend/supabase"
+	"context"
 	"os"
 	"testing"
 
@@ -13,7 +15,7 @@ func TestRepository_BlogLike_Test(t *testing.T) {
 	setupSupabase(t)
 
 	// リポジトリのインスタンスを作成
-	repo := NewBlogLikeRepository()
+	repo := NewBlogLikeRepository(supabase.Pool)
 
 	// UUIDを生成
 	blogID := os.Getenv("TEST_BLOG_ID")
@@ -22,7 +24,7 @@ func TestRepository_BlogLike_Test(t *testing.T) {
 	// ---------------------------------------------------------
 	// 1. 「いいね」を作成
 	// ---------------------------------------------------------
-	like, err := repo.CreateBlogLike(blogID, visitorID)
+	like, err := repo.CreateBlogLike(context.Background(), blogID, visitorID)
 	if err != nil {
 		t.Fatalf("Failed to create blog like: %v", err)
 	}
@@ -34,7 +36,7 @@ func TestRepository_BlogLike_Test(t *testing.T) {
 	// ---------------------------------------------------------
 	// 2. 「いいね」が存在するか確認
 	// ---------------------------------------------------------
-	liked, err := repo.IsBlogLiked(blogID, visitorID)
+	liked, err := repo.IsBlogLiked(context.Background(), blogID, visitorID)
 	if err != nil {
 		t.Fatalf("Failed to check if blog is liked: %v", err)
 	}
@@ -46,7 +48,7 @@ func TestRepository_BlogLike_Test(t *testing.T) {
 	// ---------------------------------------------------------
 	// 3. 「いいね」を削除
 	// ---------------------------------------------------------
-	err = repo.DeleteBlogLike(blogID, visitorID)
+	err = repo.DeleteBlogLike(context.Background(), blogID, visitorID)
 	if err != nil {
 		t.Fatalf("Failed to delete blog like: %v", err)
 	}
@@ -57,7 +59,7 @@ func TestRepository_BlogLike_Test(t *testing.T) {
 	// ---------------------------------------------------------
 	// 4. 「いいね」が削除されたことを確認
 	// ---------------------------------------------------------
-	liked, err = repo.IsBlogLiked(blogID, visitorID)
+	liked, err = repo.IsBlogLiked(context.Background(), blogID, visitorID)
 
 	// エラーチェックとデータ確認（削除後なので false を期待）
 	assert.Error(t, err)
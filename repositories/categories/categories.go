@@ -0,0 +1,238 @@
+package repositories_categories
+
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// カテゴリ名またはスラッグが既存のカテゴリと重複している
+var ErrCategoryConflict = errors.New("category name or slug already exists")
+
+// カテゴリを使用しているブログ(ゴミ箱内のブログを含む)が存在する
+var ErrCategoryInUse = errors.New("category is in use")
+
+// カテゴリ情報(投稿数を含む)を取得するクエリ
+const selectCategoriesQuery = `
+	SELECT c.id, c.name, c.slug, c.description, c.display_order,
+			(SELECT COUNT(*) FROM blogs b WHERE b.category = c.name AND b.deleted_at IS NULL AND b.status = 'published') AS post_count,
+			c.created_at, c.updated_at
+	FROM categories c
+`
+
+// カテゴリ情報をスキャンする
+func scanCategory(row pgx.Row) (*models.CategoryData, error) {
+	var category models.CategoryData
+	err := row.Scan(
+		&category.ID,
+		&category.Name,
+		&category.Slug,
+		&category.Description,
+		&category.DisplayOrder,
+		&category.PostCount,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// 一意制約違反・外部キー制約違反をリポジトリのエラーに変換する
+func translateConstraintError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return ErrCategoryConflict
+		case "23503":
+			return ErrCategoryInUse
+		}
+	}
+	return err
+}
+
+// 全カテゴリ情報を表示順に取得する
+func (r *CategoryRepositoryImpl) FetchCategories(ctx context.Context) ([]models.CategoryData, error) {
+	log.Printf("FetchCategories start...")
+
+	query := selectCategoriesQuery + `
+		ORDER BY c.display_order, lower(c.name)
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、全データ取得
+	rows, err := r.DB.Query(ctx, query)
+	if err != nil {
+		log.Printf("Failed to fetch categories: %v", err)
+		return nil, err
+	}
+	log.Println("Fetched categories successfully")
+	defer rows.Close()
+
+	var categories []models.CategoryData
+
+	// 結果をスキャンしてカテゴリデータをリストに追加
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			log.Printf("Failed to scan category: %v", err)
+			return nil, err
+		}
+		categories = append(categories, *category)
+	}
+
+	if rows.Err() != nil {
+		log.Printf("Failed to fetch categories: %v", rows.Err())
+		return nil, rows.Err()
+	}
+
+	log.Printf("Fetched %d categories", len(categories))
+	return categories, nil
+}
+
+// 指定されたIDに一致するカテゴリ情報を取得する
+func (r *CategoryRepositoryImpl) FetchCategoryById(ctx context.Context, id string) (*models.CategoryData, error) {
+	log.Printf("FetchCategoryById start...")
+
+	query := selectCategoriesQuery + `
+		WHERE c.id = $1
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、条件に一致するデータを取得
+	category, err := scanCategory(r.DB.QueryRow(ctx, query, id))
+	if err != nil {
+		log.Printf("Failed to fetch category: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Fetched category: %v", category)
+	return category, nil
+}
+
+// 指定された名前に一致するカテゴリ情報を取得する(大文字小文字を区別しない)
+func (r *CategoryRepositoryImpl) FetchCategoryByName(ctx context.Context, name string) (*models.CategoryData, error) {
+	log.Printf("FetchCategoryByName start...")
+
+	query := selectCategoriesQuery + `
+		WHERE lower(c.name) = lower($1)
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、条件に一致するデータを取得
+	category, err := scanCategory(r.DB.QueryRow(ctx, query, name))
+	if err != nil {
+		log.Printf("Failed to fetch category: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Fetched category: %v", category)
+	return category, nil
+}
+
+// カテゴリを作成する
+// 名前またはスラッグが既存のカテゴリと重複する場合は ErrCategoryConflict を返す。
+func (r *CategoryRepositoryImpl) CreateCategory(ctx context.Context, name, slug, description string, displayOrder int) (*models.CategoryData, error) {
+	log.Printf("CreateCategory start...")
+
+	query := `
+		INSERT INTO categories (name, slug, description, display_order)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, name, slug, description, display_order, 0, created_at, updated_at
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、データを作成
+	category, err := scanCategory(r.DB.QueryRow(ctx, query, name, slug, description, displayOrder))
+	if err != nil {
+		log.Printf("Failed to create category: %v", err)
+		return nil, translateConstraintError(err)
+	}
+	supabase.NotifyChange(ctx, r.DB, supabase.ChangeCategories)
+
+	log.Printf("Created category: %v", category)
+	return category, nil
+}
+
+// 指定されたIDに一致するカテゴリを更新する
+// カテゴリ名を変更した場合、外部キー制約により blogs.category も更新される。
+func (r *CategoryRepositoryImpl) UpdateCategory(ctx context.Context, id, name, slug, description string, displayOrder int) (*models.CategoryData, error) {
+	log.Printf("UpdateCategory start...")
+
+	// ブログ側のカテゴリ名は文の終了時に更新されるため、投稿数は変更前の名前で数える
+	query := `
+		WITH old AS (
+			SELECT name FROM categories WHERE id = $1
+		)
+		UPDATE categories
+		SET name = $2, slug = $3, description = $4, display_order = $5
+		WHERE id = $1
+		RETURNING id, name, slug, description, display_order,
+			(SELECT COUNT(*) FROM blogs b WHERE b.category = (SELECT name FROM old) AND b.deleted_at IS NULL AND b.status = 'published') AS post_count,
+			created_at, updated_at
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、データを更新
+	category, err := scanCategory(r.DB.QueryRow(ctx, query, id, name, slug, description, displayOrder))
+	if err != nil {
+		log.Printf("Failed to update category: %v", err)
+		return nil, translateConstraintError(err)
+	}
+	supabase.NotifyChange(ctx, r.DB, supabase.ChangeCategories)
+
+	log.Printf("Updated category: %v", category)
+	return category, nil
+}
+
+// 指定されたIDに一致するカテゴリを削除する
+// カテゴリを使用しているブログ(ゴミ箱内のブログを含む)が存在する場合は ErrCategoryInUse を返す。
+func (r *CategoryRepositoryImpl) DeleteCategory(ctx context.Context, id string) error {
+	log.Printf("DeleteCategory start...")
+
+	query := `
+		DELETE FROM categories
+		WHERE id = $1
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、データを削除
+	result, err := r.DB.Exec(ctx, query, id)
+	if err != nil {
+		log.Printf("Failed to delete category: %v", err)
+		return translateConstraintError(err)
+	}
+	if result.RowsAffected() == 0 {
+		log.Printf("Failed to delete category: %v", pgx.ErrNoRows)
+		return pgx.ErrNoRows
+	}
+	supabase.NotifyChange(ctx, r.DB, supabase.ChangeCategories)
+
+	log.Printf("Deleted category: %s", id)
+	return nil
+}
@@ -0,0 +1,51 @@
+package repositories_categories
+
+import (
+	"backend/supabase"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_FetchCategories(t *testing.T) {
+	// Supabaseクライアントの初期化
+	setupSupabase(t)
+
+	// リポジトリのインスタンスを作成
+	repo := NewCategoryRepository(supabase.Pool)
+
+	// メソッドを実行
+	categories, err := repo.FetchCategories(context.Background())
+
+	// エラーチェックとデータ確認
+	assert.NoError(t, err)
+	assert.NotNil(t, categories)
+
+	// 表示順に並んでいること
+	for i := 1; i < len(categories); i++ {
+		assert.LessOrEqual(t, categories[i-1].DisplayOrder, categories[i].DisplayOrder)
+	}
+
+	// 取得したカテゴリを名前(大文字小文字を区別しない)で取得できること
+	category, err := repo.FetchCategoryByName(context.Background(), categories[0].Name)
+	assert.NoError(t, err)
+	assert.Equal(t, categories[0].ID, category.ID)
+	assert.Equal(t, categories[0].PostCount, category.PostCount)
+}
+
+func TestRepository_FetchCategoryById_NotFound(t *testing.T) {
+	// Supabaseクライアントの初期化
+	setupSupabase(t)
+
+	// リポジトリのインスタンスを作成
+	repo := NewCategoryRepository(supabase.Pool)
+
+	// 存在しないIDで取得
+	category, err := repo.FetchCategoryById(context.Background(), "00000000-0000-0000-0000-000000000000")
+
+	// エラーチェックとデータ確認
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Nil(t, category)
+}
@@ -0,0 +1,29 @@
+package repositories_categories
+
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+)
+
+// CategoryRepositoryインターフェース
+type CategoryRepository interface {
+	FetchCategories(ctx context.Context) ([]models.CategoryData, error)
+	FetchCategoryById(ctx context.Context, id string) (*models.CategoryData, error)
+	FetchCategoryByName(ctx context.Context, name string) (*models.CategoryData, error)
+
+	CreateCategory(ctx context.Context, name, slug, description string, displayOrder int) (*models.CategoryData, error)
+	UpdateCategory(ctx context.Context, id, name, slug, description string, displayOrder int) (*models.CategoryData, error)
+	DeleteCategory(ctx context.Context, id string) error
+}
+
+type CategoryRepositoryImpl struct {
+	DB supabase.DB
+}
+
+// CategoryRepositoryインターフェースを実装したCategoryRepositoryImplのポインタを返す
+func NewCategoryRepository(db supabase.DB) CategoryRepository {
+	return &CategoryRepositoryImpl{
+		DB: db,
+	}
+}
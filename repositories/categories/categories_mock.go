@@ -0,0 +1,57 @@
+package repositories_categories
+
+import (
+	"backend/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) FetchCategories(ctx context.Context) ([]models.CategoryData, error) {
+	args := m.Called()
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.CategoryData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryRepository) FetchCategoryById(ctx context.Context, id string) (*models.CategoryData, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.CategoryData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryRepository) FetchCategoryByName(ctx context.Context, name string) (*models.CategoryData, error) {
+	args := m.Called(name)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.CategoryData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryRepository) CreateCategory(ctx context.Context, name, slug, description string, displayOrder int) (*models.CategoryData, error) {
+	args := m.Called(name, slug, description, displayOrder)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.CategoryData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryRepository) UpdateCategory(ctx context.Context, id, name, slug, description string, displayOrder int) (*models.CategoryData, error) {
+	args := m.Called(id, name, slug, description, displayOrder)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.CategoryData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryRepository) DeleteCategory(ctx context.Context, id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -3,21 +3,31 @@ package repositories_comments
 import (
 	"backend/models"
 	"backend/supabase"
+	"context"
+	"errors"
 	"log"
+
+	"github.com/jackc/pgx/v4"
 )
 
 // ブログIDに一致するコメント情報を取得する
-func (r *CommentRepositoryImpl) FetchCommentsByBlogId(blogId string) ([]models.CommentData, error) {
+// ゴミ箱に入っているブログのコメントは取得しない。
+func (r *CommentRepositoryImpl) FetchCommentsByBlogId(ctx context.Context, blogId string) ([]models.CommentData, error) {
 	log.Printf("FetchCommentsByBlogId start...")
 
 	query := `
-		SELECT id, blog_id, guest_user, comment, created_at
-		FROM comments
-		WHERE blog_id = $1
+		SELECT c.id, c.blog_id, c.guest_user, c.comment, c.created_at
+		FROM comments c
+		JOIN blogs b ON b.id = c.blog_id
+		WHERE c.blog_id = $1 AND b.deleted_at IS NULL
 	`
 
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
 	// Supabaseからクエリを実行し、条件に一致するデータを取得
-	rows, err := supabase.Pool.Query(supabase.Ctx, query, blogId)
+	rows, err := r.DB.Query(ctx, query, blogId)
 	if err != nil {
 		log.Printf("Failed to fetch comments: %v", err)
 		return nil, err
@@ -54,17 +64,24 @@ func (r *CommentRepositoryImpl) FetchCommentsByBlogId(blogId string) ([]models.C
 }
 
 // コメント情報を新規作成する
-func (r *CommentRepositoryImpl) CreateComment(blogId, guestUser, comment string) (*models.CommentData, error) {
+// ブログがない、またはゴミ箱に入っている場合は ErrBlogNotFound を返す。
+func (r *CommentRepositoryImpl) CreateComment(ctx context.Context, blogId, guestUser, comment string) (*models.CommentData, error) {
 	log.Printf("CreateComment start...")
 
 	query := `
 		INSERT INTO comments (blog_id, guest_user, comment)
-		VALUES ($1, $2, $3)
+		SELECT id, $2, $3
+		FROM blogs
+		WHERE id = $1 AND deleted_at IS NULL
 		RETURNING id, blog_id, guest_user, comment, created_at
 	`
 
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
 	// Supabaseからクエリを実行し、新規作成したデータを取得
-	row := supabase.Pool.QueryRow(supabase.Ctx, query, blogId, guestUser, comment)
+	row := r.DB.QueryRow(ctx, query, blogId, guestUser, comment)
 	var newComment models.CommentData
 	err := row.Scan(
 		&newComment.ID,
@@ -74,9 +91,14 @@ func (r *CommentRepositoryImpl) CreateComment(blogId, guestUser, comment string)
 		&newComment.CreatedAt,
 	)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("Failed to create comment: %v", ErrBlogNotFound)
+			return nil, ErrBlogNotFound
+		}
 		log.Printf("Failed to create comment: %v", err)
 		return nil, err
 	}
+	supabase.NotifyChange(ctx, r.DB, supabase.ChangeComments)
 
 	log.Printf("Created comment: %v", newComment)
 	return &newComment, nil
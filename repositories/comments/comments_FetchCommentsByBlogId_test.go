@@ -1,6 +1,8 @@
 package repositories_comments
 
 import (
+	"backend/supabase"
+	"context"
 	"os"
 	"testing"
 
@@ -12,13 +14,13 @@ func TestRepository_FetchCommentsByBlogId(t *testing.T) {
 	setupSupabase(t)
 
 	// リポジトリのインスタンスを作成
-	repo := NewCommentRepository()
+	repo := NewCommentRepository(supabase.Pool)
 
 	// 環境変数から取得
 	blogId := os.Getenv("TEST_BLOG_ID")
 
 	// メソッドを実行
-	comments, err := repo.FetchCommentsByBlogId(blogId)
+	comments, err := repo.FetchCommentsByBlogId(context.Background(), blogId)
 
 	// エラーチェックとデータ確認
 	assert.NoError(t, err)
@@ -30,10 +32,10 @@ func TestRepository_FetchCommentsByBlogId_InvalidBlogId(t *testing.T) {
 	setupSupabase(t)
 
 	// リポジトリのインスタンスを作成
-	repo := NewCommentRepository()
+	repo := NewCommentRepository(supabase.Pool)
 
 	// メソッドを実行
-	comments, err := repo.FetchCommentsByBlogId("1")
+	comments, err := repo.FetchCommentsByBlogId(context.Background(), "1")
 
 	// エラーチェックとデータ確認
 	assert.Error(t, err)
@@ -1,16 +1,28 @@
 package repositories_comments
 
-import "backend/models"
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"errors"
+)
+
+// コメント先のブログがない(ゴミ箱に入っている)場合のエラー
+var ErrBlogNotFound = errors.New("blog not found")
 
 // CommentRepositoryインターフェース
 type CommentRepository interface {
-	FetchCommentsByBlogId(blogId string) ([]models.CommentData, error)
-	CreateComment(blogId, guestUser, comment string) (*models.CommentData, error)
+	FetchCommentsByBlogId(ctx context.Context, blogId string) ([]models.CommentData, error)
+	CreateComment(ctx context.Context, blogId, guestUser, comment string) (*models.CommentData, error)
 }
 
-type CommentRepositoryImpl struct{}
+type CommentRepositoryImpl struct {
+	DB supabase.DB
+}
 
 // CommentRepositoryインターフェースを実装したCommentRepositoryImplのポインタを返す
-func NewCommentRepository() CommentRepository {
-	return &CommentRepositoryImpl{}
+func NewCommentRepository(db supabase.DB) CommentRepository {
+	return &CommentRepositoryImpl{
+		DB: db,
+	}
 }
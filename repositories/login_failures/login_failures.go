@@ -0,0 +1,190 @@
+package repositories_login_failures
+
+import (
+	"backend/supabase"
+	"context"
+	"log"
+	"time"
+)
+
+// ログインの失敗を記録する
+func (r *LoginFailureRepositoryImpl) RecordLoginFailure(ctx context.Context, key string, failedAt time.Time) error {
+	log.Println("RecordLoginFailure start...")
+
+	query := `INSERT INTO login_failures (key_hash, failed_at) VALUES ($1, $2)`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、失敗を記録
+	if _, err := r.DB.Exec(ctx, query, key, failedAt); err != nil {
+		log.Printf("Failed to record login failure: %v", err)
+		return err
+	}
+	return nil
+}
+
+// 指定された日時以降のログインの失敗の日時を古い順に取得する
+func (r *LoginFailureRepositoryImpl) FetchLoginFailures(ctx context.Context, key string, since time.Time) ([]time.Time, error) {
+	log.Println("FetchLoginFailures start...")
+
+	query := `
+		SELECT failed_at
+		FROM login_failures
+		WHERE key_hash = $1 AND failed_at > $2
+		ORDER BY failed_at
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、条件に一致するデータを取得
+	rows, err := r.DB.Query(ctx, query, key, since)
+	if err != nil {
+		log.Printf("Failed to fetch login failures: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	// 結果をスライスに格納
+	var failures []time.Time
+	for rows.Next() {
+		var failedAt time.Time
+		if err := rows.Scan(&failedAt); err != nil {
+			log.Printf("Failed to scan login failure: %v", err)
+			return nil, err
+		}
+		failures = append(failures, failedAt)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Failed to iterate login failures: %v", err)
+		return nil, err
+	}
+	return failures, nil
+}
+
+// ログインの試行を失敗として先に記録し、それより前に記録された期間内の失敗の日時を古い順に返す
+// 同じキーの記録はロックして1件ずつ行うため、同時に試行した場合も互いの記録を数えられる。
+func (r *LoginFailureRepositoryImpl) ReserveLoginFailure(ctx context.Context, key string, failedAt, since time.Time) ([]time.Time, error) {
+	log.Println("ReserveLoginFailure start...")
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// 同じキーの記録をトランザクションの終了までロック
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('login_failures:' || $1))`, key); err != nil {
+		log.Printf("Failed to lock login failures: %v", err)
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT failed_at
+		FROM login_failures
+		WHERE key_hash = $1 AND failed_at > $2
+		ORDER BY failed_at
+	`, key, since)
+	if err != nil {
+		log.Printf("Failed to fetch login failures: %v", err)
+		return nil, err
+	}
+	var failures []time.Time
+	for rows.Next() {
+		var at time.Time
+		if err := rows.Scan(&at); err != nil {
+			rows.Close()
+			log.Printf("Failed to scan login failure: %v", err)
+			return nil, err
+		}
+		failures = append(failures, at)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Printf("Failed to iterate login failures: %v", err)
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `INSERT INTO login_failures (key_hash, failed_at) VALUES ($1, $2)`, key, failedAt); err != nil {
+		log.Printf("Failed to record login failure: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Failed to commit transaction: %v", err)
+		return nil, err
+	}
+	return failures, nil
+}
+
+// 指定されたキー・日時のログインの失敗を1件削除する(先に記録した試行を取り消す時)
+func (r *LoginFailureRepositoryImpl) DeleteLoginFailure(ctx context.Context, key string, failedAt time.Time) error {
+	log.Println("DeleteLoginFailure start...")
+
+	query := `
+		DELETE FROM login_failures
+		WHERE id = (
+			SELECT id FROM login_failures
+			WHERE key_hash = $1 AND failed_at = $2
+			LIMIT 1
+		)
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、失敗の記録を削除
+	if _, err := r.DB.Exec(ctx, query, key, failedAt); err != nil {
+		log.Printf("Failed to delete login failure: %v", err)
+		return err
+	}
+	return nil
+}
+
+// 指定されたキーのログインの失敗をすべて削除する(ログインに成功した時)
+func (r *LoginFailureRepositoryImpl) DeleteLoginFailures(ctx context.Context, key string) error {
+	log.Println("DeleteLoginFailures start...")
+
+	query := `DELETE FROM login_failures WHERE key_hash = $1`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、失敗の記録を削除
+	if _, err := r.DB.Exec(ctx, query, key); err != nil {
+		log.Printf("Failed to delete login failures: %v", err)
+		return err
+	}
+	return nil
+}
+
+// 指定された日時より前のログインの失敗を削除し、件数を返す
+func (r *LoginFailureRepositoryImpl) DeleteLoginFailuresBefore(ctx context.Context, before time.Time) (int64, error) {
+	log.Println("DeleteLoginFailuresBefore start...")
+
+	query := `DELETE FROM login_failures WHERE failed_at <= $1`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、古い記録を削除
+	result, err := r.DB.Exec(ctx, query, before)
+	if err != nil {
+		log.Printf("Failed to delete expired login failures: %v", err)
+		return 0, err
+	}
+
+	log.Printf("Deleted %d expired login failures", result.RowsAffected())
+	return result.RowsAffected(), nil
+}
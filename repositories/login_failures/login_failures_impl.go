@@ -0,0 +1,29 @@
+package repositories_login_failures
+
+import (
+	"backend/supabase"
+	"context"
+	"time"
+)
+
+// LoginFailureRepositoryインターフェース
+// 失敗はメールアドレス・IPアドレスなどの制限の単位(キー)ごとに記録する。
+type LoginFailureRepository interface {
+	RecordLoginFailure(ctx context.Context, key string, failedAt time.Time) error
+	FetchLoginFailures(ctx context.Context, key string, since time.Time) ([]time.Time, error)
+	ReserveLoginFailure(ctx context.Context, key string, failedAt, since time.Time) ([]time.Time, error)
+	DeleteLoginFailure(ctx context.Context, key string, failedAt time.Time) error
+	DeleteLoginFailures(ctx context.Context, key string) error
+	DeleteLoginFailuresBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type LoginFailureRepositoryImpl struct {
+	DB supabase.DB
+}
+
+// LoginFailureRepositoryインターフェースを実装したLoginFailureRepositoryImplのポインタを返す
+func NewLoginFailureRepository(db supabase.DB) LoginFailureRepository {
+	return &LoginFailureRepositoryImpl{
+		DB: db,
+	}
+}
@@ -0,0 +1,48 @@
+package repositories_login_failures
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLoginFailureRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoginFailureRepository) RecordLoginFailure(ctx context.Context, key string, failedAt time.Time) error {
+	args := m.Called(key, failedAt)
+	return args.Error(0)
+}
+
+func (m *MockLoginFailureRepository) FetchLoginFailures(ctx context.Context, key string, since time.Time) ([]time.Time, error) {
+	args := m.Called(key, since)
+	if args.Get(0) != nil {
+		return args.Get(0).([]time.Time), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLoginFailureRepository) ReserveLoginFailure(ctx context.Context, key string, failedAt, since time.Time) ([]time.Time, error) {
+	args := m.Called(key, failedAt, since)
+	if args.Get(0) != nil {
+		return args.Get(0).([]time.Time), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockLoginFailureRepository) DeleteLoginFailure(ctx context.Context, key string, failedAt time.Time) error {
+	args := m.Called(key, failedAt)
+	return args.Error(0)
+}
+
+func (m *MockLoginFailureRepository) DeleteLoginFailures(ctx context.Context, key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockLoginFailureRepository) DeleteLoginFailuresBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,94 @@
+package repositories_login_failures
+
+import (
+	"backend/supabase"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_LoginFailure_PipeLine(t *testing.T) {
+	// Supabaseクライアントの初期化
+	setupSupabase(t)
+
+	// リポジトリのインスタンスを作成
+	repo := NewLoginFailureRepository(supabase.Pool)
+	ctx := context.Background()
+	key := "email:" + uuid.New().String()
+	now := time.Now()
+
+	// ---------------------------------------------------------
+	// 1. 失敗を記録し、古い順に取得
+	// ---------------------------------------------------------
+	assert.NoError(t, repo.RecordLoginFailure(ctx, key, now.Add(-time.Hour)))
+	assert.NoError(t, repo.RecordLoginFailure(ctx, key, now.Add(-time.Minute)))
+	assert.NoError(t, repo.RecordLoginFailure(ctx, key, now.Add(-2*time.Minute)))
+
+	failures, err := repo.FetchLoginFailures(ctx, key, now.Add(-10*time.Minute))
+	assert.NoError(t, err)
+	if assert.Len(t, failures, 2) {
+		assert.True(t, failures[0].Before(failures[1]))
+	}
+
+	// ---------------------------------------------------------
+	// 2. 期間を過ぎた記録を削除
+	// ---------------------------------------------------------
+	deleted, err := repo.DeleteLoginFailuresBefore(ctx, now.Add(-10*time.Minute))
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(1))
+
+	failures, err = repo.FetchLoginFailures(ctx, key, now.Add(-24*time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, failures, 2)
+
+	// ---------------------------------------------------------
+	// 3. 試行を先に記録し、それより前の失敗を取得・取り消し
+	// ---------------------------------------------------------
+	reservedAt := time.Now()
+	failures, err = repo.ReserveLoginFailure(ctx, key, reservedAt, now.Add(-10*time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, failures, 2)
+
+	failures, err = repo.FetchLoginFailures(ctx, key, now.Add(-10*time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, failures, 3)
+
+	assert.NoError(t, repo.DeleteLoginFailure(ctx, key, reservedAt))
+	failures, err = repo.FetchLoginFailures(ctx, key, now.Add(-10*time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, failures, 2)
+
+	// 同時に記録しても、それぞれ異なる件数の失敗を受け取る
+	burstKey := "ip:" + uuid.New().String()
+	counts := make(chan int, 5)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			failures, err := repo.ReserveLoginFailure(ctx, burstKey, time.Now(), now.Add(-10*time.Minute))
+			assert.NoError(t, err)
+			counts <- len(failures)
+		}()
+	}
+	wg.Wait()
+	close(counts)
+	seen := make(map[int]bool)
+	for n := range counts {
+		seen[n] = true
+	}
+	assert.Equal(t, map[int]bool{0: true, 1: true, 2: true, 3: true, 4: true}, seen)
+	assert.NoError(t, repo.DeleteLoginFailures(ctx, burstKey))
+
+	// ---------------------------------------------------------
+	// 4. ログインに成功したキーの記録を削除
+	// ---------------------------------------------------------
+	assert.NoError(t, repo.DeleteLoginFailures(ctx, key))
+	failures, err = repo.FetchLoginFailures(ctx, key, now.Add(-24*time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, failures)
+}
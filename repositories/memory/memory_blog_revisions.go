@@ -0,0 +1,108 @@
+package repositories_memory
+
+import (
+	"backend/logger"
+	"backend/models"
+	repositories_blog_revisions "backend/repositories/blog_revisions"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// BlogRevisionRepositoryのインメモリ実装
+type MemoryBlogRevisionRepository struct {
+	Store *Store
+}
+
+// BlogRevisionRepositoryインターフェースを実装したMemoryBlogRevisionRepositoryのポインタを返す
+func NewBlogRevisionRepository(store *Store) repositories_blog_revisions.BlogRevisionRepository {
+	return &MemoryBlogRevisionRepository{
+		Store: store,
+	}
+}
+
+// ブログの現在の内容を新しい版として保存する（呼び出し側でロックを取得すること）
+func (s *Store) snapshotBlog(blog models.BlogData, authorId string) {
+	revisions := s.blogRevisions[blog.ID]
+	s.blogRevisions[blog.ID] = append(revisions, models.BlogRevisionData{
+		ID:           uuid.New().String(),
+		BlogId:       blog.ID,
+		Revision:     len(revisions) + 1,
+		Title:        blog.Title,
+		Description:  blog.Description,
+		GithubUrl:    blog.GithubUrl,
+		Category:     blog.Category,
+		Tags:         blog.Tags,
+		BodyMarkdown: blog.BodyMarkdown,
+		AuthorId:     authorId,
+		CreatedAt:    time.Now(),
+	})
+}
+
+// ブログがゴミ箱に入っていないか確認する（呼び出し側でロックを取得すること）
+func (s *Store) isActiveBlog(id string) bool {
+	blog, ok := s.blogs[id]
+	return ok && blog.DeletedAt == nil
+}
+
+// 指定されたブログの版履歴を新しい順に取得する
+func (r *MemoryBlogRevisionRepository) FetchRevisionsByBlogId(ctx context.Context, blogId string) ([]models.BlogRevisionData, error) {
+	logger.InfoLog.Printf("FetchRevisionsByBlogId start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(blogId); err != nil {
+		logger.ErrorLog.Printf("Failed to fetch revisions: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	if !r.Store.isActiveBlog(blogId) {
+		logger.InfoLog.Println("Fetched 0 revisions")
+		return nil, nil
+	}
+
+	stored := r.Store.blogRevisions[blogId]
+	revisions := make([]models.BlogRevisionData, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		revisions = append(revisions, stored[i])
+	}
+
+	logger.InfoLog.Printf("Fetched %d revisions", len(revisions))
+	return revisions, nil
+}
+
+// 指定されたブログの指定された版を取得する
+func (r *MemoryBlogRevisionRepository) FetchRevision(ctx context.Context, blogId string, revision int) (*models.BlogRevisionData, error) {
+	logger.InfoLog.Printf("FetchRevision start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(blogId); err != nil {
+		logger.ErrorLog.Printf("Failed to fetch revision: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	stored := r.Store.blogRevisions[blogId]
+	if !r.Store.isActiveBlog(blogId) || revision < 1 || revision > len(stored) {
+		logger.ErrorLog.Printf("Failed to fetch revision: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+
+	result := stored[revision-1]
+	logger.InfoLog.Printf("Fetched revision: %v", result)
+	return &result, nil
+}
@@ -0,0 +1,82 @@
+package repositories_memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository_BlogRevision_PipeLine(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	store := NewStore()
+	repo := NewBlogRepository(store)
+	revisionRepo := NewBlogRevisionRepository(store)
+	seedCategories(store, "go", "web")
+
+	userId := uuid.New().String()
+	editorId := uuid.New().String()
+
+	blog, err := repo.CreateBlog(context.Background(), userId, "v1", "url", "go", "description", "tag", "")
+	assert.NoError(t, err)
+
+	// 作成直後は版履歴がない
+	revisions, err := revisionRepo.FetchRevisionsByBlogId(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, revisions)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 1. 更新のたびに更新前の内容が保存される
+	// ----------------------------------------------------------------------------------------------------------------------------
+	_, err = repo.UpdateBlog(context.Background(), blog.ID, userId, "v2", "url", "web", "description", "tag", "")
+	assert.NoError(t, err)
+	_, err = repo.UpdateBlog(context.Background(), blog.ID, editorId, "v3", "url", "web", "description", "tag", "")
+	assert.NoError(t, err)
+
+	revisions, err = revisionRepo.FetchRevisionsByBlogId(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, 2, revisions[0].Revision)
+	assert.Equal(t, "v2", revisions[0].Title)
+	assert.Equal(t, editorId, revisions[0].AuthorId)
+	assert.Equal(t, 1, revisions[1].Revision)
+	assert.Equal(t, "v1", revisions[1].Title)
+	assert.Equal(t, "go", revisions[1].Category)
+	assert.Equal(t, userId, revisions[1].AuthorId)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 2. 版の取得
+	// ----------------------------------------------------------------------------------------------------------------------------
+	revision, err := revisionRepo.FetchRevision(context.Background(), blog.ID, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", revision.Title)
+
+	_, err = revisionRepo.FetchRevision(context.Background(), blog.ID, 3)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// 更新に失敗した場合は版を保存しない
+	_, err = repo.UpdateBlog(context.Background(), blog.ID, editorId, "v4", "url", "unknown", "description", "tag", "")
+	assert.Error(t, err)
+	revisions, err = revisionRepo.FetchRevisionsByBlogId(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 3. ゴミ箱内のブログの版履歴は取得できず、完全削除で版履歴も削除される
+	// ----------------------------------------------------------------------------------------------------------------------------
+	err = repo.DeleteBlog(context.Background(), blog.ID)
+	assert.NoError(t, err)
+
+	revisions, err = revisionRepo.FetchRevisionsByBlogId(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, revisions)
+	_, err = revisionRepo.FetchRevision(context.Background(), blog.ID, 1)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	_, err = repo.PurgeDeletedBlogs(context.Background(), time.Now().Add(time.Second))
+	assert.NoError(t, err)
+	assert.Empty(t, store.blogRevisions)
+}
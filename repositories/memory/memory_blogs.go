@@ -0,0 +1,537 @@
+package repositories_memory
+
+import (
+	"backend/logger"
+	"backend/models"
+	repositories_blogs "backend/repositories/blogs"
+	repositories_tags "backend/repositories/tags"
+	utils_slug "backend/utils/slug"
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// BlogRepositoryのインメモリ実装
+type MemoryBlogRepository struct {
+	Store *Store
+}
+
+// BlogRepositoryインターフェースを実装したMemoryBlogRepositoryのポインタを返す
+func NewBlogRepository(store *Store) repositories_blogs.BlogRepository {
+	return &MemoryBlogRepository{
+		Store: store,
+	}
+}
+
+// 作成日時の降順でブログを並べ替える
+func sortBlogsByCreatedAtDesc(blogs []models.BlogData) {
+	sort.SliceStable(blogs, func(i, j int) bool {
+		if blogs[i].CreatedAt.Equal(blogs[j].CreatedAt) {
+			return blogs[i].ID > blogs[j].ID
+		}
+		return blogs[i].CreatedAt.After(blogs[j].CreatedAt)
+	})
+}
+
+// 一覧用に本文を除いたブログデータを返す
+// データベースの実装と同様に、本文は1件取得する場合のみ返す。
+func withoutBody(blog models.BlogData) models.BlogData {
+	blog.BodyMarkdown = ""
+	return blog
+}
+
+// 一覧・検索などで公開されるブログか判定する
+// ゴミ箱内のブログと公開済み以外のブログは公開されない。
+func isPublicBlog(blog models.BlogData) bool {
+	return blog.DeletedAt == nil && blog.Status == models.BlogStatusPublished
+}
+
+// 検索条件に一致するブログデータを取得する
+func (r *MemoryBlogRepository) FetchBlogs(ctx context.Context, filter models.BlogListFilter) ([]models.BlogData, error) {
+	logger.InfoLog.Printf("FetchBlogs start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	var blogs []models.BlogData
+	for _, blog := range r.Store.blogs {
+		if !r.Store.matchBlogListFilter(blog, filter) {
+			continue
+		}
+		blog = withoutBody(blog)
+
+		// カーソルより前(または同じ位置)のデータは除外する
+		if filter.After != nil && compareBlogListKey(blogListKey(blog, filter.Sort), *filter.After) <= 0 {
+			continue
+		}
+		blogs = append(blogs, blog)
+	}
+	sort.SliceStable(blogs, func(i, j int) bool {
+		return compareBlogListKey(blogListKey(blogs[i], filter.Sort), blogListKey(blogs[j], filter.Sort)) < 0
+	})
+	if filter.Limit > 0 && len(blogs) > filter.Limit {
+		blogs = blogs[:filter.Limit]
+	}
+
+	logger.InfoLog.Printf("Fetched %d blogs", len(blogs))
+	return blogs, nil
+}
+
+// 検索条件に一致するブログの総件数を取得する
+func (r *MemoryBlogRepository) CountBlogs(ctx context.Context, filter models.BlogListFilter) (int, error) {
+	logger.InfoLog.Printf("CountBlogs start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	total := 0
+	for _, blog := range r.Store.blogs {
+		if r.Store.matchBlogListFilter(blog, filter) {
+			total++
+		}
+	}
+
+	logger.InfoLog.Printf("Counted %d blogs", total)
+	return total, nil
+}
+
+// ブログが一覧の絞り込み条件に一致するか判定する（呼び出し側でロックを取得すること）
+func (s *Store) matchBlogListFilter(blog models.BlogData, filter models.BlogListFilter) bool {
+	if !isPublicBlog(blog) {
+		return false
+	}
+	if filter.Category != "" && blog.Category != filter.Category {
+		return false
+	}
+	if filter.UserId != "" && blog.UserId != filter.UserId {
+		return false
+	}
+	if filter.From != nil && blogPublishedAt(blog).Before(*filter.From) {
+		return false
+	}
+	if filter.To != nil && blogPublishedAt(blog).After(*filter.To) {
+		return false
+	}
+	if filter.Tag != "" {
+		// タグ名または別名に一致するタグが付いたブログに絞り込む(大文字小文字を区別しない)
+		tag, ok := s.findTagByName(filter.Tag)
+		return ok && containsString(s.blogTags[blog.ID], tag.ID)
+	}
+	return true
+}
+
+// ブログの公開日時を返す(未公開の場合はゼロ値)
+func blogPublishedAt(blog models.BlogData) time.Time {
+	if blog.PublishedAt == nil {
+		return time.Time{}
+	}
+	return *blog.PublishedAt
+}
+
+// 並び順に応じたブログの並び替えキーを返す
+func blogListKey(blog models.BlogData, sortKey string) models.BlogCursor {
+	key := models.BlogCursor{
+		Sort:        sortKey,
+		PublishedAt: blogPublishedAt(blog),
+		ID:          blog.ID,
+	}
+	switch sortKey {
+	case models.BlogSortMostLiked:
+		key.Count = blog.Likes
+	case models.BlogSortMostCommented:
+		key.Count = blog.CommentCnt
+	}
+	return key
+}
+
+// 並び替えキーを比較する
+// 一覧上で a が b より前に並ぶ場合は負、後ろの場合は正、同じ位置の場合は0を返す。
+func compareBlogListKey(a, b models.BlogCursor) int {
+	// 公開日時・IDの昇順での比較結果
+	asc := 0
+	switch {
+	case a.PublishedAt.Before(b.PublishedAt):
+		asc = -1
+	case a.PublishedAt.After(b.PublishedAt):
+		asc = 1
+	case a.ID < b.ID:
+		asc = -1
+	case a.ID > b.ID:
+		asc = 1
+	}
+
+	switch a.Sort {
+	case models.BlogSortOldest:
+		return asc
+	case models.BlogSortMostLiked, models.BlogSortMostCommented:
+		if a.Count > b.Count {
+			return -1
+		}
+		if a.Count < b.Count {
+			return 1
+		}
+		return -asc
+	default:
+		return -asc
+	}
+}
+
+// 指定されたユーザーIDに一致するブログデータを取得する
+// includeUnpublished が false の場合は公開済みのブログのみを取得する。
+func (r *MemoryBlogRepository) FetchBlogsByUserId(ctx context.Context, userId string, includeUnpublished bool) ([]models.BlogData, error) {
+	logger.InfoLog.Printf("FetchBlogsByUserId start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(userId); err != nil {
+		logger.ErrorLog.Printf("Failed to fetch blogs: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	var blogs []models.BlogData
+	for _, blog := range r.Store.blogs {
+		if blog.UserId != userId || blog.DeletedAt != nil {
+			continue
+		}
+		if includeUnpublished || blog.Status == models.BlogStatusPublished {
+			blogs = append(blogs, withoutBody(blog))
+		}
+	}
+	sortBlogsByCreatedAtDesc(blogs)
+
+	logger.InfoLog.Printf("Fetched %d blogs", len(blogs))
+	return blogs, nil
+}
+
+// 指定されたIDに一致するブログデータを取得する
+func (r *MemoryBlogRepository) FetchBlogById(ctx context.Context, id string) (*models.BlogData, error) {
+	logger.InfoLog.Printf("FetchBlogById start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(id); err != nil {
+		logger.ErrorLog.Printf("Failed to fetch blog: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	blog, ok := r.Store.blogs[id]
+	if !ok || blog.DeletedAt != nil {
+		logger.ErrorLog.Printf("Failed to fetch blog: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+
+	logger.InfoLog.Printf("Fetched blog: %v", blog)
+	return &blog, nil
+}
+
+// ブログデータの作成
+func (r *MemoryBlogRepository) CreateBlog(ctx context.Context, userId, title, githubUrl, category, description, tags, bodyMarkdown string) (*models.BlogData, error) {
+	logger.InfoLog.Printf("CreateBlog start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if userId == "" {
+		return nil, errors.New("user_id cannot be empty")
+	}
+	if _, err := uuid.Parse(userId); err != nil {
+		return nil, errors.New("invalid user_id format: must be a valid UUID")
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	if err := r.Store.checkCategoryReference(category); err != nil {
+		logger.ErrorLog.Printf("Failed to create blog: %v", err)
+		return nil, err
+	}
+
+	// タグを正規のタグに解決(別名は置き換え、未登録のタグは作成)
+	resolvedTags := r.Store.resolveTags(repositories_tags.SplitTagNames(tags))
+
+	now := time.Now()
+	blog := models.BlogData{
+		ID:           uuid.New().String(),
+		UserId:       userId,
+		Title:        title,
+		Description:  description,
+		GithubUrl:    githubUrl,
+		Category:     category,
+		Tags:         repositories_tags.JoinTags(resolvedTags),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		Status:       models.BlogStatusDraft,
+		Slug:         r.Store.uniqueBlogSlug(utils_slug.Generate(title)),
+		BodyMarkdown: bodyMarkdown,
+	}
+	r.Store.blogs[blog.ID] = blog
+	r.Store.replaceBlogTags(blog.ID, resolvedTags)
+
+	logger.InfoLog.Printf("Created blog: %v", blog)
+	return &blog, nil
+}
+
+// ブログデータの更新
+func (r *MemoryBlogRepository) UpdateBlog(ctx context.Context, id, userId, title, githubUrl, category, description, tags, bodyMarkdown string) (*models.BlogData, error) {
+	logger.InfoLog.Printf("UpdateBlog start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(id); err != nil {
+		logger.ErrorLog.Printf("Failed to update blog: %v", err)
+		return nil, err
+	}
+	if err := validateUUID(userId); err != nil {
+		logger.ErrorLog.Printf("Failed to update blog: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	blog, ok := r.Store.blogs[id]
+	if !ok || blog.DeletedAt != nil {
+		logger.ErrorLog.Printf("Failed to update blog: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+	if err := r.Store.checkCategoryReference(category); err != nil {
+		logger.ErrorLog.Printf("Failed to update blog: %v", err)
+		return nil, err
+	}
+
+	// 更新前の内容を版履歴に保存
+	r.Store.snapshotBlog(blog, userId)
+
+	blog.Title = title
+	blog.GithubUrl = githubUrl
+	blog.Category = category
+	// タグを正規のタグに解決(別名は置き換え、未登録のタグは作成)
+	resolvedTags := r.Store.resolveTags(repositories_tags.SplitTagNames(tags))
+
+	blog.Description = description
+	blog.Tags = repositories_tags.JoinTags(resolvedTags)
+	blog.BodyMarkdown = bodyMarkdown
+	blog.UpdatedAt = time.Now()
+	r.Store.blogs[id] = blog
+	r.Store.replaceBlogTags(id, resolvedTags)
+
+	logger.InfoLog.Printf("Updated blog: %v", blog)
+	return &blog, nil
+}
+
+// ブログデータの削除(ゴミ箱へ移動)
+func (r *MemoryBlogRepository) DeleteBlog(ctx context.Context, id string) error {
+	logger.InfoLog.Printf("DeleteBlog start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if id == "" {
+		return errors.New("id cannot be empty")
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return errors.New("invalid id format: must be a valid UUID")
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	// ゴミ箱へ移動する(保持期間を過ぎると PurgeDeletedBlogs で完全に削除される)
+	if blog, ok := r.Store.blogs[id]; ok && blog.DeletedAt == nil {
+		now := time.Now()
+		blog.DeletedAt = &now
+		r.Store.blogs[id] = blog
+	}
+
+	logger.InfoLog.Println("Deleted blog successfully")
+	return nil
+}
+
+// ブログタグ一覧を使用しているブログ数とともに取得する
+func (r *MemoryBlogRepository) FetchBlogTags(ctx context.Context) ([]models.TagCount, error) {
+	logger.InfoLog.Printf("FetchBlogTags start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	var tags []models.TagCount
+	for _, tag := range r.Store.tags {
+		tag = r.Store.tagWithAggregates(tag)
+		if tag.Count == 0 {
+			continue
+		}
+		tags = append(tags, models.TagCount{
+			Name:  tag.Name,
+			Count: tag.Count,
+		})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return strings.ToLower(tags[i].Name) < strings.ToLower(tags[j].Name)
+	})
+
+	logger.InfoLog.Printf("Fetched %d blog tags", len(tags))
+	return tags, nil
+}
+
+// 人気のあるブログを取得する
+func (r *MemoryBlogRepository) FetchBlogPopular(ctx context.Context, count int) ([]models.BlogData, error) {
+	logger.InfoLog.Printf("FetchBlogPopular start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if count < 0 {
+		return nil, errors.New("LIMIT must not be negative")
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	var blogs []models.BlogData
+	for _, blog := range r.Store.blogs {
+		if !isPublicBlog(blog) {
+			continue
+		}
+		blogs = append(blogs, models.BlogData{
+			ID:          blog.ID,
+			UserId:      blog.UserId,
+			Title:       blog.Title,
+			Likes:       blog.Likes,
+			CreatedAt:   blog.CreatedAt,
+			UpdatedAt:   blog.UpdatedAt,
+			Status:      blog.Status,
+			PublishedAt: blog.PublishedAt,
+			Slug:        blog.Slug,
+		})
+	}
+	// いいね数の多い順に、同数の場合は公開日時の新しい順に並べる
+	sort.SliceStable(blogs, func(i, j int) bool {
+		return compareBlogListKey(
+			blogListKey(blogs[i], models.BlogSortMostLiked),
+			blogListKey(blogs[j], models.BlogSortMostLiked),
+		) < 0
+	})
+	if len(blogs) > count {
+		blogs = blogs[:count]
+	}
+
+	logger.InfoLog.Printf("Fetched %d popular blogs", len(blogs))
+	return blogs, nil
+}
+
+// 検索語に一致するブログをスコア順に取得する
+// Supabase実装のILIKEと同様に、大文字小文字を区別しない部分一致で判定する。
+func (r *MemoryBlogRepository) SearchBlogs(ctx context.Context, terms []string, limit int) ([]models.BlogSearchResult, error) {
+	logger.InfoLog.Printf("SearchBlogs start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(terms) == 0 {
+		return nil, errors.New("terms cannot be empty")
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	var results []models.BlogSearchResult
+	for _, blog := range r.Store.blogs {
+		if !isPublicBlog(blog) {
+			continue
+		}
+		score, ok := searchScore(blog, terms)
+		if !ok {
+			continue
+		}
+		results = append(results, models.BlogSearchResult{
+			BlogData: withoutBody(blog),
+			Score:    score,
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return compareBlogListKey(
+			blogListKey(results[i].BlogData, models.BlogSortNewest),
+			blogListKey(results[j].BlogData, models.BlogSortNewest),
+		) < 0
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	logger.InfoLog.Printf("Searched %d blogs", len(results))
+	return results, nil
+}
+
+// 検索語ごとに一致した項目の重みを合計したスコアを返す
+// いずれかの検索語がどの項目にも一致しない場合は false を返す。
+func searchScore(blog models.BlogData, terms []string) (int, bool) {
+	title := strings.ToLower(blog.Title)
+	tags := strings.ToLower(blog.Tags)
+	description := strings.ToLower(blog.Description)
+
+	score := 0
+	for _, term := range terms {
+		term = strings.ToLower(term)
+		termScore := 0
+		if strings.Contains(title, term) {
+			termScore += models.BlogSearchWeightTitle
+		}
+		if strings.Contains(tags, term) {
+			termScore += models.BlogSearchWeightTags
+		}
+		if strings.Contains(description, term) {
+			termScore += models.BlogSearchWeightDescription
+		}
+		if termScore == 0 {
+			return 0, false
+		}
+		score += termScore
+	}
+	return score, true
+}
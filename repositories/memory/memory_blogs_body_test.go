@@ -0,0 +1,65 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository_BlogBody_PipeLine(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	store := NewStore()
+	repo := NewBlogRepository(store)
+	revisionRepo := NewBlogRevisionRepository(store)
+	seedCategories(store, "go")
+
+	userId := uuid.New().String()
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 1. 作成・取得では本文を返す
+	// ----------------------------------------------------------------------------------------------------------------------------
+	blog, err := repo.CreateBlog(context.Background(), userId, "title", "url", "go", "description", "tag", "# v1")
+	assert.NoError(t, err)
+	assert.Equal(t, "# v1", blog.BodyMarkdown)
+	publishBlog(t, repo, blog.ID)
+
+	fetched, err := repo.FetchBlogById(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "# v1", fetched.BodyMarkdown)
+
+	bySlug, err := repo.FetchBlogBySlug(context.Background(), blog.Slug)
+	assert.NoError(t, err)
+	assert.Equal(t, "# v1", bySlug.BodyMarkdown)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 2. 一覧・検索では本文を返さない
+	// ----------------------------------------------------------------------------------------------------------------------------
+	blogs, err := repo.FetchBlogs(context.Background(), models.BlogListFilter{Sort: models.BlogSortNewest})
+	assert.NoError(t, err)
+	assert.Len(t, blogs, 1)
+	assert.Empty(t, blogs[0].BodyMarkdown)
+
+	blogs, err = repo.FetchBlogsByUserId(context.Background(), userId, true)
+	assert.NoError(t, err)
+	assert.Len(t, blogs, 1)
+	assert.Empty(t, blogs[0].BodyMarkdown)
+
+	results, err := repo.SearchBlogs(context.Background(), []string{"title"}, 10)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Empty(t, results[0].BodyMarkdown)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 3. 更新前の本文を版履歴に保存する
+	// ----------------------------------------------------------------------------------------------------------------------------
+	updated, err := repo.UpdateBlog(context.Background(), blog.ID, userId, "title", "url", "go", "description", "tag", "# v2")
+	assert.NoError(t, err)
+	assert.Equal(t, "# v2", updated.BodyMarkdown)
+
+	revision, err := revisionRepo.FetchRevision(context.Background(), blog.ID, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "# v1", revision.BodyMarkdown)
+}
@@ -0,0 +1,24 @@
+package repositories_memory
+
+import (
+	"backend/logger"
+	"context"
+)
+
+// いいね数・コメント数の集計値を再計算する
+func (r *MemoryBlogRepository) ReconcileBlogCounters(ctx context.Context) (int, error) {
+	logger.InfoLog.Printf("ReconcileBlogCounters start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	count := r.Store.reconcileCounters()
+
+	logger.InfoLog.Printf("Reconciled counters of %d blogs", count)
+	return count, nil
+}
@@ -0,0 +1,70 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository_BlogCounters(t *testing.T) {
+	store := NewStore()
+	repo := NewBlogRepository(store)
+	likeRepo := NewBlogLikeRepository(store)
+	commentRepo := NewCommentRepository(store)
+	store.SeedCategory(models.CategoryData{Name: "test_category", Slug: "test-category"})
+
+	blog, err := repo.CreateBlog(context.Background(), uuid.New().String(), "test_title", "", "test_category", "", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), blog.Likes)
+	assert.Equal(t, int64(0), blog.CommentCnt)
+
+	// 127件を超えても桁あふれしない
+	visitIds := make([]string, 200)
+	for i := range visitIds {
+		visitIds[i] = uuid.New().String()
+		_, err := likeRepo.CreateBlogLike(context.Background(), blog.ID, visitIds[i])
+		assert.NoError(t, err)
+	}
+	for i := 0; i < 130; i++ {
+		_, err := commentRepo.CreateComment(context.Background(), blog.ID, "guest", "comment")
+		assert.NoError(t, err)
+	}
+
+	fetched, err := repo.FetchBlogById(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(200), fetched.Likes)
+	assert.Equal(t, int64(130), fetched.CommentCnt)
+
+	// いいねの削除で減る(登録されていないいいねの削除では変わらない)
+	assert.NoError(t, likeRepo.DeleteBlogLike(context.Background(), blog.ID, visitIds[0]))
+	assert.NoError(t, likeRepo.DeleteBlogLike(context.Background(), blog.ID, uuid.New().String()))
+
+	fetched, err = repo.FetchBlogById(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(199), fetched.Likes)
+
+	// 食い違いがない場合は何もしない
+	count, err := repo.ReconcileBlogCounters(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// ずれた集計値を再計算で修正する
+	store.mu.Lock()
+	broken := store.blogs[blog.ID]
+	broken.Likes = 5
+	broken.CommentCnt = -1
+	store.blogs[blog.ID] = broken
+	store.mu.Unlock()
+
+	count, err = repo.ReconcileBlogCounters(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	fetched, err = repo.FetchBlogById(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(199), fetched.Likes)
+	assert.Equal(t, int64(130), fetched.CommentCnt)
+}
@@ -0,0 +1,156 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	repositories_blogs_likes "backend/repositories/blogs_likes"
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// BlogLikeRepositoryのインメモリ実装
+type MemoryBlogLikeRepository struct {
+	Store *Store
+}
+
+// BlogLikeRepositoryインターフェースを実装したMemoryBlogLikeRepositoryのポインタを返す
+func NewBlogLikeRepository(store *Store) repositories_blogs_likes.BlogLikeRepository {
+	return &MemoryBlogLikeRepository{
+		Store: store,
+	}
+}
+
+// VisitIdによっていいねデータを取得
+// ゴミ箱に入っているブログへのいいねは取得しない。
+func (r *MemoryBlogLikeRepository) FetchBlogLikesByVisitId(ctx context.Context, visitId string) ([]models.BlogLikeData, error) {
+	log.Println("FetchBlogLikesByVisitId start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(visitId); err != nil {
+		log.Printf("Failed to fetch blog likes by visit id: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	var blogLikes []models.BlogLikeData
+	for _, blogLike := range r.Store.blogLikes {
+		if blogLike.VisitId == visitId && r.Store.isActiveBlog(blogLike.BlogId) {
+			blogLikes = append(blogLikes, blogLike)
+		}
+	}
+	sort.SliceStable(blogLikes, func(i, j int) bool {
+		return blogLikes[i].CreatedAt.Before(blogLikes[j].CreatedAt)
+	})
+
+	log.Printf("Fetched blog likes by visit id: %v", blogLikes)
+	return blogLikes, nil
+}
+
+// いいね存在するか確認
+// ゴミ箱に入っているブログへのいいねは存在しないものとして扱う。
+func (r *MemoryBlogLikeRepository) IsBlogLiked(ctx context.Context, blogId, visitId string) (bool, error) {
+	log.Println("IsBlogLiked start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	if err := validateUUID(blogId); err != nil {
+		log.Printf("Failed to check if blog is liked: %v", err)
+		return false, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	for _, blogLike := range r.Store.blogLikes {
+		if blogLike.BlogId == blogId && blogLike.VisitId == visitId && r.Store.isActiveBlog(blogId) {
+			log.Println("Blog is liked")
+			return true, nil
+		}
+	}
+
+	log.Printf("Failed to check if blog is liked: %v", pgx.ErrNoRows)
+	return false, pgx.ErrNoRows
+}
+
+// いいねデータの作成
+// ブログがない、またはゴミ箱に入っている場合は ErrBlogNotFound を返す。
+func (r *MemoryBlogLikeRepository) CreateBlogLike(ctx context.Context, blogId, visitId string) (*models.BlogLikeData, error) {
+	log.Println("CreateBlogLike start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(blogId); err != nil {
+		log.Printf("Failed to create blog like: %v", err)
+		return nil, err
+	}
+	if err := validateUUID(visitId); err != nil {
+		log.Printf("Failed to create blog like: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	if !r.Store.isActiveBlog(blogId) {
+		log.Printf("Failed to create blog like: %v", repositories_blogs_likes.ErrBlogNotFound)
+		return nil, repositories_blogs_likes.ErrBlogNotFound
+	}
+
+	now := time.Now()
+	blogLike := models.BlogLikeData{
+		ID:        uuid.New().String(),
+		BlogId:    blogId,
+		VisitId:   visitId,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	r.Store.blogLikes[blogLike.ID] = blogLike
+	r.Store.adjustCounters(blogId, 1, 0)
+
+	log.Printf("Created blog like: %v", blogLike)
+	return &blogLike, nil
+}
+
+// いいねデータの削除
+func (r *MemoryBlogLikeRepository) DeleteBlogLike(ctx context.Context, blogId, visitId string) error {
+	log.Println("DeleteBlogLike start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := validateUUID(blogId); err != nil {
+		log.Printf("Failed to delete blog like: %v", err)
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	for id, blogLike := range r.Store.blogLikes {
+		if blogLike.BlogId == blogId && blogLike.VisitId == visitId {
+			delete(r.Store.blogLikes, id)
+			r.Store.adjustCounters(blogId, -1, 0)
+		}
+	}
+
+	log.Println("Deleted blog like")
+	return nil
+}
@@ -0,0 +1,65 @@
+package repositories_memory
+
+import (
+	repositories_blogs_likes "backend/repositories/blogs_likes"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository_BlogLike_PipeLine(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	store := NewStore()
+	repo := NewBlogLikeRepository(store)
+	seedCategories(store, "go")
+
+	blog, err := NewBlogRepository(store).CreateBlog(context.Background(), uuid.New().String(), "title", "url", "go", "description", "tag", "")
+	assert.NoError(t, err)
+	blogID := blog.ID
+	visitorID := uuid.New().String()
+
+	// 存在しないブログにはいいねできない
+	_, err = repo.CreateBlogLike(context.Background(), uuid.New().String(), visitorID)
+	assert.ErrorIs(t, err, repositories_blogs_likes.ErrBlogNotFound)
+
+	// ---------------------------------------------------------
+	// 1. 「いいね」を作成
+	// ---------------------------------------------------------
+	like, err := repo.CreateBlogLike(context.Background(), blogID, visitorID)
+
+	// エラーチェックとデータ確認
+	assert.NoError(t, err)
+	assert.NotNil(t, like)
+
+	// ---------------------------------------------------------
+	// 2. 「いいね」が存在するか確認
+	// ---------------------------------------------------------
+	liked, err := repo.IsBlogLiked(context.Background(), blogID, visitorID)
+
+	// エラーチェックとデータ確認
+	assert.NoError(t, err)
+	assert.True(t, liked)
+
+	likes, err := repo.FetchBlogLikesByVisitId(context.Background(), visitorID)
+	assert.NoError(t, err)
+	assert.Len(t, likes, 1)
+
+	// ---------------------------------------------------------
+	// 3. 「いいね」を削除
+	// ---------------------------------------------------------
+	err = repo.DeleteBlogLike(context.Background(), blogID, visitorID)
+
+	// エラーチェック
+	assert.NoError(t, err)
+
+	// ---------------------------------------------------------
+	// 4. 「いいね」が削除されたことを確認
+	// ---------------------------------------------------------
+	liked, err = repo.IsBlogLiked(context.Background(), blogID, visitorID)
+
+	// エラーチェックとデータ確認（削除後なので false を期待）
+	assert.Error(t, err)
+	assert.False(t, liked)
+}
@@ -0,0 +1,158 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// 一覧取得テスト用のブログを作成する
+// i番目のブログには i 件のいいねを付与する。
+func setupListBlogs(t *testing.T, store *Store, userId string, n int) []models.BlogData {
+	repo := NewBlogRepository(store)
+	likeRepo := NewBlogLikeRepository(store)
+	seedCategories(store, "even", "odd")
+
+	var blogs []models.BlogData
+	for i := 0; i < n; i++ {
+		category := "even"
+		if i%2 == 1 {
+			category = "odd"
+		}
+		blog, err := repo.CreateBlog(context.Background(), userId, fmt.Sprintf("title%d", i), "github_url", category, "description", fmt.Sprintf("tag%d, common", i), "")
+		assert.NoError(t, err)
+		blog = publishBlog(t, repo, blog.ID)
+
+		for j := 0; j < i; j++ {
+			_, err := likeRepo.CreateBlogLike(context.Background(), blog.ID, uuid.New().String())
+			assert.NoError(t, err)
+		}
+		blogs = append(blogs, *blog)
+	}
+	return blogs
+}
+
+// カーソルを辿って全ページを取得する
+func fetchAllPages(t *testing.T, repo interface {
+	FetchBlogs(ctx context.Context, filter models.BlogListFilter) ([]models.BlogData, error)
+}, filter models.BlogListFilter) []models.BlogData {
+	var all []models.BlogData
+	for {
+		blogs, err := repo.FetchBlogs(context.Background(), filter)
+		assert.NoError(t, err)
+		all = append(all, blogs...)
+		if len(blogs) < filter.Limit {
+			return all
+		}
+		cursor := blogListKey(blogs[len(blogs)-1], filter.Sort)
+		filter.After = &cursor
+	}
+}
+
+func TestMemoryRepository_FetchBlogs_Pagination(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	store := NewStore()
+	repo := NewBlogRepository(store)
+	setupListBlogs(t, store, uuid.New().String(), 5)
+
+	// 新しい順: 2件ずつ辿っても全件が重複なく取得できること
+	newest := fetchAllPages(t, repo, models.BlogListFilter{Limit: 2, Sort: models.BlogSortNewest})
+	assert.Len(t, newest, 5)
+	for i := 1; i < len(newest); i++ {
+		assert.Less(t, compareBlogListKey(blogListKey(newest[i-1], models.BlogSortNewest), blogListKey(newest[i], models.BlogSortNewest)), 0)
+	}
+
+	// 古い順: 新しい順の逆順になること
+	oldest := fetchAllPages(t, repo, models.BlogListFilter{Limit: 2, Sort: models.BlogSortOldest})
+	assert.Len(t, oldest, 5)
+	for i := range oldest {
+		assert.Equal(t, newest[len(newest)-1-i].ID, oldest[i].ID)
+	}
+
+	// いいねの多い順
+	liked := fetchAllPages(t, repo, models.BlogListFilter{Limit: 2, Sort: models.BlogSortMostLiked})
+	assert.Len(t, liked, 5)
+	assert.Equal(t, "title4", liked[0].Title)
+	assert.Equal(t, int64(4), liked[0].Likes)
+	assert.Equal(t, "title0", liked[4].Title)
+}
+
+func TestMemoryRepository_FetchBlogs_Filter(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	store := NewStore()
+	repo := NewBlogRepository(store)
+	userId := uuid.New().String()
+	setupListBlogs(t, store, userId, 4)
+	setupListBlogs(t, store, uuid.New().String(), 2)
+
+	// カテゴリで絞り込み
+	blogs, err := repo.FetchBlogs(context.Background(), models.BlogListFilter{Limit: 10, Category: "odd"})
+	assert.NoError(t, err)
+	assert.Len(t, blogs, 3)
+
+	// タグで絞り込み(カンマ区切りの各要素と完全一致)
+	blogs, err = repo.FetchBlogs(context.Background(), models.BlogListFilter{Limit: 10, Tag: "tag3"})
+	assert.NoError(t, err)
+	assert.Len(t, blogs, 1)
+	blogs, err = repo.FetchBlogs(context.Background(), models.BlogListFilter{Limit: 10, Tag: "common"})
+	assert.NoError(t, err)
+	assert.Len(t, blogs, 6)
+	blogs, err = repo.FetchBlogs(context.Background(), models.BlogListFilter{Limit: 10, Tag: "tag"})
+	assert.NoError(t, err)
+	assert.Len(t, blogs, 0)
+
+	// ユーザーIDで絞り込み
+	total, err := repo.CountBlogs(context.Background(), models.BlogListFilter{UserId: userId})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, total)
+
+	// 日付で絞り込み
+	future := time.Now().Add(time.Hour)
+	blogs, err = repo.FetchBlogs(context.Background(), models.BlogListFilter{Limit: 10, From: &future})
+	assert.NoError(t, err)
+	assert.Len(t, blogs, 0)
+	total, err = repo.CountBlogs(context.Background(), models.BlogListFilter{To: &future})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, total)
+}
+
+// 一覧は作成日時ではなく公開日時で並べ、絞り込み・カーソルにも公開日時を使う
+func TestMemoryRepository_FetchBlogs_PublishedAt(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	store := NewStore()
+	repo := NewBlogRepository(store)
+	seedCategories(store, "go")
+	userId := uuid.New().String()
+
+	// 先に作成したブログを後から公開する
+	older, err := repo.CreateBlog(context.Background(), userId, "older", "github_url", "go", "description", "", "")
+	assert.NoError(t, err)
+	newer, err := repo.CreateBlog(context.Background(), userId, "newer", "github_url", "go", "description", "", "")
+	assert.NoError(t, err)
+	earlier := time.Now().Add(-time.Hour)
+	_, err = repo.UpdateBlogStatus(context.Background(), newer.ID, models.BlogStatusDraft, models.BlogStatusPublished, &earlier)
+	assert.NoError(t, err)
+	later := time.Now()
+	_, err = repo.UpdateBlogStatus(context.Background(), older.ID, models.BlogStatusDraft, models.BlogStatusPublished, &later)
+	assert.NoError(t, err)
+
+	// 新しい順は公開日時の新しい順
+	blogs := fetchAllPages(t, repo, models.BlogListFilter{Limit: 1, Sort: models.BlogSortNewest})
+	if assert.Len(t, blogs, 2) {
+		assert.Equal(t, older.ID, blogs[0].ID)
+		assert.Equal(t, newer.ID, blogs[1].ID)
+	}
+
+	// 日付の絞り込みも公開日時で判定する
+	from := earlier.Add(time.Minute)
+	blogs, err = repo.FetchBlogs(context.Background(), models.BlogListFilter{Limit: 10, From: &from})
+	assert.NoError(t, err)
+	if assert.Len(t, blogs, 1) {
+		assert.Equal(t, older.ID, blogs[0].ID)
+	}
+}
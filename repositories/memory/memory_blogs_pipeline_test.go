@@ -0,0 +1,138 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository_Blog_PipeLine(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	store := NewStore()
+	repo := NewBlogRepository(store)
+	likeRepo := NewBlogLikeRepository(store)
+	commentRepo := NewCommentRepository(store)
+	categoryRepo := NewCategoryRepository(store)
+
+	// ブログのカテゴリは登録済みである必要がある
+	store.SeedCategory(models.CategoryData{Name: "test_category", Slug: "test-category"})
+	store.SeedCategory(models.CategoryData{Name: "updated_category", Slug: "updated-category"})
+
+	userId := uuid.New().String()
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 1. ブログ生成テスト
+	// ----------------------------------------------------------------------------------------------------------------------------
+	blog, err := repo.CreateBlog(context.Background(), userId, "test_title", "test_github_url", "test_category", "test_description", "test_tags", "")
+
+	// エラーチェックとデータ確認
+	assert.NoError(t, err)
+	assert.NotNil(t, blog)
+	assert.Equal(t, userId, blog.UserId)
+	assert.Equal(t, models.BlogStatusDraft, blog.Status)
+
+	// 下書きは一覧に表示されないため公開しておく
+	now := time.Now()
+	blog, err = repo.UpdateBlogStatus(context.Background(), blog.ID, models.BlogStatusDraft, models.BlogStatusPublished, &now)
+	assert.NoError(t, err)
+	assert.Equal(t, models.BlogStatusPublished, blog.Status)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 2. いいね・コメントの集計テスト
+	// ----------------------------------------------------------------------------------------------------------------------------
+	_, err = likeRepo.CreateBlogLike(context.Background(), blog.ID, uuid.New().String())
+	assert.NoError(t, err)
+	_, err = commentRepo.CreateComment(context.Background(), blog.ID, "guest", "comment")
+	assert.NoError(t, err)
+
+	fetchedBlog, err := repo.FetchBlogById(context.Background(), blog.ID)
+
+	// エラーチェックとデータ確認
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), fetchedBlog.Likes)
+	assert.Equal(t, int64(1), fetchedBlog.CommentCnt)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 3. ブログ更新テスト
+	// ----------------------------------------------------------------------------------------------------------------------------
+	updatedBlog, err := repo.UpdateBlog(context.Background(), blog.ID, userId, "updated_title", "updated_github_url", "updated_category", "updated_description", "updated_tags", "")
+
+	// エラーチェックとデータ確認
+	assert.NoError(t, err)
+	assert.Equal(t, "updated_title", updatedBlog.Title)
+	assert.Equal(t, int64(1), updatedBlog.Likes)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 4. 一覧・カテゴリ・タグ・人気ブログ取得テスト
+	// ----------------------------------------------------------------------------------------------------------------------------
+	blogs, err := repo.FetchBlogsByUserId(context.Background(), userId, false)
+	assert.NoError(t, err)
+	assert.Len(t, blogs, 1)
+
+	categories, err := categoryRepo.FetchCategories(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, categories, 2)
+	assert.Equal(t, "test_category", categories[0].Name)
+	assert.Equal(t, 0, categories[0].PostCount)
+	assert.Equal(t, "updated_category", categories[1].Name)
+	assert.Equal(t, 1, categories[1].PostCount)
+
+	tags, err := repo.FetchBlogTags(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []models.TagCount{{Name: "updated_tags", Count: 1}}, tags)
+
+	popular, err := repo.FetchBlogPopular(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Len(t, popular, 1)
+	assert.Equal(t, blog.ID, popular[0].ID)
+	assert.Empty(t, popular[0].Description)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 5. ブログ削除テスト
+	// ----------------------------------------------------------------------------------------------------------------------------
+	err = repo.DeleteBlog(context.Background(), blog.ID)
+
+	// エラーチェック
+	assert.NoError(t, err)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 6. ブログ取得エラーテスト
+	// ----------------------------------------------------------------------------------------------------------------------------
+	fetchedBlog, err = repo.FetchBlogById(context.Background(), blog.ID)
+
+	// エラーチェックとデータ確認
+	assert.Error(t, err)
+	assert.Nil(t, fetchedBlog)
+}
+
+func TestMemoryRepository_CreateBlog_InvalidUserId(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	repo := NewBlogRepository(NewStore())
+
+	// メソッドを実行
+	blog, err := repo.CreateBlog(context.Background(), "invalid", "title", "url", "category", "description", "tags", "")
+
+	// エラーチェックとデータ確認
+	assert.Error(t, err)
+	assert.Nil(t, blog)
+}
+
+func TestMemoryRepository_FetchBlogs_Canceled(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	repo := NewBlogRepository(NewStore())
+
+	// キャンセル済みのコンテキスト
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// メソッドを実行
+	blogs, err := repo.FetchBlogs(ctx, models.BlogListFilter{Limit: 10})
+
+	// エラーチェックとデータ確認
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, blogs)
+}
@@ -0,0 +1,55 @@
+package repositories_memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository_SearchBlogs(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	store := NewStore()
+	repo := NewBlogRepository(store)
+	userId := uuid.New().String()
+	seedCategories(store, "Go", "Web", "Life")
+
+	inTitle, err := repo.CreateBlog(context.Background(), userId, "Go言語でブログを作る", "url", "Go", "バックエンドの説明", "Go, Echo", "")
+	assert.NoError(t, err)
+	inTags, err := repo.CreateBlog(context.Background(), userId, "フロントエンド入門", "url", "Web", "Next.jsの説明", "ブログ, Next.js", "")
+	assert.NoError(t, err)
+	inDescription, err := repo.CreateBlog(context.Background(), userId, "日記", "url", "Life", "今日はブログを書いた", "diary", "")
+	assert.NoError(t, err)
+	for _, id := range []string{inTitle.ID, inTags.ID, inDescription.ID} {
+		publishBlog(t, repo, id)
+	}
+
+	// 空白を含まない日本語の部分一致で検索でき、タイトル > タグ > 説明 の順に並ぶこと
+	results, err := repo.SearchBlogs(context.Background(), []string{"ブログ"}, 10)
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, inTitle.ID, results[0].ID)
+	assert.Equal(t, 3, results[0].Score)
+	assert.Equal(t, inTags.ID, results[1].ID)
+	assert.Equal(t, 2, results[1].Score)
+	assert.Equal(t, inDescription.ID, results[2].ID)
+	assert.Equal(t, 1, results[2].Score)
+
+	// 複数の検索語はすべて含むものだけが対象(大文字小文字は区別しない)
+	results, err = repo.SearchBlogs(context.Background(), []string{"ブログ", "echo"}, 10)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, inTitle.ID, results[0].ID)
+	assert.Equal(t, 5, results[0].Score)
+
+	// 取得件数の制限
+	results, err = repo.SearchBlogs(context.Background(), []string{"ブログ"}, 1)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	// 一致しない場合
+	results, err = repo.SearchBlogs(context.Background(), []string{"存在しない"}, 10)
+	assert.NoError(t, err)
+	assert.Len(t, results, 0)
+}
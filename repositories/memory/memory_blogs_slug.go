@@ -0,0 +1,106 @@
+package repositories_memory
+
+import (
+	"backend/logger"
+	"backend/models"
+	repositories_blogs "backend/repositories/blogs"
+	utils_slug "backend/utils/slug"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// スラッグが指定されたブログ以外の現在または変更前のスラッグとして使用されているか判定する（呼び出し側でロックを取得すること）
+func (s *Store) blogSlugInUse(slug, blogId string) bool {
+	for _, blog := range s.blogs {
+		if blog.Slug == slug && blog.ID != blogId {
+			return true
+		}
+	}
+	redirectTo, ok := s.blogSlugRedirects[slug]
+	return ok && redirectTo != blogId
+}
+
+// 他のブログと重複しないスラッグを返す（呼び出し側でロックを取得すること）
+// base が使用済みの場合は連番を付ける(例: "hello-2")。
+func (s *Store) uniqueBlogSlug(base string) string {
+	if !s.blogSlugInUse(base, "") {
+		return base
+	}
+	for n := 2; ; n++ {
+		if slug := utils_slug.WithSuffix(base, n); !s.blogSlugInUse(slug, "") {
+			return slug
+		}
+	}
+}
+
+// 指定されたスラッグに一致するブログデータを取得する
+// 変更前のスラッグの場合は現在のブログデータを返す。
+func (r *MemoryBlogRepository) FetchBlogBySlug(ctx context.Context, slug string) (*models.BlogData, error) {
+	logger.InfoLog.Printf("FetchBlogBySlug start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	blogId, redirected := r.Store.blogSlugRedirects[slug]
+	for _, blog := range r.Store.blogs {
+		if blog.DeletedAt != nil {
+			continue
+		}
+		if blog.Slug == slug || (redirected && blog.ID == blogId) {
+			logger.InfoLog.Printf("Fetched blog: %v", blog)
+			return &blog, nil
+		}
+	}
+
+	logger.ErrorLog.Printf("Failed to fetch blog: %v", pgx.ErrNoRows)
+	return nil, pgx.ErrNoRows
+}
+
+// 指定されたブログのスラッグを変更する
+// 変更前のスラッグはリダイレクト用に保持する。他のブログと重複する場合は ErrBlogSlugConflict を返す。
+func (r *MemoryBlogRepository) UpdateBlogSlug(ctx context.Context, id, slug string) (*models.BlogData, error) {
+	logger.InfoLog.Printf("UpdateBlogSlug start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(id); err != nil {
+		logger.ErrorLog.Printf("Failed to update blog slug: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	blog, ok := r.Store.blogs[id]
+	if !ok || blog.DeletedAt != nil {
+		logger.ErrorLog.Printf("Failed to update blog slug: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+
+	if blog.Slug != slug {
+		if r.Store.blogSlugInUse(slug, id) {
+			logger.ErrorLog.Printf("Failed to update blog slug: %v", repositories_blogs.ErrBlogSlugConflict)
+			return nil, repositories_blogs.ErrBlogSlugConflict
+		}
+
+		// 自身の変更前のスラッグに戻す場合はリダイレクトを削除し、現在のスラッグをリダイレクトとして保持する
+		delete(r.Store.blogSlugRedirects, slug)
+		r.Store.blogSlugRedirects[blog.Slug] = id
+		blog.Slug = slug
+		blog.UpdatedAt = time.Now()
+		r.Store.blogs[id] = blog
+	}
+
+	logger.InfoLog.Printf("Updated blog slug: %v", blog)
+	return &blog, nil
+}
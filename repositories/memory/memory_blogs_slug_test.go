@@ -0,0 +1,81 @@
+package repositories_memory
+
+import (
+	repositories_blogs "backend/repositories/blogs"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository_BlogSlug_PipeLine(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	store := NewStore()
+	repo := NewBlogRepository(store)
+	userId := uuid.New().String()
+	seedCategories(store, "go")
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 1. タイトルからスラッグを生成し、重複する場合は連番を付ける
+	// ----------------------------------------------------------------------------------------------------------------------------
+	first, err := repo.CreateBlog(context.Background(), userId, "Hello World", "url", "go", "description", "tag", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello-world", first.Slug)
+	second, err := repo.CreateBlog(context.Background(), userId, "Hello, World!", "url", "go", "description", "tag", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello-world-2", second.Slug)
+	japanese, err := repo.CreateBlog(context.Background(), userId, "はじめてのブログ", "url", "go", "description", "tag", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "hajimeteno-burogu", japanese.Slug)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 2. スラッグを変更しても変更前のスラッグで取得できる
+	// ----------------------------------------------------------------------------------------------------------------------------
+	updated, err := repo.UpdateBlogSlug(context.Background(), first.ID, "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", updated.Slug)
+
+	fetched, err := repo.FetchBlogBySlug(context.Background(), "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, fetched.ID)
+	fetched, err = repo.FetchBlogBySlug(context.Background(), "hello-world")
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, fetched.ID)
+	assert.Equal(t, "hello", fetched.Slug)
+
+	// 変更前のスラッグは新しいブログにも使われない
+	third, err := repo.CreateBlog(context.Background(), userId, "Hello World", "url", "go", "description", "tag", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello-world-3", third.Slug)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 3. 他のブログの現在または変更前のスラッグには変更できない
+	// ----------------------------------------------------------------------------------------------------------------------------
+	_, err = repo.UpdateBlogSlug(context.Background(), second.ID, "hello")
+	assert.ErrorIs(t, err, repositories_blogs.ErrBlogSlugConflict)
+	_, err = repo.UpdateBlogSlug(context.Background(), second.ID, "hello-world")
+	assert.ErrorIs(t, err, repositories_blogs.ErrBlogSlugConflict)
+
+	// 自身の変更前のスラッグには戻せる
+	reverted, err := repo.UpdateBlogSlug(context.Background(), first.ID, "hello-world")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello-world", reverted.Slug)
+	fetched, err = repo.FetchBlogBySlug(context.Background(), "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello-world", fetched.Slug)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 4. ゴミ箱内のブログは取得できず、完全削除で変更前のスラッグも削除される
+	// ----------------------------------------------------------------------------------------------------------------------------
+	assert.NoError(t, repo.DeleteBlog(context.Background(), first.ID))
+	_, err = repo.FetchBlogBySlug(context.Background(), "hello-world")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	_, err = repo.UpdateBlogSlug(context.Background(), first.ID, "another")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	_, err = repo.PurgeDeletedBlogs(context.Background(), first.CreatedAt.AddDate(1, 0, 0))
+	assert.NoError(t, err)
+	assert.Empty(t, store.blogSlugRedirects)
+}
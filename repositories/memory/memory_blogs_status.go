@@ -0,0 +1,69 @@
+package repositories_memory
+
+import (
+	"backend/logger"
+	"backend/models"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// 指定されたブログの公開状態と公開日時を更新する
+// 現在の状態が currentStatus のときだけ更新し、一致するブログがない場合は pgx.ErrNoRows を返す。
+func (r *MemoryBlogRepository) UpdateBlogStatus(ctx context.Context, id, currentStatus, status string, publishedAt *time.Time) (*models.BlogData, error) {
+	logger.InfoLog.Printf("UpdateBlogStatus start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(id); err != nil {
+		logger.ErrorLog.Printf("Failed to update blog status: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	blog, ok := r.Store.blogs[id]
+	if !ok || blog.DeletedAt != nil || blog.Status != currentStatus {
+		logger.ErrorLog.Printf("Failed to update blog status: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+	blog.Status = status
+	blog.PublishedAt = publishedAt
+	blog.UpdatedAt = time.Now()
+	r.Store.blogs[id] = blog
+
+	logger.InfoLog.Printf("Updated blog status: %v", blog)
+	return &blog, nil
+}
+
+// 公開予定日時が now 以前になった予約中のブログを公開し、公開した件数を返す
+func (r *MemoryBlogRepository) PublishScheduledBlogs(ctx context.Context, now time.Time) (int, error) {
+	logger.InfoLog.Printf("PublishScheduledBlogs start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	count := 0
+	for id, blog := range r.Store.blogs {
+		if blog.Status != models.BlogStatusScheduled || blog.DeletedAt != nil || blog.PublishedAt == nil || blog.PublishedAt.After(now) {
+			continue
+		}
+		blog.Status = models.BlogStatusPublished
+		blog.UpdatedAt = time.Now()
+		r.Store.blogs[id] = blog
+		count++
+	}
+
+	logger.InfoLog.Printf("Published %d scheduled blogs", count)
+	return count, nil
+}
@@ -0,0 +1,97 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	repositories_blogs "backend/repositories/blogs"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// 下書きのブログを公開済みにする
+func publishBlog(t *testing.T, repo repositories_blogs.BlogRepository, id string) *models.BlogData {
+	now := time.Now()
+	blog, err := repo.UpdateBlogStatus(context.Background(), id, models.BlogStatusDraft, models.BlogStatusPublished, &now)
+	assert.NoError(t, err)
+	return blog
+}
+
+func TestMemoryRepository_BlogStatus_PipeLine(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	store := NewStore()
+	repo := NewBlogRepository(store)
+	userId := uuid.New().String()
+	seedCategories(store, "go")
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 1. 下書きは本人の一覧にのみ表示される
+	// ----------------------------------------------------------------------------------------------------------------------------
+	blog, err := repo.CreateBlog(context.Background(), userId, "title", "url", "go", "description", "tag", "")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BlogStatusDraft, blog.Status)
+	assert.Nil(t, blog.PublishedAt)
+
+	total, err := repo.CountBlogs(context.Background(), models.BlogListFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	blogs, err := repo.FetchBlogsByUserId(context.Background(), userId, false)
+	assert.NoError(t, err)
+	assert.Empty(t, blogs)
+	blogs, err = repo.FetchBlogsByUserId(context.Background(), userId, true)
+	assert.NoError(t, err)
+	assert.Len(t, blogs, 1)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 2. 現在の状態が一致しない場合は更新されない
+	// ----------------------------------------------------------------------------------------------------------------------------
+	now := time.Now()
+	_, err = repo.UpdateBlogStatus(context.Background(), blog.ID, models.BlogStatusScheduled, models.BlogStatusPublished, &now)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 3. 公開予定日時を過ぎた予約中のブログだけが公開される
+	// ----------------------------------------------------------------------------------------------------------------------------
+	future := now.Add(time.Hour)
+	scheduled, err := repo.UpdateBlogStatus(context.Background(), blog.ID, models.BlogStatusDraft, models.BlogStatusScheduled, &future)
+	assert.NoError(t, err)
+	assert.Equal(t, models.BlogStatusScheduled, scheduled.Status)
+
+	published, err := repo.PublishScheduledBlogs(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, published)
+
+	published, err = repo.PublishScheduledBlogs(context.Background(), future)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, published)
+
+	// 公開済みのブログは再度公開されない
+	published, err = repo.PublishScheduledBlogs(context.Background(), future)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, published)
+
+	fetched, err := repo.FetchBlogById(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.BlogStatusPublished, fetched.Status)
+	assert.True(t, fetched.PublishedAt.Equal(future))
+
+	total, err = repo.CountBlogs(context.Background(), models.BlogListFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 4. ゴミ箱内のブログは公開されない
+	// ----------------------------------------------------------------------------------------------------------------------------
+	deleted, err := repo.CreateBlog(context.Background(), userId, "title", "url", "go", "description", "tag", "")
+	assert.NoError(t, err)
+	_, err = repo.UpdateBlogStatus(context.Background(), deleted.ID, models.BlogStatusDraft, models.BlogStatusScheduled, &future)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.DeleteBlog(context.Background(), deleted.ID))
+
+	published, err = repo.PublishScheduledBlogs(context.Background(), future)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, published)
+}
@@ -0,0 +1,174 @@
+package repositories_memory
+
+import (
+	"backend/logger"
+	"backend/models"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// ゴミ箱内のブログデータをすべて削除日時の新しい順に取得する
+func (r *MemoryBlogRepository) FetchDeletedBlogs(ctx context.Context) ([]models.BlogData, error) {
+	logger.InfoLog.Printf("FetchDeletedBlogs start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	blogs := r.Store.deletedBlogs(func(blog models.BlogData) bool { return true })
+
+	logger.InfoLog.Printf("Fetched %d deleted blogs", len(blogs))
+	return blogs, nil
+}
+
+// 指定されたユーザーのゴミ箱内のブログデータを削除日時の新しい順に取得する
+func (r *MemoryBlogRepository) FetchDeletedBlogsByUserId(ctx context.Context, userId string) ([]models.BlogData, error) {
+	logger.InfoLog.Printf("FetchDeletedBlogsByUserId start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(userId); err != nil {
+		logger.ErrorLog.Printf("Failed to fetch deleted blogs: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	blogs := r.Store.deletedBlogs(func(blog models.BlogData) bool { return blog.UserId == userId })
+
+	logger.InfoLog.Printf("Fetched %d deleted blogs", len(blogs))
+	return blogs, nil
+}
+
+// 条件に一致するゴミ箱内のブログデータを削除日時の新しい順に返す（呼び出し側でロックを取得すること）
+func (s *Store) deletedBlogs(match func(models.BlogData) bool) []models.BlogData {
+	var blogs []models.BlogData
+	for _, blog := range s.blogs {
+		if blog.DeletedAt != nil && match(blog) {
+			blogs = append(blogs, withoutBody(blog))
+		}
+	}
+	sort.Slice(blogs, func(i, j int) bool {
+		if blogs[i].DeletedAt.Equal(*blogs[j].DeletedAt) {
+			return blogs[i].ID > blogs[j].ID
+		}
+		return blogs[i].DeletedAt.After(*blogs[j].DeletedAt)
+	})
+	return blogs
+}
+
+// ゴミ箱内のブログデータをIDで取得する
+func (r *MemoryBlogRepository) FetchDeletedBlogById(ctx context.Context, id string) (*models.BlogData, error) {
+	logger.InfoLog.Printf("FetchDeletedBlogById start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(id); err != nil {
+		logger.ErrorLog.Printf("Failed to fetch deleted blog: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	blog, ok := r.Store.blogs[id]
+	if !ok || blog.DeletedAt == nil {
+		logger.ErrorLog.Printf("Failed to fetch deleted blog: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+	blog = withoutBody(blog)
+
+	logger.InfoLog.Printf("Fetched deleted blog: %v", blog)
+	return &blog, nil
+}
+
+// ゴミ箱内のブログデータを復元する
+func (r *MemoryBlogRepository) RestoreBlog(ctx context.Context, id string) (*models.BlogData, error) {
+	logger.InfoLog.Printf("RestoreBlog start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(id); err != nil {
+		logger.ErrorLog.Printf("Failed to restore blog: %v", err)
+		return nil, err
+	}
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	blog, ok := r.Store.blogs[id]
+	if !ok || blog.DeletedAt == nil {
+		logger.ErrorLog.Printf("Failed to restore blog: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+	blog.DeletedAt = nil
+	blog.UpdatedAt = time.Now()
+	r.Store.blogs[id] = blog
+
+	logger.InfoLog.Printf("Restored blog: %v", blog)
+	return &blog, nil
+}
+
+// 削除日時が before より前のブログを、コメント・いいね・版履歴・変更前のスラッグとともに完全に削除する
+func (r *MemoryBlogRepository) PurgeDeletedBlogs(ctx context.Context, before time.Time) (int, error) {
+	logger.InfoLog.Printf("PurgeDeletedBlogs start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	purged := make(map[string]bool)
+	for id, blog := range r.Store.blogs {
+		if blog.DeletedAt != nil && blog.DeletedAt.Before(before) {
+			purged[id] = true
+		}
+	}
+	if len(purged) == 0 {
+		logger.InfoLog.Println("No blogs to purge")
+		return 0, nil
+	}
+
+	for id, comment := range r.Store.comments {
+		if purged[comment.BlogId] {
+			delete(r.Store.comments, id)
+		}
+	}
+	for id, like := range r.Store.blogLikes {
+		if purged[like.BlogId] {
+			delete(r.Store.blogLikes, id)
+		}
+	}
+	for slug, blogId := range r.Store.blogSlugRedirects {
+		if purged[blogId] {
+			delete(r.Store.blogSlugRedirects, slug)
+		}
+	}
+	for id := range purged {
+		delete(r.Store.blogs, id)
+		delete(r.Store.blogTags, id)
+		delete(r.Store.blogRevisions, id)
+	}
+
+	logger.InfoLog.Printf("Purged %d blogs", len(purged))
+	return len(purged), nil
+}
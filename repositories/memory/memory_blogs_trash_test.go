@@ -0,0 +1,150 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	repositories_blogs_likes "backend/repositories/blogs_likes"
+	repositories_comments "backend/repositories/comments"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository_BlogTrash_PipeLine(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	store := NewStore()
+	repo := NewBlogRepository(store)
+	likeRepo := NewBlogLikeRepository(store)
+	commentRepo := NewCommentRepository(store)
+	seedCategories(store, "go")
+
+	userId := uuid.New().String()
+	otherUserId := uuid.New().String()
+	visitId := uuid.New().String()
+
+	blog, err := repo.CreateBlog(context.Background(), userId, "title", "url", "go", "description", "tag", "")
+	assert.NoError(t, err)
+	_, err = likeRepo.CreateBlogLike(context.Background(), blog.ID, visitId)
+	assert.NoError(t, err)
+	_, err = commentRepo.CreateComment(context.Background(), blog.ID, "guest", "comment")
+	assert.NoError(t, err)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 1. 削除したブログは通常の取得から除外される
+	// ----------------------------------------------------------------------------------------------------------------------------
+	err = repo.DeleteBlog(context.Background(), blog.ID)
+	assert.NoError(t, err)
+
+	_, err = repo.FetchBlogById(context.Background(), blog.ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	blogs, err := repo.FetchBlogs(context.Background(), models.BlogListFilter{Limit: 10})
+	assert.NoError(t, err)
+	assert.Empty(t, blogs)
+
+	tags, err := repo.FetchBlogTags(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+
+	// 削除済みのブログは更新できない
+	_, err = repo.UpdateBlog(context.Background(), blog.ID, userId, "title", "url", "go", "description", "tag", "")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// 削除済みのブログのコメント・いいねは取得できず、新しく追加もできない
+	comments, err := commentRepo.FetchCommentsByBlogId(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+	_, err = commentRepo.CreateComment(context.Background(), blog.ID, "guest", "comment")
+	assert.ErrorIs(t, err, repositories_comments.ErrBlogNotFound)
+
+	likes, err := likeRepo.FetchBlogLikesByVisitId(context.Background(), visitId)
+	assert.NoError(t, err)
+	assert.Empty(t, likes)
+	liked, err := likeRepo.IsBlogLiked(context.Background(), blog.ID, visitId)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.False(t, liked)
+	_, err = likeRepo.CreateBlogLike(context.Background(), blog.ID, uuid.New().String())
+	assert.ErrorIs(t, err, repositories_blogs_likes.ErrBlogNotFound)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 2. ゴミ箱の取得(ユーザーごと・すべて・IDで取得)
+	// ----------------------------------------------------------------------------------------------------------------------------
+	trash, err := repo.FetchDeletedBlogsByUserId(context.Background(), userId)
+	assert.NoError(t, err)
+	assert.Len(t, trash, 1)
+	assert.NotNil(t, trash[0].DeletedAt)
+	assert.Equal(t, int64(1), trash[0].Likes)
+
+	trash, err = repo.FetchDeletedBlogsByUserId(context.Background(), otherUserId)
+	assert.NoError(t, err)
+	assert.Empty(t, trash)
+
+	trash, err = repo.FetchDeletedBlogs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, trash, 1)
+
+	deleted, err := repo.FetchDeletedBlogById(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, userId, deleted.UserId)
+	assert.NotNil(t, deleted.DeletedAt)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 3. 復元
+	// ----------------------------------------------------------------------------------------------------------------------------
+	_, err = repo.RestoreBlog(context.Background(), uuid.New().String())
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	restored, err := repo.RestoreBlog(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	assert.Equal(t, int64(1), restored.CommentCnt)
+
+	// 復元済みのブログは再度復元できず、ゴミ箱からも取得できない
+	_, err = repo.RestoreBlog(context.Background(), blog.ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	_, err = repo.FetchDeletedBlogById(context.Background(), blog.ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	fetched, err := repo.FetchBlogById(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, blog.ID, fetched.ID)
+
+	// 復元するとコメント・いいねも再び取得できる
+	comments, err = commentRepo.FetchCommentsByBlogId(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	liked, err = likeRepo.IsBlogLiked(context.Background(), blog.ID, visitId)
+	assert.NoError(t, err)
+	assert.True(t, liked)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 4. 完全削除
+	// ----------------------------------------------------------------------------------------------------------------------------
+	err = repo.DeleteBlog(context.Background(), blog.ID)
+	assert.NoError(t, err)
+
+	// 保持期間内のブログは削除されない
+	purged, err := repo.PurgeDeletedBlogs(context.Background(), time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, purged)
+
+	purged, err = repo.PurgeDeletedBlogs(context.Background(), time.Now().Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	trash, err = repo.FetchDeletedBlogsByUserId(context.Background(), userId)
+	assert.NoError(t, err)
+	assert.Empty(t, trash)
+
+	// コメント・いいねも削除される
+	comments, err = commentRepo.FetchCommentsByBlogId(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+
+	liked, err = likeRepo.IsBlogLiked(context.Background(), blog.ID, visitId)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.False(t, liked)
+}
@@ -0,0 +1,267 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	repositories_categories "backend/repositories/categories"
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// CategoryRepositoryのインメモリ実装
+type MemoryCategoryRepository struct {
+	Store *Store
+}
+
+// CategoryRepositoryインターフェースを実装したMemoryCategoryRepositoryのポインタを返す
+func NewCategoryRepository(store *Store) repositories_categories.CategoryRepository {
+	return &MemoryCategoryRepository{
+		Store: store,
+	}
+}
+
+// ブログのカテゴリが登録済みであることを確認する（呼び出し側でロックを取得すること）
+// Postgresの外部キー制約と同様に、カテゴリ名は完全一致で比較する。
+func (s *Store) checkCategoryReference(name string) error {
+	for _, category := range s.categories {
+		if category.Name == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("insert or update on table \"blogs\" violates foreign key constraint \"blogs_category_fkey\": %q", name)
+}
+
+// 投稿数を付与したカテゴリデータを返す（呼び出し側でロックを取得すること）
+func (s *Store) categoryWithAggregates(category models.CategoryData) models.CategoryData {
+	category.PostCount = 0
+	for _, blog := range s.blogs {
+		if blog.Category == category.Name && isPublicBlog(blog) {
+			category.PostCount++
+		}
+	}
+	return category
+}
+
+// カテゴリを使用しているブログ(ゴミ箱内のブログを含む)が存在するか判定する（呼び出し側でロックを取得すること）
+func (s *Store) categoryInUse(name string) bool {
+	for _, blog := range s.blogs {
+		if blog.Category == name {
+			return true
+		}
+	}
+	return false
+}
+
+// 他のカテゴリと名前(大文字小文字を区別しない)またはスラッグが重複していないか確認する（呼び出し側でロックを取得すること）
+func (s *Store) checkCategoryConflict(id, name, slug string) error {
+	for _, other := range s.categories {
+		if other.ID == id {
+			continue
+		}
+		if strings.EqualFold(other.Name, name) || other.Slug == slug {
+			return repositories_categories.ErrCategoryConflict
+		}
+	}
+	return nil
+}
+
+// 全カテゴリ情報を表示順に取得する
+func (r *MemoryCategoryRepository) FetchCategories(ctx context.Context) ([]models.CategoryData, error) {
+	log.Printf("FetchCategories start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	var categories []models.CategoryData
+	for _, category := range r.Store.categories {
+		categories = append(categories, r.Store.categoryWithAggregates(category))
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].DisplayOrder != categories[j].DisplayOrder {
+			return categories[i].DisplayOrder < categories[j].DisplayOrder
+		}
+		return strings.ToLower(categories[i].Name) < strings.ToLower(categories[j].Name)
+	})
+
+	log.Printf("Fetched %d categories", len(categories))
+	return categories, nil
+}
+
+// 指定されたIDに一致するカテゴリ情報を取得する
+func (r *MemoryCategoryRepository) FetchCategoryById(ctx context.Context, id string) (*models.CategoryData, error) {
+	log.Printf("FetchCategoryById start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(id); err != nil {
+		log.Printf("Failed to fetch category: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	category, ok := r.Store.categories[id]
+	if !ok {
+		log.Printf("Failed to fetch category: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+
+	category = r.Store.categoryWithAggregates(category)
+	log.Printf("Fetched category: %v", category)
+	return &category, nil
+}
+
+// 指定された名前に一致するカテゴリ情報を取得する(大文字小文字を区別しない)
+func (r *MemoryCategoryRepository) FetchCategoryByName(ctx context.Context, name string) (*models.CategoryData, error) {
+	log.Printf("FetchCategoryByName start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	for _, category := range r.Store.categories {
+		if strings.EqualFold(category.Name, name) {
+			category = r.Store.categoryWithAggregates(category)
+			log.Printf("Fetched category: %v", category)
+			return &category, nil
+		}
+	}
+
+	log.Printf("Failed to fetch category: %v", pgx.ErrNoRows)
+	return nil, pgx.ErrNoRows
+}
+
+// カテゴリを作成する
+func (r *MemoryCategoryRepository) CreateCategory(ctx context.Context, name, slug, description string, displayOrder int) (*models.CategoryData, error) {
+	log.Printf("CreateCategory start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	if err := r.Store.checkCategoryConflict("", name, slug); err != nil {
+		log.Printf("Failed to create category: %v", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	category := models.CategoryData{
+		ID:           uuid.New().String(),
+		Name:         name,
+		Slug:         slug,
+		Description:  description,
+		DisplayOrder: displayOrder,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	r.Store.categories[category.ID] = category
+
+	log.Printf("Created category: %v", category)
+	return &category, nil
+}
+
+// 指定されたIDに一致するカテゴリを更新する
+// カテゴリ名を変更した場合は、外部キー制約(ON UPDATE CASCADE)と同様にブログのカテゴリも更新する。
+func (r *MemoryCategoryRepository) UpdateCategory(ctx context.Context, id, name, slug, description string, displayOrder int) (*models.CategoryData, error) {
+	log.Printf("UpdateCategory start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(id); err != nil {
+		log.Printf("Failed to update category: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	category, ok := r.Store.categories[id]
+	if !ok {
+		log.Printf("Failed to update category: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+	if err := r.Store.checkCategoryConflict(id, name, slug); err != nil {
+		log.Printf("Failed to update category: %v", err)
+		return nil, err
+	}
+
+	if category.Name != name {
+		now := time.Now()
+		for blogId, blog := range r.Store.blogs {
+			if blog.Category == category.Name {
+				blog.Category = name
+				blog.UpdatedAt = now
+				r.Store.blogs[blogId] = blog
+			}
+		}
+	}
+
+	category.Name = name
+	category.Slug = slug
+	category.Description = description
+	category.DisplayOrder = displayOrder
+	category.UpdatedAt = time.Now()
+	r.Store.categories[id] = category
+
+	category = r.Store.categoryWithAggregates(category)
+	log.Printf("Updated category: %v", category)
+	return &category, nil
+}
+
+// 指定されたIDに一致するカテゴリを削除する
+func (r *MemoryCategoryRepository) DeleteCategory(ctx context.Context, id string) error {
+	log.Printf("DeleteCategory start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := validateUUID(id); err != nil {
+		log.Printf("Failed to delete category: %v", err)
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	category, ok := r.Store.categories[id]
+	if !ok {
+		log.Printf("Failed to delete category: %v", pgx.ErrNoRows)
+		return pgx.ErrNoRows
+	}
+	if r.Store.categoryInUse(category.Name) {
+		log.Printf("Failed to delete category: %v", repositories_categories.ErrCategoryInUse)
+		return repositories_categories.ErrCategoryInUse
+	}
+	delete(r.Store.categories, id)
+
+	log.Printf("Deleted category: %s", id)
+	return nil
+}
@@ -0,0 +1,94 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	repositories_categories "backend/repositories/categories"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// テスト用のカテゴリを登録する(登録済みの名前は無視する)
+func seedCategories(store *Store, names ...string) {
+	for _, name := range names {
+		if store.checkCategoryReference(name) == nil {
+			continue
+		}
+		store.SeedCategory(models.CategoryData{Name: name, Slug: strings.ToLower(name)})
+	}
+}
+
+func TestMemoryRepository_Category_PipeLine(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	store := NewStore()
+	blogRepo := NewBlogRepository(store)
+	repo := NewCategoryRepository(store)
+	userId := uuid.New().String()
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 1. カテゴリ作成テスト(名前は大文字小文字を区別せず、スラッグとともに一意)
+	// ----------------------------------------------------------------------------------------------------------------------------
+	backend, err := repo.CreateCategory(context.Background(), "Backend", "backend", "サーバーサイド", 2)
+	assert.NoError(t, err)
+	frontend, err := repo.CreateCategory(context.Background(), "Frontend", "frontend", "", 1)
+	assert.NoError(t, err)
+
+	_, err = repo.CreateCategory(context.Background(), "backend", "backend-2", "", 0)
+	assert.ErrorIs(t, err, repositories_categories.ErrCategoryConflict)
+	_, err = repo.CreateCategory(context.Background(), "Infra", "frontend", "", 0)
+	assert.ErrorIs(t, err, repositories_categories.ErrCategoryConflict)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 2. ブログのカテゴリ参照テスト(未登録のカテゴリは作成できない)
+	// ----------------------------------------------------------------------------------------------------------------------------
+	blog, err := blogRepo.CreateBlog(context.Background(), userId, "title", "url", "Backend", "description", "Go", "")
+	assert.NoError(t, err)
+	publishBlog(t, blogRepo, blog.ID)
+	_, err = blogRepo.CreateBlog(context.Background(), userId, "title", "url", "Unknown", "description", "Go", "")
+	assert.Error(t, err)
+
+	// 表示順に並び、投稿数が集計されること
+	categories, err := repo.FetchCategories(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, categories, 2)
+	assert.Equal(t, frontend.ID, categories[0].ID)
+	assert.Equal(t, backend.ID, categories[1].ID)
+	assert.Equal(t, 1, categories[1].PostCount)
+
+	fetched, err := repo.FetchCategoryByName(context.Background(), "BACKEND")
+	assert.NoError(t, err)
+	assert.Equal(t, backend.ID, fetched.ID)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 3. カテゴリ更新テスト(名前の変更はブログにも反映される)
+	// ----------------------------------------------------------------------------------------------------------------------------
+	updated, err := repo.UpdateCategory(context.Background(), backend.ID, "Server", "server", "サーバーサイド", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "Server", updated.Name)
+	assert.Equal(t, 1, updated.PostCount)
+
+	fetchedBlog, err := blogRepo.FetchBlogById(context.Background(), blog.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Server", fetchedBlog.Category)
+
+	_, err = repo.UpdateCategory(context.Background(), backend.ID, "Frontend", "server", "", 0)
+	assert.ErrorIs(t, err, repositories_categories.ErrCategoryConflict)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 4. カテゴリ削除テスト(使用中のカテゴリは削除できない)
+	// ----------------------------------------------------------------------------------------------------------------------------
+	err = repo.DeleteCategory(context.Background(), backend.ID)
+	assert.ErrorIs(t, err, repositories_categories.ErrCategoryInUse)
+
+	err = repo.DeleteCategory(context.Background(), frontend.ID)
+	assert.NoError(t, err)
+
+	_, err = repo.FetchCategoryById(context.Background(), frontend.ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	err = repo.DeleteCategory(context.Background(), frontend.ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
@@ -0,0 +1,97 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	repositories_comments "backend/repositories/comments"
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CommentRepositoryのインメモリ実装
+type MemoryCommentRepository struct {
+	Store *Store
+}
+
+// CommentRepositoryインターフェースを実装したMemoryCommentRepositoryのポインタを返す
+func NewCommentRepository(store *Store) repositories_comments.CommentRepository {
+	return &MemoryCommentRepository{
+		Store: store,
+	}
+}
+
+// ブログIDに一致するコメント情報を取得する
+// ゴミ箱に入っているブログのコメントは取得しない。
+func (r *MemoryCommentRepository) FetchCommentsByBlogId(ctx context.Context, blogId string) ([]models.CommentData, error) {
+	log.Printf("FetchCommentsByBlogId start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(blogId); err != nil {
+		log.Printf("Failed to fetch comments: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	var comments []models.CommentData
+	if !r.Store.isActiveBlog(blogId) {
+		log.Printf("Fetched comments: %v", comments)
+		return comments, nil
+	}
+	for _, comment := range r.Store.comments {
+		if comment.BlogId == blogId {
+			comments = append(comments, comment)
+		}
+	}
+	sort.SliceStable(comments, func(i, j int) bool {
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+
+	log.Printf("Fetched comments: %v", comments)
+	return comments, nil
+}
+
+// コメント情報を新規作成する
+// ブログがない、またはゴミ箱に入っている場合は ErrBlogNotFound を返す。
+func (r *MemoryCommentRepository) CreateComment(ctx context.Context, blogId, guestUser, comment string) (*models.CommentData, error) {
+	log.Printf("CreateComment start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(blogId); err != nil {
+		log.Printf("Failed to create comment: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	if !r.Store.isActiveBlog(blogId) {
+		log.Printf("Failed to create comment: %v", repositories_comments.ErrBlogNotFound)
+		return nil, repositories_comments.ErrBlogNotFound
+	}
+
+	newComment := models.CommentData{
+		ID:        uuid.New().String(),
+		BlogId:    blogId,
+		GuestUser: guestUser,
+		Comment:   comment,
+		CreatedAt: time.Now(),
+	}
+	r.Store.comments[newComment.ID] = newComment
+	r.Store.adjustCounters(blogId, 0, 1)
+
+	log.Printf("Created comment: %v", newComment)
+	return &newComment, nil
+}
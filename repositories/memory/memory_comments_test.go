@@ -0,0 +1,48 @@
+package repositories_memory
+
+import (
+	repositories_comments "backend/repositories/comments"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository_Comment_PipeLine(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	store := NewStore()
+	repo := NewCommentRepository(store)
+	seedCategories(store, "go")
+
+	blog, err := NewBlogRepository(store).CreateBlog(context.Background(), uuid.New().String(), "title", "url", "go", "description", "tag", "")
+	assert.NoError(t, err)
+	blogId := blog.ID
+
+	// コメントを作成
+	comment, err := repo.CreateComment(context.Background(), blogId, "guest", "hello")
+	assert.NoError(t, err)
+	assert.NotNil(t, comment)
+
+	// 存在しないブログにはコメントできない
+	_, err = repo.CreateComment(context.Background(), uuid.New().String(), "guest", "hello")
+	assert.ErrorIs(t, err, repositories_comments.ErrBlogNotFound)
+
+	// ブログIDで取得
+	comments, err := repo.FetchCommentsByBlogId(context.Background(), blogId)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "hello", comments[0].Comment)
+}
+
+func TestMemoryRepository_FetchCommentsByBlogId_InvalidBlogId(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	repo := NewCommentRepository(NewStore())
+
+	// メソッドを実行
+	comments, err := repo.FetchCommentsByBlogId(context.Background(), "1")
+
+	// エラーチェックとデータ確認
+	assert.Error(t, err)
+	assert.Nil(t, comments)
+}
@@ -0,0 +1,160 @@
+package repositories_memory
+
+import (
+	repositories_login_failures "backend/repositories/login_failures"
+	"context"
+	"log"
+	"sort"
+	"time"
+)
+
+// LoginFailureRepositoryのインメモリ実装
+// 失敗回数はこのインスタンスのみで数えるため、複数のインスタンスで運用する場合はSupabaseの実装を使用すること。
+type MemoryLoginFailureRepository struct {
+	Store *Store
+}
+
+// LoginFailureRepositoryインターフェースを実装したMemoryLoginFailureRepositoryのポインタを返す
+func NewLoginFailureRepository(store *Store) repositories_login_failures.LoginFailureRepository {
+	return &MemoryLoginFailureRepository{
+		Store: store,
+	}
+}
+
+// ログインの失敗を記録する
+func (r *MemoryLoginFailureRepository) RecordLoginFailure(ctx context.Context, key string, failedAt time.Time) error {
+	log.Println("RecordLoginFailure start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	r.Store.loginFailures[key] = append(r.Store.loginFailures[key], failedAt)
+	return nil
+}
+
+// 指定された日時以降のログインの失敗の日時を古い順に取得する
+func (r *MemoryLoginFailureRepository) FetchLoginFailures(ctx context.Context, key string, since time.Time) ([]time.Time, error) {
+	log.Println("FetchLoginFailures start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	var failures []time.Time
+	for _, failedAt := range r.Store.loginFailures[key] {
+		if failedAt.After(since) {
+			failures = append(failures, failedAt)
+		}
+	}
+	sort.Slice(failures, func(i, j int) bool { return failures[i].Before(failures[j]) })
+	return failures, nil
+}
+
+// ログインの試行を失敗として先に記録し、それより前に記録された期間内の失敗の日時を古い順に返す
+// 取得と記録を同じロックの中で行うため、同時に試行した場合も互いの記録を数えられる。
+func (r *MemoryLoginFailureRepository) ReserveLoginFailure(ctx context.Context, key string, failedAt, since time.Time) ([]time.Time, error) {
+	log.Println("ReserveLoginFailure start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	var failures []time.Time
+	for _, at := range r.Store.loginFailures[key] {
+		if at.After(since) {
+			failures = append(failures, at)
+		}
+	}
+	sort.Slice(failures, func(i, j int) bool { return failures[i].Before(failures[j]) })
+
+	r.Store.loginFailures[key] = append(r.Store.loginFailures[key], failedAt)
+	return failures, nil
+}
+
+// 指定されたキー・日時のログインの失敗を1件削除する(先に記録した試行を取り消す時)
+func (r *MemoryLoginFailureRepository) DeleteLoginFailure(ctx context.Context, key string, failedAt time.Time) error {
+	log.Println("DeleteLoginFailure start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	failures := r.Store.loginFailures[key]
+	for i, at := range failures {
+		if at.Equal(failedAt) {
+			failures = append(failures[:i], failures[i+1:]...)
+			break
+		}
+	}
+	if len(failures) == 0 {
+		delete(r.Store.loginFailures, key)
+	} else {
+		r.Store.loginFailures[key] = failures
+	}
+	return nil
+}
+
+// 指定されたキーのログインの失敗をすべて削除する(ログインに成功した時)
+func (r *MemoryLoginFailureRepository) DeleteLoginFailures(ctx context.Context, key string) error {
+	log.Println("DeleteLoginFailures start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	delete(r.Store.loginFailures, key)
+	return nil
+}
+
+// 指定された日時より前のログインの失敗を削除し、件数を返す
+func (r *MemoryLoginFailureRepository) DeleteLoginFailuresBefore(ctx context.Context, before time.Time) (int64, error) {
+	log.Println("DeleteLoginFailuresBefore start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	var deleted int64
+	for key, failures := range r.Store.loginFailures {
+		kept := failures[:0]
+		for _, failedAt := range failures {
+			if failedAt.After(before) {
+				kept = append(kept, failedAt)
+			}
+		}
+		deleted += int64(len(failures) - len(kept))
+		if len(kept) == 0 {
+			delete(r.Store.loginFailures, key)
+		} else {
+			r.Store.loginFailures[key] = kept
+		}
+	}
+
+	log.Printf("Deleted %d expired login failures", deleted)
+	return deleted, nil
+}
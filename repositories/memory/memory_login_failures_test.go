@@ -0,0 +1,84 @@
+package repositories_memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository_LoginFailure_PipeLine(t *testing.T) {
+	repo := NewLoginFailureRepository(NewStore())
+	ctx := context.Background()
+	now := time.Now()
+
+	// 失敗を記録し、指定した日時以降のものを古い順に取得する
+	assert.NoError(t, repo.RecordLoginFailure(ctx, "email:a", now.Add(-time.Minute)))
+	assert.NoError(t, repo.RecordLoginFailure(ctx, "email:a", now.Add(-time.Hour)))
+	assert.NoError(t, repo.RecordLoginFailure(ctx, "email:a", now.Add(-2*time.Minute)))
+	assert.NoError(t, repo.RecordLoginFailure(ctx, "ip:b", now.Add(-time.Minute)))
+
+	failures, err := repo.FetchLoginFailures(ctx, "email:a", now.Add(-10*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{now.Add(-2 * time.Minute), now.Add(-time.Minute)}, failures)
+
+	// 期間を過ぎた記録を削除する
+	deleted, err := repo.DeleteLoginFailuresBefore(ctx, now.Add(-10*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	failures, err = repo.FetchLoginFailures(ctx, "email:a", now.Add(-24*time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, failures, 2)
+
+	// キーごとに削除し、他のキーには影響しない
+	assert.NoError(t, repo.DeleteLoginFailures(ctx, "email:a"))
+	failures, err = repo.FetchLoginFailures(ctx, "email:a", now.Add(-24*time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, failures)
+	failures, err = repo.FetchLoginFailures(ctx, "ip:b", now.Add(-24*time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, failures, 1)
+}
+
+// 試行を先に記録し、同時に試行した場合も互いの記録を数える
+func TestMemoryRepository_LoginFailure_Reserve(t *testing.T) {
+	repo := NewLoginFailureRepository(NewStore())
+	ctx := context.Background()
+	now := time.Now()
+
+	assert.NoError(t, repo.RecordLoginFailure(ctx, "email:a", now.Add(-time.Hour)))
+	assert.NoError(t, repo.RecordLoginFailure(ctx, "email:a", now.Add(-time.Minute)))
+
+	// それより前に記録された期間内の失敗を返す
+	failures, err := repo.ReserveLoginFailure(ctx, "email:a", now, now.Add(-10*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{now.Add(-time.Minute)}, failures)
+
+	// 取り消すと、その1件のみ削除する
+	assert.NoError(t, repo.DeleteLoginFailure(ctx, "email:a", now))
+	failures, err = repo.FetchLoginFailures(ctx, "email:a", now.Add(-24*time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, failures, 2)
+
+	// 同時に記録しても、それぞれ異なる件数の失敗を受け取る
+	counts := make(chan int, 10)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			failures, err := repo.ReserveLoginFailure(ctx, "ip:b", time.Now(), now.Add(-time.Minute))
+			assert.NoError(t, err)
+			counts <- len(failures)
+		}()
+	}
+	wg.Wait()
+	close(counts)
+	seen := make(map[int]bool)
+	for n := range counts {
+		seen[n] = true
+	}
+	assert.Len(t, seen, 10)
+}
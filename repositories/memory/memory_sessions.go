@@ -0,0 +1,146 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	repositories_sessions "backend/repositories/sessions"
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SessionRepositoryのインメモリ実装
+type MemorySessionRepository struct {
+	Store *Store
+}
+
+// SessionRepositoryインターフェースを実装したMemorySessionRepositoryのポインタを返す
+func NewSessionRepository(store *Store) repositories_sessions.SessionRepository {
+	return &MemorySessionRepository{
+		Store: store,
+	}
+}
+
+// セッションを登録する（呼び出し側でロックを取得すること）
+func (s *Store) insertSession(userId, familyId, tokenHash string, expiresAt, familyExpiresAt time.Time) models.SessionData {
+	session := models.SessionData{
+		ID:              uuid.New().String(),
+		UserId:          userId,
+		FamilyId:        familyId,
+		TokenHash:       tokenHash,
+		ExpiresAt:       expiresAt,
+		FamilyExpiresAt: familyExpiresAt,
+		CreatedAt:       time.Now(),
+	}
+	s.sessions[tokenHash] = session
+	return session
+}
+
+// 指定された系列の有効なセッションをすべて失効させ、件数を返す（呼び出し側でロックを取得すること）
+func (s *Store) revokeSessionFamily(familyId string, now time.Time) int {
+	count := 0
+	for hash, session := range s.sessions {
+		if session.FamilyId == familyId && session.RevokedAt == nil {
+			session.RevokedAt = &now
+			s.sessions[hash] = session
+			count++
+		}
+	}
+	return count
+}
+
+// 新しい系列のセッションを作成する(ログイン時)
+func (r *MemorySessionRepository) CreateSession(ctx context.Context, userId, tokenHash string, expiresAt time.Time) (*models.SessionData, error) {
+	log.Println("CreateSession start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(userId); err != nil {
+		log.Printf("Failed to create session: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	session := r.Store.insertSession(userId, uuid.New().String(), tokenHash, expiresAt, expiresAt)
+
+	log.Printf("Created session: %s", session.ID)
+	return &session, nil
+}
+
+// リフレッシュトークンを交換し、同じ系列の新しいセッションを作成する
+// 交換済みのトークンが再び使われた場合は、系列のセッションをすべて失効させて ErrSessionReused を返す。
+// 新しいセッションの有効期限は expiresAt と系列の有効期限のうち早い方とする。
+func (r *MemorySessionRepository) RotateSession(ctx context.Context, tokenHash, newTokenHash string, expiresAt time.Time) (*models.SessionData, error) {
+	log.Println("RotateSession start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	current, ok := r.Store.sessions[tokenHash]
+	now := time.Now()
+	switch {
+	case !ok:
+		log.Printf("Failed to rotate session: %v", repositories_sessions.ErrSessionNotFound)
+		return nil, repositories_sessions.ErrSessionNotFound
+
+	case current.RevokedAt != nil:
+		log.Printf("Failed to rotate session: %v", repositories_sessions.ErrSessionRevoked)
+		return nil, repositories_sessions.ErrSessionRevoked
+
+	case current.RotatedAt != nil:
+		// 交換済みのトークンの再利用(盗用の疑い)のため、系列をすべて失効させる
+		r.Store.revokeSessionFamily(current.FamilyId, now)
+		log.Printf("Revoked session family %s: %v", current.FamilyId, repositories_sessions.ErrSessionReused)
+		return nil, repositories_sessions.ErrSessionReused
+
+	case !current.ExpiresAt.After(now), !current.FamilyExpiresAt.After(now):
+		log.Printf("Failed to rotate session: %v", repositories_sessions.ErrSessionExpired)
+		return nil, repositories_sessions.ErrSessionExpired
+	}
+
+	// 系列の有効期限を超えないようにする
+	if expiresAt.After(current.FamilyExpiresAt) {
+		expiresAt = current.FamilyExpiresAt
+	}
+
+	// 交換元を交換済みにし、同じ系列で新しいセッションを作成
+	current.RotatedAt = &now
+	r.Store.sessions[tokenHash] = current
+	session := r.Store.insertSession(current.UserId, current.FamilyId, newTokenHash, expiresAt, current.FamilyExpiresAt)
+
+	log.Printf("Rotated session: %s", session.ID)
+	return &session, nil
+}
+
+// 指定されたリフレッシュトークンの系列のセッションをすべて失効させる(ログアウト時)
+func (r *MemorySessionRepository) RevokeSession(ctx context.Context, tokenHash string) error {
+	log.Println("RevokeSession start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	session, ok := r.Store.sessions[tokenHash]
+	if !ok || r.Store.revokeSessionFamily(session.FamilyId, time.Now()) == 0 {
+		log.Printf("Failed to revoke session: %v", repositories_sessions.ErrSessionNotFound)
+		return repositories_sessions.ErrSessionNotFound
+	}
+
+	log.Println("Revoked session successfully")
+	return nil
+}
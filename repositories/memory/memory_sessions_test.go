@@ -0,0 +1,83 @@
+package repositories_memory
+
+import (
+	repositories_sessions "backend/repositories/sessions"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository_Session_PipeLine(t *testing.T) {
+	repo := NewSessionRepository(NewStore())
+	ctx := context.Background()
+	userId := "11111111-1111-1111-1111-111111111111"
+	expiresAt := time.Now().Add(time.Hour)
+
+	// セッションを作成
+	session, err := repo.CreateSession(ctx, userId, "first", expiresAt)
+	assert.NoError(t, err)
+	assert.Equal(t, userId, session.UserId)
+
+	// トークンを交換すると同じ系列を引き継ぐ
+	rotated, err := repo.RotateSession(ctx, "first", "second", expiresAt)
+	assert.NoError(t, err)
+	assert.Equal(t, session.FamilyId, rotated.FamilyId)
+	assert.NotEqual(t, session.ID, rotated.ID)
+
+	// 交換済みのトークンを再利用すると系列ごと失効する
+	_, err = repo.RotateSession(ctx, "first", "third", expiresAt)
+	assert.ErrorIs(t, err, repositories_sessions.ErrSessionReused)
+	_, err = repo.RotateSession(ctx, "second", "third", expiresAt)
+	assert.ErrorIs(t, err, repositories_sessions.ErrSessionRevoked)
+
+	// 別の系列には影響しない
+	_, err = repo.CreateSession(ctx, userId, "other", expiresAt)
+	assert.NoError(t, err)
+	_, err = repo.RotateSession(ctx, "other", "other2", expiresAt)
+	assert.NoError(t, err)
+
+	// ログアウトで系列を失効させる(交換前のトークンでも系列全体が対象)
+	assert.NoError(t, repo.RevokeSession(ctx, "other"))
+	_, err = repo.RotateSession(ctx, "other2", "other3", expiresAt)
+	assert.ErrorIs(t, err, repositories_sessions.ErrSessionRevoked)
+	assert.ErrorIs(t, repo.RevokeSession(ctx, "other2"), repositories_sessions.ErrSessionNotFound)
+
+	// 存在しないトークン
+	_, err = repo.RotateSession(ctx, "unknown", "x", expiresAt)
+	assert.ErrorIs(t, err, repositories_sessions.ErrSessionNotFound)
+	assert.ErrorIs(t, repo.RevokeSession(ctx, "unknown"), repositories_sessions.ErrSessionNotFound)
+}
+
+func TestMemoryRepository_RotateSession_Expired(t *testing.T) {
+	repo := NewSessionRepository(NewStore())
+	ctx := context.Background()
+
+	_, err := repo.CreateSession(ctx, "11111111-1111-1111-1111-111111111111", "expired", time.Now().Add(-time.Second))
+	assert.NoError(t, err)
+
+	_, err = repo.RotateSession(ctx, "expired", "new", time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, repositories_sessions.ErrSessionExpired)
+}
+
+func TestMemoryRepository_RotateSession_FamilyExpiry(t *testing.T) {
+	repo := NewSessionRepository(NewStore())
+	ctx := context.Background()
+	familyExpiresAt := time.Now().Add(200 * time.Millisecond)
+
+	session, err := repo.CreateSession(ctx, "11111111-1111-1111-1111-111111111111", "first", familyExpiresAt)
+	assert.NoError(t, err)
+	assert.Equal(t, familyExpiresAt, session.FamilyExpiresAt)
+
+	// 交換後の有効期限は系列の有効期限を超えない
+	rotated, err := repo.RotateSession(ctx, "first", "second", time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, familyExpiresAt, rotated.ExpiresAt)
+	assert.Equal(t, familyExpiresAt, rotated.FamilyExpiresAt)
+
+	// 系列の有効期限を過ぎると交換できない
+	time.Sleep(time.Until(familyExpiresAt) + 10*time.Millisecond)
+	_, err = repo.RotateSession(ctx, "second", "third", time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, repositories_sessions.ErrSessionExpired)
+}
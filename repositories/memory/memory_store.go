@@ -0,0 +1,188 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	utils_password "backend/utils/password"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// インメモリのデータストア
+// blogs, blogs_likes, comments, users, tags, tag_aliases, blog_tags, categories, blog_revisions, blog_slug_redirects, sessions, email_verifications, password_resets, email_changes, login_failures, user_totp, recovery_codes, two_factor_challenges の各テーブルを保持し、
+// 各インメモリリポジトリで共有することで集計値(いいね数・コメント数)の更新を再現する。
+type Store struct {
+	mu        sync.RWMutex
+	users     map[string]models.UserCredentials
+	blogs     map[string]models.BlogData
+	blogLikes map[string]models.BlogLikeData
+	comments  map[string]models.CommentData
+
+	tags       map[string]models.TagData // 使用ブログ数・別名は保持しない
+	tagAliases map[string]tagAlias       // キーは小文字化した別名
+	blogTags   map[string][]string       // ブログIDごとのタグID(表示順)
+
+	categories map[string]models.CategoryData // 投稿数は保持しない
+
+	blogRevisions     map[string][]models.BlogRevisionData // ブログIDごとの版履歴(版番号の昇順)
+	blogSlugRedirects map[string]string                    // 変更前のスラッグごとのブログID
+
+	sessions map[string]models.SessionData // リフレッシュトークンのハッシュごとのセッション
+
+	emailVerifications map[string]emailVerification // 確認メールのトークンのハッシュごとの確認状況
+	passwordResets     map[string]passwordReset     // パスワード再設定のトークンのハッシュごとの使用状況
+	emailChanges       map[string]emailChange       // メールアドレス変更のトークンのハッシュごとの変更内容
+
+	loginFailures map[string][]time.Time // 制限の単位(キー)ごとのログインの失敗の日時
+
+	userTOTP            map[string]models.TOTPData    // ユーザーIDごとのTOTPの設定
+	recoveryCodes       map[string][]recoveryCode     // ユーザーIDごとのリカバリーコード
+	twoFactorChallenges map[string]twoFactorChallenge // チャレンジトークンのハッシュごとのログインのチャレンジ
+}
+
+// 空のインメモリストアを生成する
+func NewStore() *Store {
+	return &Store{
+		users:     make(map[string]models.UserCredentials),
+		blogs:     make(map[string]models.BlogData),
+		blogLikes: make(map[string]models.BlogLikeData),
+		comments:  make(map[string]models.CommentData),
+
+		tags:       make(map[string]models.TagData),
+		tagAliases: make(map[string]tagAlias),
+		blogTags:   make(map[string][]string),
+
+		categories: make(map[string]models.CategoryData),
+
+		blogRevisions:     make(map[string][]models.BlogRevisionData),
+		blogSlugRedirects: make(map[string]string),
+
+		sessions: make(map[string]models.SessionData),
+
+		emailVerifications: make(map[string]emailVerification),
+		passwordResets:     make(map[string]passwordReset),
+		emailChanges:       make(map[string]emailChange),
+
+		loginFailures: make(map[string][]time.Time),
+
+		userTOTP:            make(map[string]models.TOTPData),
+		recoveryCodes:       make(map[string][]recoveryCode),
+		twoFactorChallenges: make(map[string]twoFactorChallenge),
+	}
+}
+
+// ユーザーをパスワード(ハッシュ)とともにストアへ登録する
+// IDが空の場合は新しいUUIDを採番し、権限が空の場合はデータベースの既定値と同じ author とする。
+func (s *Store) SeedUser(user models.UserData, password string) models.UserData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user.ID == "" {
+		user.ID = uuid.New().String()
+	}
+	if user.Role == "" {
+		user.Role = models.UserRoleAuthor
+	}
+	now := time.Now()
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = now
+	}
+	if user.UpdatedAt.IsZero() {
+		user.UpdatedAt = now
+	}
+	s.users[user.ID] = models.UserCredentials{User: user, PasswordHash: password}
+	return user
+}
+
+// カテゴリをストアへ登録する
+// IDが空の場合は新しいUUIDを採番する。
+func (s *Store) SeedCategory(category models.CategoryData) models.CategoryData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if category.ID == "" {
+		category.ID = uuid.New().String()
+	}
+	now := time.Now()
+	if category.CreatedAt.IsZero() {
+		category.CreatedAt = now
+	}
+	if category.UpdatedAt.IsZero() {
+		category.UpdatedAt = now
+	}
+	s.categories[category.ID] = category
+	return category
+}
+
+// 環境変数 MEMORY_USER_* が設定されている場合、ログイン用のユーザーを登録する
+func (s *Store) SeedFromEnv() {
+	email := models.NormalizeEmail(os.Getenv("MEMORY_USER_EMAIL"))
+	if email == "" {
+		return
+	}
+	// パスワードはデータベースと同様にハッシュ化して保存する
+	password, err := utils_password.Hash(os.Getenv("MEMORY_USER_PASSWORD"))
+	if err != nil {
+		log.Printf("Failed to hash memory user password: %v", err)
+		return
+	}
+	now := time.Now()
+	s.SeedUser(models.UserData{
+		ID:    os.Getenv("MEMORY_USER_ID"),
+		Name:  os.Getenv("MEMORY_USER_NAME"),
+		Email: email,
+		Role:  os.Getenv("MEMORY_USER_ROLE"),
+		// メールアドレスは確認済みとする
+		EmailVerifiedAt: &now,
+	}, password)
+}
+
+// ブログのいいね数・コメント数を増減する（呼び出し側でロックを取得すること）
+// Postgresのトリガーと同様に、いいね・コメントの追加・削除と同時にブログの集計値を更新する。
+func (s *Store) adjustCounters(blogId string, likes, comments int64) {
+	blog, ok := s.blogs[blogId]
+	if !ok {
+		return
+	}
+	blog.Likes += likes
+	blog.CommentCnt += comments
+	s.blogs[blogId] = blog
+}
+
+// いいね・コメントからブログの集計値を再計算する（呼び出し側でロックを取得すること）
+// 集計値が食い違っていたブログの件数を返す。
+func (s *Store) reconcileCounters() int {
+	likes := make(map[string]int64)
+	for _, like := range s.blogLikes {
+		likes[like.BlogId]++
+	}
+	comments := make(map[string]int64)
+	for _, comment := range s.comments {
+		comments[comment.BlogId]++
+	}
+
+	fixed := 0
+	for id, blog := range s.blogs {
+		if blog.Likes == likes[id] && blog.CommentCnt == comments[id] {
+			continue
+		}
+		blog.Likes = likes[id]
+		blog.CommentCnt = comments[id]
+		s.blogs[id] = blog
+		fixed++
+	}
+	return fixed
+}
+
+// UUID形式であることを確認する
+// Postgresのuuid型と同様に、不正な形式の場合はエラーを返す。
+func validateUUID(value string) error {
+	if _, err := uuid.Parse(value); err != nil {
+		return fmt.Errorf("invalid input syntax for type uuid: %q", value)
+	}
+	return nil
+}
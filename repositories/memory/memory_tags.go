@@ -0,0 +1,356 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	repositories_tags "backend/repositories/tags"
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// タグの別名
+type tagAlias struct {
+	Alias string
+	TagId string
+}
+
+// TagRepositoryのインメモリ実装
+type MemoryTagRepository struct {
+	Store *Store
+}
+
+// TagRepositoryインターフェースを実装したMemoryTagRepositoryのポインタを返す
+func NewTagRepository(store *Store) repositories_tags.TagRepository {
+	return &MemoryTagRepository{
+		Store: store,
+	}
+}
+
+// タグ名または別名から正規のタグを取得する（呼び出し側でロックを取得すること）
+func (s *Store) findTagByName(name string) (models.TagData, bool) {
+	key := strings.ToLower(name)
+	for _, tag := range s.tags {
+		if strings.ToLower(tag.Name) == key {
+			return tag, true
+		}
+	}
+	if alias, ok := s.tagAliases[key]; ok {
+		return s.tags[alias.TagId], true
+	}
+	return models.TagData{}, false
+}
+
+// タグ名を正規のタグに解決し、未登録のタグは作成する（呼び出し側で書き込みロックを取得すること）
+func (s *Store) resolveTags(names []string) []models.TagData {
+	var tags []models.TagData
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		tag, ok := s.findTagByName(name)
+		if !ok {
+			now := time.Now()
+			tag = models.TagData{
+				ID:        uuid.New().String(),
+				Name:      name,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			s.tags[tag.ID] = tag
+		}
+
+		if seen[tag.ID] {
+			continue
+		}
+		seen[tag.ID] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// ブログに紐づくタグを置き換える（呼び出し側で書き込みロックを取得すること）
+func (s *Store) replaceBlogTags(blogId string, tags []models.TagData) {
+	ids := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		ids = append(ids, tag.ID)
+	}
+	s.blogTags[blogId] = ids
+}
+
+// タグを使用しているブログのタグ文字列を再構築する（呼び出し側で書き込みロックを取得すること）
+func (s *Store) refreshBlogTagStrings(tagId string) {
+	for blogId, tagIds := range s.blogTags {
+		if !containsString(tagIds, tagId) {
+			continue
+		}
+		var tags []models.TagData
+		for _, id := range tagIds {
+			tags = append(tags, s.tags[id])
+		}
+		blog := s.blogs[blogId]
+		blog.Tags = repositories_tags.JoinTags(tags)
+		blog.UpdatedAt = time.Now()
+		s.blogs[blogId] = blog
+	}
+}
+
+// 使用ブログ数と別名を付与したタグデータを返す（呼び出し側でロックを取得すること）
+func (s *Store) tagWithAggregates(tag models.TagData) models.TagData {
+	tag.Count = 0
+	for blogId, tagIds := range s.blogTags {
+		if isPublicBlog(s.blogs[blogId]) && containsString(tagIds, tag.ID) {
+			tag.Count++
+		}
+	}
+
+	tag.Aliases = []string{}
+	for _, alias := range s.tagAliases {
+		if alias.TagId == tag.ID {
+			tag.Aliases = append(tag.Aliases, alias.Alias)
+		}
+	}
+	sort.Slice(tag.Aliases, func(i, j int) bool {
+		return strings.ToLower(tag.Aliases[i]) < strings.ToLower(tag.Aliases[j])
+	})
+	return tag
+}
+
+// スライスに値が含まれるか判定する
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// 全タグ情報を取得する
+func (r *MemoryTagRepository) FetchTags(ctx context.Context) ([]models.TagData, error) {
+	log.Printf("FetchTags start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	var tags []models.TagData
+	for _, tag := range r.Store.tags {
+		tags = append(tags, r.Store.tagWithAggregates(tag))
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return strings.ToLower(tags[i].Name) < strings.ToLower(tags[j].Name)
+	})
+
+	log.Printf("Fetched tags: %v", tags)
+	return tags, nil
+}
+
+// 指定されたIDに一致するタグ情報を取得する
+func (r *MemoryTagRepository) FetchTagById(ctx context.Context, id string) (*models.TagData, error) {
+	log.Printf("FetchTagById start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(id); err != nil {
+		log.Printf("Failed to fetch tag: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	tag, ok := r.Store.tags[id]
+	if !ok {
+		log.Printf("Failed to fetch tag: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+
+	tag = r.Store.tagWithAggregates(tag)
+	log.Printf("Fetched tag: %v", tag)
+	return &tag, nil
+}
+
+// タグ名を変更する
+func (r *MemoryTagRepository) RenameTag(ctx context.Context, id, name string) (*models.TagData, error) {
+	log.Printf("RenameTag start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(id); err != nil {
+		log.Printf("Failed to rename tag: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	tag, ok := r.Store.tags[id]
+	if !ok {
+		log.Printf("Failed to rename tag: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+
+	// 他のタグ名・別名との重複を確認
+	key := strings.ToLower(name)
+	if alias, ok := r.Store.tagAliases[key]; ok {
+		if alias.TagId != id {
+			log.Printf("Failed to rename tag: %v", repositories_tags.ErrTagConflict)
+			return nil, repositories_tags.ErrTagConflict
+		}
+		delete(r.Store.tagAliases, key)
+	}
+	for _, other := range r.Store.tags {
+		if other.ID != id && strings.ToLower(other.Name) == key {
+			log.Printf("Failed to rename tag: %v", repositories_tags.ErrTagConflict)
+			return nil, repositories_tags.ErrTagConflict
+		}
+	}
+
+	tag.Name = name
+	tag.UpdatedAt = time.Now()
+	r.Store.tags[id] = tag
+	r.Store.refreshBlogTagStrings(id)
+
+	tag = r.Store.tagWithAggregates(tag)
+	log.Printf("Renamed tag: %v", tag)
+	return &tag, nil
+}
+
+// タグを統合する
+func (r *MemoryTagRepository) MergeTags(ctx context.Context, sourceId, targetId string) (*models.TagData, error) {
+	log.Printf("MergeTags start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, id := range []string{sourceId, targetId} {
+		if err := validateUUID(id); err != nil {
+			log.Printf("Failed to merge tags: %v", err)
+			return nil, err
+		}
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	source, ok := r.Store.tags[sourceId]
+	if !ok {
+		log.Printf("Failed to merge tags: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+	target, ok := r.Store.tags[targetId]
+	if !ok {
+		log.Printf("Failed to merge tags: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+
+	// ブログの付け替え(既に統合先が付いている場合は統合元を外すのみ)
+	for blogId, tagIds := range r.Store.blogTags {
+		if !containsString(tagIds, sourceId) {
+			continue
+		}
+		var replaced []string
+		for _, id := range tagIds {
+			if id == sourceId {
+				id = targetId
+			}
+			if !containsString(replaced, id) {
+				replaced = append(replaced, id)
+			}
+		}
+		r.Store.blogTags[blogId] = replaced
+	}
+
+	// 別名の付け替えと、統合元のタグ名の別名登録
+	for key, alias := range r.Store.tagAliases {
+		if alias.TagId == sourceId {
+			alias.TagId = targetId
+			r.Store.tagAliases[key] = alias
+		}
+	}
+	if _, ok := r.Store.tagAliases[strings.ToLower(source.Name)]; !ok {
+		r.Store.tagAliases[strings.ToLower(source.Name)] = tagAlias{Alias: source.Name, TagId: targetId}
+	}
+
+	delete(r.Store.tags, sourceId)
+	r.Store.refreshBlogTagStrings(targetId)
+
+	target = r.Store.tagWithAggregates(target)
+	log.Printf("Merged tags: %v", target)
+	return &target, nil
+}
+
+// タグに別名を追加する
+func (r *MemoryTagRepository) CreateTagAlias(ctx context.Context, tagId, alias string) (*models.TagData, error) {
+	log.Printf("CreateTagAlias start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(tagId); err != nil {
+		log.Printf("Failed to create tag alias: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	tag, ok := r.Store.tags[tagId]
+	if !ok {
+		log.Printf("Failed to create tag alias: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+
+	// タグ名・別名との重複を確認
+	if _, exists := r.Store.findTagByName(alias); exists {
+		log.Printf("Failed to create tag alias: %v", repositories_tags.ErrTagConflict)
+		return nil, repositories_tags.ErrTagConflict
+	}
+
+	r.Store.tagAliases[strings.ToLower(alias)] = tagAlias{Alias: alias, TagId: tagId}
+
+	tag = r.Store.tagWithAggregates(tag)
+	log.Printf("Created tag alias: %v", tag)
+	return &tag, nil
+}
+
+// タグの別名を削除する
+func (r *MemoryTagRepository) DeleteTagAlias(ctx context.Context, alias string) error {
+	log.Printf("DeleteTagAlias start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	key := strings.ToLower(alias)
+	if _, ok := r.Store.tagAliases[key]; !ok {
+		log.Printf("Failed to delete tag alias: %v", pgx.ErrNoRows)
+		return pgx.ErrNoRows
+	}
+	delete(r.Store.tagAliases, key)
+
+	log.Printf("Deleted tag alias: %s", alias)
+	return nil
+}
@@ -0,0 +1,126 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	repositories_tags "backend/repositories/tags"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// タグ名からタグを探す
+func findTag(tags []models.TagData, name string) *models.TagData {
+	for i := range tags {
+		if tags[i].Name == name {
+			return &tags[i]
+		}
+	}
+	return nil
+}
+
+func TestMemoryRepository_Tag_PipeLine(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	store := NewStore()
+	blogRepo := NewBlogRepository(store)
+	repo := NewTagRepository(store)
+	userId := uuid.New().String()
+	seedCategories(store, "category")
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 1. ブログ作成時のタグ登録テスト(大文字小文字の違いは同じタグにまとめる)
+	// ----------------------------------------------------------------------------------------------------------------------------
+	blog1, err := blogRepo.CreateBlog(context.Background(), userId, "title1", "url", "category", "description", "Go, Echo", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Go, Echo", blog1.Tags)
+	blog2, err := blogRepo.CreateBlog(context.Background(), userId, "title2", "url", "category", "description", "golang,go , echo", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "golang, Go, Echo", blog2.Tags)
+	publishBlog(t, blogRepo, blog1.ID)
+	publishBlog(t, blogRepo, blog2.ID)
+
+	tags, err := repo.FetchTags(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, tags, 3)
+	goTag := findTag(tags, "Go")
+	golangTag := findTag(tags, "golang")
+	assert.Equal(t, 2, goTag.Count)
+	assert.Equal(t, 1, golangTag.Count)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 2. タグ統合テスト(golang → Go)
+	// ----------------------------------------------------------------------------------------------------------------------------
+	merged, err := repo.MergeTags(context.Background(), golangTag.ID, goTag.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Go", merged.Name)
+	assert.Equal(t, 2, merged.Count)
+	assert.Equal(t, []string{"golang"}, merged.Aliases)
+
+	// 統合元のタグは削除され、ブログのタグ文字列も更新される
+	_, err = repo.FetchTagById(context.Background(), golangTag.ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	fetched, err := blogRepo.FetchBlogById(context.Background(), blog2.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Go, Echo", fetched.Tags)
+
+	// 別名で作成したブログは正規のタグになる
+	blog3, err := blogRepo.CreateBlog(context.Background(), userId, "title3", "url", "category", "description", "GoLang", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Go", blog3.Tags)
+	publishBlog(t, blogRepo, blog3.ID)
+
+	// 別名でも一覧を絞り込める
+	total, err := blogRepo.CountBlogs(context.Background(), models.BlogListFilter{Tag: "golang"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 3. タグ名変更テスト
+	// ----------------------------------------------------------------------------------------------------------------------------
+	renamed, err := repo.RenameTag(context.Background(), goTag.ID, "Go言語")
+	assert.NoError(t, err)
+	assert.Equal(t, "Go言語", renamed.Name)
+	fetched, err = blogRepo.FetchBlogById(context.Background(), blog1.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Go言語, Echo", fetched.Tags)
+
+	// 他のタグ名・別名との重複はエラー
+	echoTag := findTag(tags, "Echo")
+	_, err = repo.RenameTag(context.Background(), echoTag.ID, "go言語")
+	assert.ErrorIs(t, err, repositories_tags.ErrTagConflict)
+	_, err = repo.RenameTag(context.Background(), echoTag.ID, "Golang")
+	assert.ErrorIs(t, err, repositories_tags.ErrTagConflict)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 4. 別名の追加・削除テスト
+	// ----------------------------------------------------------------------------------------------------------------------------
+	withAlias, err := repo.CreateTagAlias(context.Background(), echoTag.ID, "echo-framework")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"echo-framework"}, withAlias.Aliases)
+
+	// タグ名・別名との重複はエラー
+	_, err = repo.CreateTagAlias(context.Background(), echoTag.ID, "GO言語")
+	assert.ErrorIs(t, err, repositories_tags.ErrTagConflict)
+	_, err = repo.CreateTagAlias(context.Background(), goTag.ID, "Echo-Framework")
+	assert.ErrorIs(t, err, repositories_tags.ErrTagConflict)
+
+	err = repo.DeleteTagAlias(context.Background(), "ECHO-FRAMEWORK")
+	assert.NoError(t, err)
+	err = repo.DeleteTagAlias(context.Background(), "echo-framework")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// ----------------------------------------------------------------------------------------------------------------------------
+	// 5. タグ件数テスト(ブログ削除後は件数から除外される)
+	// ----------------------------------------------------------------------------------------------------------------------------
+	err = blogRepo.DeleteBlog(context.Background(), blog1.ID)
+	assert.NoError(t, err)
+
+	counts, err := blogRepo.FetchBlogTags(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []models.TagCount{
+		{Name: "Echo", Count: 1},
+		{Name: "Go言語", Count: 2},
+	}, counts)
+}
@@ -0,0 +1,292 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	repositories_two_factor "backend/repositories/two_factor"
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// リカバリーコードの使用状況
+type recoveryCode struct {
+	codeHash string
+	usedAt   *time.Time
+}
+
+// ログインのチャレンジの状況
+type twoFactorChallenge struct {
+	userId    string
+	expiresAt time.Time
+	attempts  int
+	usedAt    *time.Time
+}
+
+// TwoFactorRepositoryのインメモリ実装
+type MemoryTwoFactorRepository struct {
+	Store *Store
+}
+
+// TwoFactorRepositoryインターフェースを実装したMemoryTwoFactorRepositoryのポインタを返す
+func NewTwoFactorRepository(store *Store) repositories_two_factor.TwoFactorRepository {
+	return &MemoryTwoFactorRepository{
+		Store: store,
+	}
+}
+
+// ユーザーのTOTPの設定を取得する
+func (r *MemoryTwoFactorRepository) FetchTOTP(ctx context.Context, userId string) (*models.TOTPData, error) {
+	log.Println("FetchTOTP start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	totp, ok := r.Store.userTOTP[userId]
+	if !ok {
+		log.Printf("Failed to fetch totp: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+	return &totp, nil
+}
+
+// 確認待ちのシークレットを保存する(登録の開始・やり直し)
+func (r *MemoryTwoFactorRepository) SaveTOTPSecret(ctx context.Context, userId, secret string) error {
+	log.Println("SaveTOTPSecret start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := validateUUID(userId); err != nil {
+		log.Printf("Failed to save totp secret: %v", err)
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	if totp, ok := r.Store.userTOTP[userId]; ok && totp.Enabled() {
+		log.Printf("Failed to save totp secret: %v", repositories_two_factor.ErrTOTPAlreadyEnabled)
+		return repositories_two_factor.ErrTOTPAlreadyEnabled
+	}
+
+	now := time.Now()
+	r.Store.userTOTP[userId] = models.TOTPData{
+		UserId:    userId,
+		Secret:    secret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	return nil
+}
+
+// 確認待ちのシークレットを有効にし、リカバリーコード(ハッシュ)を登録し直す
+func (r *MemoryTwoFactorRepository) EnableTOTP(ctx context.Context, userId, secret string, step int64, recoveryCodeHashes []string) error {
+	log.Println("EnableTOTP start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	totp, ok := r.Store.userTOTP[userId]
+	if !ok || totp.Enabled() || totp.Secret != secret {
+		log.Printf("Failed to enable totp: %v", repositories_two_factor.ErrTOTPNotPending)
+		return repositories_two_factor.ErrTOTPNotPending
+	}
+
+	now := time.Now()
+	totp.EnabledAt = &now
+	totp.LastUsedStep = step
+	totp.UpdatedAt = now
+	r.Store.userTOTP[userId] = totp
+
+	// 以前のリカバリーコードは使えなくする
+	codes := make([]recoveryCode, 0, len(recoveryCodeHashes))
+	for _, codeHash := range recoveryCodeHashes {
+		codes = append(codes, recoveryCode{codeHash: codeHash})
+	}
+	r.Store.recoveryCodes[userId] = codes
+
+	log.Printf("Enabled totp successfully: %s", userId)
+	return nil
+}
+
+// 2要素認証を無効にし、設定・リカバリーコード・未完了のチャレンジを削除する
+func (r *MemoryTwoFactorRepository) DisableTOTP(ctx context.Context, userId string) error {
+	log.Println("DisableTOTP start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	delete(r.Store.userTOTP, userId)
+	delete(r.Store.recoveryCodes, userId)
+	for tokenHash, challenge := range r.Store.twoFactorChallenges {
+		if challenge.userId == userId {
+			delete(r.Store.twoFactorChallenges, tokenHash)
+		}
+	}
+
+	log.Printf("Disabled totp successfully: %s", userId)
+	return nil
+}
+
+// コードの時間ステップを使用済みとして記録する
+func (r *MemoryTwoFactorRepository) UseTOTPStep(ctx context.Context, userId string, step int64) error {
+	log.Println("UseTOTPStep start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	totp, ok := r.Store.userTOTP[userId]
+	if !ok || !totp.Enabled() || totp.LastUsedStep >= step {
+		log.Printf("Failed to use totp step: %v", repositories_two_factor.ErrTOTPStepUsed)
+		return repositories_two_factor.ErrTOTPStepUsed
+	}
+	totp.LastUsedStep = step
+	totp.UpdatedAt = time.Now()
+	r.Store.userTOTP[userId] = totp
+	return nil
+}
+
+// リカバリーコードを使用済みにする
+func (r *MemoryTwoFactorRepository) UseRecoveryCode(ctx context.Context, userId, codeHash string) error {
+	log.Println("UseRecoveryCode start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	for i, code := range r.Store.recoveryCodes[userId] {
+		if code.codeHash == codeHash && code.usedAt == nil {
+			now := time.Now()
+			r.Store.recoveryCodes[userId][i].usedAt = &now
+			log.Printf("Used recovery code: %s", userId)
+			return nil
+		}
+	}
+
+	log.Printf("Failed to use recovery code: %v", repositories_two_factor.ErrRecoveryCodeInvalid)
+	return repositories_two_factor.ErrRecoveryCodeInvalid
+}
+
+// 未使用のリカバリーコードの数を取得する
+func (r *MemoryTwoFactorRepository) CountRecoveryCodes(ctx context.Context, userId string) (int, error) {
+	log.Println("CountRecoveryCodes start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	count := 0
+	for _, code := range r.Store.recoveryCodes[userId] {
+		if code.usedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// ログインのチャレンジを登録する
+// 同じユーザーの完了済み・期限切れのチャレンジはあわせて削除する。
+func (r *MemoryTwoFactorRepository) CreateChallenge(ctx context.Context, userId, tokenHash string, expiresAt time.Time) error {
+	log.Println("CreateChallenge start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := validateUUID(userId); err != nil {
+		log.Printf("Failed to create challenge: %v", err)
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	now := time.Now()
+	for hash, challenge := range r.Store.twoFactorChallenges {
+		if challenge.userId == userId && (challenge.usedAt != nil || !challenge.expiresAt.After(now)) {
+			delete(r.Store.twoFactorChallenges, hash)
+		}
+	}
+	r.Store.twoFactorChallenges[tokenHash] = twoFactorChallenge{userId: userId, expiresAt: expiresAt}
+
+	log.Println("Created challenge successfully")
+	return nil
+}
+
+// チャレンジの試行回数を1増やし、ユーザーIDを返す
+func (r *MemoryTwoFactorRepository) AttemptChallenge(ctx context.Context, tokenHash string, maxAttempts int) (string, error) {
+	log.Println("AttemptChallenge start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	challenge, ok := r.Store.twoFactorChallenges[tokenHash]
+	if !ok || challenge.usedAt != nil || !challenge.expiresAt.After(time.Now()) || challenge.attempts >= maxAttempts {
+		log.Printf("Failed to attempt challenge: %v", repositories_two_factor.ErrChallengeInvalid)
+		return "", repositories_two_factor.ErrChallengeInvalid
+	}
+	challenge.attempts++
+	r.Store.twoFactorChallenges[tokenHash] = challenge
+	return challenge.userId, nil
+}
+
+// チャレンジを完了済みにする(ログインの完了時)
+func (r *MemoryTwoFactorRepository) CompleteChallenge(ctx context.Context, tokenHash string) error {
+	log.Println("CompleteChallenge start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	challenge, ok := r.Store.twoFactorChallenges[tokenHash]
+	if !ok || challenge.usedAt != nil {
+		log.Printf("Failed to complete challenge: %v", repositories_two_factor.ErrChallengeInvalid)
+		return repositories_two_factor.ErrChallengeInvalid
+	}
+	now := time.Now()
+	challenge.usedAt = &now
+	r.Store.twoFactorChallenges[tokenHash] = challenge
+	return nil
+}
@@ -0,0 +1,92 @@
+package repositories_memory
+
+import (
+	repositories_two_factor "backend/repositories/two_factor"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository_TwoFactor_PipeLine(t *testing.T) {
+	repo := NewTwoFactorRepository(NewStore())
+	ctx := context.Background()
+	userId := "11111111-1111-1111-1111-111111111111"
+
+	// 登録前は設定がない
+	_, err := repo.FetchTOTP(ctx, userId)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// 登録を開始(やり直すとシークレットが変わり、確認前は無効)
+	assert.NoError(t, repo.SaveTOTPSecret(ctx, userId, "SECRETA"))
+	assert.NoError(t, repo.SaveTOTPSecret(ctx, userId, "SECRETB"))
+	totp, err := repo.FetchTOTP(ctx, userId)
+	assert.NoError(t, err)
+	assert.Equal(t, "SECRETB", totp.Secret)
+	assert.False(t, totp.Enabled())
+
+	// やり直す前のシークレットでは有効にできない
+	assert.ErrorIs(t, repo.EnableTOTP(ctx, userId, "SECRETA", 100, nil), repositories_two_factor.ErrTOTPNotPending)
+	assert.NoError(t, repo.EnableTOTP(ctx, userId, "SECRETB", 100, []string{"hash1", "hash2"}))
+	assert.ErrorIs(t, repo.EnableTOTP(ctx, userId, "SECRETB", 100, nil), repositories_two_factor.ErrTOTPNotPending)
+	assert.ErrorIs(t, repo.SaveTOTPSecret(ctx, userId, "SECRETC"), repositories_two_factor.ErrTOTPAlreadyEnabled)
+
+	// 使用済みの時間ステップ以前のコードは使えない
+	assert.ErrorIs(t, repo.UseTOTPStep(ctx, userId, 100), repositories_two_factor.ErrTOTPStepUsed)
+	assert.NoError(t, repo.UseTOTPStep(ctx, userId, 101))
+	assert.ErrorIs(t, repo.UseTOTPStep(ctx, userId, 99), repositories_two_factor.ErrTOTPStepUsed)
+
+	// リカバリーコードは一度だけ使用できる
+	assert.NoError(t, repo.UseRecoveryCode(ctx, userId, "hash1"))
+	assert.ErrorIs(t, repo.UseRecoveryCode(ctx, userId, "hash1"), repositories_two_factor.ErrRecoveryCodeInvalid)
+	assert.ErrorIs(t, repo.UseRecoveryCode(ctx, userId, "unknown"), repositories_two_factor.ErrRecoveryCodeInvalid)
+	count, err := repo.CountRecoveryCodes(ctx, userId)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// 無効にすると設定・リカバリーコード・チャレンジが削除される
+	assert.NoError(t, repo.CreateChallenge(ctx, userId, "challenge", time.Now().Add(time.Minute)))
+	assert.NoError(t, repo.DisableTOTP(ctx, userId))
+	_, err = repo.FetchTOTP(ctx, userId)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	count, err = repo.CountRecoveryCodes(ctx, userId)
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	_, err = repo.AttemptChallenge(ctx, "challenge", 5)
+	assert.ErrorIs(t, err, repositories_two_factor.ErrChallengeInvalid)
+}
+
+func TestMemoryRepository_TwoFactorChallenge(t *testing.T) {
+	repo := NewTwoFactorRepository(NewStore())
+	ctx := context.Background()
+	userId := "11111111-1111-1111-1111-111111111111"
+
+	// 試行回数の上限まで使える
+	assert.NoError(t, repo.CreateChallenge(ctx, userId, "first", time.Now().Add(time.Minute)))
+	for i := 0; i < 2; i++ {
+		id, err := repo.AttemptChallenge(ctx, "first", 2)
+		assert.NoError(t, err)
+		assert.Equal(t, userId, id)
+	}
+	_, err := repo.AttemptChallenge(ctx, "first", 2)
+	assert.ErrorIs(t, err, repositories_two_factor.ErrChallengeInvalid)
+
+	// 完了後は使えない
+	assert.NoError(t, repo.CreateChallenge(ctx, userId, "second", time.Now().Add(time.Minute)))
+	assert.NoError(t, repo.CompleteChallenge(ctx, "second"))
+	assert.ErrorIs(t, repo.CompleteChallenge(ctx, "second"), repositories_two_factor.ErrChallengeInvalid)
+	_, err = repo.AttemptChallenge(ctx, "second", 5)
+	assert.ErrorIs(t, err, repositories_two_factor.ErrChallengeInvalid)
+
+	// 期限切れ・未登録のチャレンジ
+	assert.NoError(t, repo.CreateChallenge(ctx, userId, "expired", time.Now().Add(-time.Second)))
+	_, err = repo.AttemptChallenge(ctx, "expired", 5)
+	assert.ErrorIs(t, err, repositories_two_factor.ErrChallengeInvalid)
+	_, err = repo.AttemptChallenge(ctx, "unknown", 5)
+	assert.ErrorIs(t, err, repositories_two_factor.ErrChallengeInvalid)
+
+	// 不正なユーザーID
+	assert.Error(t, repo.CreateChallenge(ctx, "invalid", "third", time.Now().Add(time.Minute)))
+}
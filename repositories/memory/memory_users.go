@@ -0,0 +1,204 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	repositories_users "backend/repositories/users"
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// UserRepositoryのインメモリ実装
+type MemoryUserRepository struct {
+	Store *Store
+}
+
+// UserRepositoryインターフェースを実装したMemoryUserRepositoryのポインタを返す
+func NewUserRepository(store *Store) repositories_users.UserRepository {
+	return &MemoryUserRepository{
+		Store: store,
+	}
+}
+
+// 指定されたメールアドレスに一致するユーザーの資格情報(パスワードのハッシュを含む)を取得する
+// パスワードの検証は呼び出し側で行う。ユーザーが見つからない場合、エラーを返す。
+func (r *MemoryUserRepository) FetchUserCredentialsByEmail(ctx context.Context, email string) (*models.UserCredentials, error) {
+	log.Printf("Fetching user credentials from memory by email: %s\n", email)
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	for _, credentials := range r.Store.users {
+		if models.NormalizeEmail(credentials.User.Email) == models.NormalizeEmail(email) {
+			log.Printf("Fetched user credentials successfully: %v", credentials)
+			return &credentials, nil
+		}
+	}
+
+	log.Printf("User not found or failed to fetch user credentials: %v", pgx.ErrNoRows)
+	return nil, pgx.ErrNoRows
+}
+
+// 指定されたIDに一致するユーザーの資格情報(パスワードのハッシュを含む)を取得する
+func (r *MemoryUserRepository) FetchUserCredentialsById(ctx context.Context, id string) (*models.UserCredentials, error) {
+	log.Println("Fetching user credentials from memory by ID")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(id); err != nil {
+		log.Printf("User not found or failed to fetch user credentials: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	credentials, ok := r.Store.users[id]
+	if !ok {
+		log.Printf("User not found or failed to fetch user credentials: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+
+	log.Printf("Fetched user credentials successfully: %v", credentials)
+	return &credentials, nil
+}
+
+// 指定されたIDに一致するユーザーを取得する
+func (r *MemoryUserRepository) FetchUserById(ctx context.Context, id string) (*models.UserData, error) {
+	log.Println("Fetching user from memory by ID")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(id); err != nil {
+		log.Printf("User not found or failed to fetch user: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	credentials, ok := r.Store.users[id]
+	if !ok {
+		log.Printf("User not found or failed to fetch user: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+
+	user := credentials.User
+	log.Printf("Fetched user successfully: %v", user)
+	return &user, nil
+}
+
+// ユーザーを登録する
+// 権限はデータベースの既定値と同じ author とし、メールアドレスは未確認の状態で登録する。
+func (r *MemoryUserRepository) CreateUser(ctx context.Context, name, email, password string) (*models.UserData, error) {
+	log.Println("Creating user in memory")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	// Postgresの一意制約と同様に、登録済みのメールアドレスは(大文字・小文字を区別せずに)受け付けない
+	for _, credentials := range r.Store.users {
+		if models.NormalizeEmail(credentials.User.Email) == models.NormalizeEmail(email) {
+			log.Printf("Failed to create user: %v", repositories_users.ErrUserEmailConflict)
+			return nil, repositories_users.ErrUserEmailConflict
+		}
+	}
+
+	now := time.Now()
+	user := models.UserData{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Email:     email,
+		Role:      models.UserRoleAuthor,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	r.Store.users[user.ID] = models.UserCredentials{User: user, PasswordHash: password}
+
+	log.Printf("Created user successfully: %v", user)
+	return &user, nil
+}
+
+// ユーザーのプロフィール(名前・自己紹介・アバター画像のURL)を更新する
+func (r *MemoryUserRepository) UpdateUserProfile(ctx context.Context, id, name, bio, avatarURL string) (*models.UserData, error) {
+	log.Println("Updating user profile in memory")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := validateUUID(id); err != nil {
+		log.Printf("Failed to update user profile: %v", err)
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	credentials, ok := r.Store.users[id]
+	if !ok {
+		log.Printf("Failed to update user profile: %v", pgx.ErrNoRows)
+		return nil, pgx.ErrNoRows
+	}
+
+	credentials.User.Name = name
+	credentials.User.Bio = bio
+	credentials.User.AvatarURL = avatarURL
+	credentials.User.UpdatedAt = time.Now()
+	r.Store.users[id] = credentials
+
+	user := credentials.User
+	log.Printf("Updated user profile successfully: %v", user)
+	return &user, nil
+}
+
+// ユーザーのパスワード(ハッシュ)のみを更新する
+func (r *MemoryUserRepository) UpdateUserPassword(ctx context.Context, id, password string) error {
+	log.Println("Updating user password in memory")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := validateUUID(id); err != nil {
+		log.Printf("Failed to update user password: %v", err)
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	credentials, ok := r.Store.users[id]
+	if !ok {
+		log.Printf("Failed to update user password: %v", pgx.ErrNoRows)
+		return pgx.ErrNoRows
+	}
+
+	credentials.PasswordHash = password
+	credentials.User.UpdatedAt = time.Now()
+	r.Store.users[id] = credentials
+
+	log.Println("Updated user password successfully")
+	return nil
+}
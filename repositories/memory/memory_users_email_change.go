@@ -0,0 +1,98 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	repositories_users "backend/repositories/users"
+	"context"
+	"log"
+	"time"
+)
+
+// メールアドレス変更のトークンの変更内容
+type emailChange struct {
+	userId    string
+	newEmail  string
+	expiresAt time.Time
+	usedAt    *time.Time
+}
+
+// メールアドレス変更のトークンを登録する
+// 変更を申請し直した場合は、以前に発行した未使用のトークンを無効にする。
+func (r *MemoryUserRepository) CreateEmailChange(ctx context.Context, userId, newEmail, tokenHash string, expiresAt time.Time) error {
+	log.Println("CreateEmailChange start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := validateUUID(userId); err != nil {
+		log.Printf("Failed to create email change: %v", err)
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	// 申請中の変更を無効にする
+	now := time.Now()
+	for hash, change := range r.Store.emailChanges {
+		if change.userId == userId && change.usedAt == nil {
+			change.usedAt = &now
+			r.Store.emailChanges[hash] = change
+		}
+	}
+
+	r.Store.emailChanges[tokenHash] = emailChange{userId: userId, newEmail: newEmail, expiresAt: expiresAt}
+
+	log.Println("Created email change successfully")
+	return nil
+}
+
+// メールアドレス変更のトークンを使用済みにし、ユーザーのメールアドレスを変更する
+// 未登録・使用済み・期限切れのトークンは ErrEmailChangeInvalid、変更先が他のユーザーに登録済みの場合は ErrUserEmailConflict を返す。
+func (r *MemoryUserRepository) ConfirmEmailChange(ctx context.Context, tokenHash string) (*models.UserData, error) {
+	log.Println("ConfirmEmailChange start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	now := time.Now()
+	change, ok := r.Store.emailChanges[tokenHash]
+	if !ok || change.usedAt != nil || !change.expiresAt.After(now) {
+		log.Printf("Failed to confirm email change: %v", repositories_users.ErrEmailChangeInvalid)
+		return nil, repositories_users.ErrEmailChangeInvalid
+	}
+	credentials, ok := r.Store.users[change.userId]
+	if !ok {
+		// ユーザーの削除とともにトークンも削除されたものとして扱う
+		log.Printf("Failed to confirm email change: %v", repositories_users.ErrEmailChangeInvalid)
+		return nil, repositories_users.ErrEmailChangeInvalid
+	}
+
+	// Postgresの一意制約と同様に、他のユーザーのメールアドレスには変更できない(トークンは使用済みにしない)
+	for id, other := range r.Store.users {
+		if id != change.userId && models.NormalizeEmail(other.User.Email) == models.NormalizeEmail(change.newEmail) {
+			log.Printf("Failed to confirm email change: %v", repositories_users.ErrUserEmailConflict)
+			return nil, repositories_users.ErrUserEmailConflict
+		}
+	}
+
+	change.usedAt = &now
+	r.Store.emailChanges[tokenHash] = change
+
+	// 新しいメールアドレスはリンクを開いた時点で確認済みとする
+	credentials.User.Email = change.newEmail
+	credentials.User.EmailVerifiedAt = &now
+	credentials.User.UpdatedAt = now
+	r.Store.users[change.userId] = credentials
+
+	user := credentials.User
+	log.Printf("Confirmed email change successfully: %s", user.ID)
+	return &user, nil
+}
@@ -0,0 +1,80 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	repositories_users "backend/repositories/users"
+	"context"
+	"log"
+	"time"
+)
+
+// 確認メールのトークンの確認状況
+type emailVerification struct {
+	userId    string
+	expiresAt time.Time
+	usedAt    *time.Time
+}
+
+// 確認メールのトークンを登録する
+func (r *MemoryUserRepository) CreateEmailVerification(ctx context.Context, userId, tokenHash string, expiresAt time.Time) error {
+	log.Println("CreateEmailVerification start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := validateUUID(userId); err != nil {
+		log.Printf("Failed to create email verification: %v", err)
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	r.Store.emailVerifications[tokenHash] = emailVerification{userId: userId, expiresAt: expiresAt}
+
+	log.Println("Created email verification successfully")
+	return nil
+}
+
+// 確認メールのトークンを使用済みにし、ユーザーのメールアドレスを確認済みにする
+// 未登録・使用済み・期限切れのトークンは ErrEmailVerificationInvalid を返す。
+func (r *MemoryUserRepository) VerifyEmail(ctx context.Context, tokenHash string) (*models.UserData, error) {
+	log.Println("VerifyEmail start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	now := time.Now()
+	verification, ok := r.Store.emailVerifications[tokenHash]
+	if !ok || verification.usedAt != nil || !verification.expiresAt.After(now) {
+		log.Printf("Failed to verify email: %v", repositories_users.ErrEmailVerificationInvalid)
+		return nil, repositories_users.ErrEmailVerificationInvalid
+	}
+	credentials, ok := r.Store.users[verification.userId]
+	if !ok {
+		// ユーザーの削除とともにトークンも削除されたものとして扱う
+		log.Printf("Failed to verify email: %v", repositories_users.ErrEmailVerificationInvalid)
+		return nil, repositories_users.ErrEmailVerificationInvalid
+	}
+
+	verification.usedAt = &now
+	r.Store.emailVerifications[tokenHash] = verification
+
+	// 確認済みの場合は最初に確認した日時を保持する
+	if credentials.User.EmailVerifiedAt == nil {
+		credentials.User.EmailVerifiedAt = &now
+		credentials.User.UpdatedAt = now
+		r.Store.users[credentials.User.ID] = credentials
+	}
+
+	user := credentials.User
+	log.Printf("Verified email successfully: %s", user.ID)
+	return &user, nil
+}
@@ -0,0 +1,93 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	repositories_users "backend/repositories/users"
+	"context"
+	"log"
+	"time"
+)
+
+// パスワード再設定のトークンの使用状況
+type passwordReset struct {
+	userId    string
+	expiresAt time.Time
+	usedAt    *time.Time
+}
+
+// パスワード再設定のトークンを登録する
+func (r *MemoryUserRepository) CreatePasswordReset(ctx context.Context, userId, tokenHash string, expiresAt time.Time) error {
+	log.Println("CreatePasswordReset start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := validateUUID(userId); err != nil {
+		log.Printf("Failed to create password reset: %v", err)
+		return err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	r.Store.passwordResets[tokenHash] = passwordReset{userId: userId, expiresAt: expiresAt}
+
+	log.Println("Created password reset successfully")
+	return nil
+}
+
+// パスワード再設定のトークンを使用済みにし、パスワード(ハッシュ)を更新する
+// 同じユーザーの未使用のトークンも無効にし、ログインセッションをすべて失効させる。
+// 未登録・使用済み・期限切れのトークンは ErrPasswordResetInvalid を返す。
+func (r *MemoryUserRepository) ResetPassword(ctx context.Context, tokenHash, password string) (*models.UserData, error) {
+	log.Println("ResetPassword start...")
+
+	// コンテキストがキャンセルされていないか確認
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	now := time.Now()
+	reset, ok := r.Store.passwordResets[tokenHash]
+	if !ok || reset.usedAt != nil || !reset.expiresAt.After(now) {
+		log.Printf("Failed to reset password: %v", repositories_users.ErrPasswordResetInvalid)
+		return nil, repositories_users.ErrPasswordResetInvalid
+	}
+	credentials, ok := r.Store.users[reset.userId]
+	if !ok {
+		// ユーザーの削除とともにトークンも削除されたものとして扱う
+		log.Printf("Failed to reset password: %v", repositories_users.ErrPasswordResetInvalid)
+		return nil, repositories_users.ErrPasswordResetInvalid
+	}
+
+	credentials.PasswordHash = password
+	credentials.User.UpdatedAt = now
+	r.Store.users[reset.userId] = credentials
+
+	// 使用したトークンと、再設定前に発行した他のトークンを無効にする
+	for hash, other := range r.Store.passwordResets {
+		if other.userId == reset.userId && other.usedAt == nil {
+			other.usedAt = &now
+			r.Store.passwordResets[hash] = other
+		}
+	}
+
+	// 古いパスワードでのログインセッションをすべて失効させる
+	revoked := 0
+	for hash, session := range r.Store.sessions {
+		if session.UserId == reset.userId && session.RevokedAt == nil {
+			session.RevokedAt = &now
+			r.Store.sessions[hash] = session
+			revoked++
+		}
+	}
+
+	user := credentials.User
+	log.Printf("Reset password successfully: %s (revoked %d sessions)", user.ID, revoked)
+	return &user, nil
+}
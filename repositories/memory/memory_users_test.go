@@ -0,0 +1,210 @@
+package repositories_memory
+
+import (
+	"backend/models"
+	repositories_sessions "backend/repositories/sessions"
+	repositories_users "backend/repositories/users"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepository_User_PipeLine(t *testing.T) {
+	// ストアにユーザーを登録
+	store := NewStore()
+	seeded := store.SeedUser(models.UserData{
+		Name:  "Test User",
+		Email: "test@example.com",
+	}, "password123")
+	repo := NewUserRepository(store)
+
+	// メールアドレスで資格情報を取得(パスワードは保存された値を返す)
+	credentials, err := repo.FetchUserCredentialsByEmail(context.Background(), "test@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, seeded.ID, credentials.User.ID)
+	assert.Equal(t, "password123", credentials.PasswordHash)
+
+	// 存在しないメールアドレス
+	credentials, err = repo.FetchUserCredentialsByEmail(context.Background(), "unknown@example.com")
+	assert.Error(t, err)
+	assert.Nil(t, credentials)
+
+	// IDで取得
+	user, err := repo.FetchUserById(context.Background(), seeded.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Test User", user.Name)
+	// 権限を指定しない場合は author
+	assert.Equal(t, models.UserRoleAuthor, user.Role)
+
+	// プロフィールを更新
+	user, err = repo.UpdateUserProfile(context.Background(), seeded.ID, "Updated User", "Gopher", "https://example.com/avatar.png")
+	assert.NoError(t, err)
+	assert.Equal(t, "Updated User", user.Name)
+	assert.Equal(t, "Gopher", user.Bio)
+	assert.Equal(t, "https://example.com/avatar.png", user.AvatarURL)
+
+	// メールアドレス・パスワードは変更されないこと
+	credentials, err = repo.FetchUserCredentialsByEmail(context.Background(), "test@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "Updated User", credentials.User.Name)
+	assert.Equal(t, "password123", credentials.PasswordHash)
+
+	// パスワードのみ更新
+	assert.NoError(t, repo.UpdateUserPassword(context.Background(), seeded.ID, "hashed"))
+	credentials, err = repo.FetchUserCredentialsById(context.Background(), seeded.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "hashed", credentials.PasswordHash)
+	assert.Equal(t, "Updated User", credentials.User.Name)
+
+	// 存在しないユーザー
+	assert.Error(t, repo.UpdateUserPassword(context.Background(), "00000000-0000-0000-0000-000000000000", "hashed"))
+	_, err = repo.FetchUserCredentialsById(context.Background(), "00000000-0000-0000-0000-000000000000")
+	assert.Error(t, err)
+}
+
+func TestMemoryRepository_FetchUserById_InvalidId(t *testing.T) {
+	// リポジトリのインスタンスを作成
+	repo := NewUserRepository(NewStore())
+
+	// メソッドを実行
+	user, err := repo.FetchUserById(context.Background(), "")
+
+	// エラーチェックとデータ確認
+	assert.Error(t, err)
+	assert.Nil(t, user)
+}
+
+func TestMemoryRepository_CreateUser(t *testing.T) {
+	repo := NewUserRepository(NewStore())
+
+	// 登録直後は author で、メールアドレスは未確認
+	user, err := repo.CreateUser(context.Background(), "New User", "new@example.com", "hashed")
+	assert.NoError(t, err)
+	assert.Equal(t, models.UserRoleAuthor, user.Role)
+	assert.Nil(t, user.EmailVerifiedAt)
+
+	credentials, err := repo.FetchUserCredentialsByEmail(context.Background(), "new@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, credentials.User.ID)
+	assert.Equal(t, "hashed", credentials.PasswordHash)
+
+	// 登録済みのメールアドレス(大文字・小文字は区別しない)
+	_, err = repo.CreateUser(context.Background(), "Other User", "New@Example.com", "hashed")
+	assert.ErrorIs(t, err, repositories_users.ErrUserEmailConflict)
+
+	credentials, err = repo.FetchUserCredentialsByEmail(context.Background(), "NEW@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, credentials.User.ID)
+}
+
+func TestMemoryRepository_VerifyEmail(t *testing.T) {
+	repo := NewUserRepository(NewStore())
+	user, err := repo.CreateUser(context.Background(), "New User", "new@example.com", "hashed")
+	assert.NoError(t, err)
+
+	// 期限切れのトークン
+	assert.NoError(t, repo.CreateEmailVerification(context.Background(), user.ID, "expired", time.Now().Add(-time.Minute)))
+	_, err = repo.VerifyEmail(context.Background(), "expired")
+	assert.ErrorIs(t, err, repositories_users.ErrEmailVerificationInvalid)
+
+	// 未登録のトークン
+	_, err = repo.VerifyEmail(context.Background(), "unknown")
+	assert.ErrorIs(t, err, repositories_users.ErrEmailVerificationInvalid)
+
+	// 有効なトークンで確認済みになる
+	assert.NoError(t, repo.CreateEmailVerification(context.Background(), user.ID, "valid", time.Now().Add(time.Hour)))
+	verified, err := repo.VerifyEmail(context.Background(), "valid")
+	assert.NoError(t, err)
+	assert.NotNil(t, verified.EmailVerifiedAt)
+
+	fetched, err := repo.FetchUserById(context.Background(), user.ID)
+	assert.NoError(t, err)
+	assert.True(t, fetched.EmailVerified())
+
+	// 使用済みのトークンは使えない
+	_, err = repo.VerifyEmail(context.Background(), "valid")
+	assert.ErrorIs(t, err, repositories_users.ErrEmailVerificationInvalid)
+}
+
+func TestMemoryRepository_ResetPassword(t *testing.T) {
+	store := NewStore()
+	repo := NewUserRepository(store)
+	sessionRepo := NewSessionRepository(store)
+	user, err := repo.CreateUser(context.Background(), "New User", "new@example.com", "old-hash")
+	assert.NoError(t, err)
+
+	// 再設定前のログインセッション
+	_, err = sessionRepo.CreateSession(context.Background(), user.ID, "session", time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	// 期限切れのトークン
+	assert.NoError(t, repo.CreatePasswordReset(context.Background(), user.ID, "expired", time.Now().Add(-time.Minute)))
+	_, err = repo.ResetPassword(context.Background(), "expired", "new-hash")
+	assert.ErrorIs(t, err, repositories_users.ErrPasswordResetInvalid)
+
+	// 未登録のトークン
+	_, err = repo.ResetPassword(context.Background(), "unknown", "new-hash")
+	assert.ErrorIs(t, err, repositories_users.ErrPasswordResetInvalid)
+
+	// 有効なトークンでパスワードが更新される
+	assert.NoError(t, repo.CreatePasswordReset(context.Background(), user.ID, "first", time.Now().Add(time.Hour)))
+	assert.NoError(t, repo.CreatePasswordReset(context.Background(), user.ID, "second", time.Now().Add(time.Hour)))
+	reset, err := repo.ResetPassword(context.Background(), "second", "new-hash")
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, reset.ID)
+
+	credentials, err := repo.FetchUserCredentialsById(context.Background(), user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "new-hash", credentials.PasswordHash)
+
+	// 使用済みのトークン・再設定前に発行した他のトークンは使えない
+	_, err = repo.ResetPassword(context.Background(), "second", "other-hash")
+	assert.ErrorIs(t, err, repositories_users.ErrPasswordResetInvalid)
+	_, err = repo.ResetPassword(context.Background(), "first", "other-hash")
+	assert.ErrorIs(t, err, repositories_users.ErrPasswordResetInvalid)
+
+	// 再設定前のログインセッションは失効している
+	_, err = sessionRepo.RotateSession(context.Background(), "session", "rotated", time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, repositories_sessions.ErrSessionRevoked)
+}
+
+func TestMemoryRepository_ConfirmEmailChange(t *testing.T) {
+	repo := NewUserRepository(NewStore())
+	user, err := repo.CreateUser(context.Background(), "New User", "new@example.com", "hashed")
+	assert.NoError(t, err)
+	other, err := repo.CreateUser(context.Background(), "Other User", "other@example.com", "hashed")
+	assert.NoError(t, err)
+
+	// 期限切れのトークン
+	assert.NoError(t, repo.CreateEmailChange(context.Background(), user.ID, "changed@example.com", "expired", time.Now().Add(-time.Minute)))
+	_, err = repo.ConfirmEmailChange(context.Background(), "expired")
+	assert.ErrorIs(t, err, repositories_users.ErrEmailChangeInvalid)
+
+	// 申請し直すと以前のトークンは使えない
+	assert.NoError(t, repo.CreateEmailChange(context.Background(), user.ID, "first@example.com", "first", time.Now().Add(time.Hour)))
+	assert.NoError(t, repo.CreateEmailChange(context.Background(), user.ID, "changed@example.com", "second", time.Now().Add(time.Hour)))
+	_, err = repo.ConfirmEmailChange(context.Background(), "first")
+	assert.ErrorIs(t, err, repositories_users.ErrEmailChangeInvalid)
+
+	// 確認するまではメールアドレスは変わらない
+	fetched, err := repo.FetchUserById(context.Background(), user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "new@example.com", fetched.Email)
+
+	// 有効なトークンで変更され、確認済みになる
+	changed, err := repo.ConfirmEmailChange(context.Background(), "second")
+	assert.NoError(t, err)
+	assert.Equal(t, "changed@example.com", changed.Email)
+	assert.True(t, changed.EmailVerified())
+
+	// 使用済みのトークンは使えない
+	_, err = repo.ConfirmEmailChange(context.Background(), "second")
+	assert.ErrorIs(t, err, repositories_users.ErrEmailChangeInvalid)
+
+	// 他のユーザーのメールアドレスには変更できない
+	assert.NoError(t, repo.CreateEmailChange(context.Background(), other.ID, "changed@example.com", "conflict", time.Now().Add(time.Hour)))
+	_, err = repo.ConfirmEmailChange(context.Background(), "conflict")
+	assert.ErrorIs(t, err, repositories_users.ErrUserEmailConflict)
+}
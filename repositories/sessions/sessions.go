@@ -0,0 +1,179 @@
+package repositories_sessions
+
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// 新しい系列のセッションを作成する(ログイン時)
+// expiresAt は系列の有効期限にもなり、以降の交換で延長されない。
+func (r *SessionRepositoryImpl) CreateSession(ctx context.Context, userId, tokenHash string, expiresAt time.Time) (*models.SessionData, error) {
+	log.Println("CreateSession start...")
+
+	query := `
+		INSERT INTO sessions (user_id, family_id, token_hash, expires_at, family_expires_at)
+		VALUES ($1, gen_random_uuid(), $2, $3, $3)
+		RETURNING id, user_id, family_id, token_hash, expires_at, family_expires_at, rotated_at, revoked_at, created_at
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、セッションを作成
+	session, err := scanSession(r.DB.QueryRow(ctx, query, userId, tokenHash, expiresAt))
+	if err != nil {
+		log.Printf("Failed to create session: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Created session: %s", session.ID)
+	return session, nil
+}
+
+// リフレッシュトークンを交換し、同じ系列の新しいセッションを作成する
+// 交換済みのトークンが再び使われた場合は盗用とみなし、系列のセッションをすべて失効させて ErrSessionReused を返す。
+// 同じトークンでの同時の交換は行ロックで直列化し、片方のみ成功させる。
+// 新しいセッションの有効期限は expiresAt と系列の有効期限のうち早い方とする。
+func (r *SessionRepositoryImpl) RotateSession(ctx context.Context, tokenHash, newTokenHash string, expiresAt time.Time) (*models.SessionData, error) {
+	log.Println("RotateSession start...")
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// 交換元のセッションをロックして取得
+	current, err := scanSession(tx.QueryRow(ctx, `
+		SELECT id, user_id, family_id, token_hash, expires_at, family_expires_at, rotated_at, revoked_at, created_at
+		FROM sessions
+		WHERE token_hash = $1
+		FOR UPDATE
+	`, tokenHash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("Failed to rotate session: %v", ErrSessionNotFound)
+			return nil, ErrSessionNotFound
+		}
+		log.Printf("Failed to fetch session: %v", err)
+		return nil, err
+	}
+
+	switch {
+	case current.RevokedAt != nil:
+		log.Printf("Failed to rotate session: %v", ErrSessionRevoked)
+		return nil, ErrSessionRevoked
+
+	case current.RotatedAt != nil:
+		// 交換済みのトークンの再利用(盗用の疑い)のため、系列をすべて失効させる
+		if _, err := tx.Exec(ctx, `
+			UPDATE sessions
+			SET revoked_at = now()
+			WHERE family_id = $1 AND revoked_at IS NULL
+		`, current.FamilyId); err != nil {
+			log.Printf("Failed to revoke session family: %v", err)
+			return nil, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			log.Printf("Failed to commit transaction: %v", err)
+			return nil, err
+		}
+		log.Printf("Revoked session family %s: %v", current.FamilyId, ErrSessionReused)
+		return nil, ErrSessionReused
+
+	case !current.ExpiresAt.After(time.Now()), !current.FamilyExpiresAt.After(time.Now()):
+		log.Printf("Failed to rotate session: %v", ErrSessionExpired)
+		return nil, ErrSessionExpired
+	}
+
+	// 系列の有効期限を超えないようにする
+	if expiresAt.After(current.FamilyExpiresAt) {
+		expiresAt = current.FamilyExpiresAt
+	}
+
+	// 交換元を交換済みにし、同じ系列で新しいセッションを作成
+	if _, err := tx.Exec(ctx, `UPDATE sessions SET rotated_at = now() WHERE id = $1`, current.ID); err != nil {
+		log.Printf("Failed to rotate session: %v", err)
+		return nil, err
+	}
+	session, err := scanSession(tx.QueryRow(ctx, `
+		INSERT INTO sessions (user_id, family_id, token_hash, expires_at, family_expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, user_id, family_id, token_hash, expires_at, family_expires_at, rotated_at, revoked_at, created_at
+	`, current.UserId, current.FamilyId, newTokenHash, expiresAt, current.FamilyExpiresAt))
+	if err != nil {
+		log.Printf("Failed to create session: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Rotated session: %s", session.ID)
+	return session, nil
+}
+
+// 指定されたリフレッシュトークンの系列のセッションをすべて失効させる(ログアウト時)
+// トークンに一致するセッションがない場合は ErrSessionNotFound を返す。
+func (r *SessionRepositoryImpl) RevokeSession(ctx context.Context, tokenHash string) error {
+	log.Println("RevokeSession start...")
+
+	query := `
+		UPDATE sessions
+		SET revoked_at = now()
+		WHERE family_id = (SELECT family_id FROM sessions WHERE token_hash = $1)
+			AND revoked_at IS NULL
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、セッションを失効
+	result, err := r.DB.Exec(ctx, query, tokenHash)
+	if err != nil {
+		log.Printf("Failed to revoke session: %v", err)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		log.Printf("Failed to revoke session: %v", ErrSessionNotFound)
+		return ErrSessionNotFound
+	}
+
+	log.Printf("Revoked %d sessions", result.RowsAffected())
+	return nil
+}
+
+// セッションをスキャンする
+func scanSession(row pgx.Row) (*models.SessionData, error) {
+	var session models.SessionData
+	err := row.Scan(
+		&session.ID,
+		&session.UserId,
+		&session.FamilyId,
+		&session.TokenHash,
+		&session.ExpiresAt,
+		&session.FamilyExpiresAt,
+		&session.RotatedAt,
+		&session.RevokedAt,
+		&session.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
@@ -0,0 +1,39 @@
+package repositories_sessions
+
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"errors"
+	"time"
+)
+
+// セッションが見つからない場合のエラー
+var ErrSessionNotFound = errors.New("session not found")
+
+// セッションの有効期限が切れている場合のエラー
+var ErrSessionExpired = errors.New("session expired")
+
+// セッションが失効している場合のエラー
+var ErrSessionRevoked = errors.New("session revoked")
+
+// 交換済みのリフレッシュトークンが再利用された場合のエラー(同じ系列のセッションはすべて失効させる)
+var ErrSessionReused = errors.New("session token reused")
+
+// SessionRepositoryインターフェース
+type SessionRepository interface {
+	CreateSession(ctx context.Context, userId, tokenHash string, expiresAt time.Time) (*models.SessionData, error)
+	RotateSession(ctx context.Context, tokenHash, newTokenHash string, expiresAt time.Time) (*models.SessionData, error)
+	RevokeSession(ctx context.Context, tokenHash string) error
+}
+
+type SessionRepositoryImpl struct {
+	DB supabase.DB
+}
+
+// SessionRepositoryインターフェースを実装したSessionRepositoryImplのポインタを返す
+func NewSessionRepository(db supabase.DB) SessionRepository {
+	return &SessionRepositoryImpl{
+		DB: db,
+	}
+}
@@ -0,0 +1,34 @@
+package repositories_sessions
+
+import (
+	"backend/models"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepository) CreateSession(ctx context.Context, userId, tokenHash string, expiresAt time.Time) (*models.SessionData, error) {
+	args := m.Called(userId, tokenHash, expiresAt)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.SessionData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSessionRepository) RotateSession(ctx context.Context, tokenHash, newTokenHash string, expiresAt time.Time) (*models.SessionData, error) {
+	args := m.Called(tokenHash, newTokenHash, expiresAt)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.SessionData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockSessionRepository) RevokeSession(ctx context.Context, tokenHash string) error {
+	args := m.Called(tokenHash)
+	return args.Error(0)
+}
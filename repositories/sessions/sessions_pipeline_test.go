@@ -0,0 +1,78 @@
+package repositories_sessions
+
+import (
+	"backend/supabase"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_Session_PipeLine(t *testing.T) {
+	// Supabaseクライアントの初期化
+	setupSupabase(t)
+
+	// リポジトリのインスタンスを作成
+	repo := NewSessionRepository(supabase.Pool)
+	ctx := context.Background()
+	userId := os.Getenv("TEST_USER_ID")
+	expiresAt := time.Now().Add(time.Hour)
+
+	// ---------------------------------------------------------
+	// 1. セッションを作成
+	// ---------------------------------------------------------
+	first := uuid.New().String()
+	session, err := repo.CreateSession(ctx, userId, first, expiresAt)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+	assert.Equal(t, userId, session.UserId)
+
+	// ---------------------------------------------------------
+	// 2. トークンを交換(同じ系列を引き継ぐ)
+	// ---------------------------------------------------------
+	second := uuid.New().String()
+	rotated, err := repo.RotateSession(ctx, first, second, expiresAt.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, session.FamilyId, rotated.FamilyId)
+	// 有効期限はログイン時の系列の有効期限を超えない
+	assert.WithinDuration(t, session.FamilyExpiresAt, rotated.ExpiresAt, time.Millisecond)
+	assert.WithinDuration(t, session.FamilyExpiresAt, rotated.FamilyExpiresAt, time.Millisecond)
+
+	// ---------------------------------------------------------
+	// 3. 交換済みのトークンを再利用すると系列ごと失効する
+	// ---------------------------------------------------------
+	_, err = repo.RotateSession(ctx, first, uuid.New().String(), expiresAt)
+	assert.ErrorIs(t, err, ErrSessionReused)
+	_, err = repo.RotateSession(ctx, second, uuid.New().String(), expiresAt)
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+
+	// ---------------------------------------------------------
+	// 4. ログアウトで系列を失効
+	// ---------------------------------------------------------
+	third := uuid.New().String()
+	_, err = repo.CreateSession(ctx, userId, third, expiresAt)
+	assert.NoError(t, err)
+	assert.NoError(t, repo.RevokeSession(ctx, third))
+	_, err = repo.RotateSession(ctx, third, uuid.New().String(), expiresAt)
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+
+	// ---------------------------------------------------------
+	// 5. 系列の有効期限を過ぎると交換できない
+	// ---------------------------------------------------------
+	fourth := uuid.New().String()
+	_, err = repo.CreateSession(ctx, userId, fourth, time.Now().Add(200*time.Millisecond))
+	assert.NoError(t, err)
+	fifth := uuid.New().String()
+	_, err = repo.RotateSession(ctx, fourth, fifth, expiresAt)
+	assert.NoError(t, err)
+	time.Sleep(300 * time.Millisecond)
+	_, err = repo.RotateSession(ctx, fifth, uuid.New().String(), expiresAt)
+	assert.ErrorIs(t, err, ErrSessionExpired)
+
+	// 存在しないトークン
+	assert.ErrorIs(t, repo.RevokeSession(ctx, uuid.New().String()), ErrSessionNotFound)
+}
@@ -0,0 +1,361 @@
+package repositories_tags
+
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// タグ情報(使用ブログ数・別名を含む)を取得するクエリ
+const selectTagsQuery = `
+	SELECT t.id, t.name, COUNT(bt.blog_id) AS count,
+			COALESCE((
+				SELECT array_agg(a.alias ORDER BY lower(a.alias))
+				FROM tag_aliases a
+				WHERE a.tag_id = t.id
+			), '{}'::text[]) AS aliases,
+			t.created_at, t.updated_at
+	FROM tags t
+	LEFT JOIN (
+		blog_tags bt
+		JOIN blogs b ON b.id = bt.blog_id AND b.deleted_at IS NULL AND b.status = 'published'
+	) ON bt.tag_id = t.id
+`
+
+// タグ情報をスキャンする
+func scanTag(row pgx.Row) (*models.TagData, error) {
+	var tag models.TagData
+	err := row.Scan(
+		&tag.ID,
+		&tag.Name,
+		&tag.Count,
+		&tag.Aliases,
+		&tag.CreatedAt,
+		&tag.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// 指定されたIDのタグ情報を取得する
+func fetchTag(ctx context.Context, db supabase.DB, id string) (*models.TagData, error) {
+	query := selectTagsQuery + `
+		WHERE t.id = $1
+		GROUP BY t.id
+	`
+	return scanTag(db.QueryRow(ctx, query, id))
+}
+
+// 指定されたIDのタグを更新用にロックする
+func lockTag(ctx context.Context, tx pgx.Tx, id string) error {
+	var lockedId string
+	return tx.QueryRow(ctx, `SELECT id FROM tags WHERE id = $1 FOR UPDATE`, id).Scan(&lockedId)
+}
+
+// 全タグ情報を取得する
+func (r *TagRepositoryImpl) FetchTags(ctx context.Context) ([]models.TagData, error) {
+	log.Printf("FetchTags start...")
+
+	query := selectTagsQuery + `
+		GROUP BY t.id
+		ORDER BY lower(t.name)
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、全データ取得
+	rows, err := r.DB.Query(ctx, query)
+	if err != nil {
+		log.Printf("Failed to fetch tags: %v", err)
+		return nil, err
+	}
+	log.Println("Fetched tags successfully")
+	defer rows.Close()
+
+	var tags []models.TagData
+
+	// 結果をスキャンしてタグデータをリストに追加
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			log.Printf("Failed to scan tag: %v", err)
+			return nil, err
+		}
+		tags = append(tags, *tag)
+	}
+
+	if rows.Err() != nil {
+		log.Printf("Failed to fetch tags: %v", rows.Err())
+		return nil, rows.Err()
+	}
+
+	log.Printf("Fetched tags: %v", tags)
+	return tags, nil
+}
+
+// 指定されたIDに一致するタグ情報を取得する
+func (r *TagRepositoryImpl) FetchTagById(ctx context.Context, id string) (*models.TagData, error) {
+	log.Printf("FetchTagById start...")
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、条件に一致するデータを取得
+	tag, err := fetchTag(ctx, r.DB, id)
+	if err != nil {
+		log.Printf("Failed to fetch tag: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Fetched tag: %v", tag)
+	return tag, nil
+}
+
+// タグ名を変更する
+// 変更後の名前が他のタグ名・別名と重複する場合は ErrTagConflict を返す。
+// このタグ自身の別名と一致する場合は、その別名を削除する。
+func (r *TagRepositoryImpl) RenameTag(ctx context.Context, id, name string) (*models.TagData, error) {
+	log.Printf("RenameTag start...")
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockTag(ctx, tx, id); err != nil {
+		log.Printf("Failed to lock tag: %v", err)
+		return nil, err
+	}
+
+	// 別名との重複を確認
+	var aliasTagId string
+	err = tx.QueryRow(ctx, `SELECT tag_id FROM tag_aliases WHERE lower(alias) = lower($1)`, name).Scan(&aliasTagId)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case err != nil:
+		log.Printf("Failed to fetch tag alias: %v", err)
+		return nil, err
+	case aliasTagId != id:
+		log.Printf("Failed to rename tag: %v", ErrTagConflict)
+		return nil, ErrTagConflict
+	default:
+		if _, err := tx.Exec(ctx, `DELETE FROM tag_aliases WHERE lower(alias) = lower($1)`, name); err != nil {
+			log.Printf("Failed to delete tag alias: %v", err)
+			return nil, err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE tags SET name = $2 WHERE id = $1`, id, name); err != nil {
+		log.Printf("Failed to rename tag: %v", err)
+		if isUniqueViolation(err) {
+			return nil, ErrTagConflict
+		}
+		return nil, err
+	}
+
+	// タグを使用しているブログのタグ文字列を更新
+	if err := refreshBlogTagStrings(ctx, tx, id); err != nil {
+		log.Printf("Failed to refresh blog tags: %v", err)
+		return nil, err
+	}
+
+	tag, err := fetchTag(ctx, tx, id)
+	if err != nil {
+		log.Printf("Failed to fetch tag: %v", err)
+		return nil, err
+	}
+
+	// コミット時に他のインスタンスへ変更を通知する
+	supabase.NotifyChange(ctx, tx, supabase.ChangeTags)
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Renamed tag: %v", tag)
+	return tag, nil
+}
+
+// タグを統合する
+// 統合元のタグが付いたブログを統合先のタグに付け替え、統合元のタグ名と別名を統合先の別名にして統合元を削除する。
+func (r *TagRepositoryImpl) MergeTags(ctx context.Context, sourceId, targetId string) (*models.TagData, error) {
+	log.Printf("MergeTags start...")
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// デッドロックを避けるため、IDの順にロックする
+	ids := []string{sourceId, targetId}
+	if targetId < sourceId {
+		ids = []string{targetId, sourceId}
+	}
+	for _, id := range ids {
+		if err := lockTag(ctx, tx, id); err != nil {
+			log.Printf("Failed to lock tag: %v", err)
+			return nil, err
+		}
+	}
+
+	statements := []struct {
+		query string
+		args  []interface{}
+	}{
+		// ブログの付け替え(既に統合先が付いている場合はそのまま)
+		{`
+			INSERT INTO blog_tags (blog_id, tag_id, position)
+			SELECT blog_id, $2, position FROM blog_tags WHERE tag_id = $1
+			ON CONFLICT DO NOTHING
+		`, []interface{}{sourceId, targetId}},
+		// 別名の付け替え
+		{`UPDATE tag_aliases SET tag_id = $2 WHERE tag_id = $1`, []interface{}{sourceId, targetId}},
+		// 統合元のタグ名を別名として登録
+		{`
+			INSERT INTO tag_aliases (alias, tag_id)
+			SELECT name, $2 FROM tags WHERE id = $1
+			ON CONFLICT DO NOTHING
+		`, []interface{}{sourceId, targetId}},
+		// 統合元のタグを削除(blog_tags はカスケードで削除される)
+		{`DELETE FROM tags WHERE id = $1`, []interface{}{sourceId}},
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt.query, stmt.args...); err != nil {
+			log.Printf("Failed to merge tags: %v", err)
+			return nil, err
+		}
+	}
+
+	// 統合先のタグを使用しているブログのタグ文字列を更新
+	if err := refreshBlogTagStrings(ctx, tx, targetId); err != nil {
+		log.Printf("Failed to refresh blog tags: %v", err)
+		return nil, err
+	}
+
+	tag, err := fetchTag(ctx, tx, targetId)
+	if err != nil {
+		log.Printf("Failed to fetch tag: %v", err)
+		return nil, err
+	}
+
+	// コミット時に他のインスタンスへ変更を通知する
+	supabase.NotifyChange(ctx, tx, supabase.ChangeTags)
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Merged tags: %v", tag)
+	return tag, nil
+}
+
+// タグに別名を追加する
+// 別名が既存のタグ名・別名と重複する場合は ErrTagConflict を返す。
+func (r *TagRepositoryImpl) CreateTagAlias(ctx context.Context, tagId, alias string) (*models.TagData, error) {
+	log.Printf("CreateTagAlias start...")
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockTag(ctx, tx, tagId); err != nil {
+		log.Printf("Failed to lock tag: %v", err)
+		return nil, err
+	}
+
+	// タグ名との重複を確認
+	var exists bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM tags WHERE lower(name) = lower($1))`, alias).Scan(&exists)
+	if err != nil {
+		log.Printf("Failed to check tag name: %v", err)
+		return nil, err
+	}
+	if exists {
+		log.Printf("Failed to create tag alias: %v", ErrTagConflict)
+		return nil, ErrTagConflict
+	}
+
+	if _, err := tx.Exec(ctx, `INSERT INTO tag_aliases (alias, tag_id) VALUES ($1, $2)`, alias, tagId); err != nil {
+		log.Printf("Failed to create tag alias: %v", err)
+		if isUniqueViolation(err) {
+			return nil, ErrTagConflict
+		}
+		return nil, err
+	}
+
+	tag, err := fetchTag(ctx, tx, tagId)
+	if err != nil {
+		log.Printf("Failed to fetch tag: %v", err)
+		return nil, err
+	}
+
+	// コミット時に他のインスタンスへ変更を通知する
+	supabase.NotifyChange(ctx, tx, supabase.ChangeTags)
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Created tag alias: %v", tag)
+	return tag, nil
+}
+
+// タグの別名を削除する
+func (r *TagRepositoryImpl) DeleteTagAlias(ctx context.Context, alias string) error {
+	log.Printf("DeleteTagAlias start...")
+
+	query := `
+		DELETE FROM tag_aliases
+		WHERE lower(alias) = lower($1)
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、別名を削除
+	result, err := r.DB.Exec(ctx, query, alias)
+	if err != nil {
+		log.Printf("Failed to delete tag alias: %v", err)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		log.Printf("Failed to delete tag alias: %v", pgx.ErrNoRows)
+		return pgx.ErrNoRows
+	}
+	supabase.NotifyChange(ctx, r.DB, supabase.ChangeTags)
+
+	log.Printf("Deleted tag alias: %s", alias)
+	return nil
+}
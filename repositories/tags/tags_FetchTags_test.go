@@ -0,0 +1,46 @@
+package repositories_tags
+
+import (
+	"backend/supabase"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_FetchTags(t *testing.T) {
+	// Supabaseクライアントの初期化
+	setupSupabase(t)
+
+	// リポジトリのインスタンスを作成
+	repo := NewTagRepository(supabase.Pool)
+
+	// メソッドを実行
+	tags, err := repo.FetchTags(context.Background())
+
+	// エラーチェックとデータ確認
+	assert.NoError(t, err)
+	assert.NotNil(t, tags)
+
+	// 取得したタグをIDで取得できること
+	tag, err := repo.FetchTagById(context.Background(), tags[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, tags[0].Name, tag.Name)
+	assert.Equal(t, tags[0].Count, tag.Count)
+}
+
+func TestRepository_FetchTagById_NotFound(t *testing.T) {
+	// Supabaseクライアントの初期化
+	setupSupabase(t)
+
+	// リポジトリのインスタンスを作成
+	repo := NewTagRepository(supabase.Pool)
+
+	// 存在しないIDで取得
+	tag, err := repo.FetchTagById(context.Background(), "00000000-0000-0000-0000-000000000000")
+
+	// エラーチェックとデータ確認
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Nil(t, tag)
+}
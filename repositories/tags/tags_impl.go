@@ -0,0 +1,30 @@
+package repositories_tags
+
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+)
+
+// TagRepositoryインターフェース
+type TagRepository interface {
+	FetchTags(ctx context.Context) ([]models.TagData, error)
+	FetchTagById(ctx context.Context, id string) (*models.TagData, error)
+
+	RenameTag(ctx context.Context, id, name string) (*models.TagData, error)
+	MergeTags(ctx context.Context, sourceId, targetId string) (*models.TagData, error)
+
+	CreateTagAlias(ctx context.Context, tagId, alias string) (*models.TagData, error)
+	DeleteTagAlias(ctx context.Context, alias string) error
+}
+
+type TagRepositoryImpl struct {
+	DB supabase.DB
+}
+
+// TagRepositoryインターフェースを実装したTagRepositoryImplのポインタを返す
+func NewTagRepository(db supabase.DB) TagRepository {
+	return &TagRepositoryImpl{
+		DB: db,
+	}
+}
@@ -0,0 +1,57 @@
+package repositories_tags
+
+import (
+	"backend/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) FetchTags(ctx context.Context) ([]models.TagData, error) {
+	args := m.Called()
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.TagData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTagRepository) FetchTagById(ctx context.Context, id string) (*models.TagData, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.TagData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTagRepository) RenameTag(ctx context.Context, id, name string) (*models.TagData, error) {
+	args := m.Called(id, name)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.TagData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTagRepository) MergeTags(ctx context.Context, sourceId, targetId string) (*models.TagData, error) {
+	args := m.Called(sourceId, targetId)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.TagData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTagRepository) CreateTagAlias(ctx context.Context, tagId, alias string) (*models.TagData, error) {
+	args := m.Called(tagId, alias)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.TagData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTagRepository) DeleteTagAlias(ctx context.Context, alias string) error {
+	args := m.Called(alias)
+	return args.Error(0)
+}
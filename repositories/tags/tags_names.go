@@ -0,0 +1,135 @@
+package repositories_tags
+
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// タグ名または別名が既に使われている場合のエラー
+var ErrTagConflict = errors.New("tag name already exists")
+
+// カンマ区切りのタグ文字列を分割し、前後の空白を除いたタグ名を返す
+func SplitTagNames(tags string) []string {
+	var names []string
+	for _, name := range strings.Split(tags, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// タグ名をカンマ区切りの文字列に連結する
+// blogs.tags(models.BlogData.Tags)の互換のため、正規のタグ名をこの形式で保持する。
+func JoinTagNames(names []string) string {
+	return strings.Join(names, ", ")
+}
+
+// 一意制約違反か判定する
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// タグ名または別名から正規のタグを取得する(大文字小文字を区別しない)
+func findTagByName(ctx context.Context, db supabase.DB, name string) (*models.TagData, error) {
+	query := `
+		SELECT t.id, t.name
+		FROM tags t
+		WHERE lower(t.name) = lower($1)
+		UNION ALL
+		SELECT t.id, t.name
+		FROM tag_aliases a
+		JOIN tags t ON t.id = a.tag_id
+		WHERE lower(a.alias) = lower($1)
+		LIMIT 1
+	`
+
+	var tag models.TagData
+	err := db.QueryRow(ctx, query, name).Scan(&tag.ID, &tag.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// タグ名を正規のタグに解決する
+// 別名は正規のタグに置き換え、存在しないタグは新規作成する。同じタグに解決された場合は先頭のみ残す。
+// 呼び出し側でトランザクションを開始し、tx を渡すこと。
+func ResolveTags(ctx context.Context, tx supabase.DB, names []string) ([]models.TagData, error) {
+	var tags []models.TagData
+	seen := make(map[string]bool)
+
+	for _, name := range names {
+		tag, err := findTagByName(ctx, tx, name)
+		if errors.Is(err, pgx.ErrNoRows) {
+			// 同時に作成された場合に備え、競合時は作成済みのタグを再取得する
+			_, err = tx.Exec(ctx, `INSERT INTO tags (name) VALUES ($1) ON CONFLICT DO NOTHING`, name)
+			if err != nil {
+				return nil, err
+			}
+			tag, err = findTagByName(ctx, tx, name)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[tag.ID] {
+			continue
+		}
+		seen[tag.ID] = true
+		tags = append(tags, *tag)
+	}
+	return tags, nil
+}
+
+// ブログに紐づくタグを置き換える
+// 呼び出し側でトランザクションを開始し、tx を渡すこと。
+func ReplaceBlogTags(ctx context.Context, tx supabase.DB, blogId string, tags []models.TagData) error {
+	_, err := tx.Exec(ctx, `DELETE FROM blog_tags WHERE blog_id = $1`, blogId)
+	if err != nil {
+		return err
+	}
+
+	for i, tag := range tags {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO blog_tags (blog_id, tag_id, position)
+			VALUES ($1, $2, $3)
+		`, blogId, tag.ID, i+1)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// タグを使用しているブログの blogs.tags を blog_tags から再構築する
+func refreshBlogTagStrings(ctx context.Context, tx supabase.DB, tagId string) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE blogs b
+		SET tags = COALESCE((
+			SELECT string_agg(t.name, ', ' ORDER BY bt.position)
+			FROM blog_tags bt
+			JOIN tags t ON t.id = bt.tag_id
+			WHERE bt.blog_id = b.id
+		), '')
+		WHERE b.id IN (SELECT blog_id FROM blog_tags WHERE tag_id = $1)
+	`, tagId)
+	return err
+}
+
+// 解決したタグの名前をカンマ区切りの文字列に連結する
+func JoinTags(tags []models.TagData) string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return JoinTagNames(names)
+}
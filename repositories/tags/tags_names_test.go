@@ -0,0 +1,18 @@
+package repositories_tags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitTagNames(t *testing.T) {
+	// 前後の空白と空要素を除いて分割すること
+	assert.Equal(t, []string{"Go", "Echo", "Next.js"}, SplitTagNames(" Go,Echo , ,Next.js,"))
+	assert.Nil(t, SplitTagNames(""))
+}
+
+func TestJoinTagNames(t *testing.T) {
+	assert.Equal(t, "Go, Echo", JoinTagNames([]string{"Go", "Echo"}))
+	assert.Equal(t, "", JoinTagNames(nil))
+}
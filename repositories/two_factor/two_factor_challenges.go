@@ -0,0 +1,109 @@
+package repositories_two_factor
+
+import (
+	"backend/supabase"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// ログインのチャレンジを登録する
+// 同じユーザーの完了済み・期限切れのチャレンジはあわせて削除する。
+func (r *TwoFactorRepositoryImpl) CreateChallenge(ctx context.Context, userId, tokenHash string, expiresAt time.Time) error {
+	log.Println("CreateChallenge start...")
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM two_factor_challenges
+		WHERE user_id = $1 AND (used_at IS NOT NULL OR expires_at <= now())
+	`, userId); err != nil {
+		log.Printf("Failed to delete expired challenges: %v", err)
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO two_factor_challenges (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+	`, userId, tokenHash, expiresAt); err != nil {
+		log.Printf("Failed to create challenge: %v", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Failed to commit transaction: %v", err)
+		return err
+	}
+
+	log.Println("Created challenge successfully")
+	return nil
+}
+
+// チャレンジの試行回数を1増やし、ユーザーIDを返す
+// 未登録・完了済み・期限切れ・試行回数が上限に達したチャレンジは ErrChallengeInvalid を返す。
+func (r *TwoFactorRepositoryImpl) AttemptChallenge(ctx context.Context, tokenHash string, maxAttempts int) (string, error) {
+	log.Println("AttemptChallenge start...")
+
+	query := `
+		UPDATE two_factor_challenges
+		SET attempts = attempts + 1
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now() AND attempts < $2
+		RETURNING user_id
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、試行回数を増やす
+	var userId string
+	if err := r.DB.QueryRow(ctx, query, tokenHash, maxAttempts).Scan(&userId); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("Failed to attempt challenge: %v", ErrChallengeInvalid)
+			return "", ErrChallengeInvalid
+		}
+		log.Printf("Failed to attempt challenge: %v", err)
+		return "", err
+	}
+	return userId, nil
+}
+
+// チャレンジを完了済みにする(ログインの完了時)
+// 完了済みのチャレンジは ErrChallengeInvalid を返す(同時に完了しようとした場合も片方のみ成功させる)。
+func (r *TwoFactorRepositoryImpl) CompleteChallenge(ctx context.Context, tokenHash string) error {
+	log.Println("CompleteChallenge start...")
+
+	query := `
+		UPDATE two_factor_challenges
+		SET used_at = now()
+		WHERE token_hash = $1 AND used_at IS NULL
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、チャレンジを完了済みにする
+	result, err := r.DB.Exec(ctx, query, tokenHash)
+	if err != nil {
+		log.Printf("Failed to complete challenge: %v", err)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		log.Printf("Failed to complete challenge: %v", ErrChallengeInvalid)
+		return ErrChallengeInvalid
+	}
+	return nil
+}
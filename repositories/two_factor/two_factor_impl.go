@@ -0,0 +1,49 @@
+package repositories_two_factor
+
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"errors"
+	"time"
+)
+
+// 2要素認証が有効なユーザーが登録をやり直そうとした場合のエラー
+var ErrTOTPAlreadyEnabled = errors.New("totp already enabled")
+
+// 確認待ちの登録がない(または登録がやり直された)場合のエラー
+var ErrTOTPNotPending = errors.New("totp not pending")
+
+// 使用済みの時間ステップのコードが再び使われた場合のエラー
+var ErrTOTPStepUsed = errors.New("totp step already used")
+
+// リカバリーコードが未登録・使用済みの場合のエラー
+var ErrRecoveryCodeInvalid = errors.New("recovery code invalid")
+
+// チャレンジトークンが未登録・使用済み・期限切れ・試行回数の上限に達した場合のエラー
+var ErrChallengeInvalid = errors.New("two factor challenge invalid")
+
+// TwoFactorRepositoryインターフェース
+type TwoFactorRepository interface {
+	FetchTOTP(ctx context.Context, userId string) (*models.TOTPData, error)
+	SaveTOTPSecret(ctx context.Context, userId, secret string) error
+	EnableTOTP(ctx context.Context, userId, secret string, step int64, recoveryCodeHashes []string) error
+	DisableTOTP(ctx context.Context, userId string) error
+	UseTOTPStep(ctx context.Context, userId string, step int64) error
+	UseRecoveryCode(ctx context.Context, userId, codeHash string) error
+	CountRecoveryCodes(ctx context.Context, userId string) (int, error)
+	CreateChallenge(ctx context.Context, userId, tokenHash string, expiresAt time.Time) error
+	AttemptChallenge(ctx context.Context, tokenHash string, maxAttempts int) (string, error)
+	CompleteChallenge(ctx context.Context, tokenHash string) error
+}
+
+type TwoFactorRepositoryImpl struct {
+	DB supabase.DB
+}
+
+// TwoFactorRepositoryインターフェースを実装したTwoFactorRepositoryImplのポインタを返す
+func NewTwoFactorRepository(db supabase.DB) TwoFactorRepository {
+	return &TwoFactorRepositoryImpl{
+		DB: db,
+	}
+}
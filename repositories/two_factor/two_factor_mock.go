@@ -0,0 +1,66 @@
+package repositories_two_factor
+
+import (
+	"backend/models"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTwoFactorRepository struct {
+	mock.Mock
+}
+
+func (m *MockTwoFactorRepository) FetchTOTP(ctx context.Context, userId string) (*models.TOTPData, error) {
+	args := m.Called(userId)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.TOTPData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) SaveTOTPSecret(ctx context.Context, userId, secret string) error {
+	args := m.Called(userId, secret)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) EnableTOTP(ctx context.Context, userId, secret string, step int64, recoveryCodeHashes []string) error {
+	args := m.Called(userId, secret, step, recoveryCodeHashes)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) DisableTOTP(ctx context.Context, userId string) error {
+	args := m.Called(userId)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) UseTOTPStep(ctx context.Context, userId string, step int64) error {
+	args := m.Called(userId, step)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) UseRecoveryCode(ctx context.Context, userId, codeHash string) error {
+	args := m.Called(userId, codeHash)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) CountRecoveryCodes(ctx context.Context, userId string) (int, error) {
+	args := m.Called(userId)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) CreateChallenge(ctx context.Context, userId, tokenHash string, expiresAt time.Time) error {
+	args := m.Called(userId, tokenHash, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) AttemptChallenge(ctx context.Context, tokenHash string, maxAttempts int) (string, error) {
+	args := m.Called(tokenHash, maxAttempts)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) CompleteChallenge(ctx context.Context, tokenHash string) error {
+	args := m.Called(tokenHash)
+	return args.Error(0)
+}
@@ -0,0 +1,86 @@
+package repositories_two_factor
+
+import (
+	"backend/supabase"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_TwoFactor_PipeLine(t *testing.T) {
+	// Supabaseクライアントの初期化
+	setupSupabase(t)
+
+	// リポジトリのインスタンスを作成
+	repo := NewTwoFactorRepository(supabase.Pool)
+	ctx := context.Background()
+	userId := os.Getenv("TEST_USER_ID")
+	defer repo.DisableTOTP(ctx, userId)
+
+	// ---------------------------------------------------------
+	// 1. 登録を開始(確認前は無効)
+	// ---------------------------------------------------------
+	assert.NoError(t, repo.SaveTOTPSecret(ctx, userId, "SECRETA"))
+	assert.NoError(t, repo.SaveTOTPSecret(ctx, userId, "SECRETB"))
+	totp, err := repo.FetchTOTP(ctx, userId)
+	if err != nil {
+		t.Fatalf("Failed to fetch totp: %v", err)
+	}
+	assert.Equal(t, "SECRETB", totp.Secret)
+	assert.False(t, totp.Enabled())
+
+	// ---------------------------------------------------------
+	// 2. やり直す前のシークレットでは有効にできない
+	// ---------------------------------------------------------
+	assert.ErrorIs(t, repo.EnableTOTP(ctx, userId, "SECRETA", 100, nil), ErrTOTPNotPending)
+	assert.NoError(t, repo.EnableTOTP(ctx, userId, "SECRETB", 100, []string{"hash1", "hash2"}))
+	assert.ErrorIs(t, repo.SaveTOTPSecret(ctx, userId, "SECRETC"), ErrTOTPAlreadyEnabled)
+
+	// ---------------------------------------------------------
+	// 3. 使用済みの時間ステップは再利用できない
+	// ---------------------------------------------------------
+	assert.ErrorIs(t, repo.UseTOTPStep(ctx, userId, 100), ErrTOTPStepUsed)
+	assert.NoError(t, repo.UseTOTPStep(ctx, userId, 101))
+
+	// ---------------------------------------------------------
+	// 4. リカバリーコードは一度だけ使用できる
+	// ---------------------------------------------------------
+	assert.NoError(t, repo.UseRecoveryCode(ctx, userId, "hash1"))
+	assert.ErrorIs(t, repo.UseRecoveryCode(ctx, userId, "hash1"), ErrRecoveryCodeInvalid)
+	count, err := repo.CountRecoveryCodes(ctx, userId)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// ---------------------------------------------------------
+	// 5. チャレンジは試行回数の上限まで使え、完了後は使えない
+	// ---------------------------------------------------------
+	token := uuid.New().String()
+	assert.NoError(t, repo.CreateChallenge(ctx, userId, token, time.Now().Add(time.Minute)))
+	id, err := repo.AttemptChallenge(ctx, token, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, userId, id)
+	_, err = repo.AttemptChallenge(ctx, token, 2)
+	assert.NoError(t, err)
+	_, err = repo.AttemptChallenge(ctx, token, 2)
+	assert.ErrorIs(t, err, ErrChallengeInvalid)
+
+	other := uuid.New().String()
+	assert.NoError(t, repo.CreateChallenge(ctx, userId, other, time.Now().Add(time.Minute)))
+	assert.NoError(t, repo.CompleteChallenge(ctx, other))
+	assert.ErrorIs(t, repo.CompleteChallenge(ctx, other), ErrChallengeInvalid)
+	_, err = repo.AttemptChallenge(ctx, other, 5)
+	assert.ErrorIs(t, err, ErrChallengeInvalid)
+
+	// ---------------------------------------------------------
+	// 6. 無効にすると設定が削除される
+	// ---------------------------------------------------------
+	assert.NoError(t, repo.DisableTOTP(ctx, userId))
+	_, err = repo.FetchTOTP(ctx, userId)
+	assert.True(t, errors.Is(err, pgx.ErrNoRows))
+}
@@ -0,0 +1,247 @@
+package repositories_two_factor
+
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"log"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// ユーザーのTOTPの設定を取得する
+// 登録していない場合は pgx.ErrNoRows を返す。
+func (r *TwoFactorRepositoryImpl) FetchTOTP(ctx context.Context, userId string) (*models.TOTPData, error) {
+	log.Println("FetchTOTP start...")
+
+	query := `
+		SELECT user_id, secret, enabled_at, last_used_step, created_at, updated_at
+		FROM user_totp
+		WHERE user_id = $1
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、設定を取得
+	totp, err := scanTOTP(r.DB.QueryRow(ctx, query, userId))
+	if err != nil {
+		log.Printf("Failed to fetch totp: %v", err)
+		return nil, err
+	}
+	return totp, nil
+}
+
+// 確認待ちのシークレットを保存する(登録の開始・やり直し)
+// 2要素認証が有効な場合は上書きせず、ErrTOTPAlreadyEnabled を返す。
+func (r *TwoFactorRepositoryImpl) SaveTOTPSecret(ctx context.Context, userId, secret string) error {
+	log.Println("SaveTOTPSecret start...")
+
+	query := `
+		INSERT INTO user_totp (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = 0, created_at = now(), updated_at = now()
+		WHERE user_totp.enabled_at IS NULL
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、シークレットを保存
+	result, err := r.DB.Exec(ctx, query, userId, secret)
+	if err != nil {
+		log.Printf("Failed to save totp secret: %v", err)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		log.Printf("Failed to save totp secret: %v", ErrTOTPAlreadyEnabled)
+		return ErrTOTPAlreadyEnabled
+	}
+	return nil
+}
+
+// 確認待ちのシークレットを有効にし、リカバリーコード(ハッシュ)を登録し直す
+// 確認に使用したコードの時間ステップを記録し、同じコードでのログインを防ぐ。
+// 確認待ちの登録がない、または確認中にシークレットが変わった場合は ErrTOTPNotPending を返す。
+func (r *TwoFactorRepositoryImpl) EnableTOTP(ctx context.Context, userId, secret string, step int64, recoveryCodeHashes []string) error {
+	log.Println("EnableTOTP start...")
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE user_totp
+		SET enabled_at = now(), last_used_step = $3, updated_at = now()
+		WHERE user_id = $1 AND secret = $2 AND enabled_at IS NULL
+	`, userId, secret, step)
+	if err != nil {
+		log.Printf("Failed to enable totp: %v", err)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		log.Printf("Failed to enable totp: %v", ErrTOTPNotPending)
+		return ErrTOTPNotPending
+	}
+
+	// 以前のリカバリーコードは使えなくする
+	if _, err := tx.Exec(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userId); err != nil {
+		log.Printf("Failed to delete recovery codes: %v", err)
+		return err
+	}
+	for _, codeHash := range recoveryCodeHashes {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO recovery_codes (user_id, code_hash)
+			VALUES ($1, $2)
+		`, userId, codeHash); err != nil {
+			log.Printf("Failed to create recovery code: %v", err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Failed to commit transaction: %v", err)
+		return err
+	}
+
+	log.Printf("Enabled totp successfully: %s", userId)
+	return nil
+}
+
+// 2要素認証を無効にし、設定・リカバリーコード・未完了のチャレンジを削除する
+func (r *TwoFactorRepositoryImpl) DisableTOTP(ctx context.Context, userId string) error {
+	log.Println("DisableTOTP start...")
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, query := range []string{
+		`DELETE FROM user_totp WHERE user_id = $1`,
+		`DELETE FROM recovery_codes WHERE user_id = $1`,
+		`DELETE FROM two_factor_challenges WHERE user_id = $1`,
+	} {
+		if _, err := tx.Exec(ctx, query, userId); err != nil {
+			log.Printf("Failed to disable totp: %v", err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Failed to commit transaction: %v", err)
+		return err
+	}
+
+	log.Printf("Disabled totp successfully: %s", userId)
+	return nil
+}
+
+// コードの時間ステップを使用済みとして記録する
+// 記録済みのステップ以前のコードは ErrTOTPStepUsed を返す(同時に使われた場合も片方のみ成功させる)。
+func (r *TwoFactorRepositoryImpl) UseTOTPStep(ctx context.Context, userId string, step int64) error {
+	log.Println("UseTOTPStep start...")
+
+	query := `
+		UPDATE user_totp
+		SET last_used_step = $2, updated_at = now()
+		WHERE user_id = $1 AND enabled_at IS NOT NULL AND last_used_step < $2
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、時間ステップを記録
+	result, err := r.DB.Exec(ctx, query, userId, step)
+	if err != nil {
+		log.Printf("Failed to use totp step: %v", err)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		log.Printf("Failed to use totp step: %v", ErrTOTPStepUsed)
+		return ErrTOTPStepUsed
+	}
+	return nil
+}
+
+// リカバリーコードを使用済みにする
+// 未登録・使用済みのコードは ErrRecoveryCodeInvalid を返す。
+func (r *TwoFactorRepositoryImpl) UseRecoveryCode(ctx context.Context, userId, codeHash string) error {
+	log.Println("UseRecoveryCode start...")
+
+	query := `
+		UPDATE recovery_codes
+		SET used_at = now()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、コードを使用済みにする
+	result, err := r.DB.Exec(ctx, query, userId, codeHash)
+	if err != nil {
+		log.Printf("Failed to use recovery code: %v", err)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		log.Printf("Failed to use recovery code: %v", ErrRecoveryCodeInvalid)
+		return ErrRecoveryCodeInvalid
+	}
+
+	log.Printf("Used recovery code: %s", userId)
+	return nil
+}
+
+// 未使用のリカバリーコードの数を取得する
+func (r *TwoFactorRepositoryImpl) CountRecoveryCodes(ctx context.Context, userId string) (int, error) {
+	log.Println("CountRecoveryCodes start...")
+
+	query := `SELECT COUNT(*) FROM recovery_codes WHERE user_id = $1 AND used_at IS NULL`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	var count int
+	if err := r.DB.QueryRow(ctx, query, userId).Scan(&count); err != nil {
+		log.Printf("Failed to count recovery codes: %v", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// TOTPの設定をスキャンする
+func scanTOTP(row pgx.Row) (*models.TOTPData, error) {
+	var totp models.TOTPData
+	err := row.Scan(
+		&totp.UserId,
+		&totp.Secret,
+		&totp.EnabledAt,
+		&totp.LastUsedStep,
+		&totp.CreatedAt,
+		&totp.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &totp, nil
+}
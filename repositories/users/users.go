@@ -3,103 +3,229 @@ package repositories_users
 import (
 	"backend/models"
 	"backend/supabase"
+	"context"
+	"errors"
 	"log"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 )
 
-// 指定されたメールアドレスとパスワードでユーザーを取得する。
-// ユーザーが見つからない場合、エラーを返す。
-func (r *UserRepositoryImpl) FetchUserByEmailAndPassword(email, password string) (*models.UserData, error) {
-	log.Printf("Fetching user from Supabase by email: %s\n", email)
+// 一意制約違反をメールアドレスの重複エラーに変換する
+func emailConflictError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrUserEmailConflict
+	}
+	return err
+}
+
+// 指定されたメールアドレスに一致するユーザーの資格情報(パスワードのハッシュを含む)を取得する
+// パスワードの検証は呼び出し側で行う。ユーザーが見つからない場合、エラーを返す。
+func (r *UserRepositoryImpl) FetchUserCredentialsByEmail(ctx context.Context, email string) (*models.UserCredentials, error) {
+	log.Printf("Fetching user credentials from Supabase by email: %s\n", email)
 
 	query := `
-        SELECT id, name, email, created_at, updated_at
-        FROM users
-        WHERE email = $1 AND password = $2
-        LIMIT 1
-    `
+		SELECT id, name, email, role, bio, avatar_url, email_verified_at, password, created_at, updated_at
+		FROM users
+		WHERE lower(email) = lower($1)
+		LIMIT 1
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
 
 	// Supabaseからクエリを実行し、条件に一致するユーザーを取得
-	row := supabase.Pool.QueryRow(supabase.Ctx, query, email, password)
+	credentials, err := scanUserCredentials(r.DB.QueryRow(ctx, query, email))
+	if err != nil {
+		log.Printf("User not found or failed to fetch user credentials: %v", err)
+		return nil, err
+	}
 
-	// 取得した結果をスキャン
+	log.Printf("Fetched user credentials successfully: %v", credentials)
+	return credentials, nil
+}
+
+// 指定されたIDに一致するユーザーの資格情報(パスワードのハッシュを含む)を取得する
+func (r *UserRepositoryImpl) FetchUserCredentialsById(ctx context.Context, id string) (*models.UserCredentials, error) {
+	log.Println("Fetching user credentials from Supabase by ID")
+
+	query := `
+		SELECT id, name, email, role, bio, avatar_url, email_verified_at, password, created_at, updated_at
+		FROM users
+		WHERE id = $1
+		LIMIT 1
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、条件に一致するユーザーを取得
+	credentials, err := scanUserCredentials(r.DB.QueryRow(ctx, query, id))
+	if err != nil {
+		log.Printf("User not found or failed to fetch user credentials: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Fetched user credentials successfully: %v", credentials)
+	return credentials, nil
+}
+
+// 資格情報をスキャンする
+func scanUserCredentials(row pgx.Row) (*models.UserCredentials, error) {
+	var credentials models.UserCredentials
+	err := row.Scan(
+		&credentials.User.ID,
+		&credentials.User.Name,
+		&credentials.User.Email,
+		&credentials.User.Role,
+		&credentials.User.Bio,
+		&credentials.User.AvatarURL,
+		&credentials.User.EmailVerifiedAt,
+		&credentials.PasswordHash,
+		&credentials.User.CreatedAt,
+		&credentials.User.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &credentials, nil
+}
+
+// ユーザー情報をスキャンする
+func scanUser(row pgx.Row) (*models.UserData, error) {
 	var user models.UserData
 	err := row.Scan(
 		&user.ID,
 		&user.Name,
 		&user.Email,
+		&user.Role,
+		&user.Bio,
+		&user.AvatarURL,
+		&user.EmailVerifiedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
 	if err != nil {
-		log.Printf("User not found or failed to fetch user: %v", err)
 		return nil, err
 	}
-
-	log.Printf("Fetched user successfully: %v", user)
 	return &user, nil
 }
 
 // 指定されたIDに一致するユーザーを取得する
-func (r *UserRepositoryImpl) FetchUserById(id string) (*models.UserData, error) {
+func (r *UserRepositoryImpl) FetchUserById(ctx context.Context, id string) (*models.UserData, error) {
 	log.Println("Fetching user from Supabase by ID")
 
 	query := `
-		SELECT id, name, email, password, created_at, updated_at
+		SELECT id, name, email, role, bio, avatar_url, email_verified_at, created_at, updated_at
 		FROM users
 		WHERE id = $1
 		LIMIT 1
 	`
 
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
 	// Supabaseからクエリを実行し、条件に一致するユーザーを取得
-	row := supabase.Pool.QueryRow(supabase.Ctx, query, id)
+	row := r.DB.QueryRow(ctx, query, id)
 
 	// 取得した結果をスキャン
-	var user models.UserData
-	err := row.Scan(
-		&user.ID,
-		&user.Name,
-		&user.Email,
-		&user.Password,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := scanUser(row)
 	if err != nil {
 		log.Printf("User not found or failed to fetch user: %v", err)
 		return nil, err
 	}
 
 	log.Printf("Fetched user successfully: %v", user)
-	return &user, nil
+	return user, nil
 }
 
-// ユーザー情報を更新する
-func (r *UserRepositoryImpl) UpdateUser(id, name, email, password string) (*models.UserData, error) {
-	log.Println("Updating user in Supabase")
+// ユーザーを登録する
+// 権限はデータベースの既定値(author)とし、メールアドレスは未確認の状態で登録する。
+// メールアドレスが登録済みの場合は ErrUserEmailConflict を返す。
+func (r *UserRepositoryImpl) CreateUser(ctx context.Context, name, email, password string) (*models.UserData, error) {
+	log.Println("Creating user in Supabase")
+
+	query := `
+		INSERT INTO users (name, email, password)
+		VALUES ($1, $2, $3)
+		RETURNING id, name, email, role, bio, avatar_url, email_verified_at, created_at, updated_at
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、ユーザーを登録
+	user, err := scanUser(r.DB.QueryRow(ctx, query, name, email, password))
+	if err != nil {
+		log.Printf("Failed to create user: %v", err)
+		return nil, emailConflictError(err)
+	}
+
+	log.Printf("Created user successfully: %v", user)
+	return user, nil
+}
+
+// ユーザーのプロフィール(名前・自己紹介・アバター画像のURL)を更新する
+// メールアドレス・パスワードは変更しない。ユーザーが見つからない場合は pgx.ErrNoRows を返す。
+func (r *UserRepositoryImpl) UpdateUserProfile(ctx context.Context, id, name, bio, avatarURL string) (*models.UserData, error) {
+	log.Println("Updating user profile in Supabase")
 
 	query := `
 		UPDATE users
-		SET name = $1, email = $2, password = $3
+		SET name = $1, bio = $2, avatar_url = $3
 		WHERE id = $4
-		RETURNING id, name, email, created_at, updated_at
+		RETURNING id, name, email, role, bio, avatar_url, email_verified_at, created_at, updated_at
 	`
 
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
 	// Supabaseからクエリを実行し、条件に一致するユーザーを更新
-	row := supabase.Pool.QueryRow(supabase.Ctx, query, name, email, password, id)
+	row := r.DB.QueryRow(ctx, query, name, bio, avatarURL, id)
 
 	// 取得した結果をスキャン
-	var user models.UserData
-	err := row.Scan(
-		&user.ID,
-		&user.Name,
-		&user.Email,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	user, err := scanUser(row)
 	if err != nil {
-		log.Printf("Failed to update user: %v", err)
+		log.Printf("Failed to update user profile: %v", err)
 		return nil, err
 	}
 
-	log.Printf("Updated user successfully: %v", user)
-	return &user, nil
+	log.Printf("Updated user profile successfully: %v", user)
+	return user, nil
+}
+
+// ユーザーのパスワード(ハッシュ)のみを更新する
+// ユーザーが見つからない場合は pgx.ErrNoRows を返す。
+func (r *UserRepositoryImpl) UpdateUserPassword(ctx context.Context, id, password string) error {
+	log.Println("Updating user password in Supabase")
+
+	query := `
+		UPDATE users
+		SET password = $2
+		WHERE id = $1
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、パスワードを更新
+	result, err := r.DB.Exec(ctx, query, id, password)
+	if err != nil {
+		log.Printf("Failed to update user password: %v", err)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		log.Printf("Failed to update user password: %v", pgx.ErrNoRows)
+		return pgx.ErrNoRows
+	}
+
+	log.Println("Updated user password successfully")
+	return nil
 }
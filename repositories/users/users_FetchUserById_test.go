@@ -1,6 +1,8 @@
 package repositories_users
 
 import (
+	"backend/supabase"
+	"context"
 	"os"
 	"testing"
 
@@ -12,7 +14,7 @@ func TestRepository_FetchUserById(t *testing.T) {
 	setupSupabase()
 
 	// リポジトリのインスタンスを作成
-	repo := NewUserRepository()
+	repo := NewUserRepository(supabase.Pool)
 
 	// テスト用の環境変数を取得
 	testUserId := os.Getenv("TEST_USER_ID")
@@ -20,7 +22,7 @@ func TestRepository_FetchUserById(t *testing.T) {
 	testEmail := os.Getenv("TEST_USER_EMAIL")
 
 	// メソッドを実行
-	user, err := repo.FetchUserById(testUserId)
+	user, err := repo.FetchUserById(context.Background(), testUserId)
 	if err != nil {
 		t.Fatalf("Failed to fetch user by id: %v", err)
 	}
@@ -36,10 +38,10 @@ func TestRepository_FetchUserById_InvalidId(t *testing.T) {
 	setupSupabase()
 
 	// リポジトリのインスタンスを作成
-	repo := NewUserRepository()
+	repo := NewUserRepository(supabase.Pool)
 
 	// メソッドを実行
-	user, err := repo.FetchUserById("")
+	user, err := repo.FetchUserById(context.Background(), "")
 
 	// エラーチェックとデータ確認
 	assert.Error(t, err)
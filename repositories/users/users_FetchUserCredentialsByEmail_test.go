@@ -0,0 +1,57 @@
+package repositories_users
+
+import (
+	"backend/supabase"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_FetchUserCredentialsByEmail(t *testing.T) {
+	// Supabaseクライアントの初期化
+	setupSupabase()
+
+	// リポジトリのインスタンスを作成
+	repo := NewUserRepository(supabase.Pool)
+
+	// テスト用の環境変数を取得
+	testName := os.Getenv("TEST_USER_NAME")
+	testEmail := os.Getenv("TEST_USER_EMAIL")
+
+	// メソッドを実行
+	user, err := repo.FetchUserCredentialsByEmail(context.Background(), testEmail)
+	if err != nil {
+		t.Fatalf("Failed to fetch user: %v", err)
+	}
+
+	// エラーチェックとデータ確認
+	assert.NoError(t, err)
+	assert.Equal(t, testName, user.User.Name)
+	assert.Equal(t, testEmail, user.User.Email)
+	assert.NotEmpty(t, user.PasswordHash)
+
+	// 大文字・小文字を区別せずに検索する
+	upper, err := repo.FetchUserCredentialsByEmail(context.Background(), strings.ToUpper(testEmail))
+	assert.NoError(t, err)
+	if assert.NotNil(t, upper) {
+		assert.Equal(t, user.User.ID, upper.User.ID)
+	}
+}
+
+func TestRepository_FetchUserCredentialsByEmail_ErrorCases(t *testing.T) {
+	// Supabaseクライアントの初期化
+	setupSupabase()
+
+	// リポジトリのインスタンスを作成
+	repo := NewUserRepository(supabase.Pool)
+
+	// メソッドを実行
+	user, err := repo.FetchUserCredentialsByEmail(context.Background(), "")
+
+	// エラーチェックとデータ確認
+	assert.Error(t, err)
+	assert.Nil(t, user)
+}
@@ -0,0 +1,110 @@
+package repositories_users
+
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// メールアドレス変更のトークンを登録する
+// 変更を申請し直した場合は、以前に発行した未使用のトークンを無効にする。
+func (r *UserRepositoryImpl) CreateEmailChange(ctx context.Context, userId, newEmail, tokenHash string, expiresAt time.Time) error {
+	log.Println("CreateEmailChange start...")
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// 申請中の変更を無効にする
+	if _, err := tx.Exec(ctx, `
+		UPDATE email_changes
+		SET used_at = now()
+		WHERE user_id = $1 AND used_at IS NULL
+	`, userId); err != nil {
+		log.Printf("Failed to invalidate email changes: %v", err)
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO email_changes (user_id, new_email, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`, userId, newEmail, tokenHash, expiresAt); err != nil {
+		log.Printf("Failed to create email change: %v", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Failed to commit transaction: %v", err)
+		return err
+	}
+
+	log.Println("Created email change successfully")
+	return nil
+}
+
+// メールアドレス変更のトークンを使用済みにし、ユーザーのメールアドレスを変更する
+// 新しいメールアドレスはリンクを開いた時点で確認済みとする。
+// 未登録・使用済み・期限切れのトークンは ErrEmailChangeInvalid、変更先が他のユーザーに登録済みの場合は ErrUserEmailConflict を返す。
+// 同じトークンでの同時の変更は行ロックで直列化し、片方のみ成功させる。
+func (r *UserRepositoryImpl) ConfirmEmailChange(ctx context.Context, tokenHash string) (*models.UserData, error) {
+	log.Println("ConfirmEmailChange start...")
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// トークンをロックして使用済みにする
+	var userId, newEmail string
+	err = tx.QueryRow(ctx, `
+		UPDATE email_changes
+		SET used_at = now()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now()
+		RETURNING user_id, new_email
+	`, tokenHash).Scan(&userId, &newEmail)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("Failed to confirm email change: %v", ErrEmailChangeInvalid)
+			return nil, ErrEmailChangeInvalid
+		}
+		log.Printf("Failed to use email change: %v", err)
+		return nil, err
+	}
+
+	user, err := scanUser(tx.QueryRow(ctx, `
+		UPDATE users
+		SET email = $2, email_verified_at = now()
+		WHERE id = $1
+		RETURNING id, name, email, role, bio, avatar_url, email_verified_at, created_at, updated_at
+	`, userId, newEmail))
+	if err != nil {
+		log.Printf("Failed to confirm email change: %v", err)
+		return nil, emailConflictError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Confirmed email change successfully: %s", user.ID)
+	return user, nil
+}
@@ -0,0 +1,91 @@
+package repositories_users
+
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// 確認メールのトークンを登録する
+// 再送した場合も、有効期限内であれば以前のトークンで確認できる。
+func (r *UserRepositoryImpl) CreateEmailVerification(ctx context.Context, userId, tokenHash string, expiresAt time.Time) error {
+	log.Println("CreateEmailVerification start...")
+
+	query := `
+		INSERT INTO email_verifications (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、トークンを登録
+	if _, err := r.DB.Exec(ctx, query, userId, tokenHash, expiresAt); err != nil {
+		log.Printf("Failed to create email verification: %v", err)
+		return err
+	}
+
+	log.Println("Created email verification successfully")
+	return nil
+}
+
+// 確認メールのトークンを使用済みにし、ユーザーのメールアドレスを確認済みにする
+// 未登録・使用済み・期限切れのトークンは ErrEmailVerificationInvalid を返す。
+// 同じトークンでの同時の確認は行ロックで直列化し、片方のみ成功させる。
+func (r *UserRepositoryImpl) VerifyEmail(ctx context.Context, tokenHash string) (*models.UserData, error) {
+	log.Println("VerifyEmail start...")
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// トークンをロックして使用済みにする
+	var userId string
+	err = tx.QueryRow(ctx, `
+		UPDATE email_verifications
+		SET used_at = now()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now()
+		RETURNING user_id
+	`, tokenHash).Scan(&userId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("Failed to verify email: %v", ErrEmailVerificationInvalid)
+			return nil, ErrEmailVerificationInvalid
+		}
+		log.Printf("Failed to use email verification: %v", err)
+		return nil, err
+	}
+
+	// 確認済みの場合は最初に確認した日時を保持する
+	user, err := scanUser(tx.QueryRow(ctx, `
+		UPDATE users
+		SET email_verified_at = COALESCE(email_verified_at, now())
+		WHERE id = $1
+		RETURNING id, name, email, role, bio, avatar_url, email_verified_at, created_at, updated_at
+	`, userId))
+	if err != nil {
+		log.Printf("Failed to verify email: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Verified email successfully: %s", user.ID)
+	return user, nil
+}
@@ -1,17 +1,48 @@
 package repositories_users
 
-import "backend/models"
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"errors"
+	"time"
+)
+
+// メールアドレスが登録済みの場合のエラー
+var ErrUserEmailConflict = errors.New("user email already exists")
+
+// 確認メールのトークンが未登録・使用済み・期限切れの場合のエラー
+var ErrEmailVerificationInvalid = errors.New("email verification token invalid")
+
+// パスワード再設定のトークンが未登録・使用済み・期限切れの場合のエラー
+var ErrPasswordResetInvalid = errors.New("password reset token invalid")
+
+// メールアドレス変更のトークンが未登録・使用済み・期限切れの場合のエラー
+var ErrEmailChangeInvalid = errors.New("email change token invalid")
 
 // UserRepositoryインターフェース
 type UserRepository interface {
-	FetchUserByEmailAndPassword(email, password string) (*models.UserData, error)
-	FetchUserById(id string) (*models.UserData, error)
-	UpdateUser(id, name, email, password string) (*models.UserData, error)
+	FetchUserCredentialsByEmail(ctx context.Context, email string) (*models.UserCredentials, error)
+	FetchUserCredentialsById(ctx context.Context, id string) (*models.UserCredentials, error)
+	FetchUserById(ctx context.Context, id string) (*models.UserData, error)
+	CreateUser(ctx context.Context, name, email, password string) (*models.UserData, error)
+	UpdateUserProfile(ctx context.Context, id, name, bio, avatarURL string) (*models.UserData, error)
+	UpdateUserPassword(ctx context.Context, id, password string) error
+	CreateEmailVerification(ctx context.Context, userId, tokenHash string, expiresAt time.Time) error
+	VerifyEmail(ctx context.Context, tokenHash string) (*models.UserData, error)
+	CreatePasswordReset(ctx context.Context, userId, tokenHash string, expiresAt time.Time) error
+	ResetPassword(ctx context.Context, tokenHash, password string) (*models.UserData, error)
+	CreateEmailChange(ctx context.Context, userId, newEmail, tokenHash string, expiresAt time.Time) error
+	ConfirmEmailChange(ctx context.Context, tokenHash string) (*models.UserData, error)
 }
 
-type UserRepositoryImpl struct{}
+type UserRepositoryImpl struct {
+	DB supabase.DB
+}
 
 // UserRepositoryインターフェースを実装したUserRepositoryImplのポインタを返す
-func NewUserRepository() UserRepository {
-	return &UserRepositoryImpl{}
+func NewUserRepository(db supabase.DB) UserRepository {
+	return &UserRepositoryImpl{
+		DB: db,
+	}
 }
@@ -2,6 +2,8 @@ package repositories_users
 
 import (
 	"backend/models"
+	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,15 +12,23 @@ type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) FetchUserByEmailAndPassword(email, password string) (*models.UserData, error) {
-	args := m.Called(email, password)
+func (m *MockUserRepository) FetchUserCredentialsByEmail(ctx context.Context, email string) (*models.UserCredentials, error) {
+	args := m.Called(email)
 	if args.Get(0) != nil {
-		return args.Get(0).(*models.UserData), args.Error(1)
+		return args.Get(0).(*models.UserCredentials), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) FetchUserCredentialsById(ctx context.Context, id string) (*models.UserCredentials, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.UserCredentials), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockUserRepository) FetchUserById(id string) (*models.UserData, error) {
+func (m *MockUserRepository) FetchUserById(ctx context.Context, id string) (*models.UserData, error) {
 	args := m.Called(id)
 	if args.Get(0) != nil {
 		return args.Get(0).(*models.UserData), args.Error(1)
@@ -26,8 +36,60 @@ func (m *MockUserRepository) FetchUserById(id string) (*models.UserData, error)
 	return nil, args.Error(1)
 }
 
-func (m *MockUserRepository) UpdateUser(id, name, email, password string) (*models.UserData, error) {
-	args := m.Called(id, name, email, password)
+func (m *MockUserRepository) CreateUser(ctx context.Context, name, email, password string) (*models.UserData, error) {
+	args := m.Called(name, email, password)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.UserData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserProfile(ctx context.Context, id, name, bio, avatarURL string) (*models.UserData, error) {
+	args := m.Called(id, name, bio, avatarURL)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.UserData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUserPassword(ctx context.Context, id, password string) error {
+	args := m.Called(id, password)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CreateEmailVerification(ctx context.Context, userId, tokenHash string, expiresAt time.Time) error {
+	args := m.Called(userId, tokenHash, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) VerifyEmail(ctx context.Context, tokenHash string) (*models.UserData, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.UserData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) CreatePasswordReset(ctx context.Context, userId, tokenHash string, expiresAt time.Time) error {
+	args := m.Called(userId, tokenHash, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ResetPassword(ctx context.Context, tokenHash, password string) (*models.UserData, error) {
+	args := m.Called(tokenHash, password)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.UserData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockUserRepository) CreateEmailChange(ctx context.Context, userId, newEmail, tokenHash string, expiresAt time.Time) error {
+	args := m.Called(userId, newEmail, tokenHash, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ConfirmEmailChange(ctx context.Context, tokenHash string) (*models.UserData, error) {
+	args := m.Called(tokenHash)
 	if args.Get(0) != nil {
 		return args.Get(0).(*models.UserData), args.Error(1)
 	}
@@ -0,0 +1,111 @@
+package repositories_users
+
+import (
+	"backend/models"
+	"backend/supabase"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// パスワード再設定のトークンを登録する
+func (r *UserRepositoryImpl) CreatePasswordReset(ctx context.Context, userId, tokenHash string, expiresAt time.Time) error {
+	log.Println("CreatePasswordReset start...")
+
+	query := `
+		INSERT INTO password_resets (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+	`
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	// Supabaseからクエリを実行し、トークンを登録
+	if _, err := r.DB.Exec(ctx, query, userId, tokenHash, expiresAt); err != nil {
+		log.Printf("Failed to create password reset: %v", err)
+		return err
+	}
+
+	log.Println("Created password reset successfully")
+	return nil
+}
+
+// パスワード再設定のトークンを使用済みにし、パスワード(ハッシュ)を更新する
+// 同じユーザーの未使用のトークンも無効にし、ログインセッションをすべて失効させる。
+// 未登録・使用済み・期限切れのトークンは ErrPasswordResetInvalid を返す。
+// 同じトークンでの同時の再設定は行ロックで直列化し、片方のみ成功させる。
+func (r *UserRepositoryImpl) ResetPassword(ctx context.Context, tokenHash, password string) (*models.UserData, error) {
+	log.Println("ResetPassword start...")
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := supabase.WithQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// トークンをロックして使用済みにする
+	var userId string
+	err = tx.QueryRow(ctx, `
+		UPDATE password_resets
+		SET used_at = now()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now()
+		RETURNING user_id
+	`, tokenHash).Scan(&userId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("Failed to reset password: %v", ErrPasswordResetInvalid)
+			return nil, ErrPasswordResetInvalid
+		}
+		log.Printf("Failed to use password reset: %v", err)
+		return nil, err
+	}
+
+	user, err := scanUser(tx.QueryRow(ctx, `
+		UPDATE users
+		SET password = $2
+		WHERE id = $1
+		RETURNING id, name, email, role, bio, avatar_url, email_verified_at, created_at, updated_at
+	`, userId, password))
+	if err != nil {
+		log.Printf("Failed to reset password: %v", err)
+		return nil, err
+	}
+
+	// 再設定前に発行した他のトークンを無効にする
+	if _, err := tx.Exec(ctx, `
+		UPDATE password_resets
+		SET used_at = now()
+		WHERE user_id = $1 AND used_at IS NULL
+	`, userId); err != nil {
+		log.Printf("Failed to invalidate password resets: %v", err)
+		return nil, err
+	}
+
+	// 古いパスワードでのログインセッションをすべて失効させる
+	result, err := tx.Exec(ctx, `
+		UPDATE sessions
+		SET revoked_at = now()
+		WHERE user_id = $1 AND revoked_at IS NULL
+	`, userId)
+	if err != nil {
+		log.Printf("Failed to revoke sessions: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("Failed to commit transaction: %v", err)
+		return nil, err
+	}
+
+	log.Printf("Reset password successfully: %s (revoked %d sessions)", user.ID, result.RowsAffected())
+	return user, nil
+}
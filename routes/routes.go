@@ -1,32 +1,112 @@
 package routes
 
 import (
+	"backend/config"
+	"backend/logger"
+	"backend/mailer"
+	"backend/middlewares"
+	"backend/supabase"
+	utils_cache "backend/utils/cache"
 	utils_cookie "backend/utils/cookie"
 
 	handlers_auth "backend/handlers/auth"
 	handlers_blogs "backend/handlers/blogs"
 	handlers_blogs_likes "backend/handlers/blogs_likes"
+	handlers_categories "backend/handlers/categories"
 	handlers_comments "backend/handlers/comments"
+	handlers_tags "backend/handlers/tags"
 	handlers_users "backend/handlers/users"
 
+	repositories_blog_revisions "backend/repositories/blog_revisions"
 	repositories_blogs "backend/repositories/blogs"
 	repositories_blogs_likes "backend/repositories/blogs_likes"
+	repositories_categories "backend/repositories/categories"
 	repositories_comments "backend/repositories/comments"
+	repositories_login_failures "backend/repositories/login_failures"
+	repositories_memory "backend/repositories/memory"
+	repositories_sessions "backend/repositories/sessions"
+	repositories_tags "backend/repositories/tags"
+	repositories_two_factor "backend/repositories/two_factor"
 	repositories_users "backend/repositories/users"
 
 	services_auth "backend/services/auth"
 	services_blogs "backend/services/blogs"
 	services_blogs_likes "backend/services/blogs_likes"
+	services_categories "backend/services/categories"
 	services_comments "backend/services/comments"
+	services_tags "backend/services/tags"
 	services_users "backend/services/users"
 
+	"context"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 )
 
+// 各リポジトリをまとめた構造体
+type repositories struct {
+	user     repositories_users.UserRepository
+	blog     repositories_blogs.BlogRepository
+	blogLike repositories_blogs_likes.BlogLikeRepository
+	comment  repositories_comments.CommentRepository
+	tag      repositories_tags.TagRepository
+	category repositories_categories.CategoryRepository
+	revision repositories_blog_revisions.BlogRevisionRepository
+	session  repositories_sessions.SessionRepository
+
+	loginFailure repositories_login_failures.LoginFailureRepository
+	twoFactor    repositories_two_factor.TwoFactorRepository
+}
+
+// 環境変数 DB_DRIVER に応じてリポジトリを初期化する
+// memory の場合はインメモリストアを、それ以外はSupabaseのコネクションプールを使用する。
+// ログインの失敗の記録のみ、環境変数 LOGIN_THROTTLE_STORE=memory でインメモリストアに切り替えられる。
+func setupRepositories() repositories {
+	if config.IsMemoryDriver() {
+		logger.InfoLog.Println("Using in-memory repositories")
+		store := repositories_memory.NewStore()
+		store.SeedFromEnv()
+
+		return repositories{
+			user:     repositories_memory.NewUserRepository(store),
+			blog:     repositories_memory.NewBlogRepository(store),
+			blogLike: repositories_memory.NewBlogLikeRepository(store),
+			comment:  repositories_memory.NewCommentRepository(store),
+			tag:      repositories_memory.NewTagRepository(store),
+			category: repositories_memory.NewCategoryRepository(store),
+			revision: repositories_memory.NewBlogRevisionRepository(store),
+			session:  repositories_memory.NewSessionRepository(store),
+
+			loginFailure: repositories_memory.NewLoginFailureRepository(store),
+			twoFactor:    repositories_memory.NewTwoFactorRepository(store),
+		}
+	}
+
+	logger.InfoLog.Println("Using Supabase repositories")
+	loginFailure := repositories_login_failures.NewLoginFailureRepository(supabase.Pool)
+	if config.LoginThrottleStore() == config.DbDriverMemory {
+		// 単一のインスタンスで運用する場合は、ログインの失敗をこのインスタンス内で数える
+		logger.InfoLog.Println("Using in-memory login failure repository")
+		loginFailure = repositories_memory.NewLoginFailureRepository(repositories_memory.NewStore())
+	}
+	return repositories{
+		user:     repositories_users.NewUserRepository(supabase.Pool),
+		blog:     repositories_blogs.NewBlogRepository(supabase.Pool),
+		blogLike: repositories_blogs_likes.NewBlogLikeRepository(supabase.Pool),
+		comment:  repositories_comments.NewCommentRepository(supabase.Pool),
+		tag:      repositories_tags.NewTagRepository(supabase.Pool),
+		category: repositories_categories.NewCategoryRepository(supabase.Pool),
+		revision: repositories_blog_revisions.NewBlogRevisionRepository(supabase.Pool),
+		session:  repositories_sessions.NewSessionRepository(supabase.Pool),
+
+		loginFailure: loginFailure,
+		twoFactor:    repositories_two_factor.NewTwoFactorRepository(supabase.Pool),
+	}
+}
+
 // ルーティングを設定する関数
-func SetupRoutes(e *echo.Echo) {
+// ctx はバックグラウンドジョブの停止に使用する。
+func SetupRoutes(ctx context.Context, e *echo.Echo) {
 	// ヘルスチェックエンドポイントの追加
 	e.GET("/", func(c echo.Context) error {
 		return c.String(http.StatusOK, "Service is running")
@@ -35,48 +115,97 @@ func SetupRoutes(e *echo.Echo) {
 	// RepositoryとServiceとHandlerの初期化
 	cookieUtils := utils_cookie.NewCookieUtils()
 
-	userRepository := repositories_users.NewUserRepository()
-	blogRepository := repositories_blogs.NewBlogRepository()
-	BlogLikeRepository := repositories_blogs_likes.NewBlogLikeRepository()
-	commentRepository := repositories_comments.NewCommentRepository()
+	repos := setupRepositories()
+
+	// 一覧系の読み取り結果のキャッシュ(各サービスで共有し、書き込み時に無効化する)
+	readCache := utils_cache.New(config.CacheTTL())
+	if !config.IsMemoryDriver() {
+		// 他のインスタンスでの書き込みを通知で受け取り、キャッシュを無効化する
+		go supabase.NewChangeListener(utils_cache.NewSync(readCache, config.CacheTTL(), config.CacheFallbackTTL())).Run(ctx)
+	}
+
+	// 確認メールなどの送信方法(SMTP_HOST が未設定の場合は送信箱に保存する)
+	mail := mailer.NewFromEnv()
 
-	authService := services_auth.NewAuthService()
-	userService := services_users.NewUserService(userRepository)
-	blogService := services_blogs.NewBlogService(blogRepository)
-	blogLikeService := services_blogs_likes.NewBlogLikeService(BlogLikeRepository)
-	commentService := services_comments.NewCommentService(commentRepository)
+	authService := services_auth.NewAuthService(repos.session, repos.user, repos.loginFailure, repos.twoFactor)
+	userService := services_users.NewUserService(repos.user, mail, repos.loginFailure)
+	blogService := services_blogs.NewBlogService(repos.blog, repos.category, repos.revision, repos.user, readCache)
+	blogLikeService := services_blogs_likes.NewBlogLikeService(repos.blogLike, readCache)
+	commentService := services_comments.NewCommentService(repos.comment, readCache)
+	tagService := services_tags.NewTagService(repos.tag, repos.user, readCache)
+	categoryService := services_categories.NewCategoryService(repos.category, repos.user, readCache)
+
+	// ゴミ箱内のブログを定期的に完全削除する
+	go services_blogs.RunTrashPurger(ctx, blogService, config.BlogPurgeInterval(), config.BlogTrashRetention())
+	// 予約中のブログを定期的に公開する
+	go services_blogs.RunPublishScheduler(ctx, blogService, config.BlogPublishInterval())
 
 	authHandler := handlers_auth.NewAuthHandler(userService, authService)
 	UserHandler := handlers_users.NewUserHandler(userService, cookieUtils)
-	BlogHandler := handlers_blogs.NewBlogHandler(blogService, cookieUtils)
+	BlogHandler := handlers_blogs.NewBlogHandler(blogService)
 	BlogLikeHandler := handlers_blogs_likes.NewBlogLikeHandler(blogLikeService, cookieUtils)
 	CommentHandler := handlers_comments.NewCommentHandler(commentService)
+	TagHandler := handlers_tags.NewTagHandler(tagService)
+	CategoryHandler := handlers_categories.NewCategoryHandler(categoryService)
 
 	// APIエンドポイントの設定
+	// ログインが必要なエンドポイントは RequireAuth を適用したグループに登録する。
 	api := e.Group("/api")
 	{
 		// ユーザー関連のエンドポイント
 		users := api.Group("/users")
 		{
+			users.POST("/register", UserHandler.Register)
+			users.POST("/verify-email", UserHandler.VerifyEmail)
+			users.POST("/password/forgot", UserHandler.ForgotPassword)
+			users.POST("/password/reset", UserHandler.ResetPassword)
+			users.POST("/email/confirm", UserHandler.ConfirmEmailChange)
 			users.POST("/login", authHandler.Login)
-			users.GET("/auth-check", authHandler.CheckAuth)
+			users.POST("/login/2fa", authHandler.LoginTwoFactor)
+			users.POST("/refresh", authHandler.Refresh)
 			users.POST("/logout", authHandler.Logout)
-
-			users.GET("/detail", UserHandler.FetchUser)
-			users.PUT("/update", UserHandler.UpdateUser)
+			users.GET("/authors/:id", UserHandler.FetchAuthor)
+		}
+		usersAuth := users.Group("", middlewares.RequireAuth())
+		{
+			usersAuth.GET("/auth-check", authHandler.CheckAuth)
+			usersAuth.GET("/detail", UserHandler.FetchUser)
+			usersAuth.PATCH("/profile", UserHandler.UpdateProfile)
+			usersAuth.POST("/password", UserHandler.ChangePassword)
+			usersAuth.POST("/email", UserHandler.RequestEmailChange)
+			usersAuth.POST("/verify-email/resend", UserHandler.ResendVerification)
+			usersAuth.GET("/2fa", authHandler.FetchTwoFactorStatus)
+			usersAuth.POST("/2fa/setup", authHandler.SetupTwoFactor)
+			usersAuth.POST("/2fa/enable", authHandler.EnableTwoFactor)
+			usersAuth.POST("/2fa/disable", authHandler.DisableTwoFactor)
 		}
 		// ブログ関連のエンドポイント
-		blogs := api.Group("/blogs")
+		// ログイン中の場合は本人の下書きなども閲覧できるため、任意で認証する
+		blogs := api.Group("/blogs", middlewares.OptionalAuth())
 		{
 			blogs.GET("", BlogHandler.FetchBlogs)
 			blogs.GET("/user/:userId", BlogHandler.FetchBlogsByUserId)
 			blogs.GET("/detail/:id", BlogHandler.FetchBlogById)
+			blogs.GET("/by-slug/:slug", BlogHandler.FetchBlogBySlug)
 			blogs.GET("/categories", BlogHandler.FetchBlogCategories)
 			blogs.GET("/tags", BlogHandler.FetchBlogTags)
 			blogs.GET("/popular/:count", BlogHandler.FetchBlogPopular)
-			blogs.POST("/create", BlogHandler.CreateBlog)
-			blogs.PUT("/update/:id", BlogHandler.UpdateBlog)
-			blogs.DELETE("/delete/:id", BlogHandler.DeleteBlog)
+			blogs.GET("/search", BlogHandler.SearchBlogs)
+		}
+		blogsAuth := blogs.Group("", middlewares.RequireAuth())
+		{
+			blogsAuth.GET("/cache-stats", BlogHandler.FetchCacheStats)
+			blogsAuth.POST("/create", BlogHandler.CreateBlog)
+			blogsAuth.PUT("/update/:id", BlogHandler.UpdateBlog)
+			blogsAuth.DELETE("/delete/:id", BlogHandler.DeleteBlog)
+			blogsAuth.PUT("/status/:id", BlogHandler.UpdateBlogStatus)
+			blogsAuth.PUT("/slug/:id", BlogHandler.UpdateBlogSlug)
+			blogsAuth.GET("/trash", BlogHandler.FetchTrash)
+			blogsAuth.POST("/restore/:id", BlogHandler.RestoreBlog)
+			blogsAuth.GET("/revisions/:id", BlogHandler.FetchBlogRevisions)
+			blogsAuth.GET("/revisions/:id/diff", BlogHandler.DiffBlogRevisions)
+			blogsAuth.GET("/revisions/:id/:revision", BlogHandler.FetchBlogRevision)
+			blogsAuth.POST("/revisions/:id/revert/:revision", BlogHandler.RevertBlog)
 		}
 		// ブログいいね関連のエンドポイント
 		blogLikes := api.Group("/blog-likes")
@@ -93,5 +222,25 @@ func SetupRoutes(e *echo.Echo) {
 			comments.GET("/blog/:blogId", CommentHandler.FetchCommentsByBlogId)
 			comments.POST("/create", CommentHandler.CreateComment)
 		}
+		// タグ管理のエンドポイント
+		tags := api.Group("/tags", middlewares.RequireAuth())
+		{
+			tags.GET("", TagHandler.FetchTags)
+			tags.PUT("/rename/:id", TagHandler.RenameTag)
+			tags.POST("/merge", TagHandler.MergeTags)
+			tags.POST("/aliases/create/:id", TagHandler.CreateTagAlias)
+			tags.DELETE("/aliases/delete/:alias", TagHandler.DeleteTagAlias)
+		}
+		// カテゴリ管理のエンドポイント
+		categories := api.Group("/categories")
+		{
+			categories.GET("/detail/:id", CategoryHandler.FetchCategoryById)
+		}
+		categoriesAuth := categories.Group("", middlewares.RequireAuth())
+		{
+			categoriesAuth.POST("/create", CategoryHandler.CreateCategory)
+			categoriesAuth.PUT("/update/:id", CategoryHandler.UpdateCategory)
+			categoriesAuth.DELETE("/delete/:id", CategoryHandler.DeleteCategory)
+		}
 	}
 }
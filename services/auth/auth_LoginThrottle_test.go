@@ -0,0 +1,200 @@
+package services_auth
+
+import (
+	repositories_login_failures "backend/repositories/login_failures"
+	repositories_memory "backend/repositories/memory"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// 直前から指定した間隔で並んだ失敗の日時を古い順に返す
+func loginFailuresAgo(now time.Time, agos ...time.Duration) []time.Time {
+	failures := make([]time.Time, 0, len(agos))
+	for _, ago := range agos {
+		failures = append(failures, now.Add(-ago))
+	}
+	return failures
+}
+
+// 同じ日時の失敗を指定した回数だけ返す
+func repeatedLoginFailures(at time.Time, count int) []time.Time {
+	failures := make([]time.Time, count)
+	for i := range failures {
+		failures[i] = at
+	}
+	return failures
+}
+
+func TestService_ReserveLoginAttempt(t *testing.T) {
+	now := time.Now()
+	emailKey := loginFailureKey("email", "test@example.com")
+	ipKey := loginFailureKey("ip", "192.0.2.1")
+
+	tests := []struct {
+		name          string
+		emailFailures []time.Time
+		ipFailures    []time.Time
+		emailErr      error
+		minRetry      time.Duration
+		maxRetry      time.Duration
+		expectedErr   string
+	}{
+		{name: "失敗なし"},
+		{name: "待ち時間なしで失敗できる回数", emailFailures: loginFailuresAgo(now, 2*time.Second, time.Second)},
+		{
+			name:          "3回の失敗で1秒待つ",
+			emailFailures: loginFailuresAgo(now, 3*time.Second, 2*time.Second, 500*time.Millisecond),
+			minRetry:      400 * time.Millisecond,
+			maxRetry:      500 * time.Millisecond,
+			expectedErr:   "too many login attempts",
+		},
+		{
+			name:          "待ち時間は失敗するたびに倍になる",
+			emailFailures: loginFailuresAgo(now, 5*time.Second, 4*time.Second, 3*time.Second, 2*time.Second, time.Second),
+			minRetry:      2900 * time.Millisecond,
+			maxRetry:      3 * time.Second,
+			expectedErr:   "too many login attempts",
+		},
+		{name: "待ち時間を過ぎた", emailFailures: loginFailuresAgo(now, 9*time.Second, 8*time.Second, 7*time.Second, 6*time.Second, 5*time.Second)},
+		{
+			name:          "待ち時間の上限",
+			emailFailures: repeatedLoginFailures(now, 9),
+			minRetry:      maxLoginDelay - time.Second,
+			maxRetry:      maxLoginDelay,
+			expectedErr:   "too many login attempts",
+		},
+		{
+			name:          "上限に達するとロックする",
+			emailFailures: repeatedLoginFailures(now.Add(-10*time.Minute), 10),
+			minRetry:      5*time.Minute - time.Second,
+			maxRetry:      5 * time.Minute,
+			expectedErr:   "too many login attempts",
+		},
+		{
+			name:        "IPアドレスごとの上限",
+			ipFailures:  repeatedLoginFailures(now.Add(-time.Minute), 50),
+			minRetry:    14*time.Minute - time.Second,
+			maxRetry:    14 * time.Minute,
+			expectedErr: "too many login attempts",
+		},
+		{name: "IPアドレスは待ち時間なしで失敗できる回数が多い", ipFailures: repeatedLoginFailures(now, 9)},
+		{name: "失敗の記録を取得できない", emailErr: errors.New("db error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLoginFailureRepo := new(repositories_login_failures.MockLoginFailureRepository)
+			service := NewAuthService(nil, nil, mockLoginFailureRepo, nil)
+
+			mockLoginFailureRepo.On("ReserveLoginFailure", emailKey, mock.Anything, mock.Anything).Return(tt.emailFailures, tt.emailErr)
+			mockLoginFailureRepo.On("ReserveLoginFailure", ipKey, mock.Anything, mock.Anything).Return(tt.ipFailures, nil)
+			mockLoginFailureRepo.On("DeleteLoginFailuresBefore", mock.Anything).Return(int64(0), nil)
+			mockLoginFailureRepo.On("DeleteLoginFailure", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			attempt, retryAfter, err := service.ReserveLoginAttempt(context.Background(), "192.0.2.1", "test@example.com")
+
+			if tt.expectedErr != "" {
+				// 制限中の試行は失敗として数えないよう、記録を取り消す
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, attempt)
+				assert.True(t, retryAfter > tt.minRetry && retryAfter <= tt.maxRetry, "retryAfter: %s", retryAfter)
+				mockLoginFailureRepo.AssertCalled(t, "DeleteLoginFailure", ipKey, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Zero(t, retryAfter)
+				if assert.NotNil(t, attempt) {
+					expectedKeys := []string{emailKey, ipKey}
+					if tt.emailErr != nil {
+						expectedKeys = []string{ipKey}
+					}
+					assert.Equal(t, expectedKeys, attempt.keys)
+				}
+				mockLoginFailureRepo.AssertNotCalled(t, "DeleteLoginFailure", mock.Anything, mock.Anything)
+			}
+			mockLoginFailureRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// 同時に試行しても、待ち時間なしで失敗できる回数を超えてパスワードを検証できない
+func TestService_ReserveLoginAttempt_Concurrent(t *testing.T) {
+	service := NewAuthService(nil, nil, repositories_memory.NewLoginFailureRepository(repositories_memory.NewStore()), nil)
+
+	var reserved int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := service.ReserveLoginAttempt(context.Background(), "192.0.2.1", "test@example.com"); err == nil {
+				atomic.AddInt32(&reserved, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(loginFreeFailuresPerEmail), reserved)
+}
+
+func TestService_ReserveLoginAttempt_Error(t *testing.T) {
+	mockLoginFailureRepo := new(repositories_login_failures.MockLoginFailureRepository)
+	service := NewAuthService(nil, nil, mockLoginFailureRepo, nil)
+
+	// タイムアウトした場合は、記録済みの試行を取り消してエラーを返す
+	mockLoginFailureRepo.On("ReserveLoginFailure", loginFailureKey("email", "test@example.com"), mock.Anything, mock.Anything).Return(nil, nil)
+	mockLoginFailureRepo.On("ReserveLoginFailure", loginFailureKey("ip", "192.0.2.1"), mock.Anything, mock.Anything).Return(nil, context.DeadlineExceeded)
+	mockLoginFailureRepo.On("DeleteLoginFailure", loginFailureKey("email", "test@example.com"), mock.Anything).Return(nil)
+
+	attempt, _, err := service.ReserveLoginAttempt(context.Background(), "192.0.2.1", "test@example.com")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, attempt)
+	mockLoginFailureRepo.AssertExpectations(t)
+}
+
+func TestService_ReleaseLoginAttempt(t *testing.T) {
+	mockLoginFailureRepo := new(repositories_login_failures.MockLoginFailureRepository)
+	service := NewAuthService(nil, nil, mockLoginFailureRepo, nil)
+	now := time.Now()
+
+	// 記録したキーごとに、記録した日時の1件のみ削除する
+	mockLoginFailureRepo.On("DeleteLoginFailure", "email:a", now).Return(nil)
+	mockLoginFailureRepo.On("DeleteLoginFailure", "ip:b", now).Return(nil)
+
+	err := service.ReleaseLoginAttempt(context.Background(), &LoginAttempt{keys: []string{"email:a", "ip:b"}, at: now})
+
+	assert.NoError(t, err)
+	assert.NoError(t, service.ReleaseLoginAttempt(context.Background(), nil))
+	mockLoginFailureRepo.AssertExpectations(t)
+}
+
+func TestService_ReleaseLoginAttempt_Error(t *testing.T) {
+	mockLoginFailureRepo := new(repositories_login_failures.MockLoginFailureRepository)
+	service := NewAuthService(nil, nil, mockLoginFailureRepo, nil)
+
+	mockLoginFailureRepo.On("DeleteLoginFailure", mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+	err := service.ReleaseLoginAttempt(context.Background(), &LoginAttempt{keys: []string{"email:a"}, at: time.Now()})
+
+	assert.EqualError(t, err, "failed to release login attempt")
+}
+
+func TestService_ResetLoginFailures(t *testing.T) {
+	mockLoginFailureRepo := new(repositories_login_failures.MockLoginFailureRepository)
+	service := NewAuthService(nil, nil, mockLoginFailureRepo, nil)
+
+	// メールアドレスの記録のみ削除する
+	mockLoginFailureRepo.On("DeleteLoginFailures", loginFailureKey("email", "test@example.com")).Return(nil)
+
+	err := service.ResetLoginFailures(context.Background(), "TEST@example.com")
+
+	assert.NoError(t, err)
+	mockLoginFailureRepo.AssertExpectations(t)
+}
@@ -0,0 +1,332 @@
+package services_auth
+
+import (
+	"backend/models"
+	repositories_login_failures "backend/repositories/login_failures"
+	repositories_two_factor "backend/repositories/two_factor"
+	repositories_users "backend/repositories/users"
+	utils_password "backend/utils/password"
+	utils_token "backend/utils/token"
+	utils_totp "backend/utils/totp"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const twoFactorUserId = "123e4567-e89b-12d3-a456-426614174000"
+
+// RFC 6238 付録Bのシークレットを使用する
+const twoFactorSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// 現在のコードを返す
+func currentTOTPCode(t *testing.T) string {
+	code, err := utils_totp.Code(twoFactorSecret, time.Now())
+	if err != nil {
+		t.Fatalf("Failed to generate code: %v", err)
+	}
+	return code
+}
+
+// 有効にした2要素認証の設定を返す
+func enabledTOTP(lastUsedStep int64) *models.TOTPData {
+	enabledAt := time.Now()
+	return &models.TOTPData{UserId: twoFactorUserId, Secret: twoFactorSecret, EnabledAt: &enabledAt, LastUsedStep: lastUsedStep}
+}
+
+// パスワードが password123 のユーザーをモックに設定する
+func mockTwoFactorCredentials(userRepository *repositories_users.MockUserRepository) {
+	hash, _ := utils_password.Hash("password123")
+	userRepository.On("FetchUserCredentialsById", twoFactorUserId).Return(&models.UserCredentials{
+		User:         models.UserData{ID: twoFactorUserId, Email: "test@example.com"},
+		PasswordHash: hash,
+	}, nil)
+}
+
+func TestService_SetupTwoFactor(t *testing.T) {
+	tests := []struct {
+		name        string
+		password    string
+		saveErr     error
+		expectedErr string
+	}{
+		{name: "登録を開始", password: "password123"},
+		{name: "パスワードが空", password: "", expectedErr: "password is required"},
+		{name: "パスワードが誤り", password: "wrongpassword", expectedErr: "invalid current password"},
+		{name: "有効にしている", password: "password123", saveErr: repositories_two_factor.ErrTOTPAlreadyEnabled, expectedErr: "two factor already enabled"},
+		{name: "リポジトリのエラー", password: "password123", saveErr: errors.New("db error"), expectedErr: "failed to set up two factor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(repositories_users.MockUserRepository)
+			mockTwoFactorRepo := new(repositories_two_factor.MockTwoFactorRepository)
+			service := NewAuthService(nil, mockUserRepo, nil, mockTwoFactorRepo)
+
+			mockTwoFactorCredentials(mockUserRepo)
+			var savedSecret string
+			mockTwoFactorRepo.On("SaveTOTPSecret", twoFactorUserId, mock.AnythingOfType("string")).
+				Run(func(args mock.Arguments) { savedSecret = args.String(1) }).
+				Return(tt.saveErr)
+
+			setup, err := service.SetupTwoFactor(context.Background(), twoFactorUserId, tt.password)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, setup)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, savedSecret, setup.Secret)
+			assert.True(t, strings.HasPrefix(setup.URI, "otpauth://totp/Blog:test@example.com?"))
+			assert.Contains(t, setup.URI, "secret="+setup.Secret)
+		})
+	}
+}
+
+func TestService_EnableTwoFactor(t *testing.T) {
+	pending := &models.TOTPData{UserId: twoFactorUserId, Secret: twoFactorSecret}
+
+	tests := []struct {
+		name        string
+		code        string
+		totp        *models.TOTPData
+		fetchErr    error
+		enableErr   error
+		expectedErr string
+	}{
+		{name: "有効にする", totp: pending},
+		{name: "コードが空", code: "-", expectedErr: "code is required"},
+		{name: "登録を開始していない", fetchErr: pgx.ErrNoRows, expectedErr: "two factor not set up"},
+		{name: "有効にしている", totp: enabledTOTP(0), expectedErr: "two factor already enabled"},
+		{name: "コードが誤り", code: "000000", totp: pending, expectedErr: "invalid code"},
+		{name: "確認中に登録をやり直した", totp: pending, enableErr: repositories_two_factor.ErrTOTPNotPending, expectedErr: "two factor not set up"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTwoFactorRepo := new(repositories_two_factor.MockTwoFactorRepository)
+			service := NewAuthService(nil, nil, nil, mockTwoFactorRepo)
+
+			code := tt.code
+			switch code {
+			case "":
+				code = currentTOTPCode(t)
+			case "-":
+				code = ""
+			}
+			mockTwoFactorRepo.On("FetchTOTP", twoFactorUserId).Return(tt.totp, tt.fetchErr)
+			var hashes []string
+			mockTwoFactorRepo.On("EnableTOTP", twoFactorUserId, twoFactorSecret, utils_totp.Step(time.Now()), mock.Anything).
+				Run(func(args mock.Arguments) { hashes = args.Get(3).([]string) }).
+				Return(tt.enableErr)
+
+			codes, err := service.EnableTwoFactor(context.Background(), twoFactorUserId, code)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, codes)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, codes, recoveryCodeCount)
+			// 保存するのはリカバリーコードそのものではなくハッシュ
+			for i, code := range codes {
+				assert.Equal(t, utils_token.Hash(code), hashes[i])
+			}
+		})
+	}
+}
+
+func TestService_DisableTwoFactor(t *testing.T) {
+	tests := []struct {
+		name        string
+		password    string
+		code        string
+		stepErr     error
+		recoveryErr error
+		expectedErr string
+	}{
+		{name: "認証アプリのコードで無効にする", password: "password123"},
+		{name: "リカバリーコードで無効にする", password: "password123", code: "AAAA BBBB CCCC DDDD"},
+		{name: "パスワードが誤り", password: "wrongpassword", expectedErr: "invalid current password"},
+		{name: "コードが誤り", password: "password123", code: "000000", expectedErr: "invalid code"},
+		{name: "使用済みのコード", password: "password123", stepErr: repositories_two_factor.ErrTOTPStepUsed, expectedErr: "invalid code"},
+		{name: "使用済みのリカバリーコード", password: "password123", code: "aaaa-bbbb-cccc-dddd", recoveryErr: repositories_two_factor.ErrRecoveryCodeInvalid, expectedErr: "invalid code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(repositories_users.MockUserRepository)
+			mockTwoFactorRepo := new(repositories_two_factor.MockTwoFactorRepository)
+			service := NewAuthService(nil, mockUserRepo, nil, mockTwoFactorRepo)
+
+			code := tt.code
+			if code == "" {
+				code = currentTOTPCode(t)
+			}
+			mockTwoFactorCredentials(mockUserRepo)
+			mockTwoFactorRepo.On("FetchTOTP", twoFactorUserId).Return(enabledTOTP(0), nil)
+			mockTwoFactorRepo.On("UseTOTPStep", twoFactorUserId, utils_totp.Step(time.Now())).Return(tt.stepErr)
+			mockTwoFactorRepo.On("UseRecoveryCode", twoFactorUserId, utils_token.Hash("aaaa-bbbb-cccc-dddd")).Return(tt.recoveryErr)
+			mockTwoFactorRepo.On("DisableTOTP", twoFactorUserId).Return(nil)
+
+			err := service.DisableTwoFactor(context.Background(), twoFactorUserId, tt.password, code)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				mockTwoFactorRepo.AssertNotCalled(t, "DisableTOTP", twoFactorUserId)
+			} else {
+				assert.NoError(t, err)
+				mockTwoFactorRepo.AssertCalled(t, "DisableTOTP", twoFactorUserId)
+			}
+		})
+	}
+}
+
+func TestService_FetchTwoFactorStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		totp     *models.TOTPData
+		fetchErr error
+		expected models.TwoFactorStatus
+	}{
+		{name: "登録していない", fetchErr: pgx.ErrNoRows},
+		{name: "確認前", totp: &models.TOTPData{UserId: twoFactorUserId, Secret: twoFactorSecret}},
+		{name: "有効", totp: enabledTOTP(0), expected: models.TwoFactorStatus{Enabled: true, RecoveryCodesRemaining: 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTwoFactorRepo := new(repositories_two_factor.MockTwoFactorRepository)
+			service := NewAuthService(nil, nil, nil, mockTwoFactorRepo)
+
+			mockTwoFactorRepo.On("FetchTOTP", twoFactorUserId).Return(tt.totp, tt.fetchErr)
+			mockTwoFactorRepo.On("CountRecoveryCodes", twoFactorUserId).Return(7, nil)
+
+			status, err := service.FetchTwoFactorStatus(context.Background(), twoFactorUserId)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, *status)
+		})
+	}
+}
+
+func TestService_CreateTwoFactorChallenge(t *testing.T) {
+	// 2要素認証を有効にしていない場合は発行しない
+	for _, totp := range []*models.TOTPData{nil, {UserId: twoFactorUserId, Secret: twoFactorSecret}} {
+		mockTwoFactorRepo := new(repositories_two_factor.MockTwoFactorRepository)
+		service := NewAuthService(nil, nil, nil, mockTwoFactorRepo)
+		if totp == nil {
+			mockTwoFactorRepo.On("FetchTOTP", twoFactorUserId).Return(nil, pgx.ErrNoRows)
+		} else {
+			mockTwoFactorRepo.On("FetchTOTP", twoFactorUserId).Return(totp, nil)
+		}
+
+		challenge, err := service.CreateTwoFactorChallenge(context.Background(), twoFactorUserId)
+
+		assert.NoError(t, err)
+		assert.Nil(t, challenge)
+		mockTwoFactorRepo.AssertNotCalled(t, "CreateChallenge", twoFactorUserId, mock.Anything, mock.Anything)
+	}
+
+	// 有効な場合はチャレンジトークンのハッシュを保存する
+	mockTwoFactorRepo := new(repositories_two_factor.MockTwoFactorRepository)
+	service := NewAuthService(nil, nil, nil, mockTwoFactorRepo)
+	mockTwoFactorRepo.On("FetchTOTP", twoFactorUserId).Return(enabledTOTP(0), nil)
+	var storedHash string
+	mockTwoFactorRepo.On("CreateChallenge", twoFactorUserId, mock.AnythingOfType("string"), mock.Anything).
+		Run(func(args mock.Arguments) { storedHash = args.String(1) }).
+		Return(nil)
+
+	challenge, err := service.CreateTwoFactorChallenge(context.Background(), twoFactorUserId)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, challenge.Token)
+	assert.Equal(t, utils_token.Hash(challenge.Token), storedHash)
+	assert.True(t, challenge.ExpiresAt.After(time.Now()))
+}
+
+func TestService_CompleteTwoFactorLogin(t *testing.T) {
+	user := &models.UserData{ID: twoFactorUserId, Email: "test@example.com"}
+	challengeHash := utils_token.Hash("challenge-token")
+	emailKey := loginFailureKey("email", "test@example.com")
+	ipKey := loginFailureKey("ip", "192.0.2.1")
+
+	tests := []struct {
+		name         string
+		token        string
+		code         string
+		attemptErr   error
+		stepErr      error
+		failures     []time.Time
+		completeErr  error
+		expectedErr  string
+		recorded     bool
+		throttled    bool
+		completedRun bool
+	}{
+		{name: "ログイン成功", token: "challenge-token", completedRun: true},
+		{name: "入力が空", token: "", expectedErr: "challenge token and code are required"},
+		{name: "無効なチャレンジ", token: "challenge-token", attemptErr: repositories_two_factor.ErrChallengeInvalid, expectedErr: "invalid challenge token"},
+		{name: "コードが誤り", token: "challenge-token", code: "000000", expectedErr: "invalid code", recorded: true},
+		{name: "使用済みのコード", token: "challenge-token", stepErr: repositories_two_factor.ErrTOTPStepUsed, expectedErr: "invalid code", recorded: true},
+		{name: "失敗が続いている", token: "challenge-token", failures: repeatedLoginFailures(time.Now(), 10), expectedErr: "too many login attempts", throttled: true},
+		{name: "同時に完了した", token: "challenge-token", completeErr: repositories_two_factor.ErrChallengeInvalid, expectedErr: "invalid challenge token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(repositories_users.MockUserRepository)
+			mockLoginFailureRepo := new(repositories_login_failures.MockLoginFailureRepository)
+			mockTwoFactorRepo := new(repositories_two_factor.MockTwoFactorRepository)
+			service := NewAuthService(nil, mockUserRepo, mockLoginFailureRepo, mockTwoFactorRepo)
+
+			code := tt.code
+			if code == "" {
+				code = currentTOTPCode(t)
+			}
+			mockTwoFactorRepo.On("AttemptChallenge", challengeHash, maxTwoFactorAttempts).Return(twoFactorUserId, tt.attemptErr)
+			mockUserRepo.On("FetchUserById", twoFactorUserId).Return(user, nil)
+			mockLoginFailureRepo.On("ReserveLoginFailure", emailKey, mock.Anything, mock.Anything).Return(tt.failures, nil)
+			mockLoginFailureRepo.On("ReserveLoginFailure", ipKey, mock.Anything, mock.Anything).Return(nil, nil)
+			mockTwoFactorRepo.On("FetchTOTP", twoFactorUserId).Return(enabledTOTP(0), nil)
+			mockTwoFactorRepo.On("UseTOTPStep", twoFactorUserId, utils_totp.Step(time.Now())).Return(tt.stepErr)
+			mockTwoFactorRepo.On("CompleteChallenge", challengeHash).Return(tt.completeErr)
+			mockLoginFailureRepo.On("DeleteLoginFailure", mock.Anything, mock.Anything).Return(nil)
+			mockLoginFailureRepo.On("DeleteLoginFailuresBefore", mock.Anything).Return(int64(0), nil)
+			mockLoginFailureRepo.On("DeleteLoginFailures", emailKey).Return(nil)
+
+			loggedIn, retryAfter, err := service.CompleteTwoFactorLogin(context.Background(), "192.0.2.1", tt.token, code)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, loggedIn)
+				mockLoginFailureRepo.AssertNotCalled(t, "DeleteLoginFailures", emailKey)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, user, loggedIn)
+				// 先に記録した試行を取り消し、ログインに成功したメールアドレスの失敗の記録を削除する
+				mockLoginFailureRepo.AssertCalled(t, "DeleteLoginFailure", ipKey, mock.Anything)
+				mockLoginFailureRepo.AssertCalled(t, "DeleteLoginFailures", emailKey)
+			}
+			if tt.recorded {
+				// コードの誤りは、先に記録した試行をそのまま失敗として残す
+				mockLoginFailureRepo.AssertCalled(t, "ReserveLoginFailure", emailKey, mock.Anything, mock.Anything)
+				mockLoginFailureRepo.AssertNotCalled(t, "DeleteLoginFailure", mock.Anything, mock.Anything)
+			}
+			if tt.throttled {
+				// ロック中はコードを確認しない
+				assert.True(t, retryAfter > 0)
+				mockTwoFactorRepo.AssertNotCalled(t, "FetchTOTP", twoFactorUserId)
+			} else {
+				assert.Zero(t, retryAfter)
+			}
+		})
+	}
+}
@@ -1,13 +1,49 @@
 package services_auth
 
+import (
+	"backend/models"
+	repositories_login_failures "backend/repositories/login_failures"
+	repositories_sessions "backend/repositories/sessions"
+	repositories_two_factor "backend/repositories/two_factor"
+	repositories_users "backend/repositories/users"
+	"context"
+	"time"
+)
+
 // AuthServiceインターフェース
 type AuthService interface {
 	Login(email, password string) error
+	IssueTokens(ctx context.Context, user *models.UserData) (*models.AuthTokens, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (*models.AuthTokens, error)
+	Logout(ctx context.Context, refreshToken string) error
+	ReserveLoginAttempt(ctx context.Context, ip, email string) (*LoginAttempt, time.Duration, error)
+	ReleaseLoginAttempt(ctx context.Context, attempt *LoginAttempt) error
+	ResetLoginFailures(ctx context.Context, email string) error
+	SetupTwoFactor(ctx context.Context, userId, password string) (*models.TOTPSetup, error)
+	EnableTwoFactor(ctx context.Context, userId, code string) ([]string, error)
+	DisableTwoFactor(ctx context.Context, userId, password, code string) error
+	FetchTwoFactorStatus(ctx context.Context, userId string) (*models.TwoFactorStatus, error)
+	CreateTwoFactorChallenge(ctx context.Context, userId string) (*models.TwoFactorChallenge, error)
+	CompleteTwoFactorLogin(ctx context.Context, ip, challengeToken, code string) (*models.UserData, time.Duration, error)
 }
 type AuthServiceImpl struct {
+	SessionRepository      repositories_sessions.SessionRepository
+	UserRepository         repositories_users.UserRepository
+	LoginFailureRepository repositories_login_failures.LoginFailureRepository
+	TwoFactorRepository    repositories_two_factor.TwoFactorRepository
 }
 
 // AuthServiceインターフェースを実装したAuthServiceImplのポインタを返す
-func NewAuthService() AuthService {
-	return &AuthServiceImpl{}
+func NewAuthService(
+	sessionRepository repositories_sessions.SessionRepository,
+	userRepository repositories_users.UserRepository,
+	loginFailureRepository repositories_login_failures.LoginFailureRepository,
+	twoFactorRepository repositories_two_factor.TwoFactorRepository,
+) AuthService {
+	return &AuthServiceImpl{
+		SessionRepository:      sessionRepository,
+		UserRepository:         userRepository,
+		LoginFailureRepository: loginFailureRepository,
+		TwoFactorRepository:    twoFactorRepository,
+	}
 }
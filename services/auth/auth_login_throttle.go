@@ -0,0 +1,165 @@
+package services_auth
+
+import (
+	"backend/config"
+	"backend/models"
+	utils_timeout "backend/utils/timeout"
+	utils_token "backend/utils/token"
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// 待ち時間なしで失敗できる回数
+const (
+	loginFreeFailuresPerEmail = 3
+	loginFreeFailuresPerIP    = 10
+)
+
+// 失敗後の待ち時間の上限
+const maxLoginDelay = time.Minute
+
+// ログイン試行を制限する単位ごとの上限
+type loginLimit struct {
+	key          string
+	freeFailures int // この回数の失敗から待ち時間を設ける
+	maxFailures  int // この回数の失敗でロックする
+}
+
+// メールアドレスとIPアドレスそれぞれの制限を返す
+// 存在しないアカウントも同じように制限し、ロックの有無からアカウントの存在がわからないようにする。
+func loginLimits(ip, email string) []loginLimit {
+	limits := []loginLimit{{
+		key:          loginFailureKey("email", models.NormalizeEmail(email)),
+		freeFailures: loginFreeFailuresPerEmail,
+		maxFailures:  config.LoginMaxFailuresPerEmail(),
+	}}
+	if ip != "" {
+		limits = append(limits, loginLimit{
+			key:          loginFailureKey("ip", ip),
+			freeFailures: loginFreeFailuresPerIP,
+			maxFailures:  config.LoginMaxFailuresPerIP(),
+		})
+	}
+	return limits
+}
+
+// 失敗を記録するキーを返す
+// メールアドレス・IPアドレスはそのまま保存せず、ハッシュ化する。
+func loginFailureKey(kind, value string) string {
+	return kind + ":" + utils_token.Hash(value)
+}
+
+// 期間内の失敗(古い順)から、次にログインを試せるまでの時間を返す
+// 上限に達した場合は期間内の失敗が上限を下回るまでロックし、それまでは直前の失敗から待ち時間を設ける。
+// すぐに試せる場合は0以下を返す。
+func (l loginLimit) retryAfter(failures []time.Time, now time.Time, window time.Duration) time.Duration {
+	n := len(failures)
+	switch {
+	case n >= l.maxFailures:
+		return failures[n-l.maxFailures].Add(window).Sub(now)
+	case n >= l.freeFailures:
+		return failures[n-1].Add(loginDelay(n - l.freeFailures)).Sub(now)
+	}
+	return 0
+}
+
+// 待ち時間を返す(失敗するたびに倍にし、上限で打ち切る)
+func loginDelay(excess int) time.Duration {
+	delay := config.LoginThrottleDelay()
+	for i := 0; i < excess && delay < maxLoginDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxLoginDelay {
+		return maxLoginDelay
+	}
+	return delay
+}
+
+// パスワード・コードを確認する前に、失敗として先に記録したログインの試行
+// 確認に失敗した場合はそのまま失敗の記録として残し、成功した場合は ReleaseLoginAttempt で取り消す。
+type LoginAttempt struct {
+	keys []string  // 記録したキー
+	at   time.Time // 記録した日時
+}
+
+// ログインの試行を失敗として先に記録し、ログインを試せるか確認する
+// 記録と期間内の失敗の取得はキーごとに1件ずつ行うため、同時に試行しても待ち時間・ロックを回避できない。
+// 直近の失敗が続いている場合は記録を取り消し、"too many login attempts" エラーと次に試せるまでの時間を返す。
+// 失敗の記録を保存・取得できない場合は、ログインを妨げないよう制限しない。
+func (s *AuthServiceImpl) ReserveLoginAttempt(ctx context.Context, ip, email string) (*LoginAttempt, time.Duration, error) {
+	now := time.Now()
+	window := config.LoginThrottleWindow()
+	attempt := &LoginAttempt{at: now}
+
+	var retryAfter time.Duration
+	for _, limit := range loginLimits(ip, email) {
+		failures, err := s.LoginFailureRepository.ReserveLoginFailure(ctx, limit.key, now, now.Add(-window))
+		if err != nil {
+			log.Printf("Failed to reserve login attempt: %v", err)
+			if utils_timeout.IsTimeout(err) {
+				s.releaseLoginAttempt(ctx, attempt)
+				return nil, 0, err
+			}
+			continue
+		}
+		attempt.keys = append(attempt.keys, limit.key)
+		if wait := limit.retryAfter(failures, now, window); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	// 失敗を数える期間を過ぎた記録を削除する
+	if _, err := s.LoginFailureRepository.DeleteLoginFailuresBefore(ctx, now.Add(-window)); err != nil {
+		log.Printf("Failed to delete expired login failures: %v", err)
+	}
+
+	if retryAfter > 0 {
+		// パスワード・コードを確認しない試行は失敗として数えない
+		s.releaseLoginAttempt(ctx, attempt)
+		log.Printf("Login throttled for %s", retryAfter)
+		return nil, retryAfter, errors.New("too many login attempts")
+	}
+	return attempt, 0, nil
+}
+
+// 先に記録したログインの試行を取り消す
+// パスワード・コードが正しかった場合や、確認できなかった場合に呼び出す。
+func (s *AuthServiceImpl) ReleaseLoginAttempt(ctx context.Context, attempt *LoginAttempt) error {
+	if attempt == nil {
+		return nil
+	}
+	for _, key := range attempt.keys {
+		if err := s.LoginFailureRepository.DeleteLoginFailure(ctx, key, attempt.at); err != nil {
+			log.Printf("Failed to release login attempt: %v", err)
+			if utils_timeout.IsTimeout(err) {
+				return err
+			}
+			return errors.New("failed to release login attempt")
+		}
+	}
+	return nil
+}
+
+// 先に記録したログインの試行を取り消す(失敗はログに記録するのみ)
+func (s *AuthServiceImpl) releaseLoginAttempt(ctx context.Context, attempt *LoginAttempt) {
+	if err := s.ReleaseLoginAttempt(ctx, attempt); err != nil {
+		log.Printf("Failed to release login attempt: %v", err)
+	}
+}
+
+// ログインに成功したメールアドレスの失敗の記録を削除する
+// IPアドレスの記録は、他のアカウントへの試行を続けられないよう残す。
+func (s *AuthServiceImpl) ResetLoginFailures(ctx context.Context, email string) error {
+	for _, limit := range loginLimits("", email) {
+		if err := s.LoginFailureRepository.DeleteLoginFailures(ctx, limit.key); err != nil {
+			log.Printf("Failed to reset login failures: %v", err)
+			if utils_timeout.IsTimeout(err) {
+				return err
+			}
+			return errors.New("failed to reset login failures")
+		}
+	}
+	return nil
+}
@@ -1,6 +1,12 @@
 package services_auth
 
-import "github.com/stretchr/testify/mock"
+import (
+	"backend/models"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
 
 type MockAuthService struct {
 	mock.Mock
@@ -11,3 +17,87 @@ func (m *MockAuthService) Login(email, password string) error {
 	args := m.Called(email, password)
 	return args.Error(0)
 }
+
+func (m *MockAuthService) IssueTokens(ctx context.Context, user *models.UserData) (*models.AuthTokens, error) {
+	args := m.Called(user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AuthTokens), args.Error(1)
+}
+
+func (m *MockAuthService) RefreshTokens(ctx context.Context, refreshToken string) (*models.AuthTokens, error) {
+	args := m.Called(refreshToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AuthTokens), args.Error(1)
+}
+
+func (m *MockAuthService) Logout(ctx context.Context, refreshToken string) error {
+	args := m.Called(refreshToken)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) ReserveLoginAttempt(ctx context.Context, ip, email string) (*LoginAttempt, time.Duration, error) {
+	args := m.Called(ip, email)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(time.Duration), args.Error(2)
+	}
+	return args.Get(0).(*LoginAttempt), args.Get(1).(time.Duration), args.Error(2)
+}
+
+func (m *MockAuthService) ReleaseLoginAttempt(ctx context.Context, attempt *LoginAttempt) error {
+	args := m.Called(attempt)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) ResetLoginFailures(ctx context.Context, email string) error {
+	args := m.Called(email)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) SetupTwoFactor(ctx context.Context, userId, password string) (*models.TOTPSetup, error) {
+	args := m.Called(userId, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TOTPSetup), args.Error(1)
+}
+
+func (m *MockAuthService) EnableTwoFactor(ctx context.Context, userId, code string) ([]string, error) {
+	args := m.Called(userId, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockAuthService) DisableTwoFactor(ctx context.Context, userId, password, code string) error {
+	args := m.Called(userId, password, code)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) FetchTwoFactorStatus(ctx context.Context, userId string) (*models.TwoFactorStatus, error) {
+	args := m.Called(userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TwoFactorStatus), args.Error(1)
+}
+
+func (m *MockAuthService) CreateTwoFactorChallenge(ctx context.Context, userId string) (*models.TwoFactorChallenge, error) {
+	args := m.Called(userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TwoFactorChallenge), args.Error(1)
+}
+
+func (m *MockAuthService) CompleteTwoFactorLogin(ctx context.Context, ip, challengeToken, code string) (*models.UserData, time.Duration, error) {
+	args := m.Called(ip, challengeToken, code)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(time.Duration), args.Error(2)
+	}
+	return args.Get(0).(*models.UserData), args.Get(1).(time.Duration), args.Error(2)
+}
@@ -0,0 +1,162 @@
+package services_auth
+
+import (
+	"backend/config"
+	"backend/models"
+	repositories_sessions "backend/repositories/sessions"
+	utils_timeout "backend/utils/timeout"
+	utils_token "backend/utils/token"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/jackc/pgx/v4"
+)
+
+// ログインしたユーザーにアクセストークンとリフレッシュトークンを発行する
+// リフレッシュトークンごとに新しい系列のセッションを作成する。
+func (s *AuthServiceImpl) IssueTokens(ctx context.Context, user *models.UserData) (*models.AuthTokens, error) {
+	log.Println("Issuing tokens...")
+
+	refreshToken, err := utils_token.New()
+	if err != nil {
+		log.Printf("Failed to generate refresh token: %v", err)
+		return nil, errors.New("failed to issue tokens")
+	}
+	refreshExpiresAt := time.Now().Add(config.RefreshTokenTTL())
+
+	if _, err := s.SessionRepository.CreateSession(ctx, user.ID, utils_token.Hash(refreshToken), refreshExpiresAt); err != nil {
+		log.Printf("Failed to create session: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		return nil, errors.New("failed to issue tokens")
+	}
+
+	tokens, err := newAuthTokens(user, refreshToken, refreshExpiresAt)
+	if err != nil {
+		log.Printf("Failed to create access token: %v", err)
+		return nil, errors.New("failed to issue tokens")
+	}
+
+	log.Println("Issued tokens successfully")
+	return tokens, nil
+}
+
+// リフレッシュトークンを新しいトークンと交換し、アクセストークンを発行し直す
+// 交換済み・失効済み・期限切れ・未登録のトークンはすべて "invalid refresh token" エラーとする。
+// 交換済みのトークンが再利用された場合は、同じ系列のセッションがすべて失効する。
+// 新しいトークンの有効期限はログイン時に決まる系列の有効期限を超えない。
+func (s *AuthServiceImpl) RefreshTokens(ctx context.Context, refreshToken string) (*models.AuthTokens, error) {
+	log.Println("Refreshing tokens...")
+
+	// バリデーション：refreshTokenが空でないことを確認
+	if refreshToken == "" {
+		log.Println("Refresh token is required")
+		return nil, errors.New("refresh token is required")
+	}
+
+	newToken, err := utils_token.New()
+	if err != nil {
+		log.Printf("Failed to generate refresh token: %v", err)
+		return nil, errors.New("failed to refresh tokens")
+	}
+	refreshExpiresAt := time.Now().Add(config.RefreshTokenTTL())
+
+	session, err := s.SessionRepository.RotateSession(ctx, utils_token.Hash(refreshToken), utils_token.Hash(newToken), refreshExpiresAt)
+	if err != nil {
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		switch {
+		case errors.Is(err, repositories_sessions.ErrSessionReused):
+			log.Printf("Refresh token reuse detected, revoked all sessions of the login: %v", err)
+			return nil, errors.New("invalid refresh token")
+		case errors.Is(err, repositories_sessions.ErrSessionNotFound),
+			errors.Is(err, repositories_sessions.ErrSessionRevoked),
+			errors.Is(err, repositories_sessions.ErrSessionExpired):
+			log.Printf("Invalid refresh token: %v", err)
+			return nil, errors.New("invalid refresh token")
+		default:
+			log.Printf("Failed to rotate session: %v", err)
+			return nil, errors.New("failed to refresh tokens")
+		}
+	}
+
+	// アクセストークンには最新のユーザー情報を含める
+	user, err := s.UserRepository.FetchUserById(ctx, session.UserId)
+	if err != nil {
+		log.Printf("Failed to fetch user: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("invalid refresh token")
+		}
+		return nil, errors.New("failed to refresh tokens")
+	}
+
+	tokens, err := newAuthTokens(user, newToken, session.ExpiresAt)
+	if err != nil {
+		log.Printf("Failed to create access token: %v", err)
+		return nil, errors.New("failed to refresh tokens")
+	}
+
+	log.Println("Refreshed tokens successfully")
+	return tokens, nil
+}
+
+// リフレッシュトークンのセッションを(交換前後のトークンを含めて)失効させる
+// トークンが空・未登録・失効済みの場合は、失効させるものがないため成功とする。
+func (s *AuthServiceImpl) Logout(ctx context.Context, refreshToken string) error {
+	log.Println("Revoking session...")
+
+	if refreshToken == "" {
+		log.Println("No refresh token to revoke")
+		return nil
+	}
+
+	err := s.SessionRepository.RevokeSession(ctx, utils_token.Hash(refreshToken))
+	if err != nil {
+		if errors.Is(err, repositories_sessions.ErrSessionNotFound) {
+			log.Println("Session already revoked")
+			return nil
+		}
+		log.Printf("Failed to revoke session: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		return errors.New("failed to revoke session")
+	}
+
+	log.Println("Revoked session successfully")
+	return nil
+}
+
+// アクセストークンを作成し、リフレッシュトークンとまとめる
+func newAuthTokens(user *models.UserData, refreshToken string, refreshExpiresAt time.Time) (*models.AuthTokens, error) {
+	now := time.Now()
+	accessExpiresAt := now.Add(config.AccessTokenTTL())
+	claims := &models.Claims{
+		UserID:   user.ID,
+		Email:    user.Email,
+		Username: user.Name,
+		StandardClaims: jwt.StandardClaims{
+			IssuedAt:  now.Unix(),
+			ExpiresAt: accessExpiresAt.Unix(),
+		},
+	}
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(config.JwtKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthTokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshExpiresAt,
+	}, nil
+}
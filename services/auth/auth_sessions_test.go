@@ -0,0 +1,163 @@
+package services_auth
+
+import (
+	"backend/models"
+	repositories_memory "backend/repositories/memory"
+	repositories_sessions "backend/repositories/sessions"
+	repositories_users "backend/repositories/users"
+	utils_token "backend/utils/token"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var sessionUser = &models.UserData{
+	ID:    "123e4567-e89b-12d3-a456-426614174000",
+	Name:  "Test User",
+	Email: "test@example.com",
+}
+
+func TestService_IssueTokens(t *testing.T) {
+	mockSessionRepo := new(repositories_sessions.MockSessionRepository)
+	mockUserRepo := new(repositories_users.MockUserRepository)
+	service := NewAuthService(mockSessionRepo, mockUserRepo, nil, nil)
+
+	var storedHash string
+	mockSessionRepo.On("CreateSession", sessionUser.ID, mock.AnythingOfType("string"), mock.Anything).
+		Run(func(args mock.Arguments) { storedHash = args.String(1) }).
+		Return(&models.SessionData{}, nil)
+
+	tokens, err := service.IssueTokens(context.Background(), sessionUser)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.AccessToken)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	// 保存するのはトークンそのものではなくハッシュ
+	assert.NotEqual(t, tokens.RefreshToken, storedHash)
+	assert.Equal(t, utils_token.Hash(tokens.RefreshToken), storedHash)
+	assert.True(t, tokens.RefreshTokenExpiresAt.After(tokens.AccessTokenExpiresAt))
+	mockSessionRepo.AssertExpectations(t)
+}
+
+func TestService_RefreshTokens(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		rotateErr   error
+		userErr     error
+		expectedErr string
+	}{
+		{name: "トークンを交換", token: "refresh-token"},
+		{name: "トークンが空", token: "", expectedErr: "refresh token is required"},
+		{name: "交換済みトークンの再利用", token: "refresh-token", rotateErr: repositories_sessions.ErrSessionReused, expectedErr: "invalid refresh token"},
+		{name: "未登録のトークン", token: "refresh-token", rotateErr: repositories_sessions.ErrSessionNotFound, expectedErr: "invalid refresh token"},
+		{name: "失効済みのトークン", token: "refresh-token", rotateErr: repositories_sessions.ErrSessionRevoked, expectedErr: "invalid refresh token"},
+		{name: "期限切れのトークン", token: "refresh-token", rotateErr: repositories_sessions.ErrSessionExpired, expectedErr: "invalid refresh token"},
+		{name: "リポジトリのエラー", token: "refresh-token", rotateErr: errors.New("db error"), expectedErr: "failed to refresh tokens"},
+		{name: "ユーザーが削除済み", token: "refresh-token", userErr: pgx.ErrNoRows, expectedErr: "invalid refresh token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSessionRepo := new(repositories_sessions.MockSessionRepository)
+			mockUserRepo := new(repositories_users.MockUserRepository)
+			service := NewAuthService(mockSessionRepo, mockUserRepo, nil, nil)
+
+			var newHash string
+			expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+			if tt.token != "" {
+				call := mockSessionRepo.On("RotateSession", utils_token.Hash(tt.token), mock.AnythingOfType("string"), mock.Anything)
+				if tt.rotateErr != nil {
+					call.Return(nil, tt.rotateErr)
+				} else {
+					call.Run(func(args mock.Arguments) { newHash = args.String(1) }).
+						Return(&models.SessionData{UserId: sessionUser.ID, ExpiresAt: expiresAt}, nil)
+					if tt.userErr != nil {
+						mockUserRepo.On("FetchUserById", sessionUser.ID).Return(nil, tt.userErr)
+					} else {
+						mockUserRepo.On("FetchUserById", sessionUser.ID).Return(sessionUser, nil)
+					}
+				}
+			}
+
+			tokens, err := service.RefreshTokens(context.Background(), tt.token)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, tokens)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEqual(t, tt.token, tokens.RefreshToken)
+				assert.Equal(t, utils_token.Hash(tokens.RefreshToken), newHash)
+				assert.NotEmpty(t, tokens.AccessToken)
+				// 有効期限は系列の有効期限で切り詰めたセッションの有効期限を使う
+				assert.Equal(t, expiresAt, tokens.RefreshTokenExpiresAt)
+			}
+			mockSessionRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_RefreshTokens_FamilyExpiry(t *testing.T) {
+	t.Setenv("REFRESH_TOKEN_TTL", "300ms")
+	mockUserRepo := new(repositories_users.MockUserRepository)
+	mockUserRepo.On("FetchUserById", sessionUser.ID).Return(sessionUser, nil)
+	service := NewAuthService(repositories_memory.NewSessionRepository(repositories_memory.NewStore()), mockUserRepo, nil, nil)
+	ctx := context.Background()
+
+	issued, err := service.IssueTokens(ctx, sessionUser)
+	assert.NoError(t, err)
+
+	// 交換してもログイン時の有効期限は延長されない
+	time.Sleep(100 * time.Millisecond)
+	refreshed, err := service.RefreshTokens(ctx, issued.RefreshToken)
+	assert.NoError(t, err)
+	assert.False(t, refreshed.RefreshTokenExpiresAt.After(issued.RefreshTokenExpiresAt))
+
+	// ログインから有効期間を過ぎると、交換を続けていても再ログインが必要になる
+	time.Sleep(time.Until(issued.RefreshTokenExpiresAt) + 50*time.Millisecond)
+	tokens, err := service.RefreshTokens(ctx, refreshed.RefreshToken)
+	assert.EqualError(t, err, "invalid refresh token")
+	assert.Nil(t, tokens)
+}
+
+func TestService_Logout(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		revokeErr   error
+		expectedErr string
+	}{
+		{name: "セッションを失効", token: "refresh-token"},
+		{name: "トークンが空", token: ""},
+		{name: "失効済みのセッション", token: "refresh-token", revokeErr: repositories_sessions.ErrSessionNotFound},
+		{name: "リポジトリのエラー", token: "refresh-token", revokeErr: errors.New("db error"), expectedErr: "failed to revoke session"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSessionRepo := new(repositories_sessions.MockSessionRepository)
+			mockUserRepo := new(repositories_users.MockUserRepository)
+			service := NewAuthService(mockSessionRepo, mockUserRepo, nil, nil)
+
+			if tt.token != "" {
+				mockSessionRepo.On("RevokeSession", utils_token.Hash(tt.token)).Return(tt.revokeErr)
+			}
+
+			err := service.Logout(context.Background(), tt.token)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockSessionRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package services_auth
+
+import (
+	"backend/config"
+	"backend/models"
+	repositories_two_factor "backend/repositories/two_factor"
+	utils_password "backend/utils/password"
+	utils_timeout "backend/utils/timeout"
+	utils_token "backend/utils/token"
+	utils_totp "backend/utils/totp"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// 有効にした時に発行するリカバリーコードの数
+const recoveryCodeCount = 10
+
+// 2要素認証の登録を開始し、シークレットと otpauth URI を返す
+// 本人確認のため現在のパスワードを求める。確認前の登録はやり直すたびにシークレットが変わる。
+func (s *AuthServiceImpl) SetupTwoFactor(ctx context.Context, userId, password string) (*models.TOTPSetup, error) {
+	log.Println("Setting up two factor...")
+
+	// バリデーション：passwordが空でないことを確認
+	if password == "" {
+		log.Println("Password is required")
+		return nil, errors.New("password is required")
+	}
+
+	user, err := s.verifyPassword(ctx, userId, password, "failed to set up two factor")
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := utils_totp.NewSecret()
+	if err != nil {
+		log.Printf("Failed to generate totp secret: %v", err)
+		return nil, errors.New("failed to set up two factor")
+	}
+	if err := s.TwoFactorRepository.SaveTOTPSecret(ctx, userId, secret); err != nil {
+		log.Printf("Failed to save totp secret: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, repositories_two_factor.ErrTOTPAlreadyEnabled) {
+			return nil, errors.New("two factor already enabled")
+		}
+		return nil, errors.New("failed to set up two factor")
+	}
+
+	log.Println("Two factor setup started")
+	return &models.TOTPSetup{
+		Secret: secret,
+		URI:    utils_totp.URI(config.TOTPIssuer(), user.Email, secret),
+	}, nil
+}
+
+// 認証アプリのコードで登録を確認して2要素認証を有効にし、リカバリーコードを返す
+// リカバリーコードはハッシュのみを保存するため、平文はこの時にのみ返す。
+func (s *AuthServiceImpl) EnableTwoFactor(ctx context.Context, userId, code string) ([]string, error) {
+	log.Println("Enabling two factor...")
+
+	// バリデーション：codeが空でないことを確認
+	if code == "" {
+		log.Println("Code is required")
+		return nil, errors.New("code is required")
+	}
+
+	totp, err := s.TwoFactorRepository.FetchTOTP(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to fetch totp: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("two factor not set up")
+		}
+		return nil, errors.New("failed to enable two factor")
+	}
+	if totp.Enabled() {
+		log.Println("Two factor already enabled")
+		return nil, errors.New("two factor already enabled")
+	}
+
+	step, ok := utils_totp.Validate(totp.Secret, code, time.Now())
+	if !ok {
+		log.Println("Invalid totp code")
+		return nil, errors.New("invalid code")
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := utils_totp.NewRecoveryCode()
+		if err != nil {
+			log.Printf("Failed to generate recovery code: %v", err)
+			return nil, errors.New("failed to enable two factor")
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, utils_token.Hash(code))
+	}
+
+	if err := s.TwoFactorRepository.EnableTOTP(ctx, userId, totp.Secret, step, hashes); err != nil {
+		log.Printf("Failed to enable totp: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, repositories_two_factor.ErrTOTPNotPending) {
+			// 確認中に登録がやり直された
+			return nil, errors.New("two factor not set up")
+		}
+		return nil, errors.New("failed to enable two factor")
+	}
+
+	log.Println("Two factor enabled")
+	return codes, nil
+}
+
+// 2要素認証を無効にする
+// 本人確認のため、現在のパスワードと認証アプリのコード(またはリカバリーコード)を求める。
+func (s *AuthServiceImpl) DisableTwoFactor(ctx context.Context, userId, password, code string) error {
+	log.Println("Disabling two factor...")
+
+	// バリデーション：password, codeが空でないことを確認
+	if password == "" {
+		log.Println("Password is required")
+		return errors.New("password is required")
+	}
+	if code == "" {
+		log.Println("Code is required")
+		return errors.New("code is required")
+	}
+
+	if _, err := s.verifyPassword(ctx, userId, password, "failed to disable two factor"); err != nil {
+		return err
+	}
+	if err := s.verifyTwoFactorCode(ctx, userId, code, "failed to disable two factor"); err != nil {
+		return err
+	}
+
+	if err := s.TwoFactorRepository.DisableTOTP(ctx, userId); err != nil {
+		log.Printf("Failed to disable totp: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		return errors.New("failed to disable two factor")
+	}
+
+	log.Println("Two factor disabled")
+	return nil
+}
+
+// 2要素認証の状態を取得する
+func (s *AuthServiceImpl) FetchTwoFactorStatus(ctx context.Context, userId string) (*models.TwoFactorStatus, error) {
+	log.Println("Fetching two factor status...")
+
+	totp, err := s.TwoFactorRepository.FetchTOTP(ctx, userId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &models.TwoFactorStatus{}, nil
+		}
+		log.Printf("Failed to fetch totp: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		return nil, errors.New("failed to fetch two factor status")
+	}
+	if !totp.Enabled() {
+		return &models.TwoFactorStatus{}, nil
+	}
+
+	count, err := s.TwoFactorRepository.CountRecoveryCodes(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to count recovery codes: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		return nil, errors.New("failed to fetch two factor status")
+	}
+	return &models.TwoFactorStatus{Enabled: true, RecoveryCodesRemaining: count}, nil
+}
+
+// 現在のパスワードを確認し、ユーザー情報を返す
+// パスワードが誤っている場合は "invalid current password"、ユーザーがない場合は "user not found"、それ以外の失敗は failure のエラーを返す。
+func (s *AuthServiceImpl) verifyPassword(ctx context.Context, userId, password, failure string) (*models.UserData, error) {
+	credentials, err := s.UserRepository.FetchUserCredentialsById(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to fetch user credentials: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("user not found")
+		}
+		return nil, errors.New(failure)
+	}
+
+	match, _, err := utils_password.Verify(credentials.PasswordHash, password)
+	if err != nil || !match {
+		log.Printf("Invalid current password")
+		return nil, errors.New("invalid current password")
+	}
+	user := credentials.User
+	return &user, nil
+}
+
+// 認証アプリのコード、またはリカバリーコードを確認して使用済みにする
+// 6桁の数字は認証アプリのコード、それ以外はリカバリーコードとして扱う。
+// 2要素認証が有効でない場合は "two factor not enabled"、コードが誤り・使用済みの場合は "invalid code"、それ以外の失敗は failure のエラーを返す。
+func (s *AuthServiceImpl) verifyTwoFactorCode(ctx context.Context, userId, code, failure string) error {
+	totp, err := s.TwoFactorRepository.FetchTOTP(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to fetch totp: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("two factor not enabled")
+		}
+		return errors.New(failure)
+	}
+	if !totp.Enabled() {
+		log.Println("Two factor not enabled")
+		return errors.New("two factor not enabled")
+	}
+
+	if isTOTPCode(code) {
+		step, ok := utils_totp.Validate(totp.Secret, code, time.Now())
+		if !ok {
+			log.Println("Invalid totp code")
+			return errors.New("invalid code")
+		}
+		// 同じコードを再び使えないよう、時間ステップを記録する
+		if err := s.TwoFactorRepository.UseTOTPStep(ctx, userId, step); err != nil {
+			log.Printf("Failed to use totp step: %v", err)
+			if utils_timeout.IsTimeout(err) {
+				return err
+			}
+			if errors.Is(err, repositories_two_factor.ErrTOTPStepUsed) {
+				return errors.New("invalid code")
+			}
+			return errors.New(failure)
+		}
+		return nil
+	}
+
+	codeHash := utils_token.Hash(utils_totp.NormalizeRecoveryCode(code))
+	if err := s.TwoFactorRepository.UseRecoveryCode(ctx, userId, codeHash); err != nil {
+		log.Printf("Failed to use recovery code: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		if errors.Is(err, repositories_two_factor.ErrRecoveryCodeInvalid) {
+			return errors.New("invalid code")
+		}
+		return errors.New(failure)
+	}
+	return nil
+}
+
+// 認証アプリのコード(数字のみの6桁)か判定する
+func isTOTPCode(code string) bool {
+	if len(code) != utils_totp.Digits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
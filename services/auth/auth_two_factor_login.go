@@ -0,0 +1,136 @@
+package services_auth
+
+import (
+	"backend/config"
+	"backend/models"
+	repositories_two_factor "backend/repositories/two_factor"
+	utils_timeout "backend/utils/timeout"
+	utils_token "backend/utils/token"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// チャレンジ1つあたりのコードの試行回数の上限
+// 上限に達した場合は、パスワードの確認からやり直す。
+const maxTwoFactorAttempts = 5
+
+// パスワードを確認したユーザーが2要素認証を有効にしている場合、コードを待つチャレンジを発行する
+// 2要素認証を有効にしていない場合は nil を返し、そのままログインさせる。
+func (s *AuthServiceImpl) CreateTwoFactorChallenge(ctx context.Context, userId string) (*models.TwoFactorChallenge, error) {
+	totp, err := s.TwoFactorRepository.FetchTOTP(ctx, userId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		log.Printf("Failed to fetch totp: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		return nil, errors.New("failed to create two factor challenge")
+	}
+	if !totp.Enabled() {
+		return nil, nil
+	}
+
+	log.Println("Creating two factor challenge...")
+
+	token, err := utils_token.New()
+	if err != nil {
+		log.Printf("Failed to generate challenge token: %v", err)
+		return nil, errors.New("failed to create two factor challenge")
+	}
+	expiresAt := time.Now().Add(config.TwoFactorChallengeTTL())
+
+	if err := s.TwoFactorRepository.CreateChallenge(ctx, userId, utils_token.Hash(token), expiresAt); err != nil {
+		log.Printf("Failed to create challenge: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		return nil, errors.New("failed to create two factor challenge")
+	}
+
+	log.Println("Created two factor challenge")
+	return &models.TwoFactorChallenge{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// チャレンジトークンと2段階目のコードを確認し、ログインするユーザーを返す
+// 未登録・完了済み・期限切れ・試行回数の上限に達したチャレンジは "invalid challenge token" エラーとする。
+// コードの誤りはパスワードの誤りと同じくログインの失敗として記録し、失敗が続いている場合は
+// "too many login attempts" エラーと次に試せるまでの時間を返す。
+func (s *AuthServiceImpl) CompleteTwoFactorLogin(ctx context.Context, ip, challengeToken, code string) (*models.UserData, time.Duration, error) {
+	log.Println("Completing two factor login...")
+
+	// バリデーション：challengeToken, codeが空でないことを確認
+	if challengeToken == "" || code == "" {
+		log.Println("Challenge token and code are required")
+		return nil, 0, errors.New("challenge token and code are required")
+	}
+
+	tokenHash := utils_token.Hash(challengeToken)
+	userId, err := s.TwoFactorRepository.AttemptChallenge(ctx, tokenHash, maxTwoFactorAttempts)
+	if err != nil {
+		log.Printf("Failed to attempt challenge: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, 0, err
+		}
+		if errors.Is(err, repositories_two_factor.ErrChallengeInvalid) {
+			return nil, 0, errors.New("invalid challenge token")
+		}
+		return nil, 0, errors.New("failed to complete login")
+	}
+
+	user, err := s.UserRepository.FetchUserById(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to fetch user: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, 0, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, 0, errors.New("invalid challenge token")
+		}
+		return nil, 0, errors.New("failed to complete login")
+	}
+
+	// パスワードと同じく、試行を先に記録し、失敗が続いている場合はコードを確認しない
+	attempt, retryAfter, err := s.ReserveLoginAttempt(ctx, ip, user.Email)
+	if err != nil {
+		return nil, retryAfter, err
+	}
+
+	if err := s.verifyTwoFactorCode(ctx, userId, code, "failed to complete login"); err != nil {
+		// コードの誤りは記録をそのまま失敗として残し、それ以外は取り消す
+		if err.Error() != "invalid code" {
+			s.releaseLoginAttempt(ctx, attempt)
+		}
+		if err.Error() == "two factor not enabled" {
+			// チャレンジの発行後に2要素認証が無効にされた
+			return nil, 0, errors.New("invalid challenge token")
+		}
+		return nil, 0, err
+	}
+
+	// コードが正しいため、先に記録した試行を取り消す
+	s.releaseLoginAttempt(ctx, attempt)
+
+	if err := s.TwoFactorRepository.CompleteChallenge(ctx, tokenHash); err != nil {
+		log.Printf("Failed to complete challenge: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, 0, err
+		}
+		if errors.Is(err, repositories_two_factor.ErrChallengeInvalid) {
+			return nil, 0, errors.New("invalid challenge token")
+		}
+		return nil, 0, errors.New("failed to complete login")
+	}
+
+	if err := s.ResetLoginFailures(ctx, user.Email); err != nil {
+		log.Printf("Failed to reset login failures: %v", err)
+	}
+
+	log.Printf("Two factor login completed: %s", user.ID)
+	return user, 0, nil
+}
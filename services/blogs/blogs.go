@@ -3,18 +3,197 @@ package services_blogs
 import (
 	"backend/logger"
 	"backend/models"
+	utils_cache "backend/utils/cache"
+	utils_cursor "backend/utils/cursor"
+	utils_timeout "backend/utils/timeout"
+	"context"
 	"errors"
-	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// ブログ一覧の取得件数の既定値と上限
+const (
+	defaultBlogListLimit = 20
+	maxBlogListLimit     = 100
 )
 
-// 全ブログデータを取得する
-func (s *BlogServiceImpl) FetchBlogs() ([]models.BlogData, error) {
-	return s.BlogRepository.FetchBlogs()
+// 検索条件に一致するブログデータをカーソル単位で取得する
+func (s *BlogServiceImpl) FetchBlogs(ctx context.Context, params models.BlogListParams) (*models.BlogPage, error) {
+	logger.InfoLog.Printf("FetchBlogs start...")
+
+	// バリデーション
+	filter, err := newBlogListFilter(params)
+	if err != nil {
+		logger.ErrorLog.Printf("Invalid params: %v", err)
+		return nil, err
+	}
+	logger.InfoLog.Println("Valid params")
+
+	// キャッシュにない場合はリポジトリから取得
+	page, err := s.Cache.Load(utils_cache.NamespaceBlogs, blogListCacheKey(filter, params.IncludeTotal), func() (interface{}, error) {
+		return s.fetchBlogPage(ctx, filter, params.IncludeTotal)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page.(*models.BlogPage), nil
+}
+
+// ブログ一覧をリポジトリから取得し、次ページのカーソルと総件数を付与する
+func (s *BlogServiceImpl) fetchBlogPage(ctx context.Context, filter models.BlogListFilter, includeTotal bool) (*models.BlogPage, error) {
+	// 次ページの有無を判定するため、1件多く取得する
+	limit := filter.Limit
+	filter.Limit = limit + 1
+
+	// リポジトリを呼び出してブログデータを取得
+	blogs, err := s.BlogRepository.FetchBlogs(ctx, filter)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch blogs: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		return nil, errors.New("failed to fetch blogs")
+	}
+
+	page := &models.BlogPage{
+		Items: []models.BlogData{},
+	}
+	if len(blogs) > limit {
+		blogs = blogs[:limit]
+
+		// 最後の要素の並び替えキーを次ページのカーソルにする
+		last := blogs[len(blogs)-1]
+		cursor := models.BlogCursor{
+			Sort: filter.Sort,
+			ID:   last.ID,
+		}
+		if last.PublishedAt != nil {
+			cursor.PublishedAt = *last.PublishedAt
+		}
+		switch filter.Sort {
+		case models.BlogSortMostLiked:
+			cursor.Count = last.Likes
+		case models.BlogSortMostCommented:
+			cursor.Count = last.CommentCnt
+		}
+		nextCursor, err := utils_cursor.Encode(cursor)
+		if err != nil {
+			logger.ErrorLog.Printf("Failed to encode cursor: %v", err)
+			return nil, errors.New("failed to fetch blogs")
+		}
+		page.NextCursor = &nextCursor
+	}
+	page.Items = append(page.Items, blogs...)
+
+	// 総件数の取得
+	if includeTotal {
+		total, err := s.BlogRepository.CountBlogs(ctx, filter)
+		if err != nil {
+			logger.ErrorLog.Printf("Failed to count blogs: %v", err)
+			if utils_timeout.IsTimeout(err) {
+				return nil, err
+			}
+			return nil, errors.New("failed to fetch blogs")
+		}
+		page.Total = &total
+	}
+
+	logger.InfoLog.Printf("Fetched %d blogs", len(page.Items))
+	return page, nil
+}
+
+// リクエストの取得条件を検証し、リポジトリ用の検索条件に変換する
+func newBlogListFilter(params models.BlogListParams) (models.BlogListFilter, error) {
+	filter := models.BlogListFilter{
+		Limit:    params.Limit,
+		Category: params.Category,
+		Tag:      params.Tag,
+		UserId:   params.UserId,
+		Sort:     params.Sort,
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultBlogListLimit
+	}
+	if filter.Limit < 0 || filter.Limit > maxBlogListLimit {
+		return filter, errors.New("invalid limit")
+	}
+
+	switch filter.Sort {
+	case "":
+		filter.Sort = models.BlogSortNewest
+	case models.BlogSortNewest, models.BlogSortOldest, models.BlogSortMostLiked, models.BlogSortMostCommented:
+	default:
+		return filter, errors.New("invalid sort")
+	}
+
+	if filter.UserId != "" {
+		if _, err := uuid.Parse(filter.UserId); err != nil {
+			return filter, errors.New("invalid userId")
+		}
+	}
+
+	if params.From != "" {
+		from, err := parseBlogListDate(params.From, false)
+		if err != nil {
+			return filter, errors.New("invalid from")
+		}
+		filter.From = &from
+	}
+	if params.To != "" {
+		to, err := parseBlogListDate(params.To, true)
+		if err != nil {
+			return filter, errors.New("invalid to")
+		}
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return filter, errors.New("invalid date range")
+	}
+
+	// カーソルは同じ並び順で発行されたもののみ受け付ける
+	if params.Cursor != "" {
+		var cursor models.BlogCursor
+		if err := utils_cursor.Decode(params.Cursor, &cursor); err != nil {
+			return filter, errors.New("invalid cursor")
+		}
+		if cursor.Sort != filter.Sort || cursor.ID == "" || cursor.PublishedAt.IsZero() {
+			return filter, errors.New("invalid cursor")
+		}
+		if _, err := uuid.Parse(cursor.ID); err != nil {
+			return filter, errors.New("invalid cursor")
+		}
+		filter.After = &cursor
+	}
+
+	return filter, nil
+}
+
+// 日付の絞り込み条件を解析する
+// RFC3339形式またはYYYY-MM-DD形式を受け付け、日付のみの上限はその日の終わりまでを含める。
+func parseBlogListDate(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
 }
 
 // 指定されたユーザーIDに一致するブログデータを取得する
-func (s *BlogServiceImpl) FetchBlogsByUserId(userId string) ([]models.BlogData, error) {
+// 閲覧者(viewerId)が本人の場合は下書き・予約中・非公開のブログも含める。
+func (s *BlogServiceImpl) FetchBlogsByUserId(ctx context.Context, userId, viewerId string) ([]models.BlogData, error) {
 	logger.InfoLog.Printf("FetchBlogsByUserId start...")
 
 	// バリデーション
@@ -25,9 +204,12 @@ func (s *BlogServiceImpl) FetchBlogsByUserId(userId string) ([]models.BlogData,
 	logger.InfoLog.Println("Valid userId")
 
 	// リポジトリを呼び出してブログデータを取得
-	blogs, err := s.BlogRepository.FetchBlogsByUserId(userId)
+	blogs, err := s.BlogRepository.FetchBlogsByUserId(ctx, userId, viewerId == userId)
 	if err != nil {
 		logger.ErrorLog.Printf("Failed to fetch blogs: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
 		return nil, errors.New("blogs not found")
 	}
 
@@ -35,8 +217,15 @@ func (s *BlogServiceImpl) FetchBlogsByUserId(userId string) ([]models.BlogData,
 	return blogs, nil
 }
 
-// 指定されたIDに一致するブログデータを取得する
-func (s *BlogServiceImpl) FetchBlogById(id string) (*models.BlogData, error) {
+// ブログを閲覧者(viewerId)に表示できるか判定する
+// 公開済み以外のブログは投稿者本人にのみ表示する。
+func isVisibleTo(blog *models.BlogData, viewerId string) bool {
+	return blog.Status == models.BlogStatusPublished || blog.UserId == viewerId
+}
+
+// 指定されたIDに一致するブログデータを、本文を変換したHTML・目次・読了時間とともに取得する
+// 公開済み以外のブログは、閲覧者(viewerId)が投稿者本人の場合のみ取得できる。
+func (s *BlogServiceImpl) FetchBlogById(ctx context.Context, id, viewerId string) (*models.BlogDetail, error) {
 	logger.InfoLog.Printf("FetchBlogById start...")
 
 	// バリデーション
@@ -47,18 +236,28 @@ func (s *BlogServiceImpl) FetchBlogById(id string) (*models.BlogData, error) {
 	logger.InfoLog.Println("Valid id")
 
 	// リポジトリを呼び出してブログデータを取得
-	blog, err := s.BlogRepository.FetchBlogById(id)
+	blog, err := s.BlogRepository.FetchBlogById(ctx, id)
 	if err != nil {
 		logger.ErrorLog.Printf("Failed to fetch blog: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		return nil, errors.New("blog not found")
+	}
+
+	// 公開されていないブログは投稿者以外には存在しないものとして扱う
+	if !isVisibleTo(blog, viewerId) {
+		logger.ErrorLog.Printf("Blog is not published: %s", id)
 		return nil, errors.New("blog not found")
 	}
 
 	logger.InfoLog.Printf("Fetched blog successfully: %v", blog)
-	return blog, nil
+	return newBlogDetail(blog), nil
 }
 
 // ブログデータを作成する
-func (s *BlogServiceImpl) CreateBlog(userId, title, githubUrl, category, description, tags string) (*models.BlogData, error) {
+// メールアドレスを確認していないユーザーは "email not verified" エラーとなる。
+func (s *BlogServiceImpl) CreateBlog(ctx context.Context, userId, title, githubUrl, category, description, tags, bodyMarkdown string) (*models.BlogData, error) {
 	logger.InfoLog.Printf("CreateBlog start...")
 
 	// バリデーション
@@ -86,21 +285,50 @@ func (s *BlogServiceImpl) CreateBlog(userId, title, githubUrl, category, descrip
 		logger.ErrorLog.Printf("invalid tags: %s", tags)
 		return nil, errors.New("invalid tags")
 	}
+	if !validBodyMarkdown(bodyMarkdown) {
+		logger.ErrorLog.Printf("invalid bodyMarkdown: %d bytes", len(bodyMarkdown))
+		return nil, errors.New("invalid bodyMarkdown")
+	}
 	logger.InfoLog.Println("Valid input")
 
+	// メールアドレスを確認していないユーザーは投稿できない
+	if err := s.checkCanCreateBlog(ctx, userId, "failed to create blog"); err != nil {
+		return nil, err
+	}
+
+	// 登録済みのカテゴリに解決(未登録のカテゴリは受け付けない)
+	category, err := s.resolveCategory(ctx, category)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to resolve category: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("unknown category")
+		}
+		return nil, errors.New("failed to create blog")
+	}
+
 	// リポジトリを呼び出してブログデータを作成
-	blog, err := s.BlogRepository.CreateBlog(userId, title, githubUrl, category, description, tags)
+	blog, err := s.BlogRepository.CreateBlog(ctx, userId, title, githubUrl, category, description, tags, bodyMarkdown)
 	if err != nil {
 		logger.ErrorLog.Printf("Failed to create blog: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
 		return nil, errors.New("failed to create blog")
 	}
 
+	// 一覧系のキャッシュを無効化
+	s.invalidateCache()
+
 	logger.InfoLog.Printf("Created blog successfully: %v", blog)
 	return blog, nil
 }
 
 // 指定されたIDに一致するブログデータを更新する
-func (s *BlogServiceImpl) UpdateBlog(id, title, githubUrl, category, description, tags string) (*models.BlogData, error) {
+// 更新前の内容は userId を作成者とする版として保存される。権限がない場合は "forbidden" エラーを返す。
+func (s *BlogServiceImpl) UpdateBlog(ctx context.Context, id, userId, title, githubUrl, category, description, tags, bodyMarkdown string) (*models.BlogData, error) {
 	logger.InfoLog.Printf("UpdateBlog start...")
 
 	// バリデーション
@@ -108,6 +336,10 @@ func (s *BlogServiceImpl) UpdateBlog(id, title, githubUrl, category, description
 		logger.ErrorLog.Printf("invalid id: %s", id)
 		return nil, errors.New("invalid id")
 	}
+	if userId == "" {
+		logger.ErrorLog.Printf("invalid userId: %s", userId)
+		return nil, errors.New("invalid userId")
+	}
 	if title == "" {
 		logger.ErrorLog.Printf("invalid title: %s", title)
 		return nil, errors.New("invalid title")
@@ -128,21 +360,50 @@ func (s *BlogServiceImpl) UpdateBlog(id, title, githubUrl, category, description
 		logger.ErrorLog.Printf("invalid tags: %s", tags)
 		return nil, errors.New("invalid tags")
 	}
+	if !validBodyMarkdown(bodyMarkdown) {
+		logger.ErrorLog.Printf("invalid bodyMarkdown: %d bytes", len(bodyMarkdown))
+		return nil, errors.New("invalid bodyMarkdown")
+	}
 	logger.InfoLog.Println("Valid input")
 
+	// 更新できるか確認
+	if _, err := s.fetchAuthorizedBlog(ctx, id, userId, blogActionUpdate, "failed to update blog"); err != nil {
+		return nil, err
+	}
+
+	// 登録済みのカテゴリに解決(未登録のカテゴリは受け付けない)
+	category, err := s.resolveCategory(ctx, category)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to resolve category: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("unknown category")
+		}
+		return nil, errors.New("failed to update blog")
+	}
+
 	// リポジトリを呼び出してブログデータを更新
-	blog, err := s.BlogRepository.UpdateBlog(id, title, githubUrl, category, description, tags)
+	blog, err := s.BlogRepository.UpdateBlog(ctx, id, userId, title, githubUrl, category, description, tags, bodyMarkdown)
 	if err != nil {
 		logger.ErrorLog.Printf("Failed to update blog: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
 		return nil, errors.New("failed to update blog")
 	}
 
+	// 一覧系のキャッシュを無効化
+	s.invalidateCache()
+
 	logger.InfoLog.Printf("Updated blog successfully: %v", blog)
 	return blog, nil
 }
 
 // 指定されたIDに一致するブログデータを削除する
-func (s *BlogServiceImpl) DeleteBlog(id string) error {
+// 権限がない場合は "forbidden" エラーを返す。
+func (s *BlogServiceImpl) DeleteBlog(ctx context.Context, id, userId string) error {
 	logger.InfoLog.Printf("DeleteBlog start...")
 
 	// バリデーション
@@ -150,72 +411,174 @@ func (s *BlogServiceImpl) DeleteBlog(id string) error {
 		logger.ErrorLog.Printf("invalid id: %s", id)
 		return errors.New("invalid id")
 	}
+	if userId == "" {
+		logger.ErrorLog.Printf("invalid userId: %s", userId)
+		return errors.New("invalid userId")
+	}
 	logger.InfoLog.Println("Valid id")
 
+	// 削除できるか確認
+	if _, err := s.fetchAuthorizedBlog(ctx, id, userId, blogActionDelete, "failed to delete blog"); err != nil {
+		return err
+	}
+
 	// リポジトリを呼び出してブログデータを削除
-	err := s.BlogRepository.DeleteBlog(id)
+	err := s.BlogRepository.DeleteBlog(ctx, id)
 	if err != nil {
 		logger.ErrorLog.Printf("Failed to delete blog: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
 		return errors.New("failed to delete blog")
 	}
 
+	// 一覧系のキャッシュを無効化
+	s.invalidateCache()
+
 	logger.InfoLog.Println("Deleted blog successfully")
 	return nil
 }
 
-// ブログカテゴリを取得する
-func (s *BlogServiceImpl) FetchBlogCategories() ([]string, error) {
-	return s.BlogRepository.FetchBlogCategories()
+// 登録済みのカテゴリから名前が一致するもの(大文字小文字を区別しない)を探し、正規のカテゴリ名を返す
+func (s *BlogServiceImpl) resolveCategory(ctx context.Context, name string) (string, error) {
+	category, err := s.CategoryRepository.FetchCategoryByName(ctx, strings.TrimSpace(name))
+	if err != nil {
+		return "", err
+	}
+	return category.Name, nil
 }
 
-// ブログタグを取得する
-func (s *BlogServiceImpl) FetchBlogTags() ([]string, error) {
-	tagsList, err := s.BlogRepository.FetchBlogTags()
+// ブログカテゴリ一覧を表示順に取得する
+func (s *BlogServiceImpl) FetchBlogCategories(ctx context.Context) ([]models.CategoryData, error) {
+	// キャッシュにない場合はリポジトリから取得
+	categories, err := s.Cache.Load(utils_cache.NamespaceCategories, "", func() (interface{}, error) {
+		categories, err := s.CategoryRepository.FetchCategories(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if categories == nil {
+			categories = []models.CategoryData{}
+		}
+		return categories, nil
+	})
 	if err != nil {
-		logger.ErrorLog.Printf("Failed to fetch blog tags: %v", err)
+		logger.ErrorLog.Printf("Failed to fetch blog categories: %v", err)
 		return nil, err
 	}
+	return categories.([]models.CategoryData), nil
+}
 
-	// カンマ区切りのタグを分割し、余分な空白を削除
-	uniqueTags := make(map[string]struct{})
-	for _, tags := range tagsList {
-		splitTags := strings.Split(tags, ",")
-		for _, tag := range splitTags {
-			cleanedTag := strings.TrimSpace(tag)
-			if cleanedTag != "" {
-				uniqueTags[cleanedTag] = struct{}{}
-			}
+// ブログタグ一覧を使用しているブログ数とともに取得する
+func (s *BlogServiceImpl) FetchBlogTags(ctx context.Context) ([]models.TagCount, error) {
+	// キャッシュにない場合はリポジトリから取得
+	tags, err := s.Cache.Load(utils_cache.NamespaceTags, "", func() (interface{}, error) {
+		tags, err := s.BlogRepository.FetchBlogTags(ctx)
+		if err != nil {
+			return nil, err
 		}
+		if tags == nil {
+			tags = []models.TagCount{}
+		}
+		return tags, nil
+	})
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch blog tags: %v", err)
+		return nil, err
 	}
-
-	// ユニークなタグをリスト化し、ソート
-	result := make([]string, 0, len(uniqueTags))
-	for tag := range uniqueTags {
-		result = append(result, tag)
-	}
-	sort.Strings(result)
-
-	// ソートしたタグを返す
-	return result, nil
+	return tags.([]models.TagCount), nil
 }
 
 // 人気のあるブログを取得する
-func (s *BlogServiceImpl) FetchBlogPopular(count int) ([]models.BlogData, error) {
+// 件数はブログ一覧の上限までとし、超える場合は "invalid count" エラーを返す(キャッシュのキーにもなるため)。
+func (s *BlogServiceImpl) FetchBlogPopular(ctx context.Context, count int) ([]models.BlogData, error) {
 
 	// バリデーション
-	if count <= 0 {
+	if count <= 0 || count > maxBlogListLimit {
 		logger.ErrorLog.Printf("invalid count: %d", count)
 		return nil, errors.New("invalid count")
 	}
 	logger.InfoLog.Println("Valid count")
 
-	// リポジトリを呼び出して人気のあるブログを取得
-	blogs, err := s.BlogRepository.FetchBlogPopular(count)
+	// キャッシュにない場合はリポジトリを呼び出して人気のあるブログを取得
+	blogs, err := s.Cache.Load(utils_cache.NamespacePopular, strconv.Itoa(count), func() (interface{}, error) {
+		return s.BlogRepository.FetchBlogPopular(ctx, count)
+	})
 	if err != nil {
 		logger.ErrorLog.Printf("Failed to fetch popular blogs: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
 		return nil, errors.New("failed to fetch popular blogs")
 	}
 
 	logger.InfoLog.Printf("Fetched popular blogs successfully: %v", blogs)
-	return blogs, nil
+	return blogs.([]models.BlogData), nil
+}
+
+// ブログ検索の制限値
+const (
+	maxSearchQueryLength = 100 // 検索クエリの最大文字数
+	maxSearchTerms       = 5   // 検索語の最大数
+)
+
+// 検索クエリに一致するブログを検索する
+// クエリは空白(全角空白を含む)で検索語に分割し、すべての検索語を含むブログをスコア順に返す。
+func (s *BlogServiceImpl) SearchBlogs(ctx context.Context, q string, limit int) (*models.BlogSearchPage, error) {
+	logger.InfoLog.Printf("SearchBlogs start...")
+
+	// バリデーション
+	terms := splitSearchTerms(q)
+	if len(terms) == 0 || len(terms) > maxSearchTerms || utf8.RuneCountInString(q) > maxSearchQueryLength {
+		logger.ErrorLog.Printf("invalid query: %s", q)
+		return nil, errors.New("invalid query")
+	}
+	if limit == 0 {
+		limit = defaultBlogListLimit
+	}
+	if limit < 0 || limit > maxBlogListLimit {
+		logger.ErrorLog.Printf("invalid limit: %d", limit)
+		return nil, errors.New("invalid limit")
+	}
+	logger.InfoLog.Println("Valid query")
+
+	// リポジトリを呼び出してブログを検索
+	results, err := s.BlogRepository.SearchBlogs(ctx, terms, limit)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to search blogs: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		return nil, errors.New("failed to search blogs")
+	}
+
+	// 検索語をハイライトした抜粋を付与
+	page := &models.BlogSearchPage{
+		Items: []models.BlogSearchResult{},
+	}
+	for _, result := range results {
+		result.Highlights = models.BlogSearchHighlights{
+			Title:       highlight(result.Title, terms),
+			Tags:        highlight(result.Tags, terms),
+			Description: highlightSnippet(result.Description, terms),
+		}
+		page.Items = append(page.Items, result)
+	}
+
+	logger.InfoLog.Printf("Searched %d blogs", len(page.Items))
+	return page, nil
+}
+
+// 検索クエリを小文字化した検索語に分割する(重複は除く)
+func splitSearchTerms(q string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, term := range strings.Fields(q) {
+		term = strings.ToLower(term)
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		terms = append(terms, term)
+	}
+	return terms
 }
@@ -0,0 +1,27 @@
+package services_blogs
+
+import (
+	"backend/models"
+	utils_markdown "backend/utils/markdown"
+	"unicode/utf8"
+)
+
+// 本文(Markdown)の最大文字数
+const maxBodyMarkdownLength = 100000
+
+// 本文(Markdown)が有効か判定する(空の本文は許可する)
+func validBodyMarkdown(body string) bool {
+	return utf8.ValidString(body) && utf8.RuneCountInString(body) <= maxBodyMarkdownLength
+}
+
+// 本文をHTMLに変換し、目次・単語数・読了時間を加えたブログの詳細を返す
+func newBlogDetail(blog *models.BlogData) *models.BlogDetail {
+	doc := utils_markdown.Render(blog.BodyMarkdown)
+	return &models.BlogDetail{
+		BlogData:    *blog,
+		BodyHTML:    doc.HTML,
+		Toc:         doc.Toc,
+		WordCount:   doc.WordCount,
+		ReadingTime: doc.ReadingTime,
+	}
+}
@@ -0,0 +1,56 @@
+package services_blogs
+
+import (
+	"backend/models"
+	utils_cache "backend/utils/cache"
+	"context"
+	"fmt"
+	"time"
+)
+
+// ブログ一覧のキャッシュのキーを組み立てる
+func blogListCacheKey(filter models.BlogListFilter, includeTotal bool) string {
+	key := fmt.Sprintf("limit=%d category=%q tag=%q user=%q sort=%q total=%t",
+		filter.Limit, filter.Category, filter.Tag, filter.UserId, filter.Sort, includeTotal)
+	if filter.From != nil {
+		key += " from=" + filter.From.Format(time.RFC3339Nano)
+	}
+	if filter.To != nil {
+		key += " to=" + filter.To.Format(time.RFC3339Nano)
+	}
+	if filter.After != nil {
+		key += fmt.Sprintf(" after=%d,%s,%s", filter.After.Count, filter.After.PublishedAt.Format(time.RFC3339Nano), filter.After.ID)
+	}
+	return key
+}
+
+// ブログの書き込み後に、一覧系のキャッシュを無効化する
+// 公開状態・タグ・カテゴリの変更は、タグ一覧の使用ブログ数やカテゴリ一覧の投稿数にも影響する。
+func (s *BlogServiceImpl) invalidateCache() {
+	s.Cache.Invalidate(
+		utils_cache.NamespaceBlogs,
+		utils_cache.NamespacePopular,
+		utils_cache.NamespaceTags,
+		utils_cache.NamespaceCategories,
+	)
+}
+
+// 一覧系のデータが最後に変更された日時を返す
+// キャッシュを使用しない場合はゼロ値を返す。
+func (s *BlogServiceImpl) LastModified() time.Time {
+	return s.Cache.LastModified(
+		utils_cache.NamespaceBlogs,
+		utils_cache.NamespacePopular,
+		utils_cache.NamespaceTags,
+		utils_cache.NamespaceCategories,
+	)
+}
+
+// キャッシュの名前空間ごとの統計を返す
+// admin・editor のみ取得できる。権限がない場合は "forbidden" エラーを返す。
+func (s *BlogServiceImpl) FetchCacheStats(ctx context.Context, userId string) (map[string]models.CacheStats, error) {
+	if err := s.checkCanManageSite(ctx, userId, "failed to fetch cache stats"); err != nil {
+		return nil, err
+	}
+	return s.Cache.Stats(), nil
+}
@@ -0,0 +1,94 @@
+package services_blogs
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// 抜粋の長さ(文字数)
+const (
+	snippetLength = 100 // 抜粋の最大文字数
+	snippetBefore = 30  // 最初に一致した位置より前に含める文字数
+)
+
+// テキスト中の検索語に一致する位置を返す(大文字小文字を区別しない)
+func matchMask(text []rune, terms []string) []bool {
+	mask := make([]bool, len(text))
+
+	lower := make([]rune, len(text))
+	for i, r := range text {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	for _, term := range terms {
+		t := []rune(strings.ToLower(term))
+		if len(t) == 0 {
+			continue
+		}
+		for i := 0; i+len(t) <= len(lower); i++ {
+			if string(lower[i:i+len(t)]) == string(t) {
+				for j := i; j < i+len(t); j++ {
+					mask[j] = true
+				}
+			}
+		}
+	}
+	return mask
+}
+
+// 一致した範囲を<mark>タグで囲み、それ以外をHTMLエスケープして連結する
+func markRange(text []rune, mask []bool, start, end int) string {
+	var b strings.Builder
+	for i := start; i < end; {
+		j := i
+		for j < end && mask[j] == mask[i] {
+			j++
+		}
+		segment := html.EscapeString(string(text[i:j]))
+		if mask[i] {
+			b.WriteString("<mark>" + segment + "</mark>")
+		} else {
+			b.WriteString(segment)
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// テキスト全体の検索語をハイライトする
+func highlight(text string, terms []string) string {
+	runes := []rune(text)
+	return markRange(runes, matchMask(runes, terms), 0, len(runes))
+}
+
+// 最初に一致した位置の周辺を抜粋し、検索語をハイライトする
+// 一致しない場合は先頭から抜粋する。
+func highlightSnippet(text string, terms []string) string {
+	runes := []rune(text)
+	mask := matchMask(runes, terms)
+
+	start := 0
+	for i, matched := range mask {
+		if matched {
+			start = i - snippetBefore
+			break
+		}
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetLength
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	snippet := markRange(runes, mask, start, end)
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
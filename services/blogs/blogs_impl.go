@@ -2,33 +2,70 @@ package services_blogs
 
 import (
 	"backend/models"
+	repositories_blog_revisions "backend/repositories/blog_revisions"
 	repositories_blogs "backend/repositories/blogs"
+	repositories_categories "backend/repositories/categories"
+	repositories_users "backend/repositories/users"
+	utils_cache "backend/utils/cache"
+	"context"
+	"time"
 )
 
 // BlogServiceインターフェース
 type BlogService interface {
-	FetchBlogs() ([]models.BlogData, error)
-	FetchBlogsByUserId(userId string) ([]models.BlogData, error)
-	FetchBlogById(id string) (*models.BlogData, error)
+	FetchBlogs(ctx context.Context, params models.BlogListParams) (*models.BlogPage, error)
+	FetchBlogsByUserId(ctx context.Context, userId, viewerId string) ([]models.BlogData, error)
+	FetchBlogById(ctx context.Context, id, viewerId string) (*models.BlogDetail, error)
+	FetchBlogBySlug(ctx context.Context, slug, viewerId string) (*models.BlogDetail, error)
 
-	CreateBlog(userId, title, githubUrl, category, description, tags string) (*models.BlogData, error)
-	UpdateBlog(id, title, githubUrl, category, description, tags string) (*models.BlogData, error)
-	DeleteBlog(id string) error
+	CreateBlog(ctx context.Context, userId, title, githubUrl, category, description, tags, bodyMarkdown string) (*models.BlogData, error)
+	UpdateBlog(ctx context.Context, id, userId, title, githubUrl, category, description, tags, bodyMarkdown string) (*models.BlogData, error)
+	DeleteBlog(ctx context.Context, id, userId string) error
 
-	FetchBlogCategories() ([]string, error)
-	FetchBlogTags() ([]string, error)
-	FetchBlogPopular(count int) ([]models.BlogData, error)
+	FetchDeletedBlogs(ctx context.Context, userId string) ([]models.BlogData, error)
+	RestoreBlog(ctx context.Context, id, userId string) (*models.BlogData, error)
+	PurgeDeletedBlogs(ctx context.Context, retention time.Duration) (int, error)
+
+	UpdateBlogStatus(ctx context.Context, id, userId, status string, publishedAt *time.Time) (*models.BlogData, error)
+	PublishScheduledBlogs(ctx context.Context) (int, error)
+
+	UpdateBlogSlug(ctx context.Context, id, userId, slug string) (*models.BlogData, error)
+
+	FetchBlogRevisions(ctx context.Context, id, userId string) ([]models.BlogRevisionData, error)
+	FetchBlogRevision(ctx context.Context, id string, revision int, userId string) (*models.BlogRevisionData, error)
+	DiffBlogRevisions(ctx context.Context, id string, from, to int, userId string) (*models.BlogRevisionDiff, error)
+	RevertBlog(ctx context.Context, id string, revision int, userId string) (*models.BlogData, error)
+
+	FetchBlogCategories(ctx context.Context) ([]models.CategoryData, error)
+	FetchBlogTags(ctx context.Context) ([]models.TagCount, error)
+	FetchBlogPopular(ctx context.Context, count int) ([]models.BlogData, error)
+	SearchBlogs(ctx context.Context, q string, limit int) (*models.BlogSearchPage, error)
+
+	LastModified() time.Time
+	FetchCacheStats(ctx context.Context, userId string) (map[string]models.CacheStats, error)
 }
 
 type BlogServiceImpl struct {
-	BlogRepository repositories_blogs.BlogRepository
+	BlogRepository         repositories_blogs.BlogRepository
+	CategoryRepository     repositories_categories.CategoryRepository
+	BlogRevisionRepository repositories_blog_revisions.BlogRevisionRepository
+	UserRepository         repositories_users.UserRepository
+	Cache                  *utils_cache.Cache
 }
 
 // BlogServiceインターフェースを実装したBlogServiceImplのポインタを返す
 func NewBlogService(
 	blogRepository repositories_blogs.BlogRepository,
+	categoryRepository repositories_categories.CategoryRepository,
+	blogRevisionRepository repositories_blog_revisions.BlogRevisionRepository,
+	userRepository repositories_users.UserRepository,
+	cache *utils_cache.Cache,
 ) BlogService {
 	return &BlogServiceImpl{
-		BlogRepository: blogRepository,
+		BlogRepository:         blogRepository,
+		CategoryRepository:     categoryRepository,
+		BlogRevisionRepository: blogRevisionRepository,
+		UserRepository:         userRepository,
+		Cache:                  cache,
 	}
 }
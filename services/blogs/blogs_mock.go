@@ -2,6 +2,8 @@ package services_blogs
 
 import (
 	"backend/models"
+	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,71 +12,174 @@ type MockBlogService struct {
 	mock.Mock
 }
 
-func (m *MockBlogService) FetchBlogs() ([]models.BlogData, error) {
-	args := m.Called()
+func (m *MockBlogService) FetchBlogs(ctx context.Context, params models.BlogListParams) (*models.BlogPage, error) {
+	args := m.Called(params)
 	if args.Get(0) != nil {
-		return args.Get(0).([]models.BlogData), args.Error(1)
+		return args.Get(0).(*models.BlogPage), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockBlogService) FetchBlogsByUserId(userId string) ([]models.BlogData, error) {
-	args := m.Called(userId)
+func (m *MockBlogService) FetchBlogsByUserId(ctx context.Context, userId, viewerId string) ([]models.BlogData, error) {
+	args := m.Called(userId, viewerId)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]models.BlogData), args.Error(1)
 }
 
-func (m *MockBlogService) FetchBlogById(id string) (*models.BlogData, error) {
-	args := m.Called(id)
+func (m *MockBlogService) FetchBlogById(ctx context.Context, id, viewerId string) (*models.BlogDetail, error) {
+	args := m.Called(id, viewerId)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*models.BlogData), args.Error(1)
+	return args.Get(0).(*models.BlogDetail), args.Error(1)
 }
 
-func (m *MockBlogService) CreateBlog(userId, title, githubUrl, category, description, tags string) (*models.BlogData, error) {
-	args := m.Called(userId, title, githubUrl, category, description, tags)
+func (m *MockBlogService) CreateBlog(ctx context.Context, userId, title, githubUrl, category, description, tags, bodyMarkdown string) (*models.BlogData, error) {
+	args := m.Called(userId, title, githubUrl, category, description, tags, bodyMarkdown)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.BlogData), args.Error(1)
 }
 
-func (m *MockBlogService) UpdateBlog(id, title, githubUrl, category, description, tags string) (*models.BlogData, error) {
-	args := m.Called(id, title, githubUrl, category, description, tags)
+func (m *MockBlogService) UpdateBlog(ctx context.Context, id, userId, title, githubUrl, category, description, tags, bodyMarkdown string) (*models.BlogData, error) {
+	args := m.Called(id, userId, title, githubUrl, category, description, tags, bodyMarkdown)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.BlogData), args.Error(1)
 }
 
-func (m *MockBlogService) DeleteBlog(id string) error {
-	args := m.Called(id)
+func (m *MockBlogService) DeleteBlog(ctx context.Context, id, userId string) error {
+	args := m.Called(id, userId)
 	return args.Error(0)
 }
 
-func (m *MockBlogService) FetchBlogCategories() ([]string, error) {
+func (m *MockBlogService) FetchBlogCategories(ctx context.Context) ([]models.CategoryData, error) {
 	args := m.Called()
 	if args.Get(0) != nil {
-		return args.Get(0).([]string), args.Error(1)
+		return args.Get(0).([]models.CategoryData), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockBlogService) FetchBlogTags() ([]string, error) {
+func (m *MockBlogService) FetchBlogTags(ctx context.Context) ([]models.TagCount, error) {
 	args := m.Called()
 	if args.Get(0) != nil {
-		return args.Get(0).([]string), args.Error(1)
+		return args.Get(0).([]models.TagCount), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
 
-func (m *MockBlogService) FetchBlogPopular(count int) ([]models.BlogData, error) {
+func (m *MockBlogService) FetchBlogPopular(ctx context.Context, count int) ([]models.BlogData, error) {
 	args := m.Called(count)
 	if args.Get(0) != nil {
 		return args.Get(0).([]models.BlogData), args.Error(1)
 	}
 	return nil, args.Error(1)
 }
+
+func (m *MockBlogService) SearchBlogs(ctx context.Context, q string, limit int) (*models.BlogSearchPage, error) {
+	args := m.Called(q, limit)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.BlogSearchPage), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBlogService) FetchDeletedBlogs(ctx context.Context, userId string) ([]models.BlogData, error) {
+	args := m.Called(userId)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.BlogData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBlogService) RestoreBlog(ctx context.Context, id, userId string) (*models.BlogData, error) {
+	args := m.Called(id, userId)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.BlogData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBlogService) PurgeDeletedBlogs(ctx context.Context, retention time.Duration) (int, error) {
+	args := m.Called(retention)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBlogService) UpdateBlogStatus(ctx context.Context, id, userId, status string, publishedAt *time.Time) (*models.BlogData, error) {
+	args := m.Called(id, userId, status, publishedAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BlogData), args.Error(1)
+}
+
+func (m *MockBlogService) PublishScheduledBlogs(ctx context.Context) (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBlogService) FetchBlogRevisions(ctx context.Context, id, userId string) ([]models.BlogRevisionData, error) {
+	args := m.Called(id, userId)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.BlogRevisionData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBlogService) FetchBlogRevision(ctx context.Context, id string, revision int, userId string) (*models.BlogRevisionData, error) {
+	args := m.Called(id, revision, userId)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.BlogRevisionData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBlogService) DiffBlogRevisions(ctx context.Context, id string, from, to int, userId string) (*models.BlogRevisionDiff, error) {
+	args := m.Called(id, from, to, userId)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.BlogRevisionDiff), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBlogService) RevertBlog(ctx context.Context, id string, revision int, userId string) (*models.BlogData, error) {
+	args := m.Called(id, revision, userId)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.BlogData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockBlogService) FetchBlogBySlug(ctx context.Context, slug, viewerId string) (*models.BlogDetail, error) {
+	args := m.Called(slug, viewerId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BlogDetail), args.Error(1)
+}
+
+func (m *MockBlogService) UpdateBlogSlug(ctx context.Context, id, userId, slug string) (*models.BlogData, error) {
+	args := m.Called(id, userId, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BlogData), args.Error(1)
+}
+
+func (m *MockBlogService) LastModified() time.Time {
+	args := m.Called()
+	return args.Get(0).(time.Time)
+}
+
+func (m *MockBlogService) FetchCacheStats(ctx context.Context, userId string) (map[string]models.CacheStats, error) {
+	args := m.Called(userId)
+	if args.Get(0) != nil {
+		return args.Get(0).(map[string]models.CacheStats), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
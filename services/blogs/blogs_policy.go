@@ -0,0 +1,114 @@
+package services_blogs
+
+import (
+	"backend/logger"
+	"backend/models"
+	utils_timeout "backend/utils/timeout"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// ブログに対する操作の種類
+const (
+	blogActionUpdate  = "update"  // 内容・公開状態・スラッグの変更、版の閲覧・復元
+	blogActionDelete  = "delete"  // ゴミ箱への移動
+	blogActionRestore = "restore" // ゴミ箱からの復元
+)
+
+// 権限ごとにブログの操作を許可するか判定する
+// author は自分のブログのみ、editor・admin はすべてのブログを操作できる。
+func canModifyBlog(role string, isOwner bool) bool {
+	switch role {
+	case models.UserRoleAdmin, models.UserRoleEditor:
+		return true
+	case models.UserRoleAuthor:
+		return isOwner
+	}
+	return false
+}
+
+// 操作対象のブログを取得し、ログイン中のユーザーが操作できるか確認する
+// 復元ではゴミ箱内のブログを、それ以外ではゴミ箱に入っていないブログを対象とする。
+// ブログがない場合は "blog not found"、権限がない場合は "forbidden"、それ以外の失敗は failure のエラーを返す。
+// 権限は変更が即時に反映されるよう、トークンではなくユーザー情報から取得する。
+func (s *BlogServiceImpl) fetchAuthorizedBlog(ctx context.Context, id, userId, action, failure string) (*models.BlogData, error) {
+	fetchBlog := s.BlogRepository.FetchBlogById
+	if action == blogActionRestore {
+		fetchBlog = s.BlogRepository.FetchDeletedBlogById
+	}
+	blog, err := fetchBlog(ctx, id)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch blog: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("blog not found")
+		}
+		return nil, errors.New(failure)
+	}
+
+	user, err := s.UserRepository.FetchUserById(ctx, userId)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch user: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("forbidden")
+		}
+		return nil, errors.New(failure)
+	}
+
+	if !canModifyBlog(user.Role, blog.UserId == user.ID) {
+		logger.ErrorLog.Printf("User %s (%s) is not allowed to %s blog %s", userId, user.Role, action, id)
+		return nil, errors.New("forbidden")
+	}
+	return blog, nil
+}
+
+// ログイン中のユーザーがブログを投稿できるか確認する
+// メールアドレスを確認していない場合は "email not verified"、ユーザーがない場合は "forbidden"、それ以外の失敗は failure のエラーを返す。
+func (s *BlogServiceImpl) checkCanCreateBlog(ctx context.Context, userId, failure string) error {
+	user, err := s.UserRepository.FetchUserById(ctx, userId)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch user: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("forbidden")
+		}
+		return errors.New(failure)
+	}
+
+	if !user.EmailVerified() {
+		logger.ErrorLog.Printf("User %s has not verified email", userId)
+		return errors.New("email not verified")
+	}
+	return nil
+}
+
+// ログイン中のユーザーがサイト全体に関わる情報(キャッシュの統計など)を扱えるか確認する
+// admin・editor 以外の場合とユーザーがない場合は "forbidden"、それ以外の失敗は failure のエラーを返す。
+func (s *BlogServiceImpl) checkCanManageSite(ctx context.Context, userId, failure string) error {
+	user, err := s.UserRepository.FetchUserById(ctx, userId)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch user: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("forbidden")
+		}
+		return errors.New(failure)
+	}
+
+	if !models.CanManageSite(user.Role) {
+		logger.ErrorLog.Printf("User %s (%s) is not allowed to manage site", userId, user.Role)
+		return errors.New("forbidden")
+	}
+	return nil
+}
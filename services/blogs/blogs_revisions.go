@@ -0,0 +1,232 @@
+package services_blogs
+
+import (
+	"backend/logger"
+	"backend/models"
+	utils_timeout "backend/utils/timeout"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// 指定されたブログの版履歴を新しい順に取得する
+// 版には下書きや非公開の内容も含まれるため、ブログを変更できるユーザーのみ取得できる。権限がない場合は "forbidden" エラーを返す。
+func (s *BlogServiceImpl) FetchBlogRevisions(ctx context.Context, id, userId string) ([]models.BlogRevisionData, error) {
+	logger.InfoLog.Printf("FetchBlogRevisions start...")
+
+	// バリデーション
+	if _, err := uuid.Parse(id); err != nil {
+		logger.ErrorLog.Printf("invalid id: %s", id)
+		return nil, errors.New("invalid id")
+	}
+	if userId == "" {
+		logger.ErrorLog.Printf("invalid userId: %s", userId)
+		return nil, errors.New("invalid userId")
+	}
+	logger.InfoLog.Println("Valid input")
+
+	// 閲覧できるか確認する(ブログが存在しない場合と版履歴が空の場合も区別する)
+	if _, err := s.fetchAuthorizedBlog(ctx, id, userId, blogActionUpdate, "failed to fetch revisions"); err != nil {
+		return nil, err
+	}
+
+	// リポジトリを呼び出して版履歴を取得
+	revisions, err := s.BlogRevisionRepository.FetchRevisionsByBlogId(ctx, id)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch revisions: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		return nil, errors.New("failed to fetch revisions")
+	}
+
+	if revisions == nil {
+		revisions = []models.BlogRevisionData{}
+	}
+
+	logger.InfoLog.Printf("Fetched revisions successfully: %d", len(revisions))
+	return revisions, nil
+}
+
+// 指定されたブログの指定された版を取得する
+// 版履歴と同じく、ブログを変更できるユーザーのみ取得できる。権限がない場合は "forbidden" エラーを返す。
+func (s *BlogServiceImpl) FetchBlogRevision(ctx context.Context, id string, revision int, userId string) (*models.BlogRevisionData, error) {
+	logger.InfoLog.Printf("FetchBlogRevision start...")
+
+	// バリデーション
+	if _, err := uuid.Parse(id); err != nil {
+		logger.ErrorLog.Printf("invalid id: %s", id)
+		return nil, errors.New("invalid id")
+	}
+	if revision < 1 {
+		logger.ErrorLog.Printf("invalid revision: %d", revision)
+		return nil, errors.New("invalid revision")
+	}
+	if userId == "" {
+		logger.ErrorLog.Printf("invalid userId: %s", userId)
+		return nil, errors.New("invalid userId")
+	}
+	logger.InfoLog.Println("Valid input")
+
+	// 閲覧できるか確認
+	if _, err := s.fetchAuthorizedBlog(ctx, id, userId, blogActionUpdate, "failed to fetch revision"); err != nil {
+		return nil, err
+	}
+
+	result, err := s.fetchRevision(ctx, id, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.InfoLog.Printf("Fetched revision successfully: %v", result)
+	return result, nil
+}
+
+// 指定されたブログの2つの版のフィールド単位の差分を取得する
+// 版履歴と同じく、ブログを変更できるユーザーのみ取得できる。権限がない場合は "forbidden" エラーを返す。
+func (s *BlogServiceImpl) DiffBlogRevisions(ctx context.Context, id string, from, to int, userId string) (*models.BlogRevisionDiff, error) {
+	logger.InfoLog.Printf("DiffBlogRevisions start...")
+
+	// バリデーション
+	if _, err := uuid.Parse(id); err != nil {
+		logger.ErrorLog.Printf("invalid id: %s", id)
+		return nil, errors.New("invalid id")
+	}
+	if from < 1 || to < 1 {
+		logger.ErrorLog.Printf("invalid revision: from=%d, to=%d", from, to)
+		return nil, errors.New("invalid revision")
+	}
+	if userId == "" {
+		logger.ErrorLog.Printf("invalid userId: %s", userId)
+		return nil, errors.New("invalid userId")
+	}
+	logger.InfoLog.Println("Valid input")
+
+	// 閲覧できるか確認
+	if _, err := s.fetchAuthorizedBlog(ctx, id, userId, blogActionUpdate, "failed to diff revisions"); err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := s.fetchRevision(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := s.fetchRevision(ctx, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &models.BlogRevisionDiff{
+		BlogId:  id,
+		From:    from,
+		To:      to,
+		Changes: diffRevisionFields(fromRevision, toRevision),
+	}
+
+	logger.InfoLog.Printf("Diffed revisions successfully: %d changes", len(diff.Changes))
+	return diff, nil
+}
+
+// ブログを指定された版の内容に戻す
+// 通常の更新と同様に、戻す直前の内容は userId を作成者とする新しい版として保存される。権限がない場合は "forbidden" エラーを返す。
+func (s *BlogServiceImpl) RevertBlog(ctx context.Context, id string, revision int, userId string) (*models.BlogData, error) {
+	logger.InfoLog.Printf("RevertBlog start...")
+
+	// バリデーション
+	if _, err := uuid.Parse(id); err != nil {
+		logger.ErrorLog.Printf("invalid id: %s", id)
+		return nil, errors.New("invalid id")
+	}
+	if revision < 1 {
+		logger.ErrorLog.Printf("invalid revision: %d", revision)
+		return nil, errors.New("invalid revision")
+	}
+	if userId == "" {
+		logger.ErrorLog.Printf("invalid userId: %s", userId)
+		return nil, errors.New("invalid userId")
+	}
+	logger.InfoLog.Println("Valid input")
+
+	// 戻せるか確認
+	if _, err := s.fetchAuthorizedBlog(ctx, id, userId, blogActionUpdate, "failed to revert blog"); err != nil {
+		return nil, err
+	}
+
+	target, err := s.fetchRevision(ctx, id, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	// 版の保存後にカテゴリ名が変更・削除されている場合があるため、登録済みのカテゴリに解決し直す
+	category, err := s.resolveCategory(ctx, target.Category)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to resolve category: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("unknown category")
+		}
+		return nil, errors.New("failed to revert blog")
+	}
+
+	// リポジトリを呼び出してブログデータを更新
+	blog, err := s.BlogRepository.UpdateBlog(ctx, id, userId, target.Title, target.GithubUrl, category, target.Description, target.Tags, target.BodyMarkdown)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to revert blog: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("blog not found")
+		}
+		return nil, errors.New("failed to revert blog")
+	}
+
+	// 一覧系のキャッシュを無効化
+	s.invalidateCache()
+
+	logger.InfoLog.Printf("Reverted blog successfully: %v", blog)
+	return blog, nil
+}
+
+// 版を取得し、リポジトリのエラーをサービスのエラーに変換する
+func (s *BlogServiceImpl) fetchRevision(ctx context.Context, id string, revision int) (*models.BlogRevisionData, error) {
+	result, err := s.BlogRevisionRepository.FetchRevision(ctx, id, revision)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch revision: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("revision not found")
+		}
+		return nil, errors.New("failed to fetch revision")
+	}
+	return result, nil
+}
+
+// 2つの版で値が異なるフィールドを返す
+func diffRevisionFields(from, to *models.BlogRevisionData) []models.BlogFieldChange {
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"title", from.Title, to.Title},
+		{"description", from.Description, to.Description},
+		{"github_url", from.GithubUrl, to.GithubUrl},
+		{"category", from.Category, to.Category},
+		{"tags", from.Tags, to.Tags},
+		{"body_markdown", from.BodyMarkdown, to.BodyMarkdown},
+	}
+
+	changes := []models.BlogFieldChange{}
+	for _, field := range fields {
+		if field.from != field.to {
+			changes = append(changes, models.BlogFieldChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,97 @@
+package services_blogs
+
+import (
+	"backend/logger"
+	"backend/models"
+	repositories_blogs "backend/repositories/blogs"
+	utils_slug "backend/utils/slug"
+	utils_timeout "backend/utils/timeout"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// 指定されたスラッグに一致するブログデータを取得する
+// 変更前のスラッグの場合も現在のブログデータを返す(返却したブログのスラッグと比較してリダイレクトすること)。
+// 公開済み以外のブログは、閲覧者(viewerId)が投稿者本人の場合のみ取得できる。
+func (s *BlogServiceImpl) FetchBlogBySlug(ctx context.Context, slug, viewerId string) (*models.BlogDetail, error) {
+	logger.InfoLog.Printf("FetchBlogBySlug start...")
+
+	// バリデーション
+	if !utils_slug.Valid(slug) {
+		logger.ErrorLog.Printf("invalid slug: %s", slug)
+		return nil, errors.New("invalid slug")
+	}
+	logger.InfoLog.Println("Valid slug")
+
+	// リポジトリを呼び出してブログデータを取得
+	blog, err := s.BlogRepository.FetchBlogBySlug(ctx, slug)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch blog: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		return nil, errors.New("blog not found")
+	}
+
+	// 公開されていないブログは投稿者以外には存在しないものとして扱う
+	if !isVisibleTo(blog, viewerId) {
+		logger.ErrorLog.Printf("Blog is not published: %s", slug)
+		return nil, errors.New("blog not found")
+	}
+
+	logger.InfoLog.Printf("Fetched blog successfully: %v", blog)
+	return newBlogDetail(blog), nil
+}
+
+// 指定されたブログのスラッグを変更する
+// 変更前のスラッグは古いリンクからのリダイレクト用に保持される。
+func (s *BlogServiceImpl) UpdateBlogSlug(ctx context.Context, id, userId, slug string) (*models.BlogData, error) {
+	logger.InfoLog.Printf("UpdateBlogSlug start...")
+
+	// バリデーション
+	slug = strings.TrimSpace(slug)
+	if _, err := uuid.Parse(id); err != nil {
+		logger.ErrorLog.Printf("invalid id: %s", id)
+		return nil, errors.New("invalid id")
+	}
+	if _, err := uuid.Parse(userId); err != nil {
+		logger.ErrorLog.Printf("invalid userId: %s", userId)
+		return nil, errors.New("invalid userId")
+	}
+	if !utils_slug.Valid(slug) {
+		logger.ErrorLog.Printf("invalid slug: %s", slug)
+		return nil, errors.New("invalid slug")
+	}
+	logger.InfoLog.Println("Valid input")
+
+	// 変更できるか確認
+	if _, err := s.fetchAuthorizedBlog(ctx, id, userId, blogActionUpdate, "failed to update blog slug"); err != nil {
+		return nil, err
+	}
+
+	// リポジトリを呼び出してスラッグを変更
+	updated, err := s.BlogRepository.UpdateBlogSlug(ctx, id, slug)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to update blog slug: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, repositories_blogs.ErrBlogSlugConflict) {
+			return nil, errors.New("slug conflict")
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("blog not found")
+		}
+		return nil, errors.New("failed to update blog slug")
+	}
+
+	// 一覧系のキャッシュを無効化
+	s.invalidateCache()
+
+	logger.InfoLog.Printf("Updated blog slug successfully: %v", updated)
+	return updated, nil
+}
@@ -0,0 +1,154 @@
+package services_blogs
+
+import (
+	"backend/logger"
+	"backend/models"
+	utils_timeout "backend/utils/timeout"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// 現在の公開状態から変更できる公開状態
+// 公開済みのブログを取り下げる場合は下書きではなく非公開にする。
+var blogStatusTransitions = map[string][]string{
+	models.BlogStatusDraft:       {models.BlogStatusScheduled, models.BlogStatusPublished},
+	models.BlogStatusScheduled:   {models.BlogStatusDraft, models.BlogStatusScheduled, models.BlogStatusPublished},
+	models.BlogStatusPublished:   {models.BlogStatusUnpublished},
+	models.BlogStatusUnpublished: {models.BlogStatusDraft, models.BlogStatusScheduled, models.BlogStatusPublished},
+}
+
+// 公開状態が from から to へ変更できるか判定する
+func canTransitBlogStatus(from, to string) bool {
+	for _, status := range blogStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// 指定されたブログの公開状態を変更する
+// 予約(scheduled)には未来の公開日時が必要で、公開(published)で公開日時を省略した場合は現在日時を使用する。
+func (s *BlogServiceImpl) UpdateBlogStatus(ctx context.Context, id, userId, status string, publishedAt *time.Time) (*models.BlogData, error) {
+	logger.InfoLog.Printf("UpdateBlogStatus start...")
+
+	// バリデーション
+	if _, err := uuid.Parse(id); err != nil {
+		logger.ErrorLog.Printf("invalid id: %s", id)
+		return nil, errors.New("invalid id")
+	}
+	if _, err := uuid.Parse(userId); err != nil {
+		logger.ErrorLog.Printf("invalid userId: %s", userId)
+		return nil, errors.New("invalid userId")
+	}
+	if _, ok := blogStatusTransitions[status]; !ok {
+		logger.ErrorLog.Printf("invalid status: %s", status)
+		return nil, errors.New("invalid status")
+	}
+	now := time.Now()
+	switch status {
+	case models.BlogStatusScheduled:
+		if publishedAt == nil || !publishedAt.After(now) {
+			logger.ErrorLog.Printf("invalid publishedAt: %v", publishedAt)
+			return nil, errors.New("invalid publishedAt")
+		}
+	case models.BlogStatusPublished:
+		if publishedAt == nil {
+			publishedAt = &now
+		}
+		if publishedAt.After(now) {
+			logger.ErrorLog.Printf("invalid publishedAt: %v", publishedAt)
+			return nil, errors.New("invalid publishedAt")
+		}
+	}
+	logger.InfoLog.Println("Valid input")
+
+	// 変更前のブログデータを取得し、変更できるか確認
+	blog, err := s.fetchAuthorizedBlog(ctx, id, userId, blogActionUpdate, "failed to update blog status")
+	if err != nil {
+		return nil, err
+	}
+	if !canTransitBlogStatus(blog.Status, status) {
+		logger.ErrorLog.Printf("invalid status transition: %s -> %s", blog.Status, status)
+		return nil, errors.New("invalid status transition")
+	}
+
+	switch status {
+	case models.BlogStatusDraft:
+		// 下書きは公開日時を持たない
+		publishedAt = nil
+	case models.BlogStatusUnpublished:
+		// 非公開にしても最初に公開した日時は保持する
+		publishedAt = blog.PublishedAt
+	}
+
+	// 取得時の状態から変わっていない場合だけ更新する(予約公開と同時に変更された場合は競合として扱う)
+	updated, err := s.BlogRepository.UpdateBlogStatus(ctx, id, blog.Status, status, publishedAt)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to update blog status: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("invalid status transition")
+		}
+		return nil, errors.New("failed to update blog status")
+	}
+
+	// 一覧系のキャッシュを無効化
+	s.invalidateCache()
+
+	logger.InfoLog.Printf("Updated blog status successfully: %v", updated)
+	return updated, nil
+}
+
+// 公開予定日時を過ぎた予約中のブログを公開する
+func (s *BlogServiceImpl) PublishScheduledBlogs(ctx context.Context) (int, error) {
+	logger.InfoLog.Printf("PublishScheduledBlogs start...")
+
+	// リポジトリを呼び出して予約中のブログを公開
+	published, err := s.BlogRepository.PublishScheduledBlogs(ctx, time.Now())
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to publish scheduled blogs: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return 0, err
+		}
+		return 0, errors.New("failed to publish scheduled blogs")
+	}
+
+	// 公開したブログがある場合は一覧系のキャッシュを無効化
+	if published > 0 {
+		s.invalidateCache()
+	}
+
+	logger.InfoLog.Printf("Published %d scheduled blogs", published)
+	return published, nil
+}
+
+// 予約中のブログを一定間隔で公開する
+// ctx がキャンセルされるまでブロックするため、goroutine として起動すること。
+// 公開は条件付きの UPDATE 1文で行うため、複数のインスタンスで同時に起動しても問題ない。
+func RunPublishScheduler(ctx context.Context, service BlogService, interval time.Duration) {
+	logger.InfoLog.Printf("Publish scheduler started (interval: %s)", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// 前回の実行が失敗しても次の間隔で再試行する
+		if _, err := service.PublishScheduledBlogs(ctx); err != nil {
+			logger.ErrorLog.Printf("Scheduled publish failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.InfoLog.Println("Publish scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package services_blogs
+
+import (
+	"backend/logger"
+	"backend/models"
+	utils_timeout "backend/utils/timeout"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// ログイン中のユーザーが復元できるゴミ箱内のブログデータを取得する
+// author は自分のブログのみ、editor・admin はすべてのユーザーのブログを取得する。
+func (s *BlogServiceImpl) FetchDeletedBlogs(ctx context.Context, userId string) ([]models.BlogData, error) {
+	logger.InfoLog.Printf("FetchDeletedBlogs start...")
+
+	// バリデーション
+	if _, err := uuid.Parse(userId); err != nil {
+		logger.ErrorLog.Printf("invalid userId: %s", userId)
+		return nil, errors.New("invalid userId")
+	}
+	logger.InfoLog.Println("Valid userId")
+
+	// 権限は変更が即時に反映されるよう、ユーザー情報から取得する
+	user, err := s.UserRepository.FetchUserById(ctx, userId)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch user: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("forbidden")
+		}
+		return nil, errors.New("failed to fetch deleted blogs")
+	}
+
+	// リポジトリを呼び出してゴミ箱内のブログデータを取得(他人のブログも操作できる権限ではすべてを対象とする)
+	var blogs []models.BlogData
+	if canModifyBlog(user.Role, false) {
+		blogs, err = s.BlogRepository.FetchDeletedBlogs(ctx)
+	} else {
+		blogs, err = s.BlogRepository.FetchDeletedBlogsByUserId(ctx, userId)
+	}
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to fetch deleted blogs: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		return nil, errors.New("failed to fetch deleted blogs")
+	}
+
+	if blogs == nil {
+		blogs = []models.BlogData{}
+	}
+
+	logger.InfoLog.Printf("Fetched deleted blogs successfully: %v", blogs)
+	return blogs, nil
+}
+
+// ゴミ箱内のブログデータを復元する
+// 復元できるのはブログを削除できるユーザーのみ(権限がない場合は "forbidden" エラーを返す)。
+func (s *BlogServiceImpl) RestoreBlog(ctx context.Context, id, userId string) (*models.BlogData, error) {
+	logger.InfoLog.Printf("RestoreBlog start...")
+
+	// バリデーション
+	if _, err := uuid.Parse(id); err != nil {
+		logger.ErrorLog.Printf("invalid id: %s", id)
+		return nil, errors.New("invalid id")
+	}
+	if _, err := uuid.Parse(userId); err != nil {
+		logger.ErrorLog.Printf("invalid userId: %s", userId)
+		return nil, errors.New("invalid userId")
+	}
+	logger.InfoLog.Println("Valid id and userId")
+
+	// ゴミ箱内のブログを取得し、復元できるか確認
+	if _, err := s.fetchAuthorizedBlog(ctx, id, userId, blogActionRestore, "failed to restore blog"); err != nil {
+		return nil, err
+	}
+
+	// リポジトリを呼び出してブログデータを復元
+	blog, err := s.BlogRepository.RestoreBlog(ctx, id)
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to restore blog: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("blog not found")
+		}
+		return nil, errors.New("failed to restore blog")
+	}
+
+	// 一覧系のキャッシュを無効化
+	s.invalidateCache()
+
+	logger.InfoLog.Printf("Restored blog successfully: %v", blog)
+	return blog, nil
+}
+
+// ゴミ箱に移動してから保持期間を過ぎたブログを完全に削除する
+func (s *BlogServiceImpl) PurgeDeletedBlogs(ctx context.Context, retention time.Duration) (int, error) {
+	logger.InfoLog.Printf("PurgeDeletedBlogs start...")
+
+	// バリデーション
+	if retention <= 0 {
+		logger.ErrorLog.Printf("invalid retention: %s", retention)
+		return 0, errors.New("invalid retention")
+	}
+
+	// リポジトリを呼び出してブログデータを完全に削除
+	purged, err := s.BlogRepository.PurgeDeletedBlogs(ctx, time.Now().Add(-retention))
+	if err != nil {
+		logger.ErrorLog.Printf("Failed to purge deleted blogs: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return 0, err
+		}
+		return 0, errors.New("failed to purge deleted blogs")
+	}
+
+	// 削除したブログがある場合は一覧系のキャッシュを無効化
+	if purged > 0 {
+		s.invalidateCache()
+	}
+
+	logger.InfoLog.Printf("Purged %d deleted blogs", purged)
+	return purged, nil
+}
+
+// ゴミ箱内のブログを一定間隔で完全に削除する
+// ctx がキャンセルされるまでブロックするため、goroutine として起動すること。
+func RunTrashPurger(ctx context.Context, service BlogService, interval, retention time.Duration) {
+	logger.InfoLog.Printf("Trash purger started (interval: %s, retention: %s)", interval, retention)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// 前回の実行が失敗しても次の間隔で再試行する
+		if _, err := service.PurgeDeletedBlogs(ctx, retention); err != nil {
+			logger.ErrorLog.Printf("Trash purge failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.InfoLog.Println("Trash purger stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
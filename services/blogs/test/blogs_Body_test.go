@@ -0,0 +1,110 @@
+package services_blogs_test
+
+import (
+	"backend/models"
+	repositories_blogs "backend/repositories/blogs"
+	repositories_categories "backend/repositories/categories"
+	repositories_users "backend/repositories/users"
+	services_blogs "backend/services/blogs"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_FetchBlogById_Body(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+	// モックデータ
+	mockBlogData := &models.BlogData{
+		ID:           "1",
+		Status:       models.BlogStatusPublished,
+		BodyMarkdown: "# はじめに\n\nHello <script>alert(1)</script>\n\n## Setup\n\n本文",
+	}
+	mockBlogRepository.On("FetchBlogById", "1").Return(mockBlogData, nil)
+
+	// ブログデータを取得
+	blog, err := blogService.FetchBlogById(context.Background(), "1", "")
+
+	// 本文をサニタイズ済みのHTMLに変換し、目次・単語数・読了時間を求めること
+	assert.NoError(t, err)
+	assert.Equal(t, *mockBlogData, blog.BlogData)
+	assert.Contains(t, blog.BodyHTML, `<h1 id="hajimeni">はじめに</h1>`)
+	assert.NotContains(t, blog.BodyHTML, "<script")
+	assert.Equal(t, []models.BlogTocEntry{
+		{Level: 1, ID: "hajimeni", Text: "はじめに"},
+		{Level: 2, ID: "setup", Text: "Setup"},
+	}, blog.Toc)
+	assert.Equal(t, 10, blog.WordCount)
+	assert.Equal(t, 1, blog.ReadingTime)
+}
+
+func TestService_FetchBlogById_EmptyBody(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+	mockBlogRepository.On("FetchBlogById", "1").Return(&models.BlogData{ID: "1", Status: models.BlogStatusPublished}, nil)
+
+	// ブログデータを取得
+	blog, err := blogService.FetchBlogById(context.Background(), "1", "")
+
+	// 本文がない場合は空の目次を返すこと
+	assert.NoError(t, err)
+	assert.Equal(t, "", blog.BodyHTML)
+	assert.Equal(t, []models.BlogTocEntry{}, blog.Toc)
+	assert.Equal(t, 0, blog.ReadingTime)
+}
+
+func TestService_CreateBlog_InvalidBodyMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"too long", strings.Repeat("あ", 100001)},
+		{"invalid utf8", "\xff\xfe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, nil, nil)
+
+			// テスト対象メソッドの呼び出し
+			blog, err := blogService.CreateBlog(context.Background(), "user1", "Test Blog", "https://github.com/user/repo", "Tech", "desc", "go", tt.body)
+
+			// アサーション
+			assert.EqualError(t, err, "invalid bodyMarkdown")
+			assert.Nil(t, blog)
+			mockBlogRepository.AssertNotCalled(t, "CreateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_UpdateBlog_BodyMarkdown(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, mockUserRepository, nil)
+
+	// 本文の上限ちょうどは受け付けること
+	body := strings.Repeat("あ", 100000)
+	mockBlogOwnership(mockBlogRepository, mockUserRepository, "1", "user1", "user1", models.UserRoleAuthor)
+	mockCategoryRepository.On("FetchCategoryByName", "Tech").Return(&models.CategoryData{Name: "Tech"}, nil)
+	mockBlogRepository.On("UpdateBlog", "1", "user1", "Test Blog", "https://github.com/user/repo", "Tech", "desc", "go", body).Return(&models.BlogData{ID: "1", BodyMarkdown: body}, nil)
+
+	// テスト対象メソッドの呼び出し
+	blog, err := blogService.UpdateBlog(context.Background(), "1", "user1", "Test Blog", "https://github.com/user/repo", "Tech", "desc", "go", body)
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Equal(t, body, blog.BodyMarkdown)
+	mockBlogRepository.AssertExpectations(t)
+}
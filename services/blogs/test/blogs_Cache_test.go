@@ -0,0 +1,98 @@
+package services_blogs_test
+
+import (
+	"backend/models"
+	repositories_blogs "backend/repositories/blogs"
+	repositories_users "backend/repositories/users"
+	services_blogs "backend/services/blogs"
+	utils_cache "backend/utils/cache"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Cache_FetchBlogTags(t *testing.T) {
+	// モックリポジトリとキャッシュをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	cache := utils_cache.New(time.Minute)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, cache)
+
+	mockBlogTags := []models.TagCount{{Name: "Go", Count: 1}}
+	mockBlogRepository.On("FetchBlogTags").Return(mockBlogTags, nil).Once()
+
+	// 2回目はリポジトリを呼び出さずキャッシュから返す
+	for i := 0; i < 2; i++ {
+		tags, err := blogService.FetchBlogTags(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, mockBlogTags, tags)
+	}
+	mockBlogRepository.AssertNumberOfCalls(t, "FetchBlogTags", 1)
+
+	mockBlogActor(mockUserRepository, "admin1", models.UserRoleAdmin)
+	allStats, err := blogService.FetchCacheStats(context.Background(), "admin1")
+	assert.NoError(t, err)
+	stats := allStats[utils_cache.NamespaceTags]
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+
+	// ブログを削除するとキャッシュを無効化し、再度リポジトリから取得する
+	before := blogService.LastModified()
+	time.Sleep(time.Millisecond)
+	mockBlogOwnership(mockBlogRepository, mockUserRepository, "1", "user1", "user1", models.UserRoleAuthor)
+	mockBlogRepository.On("DeleteBlog", "1").Return(nil)
+	assert.NoError(t, blogService.DeleteBlog(context.Background(), "1", "user1"))
+	assert.True(t, blogService.LastModified().After(before))
+
+	mockBlogRepository.On("FetchBlogTags").Return([]models.TagCount{}, nil).Once()
+	tags, err := blogService.FetchBlogTags(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+	mockBlogRepository.AssertNumberOfCalls(t, "FetchBlogTags", 2)
+}
+
+func TestService_Cache_FetchBlogs(t *testing.T) {
+	// モックリポジトリとキャッシュをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	cache := utils_cache.New(time.Minute)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, cache)
+
+	newest := models.BlogListFilter{Limit: 21, Sort: models.BlogSortNewest}
+	liked := models.BlogListFilter{Limit: 21, Sort: models.BlogSortMostLiked}
+	mockBlogRepository.On("FetchBlogs", newest).Return([]models.BlogData{{ID: "1"}}, nil).Once()
+	mockBlogRepository.On("FetchBlogs", liked).Return([]models.BlogData{{ID: "2"}}, nil).Once()
+
+	// 取得条件ごとにキャッシュする
+	for i := 0; i < 2; i++ {
+		page, err := blogService.FetchBlogs(context.Background(), models.BlogListParams{})
+		assert.NoError(t, err)
+		assert.Equal(t, "1", page.Items[0].ID)
+
+		page, err = blogService.FetchBlogs(context.Background(), models.BlogListParams{Sort: models.BlogSortMostLiked})
+		assert.NoError(t, err)
+		assert.Equal(t, "2", page.Items[0].ID)
+	}
+	mockBlogRepository.AssertNumberOfCalls(t, "FetchBlogs", 2)
+}
+
+func TestService_Cache_Error(t *testing.T) {
+	// モックリポジトリとキャッシュをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	cache := utils_cache.New(time.Minute)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, cache)
+
+	// エラーはキャッシュせず、次回はリポジトリから取得する
+	mockBlogRepository.On("FetchBlogPopular", 3).Return(nil, errors.New("db error")).Once()
+	mockBlogRepository.On("FetchBlogPopular", 3).Return([]models.BlogData{{ID: "1"}}, nil).Once()
+
+	_, err := blogService.FetchBlogPopular(context.Background(), 3)
+	assert.EqualError(t, err, "failed to fetch popular blogs")
+
+	blogs, err := blogService.FetchBlogPopular(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Len(t, blogs, 1)
+	mockBlogRepository.AssertExpectations(t)
+}
@@ -3,11 +3,15 @@ package services_blogs_test
 import (
 	"backend/models"
 	repositories_blogs "backend/repositories/blogs"
+	repositories_categories "backend/repositories/categories"
+	repositories_users "backend/repositories/users"
 	services_blogs "backend/services/blogs"
+	"context"
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -15,7 +19,9 @@ import (
 func TestService_CreateBlog(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, mockUserRepository, nil)
 
 	// 入力データ
 	userId := "user1"
@@ -39,10 +45,12 @@ func TestService_CreateBlog(t *testing.T) {
 	}
 
 	// モックの設定
-	mockBlogRepository.On("CreateBlog", userId, title, githubURL, category, description, tags).Return(&expectedBlog, nil)
+	mockVerifiedAuthor(mockUserRepository, userId)
+	mockCategoryRepository.On("FetchCategoryByName", category).Return(&models.CategoryData{Name: category}, nil)
+	mockBlogRepository.On("CreateBlog", userId, title, githubURL, category, description, tags, "").Return(&expectedBlog, nil)
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.CreateBlog(userId, title, githubURL, category, description, tags)
+	blog, err := blogService.CreateBlog(context.Background(), userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.NoError(t, err)
@@ -55,7 +63,8 @@ func TestService_CreateBlog(t *testing.T) {
 func TestService_CreateBlog_InvalidUserId(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, nil, nil)
 
 	// 入力データ
 	userId := ""
@@ -66,7 +75,7 @@ func TestService_CreateBlog_InvalidUserId(t *testing.T) {
 	tags := "go, testing"
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.CreateBlog(userId, title, githubURL, category, description, tags)
+	blog, err := blogService.CreateBlog(context.Background(), userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.Error(t, err)
@@ -74,13 +83,15 @@ func TestService_CreateBlog_InvalidUserId(t *testing.T) {
 	assert.Nil(t, blog)
 
 	// モックの呼び出しがないことを確認
-	mockBlogRepository.AssertNotCalled(t, "CreateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockBlogRepository.AssertNotCalled(t, "CreateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockCategoryRepository.AssertNotCalled(t, "FetchCategoryByName", mock.Anything)
 }
 
 func TestService_CreateBlog_InvalidTitle(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, nil, nil)
 
 	// 入力データ
 	userId := "user1"
@@ -91,7 +102,7 @@ func TestService_CreateBlog_InvalidTitle(t *testing.T) {
 	tags := "go, testing"
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.CreateBlog(userId, title, githubURL, category, description, tags)
+	blog, err := blogService.CreateBlog(context.Background(), userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.Error(t, err)
@@ -99,13 +110,15 @@ func TestService_CreateBlog_InvalidTitle(t *testing.T) {
 	assert.Nil(t, blog)
 
 	// モックの呼び出しがないことを確認
-	mockBlogRepository.AssertNotCalled(t, "CreateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockBlogRepository.AssertNotCalled(t, "CreateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockCategoryRepository.AssertNotCalled(t, "FetchCategoryByName", mock.Anything)
 }
 
 func TestService_CreateBlog_InvalidGitHubURL(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, nil, nil)
 
 	// 入力データ
 	userId := "user1"
@@ -116,7 +129,7 @@ func TestService_CreateBlog_InvalidGitHubURL(t *testing.T) {
 	tags := "go, testing"
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.CreateBlog(userId, title, githubURL, category, description, tags)
+	blog, err := blogService.CreateBlog(context.Background(), userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.Error(t, err)
@@ -124,13 +137,15 @@ func TestService_CreateBlog_InvalidGitHubURL(t *testing.T) {
 	assert.Nil(t, blog)
 
 	// モックの呼び出しがないことを確認
-	mockBlogRepository.AssertNotCalled(t, "CreateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockBlogRepository.AssertNotCalled(t, "CreateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockCategoryRepository.AssertNotCalled(t, "FetchCategoryByName", mock.Anything)
 }
 
 func TestService_CreateBlog_InvalidCategory(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, nil, nil)
 
 	// 入力データ
 	userId := "user1"
@@ -141,7 +156,7 @@ func TestService_CreateBlog_InvalidCategory(t *testing.T) {
 	tags := "go, testing"
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.CreateBlog(userId, title, githubURL, category, description, tags)
+	blog, err := blogService.CreateBlog(context.Background(), userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.Error(t, err)
@@ -149,13 +164,15 @@ func TestService_CreateBlog_InvalidCategory(t *testing.T) {
 	assert.Nil(t, blog)
 
 	// モックの呼び出しがないことを確認
-	mockBlogRepository.AssertNotCalled(t, "CreateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockBlogRepository.AssertNotCalled(t, "CreateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockCategoryRepository.AssertNotCalled(t, "FetchCategoryByName", mock.Anything)
 }
 
 func TestService_CreateBlog_InvalidDescription(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, nil, nil)
 
 	// 入力データ
 	userId := "user1"
@@ -166,7 +183,7 @@ func TestService_CreateBlog_InvalidDescription(t *testing.T) {
 	tags := "go, testing"
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.CreateBlog(userId, title, githubURL, category, description, tags)
+	blog, err := blogService.CreateBlog(context.Background(), userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.Error(t, err)
@@ -174,13 +191,15 @@ func TestService_CreateBlog_InvalidDescription(t *testing.T) {
 	assert.Nil(t, blog)
 
 	// モックの呼び出しがないことを確認
-	mockBlogRepository.AssertNotCalled(t, "CreateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockBlogRepository.AssertNotCalled(t, "CreateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockCategoryRepository.AssertNotCalled(t, "FetchCategoryByName", mock.Anything)
 }
 
 func TestService_CreateBlog_InvalidTags(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, nil, nil)
 
 	// 入力データ
 	userId := "user1"
@@ -191,7 +210,7 @@ func TestService_CreateBlog_InvalidTags(t *testing.T) {
 	tags := ""
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.CreateBlog(userId, title, githubURL, category, description, tags)
+	blog, err := blogService.CreateBlog(context.Background(), userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.Error(t, err)
@@ -199,13 +218,16 @@ func TestService_CreateBlog_InvalidTags(t *testing.T) {
 	assert.Nil(t, blog)
 
 	// モックの呼び出しがないことを確認
-	mockBlogRepository.AssertNotCalled(t, "CreateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockBlogRepository.AssertNotCalled(t, "CreateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockCategoryRepository.AssertNotCalled(t, "FetchCategoryByName", mock.Anything)
 }
 
 func TestService_CreateBlog_RepositoryError(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, mockUserRepository, nil)
 
 	// 入力データ
 	userId := "user1"
@@ -216,10 +238,12 @@ func TestService_CreateBlog_RepositoryError(t *testing.T) {
 	tags := "go, testing"
 
 	// モックの設定: リポジトリがエラーを返す
-	mockBlogRepository.On("CreateBlog", userId, title, githubURL, category, description, tags).Return(nil, errors.New("repository failure"))
+	mockVerifiedAuthor(mockUserRepository, userId)
+	mockCategoryRepository.On("FetchCategoryByName", category).Return(&models.CategoryData{Name: category}, nil)
+	mockBlogRepository.On("CreateBlog", userId, title, githubURL, category, description, tags, "").Return(nil, errors.New("repository failure"))
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.CreateBlog(userId, title, githubURL, category, description, tags)
+	blog, err := blogService.CreateBlog(context.Background(), userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.Error(t, err)
@@ -229,3 +253,86 @@ func TestService_CreateBlog_RepositoryError(t *testing.T) {
 	// モックの期待通りの呼び出しを検証
 	mockBlogRepository.AssertExpectations(t)
 }
+
+func TestService_CreateBlog_UnknownCategory(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, mockUserRepository, nil)
+
+	// モックの設定: 未登録のカテゴリ
+	mockVerifiedAuthor(mockUserRepository, "user1")
+	mockCategoryRepository.On("FetchCategoryByName", "Unknown").Return(nil, pgx.ErrNoRows)
+
+	// テスト対象メソッドの呼び出し
+	blog, err := blogService.CreateBlog(context.Background(), "user1", "Test Blog", "https://github.com/user/repo", "Unknown", "This is a test blog.", "go", "")
+
+	// アサーション
+	assert.EqualError(t, err, "unknown category")
+	assert.Nil(t, blog)
+
+	// ブログが作成されないことを確認
+	mockCategoryRepository.AssertExpectations(t)
+	mockBlogRepository.AssertNotCalled(t, "CreateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_CreateBlog_CanonicalCategory(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, mockUserRepository, nil)
+
+	// モックの設定: 大文字小文字の違いは登録済みのカテゴリ名に揃える
+	mockVerifiedAuthor(mockUserRepository, "user1")
+	mockCategoryRepository.On("FetchCategoryByName", "tech").Return(&models.CategoryData{Name: "Tech"}, nil)
+	mockBlogRepository.On("CreateBlog", "user1", "Test Blog", "https://github.com/user/repo", "Tech", "This is a test blog.", "go", "").Return(&models.BlogData{ID: "123", Category: "Tech"}, nil)
+
+	// テスト対象メソッドの呼び出し
+	blog, err := blogService.CreateBlog(context.Background(), "user1", "Test Blog", "https://github.com/user/repo", "tech", "This is a test blog.", "go", "")
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Equal(t, "Tech", blog.Category)
+
+	// モックの期待通りの呼び出しを検証
+	mockCategoryRepository.AssertExpectations(t)
+	mockBlogRepository.AssertExpectations(t)
+}
+
+func TestService_CreateBlog_EmailNotVerified(t *testing.T) {
+	tests := []struct {
+		name        string
+		user        *models.UserData
+		userErr     error
+		expectedErr string
+	}{
+		{name: "メールアドレスが未確認", user: &models.UserData{ID: "user1", Role: models.UserRoleAuthor}, expectedErr: "email not verified"},
+		{name: "ユーザーが存在しない", userErr: pgx.ErrNoRows, expectedErr: "forbidden"},
+		{name: "ユーザーの取得に失敗", userErr: errors.New("db error"), expectedErr: "failed to create blog"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, mockUserRepository, nil)
+
+			// モックの設定
+			mockUserRepository.On("FetchUserById", "user1").Return(tt.user, tt.userErr)
+
+			// テスト対象メソッドの呼び出し
+			blog, err := blogService.CreateBlog(context.Background(), "user1", "Test Blog", "https://github.com/user/repo", "Tech", "This is a test blog.", "go", "")
+
+			// アサーション
+			assert.EqualError(t, err, tt.expectedErr)
+			assert.Nil(t, blog)
+
+			// ブログが作成されないことを確認
+			mockBlogRepository.AssertNotCalled(t, "CreateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package services_blogs_test
 
 import (
+	"backend/models"
 	repositories_blogs "backend/repositories/blogs"
+	repositories_users "backend/repositories/users"
 	services_blogs "backend/services/blogs"
+	"context"
 	"errors"
 	"testing"
 
@@ -12,16 +15,19 @@ import (
 func TestService_DeleteBlog(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
 
 	// 入力データ
 	id := "123"
+	userId := "user1"
 
 	// モックの設定
+	mockBlogOwnership(mockBlogRepository, mockUserRepository, id, userId, userId, models.UserRoleAuthor)
 	mockBlogRepository.On("DeleteBlog", id).Return(nil, nil)
 
 	// テスト対象メソッドの呼び出し
-	err := blogService.DeleteBlog(id)
+	err := blogService.DeleteBlog(context.Background(), id, userId)
 
 	// アサーション
 	assert.NoError(t, err)
@@ -34,7 +40,7 @@ func TestService_DeleteBlog(t *testing.T) {
 func TestService_DeleteBlog_InvalidId(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
 
 	// 入力データ
 	id := ""
@@ -43,7 +49,7 @@ func TestService_DeleteBlog_InvalidId(t *testing.T) {
 	mockBlogRepository.On("DeleteBlog", id).Return(nil, errors.New("invalid id"))
 
 	// テスト対象メソッドの呼び出し
-	err := blogService.DeleteBlog(id)
+	err := blogService.DeleteBlog(context.Background(), id, "user1")
 
 	// アサーション
 	assert.Error(t, err)
@@ -54,19 +60,35 @@ func TestService_DeleteBlog_InvalidId(t *testing.T) {
 	mockBlogRepository.AssertNotCalled(t, "DeleteBlog", id)
 }
 
+func TestService_DeleteBlog_InvalidUserId(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+	// テスト対象メソッドの呼び出し
+	err := blogService.DeleteBlog(context.Background(), "123", "")
+
+	// アサーション
+	assert.EqualError(t, err, "invalid userId")
+	mockBlogRepository.AssertNotCalled(t, "DeleteBlog", "123")
+}
+
 func TestService_DeleteBlog_NotBlog(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
 
 	// 入力データ
 	id := "123"
+	userId := "user1"
 
 	// モックの設定
+	mockBlogOwnership(mockBlogRepository, mockUserRepository, id, userId, userId, models.UserRoleAuthor)
 	mockBlogRepository.On("DeleteBlog", id).Return(errors.New("failed to delete blog"))
 
 	// テスト対象メソッドの呼び出し
-	err := blogService.DeleteBlog(id)
+	err := blogService.DeleteBlog(context.Background(), id, userId)
 
 	// アサーション
 	assert.Error(t, err)
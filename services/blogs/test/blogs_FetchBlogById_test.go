@@ -4,6 +4,7 @@ import (
 	"backend/models"
 	repositories_blogs "backend/repositories/blogs"
 	services_blogs "backend/services/blogs"
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -14,7 +15,7 @@ import (
 func TestService_FetchBlogById(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
 
 	// モックデータ
 	mockBlogData := &models.BlogData{
@@ -26,13 +27,14 @@ func TestService_FetchBlogById(t *testing.T) {
 		Tags:      "Tag1",
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+		Status:    models.BlogStatusPublished,
 	}
 
 	// ブログが存在する場合
 	mockBlogRepository.On("FetchBlogById", "1").Return(mockBlogData, nil)
 
 	// ブログデータを取得
-	blog, err := blogService.FetchBlogById("1")
+	blog, err := blogService.FetchBlogById(context.Background(), "1", "")
 
 	// エラーチェック
 	assert.NoError(t, err)
@@ -45,10 +47,10 @@ func TestService_FetchBlogById(t *testing.T) {
 func TestService_FetchBlogById_InvalidId(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
 
 	// IDが空の場合
-	blog, err := blogService.FetchBlogById("")
+	blog, err := blogService.FetchBlogById(context.Background(), "", "")
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -62,13 +64,13 @@ func TestService_FetchBlogById_InvalidId(t *testing.T) {
 func TestService_FetchBlogById_NotBlog(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
 
 	// モックを設定
 	mockBlogRepository.On("FetchBlogById", "1").Return(nil, errors.New("blog not found"))
 
 	// ブログが存在しない場合
-	blog, err := blogService.FetchBlogById("1")
+	blog, err := blogService.FetchBlogById(context.Background(), "1", "")
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -78,3 +80,63 @@ func TestService_FetchBlogById_NotBlog(t *testing.T) {
 	// モックが期待通りに呼び出されたかを確認
 	mockBlogRepository.AssertExpectations(t)
 }
+
+func TestService_FetchBlogById_Timeout(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+	// モックを設定
+	mockBlogRepository.On("FetchBlogById", "1").Return(nil, context.DeadlineExceeded)
+
+	// タイムアウトした場合
+	blog, err := blogService.FetchBlogById(context.Background(), "1", "")
+
+	// タイムアウトエラーがそのまま返ること
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, blog)
+
+	// モックが期待通りに呼び出されたかを確認
+	mockBlogRepository.AssertExpectations(t)
+}
+
+func TestService_FetchBlogById_Unpublished(t *testing.T) {
+	tests := []struct {
+		name     string
+		viewerId string
+		wantErr  string
+	}{
+		// 投稿者本人は下書きも取得できる
+		{name: "owner", viewerId: "1", wantErr: ""},
+		// 投稿者以外には存在しないものとして扱う
+		{name: "other user", viewerId: "2", wantErr: "blog not found"},
+		{name: "guest", viewerId: "", wantErr: "blog not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+			// モックを設定
+			mockBlogRepository.On("FetchBlogById", "1").Return(&models.BlogData{
+				ID:     "1",
+				UserId: "1",
+				Status: models.BlogStatusDraft,
+			}, nil)
+
+			// ブログデータを取得
+			blog, err := blogService.FetchBlogById(context.Background(), "1", tt.viewerId)
+
+			// エラーチェック
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, models.BlogStatusDraft, blog.Status)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, blog)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package services_blogs_test
 
 import (
-	repositories_blogs "backend/repositories/blogs"
+	"backend/models"
+	repositories_categories "backend/repositories/categories"
 	services_blogs "backend/services/blogs"
+	"context"
 	"errors"
 	"testing"
 
@@ -11,40 +13,36 @@ import (
 
 func TestService_FetchBlogCategories(t *testing.T) {
 	// モックリポジトリをインスタンス化
-	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(nil, mockCategoryRepository, nil, nil, nil)
 
-	mockBlogCategories := []string{
-		"Category1",
-		"Category2",
+	mockBlogCategories := []models.CategoryData{
+		{ID: "1", Name: "Category1", Slug: "category1", DisplayOrder: 0, PostCount: 3},
+		{ID: "2", Name: "Category2", Slug: "category2", DisplayOrder: 1, PostCount: 0},
 	}
 
-	// ブログが存在する場合
-	mockBlogRepository.On("FetchBlogCategories").Return(mockBlogCategories, nil)
+	// カテゴリが存在する場合
+	mockCategoryRepository.On("FetchCategories").Return(mockBlogCategories, nil)
 
-	blogCategories, err := blogService.FetchBlogCategories()
+	blogCategories, err := blogService.FetchBlogCategories(context.Background())
 
 	// エラーチェック
 	assert.NoError(t, err)
-	assert.NotNil(t, blogCategories)
-	assert.Len(t, blogCategories, 2)
+	assert.Equal(t, mockBlogCategories, blogCategories)
 
 	// モックが期待通りに呼び出されたかを確認
-	mockBlogRepository.AssertExpectations(t)
+	mockCategoryRepository.AssertExpectations(t)
 }
 
 func TestService_FetchBlogCategories_NoData(t *testing.T) {
 	// モックリポジトリをインスタンス化
-	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
-
-	// モックデータ
-	mockBlogCategories := []string{}
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(nil, mockCategoryRepository, nil, nil, nil)
 
-	// ブログが存在する場合
-	mockBlogRepository.On("FetchBlogCategories").Return(mockBlogCategories, nil)
+	// カテゴリが存在しない場合は空のスライスを返す
+	mockCategoryRepository.On("FetchCategories").Return(nil, nil)
 
-	blogCategories, err := blogService.FetchBlogCategories()
+	blogCategories, err := blogService.FetchBlogCategories(context.Background())
 
 	// エラーチェック
 	assert.NoError(t, err)
@@ -52,23 +50,23 @@ func TestService_FetchBlogCategories_NoData(t *testing.T) {
 	assert.Len(t, blogCategories, 0)
 
 	// モックが期待通りに呼び出されたかを確認
-	mockBlogRepository.AssertExpectations(t)
+	mockCategoryRepository.AssertExpectations(t)
 }
 
 func TestService_FetchBlogCategories_ErrorCase(t *testing.T) {
 	// モックリポジトリをインスタンス化
-	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(nil, mockCategoryRepository, nil, nil, nil)
 
-	// ブログが存在する場合
-	mockBlogRepository.On("FetchBlogCategories").Return(nil, errors.New("No data"))
+	// リポジトリがエラーを返す場合
+	mockCategoryRepository.On("FetchCategories").Return(nil, errors.New("No data"))
 
-	blogCategories, err := blogService.FetchBlogCategories()
+	blogCategories, err := blogService.FetchBlogCategories(context.Background())
 
 	// エラーチェック
 	assert.Error(t, err)
 	assert.Nil(t, blogCategories)
 
 	// モックが期待通りに呼び出されたかを確認
-	mockBlogRepository.AssertExpectations(t)
+	mockCategoryRepository.AssertExpectations(t)
 }
@@ -4,6 +4,7 @@ import (
 	"backend/models"
 	repositories_blogs "backend/repositories/blogs"
 	services_blogs "backend/services/blogs"
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -15,7 +16,7 @@ import (
 func TestService_FetchBlogPopular(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
 
 	mockBlogData := []models.BlogData{
 		{
@@ -43,7 +44,7 @@ func TestService_FetchBlogPopular(t *testing.T) {
 	// ブログが存在する場合
 	mockBlogRepository.On("FetchBlogPopular", 2).Return(mockBlogData, nil)
 
-	blogData, err := blogService.FetchBlogPopular(2)
+	blogData, err := blogService.FetchBlogPopular(context.Background(), 2)
 
 	// エラーチェック
 	assert.NoError(t, err)
@@ -57,12 +58,12 @@ func TestService_FetchBlogPopular(t *testing.T) {
 func TestService_FetchBlogPopular_InvalidCount(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
 
 	// ブログが存在する場合
 	mockBlogRepository.On("FetchBlogPopular", 0).Return(nil, errors.New("No data"))
 
-	blogData, err := blogService.FetchBlogPopular(0)
+	blogData, err := blogService.FetchBlogPopular(context.Background(), 0)
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -71,3 +72,19 @@ func TestService_FetchBlogPopular_InvalidCount(t *testing.T) {
 	// モックの呼び出しがないことを確認
 	mockBlogRepository.AssertNotCalled(t, "FetchBlogPopular", mock.Anything)
 }
+
+func TestService_FetchBlogPopular_CountOverLimit(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+	// 上限(100件)を超える件数はキャッシュ・リポジトリを使わずに拒否する
+	blogData, err := blogService.FetchBlogPopular(context.Background(), 101)
+
+	// エラーチェック
+	assert.EqualError(t, err, "invalid count")
+	assert.Nil(t, blogData)
+
+	// モックの呼び出しがないことを確認
+	mockBlogRepository.AssertNotCalled(t, "FetchBlogPopular", mock.Anything)
+}
@@ -1,8 +1,10 @@
 package services_blogs_test
 
 import (
+	"backend/models"
 	repositories_blogs "backend/repositories/blogs"
 	services_blogs "backend/services/blogs"
+	"context"
 	"errors"
 	"testing"
 
@@ -12,22 +14,23 @@ import (
 func TestService_FetchBlogTags(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
 
-	mockBlogTags := []string{
-		"Tag1",
-		"Tag2",
+	mockBlogTags := []models.TagCount{
+		{Name: "Tag1", Count: 2},
+		{Name: "Tag2", Count: 1},
 	}
 
 	// ブログが存在する場合
 	mockBlogRepository.On("FetchBlogTags").Return(mockBlogTags, nil)
 
-	blogTags, err := blogService.FetchBlogTags()
+	blogTags, err := blogService.FetchBlogTags(context.Background())
 
 	// エラーチェック
 	assert.NoError(t, err)
 	assert.NotNil(t, blogTags)
 	assert.Len(t, blogTags, 2)
+	assert.Equal(t, 2, blogTags[0].Count)
 
 	// モックが期待通りに呼び出されたかを確認
 	mockBlogRepository.AssertExpectations(t)
@@ -36,15 +39,15 @@ func TestService_FetchBlogTags(t *testing.T) {
 func TestService_FetchBlogTags_NoData(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
 
 	// モックデータ
-	mockBlogTags := []string{}
+	mockBlogTags := []models.TagCount{}
 
 	// ブログが存在する場合
 	mockBlogRepository.On("FetchBlogTags").Return(mockBlogTags, nil)
 
-	blogTags, err := blogService.FetchBlogTags()
+	blogTags, err := blogService.FetchBlogTags(context.Background())
 
 	// エラーチェック
 	assert.NoError(t, err)
@@ -58,12 +61,12 @@ func TestService_FetchBlogTags_NoData(t *testing.T) {
 func TestService_FetchBlogTags_ErrorCase(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
 
 	// ブログが存在する場合
 	mockBlogRepository.On("FetchBlogTags").Return(nil, errors.New("No data"))
 
-	blogTags, err := blogService.FetchBlogTags()
+	blogTags, err := blogService.FetchBlogTags(context.Background())
 
 	// エラーチェック
 	assert.Error(t, err)
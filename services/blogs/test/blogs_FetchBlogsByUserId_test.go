@@ -4,6 +4,7 @@ import (
 	"backend/models"
 	repositories_blogs "backend/repositories/blogs"
 	services_blogs "backend/services/blogs"
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -14,7 +15,7 @@ import (
 func TestService_FetchBlogsByUserId(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
 
 	// ブログが存在する場合
 	mockBlogData := []models.BlogData{
@@ -39,10 +40,10 @@ func TestService_FetchBlogsByUserId(t *testing.T) {
 			UpdatedAt: time.Now(),
 		},
 	}
-	mockBlogRepository.On("FetchBlogsByUserId", "1").Return(mockBlogData, nil)
+	mockBlogRepository.On("FetchBlogsByUserId", "1", false).Return(mockBlogData, nil)
 
 	// サービス層メソッドの実行
-	blogs, err := blogService.FetchBlogsByUserId("1")
+	blogs, err := blogService.FetchBlogsByUserId(context.Background(), "1", "")
 
 	// エラーチェック
 	assert.NoError(t, err)
@@ -58,10 +59,10 @@ func TestService_FetchBlogsByUserId(t *testing.T) {
 func TestService_FetchBlogsByUserId_InvalidCases(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
 
 	// サービス層メソッドの実行
-	_, err := blogService.FetchBlogsByUserId("")
+	_, err := blogService.FetchBlogsByUserId(context.Background(), "", "")
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -74,13 +75,13 @@ func TestService_FetchBlogsByUserId_InvalidCases(t *testing.T) {
 func TestService_FetchBlogsByUserId_NotUser(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
 
 	// "blog not found" エラーメッセージを返すように設定
-	mockBlogRepository.On("FetchBlogsByUserId", "2").Return(nil, errors.New("blog not found"))
+	mockBlogRepository.On("FetchBlogsByUserId", "2", false).Return(nil, errors.New("blog not found"))
 
 	// サービス層メソッドの実行
-	blogs, err := blogService.FetchBlogsByUserId("2")
+	blogs, err := blogService.FetchBlogsByUserId(context.Background(), "2", "")
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -90,3 +91,26 @@ func TestService_FetchBlogsByUserId_NotUser(t *testing.T) {
 	// モックが期待通りに呼び出されたかを確認
 	mockBlogRepository.AssertExpectations(t)
 }
+
+func TestService_FetchBlogsByUserId_Owner(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+	// 本人が閲覧する場合は下書きも含めて取得する
+	mockBlogData := []models.BlogData{
+		{ID: "1", UserId: "1", Status: models.BlogStatusDraft},
+		{ID: "2", UserId: "1", Status: models.BlogStatusPublished},
+	}
+	mockBlogRepository.On("FetchBlogsByUserId", "1", true).Return(mockBlogData, nil)
+
+	// サービス層メソッドの実行
+	blogs, err := blogService.FetchBlogsByUserId(context.Background(), "1", "1")
+
+	// エラーチェックとデータ確認
+	assert.NoError(t, err)
+	assert.Len(t, blogs, 2)
+
+	// モックが期待通りに呼び出されたかを確認
+	mockBlogRepository.AssertExpectations(t)
+}
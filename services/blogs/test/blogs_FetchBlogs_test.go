@@ -4,16 +4,20 @@ import (
 	"backend/models"
 	repositories_blogs "backend/repositories/blogs"
 	services_blogs "backend/services/blogs"
+	utils_cursor "backend/utils/cursor"
+	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestService_FetchBlogs(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
 
 	mockBlogData := []models.BlogData{
 		{
@@ -38,15 +42,20 @@ func TestService_FetchBlogs(t *testing.T) {
 		},
 	}
 
-	// ブログが存在する場合
-	mockBlogRepository.On("FetchBlogs").Return(mockBlogData, nil)
+	// ブログが存在する場合(既定値では21件を要求する)
+	mockBlogRepository.On("FetchBlogs", models.BlogListFilter{
+		Limit: 21,
+		Sort:  models.BlogSortNewest,
+	}).Return(mockBlogData, nil)
 
-	blogs, err := blogService.FetchBlogs()
+	page, err := blogService.FetchBlogs(context.Background(), models.BlogListParams{})
 
 	// エラーチェック
 	assert.NoError(t, err)
-	assert.NotNil(t, blogs)
-	assert.Len(t, blogs, 2)
+	assert.NotNil(t, page)
+	assert.Len(t, page.Items, 2)
+	assert.Nil(t, page.NextCursor)
+	assert.Nil(t, page.Total)
 
 	// モックが期待通りに呼び出されたかを確認
 	mockBlogRepository.AssertExpectations(t)
@@ -55,17 +64,181 @@ func TestService_FetchBlogs(t *testing.T) {
 func TestService_FetchUsers_EmptyList(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
 
 	// ブログが存在しない場合
-	mockBlogRepository.On("FetchBlogs").Return([]models.BlogData{}, nil)
+	mockBlogRepository.On("FetchBlogs", mock.Anything).Return(nil, nil)
 
-	blogs, err := blogService.FetchBlogs()
+	page, err := blogService.FetchBlogs(context.Background(), models.BlogListParams{})
 
 	// エラーチェック
 	assert.NoError(t, err)
-	assert.NotNil(t, blogs)
-	assert.Len(t, blogs, 0)
+	assert.NotNil(t, page)
+	assert.NotNil(t, page.Items)
+	assert.Len(t, page.Items, 0)
+
+	// モックが期待通りに呼び出されたかを確認
+	mockBlogRepository.AssertExpectations(t)
+}
+
+func TestService_FetchBlogs_NextCursor(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+	// カーソルには作成日時ではなく公開日時を使う
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	publishedAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	mockBlogData := []models.BlogData{
+		{ID: "00000000-0000-0000-0000-000000000003", Likes: 5, CreatedAt: createdAt, PublishedAt: &publishedAt},
+		{ID: "00000000-0000-0000-0000-000000000002", Likes: 3, CreatedAt: createdAt, PublishedAt: &publishedAt},
+		{ID: "00000000-0000-0000-0000-000000000001", Likes: 1, CreatedAt: createdAt, PublishedAt: &publishedAt},
+	}
+
+	// limit+1件返却された場合は次ページが存在する
+	mockBlogRepository.On("FetchBlogs", models.BlogListFilter{
+		Limit:    3,
+		Category: "Category1",
+		Sort:     models.BlogSortMostLiked,
+	}).Return(mockBlogData, nil)
+	mockBlogRepository.On("CountBlogs", mock.Anything).Return(10, nil)
+
+	page, err := blogService.FetchBlogs(context.Background(), models.BlogListParams{
+		Limit:        2,
+		Category:     "Category1",
+		Sort:         models.BlogSortMostLiked,
+		IncludeTotal: true,
+	})
+
+	// エラーチェック
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+	assert.Equal(t, 10, *page.Total)
+
+	// カーソルに最後の要素の並び替えキーが含まれること
+	assert.NotNil(t, page.NextCursor)
+	var cursor models.BlogCursor
+	assert.NoError(t, utils_cursor.Decode(*page.NextCursor, &cursor))
+	assert.Equal(t, models.BlogSortMostLiked, cursor.Sort)
+	assert.Equal(t, int64(3), cursor.Count)
+	assert.Equal(t, mockBlogData[1].ID, cursor.ID)
+	assert.True(t, publishedAt.Equal(cursor.PublishedAt))
+
+	// 発行したカーソルで次ページを要求した場合、リポジトリにカーソルが渡ること
+	expectedCursor := cursor
+	mockBlogRepository.On("FetchBlogs", models.BlogListFilter{
+		Limit:    3,
+		Category: "Category1",
+		Sort:     models.BlogSortMostLiked,
+		After:    &expectedCursor,
+	}).Return(mockBlogData[2:], nil)
+
+	page, err = blogService.FetchBlogs(context.Background(), models.BlogListParams{
+		Limit:    2,
+		Cursor:   *page.NextCursor,
+		Category: "Category1",
+		Sort:     models.BlogSortMostLiked,
+	})
+
+	// エラーチェック
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 1)
+	assert.Nil(t, page.NextCursor)
+
+	// モックが期待通りに呼び出されたかを確認
+	mockBlogRepository.AssertExpectations(t)
+}
+
+func TestService_FetchBlogs_InvalidParams(t *testing.T) {
+	// 並び順の異なるカーソル
+	otherSortCursor, _ := utils_cursor.Encode(models.BlogCursor{
+		Sort:        models.BlogSortOldest,
+		PublishedAt: time.Now(),
+		ID:          "00000000-0000-0000-0000-000000000001",
+	})
+	// 作成日時で並べていた頃に発行したカーソル(公開日時を含まない)
+	createdAtCursor, _ := utils_cursor.Encode(map[string]string{
+		"s":  models.BlogSortNewest,
+		"t":  time.Now().Format(time.RFC3339Nano),
+		"id": "00000000-0000-0000-0000-000000000001",
+	})
+
+	tests := []struct {
+		name   string
+		params models.BlogListParams
+		errMsg string
+	}{
+		{"負のlimit", models.BlogListParams{Limit: -1}, "invalid limit"},
+		{"上限を超えるlimit", models.BlogListParams{Limit: 101}, "invalid limit"},
+		{"不正なsort", models.BlogListParams{Sort: "popular"}, "invalid sort"},
+		{"不正なuser_id", models.BlogListParams{UserId: "invalid"}, "invalid userId"},
+		{"不正なfrom", models.BlogListParams{From: "2024/01/01"}, "invalid from"},
+		{"不正なto", models.BlogListParams{To: "yesterday"}, "invalid to"},
+		{"逆転した日付範囲", models.BlogListParams{From: "2024-02-01", To: "2024-01-01"}, "invalid date range"},
+		{"不正なcursor", models.BlogListParams{Cursor: "invalid"}, "invalid cursor"},
+		{"並び順の異なるcursor", models.BlogListParams{Cursor: otherSortCursor}, "invalid cursor"},
+		{"公開日時を含まないcursor", models.BlogListParams{Cursor: createdAtCursor}, "invalid cursor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+			page, err := blogService.FetchBlogs(context.Background(), tt.params)
+
+			// エラーチェック
+			assert.Nil(t, page)
+			assert.EqualError(t, err, tt.errMsg)
+
+			// リポジトリが呼び出されないことを確認
+			mockBlogRepository.AssertNotCalled(t, "FetchBlogs", mock.Anything)
+		})
+	}
+}
+
+func TestService_FetchBlogs_DateRange(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+	// 日付のみのtoはその日の終わりまでを含む
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 23, 59, 59, 999999999, time.UTC)
+	mockBlogRepository.On("FetchBlogs", models.BlogListFilter{
+		Limit: 21,
+		Sort:  models.BlogSortNewest,
+		From:  &from,
+		To:    &to,
+	}).Return([]models.BlogData{}, nil)
+
+	page, err := blogService.FetchBlogs(context.Background(), models.BlogListParams{
+		From: "2024-01-01",
+		To:   "2024-01-31",
+	})
+
+	// エラーチェック
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 0)
+
+	// モックが期待通りに呼び出されたかを確認
+	mockBlogRepository.AssertExpectations(t)
+}
+
+func TestService_FetchBlogs_Error(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+	// リポジトリがエラーを返す場合
+	mockBlogRepository.On("FetchBlogs", mock.Anything).Return(nil, errors.New("db error"))
+
+	page, err := blogService.FetchBlogs(context.Background(), models.BlogListParams{})
+
+	// エラーチェック
+	assert.Nil(t, page)
+	assert.EqualError(t, err, "failed to fetch blogs")
 
 	// モックが期待通りに呼び出されたかを確認
 	mockBlogRepository.AssertExpectations(t)
@@ -0,0 +1,204 @@
+package services_blogs_test
+
+import (
+	"backend/models"
+	repositories_blogs "backend/repositories/blogs"
+	repositories_categories "backend/repositories/categories"
+	repositories_users "backend/repositories/users"
+	services_blogs "backend/services/blogs"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	policyBlogId  = "123e4567-e89b-12d3-a456-426614174000"
+	policyOwnerId = "123e4567-e89b-12d3-a456-426614174001"
+	policyOtherId = "123e4567-e89b-12d3-a456-426614174002"
+)
+
+// 操作するユーザーを権限とともにモックに設定する
+func mockBlogActor(userRepository *repositories_users.MockUserRepository, userId, role string) {
+	userRepository.On("FetchUserById", userId).Return(&models.UserData{ID: userId, Role: role}, nil)
+}
+
+// メールアドレスを確認済みの投稿者をモックに設定する
+func mockVerifiedAuthor(userRepository *repositories_users.MockUserRepository, userId string) {
+	verifiedAt := time.Now()
+	userRepository.On("FetchUserById", userId).Return(&models.UserData{ID: userId, Role: models.UserRoleAuthor, EmailVerifiedAt: &verifiedAt}, nil)
+}
+
+// ブログとその投稿者、操作するユーザーをモックに設定する
+func mockBlogOwnership(blogRepository *repositories_blogs.MockBlogRepository, userRepository *repositories_users.MockUserRepository, blogId, ownerId, userId, role string) {
+	blogRepository.On("FetchBlogById", blogId).Return(&models.BlogData{ID: blogId, UserId: ownerId}, nil)
+	mockBlogActor(userRepository, userId, role)
+}
+
+// 権限・投稿者本人かどうか・操作ごとに、許可されるかを確認する
+func TestService_BlogPolicy_Matrix(t *testing.T) {
+	tests := []struct {
+		role    string
+		owner   bool
+		update  bool
+		delete  bool
+		restore bool
+	}{
+		{role: models.UserRoleAuthor, owner: true, update: true, delete: true, restore: true},
+		{role: models.UserRoleAuthor, owner: false, update: false, delete: false, restore: false},
+		{role: models.UserRoleEditor, owner: true, update: true, delete: true, restore: true},
+		{role: models.UserRoleEditor, owner: false, update: true, delete: true, restore: true},
+		{role: models.UserRoleAdmin, owner: true, update: true, delete: true, restore: true},
+		{role: models.UserRoleAdmin, owner: false, update: true, delete: true, restore: true},
+		{role: "", owner: true, update: false, delete: false, restore: false},
+	}
+
+	for _, tt := range tests {
+		userId := policyOtherId
+		name := tt.role + "/他人のブログ"
+		if tt.owner {
+			userId = policyOwnerId
+			name = tt.role + "/自分のブログ"
+		}
+
+		t.Run(name+"/更新", func(t *testing.T) {
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, mockUserRepository, nil)
+
+			mockBlogOwnership(mockBlogRepository, mockUserRepository, policyBlogId, policyOwnerId, userId, tt.role)
+			if tt.update {
+				mockCategoryRepository.On("FetchCategoryByName", "Tech").Return(&models.CategoryData{Name: "Tech"}, nil)
+				mockBlogRepository.On("UpdateBlog", policyBlogId, userId, "Title", "https://github.com", "Tech", "Description", "Go", "").
+					Return(&models.BlogData{ID: policyBlogId, UserId: policyOwnerId}, nil)
+			}
+
+			blog, err := blogService.UpdateBlog(context.Background(), policyBlogId, userId, "Title", "https://github.com", "Tech", "Description", "Go", "")
+
+			if tt.update {
+				assert.NoError(t, err)
+				// 投稿者は変わらない
+				assert.Equal(t, policyOwnerId, blog.UserId)
+			} else {
+				assert.EqualError(t, err, "forbidden")
+				mockBlogRepository.AssertNotCalled(t, "UpdateBlog", policyBlogId, userId, "Title", "https://github.com", "Tech", "Description", "Go", "")
+			}
+			mockBlogRepository.AssertExpectations(t)
+		})
+
+		t.Run(name+"/削除", func(t *testing.T) {
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
+
+			mockBlogOwnership(mockBlogRepository, mockUserRepository, policyBlogId, policyOwnerId, userId, tt.role)
+			if tt.delete {
+				mockBlogRepository.On("DeleteBlog", policyBlogId).Return(nil)
+			}
+
+			err := blogService.DeleteBlog(context.Background(), policyBlogId, userId)
+
+			if tt.delete {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, "forbidden")
+				mockBlogRepository.AssertNotCalled(t, "DeleteBlog", policyBlogId)
+			}
+			mockBlogRepository.AssertExpectations(t)
+		})
+
+		t.Run(name+"/復元", func(t *testing.T) {
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
+
+			// 復元ではゴミ箱内のブログを対象とする
+			mockBlogRepository.On("FetchDeletedBlogById", policyBlogId).Return(&models.BlogData{ID: policyBlogId, UserId: policyOwnerId}, nil)
+			mockBlogActor(mockUserRepository, userId, tt.role)
+			if tt.restore {
+				mockBlogRepository.On("RestoreBlog", policyBlogId).Return(&models.BlogData{ID: policyBlogId, UserId: policyOwnerId}, nil)
+			}
+
+			blog, err := blogService.RestoreBlog(context.Background(), policyBlogId, userId)
+
+			if tt.restore {
+				assert.NoError(t, err)
+				// 投稿者は変わらない
+				assert.Equal(t, policyOwnerId, blog.UserId)
+			} else {
+				assert.EqualError(t, err, "forbidden")
+				mockBlogRepository.AssertNotCalled(t, "RestoreBlog", policyBlogId)
+			}
+			mockBlogRepository.AssertExpectations(t)
+		})
+	}
+}
+
+// キャッシュの統計は admin・editor のみ取得できる
+func TestService_BlogPolicy_CacheStats(t *testing.T) {
+	tests := []struct {
+		role    string
+		allowed bool
+	}{
+		{role: models.UserRoleAdmin, allowed: true},
+		{role: models.UserRoleEditor, allowed: true},
+		{role: models.UserRoleAuthor, allowed: false},
+		{role: "", allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			blogService := services_blogs.NewBlogService(nil, nil, nil, mockUserRepository, nil)
+
+			mockBlogActor(mockUserRepository, policyOtherId, tt.role)
+
+			stats, err := blogService.FetchCacheStats(context.Background(), policyOtherId)
+
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, "forbidden")
+				assert.Nil(t, stats)
+			}
+		})
+	}
+}
+
+func TestService_BlogPolicy_ErrorCases(t *testing.T) {
+	tests := []struct {
+		name        string
+		blogErr     error
+		userErr     error
+		expectedErr string
+	}{
+		{name: "ブログが存在しない", blogErr: pgx.ErrNoRows, expectedErr: "blog not found"},
+		{name: "ブログの取得に失敗", blogErr: errors.New("db error"), expectedErr: "failed to delete blog"},
+		{name: "ユーザーが存在しない", userErr: pgx.ErrNoRows, expectedErr: "forbidden"},
+		{name: "ユーザーの取得に失敗", userErr: errors.New("db error"), expectedErr: "failed to delete blog"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
+
+			if tt.blogErr != nil {
+				mockBlogRepository.On("FetchBlogById", policyBlogId).Return(nil, tt.blogErr)
+			} else {
+				mockBlogRepository.On("FetchBlogById", policyBlogId).Return(&models.BlogData{ID: policyBlogId, UserId: policyOwnerId}, nil)
+				mockUserRepository.On("FetchUserById", policyOwnerId).Return(nil, tt.userErr)
+			}
+
+			err := blogService.DeleteBlog(context.Background(), policyBlogId, policyOwnerId)
+
+			assert.EqualError(t, err, tt.expectedErr)
+			mockBlogRepository.AssertNotCalled(t, "DeleteBlog", policyBlogId)
+		})
+	}
+}
@@ -0,0 +1,412 @@
+package services_blogs_test
+
+import (
+	"backend/models"
+	repositories_blog_revisions "backend/repositories/blog_revisions"
+	repositories_blogs "backend/repositories/blogs"
+	repositories_categories "backend/repositories/categories"
+	repositories_users "backend/repositories/users"
+	services_blogs "backend/services/blogs"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_FetchBlogRevisions(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockRevisionRepository := new(repositories_blog_revisions.MockBlogRevisionRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, mockRevisionRepository, mockUserRepository, nil)
+
+	// 入力データ
+	id := uuid.New().String()
+	userId := uuid.New().String()
+	expected := []models.BlogRevisionData{{BlogId: id, Revision: 2}, {BlogId: id, Revision: 1}}
+
+	// モックの設定
+	mockBlogOwnership(mockBlogRepository, mockUserRepository, id, userId, userId, models.UserRoleAuthor)
+	mockRevisionRepository.On("FetchRevisionsByBlogId", id).Return(expected, nil)
+
+	// テスト対象メソッドの呼び出し
+	revisions, err := blogService.FetchBlogRevisions(context.Background(), id, userId)
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Equal(t, expected, revisions)
+
+	// モックの期待通りの呼び出しを検証
+	mockBlogRepository.AssertExpectations(t)
+	mockRevisionRepository.AssertExpectations(t)
+}
+
+func TestService_FetchBlogRevisions_BlogNotFound(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockRevisionRepository := new(repositories_blog_revisions.MockBlogRevisionRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, mockRevisionRepository, nil, nil)
+
+	// 入力データ
+	id := uuid.New().String()
+
+	// モックの設定
+	mockBlogRepository.On("FetchBlogById", id).Return(nil, pgx.ErrNoRows)
+
+	// テスト対象メソッドの呼び出し
+	revisions, err := blogService.FetchBlogRevisions(context.Background(), id, uuid.New().String())
+
+	// アサーション
+	assert.EqualError(t, err, "blog not found")
+	assert.Nil(t, revisions)
+
+	// モックの期待通りの呼び出しを検証
+	mockRevisionRepository.AssertNotCalled(t, "FetchRevisionsByBlogId", mock.Anything)
+}
+
+func TestService_FetchBlogRevision(t *testing.T) {
+	// 入力データ
+	id := uuid.New().String()
+	userId := uuid.New().String()
+
+	tests := []struct {
+		name        string
+		id          string
+		revision    int
+		repoResult  *models.BlogRevisionData
+		repoErr     error
+		expectedErr string
+		callRepo    bool
+	}{
+		{
+			name:       "取得成功",
+			id:         id,
+			revision:   1,
+			repoResult: &models.BlogRevisionData{BlogId: id, Revision: 1},
+			callRepo:   true,
+		},
+		{
+			name:        "不正なID",
+			id:          "invalid",
+			revision:    1,
+			expectedErr: "invalid id",
+		},
+		{
+			name:        "不正な版番号",
+			id:          id,
+			revision:    0,
+			expectedErr: "invalid revision",
+		},
+		{
+			name:        "版が存在しない",
+			id:          id,
+			revision:    5,
+			repoErr:     pgx.ErrNoRows,
+			expectedErr: "revision not found",
+			callRepo:    true,
+		},
+		{
+			name:        "リポジトリエラー",
+			id:          id,
+			revision:    1,
+			repoErr:     errors.New("db error"),
+			expectedErr: "failed to fetch revision",
+			callRepo:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			mockRevisionRepository := new(repositories_blog_revisions.MockBlogRevisionRepository)
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, nil, mockRevisionRepository, mockUserRepository, nil)
+
+			// モックの設定
+			if tt.callRepo {
+				mockBlogOwnership(mockBlogRepository, mockUserRepository, tt.id, userId, userId, models.UserRoleAuthor)
+				mockRevisionRepository.On("FetchRevision", tt.id, tt.revision).Return(tt.repoResult, tt.repoErr)
+			}
+
+			// テスト対象メソッドの呼び出し
+			revision, err := blogService.FetchBlogRevision(context.Background(), tt.id, tt.revision, userId)
+
+			// アサーション
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, revision)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.repoResult, revision)
+			}
+
+			// モックの期待通りの呼び出しを検証
+			if tt.callRepo {
+				mockRevisionRepository.AssertExpectations(t)
+			} else {
+				mockRevisionRepository.AssertNotCalled(t, "FetchRevision", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestService_DiffBlogRevisions(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockRevisionRepository := new(repositories_blog_revisions.MockBlogRevisionRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, mockRevisionRepository, mockUserRepository, nil)
+
+	// 入力データ
+	id := uuid.New().String()
+	userId := uuid.New().String()
+	from := &models.BlogRevisionData{BlogId: id, Revision: 1, Title: "old", Description: "same", GithubUrl: "url", Category: "go", Tags: "a", BodyMarkdown: "# old"}
+	to := &models.BlogRevisionData{BlogId: id, Revision: 3, Title: "new", Description: "same", GithubUrl: "url", Category: "web", Tags: "a", BodyMarkdown: "# new"}
+
+	// モックの設定
+	mockBlogOwnership(mockBlogRepository, mockUserRepository, id, userId, userId, models.UserRoleAuthor)
+	mockRevisionRepository.On("FetchRevision", id, 1).Return(from, nil)
+	mockRevisionRepository.On("FetchRevision", id, 3).Return(to, nil)
+
+	// テスト対象メソッドの呼び出し
+	diff, err := blogService.DiffBlogRevisions(context.Background(), id, 1, 3, userId)
+
+	// アサーション(変更されたフィールドのみ、定義順に返す)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.BlogRevisionDiff{
+		BlogId: id,
+		From:   1,
+		To:     3,
+		Changes: []models.BlogFieldChange{
+			{Field: "title", From: "old", To: "new"},
+			{Field: "category", From: "go", To: "web"},
+			{Field: "body_markdown", From: "# old", To: "# new"},
+		},
+	}, diff)
+
+	// モックの期待通りの呼び出しを検証
+	mockRevisionRepository.AssertExpectations(t)
+}
+
+func TestService_DiffBlogRevisions_SameRevision(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockRevisionRepository := new(repositories_blog_revisions.MockBlogRevisionRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, mockRevisionRepository, mockUserRepository, nil)
+
+	// 入力データ
+	id := uuid.New().String()
+	userId := uuid.New().String()
+
+	// モックの設定
+	mockBlogOwnership(mockBlogRepository, mockUserRepository, id, userId, userId, models.UserRoleAuthor)
+	mockRevisionRepository.On("FetchRevision", id, 2).Return(&models.BlogRevisionData{BlogId: id, Revision: 2, Title: "title"}, nil)
+
+	// テスト対象メソッドの呼び出し
+	diff, err := blogService.DiffBlogRevisions(context.Background(), id, 2, 2, userId)
+
+	// アサーション(差分は空配列)
+	assert.NoError(t, err)
+	assert.NotNil(t, diff.Changes)
+	assert.Empty(t, diff.Changes)
+}
+
+func TestService_DiffBlogRevisions_NotFound(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockRevisionRepository := new(repositories_blog_revisions.MockBlogRevisionRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, mockRevisionRepository, mockUserRepository, nil)
+
+	// 入力データ
+	id := uuid.New().String()
+	userId := uuid.New().String()
+
+	// モックの設定
+	mockBlogOwnership(mockBlogRepository, mockUserRepository, id, userId, userId, models.UserRoleAuthor)
+	mockRevisionRepository.On("FetchRevision", id, 1).Return(&models.BlogRevisionData{BlogId: id, Revision: 1}, nil)
+	mockRevisionRepository.On("FetchRevision", id, 9).Return(nil, pgx.ErrNoRows)
+
+	// テスト対象メソッドの呼び出し
+	diff, err := blogService.DiffBlogRevisions(context.Background(), id, 1, 9, userId)
+
+	// アサーション
+	assert.EqualError(t, err, "revision not found")
+	assert.Nil(t, diff)
+
+	// 不正な版番号ではリポジトリを呼び出さない
+	diff, err = blogService.DiffBlogRevisions(context.Background(), id, 0, 1, userId)
+	assert.EqualError(t, err, "invalid revision")
+	assert.Nil(t, diff)
+	mockRevisionRepository.AssertNumberOfCalls(t, "FetchRevision", 2)
+}
+
+func TestService_RevertBlog(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	mockRevisionRepository := new(repositories_blog_revisions.MockBlogRevisionRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, mockRevisionRepository, mockUserRepository, nil)
+
+	// 入力データ
+	id := uuid.New().String()
+	userId := uuid.New().String()
+	revision := &models.BlogRevisionData{BlogId: id, Revision: 1, Title: "old", Description: "desc", GithubUrl: "url", Category: "tech", Tags: "go", BodyMarkdown: "old body"}
+	expected := &models.BlogData{ID: id, Title: "old", Category: "Tech", BodyMarkdown: "old body"}
+
+	// モックの設定(カテゴリは登録済みの名前に解決し直す)
+	mockBlogOwnership(mockBlogRepository, mockUserRepository, id, userId, userId, models.UserRoleAuthor)
+	mockRevisionRepository.On("FetchRevision", id, 1).Return(revision, nil)
+	mockCategoryRepository.On("FetchCategoryByName", "tech").Return(&models.CategoryData{Name: "Tech"}, nil)
+	mockBlogRepository.On("UpdateBlog", id, userId, "old", "url", "Tech", "desc", "go", "old body").Return(expected, nil)
+
+	// テスト対象メソッドの呼び出し
+	blog, err := blogService.RevertBlog(context.Background(), id, 1, userId)
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Equal(t, expected, blog)
+
+	// モックの期待通りの呼び出しを検証
+	mockRevisionRepository.AssertExpectations(t)
+	mockCategoryRepository.AssertExpectations(t)
+	mockBlogRepository.AssertExpectations(t)
+}
+
+func TestService_RevertBlog_Error(t *testing.T) {
+	// 入力データ
+	id := uuid.New().String()
+	userId := uuid.New().String()
+	revision := &models.BlogRevisionData{BlogId: id, Revision: 1, Title: "old", Description: "desc", GithubUrl: "url", Category: "deleted", Tags: "go"}
+
+	tests := []struct {
+		name        string
+		ownerId     string
+		revisionErr error
+		categoryErr error
+		updateErr   error
+		expectedErr string
+	}{
+		{
+			name:        "他人のブログ",
+			ownerId:     uuid.New().String(),
+			expectedErr: "forbidden",
+		},
+		{
+			name:        "版が存在しない",
+			revisionErr: pgx.ErrNoRows,
+			expectedErr: "revision not found",
+		},
+		{
+			name:        "カテゴリが削除済み",
+			categoryErr: pgx.ErrNoRows,
+			expectedErr: "unknown category",
+		},
+		{
+			name:        "ブログが存在しない",
+			updateErr:   pgx.ErrNoRows,
+			expectedErr: "blog not found",
+		},
+		{
+			name:        "更新エラー",
+			updateErr:   errors.New("db error"),
+			expectedErr: "failed to revert blog",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+			mockRevisionRepository := new(repositories_blog_revisions.MockBlogRevisionRepository)
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, mockRevisionRepository, mockUserRepository, nil)
+
+			// モックの設定
+			ownerId := userId
+			if tt.ownerId != "" {
+				ownerId = tt.ownerId
+			}
+			mockBlogOwnership(mockBlogRepository, mockUserRepository, id, ownerId, userId, models.UserRoleAuthor)
+			if tt.revisionErr != nil {
+				mockRevisionRepository.On("FetchRevision", id, 1).Return(nil, tt.revisionErr)
+			} else {
+				mockRevisionRepository.On("FetchRevision", id, 1).Return(revision, nil)
+			}
+			if tt.categoryErr != nil {
+				mockCategoryRepository.On("FetchCategoryByName", "deleted").Return(nil, tt.categoryErr)
+			} else {
+				mockCategoryRepository.On("FetchCategoryByName", "deleted").Return(&models.CategoryData{Name: "deleted"}, nil)
+			}
+			mockBlogRepository.On("UpdateBlog", id, userId, "old", "url", "deleted", "desc", "go", "").Return(nil, tt.updateErr)
+
+			// テスト対象メソッドの呼び出し
+			blog, err := blogService.RevertBlog(context.Background(), id, 1, userId)
+
+			// アサーション
+			assert.EqualError(t, err, tt.expectedErr)
+			assert.Nil(t, blog)
+		})
+	}
+}
+
+// 版履歴は下書きや非公開の内容を含むため、ブログを変更できないユーザーには返さない
+func TestService_BlogRevisions_Forbidden(t *testing.T) {
+	id := uuid.New().String()
+	ownerId := uuid.New().String()
+	userId := uuid.New().String()
+
+	calls := map[string]func(blogService services_blogs.BlogService) (interface{}, error){
+		"版履歴": func(blogService services_blogs.BlogService) (interface{}, error) {
+			return blogService.FetchBlogRevisions(context.Background(), id, userId)
+		},
+		"版": func(blogService services_blogs.BlogService) (interface{}, error) {
+			return blogService.FetchBlogRevision(context.Background(), id, 1, userId)
+		},
+		"差分": func(blogService services_blogs.BlogService) (interface{}, error) {
+			return blogService.DiffBlogRevisions(context.Background(), id, 1, 2, userId)
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			mockRevisionRepository := new(repositories_blog_revisions.MockBlogRevisionRepository)
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, nil, mockRevisionRepository, mockUserRepository, nil)
+
+			// モックの設定(他人のブログを author が閲覧する)
+			mockBlogOwnership(mockBlogRepository, mockUserRepository, id, ownerId, userId, models.UserRoleAuthor)
+
+			// テスト対象メソッドの呼び出し
+			_, err := call(blogService)
+
+			// アサーション
+			assert.EqualError(t, err, "forbidden")
+			mockRevisionRepository.AssertNotCalled(t, "FetchRevisionsByBlogId", mock.Anything)
+			mockRevisionRepository.AssertNotCalled(t, "FetchRevision", mock.Anything, mock.Anything)
+		})
+	}
+
+	// editor は他人のブログの版履歴も閲覧できる
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockRevisionRepository := new(repositories_blog_revisions.MockBlogRevisionRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, mockRevisionRepository, mockUserRepository, nil)
+	mockBlogOwnership(mockBlogRepository, mockUserRepository, id, ownerId, userId, models.UserRoleEditor)
+	mockRevisionRepository.On("FetchRevisionsByBlogId", id).Return([]models.BlogRevisionData{{BlogId: id, Revision: 1}}, nil)
+
+	revisions, err := blogService.FetchBlogRevisions(context.Background(), id, userId)
+
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 1)
+}
@@ -0,0 +1,103 @@
+package services_blogs_test
+
+import (
+	"backend/models"
+	repositories_blogs "backend/repositories/blogs"
+	services_blogs "backend/services/blogs"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_SearchBlogs(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+	mockResults := []models.BlogSearchResult{
+		{
+			BlogData: models.BlogData{
+				ID:          "1",
+				Title:       "Goで作るブログ<API>",
+				Tags:        "Go, Echo",
+				Description: strings.Repeat("あ", 50) + "ブログの説明" + strings.Repeat("い", 100),
+			},
+			Score: 4,
+		},
+	}
+
+	// 全角空白で区切られた検索語は小文字化・重複除去してリポジトリに渡す
+	mockBlogRepository.On("SearchBlogs", []string{"ブログ", "go"}, 20).Return(mockResults, nil)
+
+	page, err := blogService.SearchBlogs(context.Background(), "ブログ　Go ブログ", 0)
+
+	// エラーチェック
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, 4, page.Items[0].Score)
+
+	// ハイライトの確認(HTMLはエスケープされる)
+	highlights := page.Items[0].Highlights
+	assert.Equal(t, "<mark>Go</mark>で作る<mark>ブログ</mark>&lt;API&gt;", highlights.Title)
+	assert.Equal(t, "<mark>Go</mark>, Echo", highlights.Tags)
+	assert.True(t, strings.HasPrefix(highlights.Description, "…"))
+	assert.True(t, strings.HasSuffix(highlights.Description, "…"))
+	assert.Contains(t, highlights.Description, "<mark>ブログ</mark>の説明")
+
+	// モックが期待通りに呼び出されたかを確認
+	mockBlogRepository.AssertExpectations(t)
+}
+
+func TestService_SearchBlogs_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		q      string
+		limit  int
+		errMsg string
+	}{
+		{"空のクエリ", "", 0, "invalid query"},
+		{"空白のみのクエリ", " 　 ", 0, "invalid query"},
+		{"検索語が多すぎる", "a b c d e f", 0, "invalid query"},
+		{"クエリが長すぎる", strings.Repeat("あ", 101), 0, "invalid query"},
+		{"不正なlimit", "go", 101, "invalid limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+			page, err := blogService.SearchBlogs(context.Background(), tt.q, tt.limit)
+
+			// エラーチェック
+			assert.Nil(t, page)
+			assert.EqualError(t, err, tt.errMsg)
+
+			// リポジトリが呼び出されないことを確認
+			mockBlogRepository.AssertNotCalled(t, "SearchBlogs", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_SearchBlogs_Error(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+	// リポジトリがエラーを返す場合
+	mockBlogRepository.On("SearchBlogs", []string{"go"}, 20).Return(nil, errors.New("db error"))
+
+	page, err := blogService.SearchBlogs(context.Background(), "go", 0)
+
+	// エラーチェック
+	assert.Nil(t, page)
+	assert.EqualError(t, err, "failed to search blogs")
+
+	// モックが期待通りに呼び出されたかを確認
+	mockBlogRepository.AssertExpectations(t)
+}
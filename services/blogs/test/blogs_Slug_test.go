@@ -0,0 +1,142 @@
+package services_blogs_test
+
+import (
+	"backend/models"
+	repositories_blogs "backend/repositories/blogs"
+	repositories_users "backend/repositories/users"
+	services_blogs "backend/services/blogs"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_FetchBlogBySlug(t *testing.T) {
+	userId := uuid.New().String()
+
+	tests := []struct {
+		name     string
+		slug     string
+		blog     *models.BlogData
+		repoErr  error
+		viewerId string
+		wantErr  string
+	}{
+		{name: "published", slug: "hello", blog: &models.BlogData{Slug: "hello", UserId: userId, Status: models.BlogStatusPublished}},
+		// 変更前のスラッグでも現在のブログデータを返す
+		{name: "old slug", slug: "old", blog: &models.BlogData{Slug: "hello", UserId: userId, Status: models.BlogStatusPublished}},
+		{name: "draft for owner", slug: "hello", blog: &models.BlogData{Slug: "hello", UserId: userId, Status: models.BlogStatusDraft}, viewerId: userId},
+		{name: "draft for others", slug: "hello", blog: &models.BlogData{Slug: "hello", UserId: userId, Status: models.BlogStatusDraft}, wantErr: "blog not found"},
+		{name: "not found", slug: "hello", repoErr: pgx.ErrNoRows, wantErr: "blog not found"},
+		{name: "invalid slug", slug: "Hello World", wantErr: "invalid slug"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+			// モックの設定
+			if tt.blog != nil {
+				mockBlogRepository.On("FetchBlogBySlug", tt.slug).Return(tt.blog, nil)
+			} else {
+				mockBlogRepository.On("FetchBlogBySlug", tt.slug).Return(nil, tt.repoErr)
+			}
+
+			// テスト対象メソッドの呼び出し
+			blog, err := blogService.FetchBlogBySlug(context.Background(), tt.slug, tt.viewerId)
+
+			// アサーション
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, blog)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, *tt.blog, blog.BlogData)
+		})
+	}
+}
+
+func TestService_UpdateBlogSlug(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
+
+	// 入力データ
+	id := uuid.New().String()
+	userId := uuid.New().String()
+
+	// モックの設定
+	mockBlogActor(mockUserRepository, userId, models.UserRoleAuthor)
+	mockBlogRepository.On("FetchBlogById", id).Return(&models.BlogData{ID: id, UserId: userId, Slug: "old"}, nil)
+	mockBlogRepository.On("UpdateBlogSlug", id, "new-slug").Return(&models.BlogData{ID: id, UserId: userId, Slug: "new-slug"}, nil)
+
+	// 前後の空白は取り除くこと
+	blog, err := blogService.UpdateBlogSlug(context.Background(), id, userId, " new-slug ")
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Equal(t, "new-slug", blog.Slug)
+	mockBlogRepository.AssertExpectations(t)
+}
+
+func TestService_UpdateBlogSlug_ErrorCases(t *testing.T) {
+	id := uuid.New().String()
+	userId := uuid.New().String()
+
+	tests := []struct {
+		name      string
+		id        string
+		userId    string
+		slug      string
+		fetchBlog *models.BlogData
+		fetchErr  error
+		updateErr error
+		wantErr   string
+	}{
+		{name: "invalid id", id: "invalid", userId: userId, slug: "slug", wantErr: "invalid id"},
+		{name: "invalid userId", id: id, userId: "", slug: "slug", wantErr: "invalid userId"},
+		{name: "empty slug", id: id, userId: userId, slug: "", wantErr: "invalid slug"},
+		{name: "japanese slug", id: id, userId: userId, slug: "ブログ", wantErr: "invalid slug"},
+		{name: "blog not found", id: id, userId: userId, slug: "slug", fetchErr: pgx.ErrNoRows, wantErr: "blog not found"},
+		// author は他人のブログのスラッグを変更できない
+		{name: "other user's blog", id: id, userId: userId, slug: "slug", fetchBlog: &models.BlogData{ID: id, UserId: uuid.New().String()}, wantErr: "forbidden"},
+		{name: "slug conflict", id: id, userId: userId, slug: "slug", fetchBlog: &models.BlogData{ID: id, UserId: userId}, updateErr: repositories_blogs.ErrBlogSlugConflict, wantErr: "slug conflict"},
+		{name: "repository error", id: id, userId: userId, slug: "slug", fetchBlog: &models.BlogData{ID: id, UserId: userId}, updateErr: errors.New("db error"), wantErr: "failed to update blog slug"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
+
+			// モックの設定
+			mockBlogActor(mockUserRepository, tt.userId, models.UserRoleAuthor)
+			if tt.fetchBlog != nil {
+				mockBlogRepository.On("FetchBlogById", tt.id).Return(tt.fetchBlog, nil)
+			} else {
+				mockBlogRepository.On("FetchBlogById", tt.id).Return(nil, tt.fetchErr)
+			}
+			mockBlogRepository.On("UpdateBlogSlug", tt.id, tt.slug).Return(nil, tt.updateErr)
+
+			// テスト対象メソッドの呼び出し
+			blog, err := blogService.UpdateBlogSlug(context.Background(), tt.id, tt.userId, tt.slug)
+
+			// アサーション
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Nil(t, blog)
+			if tt.updateErr == nil {
+				mockBlogRepository.AssertNotCalled(t, "UpdateBlogSlug", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
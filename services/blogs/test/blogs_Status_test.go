@@ -0,0 +1,254 @@
+package services_blogs_test
+
+import (
+	"backend/models"
+	repositories_blogs "backend/repositories/blogs"
+	repositories_users "backend/repositories/users"
+	services_blogs "backend/services/blogs"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_UpdateBlogStatus(t *testing.T) {
+	id := uuid.New().String()
+	userId := uuid.New().String()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		current     string
+		status      string
+		publishedAt *time.Time
+		wantErr     string
+	}{
+		// 許可される状態遷移
+		{name: "draft to published", current: models.BlogStatusDraft, status: models.BlogStatusPublished},
+		{name: "draft to published with past date", current: models.BlogStatusDraft, status: models.BlogStatusPublished, publishedAt: &past},
+		{name: "draft to scheduled", current: models.BlogStatusDraft, status: models.BlogStatusScheduled, publishedAt: &future},
+		{name: "scheduled to scheduled", current: models.BlogStatusScheduled, status: models.BlogStatusScheduled, publishedAt: &future},
+		{name: "scheduled to draft", current: models.BlogStatusScheduled, status: models.BlogStatusDraft},
+		{name: "published to unpublished", current: models.BlogStatusPublished, status: models.BlogStatusUnpublished},
+		{name: "unpublished to published", current: models.BlogStatusUnpublished, status: models.BlogStatusPublished},
+		// 許可されない状態遷移
+		{name: "published to draft", current: models.BlogStatusPublished, status: models.BlogStatusDraft, wantErr: "invalid status transition"},
+		{name: "published to published", current: models.BlogStatusPublished, status: models.BlogStatusPublished, wantErr: "invalid status transition"},
+		{name: "draft to unpublished", current: models.BlogStatusDraft, status: models.BlogStatusUnpublished, wantErr: "invalid status transition"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
+
+			// モックの設定
+			mockBlogActor(mockUserRepository, userId, models.UserRoleAuthor)
+			mockBlogRepository.On("FetchBlogById", id).Return(&models.BlogData{ID: id, UserId: userId, Status: tt.current, PublishedAt: &past}, nil)
+			mockBlogRepository.On("UpdateBlogStatus", id, tt.current, tt.status, mock.Anything).Return(&models.BlogData{ID: id, Status: tt.status}, nil)
+
+			// テスト対象メソッドの呼び出し
+			blog, err := blogService.UpdateBlogStatus(context.Background(), id, userId, tt.status, tt.publishedAt)
+
+			// アサーション
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, blog)
+				mockBlogRepository.AssertNotCalled(t, "UpdateBlogStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, blog.Status)
+			mockBlogRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_UpdateBlogStatus_PublishedAt(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
+
+	// 入力データ
+	id := uuid.New().String()
+	userId := uuid.New().String()
+	firstPublishedAt := time.Now().Add(-24 * time.Hour)
+
+	// モックの設定
+	mockBlogActor(mockUserRepository, userId, models.UserRoleAuthor)
+	mockBlogRepository.On("FetchBlogById", id).Return(&models.BlogData{ID: id, UserId: userId, Status: models.BlogStatusPublished, PublishedAt: &firstPublishedAt}, nil)
+	mockBlogRepository.On("UpdateBlogStatus", id, models.BlogStatusPublished, models.BlogStatusUnpublished, &firstPublishedAt).Return(&models.BlogData{ID: id}, nil)
+
+	// 非公開にしても最初の公開日時が保持されること
+	_, err := blogService.UpdateBlogStatus(context.Background(), id, userId, models.BlogStatusUnpublished, nil)
+	assert.NoError(t, err)
+	mockBlogRepository.AssertExpectations(t)
+
+	// 下書きにする場合は公開日時を持たないこと
+	mockBlogRepository = new(repositories_blogs.MockBlogRepository)
+	blogService = services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
+	mockBlogRepository.On("FetchBlogById", id).Return(&models.BlogData{ID: id, UserId: userId, Status: models.BlogStatusUnpublished, PublishedAt: &firstPublishedAt}, nil)
+	mockBlogRepository.On("UpdateBlogStatus", id, models.BlogStatusUnpublished, models.BlogStatusDraft, (*time.Time)(nil)).Return(&models.BlogData{ID: id}, nil)
+
+	_, err = blogService.UpdateBlogStatus(context.Background(), id, userId, models.BlogStatusDraft, &firstPublishedAt)
+	assert.NoError(t, err)
+	mockBlogRepository.AssertExpectations(t)
+}
+
+func TestService_UpdateBlogStatus_InvalidInput(t *testing.T) {
+	id := uuid.New().String()
+	userId := uuid.New().String()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		id          string
+		userId      string
+		status      string
+		publishedAt *time.Time
+		wantErr     string
+	}{
+		{name: "invalid id", id: "invalid", userId: userId, status: models.BlogStatusPublished, wantErr: "invalid id"},
+		{name: "invalid userId", id: id, userId: "", status: models.BlogStatusPublished, wantErr: "invalid userId"},
+		{name: "unknown status", id: id, userId: userId, status: "archived", wantErr: "invalid status"},
+		// 予約には未来の公開日時が必要
+		{name: "scheduled without date", id: id, userId: userId, status: models.BlogStatusScheduled, wantErr: "invalid publishedAt"},
+		{name: "scheduled with past date", id: id, userId: userId, status: models.BlogStatusScheduled, publishedAt: &past, wantErr: "invalid publishedAt"},
+		// 未来の日時での公開は予約を使う
+		{name: "published with future date", id: id, userId: userId, status: models.BlogStatusPublished, publishedAt: &future, wantErr: "invalid publishedAt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+			// テスト対象メソッドの呼び出し
+			blog, err := blogService.UpdateBlogStatus(context.Background(), tt.id, tt.userId, tt.status, tt.publishedAt)
+
+			// アサーション
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Nil(t, blog)
+			mockBlogRepository.AssertNotCalled(t, "FetchBlogById", mock.Anything)
+		})
+	}
+}
+
+func TestService_UpdateBlogStatus_ErrorCases(t *testing.T) {
+	id := uuid.New().String()
+	userId := uuid.New().String()
+
+	t.Run("blog not found", func(t *testing.T) {
+		mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+		blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+		mockBlogRepository.On("FetchBlogById", id).Return(nil, pgx.ErrNoRows)
+
+		_, err := blogService.UpdateBlogStatus(context.Background(), id, userId, models.BlogStatusPublished, nil)
+		assert.EqualError(t, err, "blog not found")
+	})
+
+	t.Run("other user's blog", func(t *testing.T) {
+		// author は他人のブログの公開状態を変更できない
+		mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+		mockUserRepository := new(repositories_users.MockUserRepository)
+		blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
+		mockBlogOwnership(mockBlogRepository, mockUserRepository, id, uuid.New().String(), userId, models.UserRoleAuthor)
+
+		_, err := blogService.UpdateBlogStatus(context.Background(), id, userId, models.BlogStatusPublished, nil)
+		assert.EqualError(t, err, "forbidden")
+		mockBlogRepository.AssertNotCalled(t, "UpdateBlogStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("status changed concurrently", func(t *testing.T) {
+		// 取得後に予約公開などで状態が変わった場合は競合として扱う
+		mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+		mockUserRepository := new(repositories_users.MockUserRepository)
+		blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
+		mockBlogActor(mockUserRepository, userId, models.UserRoleAuthor)
+		mockBlogRepository.On("FetchBlogById", id).Return(&models.BlogData{ID: id, UserId: userId, Status: models.BlogStatusScheduled}, nil)
+		mockBlogRepository.On("UpdateBlogStatus", id, models.BlogStatusScheduled, models.BlogStatusDraft, (*time.Time)(nil)).Return(nil, pgx.ErrNoRows)
+
+		_, err := blogService.UpdateBlogStatus(context.Background(), id, userId, models.BlogStatusDraft, nil)
+		assert.EqualError(t, err, "invalid status transition")
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+		mockUserRepository := new(repositories_users.MockUserRepository)
+		blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
+		mockBlogActor(mockUserRepository, userId, models.UserRoleAuthor)
+		mockBlogRepository.On("FetchBlogById", id).Return(&models.BlogData{ID: id, UserId: userId, Status: models.BlogStatusDraft}, nil)
+		mockBlogRepository.On("UpdateBlogStatus", id, models.BlogStatusDraft, models.BlogStatusPublished, mock.Anything).Return(nil, errors.New("db error"))
+
+		_, err := blogService.UpdateBlogStatus(context.Background(), id, userId, models.BlogStatusPublished, nil)
+		assert.EqualError(t, err, "failed to update blog status")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+		blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+		mockBlogRepository.On("FetchBlogById", id).Return(nil, context.DeadlineExceeded)
+
+		_, err := blogService.UpdateBlogStatus(context.Background(), id, userId, models.BlogStatusPublished, nil)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestService_PublishScheduledBlogs(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+	// 現在日時を基準に公開すること
+	before := time.Now()
+	mockBlogRepository.On("PublishScheduledBlogs", mock.MatchedBy(func(now time.Time) bool {
+		return !now.Before(before) && !now.After(time.Now())
+	})).Return(2, nil).Once()
+
+	published, err := blogService.PublishScheduledBlogs(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, published)
+
+	// リポジトリのエラー
+	mockBlogRepository.On("PublishScheduledBlogs", mock.Anything).Return(0, errors.New("db error")).Once()
+	published, err = blogService.PublishScheduledBlogs(context.Background())
+	assert.EqualError(t, err, "failed to publish scheduled blogs")
+	assert.Equal(t, 0, published)
+}
+
+func TestService_RunPublishScheduler(t *testing.T) {
+	// モックサービスをインスタンス化
+	mockBlogService := new(services_blogs.MockBlogService)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	// 1回目の実行後にキャンセルする
+	mockBlogService.On("PublishScheduledBlogs").Return(0, nil).Run(func(mock.Arguments) {
+		cancel()
+	})
+
+	go func() {
+		services_blogs.RunPublishScheduler(ctx, mockBlogService, time.Hour)
+		close(done)
+	}()
+
+	// キャンセル後に停止すること
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish scheduler did not stop")
+	}
+	mockBlogService.AssertNumberOfCalls(t, "PublishScheduledBlogs", 1)
+}
@@ -0,0 +1,284 @@
+package services_blogs_test
+
+import (
+	"backend/models"
+	repositories_blogs "backend/repositories/blogs"
+	repositories_users "backend/repositories/users"
+	services_blogs "backend/services/blogs"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_FetchDeletedBlogs(t *testing.T) {
+	// 入力データ
+	userId := uuid.New().String()
+	deletedAt := time.Now()
+	own := []models.BlogData{{ID: uuid.New().String(), UserId: userId, DeletedAt: &deletedAt}}
+	all := append([]models.BlogData{{ID: uuid.New().String(), UserId: uuid.New().String(), DeletedAt: &deletedAt}}, own...)
+
+	tests := []struct {
+		name     string
+		role     string
+		expected []models.BlogData
+	}{
+		// author は自分のブログのみ、editor・admin はすべてのユーザーのブログを取得する
+		{name: "author", role: models.UserRoleAuthor, expected: own},
+		{name: "editor", role: models.UserRoleEditor, expected: all},
+		{name: "admin", role: models.UserRoleAdmin, expected: all},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
+
+			// モックの設定
+			mockBlogActor(mockUserRepository, userId, tt.role)
+			if tt.role == models.UserRoleAuthor {
+				mockBlogRepository.On("FetchDeletedBlogsByUserId", userId).Return(own, nil)
+			} else {
+				mockBlogRepository.On("FetchDeletedBlogs").Return(all, nil)
+			}
+
+			// テスト対象メソッドの呼び出し
+			blogs, err := blogService.FetchDeletedBlogs(context.Background(), userId)
+
+			// アサーション
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, blogs)
+
+			// モックの期待通りの呼び出しを検証
+			mockBlogRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_FetchDeletedBlogs_Empty(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
+
+	// 入力データ
+	userId := uuid.New().String()
+
+	// モックの設定
+	mockBlogActor(mockUserRepository, userId, models.UserRoleAuthor)
+	mockBlogRepository.On("FetchDeletedBlogsByUserId", userId).Return(nil, nil)
+
+	// テスト対象メソッドの呼び出し
+	blogs, err := blogService.FetchDeletedBlogs(context.Background(), userId)
+
+	// アサーション（空配列として返す）
+	assert.NoError(t, err)
+	assert.NotNil(t, blogs)
+	assert.Empty(t, blogs)
+
+	// モックの期待通りの呼び出しを検証
+	mockBlogRepository.AssertExpectations(t)
+}
+
+func TestService_FetchDeletedBlogs_InvalidUserId(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+	// テスト対象メソッドの呼び出し
+	blogs, err := blogService.FetchDeletedBlogs(context.Background(), "invalid")
+
+	// アサーション
+	assert.EqualError(t, err, "invalid userId")
+	assert.Nil(t, blogs)
+
+	// モックの期待通りの呼び出しを検証
+	mockBlogRepository.AssertNotCalled(t, "FetchDeletedBlogsByUserId", mock.Anything)
+}
+
+func TestService_FetchDeletedBlogs_UserNotFound(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
+
+	// 入力データ
+	userId := uuid.New().String()
+
+	// モックの設定
+	mockUserRepository.On("FetchUserById", userId).Return(nil, pgx.ErrNoRows)
+
+	// テスト対象メソッドの呼び出し
+	blogs, err := blogService.FetchDeletedBlogs(context.Background(), userId)
+
+	// アサーション
+	assert.EqualError(t, err, "forbidden")
+	assert.Nil(t, blogs)
+
+	// モックの期待通りの呼び出しを検証
+	mockBlogRepository.AssertNotCalled(t, "FetchDeletedBlogsByUserId", mock.Anything)
+	mockBlogRepository.AssertNotCalled(t, "FetchDeletedBlogs")
+}
+
+func TestService_RestoreBlog(t *testing.T) {
+	// 入力データ
+	id := uuid.New().String()
+	userId := uuid.New().String()
+
+	tests := []struct {
+		name        string
+		id          string
+		fetchErr    error
+		repoBlog    *models.BlogData
+		repoErr     error
+		expectedErr string
+		callRepo    bool
+	}{
+		{
+			name:     "復元成功",
+			id:       id,
+			repoBlog: &models.BlogData{ID: id, UserId: userId},
+			callRepo: true,
+		},
+		{
+			name:        "不正なID",
+			id:          "invalid",
+			expectedErr: "invalid id",
+		},
+		{
+			name:        "ゴミ箱に存在しない",
+			id:          id,
+			fetchErr:    pgx.ErrNoRows,
+			expectedErr: "blog not found",
+		},
+		{
+			name:        "同時に復元された",
+			id:          id,
+			repoErr:     pgx.ErrNoRows,
+			expectedErr: "blog not found",
+			callRepo:    true,
+		},
+		{
+			name:        "リポジトリエラー",
+			id:          id,
+			repoErr:     errors.New("db error"),
+			expectedErr: "failed to restore blog",
+			callRepo:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, mockUserRepository, nil)
+
+			// モックの設定
+			if tt.fetchErr != nil {
+				mockBlogRepository.On("FetchDeletedBlogById", tt.id).Return(nil, tt.fetchErr)
+			} else {
+				mockBlogRepository.On("FetchDeletedBlogById", tt.id).Return(&models.BlogData{ID: tt.id, UserId: userId}, nil)
+				mockBlogActor(mockUserRepository, userId, models.UserRoleAuthor)
+			}
+			if tt.callRepo {
+				mockBlogRepository.On("RestoreBlog", tt.id).Return(tt.repoBlog, tt.repoErr)
+			}
+
+			// テスト対象メソッドの呼び出し
+			blog, err := blogService.RestoreBlog(context.Background(), tt.id, userId)
+
+			// アサーション
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, blog)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.repoBlog, blog)
+			}
+
+			// モックの期待通りの呼び出しを検証
+			if tt.callRepo {
+				mockBlogRepository.AssertExpectations(t)
+			} else {
+				mockBlogRepository.AssertNotCalled(t, "RestoreBlog", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestService_PurgeDeletedBlogs(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+	// 入力データ
+	retention := 24 * time.Hour
+	start := time.Now()
+
+	// モックの設定（保持期間を差し引いた日時で呼び出されること）
+	mockBlogRepository.On("PurgeDeletedBlogs", mock.MatchedBy(func(before time.Time) bool {
+		return !before.After(start.Add(-retention).Add(time.Minute)) && !before.Before(start.Add(-retention))
+	})).Return(3, nil)
+
+	// テスト対象メソッドの呼び出し
+	purged, err := blogService.PurgeDeletedBlogs(context.Background(), retention)
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Equal(t, 3, purged)
+
+	// モックの期待通りの呼び出しを検証
+	mockBlogRepository.AssertExpectations(t)
+}
+
+func TestService_PurgeDeletedBlogs_Error(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, nil, nil, nil, nil)
+
+	// 不正な保持期間ではリポジトリを呼び出さない
+	purged, err := blogService.PurgeDeletedBlogs(context.Background(), 0)
+	assert.EqualError(t, err, "invalid retention")
+	assert.Equal(t, 0, purged)
+	mockBlogRepository.AssertNotCalled(t, "PurgeDeletedBlogs", mock.Anything)
+
+	// リポジトリエラー
+	mockBlogRepository.On("PurgeDeletedBlogs", mock.Anything).Return(0, errors.New("db error"))
+	purged, err = blogService.PurgeDeletedBlogs(context.Background(), time.Hour)
+	assert.EqualError(t, err, "failed to purge deleted blogs")
+	assert.Equal(t, 0, purged)
+}
+
+func TestService_RunTrashPurger(t *testing.T) {
+	// モックサービスをインスタンス化
+	mockBlogService := new(services_blogs.MockBlogService)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	// 1回目の実行後にキャンセルする
+	mockBlogService.On("PurgeDeletedBlogs", time.Hour).Return(0, nil).Run(func(mock.Arguments) {
+		cancel()
+	})
+
+	go func() {
+		services_blogs.RunTrashPurger(ctx, mockBlogService, time.Hour, time.Hour)
+		close(done)
+	}()
+
+	// キャンセル後に停止すること
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("trash purger did not stop")
+	}
+	mockBlogService.AssertNumberOfCalls(t, "PurgeDeletedBlogs", 1)
+}
@@ -3,18 +3,25 @@ package services_blogs_test
 import (
 	"backend/models"
 	repositories_blogs "backend/repositories/blogs"
+	repositories_categories "backend/repositories/categories"
+	repositories_users "backend/repositories/users"
 	services_blogs "backend/services/blogs"
+	"context"
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestService_UpdateBlog(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, mockUserRepository, nil)
 
 	// 入力データ
 	id := "123"
@@ -39,10 +46,12 @@ func TestService_UpdateBlog(t *testing.T) {
 	}
 
 	// モックの設定
-	mockBlogRepository.On("UpdateBlog", id, title, githubURL, category, description, tags).Return(&expectedBlog, nil)
+	mockBlogOwnership(mockBlogRepository, mockUserRepository, id, userId, userId, models.UserRoleAuthor)
+	mockCategoryRepository.On("FetchCategoryByName", category).Return(&models.CategoryData{Name: category}, nil)
+	mockBlogRepository.On("UpdateBlog", id, userId, title, githubURL, category, description, tags, "").Return(&expectedBlog, nil)
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.UpdateBlog(id, title, githubURL, category, description, tags)
+	blog, err := blogService.UpdateBlog(context.Background(), id, userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.NoError(t, err)
@@ -56,10 +65,12 @@ func TestService_UpdateBlog(t *testing.T) {
 func TestService_UpdateBlog_InvalidId(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, nil, nil)
 
 	// 入力データ
 	id := ""
+	userId := "user1"
 	title := "Test Blog"
 	githubURL := "https://github.com/user/repo"
 	category := "Tech"
@@ -67,7 +78,7 @@ func TestService_UpdateBlog_InvalidId(t *testing.T) {
 	tags := "go, testing"
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.UpdateBlog(id, title, githubURL, category, description, tags)
+	blog, err := blogService.UpdateBlog(context.Background(), id, userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.Error(t, err)
@@ -75,16 +86,47 @@ func TestService_UpdateBlog_InvalidId(t *testing.T) {
 	assert.Equal(t, "invalid id", err.Error())
 
 	// モックの期待通りの呼び出しを検証
-	mockBlogRepository.AssertNotCalled(t, "UpdateBlog", id, title, githubURL, category, description, tags)
+	mockBlogRepository.AssertNotCalled(t, "UpdateBlog", id, userId, title, githubURL, category, description, tags, "")
+	mockCategoryRepository.AssertNotCalled(t, "FetchCategoryByName", mock.Anything)
+}
+
+func TestService_UpdateBlog_InvalidUserId(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, nil, nil)
+
+	// 入力データ
+	id := "123"
+	userId := ""
+	title := "Test Blog"
+	githubURL := "https://github.com/user/repo"
+	category := "Tech"
+	description := "This is a test blog."
+	tags := "go, testing"
+
+	// テスト対象メソッドの呼び出し
+	blog, err := blogService.UpdateBlog(context.Background(), id, userId, title, githubURL, category, description, tags, "")
+
+	// アサーション
+	assert.Error(t, err)
+	assert.Nil(t, blog)
+	assert.Equal(t, "invalid userId", err.Error())
+
+	// モックの期待通りの呼び出しを検証
+	mockBlogRepository.AssertNotCalled(t, "UpdateBlog", id, userId, title, githubURL, category, description, tags, "")
+	mockCategoryRepository.AssertNotCalled(t, "FetchCategoryByName", mock.Anything)
 }
 
 func TestService_UpdateBlog_InvalidTitle(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, nil, nil)
 
 	// 入力データ
 	id := "123"
+	userId := "user1"
 	title := ""
 	githubURL := "https://github.com/user/repo"
 	category := "Tech"
@@ -92,7 +134,7 @@ func TestService_UpdateBlog_InvalidTitle(t *testing.T) {
 	tags := "go, testing"
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.UpdateBlog(id, title, githubURL, category, description, tags)
+	blog, err := blogService.UpdateBlog(context.Background(), id, userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.Error(t, err)
@@ -100,16 +142,19 @@ func TestService_UpdateBlog_InvalidTitle(t *testing.T) {
 	assert.Equal(t, "invalid title", err.Error())
 
 	// モックの期待通りの呼び出しを検証
-	mockBlogRepository.AssertNotCalled(t, "UpdateBlog", id, title, githubURL, category, description, tags)
+	mockBlogRepository.AssertNotCalled(t, "UpdateBlog", id, userId, title, githubURL, category, description, tags, "")
+	mockCategoryRepository.AssertNotCalled(t, "FetchCategoryByName", mock.Anything)
 }
 
 func TestService_UpdateBlog_InvalidGithubUrl(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, nil, nil)
 
 	// 入力データ
 	id := "123"
+	userId := "user1"
 	title := "Test Blog"
 	githubURL := ""
 	category := "Tech"
@@ -117,7 +162,7 @@ func TestService_UpdateBlog_InvalidGithubUrl(t *testing.T) {
 	tags := "go, testing"
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.UpdateBlog(id, title, githubURL, category, description, tags)
+	blog, err := blogService.UpdateBlog(context.Background(), id, userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.Error(t, err)
@@ -125,16 +170,19 @@ func TestService_UpdateBlog_InvalidGithubUrl(t *testing.T) {
 	assert.Equal(t, "invalid githubUrl", err.Error())
 
 	// モックの期待通りの呼び出しを検証
-	mockBlogRepository.AssertNotCalled(t, "UpdateBlog", id, title, githubURL, category, description, tags)
+	mockBlogRepository.AssertNotCalled(t, "UpdateBlog", id, userId, title, githubURL, category, description, tags, "")
+	mockCategoryRepository.AssertNotCalled(t, "FetchCategoryByName", mock.Anything)
 }
 
 func TestService_UpdateBlog_InvalidCategory(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, nil, nil)
 
 	// 入力データ
 	id := "123"
+	userId := "user1"
 	title := "Test Blog"
 	githubURL := "https://github.com/user/repo"
 	category := ""
@@ -142,7 +190,7 @@ func TestService_UpdateBlog_InvalidCategory(t *testing.T) {
 	tags := "go, testing"
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.UpdateBlog(id, title, githubURL, category, description, tags)
+	blog, err := blogService.UpdateBlog(context.Background(), id, userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.Error(t, err)
@@ -150,16 +198,19 @@ func TestService_UpdateBlog_InvalidCategory(t *testing.T) {
 	assert.Equal(t, "invalid category", err.Error())
 
 	// モックの期待通りの呼び出しを検証
-	mockBlogRepository.AssertNotCalled(t, "UpdateBlog", id, title, githubURL, category, description, tags)
+	mockBlogRepository.AssertNotCalled(t, "UpdateBlog", id, userId, title, githubURL, category, description, tags, "")
+	mockCategoryRepository.AssertNotCalled(t, "FetchCategoryByName", mock.Anything)
 }
 
 func TestService_UpdateBlog_InvalidDescription(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, nil, nil)
 
 	// 入力データ
 	id := "123"
+	userId := "user1"
 	title := "Test Blog"
 	githubURL := "https://github.com/user/repo"
 	category := "Tech"
@@ -167,7 +218,7 @@ func TestService_UpdateBlog_InvalidDescription(t *testing.T) {
 	tags := "go, testing"
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.UpdateBlog(id, title, githubURL, category, description, tags)
+	blog, err := blogService.UpdateBlog(context.Background(), id, userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.Error(t, err)
@@ -175,16 +226,19 @@ func TestService_UpdateBlog_InvalidDescription(t *testing.T) {
 	assert.Equal(t, "invalid description", err.Error())
 
 	// モックの期待通りの呼び出しを検証
-	mockBlogRepository.AssertNotCalled(t, "UpdateBlog", id, title, githubURL, category, description, tags)
+	mockBlogRepository.AssertNotCalled(t, "UpdateBlog", id, userId, title, githubURL, category, description, tags, "")
+	mockCategoryRepository.AssertNotCalled(t, "FetchCategoryByName", mock.Anything)
 }
 
 func TestService_UpdateBlog_InvalidTags(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, nil, nil)
 
 	// 入力データ
 	id := "123"
+	userId := "user1"
 	title := "Test Blog"
 	githubURL := "https://github.com/user/repo"
 	category := "Tech"
@@ -192,7 +246,7 @@ func TestService_UpdateBlog_InvalidTags(t *testing.T) {
 	tags := ""
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.UpdateBlog(id, title, githubURL, category, description, tags)
+	blog, err := blogService.UpdateBlog(context.Background(), id, userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.Error(t, err)
@@ -200,16 +254,20 @@ func TestService_UpdateBlog_InvalidTags(t *testing.T) {
 	assert.Equal(t, "invalid tags", err.Error())
 
 	// モックの期待通りの呼び出しを検証
-	mockBlogRepository.AssertNotCalled(t, "UpdateBlog", id, title, githubURL, category, description, tags)
+	mockBlogRepository.AssertNotCalled(t, "UpdateBlog", id, userId, title, githubURL, category, description, tags, "")
+	mockCategoryRepository.AssertNotCalled(t, "FetchCategoryByName", mock.Anything)
 }
 
 func TestService_UpdateBlog_NoUpdate(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
-	blogService := services_blogs.NewBlogService(mockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, mockUserRepository, nil)
 
 	// 入力データ
 	id := "123"
+	userId := "user1"
 	title := "Test Blog"
 	githubURL := "https://github.com/user/repo"
 	category := "Tech"
@@ -217,10 +275,12 @@ func TestService_UpdateBlog_NoUpdate(t *testing.T) {
 	tags := "go, testing"
 
 	// モックの設定
-	mockBlogRepository.On("UpdateBlog", id, title, githubURL, category, description, tags).Return(nil, errors.New("no update"))
+	mockBlogOwnership(mockBlogRepository, mockUserRepository, id, userId, userId, models.UserRoleAuthor)
+	mockCategoryRepository.On("FetchCategoryByName", category).Return(&models.CategoryData{Name: category}, nil)
+	mockBlogRepository.On("UpdateBlog", id, userId, title, githubURL, category, description, tags, "").Return(nil, errors.New("no update"))
 
 	// テスト対象メソッドの呼び出し
-	blog, err := blogService.UpdateBlog(id, title, githubURL, category, description, tags)
+	blog, err := blogService.UpdateBlog(context.Background(), id, userId, title, githubURL, category, description, tags, "")
 
 	// アサーション
 	assert.Error(t, err)
@@ -230,3 +290,26 @@ func TestService_UpdateBlog_NoUpdate(t *testing.T) {
 	// モックの期待通りの呼び出しを検証
 	mockBlogRepository.AssertExpectations(t)
 }
+
+func TestService_UpdateBlog_UnknownCategory(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogRepository := new(repositories_blogs.MockBlogRepository)
+	mockCategoryRepository := new(repositories_categories.MockCategoryRepository)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	blogService := services_blogs.NewBlogService(mockBlogRepository, mockCategoryRepository, nil, mockUserRepository, nil)
+
+	// モックの設定: 未登録のカテゴリ
+	mockBlogOwnership(mockBlogRepository, mockUserRepository, "1", "user1", "user1", models.UserRoleAuthor)
+	mockCategoryRepository.On("FetchCategoryByName", "Unknown").Return(nil, pgx.ErrNoRows)
+
+	// テスト対象メソッドの呼び出し
+	blog, err := blogService.UpdateBlog(context.Background(), "1", "user1", "Test Blog", "https://github.com/user/repo", "Unknown", "This is a test blog.", "go", "")
+
+	// アサーション
+	assert.EqualError(t, err, "unknown category")
+	assert.Nil(t, blog)
+
+	// ブログが更新されないことを確認
+	mockCategoryRepository.AssertExpectations(t)
+	mockBlogRepository.AssertNotCalled(t, "UpdateBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
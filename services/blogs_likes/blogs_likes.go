@@ -2,12 +2,15 @@ package services_blogs_likes
 
 import (
 	"backend/models"
+	repositories_blogs_likes "backend/repositories/blogs_likes"
+	utils_cache "backend/utils/cache"
+	"context"
 	"errors"
 	"log"
 )
 
 // VisitIdに紐づくいいねデータを取得
-func (s *BlogLikeServiceImpl) FetchBlogLikesByVisitId(visitId string) ([]models.BlogLikeData, error) {
+func (s *BlogLikeServiceImpl) FetchBlogLikesByVisitId(ctx context.Context, visitId string) ([]models.BlogLikeData, error) {
 	log.Println("FetchBlogLikesByVisitId start...")
 
 	// バリデーション
@@ -19,7 +22,7 @@ func (s *BlogLikeServiceImpl) FetchBlogLikesByVisitId(visitId string) ([]models.
 	log.Println("validation passed")
 
 	// いいねデータを取得
-	blogLikes, err := s.BlogLikeRepository.FetchBlogLikesByVisitId(visitId)
+	blogLikes, err := s.BlogLikeRepository.FetchBlogLikesByVisitId(ctx, visitId)
 	if err != nil {
 		return nil, err
 	}
@@ -28,7 +31,7 @@ func (s *BlogLikeServiceImpl) FetchBlogLikesByVisitId(visitId string) ([]models.
 }
 
 // いいね存在するか確認
-func (s *BlogLikeServiceImpl) IsBlogLiked(blogId, visitId string) (bool, error) {
+func (s *BlogLikeServiceImpl) IsBlogLiked(ctx context.Context, blogId, visitId string) (bool, error) {
 	log.Println("IsBlogLiked start...")
 
 	// バリデーション
@@ -39,7 +42,7 @@ func (s *BlogLikeServiceImpl) IsBlogLiked(blogId, visitId string) (bool, error)
 	log.Println("validation passed")
 
 	// いいねデータが存在するか確認
-	isLiked, err := s.BlogLikeRepository.IsBlogLiked(blogId, visitId)
+	isLiked, err := s.BlogLikeRepository.IsBlogLiked(ctx, blogId, visitId)
 	if err != nil {
 		return false, err
 	}
@@ -48,7 +51,8 @@ func (s *BlogLikeServiceImpl) IsBlogLiked(blogId, visitId string) (bool, error)
 }
 
 // いいねデータの作成
-func (s *BlogLikeServiceImpl) CreateBlogLike(blogId, visitId string) (*models.BlogLikeData, error) {
+// ブログがない、またはゴミ箱に入っている場合は "blog not found" エラーを返す。
+func (s *BlogLikeServiceImpl) CreateBlogLike(ctx context.Context, blogId, visitId string) (*models.BlogLikeData, error) {
 	log.Println("CreateBlogLike start...")
 
 	// バリデーション
@@ -57,7 +61,7 @@ func (s *BlogLikeServiceImpl) CreateBlogLike(blogId, visitId string) (*models.Bl
 		return nil, errors.New("blogId or VisitId is empty")
 	}
 	// ブログがいいねされているか確認
-	IsBlogLiked, _ := s.BlogLikeRepository.IsBlogLiked(blogId, visitId)
+	IsBlogLiked, _ := s.BlogLikeRepository.IsBlogLiked(ctx, blogId, visitId)
 	if IsBlogLiked {
 		log.Println("Blog is already liked")
 		return nil, errors.New("blog is already liked")
@@ -66,23 +70,38 @@ func (s *BlogLikeServiceImpl) CreateBlogLike(blogId, visitId string) (*models.Bl
 	log.Println("validation passed")
 
 	// いいねデータを作成
-	blogLike, err := s.BlogLikeRepository.CreateBlogLike(blogId, visitId)
+	blogLike, err := s.BlogLikeRepository.CreateBlogLike(ctx, blogId, visitId)
 	if err != nil {
+		if errors.Is(err, repositories_blogs_likes.ErrBlogNotFound) {
+			log.Println("Blog not found")
+			return nil, errors.New("blog not found")
+		}
 		return nil, err
 	}
 
+	// いいね数を含むブログ一覧・人気のブログのキャッシュを無効化
+	s.invalidateCache()
+
 	return blogLike, nil
 }
 
 // いいねデータの削除
-func (s *BlogLikeServiceImpl) DeleteBlogLike(blogId, visitId string) error {
+func (s *BlogLikeServiceImpl) DeleteBlogLike(ctx context.Context, blogId, visitId string) error {
 	log.Println("DeleteBlogLike start...")
 
 	// いいねデータを削除
-	err := s.BlogLikeRepository.DeleteBlogLike(blogId, visitId)
+	err := s.BlogLikeRepository.DeleteBlogLike(ctx, blogId, visitId)
 	if err != nil {
 		return err
 	}
 
+	// いいね数を含むブログ一覧・人気のブログのキャッシュを無効化
+	s.invalidateCache()
+
 	return nil
 }
+
+// いいね数を含むキャッシュを無効化する
+func (s *BlogLikeServiceImpl) invalidateCache() {
+	s.Cache.Invalidate(utils_cache.NamespaceBlogs, utils_cache.NamespacePopular)
+}
@@ -0,0 +1,33 @@
+package services_blogs_likes
+
+import (
+	"backend/models"
+	repositories_blogs_likes "backend/repositories/blogs_likes"
+	utils_cache "backend/utils/cache"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_BlogLike_InvalidatesCache(t *testing.T) {
+	// モックリポジトリとキャッシュをインスタンス化
+	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
+	cache := utils_cache.New(time.Minute)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, cache)
+
+	mockBlogLikeRepository.On("IsBlogLiked", "1", "1").Return(false, nil)
+	mockBlogLikeRepository.On("CreateBlogLike", "1", "1").Return(&models.BlogLikeData{BlogId: "1", VisitId: "1"}, nil)
+	mockBlogLikeRepository.On("DeleteBlogLike", "1", "1").Return(nil)
+
+	// いいねの作成・削除でブログ一覧・人気のブログのキャッシュを無効化する
+	_, err := blogLikeService.CreateBlogLike(context.Background(), "1", "1")
+	assert.NoError(t, err)
+	assert.NoError(t, blogLikeService.DeleteBlogLike(context.Background(), "1", "1"))
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(2), stats[utils_cache.NamespaceBlogs].Invalidations)
+	assert.Equal(t, uint64(2), stats[utils_cache.NamespacePopular].Invalidations)
+	assert.NotContains(t, stats, utils_cache.NamespaceTags)
+}
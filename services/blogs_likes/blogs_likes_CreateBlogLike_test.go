@@ -3,6 +3,7 @@ package services_blogs_likes
 import (
 	"backend/models"
 	repositories_blogs_likes "backend/repositories/blogs_likes"
+	"context"
 	"errors"
 	"testing"
 
@@ -12,7 +13,7 @@ import (
 func TestService_CreateBlogLike(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
-	blogLikeService := NewBlogLikeService(mockBlogLikeRepository)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, nil)
 
 	// モックデータ
 	blogLikeData := &models.BlogLikeData{
@@ -25,7 +26,7 @@ func TestService_CreateBlogLike(t *testing.T) {
 	mockBlogLikeRepository.On("CreateBlogLike", "1", "1").Return(blogLikeData, nil)
 
 	// 実行
-	createdBlogLikeData, err := blogLikeService.CreateBlogLike("1", "1")
+	createdBlogLikeData, err := blogLikeService.CreateBlogLike(context.Background(), "1", "1")
 
 	// エラーチェック
 	assert.NoError(t, err)
@@ -38,10 +39,10 @@ func TestService_CreateBlogLike(t *testing.T) {
 func TestService_CreateBlogLike_InValidBlogId(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
-	blogLikeService := NewBlogLikeService(mockBlogLikeRepository)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, nil)
 
 	// 実行
-	createdBlogLikeData, err := blogLikeService.CreateBlogLike("", "1")
+	createdBlogLikeData, err := blogLikeService.CreateBlogLike(context.Background(), "", "1")
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -56,10 +57,10 @@ func TestService_CreateBlogLike_InValidBlogId(t *testing.T) {
 func TestService_CreateBlogLike_InValidVisitId(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
-	blogLikeService := NewBlogLikeService(mockBlogLikeRepository)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, nil)
 
 	// 実行
-	createdBlogLikeData, err := blogLikeService.CreateBlogLike("1", "")
+	createdBlogLikeData, err := blogLikeService.CreateBlogLike(context.Background(), "1", "")
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -74,13 +75,13 @@ func TestService_CreateBlogLike_InValidVisitId(t *testing.T) {
 func TestService_CreateBlogLike_AlreadyBlogLike(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
-	blogLikeService := NewBlogLikeService(mockBlogLikeRepository)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, nil)
 
 	// モックの設定
 	mockBlogLikeRepository.On("IsBlogLiked", "1", "1").Return(true, nil)
 
 	// 実行
-	createdBlogLikeData, err := blogLikeService.CreateBlogLike("1", "1")
+	createdBlogLikeData, err := blogLikeService.CreateBlogLike(context.Background(), "1", "1")
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -95,14 +96,14 @@ func TestService_CreateBlogLike_AlreadyBlogLike(t *testing.T) {
 func TestService_CreateBlogLike_NotCreate(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
-	blogLikeService := NewBlogLikeService(mockBlogLikeRepository)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, nil)
 
 	// モックの設定
 	mockBlogLikeRepository.On("IsBlogLiked", "1", "1").Return(false, nil)
 	mockBlogLikeRepository.On("CreateBlogLike", "1", "1").Return(nil, errors.New("not created"))
 
 	// 実行
-	createdBlogLikeData, err := blogLikeService.CreateBlogLike("1", "1")
+	createdBlogLikeData, err := blogLikeService.CreateBlogLike(context.Background(), "1", "1")
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -112,3 +113,23 @@ func TestService_CreateBlogLike_NotCreate(t *testing.T) {
 	// モックの呼び出し確認
 	mockBlogLikeRepository.AssertExpectations(t)
 }
+
+func TestService_CreateBlogLike_BlogNotFound(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, nil)
+
+	// モックの設定(ブログがゴミ箱に入っている)
+	mockBlogLikeRepository.On("IsBlogLiked", "1", "1").Return(false, errors.New("no rows in result set"))
+	mockBlogLikeRepository.On("CreateBlogLike", "1", "1").Return(nil, repositories_blogs_likes.ErrBlogNotFound)
+
+	// 実行
+	createdBlogLikeData, err := blogLikeService.CreateBlogLike(context.Background(), "1", "1")
+
+	// エラーチェック
+	assert.EqualError(t, err, "blog not found")
+	assert.Nil(t, createdBlogLikeData)
+
+	// モックが期待通りに呼び出されたかを確認
+	mockBlogLikeRepository.AssertExpectations(t)
+}
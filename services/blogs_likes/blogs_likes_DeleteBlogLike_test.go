@@ -2,6 +2,7 @@ package services_blogs_likes
 
 import (
 	repositories_blogs_likes "backend/repositories/blogs_likes"
+	"context"
 	"errors"
 	"testing"
 
@@ -11,12 +12,12 @@ import (
 func TestService_DeleteBlogLike(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
-	blogLikeService := NewBlogLikeService(mockBlogLikeRepository)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, nil)
 
 	mockBlogLikeRepository.On("DeleteBlogLike", "1", "1").Return(nil)
 
 	// 実行
-	err := blogLikeService.DeleteBlogLike("1", "1")
+	err := blogLikeService.DeleteBlogLike(context.Background(), "1", "1")
 
 	// エラーチェック
 	assert.NoError(t, err)
@@ -28,12 +29,12 @@ func TestService_DeleteBlogLike(t *testing.T) {
 func TestService_DeleteBlogLike_NoDelete(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
-	blogLikeService := NewBlogLikeService(mockBlogLikeRepository)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, nil)
 
 	mockBlogLikeRepository.On("DeleteBlogLike", "1", "1").Return(errors.New("Delete Error"))
 
 	// 実行
-	err := blogLikeService.DeleteBlogLike("1", "1")
+	err := blogLikeService.DeleteBlogLike(context.Background(), "1", "1")
 
 	// エラーチェック
 	assert.Error(t, err)
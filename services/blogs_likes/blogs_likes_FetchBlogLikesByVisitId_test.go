@@ -3,6 +3,7 @@ package services_blogs_likes
 import (
 	"backend/models"
 	repositories_blogs_likes "backend/repositories/blogs_likes"
+	"context"
 	"errors"
 	"testing"
 
@@ -12,7 +13,7 @@ import (
 func TestService_FetchBlogLikesByVisitId(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
-	blogLikeService := NewBlogLikeService(mockBlogLikeRepository)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, nil)
 
 	// モックデータの設定
 	mockData := []models.BlogLikeData{
@@ -30,7 +31,7 @@ func TestService_FetchBlogLikesByVisitId(t *testing.T) {
 	mockBlogLikeRepository.On("FetchBlogLikesByVisitId", "1").Return(mockData, nil)
 
 	// 実行
-	blogLikesData, err := blogLikeService.FetchBlogLikesByVisitId("1")
+	blogLikesData, err := blogLikeService.FetchBlogLikesByVisitId(context.Background(), "1")
 
 	// エラーチェック
 	assert.NoError(t, err)
@@ -44,10 +45,10 @@ func TestService_FetchBlogLikesByVisitId(t *testing.T) {
 func TestService_FetchBlogLikesByVisitId_InvalidVisitId(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
-	blogLikeService := NewBlogLikeService(mockBlogLikeRepository)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, nil)
 
 	// 実行
-	blogLikesData, err := blogLikeService.FetchBlogLikesByVisitId("")
+	blogLikesData, err := blogLikeService.FetchBlogLikesByVisitId(context.Background(), "")
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -61,13 +62,13 @@ func TestService_FetchBlogLikesByVisitId_InvalidVisitId(t *testing.T) {
 func TestService_FetchBlogLikesByVisitId_NoData(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
-	blogLikeService := NewBlogLikeService(mockBlogLikeRepository)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, nil)
 
 	// モックの設定
 	mockBlogLikeRepository.On("FetchBlogLikesByVisitId", "1").Return(nil, errors.New("no data"))
 
 	// 実行
-	blogLikesData, err := blogLikeService.FetchBlogLikesByVisitId("1")
+	blogLikesData, err := blogLikeService.FetchBlogLikesByVisitId(context.Background(), "1")
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -2,6 +2,7 @@ package services_blogs_likes
 
 import (
 	repositories_blogs_likes "backend/repositories/blogs_likes"
+	"context"
 	"errors"
 	"testing"
 
@@ -11,13 +12,13 @@ import (
 func TestService_IsBlogLiked(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
-	blogLikeService := NewBlogLikeService(mockBlogLikeRepository)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, nil)
 
 	// モックの設定
 	mockBlogLikeRepository.On("IsBlogLiked", "1", "1").Return(true, nil)
 
 	// 実行
-	isLiked, err := blogLikeService.IsBlogLiked("1", "1")
+	isLiked, err := blogLikeService.IsBlogLiked(context.Background(), "1", "1")
 
 	// エラーチェック
 	assert.NoError(t, err)
@@ -30,10 +31,10 @@ func TestService_IsBlogLiked(t *testing.T) {
 func TestService_IsBlogLiked_InvalidBlogId(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
-	blogLikeService := NewBlogLikeService(mockBlogLikeRepository)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, nil)
 
 	// 実行
-	isLiked, err := blogLikeService.IsBlogLiked("", "1")
+	isLiked, err := blogLikeService.IsBlogLiked(context.Background(), "", "1")
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -47,10 +48,10 @@ func TestService_IsBlogLiked_InvalidBlogId(t *testing.T) {
 func TestService_IsBlogLiked_InvalidVisitId(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
-	blogLikeService := NewBlogLikeService(mockBlogLikeRepository)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, nil)
 
 	// 実行
-	isLiked, err := blogLikeService.IsBlogLiked("1", "")
+	isLiked, err := blogLikeService.IsBlogLiked(context.Background(), "1", "")
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -64,13 +65,13 @@ func TestService_IsBlogLiked_InvalidVisitId(t *testing.T) {
 func TestService_IsBlogLiked_NotLike(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockBlogLikeRepository := new(repositories_blogs_likes.MockBlogLikeRepository)
-	blogLikeService := NewBlogLikeService(mockBlogLikeRepository)
+	blogLikeService := NewBlogLikeService(mockBlogLikeRepository, nil)
 
 	// モックの設定
 	mockBlogLikeRepository.On("IsBlogLiked", "1", "1").Return(false, errors.New("not found"))
 
 	// 実行
-	isLiked, err := blogLikeService.IsBlogLiked("1", "1")
+	isLiked, err := blogLikeService.IsBlogLiked(context.Background(), "1", "1")
 
 	// エラーチェック
 	assert.Error(t, err)
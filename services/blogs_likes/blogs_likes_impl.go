@@ -3,25 +3,30 @@ package services_blogs_likes
 import (
 	"backend/models"
 	repositories_blogs_likes "backend/repositories/blogs_likes"
+	utils_cache "backend/utils/cache"
+	"context"
 )
 
 // BlogLikeServiceインターフェース
 type BlogLikeService interface {
-	FetchBlogLikesByVisitId(visitId string) ([]models.BlogLikeData, error)
-	IsBlogLiked(blogId, visitId string) (bool, error)
-	CreateBlogLike(blogId, visitId string) (*models.BlogLikeData, error)
-	DeleteBlogLike(blogId, visitId string) error
+	FetchBlogLikesByVisitId(ctx context.Context, visitId string) ([]models.BlogLikeData, error)
+	IsBlogLiked(ctx context.Context, blogId, visitId string) (bool, error)
+	CreateBlogLike(ctx context.Context, blogId, visitId string) (*models.BlogLikeData, error)
+	DeleteBlogLike(ctx context.Context, blogId, visitId string) error
 }
 
 type BlogLikeServiceImpl struct {
 	BlogLikeRepository repositories_blogs_likes.BlogLikeRepository
+	Cache              *utils_cache.Cache
 }
 
 // BlogLikeServiceインターフェースを実装したBlogLikeServiceImplのポインタを返す
 func NewBlogLikeService(
 	blogLikeRepository repositories_blogs_likes.BlogLikeRepository,
+	cache *utils_cache.Cache,
 ) BlogLikeService {
 	return &BlogLikeServiceImpl{
 		BlogLikeRepository: blogLikeRepository,
+		Cache:              cache,
 	}
 }
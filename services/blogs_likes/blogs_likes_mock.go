@@ -2,6 +2,7 @@ package services_blogs_likes
 
 import (
 	"backend/models"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,7 +11,7 @@ type MockBlogLikeService struct {
 	mock.Mock
 }
 
-func (m *MockBlogLikeService) FetchBlogLikesByVisitId(visitId string) ([]models.BlogLikeData, error) {
+func (m *MockBlogLikeService) FetchBlogLikesByVisitId(ctx context.Context, visitId string) ([]models.BlogLikeData, error) {
 	args := m.Called(visitId)
 	if args.Get(0) != nil {
 		return args.Get(0).([]models.BlogLikeData), args.Error(1)
@@ -18,17 +19,17 @@ func (m *MockBlogLikeService) FetchBlogLikesByVisitId(visitId string) ([]models.
 	return nil, args.Error(1)
 }
 
-func (m *MockBlogLikeService) IsBlogLiked(blogId, visitId string) (bool, error) {
+func (m *MockBlogLikeService) IsBlogLiked(ctx context.Context, blogId, visitId string) (bool, error) {
 	args := m.Called(blogId, visitId)
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockBlogLikeService) CreateBlogLike(blogId, visitId string) (*models.BlogLikeData, error) {
+func (m *MockBlogLikeService) CreateBlogLike(ctx context.Context, blogId, visitId string) (*models.BlogLikeData, error) {
 	args := m.Called(blogId, visitId)
 	return args.Get(0).(*models.BlogLikeData), args.Error(1)
 }
 
-func (m *MockBlogLikeService) DeleteBlogLike(blogId, visitId string) error {
+func (m *MockBlogLikeService) DeleteBlogLike(ctx context.Context, blogId, visitId string) error {
 	args := m.Called(blogId, visitId)
 	return args.Error(0)
 }
@@ -0,0 +1,215 @@
+package services_categories
+
+import (
+	"backend/models"
+	repositories_categories "backend/repositories/categories"
+	utils_cache "backend/utils/cache"
+	utils_timeout "backend/utils/timeout"
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// カテゴリ名・スラッグ・説明の最大文字数
+const (
+	maxCategoryNameLength        = 50
+	maxCategorySlugLength        = 100
+	maxCategoryDescriptionLength = 500
+)
+
+// スラッグは半角英小文字・数字をハイフンで区切った形式とする
+var categorySlugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// UUID形式のIDか判定する
+func isValidId(id string) bool {
+	_, err := uuid.Parse(id)
+	return err == nil
+}
+
+// カテゴリの入力値を検証する
+// 名前・スラッグ・説明は前後の空白を取り除いた値を返す。
+func validateCategoryInput(name, slug, description string, displayOrder int) (string, string, string, error) {
+	name = strings.TrimSpace(name)
+	slug = strings.TrimSpace(slug)
+	description = strings.TrimSpace(description)
+
+	if name == "" || utf8.RuneCountInString(name) > maxCategoryNameLength {
+		log.Printf("invalid name: %s", name)
+		return "", "", "", errors.New("invalid name")
+	}
+	if !categorySlugPattern.MatchString(slug) || len(slug) > maxCategorySlugLength {
+		log.Printf("invalid slug: %s", slug)
+		return "", "", "", errors.New("invalid slug")
+	}
+	if utf8.RuneCountInString(description) > maxCategoryDescriptionLength {
+		log.Printf("invalid description: %s", description)
+		return "", "", "", errors.New("invalid description")
+	}
+	if displayOrder < 0 {
+		log.Printf("invalid displayOrder: %d", displayOrder)
+		return "", "", "", errors.New("invalid displayOrder")
+	}
+	return name, slug, description, nil
+}
+
+// 指定されたIDに一致するカテゴリ情報を取得する
+func (s *CategoryServiceImpl) FetchCategoryById(ctx context.Context, id string) (*models.CategoryData, error) {
+	log.Printf("FetchCategoryById start...")
+
+	// バリデーション
+	if !isValidId(id) {
+		log.Printf("invalid id: %s", id)
+		return nil, errors.New("invalid id")
+	}
+	log.Println("Valid id")
+
+	// リポジトリを呼び出してカテゴリ情報を取得
+	category, err := s.CategoryRepository.FetchCategoryById(ctx, id)
+	if err != nil {
+		log.Printf("Failed to fetch category: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("category not found")
+		}
+		return nil, errors.New("failed to fetch category")
+	}
+
+	log.Printf("Fetched category successfully: %v", category)
+	return category, nil
+}
+
+// カテゴリを作成する
+// admin・editor のみ作成できる。権限がない場合は "forbidden" エラーを返す。
+func (s *CategoryServiceImpl) CreateCategory(ctx context.Context, userId, name, slug, description string, displayOrder int) (*models.CategoryData, error) {
+	log.Printf("CreateCategory start...")
+
+	// バリデーション
+	name, slug, description, err := validateCategoryInput(name, slug, description, displayOrder)
+	if err != nil {
+		return nil, err
+	}
+	log.Println("Valid input")
+
+	// 管理できるか確認
+	if err := s.checkCanManage(ctx, userId, "failed to create category"); err != nil {
+		return nil, err
+	}
+
+	// リポジトリを呼び出してカテゴリを作成
+	category, err := s.CategoryRepository.CreateCategory(ctx, name, slug, description, displayOrder)
+	if err != nil {
+		log.Printf("Failed to create category: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, repositories_categories.ErrCategoryConflict) {
+			return nil, errors.New("category already exists")
+		}
+		return nil, errors.New("failed to create category")
+	}
+
+	// カテゴリ一覧・ブログ一覧のキャッシュを無効化
+	s.invalidateCache()
+
+	log.Printf("Created category successfully: %v", category)
+	return category, nil
+}
+
+// 指定されたIDに一致するカテゴリを更新する
+// カテゴリ名を変更した場合、そのカテゴリのブログも新しい名前になる。admin・editor のみ更新できる。
+func (s *CategoryServiceImpl) UpdateCategory(ctx context.Context, id, userId, name, slug, description string, displayOrder int) (*models.CategoryData, error) {
+	log.Printf("UpdateCategory start...")
+
+	// バリデーション
+	if !isValidId(id) {
+		log.Printf("invalid id: %s", id)
+		return nil, errors.New("invalid id")
+	}
+	name, slug, description, err := validateCategoryInput(name, slug, description, displayOrder)
+	if err != nil {
+		return nil, err
+	}
+	log.Println("Valid input")
+
+	// 管理できるか確認
+	if err := s.checkCanManage(ctx, userId, "failed to update category"); err != nil {
+		return nil, err
+	}
+
+	// リポジトリを呼び出してカテゴリを更新
+	category, err := s.CategoryRepository.UpdateCategory(ctx, id, name, slug, description, displayOrder)
+	if err != nil {
+		log.Printf("Failed to update category: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, errors.New("category not found")
+		case errors.Is(err, repositories_categories.ErrCategoryConflict):
+			return nil, errors.New("category already exists")
+		default:
+			return nil, errors.New("failed to update category")
+		}
+	}
+
+	// カテゴリ一覧・ブログ一覧のキャッシュを無効化
+	s.invalidateCache()
+
+	log.Printf("Updated category successfully: %v", category)
+	return category, nil
+}
+
+// 指定されたIDに一致するカテゴリを削除する
+// ブログで使用中のカテゴリは削除できない。admin・editor のみ削除できる。
+func (s *CategoryServiceImpl) DeleteCategory(ctx context.Context, id, userId string) error {
+	log.Printf("DeleteCategory start...")
+
+	// バリデーション
+	if !isValidId(id) {
+		log.Printf("invalid id: %s", id)
+		return errors.New("invalid id")
+	}
+	log.Println("Valid id")
+
+	// 管理できるか確認
+	if err := s.checkCanManage(ctx, userId, "failed to delete category"); err != nil {
+		return err
+	}
+
+	// リポジトリを呼び出してカテゴリを削除
+	err := s.CategoryRepository.DeleteCategory(ctx, id)
+	if err != nil {
+		log.Printf("Failed to delete category: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return errors.New("category not found")
+		case errors.Is(err, repositories_categories.ErrCategoryInUse):
+			return errors.New("category in use")
+		default:
+			return errors.New("failed to delete category")
+		}
+	}
+
+	// カテゴリ一覧・ブログ一覧のキャッシュを無効化
+	s.invalidateCache()
+
+	log.Println("Deleted category successfully")
+	return nil
+}
+
+// カテゴリ名・投稿数を含むキャッシュを無効化する
+func (s *CategoryServiceImpl) invalidateCache() {
+	s.Cache.Invalidate(utils_cache.NamespaceCategories, utils_cache.NamespaceBlogs)
+}
@@ -0,0 +1,105 @@
+package services_categories
+
+import (
+	"backend/models"
+	repositories_categories "backend/repositories/categories"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testCategoryId = "00000000-0000-0000-0000-000000000001"
+
+func TestService_CreateCategory(t *testing.T) {
+	// モックリポジトリの生成
+	mockCategoryRepo := new(repositories_categories.MockCategoryRepository)
+	categoryService := NewCategoryService(mockCategoryRepo, newCategoryManagerRepository(), nil)
+
+	// モックの設定(前後の空白は取り除かれる)
+	mockCategoryRepo.On("CreateCategory", "バックエンド", "backend", "サーバーサイドの記事", 1).
+		Return(&models.CategoryData{ID: testCategoryId, Name: "バックエンド", Slug: "backend"}, nil)
+
+	// テスト対象メソッドの呼び出し
+	category, err := categoryService.CreateCategory(context.Background(), testUserId, " バックエンド ", "backend ", " サーバーサイドの記事", 1)
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Equal(t, "backend", category.Slug)
+
+	// モックの期待通りの呼び出しを検証
+	mockCategoryRepo.AssertExpectations(t)
+}
+
+func TestService_CreateCategory_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name         string
+		catName      string
+		slug         string
+		description  string
+		displayOrder int
+		errMsg       string
+	}{
+		{"空の名前", " ", "backend", "", 0, "invalid name"},
+		{"長すぎる名前", strings.Repeat("あ", 51), "backend", "", 0, "invalid name"},
+		{"空のスラッグ", "Backend", "", "", 0, "invalid slug"},
+		{"大文字を含むスラッグ", "Backend", "Backend", "", 0, "invalid slug"},
+		{"日本語のスラッグ", "Backend", "バックエンド", "", 0, "invalid slug"},
+		{"ハイフンで終わるスラッグ", "Backend", "backend-", "", 0, "invalid slug"},
+		{"長すぎる説明", "Backend", "backend", strings.Repeat("a", 501), 0, "invalid description"},
+		{"負の表示順", "Backend", "backend", "", -1, "invalid displayOrder"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリの生成
+			mockCategoryRepo := new(repositories_categories.MockCategoryRepository)
+			categoryService := NewCategoryService(mockCategoryRepo, newCategoryManagerRepository(), nil)
+
+			// テスト対象メソッドの呼び出し
+			category, err := categoryService.CreateCategory(context.Background(), testUserId, tt.catName, tt.slug, tt.description, tt.displayOrder)
+
+			// アサーション
+			assert.EqualError(t, err, tt.errMsg)
+			assert.Nil(t, category)
+
+			// リポジトリが呼び出されないことを確認
+			mockCategoryRepo.AssertNotCalled(t, "CreateCategory", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_CreateCategory_RepositoryError(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+		errMsg  string
+	}{
+		{"名前・スラッグの重複", repositories_categories.ErrCategoryConflict, "category already exists"},
+		{"その他のエラー", errors.New("db error"), "failed to create category"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリの生成
+			mockCategoryRepo := new(repositories_categories.MockCategoryRepository)
+			categoryService := NewCategoryService(mockCategoryRepo, newCategoryManagerRepository(), nil)
+
+			// モックの設定
+			mockCategoryRepo.On("CreateCategory", "Backend", "backend", "", 0).Return(nil, tt.repoErr)
+
+			// テスト対象メソッドの呼び出し
+			category, err := categoryService.CreateCategory(context.Background(), testUserId, "Backend", "backend", "", 0)
+
+			// アサーション
+			assert.EqualError(t, err, tt.errMsg)
+			assert.Nil(t, category)
+
+			// モックの期待通りの呼び出しを検証
+			mockCategoryRepo.AssertExpectations(t)
+		})
+	}
+}
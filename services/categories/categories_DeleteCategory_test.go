@@ -0,0 +1,61 @@
+package services_categories
+
+import (
+	repositories_categories "backend/repositories/categories"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_DeleteCategory(t *testing.T) {
+	// モックリポジトリの生成
+	mockCategoryRepo := new(repositories_categories.MockCategoryRepository)
+	categoryService := NewCategoryService(mockCategoryRepo, newCategoryManagerRepository(), nil)
+
+	// モックの設定
+	mockCategoryRepo.On("DeleteCategory", testCategoryId).Return(nil)
+
+	// テスト対象メソッドの呼び出し
+	err := categoryService.DeleteCategory(context.Background(), testCategoryId, testUserId)
+
+	// アサーション
+	assert.NoError(t, err)
+
+	// モックの期待通りの呼び出しを検証
+	mockCategoryRepo.AssertExpectations(t)
+}
+
+func TestService_DeleteCategory_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+		errMsg  string
+	}{
+		{"存在しないカテゴリ", pgx.ErrNoRows, "category not found"},
+		{"使用中のカテゴリ", repositories_categories.ErrCategoryInUse, "category in use"},
+		{"その他のエラー", errors.New("db error"), "failed to delete category"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリの生成
+			mockCategoryRepo := new(repositories_categories.MockCategoryRepository)
+			categoryService := NewCategoryService(mockCategoryRepo, newCategoryManagerRepository(), nil)
+
+			// モックの設定
+			mockCategoryRepo.On("DeleteCategory", testCategoryId).Return(tt.repoErr)
+
+			// テスト対象メソッドの呼び出し
+			err := categoryService.DeleteCategory(context.Background(), testCategoryId, testUserId)
+
+			// アサーション
+			assert.EqualError(t, err, tt.errMsg)
+
+			// モックの期待通りの呼び出しを検証
+			mockCategoryRepo.AssertExpectations(t)
+		})
+	}
+}
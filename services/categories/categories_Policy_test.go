@@ -0,0 +1,105 @@
+package services_categories
+
+import (
+	"backend/models"
+	repositories_categories "backend/repositories/categories"
+	repositories_users "backend/repositories/users"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserId = "00000000-0000-0000-0000-0000000000aa"
+
+// カテゴリを管理できるユーザー(editor)を設定したモックを返す
+func newCategoryManagerRepository() *repositories_users.MockUserRepository {
+	mockUserRepo := new(repositories_users.MockUserRepository)
+	mockUserRepo.On("FetchUserById", testUserId).Return(&models.UserData{ID: testUserId, Role: models.UserRoleEditor}, nil)
+	return mockUserRepo
+}
+
+// 権限・操作ごとに、カテゴリの管理が許可されるかを確認する
+func TestService_CategoryPolicy_Matrix(t *testing.T) {
+	actions := map[string]func(categoryService CategoryService) error{
+		"作成": func(categoryService CategoryService) error {
+			_, err := categoryService.CreateCategory(context.Background(), testUserId, "Backend", "backend", "", 0)
+			return err
+		},
+		"更新": func(categoryService CategoryService) error {
+			_, err := categoryService.UpdateCategory(context.Background(), testCategoryId, testUserId, "Backend", "backend", "", 0)
+			return err
+		},
+		"削除": func(categoryService CategoryService) error {
+			return categoryService.DeleteCategory(context.Background(), testCategoryId, testUserId)
+		},
+	}
+	roles := map[string]bool{
+		models.UserRoleAdmin:  true,
+		models.UserRoleEditor: true,
+		models.UserRoleAuthor: false,
+		"":                    false,
+	}
+
+	for action, call := range actions {
+		for role, allowed := range roles {
+			t.Run(role+"/"+action, func(t *testing.T) {
+				// モックリポジトリの生成
+				mockCategoryRepo := new(repositories_categories.MockCategoryRepository)
+				mockUserRepo := new(repositories_users.MockUserRepository)
+				categoryService := NewCategoryService(mockCategoryRepo, mockUserRepo, nil)
+
+				// モックの設定
+				category := &models.CategoryData{ID: testCategoryId, Name: "Backend", Slug: "backend"}
+				mockUserRepo.On("FetchUserById", testUserId).Return(&models.UserData{ID: testUserId, Role: role}, nil)
+				mockCategoryRepo.On("CreateCategory", "Backend", "backend", "", 0).Return(category, nil)
+				mockCategoryRepo.On("UpdateCategory", testCategoryId, "Backend", "backend", "", 0).Return(category, nil)
+				mockCategoryRepo.On("DeleteCategory", testCategoryId).Return(nil)
+
+				// テスト対象メソッドの呼び出し
+				err := call(categoryService)
+
+				// アサーション
+				if allowed {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, err, "forbidden")
+					assert.Empty(t, mockCategoryRepo.Calls)
+				}
+			})
+		}
+	}
+}
+
+func TestService_CategoryPolicy_UserError(t *testing.T) {
+	tests := []struct {
+		name    string
+		userErr error
+		errMsg  string
+	}{
+		{"ユーザーが存在しない", pgx.ErrNoRows, "forbidden"},
+		{"ユーザーの取得に失敗", errors.New("db error"), "failed to delete category"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリの生成
+			mockCategoryRepo := new(repositories_categories.MockCategoryRepository)
+			mockUserRepo := new(repositories_users.MockUserRepository)
+			categoryService := NewCategoryService(mockCategoryRepo, mockUserRepo, nil)
+
+			// モックの設定
+			mockUserRepo.On("FetchUserById", testUserId).Return(nil, tt.userErr)
+
+			// テスト対象メソッドの呼び出し
+			err := categoryService.DeleteCategory(context.Background(), testCategoryId, testUserId)
+
+			// アサーション
+			assert.EqualError(t, err, tt.errMsg)
+			mockCategoryRepo.AssertNotCalled(t, "DeleteCategory", mock.Anything)
+		})
+	}
+}
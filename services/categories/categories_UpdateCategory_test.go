@@ -0,0 +1,82 @@
+package services_categories
+
+import (
+	"backend/models"
+	repositories_categories "backend/repositories/categories"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_UpdateCategory(t *testing.T) {
+	// モックリポジトリの生成
+	mockCategoryRepo := new(repositories_categories.MockCategoryRepository)
+	categoryService := NewCategoryService(mockCategoryRepo, newCategoryManagerRepository(), nil)
+
+	// モックの設定
+	mockCategoryRepo.On("UpdateCategory", testCategoryId, "Server", "server", "", 2).
+		Return(&models.CategoryData{ID: testCategoryId, Name: "Server", Slug: "server", DisplayOrder: 2, PostCount: 3}, nil)
+
+	// テスト対象メソッドの呼び出し
+	category, err := categoryService.UpdateCategory(context.Background(), testCategoryId, testUserId, "Server", "server", "", 2)
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Equal(t, "Server", category.Name)
+	assert.Equal(t, 3, category.PostCount)
+
+	// モックの期待通りの呼び出しを検証
+	mockCategoryRepo.AssertExpectations(t)
+}
+
+func TestService_UpdateCategory_InvalidId(t *testing.T) {
+	// モックリポジトリの生成
+	mockCategoryRepo := new(repositories_categories.MockCategoryRepository)
+	categoryService := NewCategoryService(mockCategoryRepo, newCategoryManagerRepository(), nil)
+
+	// テスト対象メソッドの呼び出し
+	_, err := categoryService.UpdateCategory(context.Background(), "invalid", testUserId, "Server", "server", "", 0)
+
+	// アサーション
+	assert.EqualError(t, err, "invalid id")
+
+	// リポジトリが呼び出されないことを確認
+	mockCategoryRepo.AssertNotCalled(t, "UpdateCategory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_UpdateCategory_RepositoryError(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+		errMsg  string
+	}{
+		{"存在しないカテゴリ", pgx.ErrNoRows, "category not found"},
+		{"名前・スラッグの重複", repositories_categories.ErrCategoryConflict, "category already exists"},
+		{"その他のエラー", errors.New("db error"), "failed to update category"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリの生成
+			mockCategoryRepo := new(repositories_categories.MockCategoryRepository)
+			categoryService := NewCategoryService(mockCategoryRepo, newCategoryManagerRepository(), nil)
+
+			// モックの設定
+			mockCategoryRepo.On("UpdateCategory", testCategoryId, "Server", "server", "", 0).Return(nil, tt.repoErr)
+
+			// テスト対象メソッドの呼び出し
+			category, err := categoryService.UpdateCategory(context.Background(), testCategoryId, testUserId, "Server", "server", "", 0)
+
+			// アサーション
+			assert.EqualError(t, err, tt.errMsg)
+			assert.Nil(t, category)
+
+			// モックの期待通りの呼び出しを検証
+			mockCategoryRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package services_categories
+
+import (
+	"backend/models"
+	repositories_categories "backend/repositories/categories"
+	repositories_users "backend/repositories/users"
+	utils_cache "backend/utils/cache"
+	"context"
+)
+
+// CategoryServiceインターフェース
+type CategoryService interface {
+	FetchCategoryById(ctx context.Context, id string) (*models.CategoryData, error)
+
+	CreateCategory(ctx context.Context, userId, name, slug, description string, displayOrder int) (*models.CategoryData, error)
+	UpdateCategory(ctx context.Context, id, userId, name, slug, description string, displayOrder int) (*models.CategoryData, error)
+	DeleteCategory(ctx context.Context, id, userId string) error
+}
+
+type CategoryServiceImpl struct {
+	CategoryRepository repositories_categories.CategoryRepository
+	UserRepository     repositories_users.UserRepository
+	Cache              *utils_cache.Cache
+}
+
+// CategoryServiceインターフェースを実装したCategoryServiceImplのポインタを返す
+func NewCategoryService(
+	categoryRepository repositories_categories.CategoryRepository,
+	userRepository repositories_users.UserRepository,
+	cache *utils_cache.Cache,
+) CategoryService {
+	return &CategoryServiceImpl{
+		CategoryRepository: categoryRepository,
+		UserRepository:     userRepository,
+		Cache:              cache,
+	}
+}
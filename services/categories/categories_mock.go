@@ -0,0 +1,41 @@
+package services_categories
+
+import (
+	"backend/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCategoryService struct {
+	mock.Mock
+}
+
+func (m *MockCategoryService) FetchCategoryById(ctx context.Context, id string) (*models.CategoryData, error) {
+	args := m.Called(id)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.CategoryData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryService) CreateCategory(ctx context.Context, userId, name, slug, description string, displayOrder int) (*models.CategoryData, error) {
+	args := m.Called(userId, name, slug, description, displayOrder)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.CategoryData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryService) UpdateCategory(ctx context.Context, id, userId, name, slug, description string, displayOrder int) (*models.CategoryData, error) {
+	args := m.Called(id, userId, name, slug, description, displayOrder)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.CategoryData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockCategoryService) DeleteCategory(ctx context.Context, id, userId string) error {
+	args := m.Called(id, userId)
+	return args.Error(0)
+}
@@ -2,12 +2,16 @@ package services_comments
 
 import (
 	"backend/models"
+	repositories_comments "backend/repositories/comments"
+	utils_cache "backend/utils/cache"
+	utils_timeout "backend/utils/timeout"
+	"context"
 	"errors"
 	"log"
 )
 
 // 指定されたブログIDに一致するコメントデータを取得する
-func (s *CommentServiceImpl) FetchCommentsByBlogId(blogId string) ([]models.CommentData, error) {
+func (s *CommentServiceImpl) FetchCommentsByBlogId(ctx context.Context, blogId string) ([]models.CommentData, error) {
 	log.Printf("FetchCommentsByBlogId start...")
 
 	// バリデーション
@@ -18,9 +22,12 @@ func (s *CommentServiceImpl) FetchCommentsByBlogId(blogId string) ([]models.Comm
 	log.Println("Valid blogId")
 
 	// リポジトリを呼び出してブログデータを取得
-	comments, err := s.CommentRepository.FetchCommentsByBlogId(blogId)
+	comments, err := s.CommentRepository.FetchCommentsByBlogId(ctx, blogId)
 	if err != nil {
 		log.Printf("Failed to fetch comments: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
 		return nil, errors.New("comments not found")
 	}
 
@@ -29,7 +36,8 @@ func (s *CommentServiceImpl) FetchCommentsByBlogId(blogId string) ([]models.Comm
 }
 
 // コメントデータを新規作成する
-func (s *CommentServiceImpl) CreateComment(blogId, guestUser, comment string) (*models.CommentData, error) {
+// ブログがない、またはゴミ箱に入っている場合は "blog not found" エラーを返す。
+func (s *CommentServiceImpl) CreateComment(ctx context.Context, blogId, guestUser, comment string) (*models.CommentData, error) {
 	log.Printf("CreateComment start...")
 
 	// バリデーション
@@ -48,12 +56,21 @@ func (s *CommentServiceImpl) CreateComment(blogId, guestUser, comment string) (*
 	log.Println("Valid blogId, guestUser and comment")
 
 	// リポジトリを呼び出してコメントデータを作成
-	newComment, err := s.CommentRepository.CreateComment(blogId, guestUser, comment)
+	newComment, err := s.CommentRepository.CreateComment(ctx, blogId, guestUser, comment)
 	if err != nil {
 		log.Printf("Failed to create comment: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, repositories_comments.ErrBlogNotFound) {
+			return nil, errors.New("blog not found")
+		}
 		return nil, errors.New("failed to create comment")
 	}
 
+	// コメント数を含むブログ一覧のキャッシュを無効化
+	s.Cache.Invalidate(utils_cache.NamespaceBlogs)
+
 	log.Printf("Created comment successfully: %v", newComment)
 	return newComment, nil
 }
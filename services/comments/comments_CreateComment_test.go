@@ -3,6 +3,7 @@ package services_comments
 import (
 	"backend/models"
 	repositories_comments "backend/repositories/comments"
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -13,7 +14,7 @@ import (
 func TestService_CreateComment(t *testing.T) {
 	// モックリポジトリの生成
 	mockCommentRepo := new(repositories_comments.MockCommentRepository)
-	commentService := NewCommentService(mockCommentRepo)
+	commentService := NewCommentService(mockCommentRepo, nil)
 
 	// 入力データ
 	blogId := "1"
@@ -33,7 +34,7 @@ func TestService_CreateComment(t *testing.T) {
 	mockCommentRepo.On("CreateComment", blogId, guestUser, comment).Return(&expectedComment, nil)
 
 	// テスト対象メソッドの呼び出し
-	blog, err := commentService.CreateComment(blogId, guestUser, comment)
+	blog, err := commentService.CreateComment(context.Background(), blogId, guestUser, comment)
 
 	// アサーション
 	assert.NoError(t, err)
@@ -46,7 +47,7 @@ func TestService_CreateComment(t *testing.T) {
 func TestService_CreateComment_InvalidBlogId(t *testing.T) {
 	// モックリポジトリの生成
 	mockCommentRepo := new(repositories_comments.MockCommentRepository)
-	commentService := NewCommentService(mockCommentRepo)
+	commentService := NewCommentService(mockCommentRepo, nil)
 
 	// 入力データ
 	blogId := ""
@@ -57,7 +58,7 @@ func TestService_CreateComment_InvalidBlogId(t *testing.T) {
 	mockCommentRepo.On("CreateComment", blogId, guestUser, comment).Return(nil, errors.New("invalid blogId"))
 
 	// テスト対象メソッドの呼び出し
-	blog, err := commentService.CreateComment(blogId, guestUser, comment)
+	blog, err := commentService.CreateComment(context.Background(), blogId, guestUser, comment)
 
 	// アサーション
 	assert.Error(t, err)
@@ -71,7 +72,7 @@ func TestService_CreateComment_InvalidBlogId(t *testing.T) {
 func TestService_CreateComment_InvalidGuestUser(t *testing.T) {
 	// モックリポジトリの生成
 	mockCommentRepo := new(repositories_comments.MockCommentRepository)
-	commentService := NewCommentService(mockCommentRepo)
+	commentService := NewCommentService(mockCommentRepo, nil)
 
 	// 入力データ
 	blogId := "1"
@@ -82,7 +83,7 @@ func TestService_CreateComment_InvalidGuestUser(t *testing.T) {
 	mockCommentRepo.On("CreateComment", blogId, guestUser, comment).Return(nil, errors.New("invalid guestUser"))
 
 	// テスト対象メソッドの呼び出し
-	blog, err := commentService.CreateComment(blogId, guestUser, comment)
+	blog, err := commentService.CreateComment(context.Background(), blogId, guestUser, comment)
 
 	// アサーション
 	assert.Error(t, err)
@@ -96,7 +97,7 @@ func TestService_CreateComment_InvalidGuestUser(t *testing.T) {
 func TestService_CreateComment_InvalidComment(t *testing.T) {
 	// モックリポジトリの生成
 	mockCommentRepo := new(repositories_comments.MockCommentRepository)
-	commentService := NewCommentService(mockCommentRepo)
+	commentService := NewCommentService(mockCommentRepo, nil)
 
 	// 入力データ
 	blogId := "1"
@@ -107,7 +108,7 @@ func TestService_CreateComment_InvalidComment(t *testing.T) {
 	mockCommentRepo.On("CreateComment", blogId, guestUser, comment).Return(nil, errors.New("invalid comment"))
 
 	// テスト対象メソッドの呼び出し
-	blog, err := commentService.CreateComment(blogId, guestUser, comment)
+	blog, err := commentService.CreateComment(context.Background(), blogId, guestUser, comment)
 
 	// アサーション
 	assert.Error(t, err)
@@ -121,7 +122,7 @@ func TestService_CreateComment_InvalidComment(t *testing.T) {
 func TestService_CreateComment_NotCreate(t *testing.T) {
 	// モックリポジトリの生成
 	mockCommentRepo := new(repositories_comments.MockCommentRepository)
-	commentService := NewCommentService(mockCommentRepo)
+	commentService := NewCommentService(mockCommentRepo, nil)
 
 	// 入力データ
 	blogId := "1"
@@ -132,7 +133,7 @@ func TestService_CreateComment_NotCreate(t *testing.T) {
 	mockCommentRepo.On("CreateComment", blogId, guestUser, comment).Return(nil, errors.New("failed to create comment"))
 
 	// テスト対象メソッドの呼び出し
-	blog, err := commentService.CreateComment(blogId, guestUser, comment)
+	blog, err := commentService.CreateComment(context.Background(), blogId, guestUser, comment)
 
 	// アサーション
 	assert.Error(t, err)
@@ -142,3 +143,27 @@ func TestService_CreateComment_NotCreate(t *testing.T) {
 	// モックの期待通りの呼び出しを検証
 	mockCommentRepo.AssertExpectations(t)
 }
+
+func TestService_CreateComment_BlogNotFound(t *testing.T) {
+	// モックリポジトリの生成
+	mockCommentRepo := new(repositories_comments.MockCommentRepository)
+	commentService := NewCommentService(mockCommentRepo, nil)
+
+	// 入力データ
+	blogId := "1"
+	guestUser := "guestUser1"
+	comment := "comment1"
+
+	// モックの設定(ブログがゴミ箱に入っている)
+	mockCommentRepo.On("CreateComment", blogId, guestUser, comment).Return(nil, repositories_comments.ErrBlogNotFound)
+
+	// テスト対象メソッドの呼び出し
+	blog, err := commentService.CreateComment(context.Background(), blogId, guestUser, comment)
+
+	// アサーション
+	assert.EqualError(t, err, "blog not found")
+	assert.Nil(t, blog)
+
+	// モックの期待通りの呼び出しを検証
+	mockCommentRepo.AssertExpectations(t)
+}
@@ -3,6 +3,7 @@ package services_comments
 import (
 	"backend/models"
 	repositories_comments "backend/repositories/comments"
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -13,7 +14,7 @@ import (
 func TestService_FetchCommentsByBlogId(t *testing.T) {
 	// モックリポジトリの生成
 	mockCommentRepo := new(repositories_comments.MockCommentRepository)
-	serviceComment := NewCommentService(mockCommentRepo)
+	serviceComment := NewCommentService(mockCommentRepo, nil)
 
 	// テストデータ
 	blogId := "1"
@@ -39,7 +40,7 @@ func TestService_FetchCommentsByBlogId(t *testing.T) {
 	mockCommentRepo.On("FetchCommentsByBlogId", blogId).Return(mockCommentData, nil)
 
 	// テスト対象メソッドの実行
-	comments, err := serviceComment.FetchCommentsByBlogId(blogId)
+	comments, err := serviceComment.FetchCommentsByBlogId(context.Background(), blogId)
 
 	// エラーチェック
 	assert.NoError(t, err)
@@ -53,13 +54,13 @@ func TestService_FetchCommentsByBlogId(t *testing.T) {
 func TestService_FetchCommentsByBlogId_InvalidBlogId(t *testing.T) {
 	// モックリポジトリの生成
 	mockCommentRepo := new(repositories_comments.MockCommentRepository)
-	commentService := NewCommentService(mockCommentRepo)
+	commentService := NewCommentService(mockCommentRepo, nil)
 
 	// テストデータ
 	blogId := ""
 
 	// テスト対象メソッドの実行
-	comments, err := commentService.FetchCommentsByBlogId(blogId)
+	comments, err := commentService.FetchCommentsByBlogId(context.Background(), blogId)
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -73,7 +74,7 @@ func TestService_FetchCommentsByBlogId_InvalidBlogId(t *testing.T) {
 func TestService_FetchCommentsByBlogId_NotComments(t *testing.T) {
 	// モックリポジトリの生成
 	mockCommentRepo := new(repositories_comments.MockCommentRepository)
-	commentService := NewCommentService(mockCommentRepo)
+	commentService := NewCommentService(mockCommentRepo, nil)
 
 	// テストデータ
 	blogId := "1"
@@ -82,7 +83,7 @@ func TestService_FetchCommentsByBlogId_NotComments(t *testing.T) {
 	mockCommentRepo.On("FetchCommentsByBlogId", blogId).Return(nil, errors.New("comments not found"))
 
 	// テスト対象メソッドの実行
-	comments, err := commentService.FetchCommentsByBlogId(blogId)
+	comments, err := commentService.FetchCommentsByBlogId(context.Background(), blogId)
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -3,23 +3,28 @@ package services_comments
 import (
 	"backend/models"
 	repositories_comments "backend/repositories/comments"
+	utils_cache "backend/utils/cache"
+	"context"
 )
 
 // CommentServiceインターフェース
 type CommentService interface {
-	FetchCommentsByBlogId(blogId string) ([]models.CommentData, error)
-	CreateComment(blogId, guestUser, comment string) (*models.CommentData, error)
+	FetchCommentsByBlogId(ctx context.Context, blogId string) ([]models.CommentData, error)
+	CreateComment(ctx context.Context, blogId, guestUser, comment string) (*models.CommentData, error)
 }
 
 type CommentServiceImpl struct {
 	CommentRepository repositories_comments.CommentRepository
+	Cache             *utils_cache.Cache
 }
 
 // CommentServiceインターフェースを実装したCommentServiceImplのポインタを返す
 func NewCommentService(
 	commentRepository repositories_comments.CommentRepository,
+	cache *utils_cache.Cache,
 ) CommentService {
 	return &CommentServiceImpl{
 		CommentRepository: commentRepository,
+		Cache:             cache,
 	}
 }
@@ -2,6 +2,7 @@ package services_comments
 
 import (
 	"backend/models"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,7 +11,7 @@ type MockCommentService struct {
 	mock.Mock
 }
 
-func (m *MockCommentService) FetchCommentsByBlogId(blogId string) ([]models.CommentData, error) {
+func (m *MockCommentService) FetchCommentsByBlogId(ctx context.Context, blogId string) ([]models.CommentData, error) {
 	args := m.Called(blogId)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -18,7 +19,7 @@ func (m *MockCommentService) FetchCommentsByBlogId(blogId string) ([]models.Comm
 	return args.Get(0).([]models.CommentData), args.Error(1)
 }
 
-func (m *MockCommentService) CreateComment(blogId, guestUser, comment string) (*models.CommentData, error) {
+func (m *MockCommentService) CreateComment(ctx context.Context, blogId, guestUser, comment string) (*models.CommentData, error) {
 	args := m.Called(blogId, guestUser, comment)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
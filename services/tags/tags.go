@@ -0,0 +1,229 @@
+package services_tags
+
+import (
+	"backend/models"
+	repositories_tags "backend/repositories/tags"
+	utils_cache "backend/utils/cache"
+	utils_timeout "backend/utils/timeout"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// タグ名・別名の最大文字数
+const maxTagNameLength = 50
+
+// タグ名・別名として有効か判定する
+// タグはカンマ区切りで保持するため、カンマを含む名前は受け付けない。
+func isValidTagName(name string) bool {
+	return name != "" && !strings.Contains(name, ",") && utf8.RuneCountInString(name) <= maxTagNameLength
+}
+
+// UUID形式のIDか判定する
+func isValidId(id string) bool {
+	_, err := uuid.Parse(id)
+	return err == nil
+}
+
+// 全タグ情報を取得する
+func (s *TagServiceImpl) FetchTags(ctx context.Context) ([]models.TagData, error) {
+	log.Printf("FetchTags start...")
+
+	// リポジトリを呼び出してタグ情報を取得
+	tags, err := s.TagRepository.FetchTags(ctx)
+	if err != nil {
+		log.Printf("Failed to fetch tags: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		return nil, errors.New("failed to fetch tags")
+	}
+
+	if tags == nil {
+		tags = []models.TagData{}
+	}
+
+	log.Printf("Fetched tags successfully: %v", tags)
+	return tags, nil
+}
+
+// タグ名を変更する
+// admin・editor のみ変更できる。権限がない場合は "forbidden" エラーを返す。
+func (s *TagServiceImpl) RenameTag(ctx context.Context, id, userId, name string) (*models.TagData, error) {
+	log.Printf("RenameTag start...")
+
+	// バリデーション
+	name = strings.TrimSpace(name)
+	if !isValidId(id) {
+		log.Printf("invalid id: %s", id)
+		return nil, errors.New("invalid id")
+	}
+	if !isValidTagName(name) {
+		log.Printf("invalid name: %s", name)
+		return nil, errors.New("invalid name")
+	}
+	log.Println("Valid id and name")
+
+	// 管理できるか確認
+	if err := s.checkCanManage(ctx, userId, "failed to rename tag"); err != nil {
+		return nil, err
+	}
+
+	// リポジトリを呼び出してタグ名を変更
+	tag, err := s.TagRepository.RenameTag(ctx, id, name)
+	if err != nil {
+		log.Printf("Failed to rename tag: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, errors.New("tag not found")
+		case errors.Is(err, repositories_tags.ErrTagConflict):
+			return nil, errors.New("tag already exists")
+		default:
+			return nil, errors.New("failed to rename tag")
+		}
+	}
+
+	// タグ一覧・ブログ一覧のキャッシュを無効化
+	s.invalidateCache()
+
+	log.Printf("Renamed tag successfully: %v", tag)
+	return tag, nil
+}
+
+// タグを統合する
+// 統合元のタグは削除され、その名前は統合先の別名になる。admin・editor のみ統合できる。
+func (s *TagServiceImpl) MergeTags(ctx context.Context, userId, sourceId, targetId string) (*models.TagData, error) {
+	log.Printf("MergeTags start...")
+
+	// バリデーション
+	if !isValidId(sourceId) || !isValidId(targetId) {
+		log.Printf("invalid id: %s, %s", sourceId, targetId)
+		return nil, errors.New("invalid id")
+	}
+	if sourceId == targetId {
+		log.Printf("cannot merge same tag: %s", sourceId)
+		return nil, errors.New("cannot merge same tag")
+	}
+	log.Println("Valid sourceId and targetId")
+
+	// 管理できるか確認
+	if err := s.checkCanManage(ctx, userId, "failed to merge tags"); err != nil {
+		return nil, err
+	}
+
+	// リポジトリを呼び出してタグを統合
+	tag, err := s.TagRepository.MergeTags(ctx, sourceId, targetId)
+	if err != nil {
+		log.Printf("Failed to merge tags: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("tag not found")
+		}
+		return nil, errors.New("failed to merge tags")
+	}
+
+	// タグ一覧・ブログ一覧のキャッシュを無効化
+	s.invalidateCache()
+
+	log.Printf("Merged tags successfully: %v", tag)
+	return tag, nil
+}
+
+// タグに別名を追加する
+// admin・editor のみ追加できる。
+func (s *TagServiceImpl) CreateTagAlias(ctx context.Context, tagId, userId, alias string) (*models.TagData, error) {
+	log.Printf("CreateTagAlias start...")
+
+	// バリデーション
+	alias = strings.TrimSpace(alias)
+	if !isValidId(tagId) {
+		log.Printf("invalid id: %s", tagId)
+		return nil, errors.New("invalid id")
+	}
+	if !isValidTagName(alias) {
+		log.Printf("invalid alias: %s", alias)
+		return nil, errors.New("invalid alias")
+	}
+	log.Println("Valid tagId and alias")
+
+	// 管理できるか確認
+	if err := s.checkCanManage(ctx, userId, "failed to create tag alias"); err != nil {
+		return nil, err
+	}
+
+	// リポジトリを呼び出して別名を追加
+	tag, err := s.TagRepository.CreateTagAlias(ctx, tagId, alias)
+	if err != nil {
+		log.Printf("Failed to create tag alias: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, errors.New("tag not found")
+		case errors.Is(err, repositories_tags.ErrTagConflict):
+			return nil, errors.New("tag already exists")
+		default:
+			return nil, errors.New("failed to create tag alias")
+		}
+	}
+
+	// タグ一覧・ブログ一覧のキャッシュを無効化
+	s.invalidateCache()
+
+	log.Printf("Created tag alias successfully: %v", tag)
+	return tag, nil
+}
+
+// タグの別名を削除する
+// admin・editor のみ削除できる。
+func (s *TagServiceImpl) DeleteTagAlias(ctx context.Context, alias, userId string) error {
+	log.Printf("DeleteTagAlias start...")
+
+	// バリデーション
+	alias = strings.TrimSpace(alias)
+	if !isValidTagName(alias) {
+		log.Printf("invalid alias: %s", alias)
+		return errors.New("invalid alias")
+	}
+	log.Println("Valid alias")
+
+	// 管理できるか確認
+	if err := s.checkCanManage(ctx, userId, "failed to delete tag alias"); err != nil {
+		return err
+	}
+
+	// リポジトリを呼び出して別名を削除
+	err := s.TagRepository.DeleteTagAlias(ctx, alias)
+	if err != nil {
+		log.Printf("Failed to delete tag alias: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("alias not found")
+		}
+		return errors.New("failed to delete tag alias")
+	}
+
+	// タグ一覧・ブログ一覧のキャッシュを無効化
+	s.invalidateCache()
+
+	log.Println("Deleted tag alias successfully")
+	return nil
+}
+
+// タグ名・使用ブログ数を含むキャッシュを無効化する
+func (s *TagServiceImpl) invalidateCache() {
+	s.Cache.Invalidate(utils_cache.NamespaceTags, utils_cache.NamespaceBlogs)
+}
@@ -0,0 +1,52 @@
+package services_tags
+
+import (
+	"backend/models"
+	repositories_tags "backend/repositories/tags"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_CreateTagAlias(t *testing.T) {
+	// モックリポジトリの生成
+	mockTagRepo := new(repositories_tags.MockTagRepository)
+	tagService := NewTagService(mockTagRepo, newTagManagerRepository(), nil)
+
+	// モックの設定
+	mockTagRepo.On("CreateTagAlias", testTagId, "golang").Return(&models.TagData{
+		ID:      testTagId,
+		Name:    "Go",
+		Aliases: []string{"golang"},
+	}, nil)
+
+	// テスト対象メソッドの呼び出し
+	tag, err := tagService.CreateTagAlias(context.Background(), testTagId, testUserId, "golang")
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"golang"}, tag.Aliases)
+
+	// モックの期待通りの呼び出しを検証
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestService_CreateTagAlias_Conflict(t *testing.T) {
+	// モックリポジトリの生成
+	mockTagRepo := new(repositories_tags.MockTagRepository)
+	tagService := NewTagService(mockTagRepo, newTagManagerRepository(), nil)
+
+	// モックの設定
+	mockTagRepo.On("CreateTagAlias", testTagId, "Echo").Return(nil, repositories_tags.ErrTagConflict)
+
+	// テスト対象メソッドの呼び出し
+	tag, err := tagService.CreateTagAlias(context.Background(), testTagId, testUserId, "Echo")
+
+	// アサーション
+	assert.Nil(t, tag)
+	assert.EqualError(t, err, "tag already exists")
+
+	// モックの期待通りの呼び出しを検証
+	mockTagRepo.AssertExpectations(t)
+}
@@ -0,0 +1,46 @@
+package services_tags
+
+import (
+	repositories_tags "backend/repositories/tags"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_DeleteTagAlias(t *testing.T) {
+	// モックリポジトリの生成
+	mockTagRepo := new(repositories_tags.MockTagRepository)
+	tagService := NewTagService(mockTagRepo, newTagManagerRepository(), nil)
+
+	// モックの設定
+	mockTagRepo.On("DeleteTagAlias", "golang").Return(nil)
+
+	// テスト対象メソッドの呼び出し
+	err := tagService.DeleteTagAlias(context.Background(), "golang", testUserId)
+
+	// アサーション
+	assert.NoError(t, err)
+
+	// モックの期待通りの呼び出しを検証
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestService_DeleteTagAlias_NotFound(t *testing.T) {
+	// モックリポジトリの生成
+	mockTagRepo := new(repositories_tags.MockTagRepository)
+	tagService := NewTagService(mockTagRepo, newTagManagerRepository(), nil)
+
+	// モックの設定
+	mockTagRepo.On("DeleteTagAlias", "golang").Return(pgx.ErrNoRows)
+
+	// テスト対象メソッドの呼び出し
+	err := tagService.DeleteTagAlias(context.Background(), "golang", testUserId)
+
+	// アサーション
+	assert.EqualError(t, err, "alias not found")
+
+	// モックの期待通りの呼び出しを検証
+	mockTagRepo.AssertExpectations(t)
+}
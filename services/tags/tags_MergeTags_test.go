@@ -0,0 +1,72 @@
+package services_tags
+
+import (
+	"backend/models"
+	repositories_tags "backend/repositories/tags"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTargetTagId = "00000000-0000-0000-0000-000000000002"
+
+func TestService_MergeTags(t *testing.T) {
+	// モックリポジトリの生成
+	mockTagRepo := new(repositories_tags.MockTagRepository)
+	tagService := NewTagService(mockTagRepo, newTagManagerRepository(), nil)
+
+	// モックの設定
+	mockTagRepo.On("MergeTags", testTagId, testTargetTagId).Return(&models.TagData{
+		ID:      testTargetTagId,
+		Name:    "Go",
+		Count:   3,
+		Aliases: []string{"golang"},
+	}, nil)
+
+	// テスト対象メソッドの呼び出し
+	tag, err := tagService.MergeTags(context.Background(), testUserId, testTagId, testTargetTagId)
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"golang"}, tag.Aliases)
+
+	// モックの期待通りの呼び出しを検証
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestService_MergeTags_SameTag(t *testing.T) {
+	// モックリポジトリの生成
+	mockTagRepo := new(repositories_tags.MockTagRepository)
+	tagService := NewTagService(mockTagRepo, newTagManagerRepository(), nil)
+
+	// テスト対象メソッドの呼び出し
+	tag, err := tagService.MergeTags(context.Background(), testUserId, testTagId, testTagId)
+
+	// アサーション
+	assert.Nil(t, tag)
+	assert.EqualError(t, err, "cannot merge same tag")
+
+	// リポジトリが呼び出されないことを確認
+	mockTagRepo.AssertNotCalled(t, "MergeTags")
+}
+
+func TestService_MergeTags_NotFound(t *testing.T) {
+	// モックリポジトリの生成
+	mockTagRepo := new(repositories_tags.MockTagRepository)
+	tagService := NewTagService(mockTagRepo, newTagManagerRepository(), nil)
+
+	// モックの設定
+	mockTagRepo.On("MergeTags", testTagId, testTargetTagId).Return(nil, pgx.ErrNoRows)
+
+	// テスト対象メソッドの呼び出し
+	tag, err := tagService.MergeTags(context.Background(), testUserId, testTagId, testTargetTagId)
+
+	// アサーション
+	assert.Nil(t, tag)
+	assert.EqualError(t, err, "tag not found")
+
+	// モックの期待通りの呼び出しを検証
+	mockTagRepo.AssertExpectations(t)
+}
@@ -0,0 +1,109 @@
+package services_tags
+
+import (
+	"backend/models"
+	repositories_tags "backend/repositories/tags"
+	repositories_users "backend/repositories/users"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserId = "00000000-0000-0000-0000-0000000000aa"
+
+// タグを管理できるユーザー(editor)を設定したモックを返す
+func newTagManagerRepository() *repositories_users.MockUserRepository {
+	mockUserRepo := new(repositories_users.MockUserRepository)
+	mockUserRepo.On("FetchUserById", testUserId).Return(&models.UserData{ID: testUserId, Role: models.UserRoleEditor}, nil)
+	return mockUserRepo
+}
+
+// 権限・操作ごとに、タグの管理が許可されるかを確認する
+func TestService_TagPolicy_Matrix(t *testing.T) {
+	actions := map[string]func(tagService TagService) error{
+		"名前の変更": func(tagService TagService) error {
+			_, err := tagService.RenameTag(context.Background(), testTagId, testUserId, "Go")
+			return err
+		},
+		"統合": func(tagService TagService) error {
+			_, err := tagService.MergeTags(context.Background(), testUserId, testTagId, testTargetTagId)
+			return err
+		},
+		"別名の追加": func(tagService TagService) error {
+			_, err := tagService.CreateTagAlias(context.Background(), testTagId, testUserId, "golang")
+			return err
+		},
+		"別名の削除": func(tagService TagService) error {
+			return tagService.DeleteTagAlias(context.Background(), "golang", testUserId)
+		},
+	}
+	roles := map[string]bool{
+		models.UserRoleAdmin:  true,
+		models.UserRoleEditor: true,
+		models.UserRoleAuthor: false,
+		"":                    false,
+	}
+
+	for action, call := range actions {
+		for role, allowed := range roles {
+			t.Run(role+"/"+action, func(t *testing.T) {
+				// モックリポジトリの生成
+				mockTagRepo := new(repositories_tags.MockTagRepository)
+				mockUserRepo := new(repositories_users.MockUserRepository)
+				tagService := NewTagService(mockTagRepo, mockUserRepo, nil)
+
+				// モックの設定
+				mockUserRepo.On("FetchUserById", testUserId).Return(&models.UserData{ID: testUserId, Role: role}, nil)
+				mockTagRepo.On("RenameTag", testTagId, "Go").Return(&models.TagData{ID: testTagId}, nil)
+				mockTagRepo.On("MergeTags", testTagId, testTargetTagId).Return(&models.TagData{ID: testTargetTagId}, nil)
+				mockTagRepo.On("CreateTagAlias", testTagId, "golang").Return(&models.TagData{ID: testTagId}, nil)
+				mockTagRepo.On("DeleteTagAlias", "golang").Return(nil)
+
+				// テスト対象メソッドの呼び出し
+				err := call(tagService)
+
+				// アサーション
+				if allowed {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, err, "forbidden")
+					assert.Empty(t, mockTagRepo.Calls)
+				}
+			})
+		}
+	}
+}
+
+func TestService_TagPolicy_UserError(t *testing.T) {
+	tests := []struct {
+		name    string
+		userErr error
+		errMsg  string
+	}{
+		{"ユーザーが存在しない", pgx.ErrNoRows, "forbidden"},
+		{"ユーザーの取得に失敗", errors.New("db error"), "failed to rename tag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリの生成
+			mockTagRepo := new(repositories_tags.MockTagRepository)
+			mockUserRepo := new(repositories_users.MockUserRepository)
+			tagService := NewTagService(mockTagRepo, mockUserRepo, nil)
+
+			// モックの設定
+			mockUserRepo.On("FetchUserById", testUserId).Return(nil, tt.userErr)
+
+			// テスト対象メソッドの呼び出し
+			_, err := tagService.RenameTag(context.Background(), testTagId, testUserId, "Go")
+
+			// アサーション
+			assert.EqualError(t, err, tt.errMsg)
+			mockTagRepo.AssertNotCalled(t, "RenameTag", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package services_tags
+
+import (
+	"backend/models"
+	repositories_tags "backend/repositories/tags"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTagId = "00000000-0000-0000-0000-000000000001"
+
+func TestService_RenameTag(t *testing.T) {
+	// モックリポジトリの生成
+	mockTagRepo := new(repositories_tags.MockTagRepository)
+	tagService := NewTagService(mockTagRepo, newTagManagerRepository(), nil)
+
+	// モックの設定(前後の空白は取り除かれる)
+	mockTagRepo.On("RenameTag", testTagId, "Go").Return(&models.TagData{ID: testTagId, Name: "Go"}, nil)
+
+	// テスト対象メソッドの呼び出し
+	tag, err := tagService.RenameTag(context.Background(), testTagId, testUserId, "  Go ")
+
+	// アサーション
+	assert.NoError(t, err)
+	assert.Equal(t, "Go", tag.Name)
+
+	// モックの期待通りの呼び出しを検証
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestService_RenameTag_InvalidInput(t *testing.T) {
+	// モックリポジトリの生成
+	mockTagRepo := new(repositories_tags.MockTagRepository)
+	tagService := NewTagService(mockTagRepo, newTagManagerRepository(), nil)
+
+	// 不正なID
+	_, err := tagService.RenameTag(context.Background(), "invalid", testUserId, "Go")
+	assert.EqualError(t, err, "invalid id")
+
+	// 空・カンマを含む名前
+	_, err = tagService.RenameTag(context.Background(), testTagId, testUserId, " ")
+	assert.EqualError(t, err, "invalid name")
+	_, err = tagService.RenameTag(context.Background(), testTagId, testUserId, "Go,Echo")
+	assert.EqualError(t, err, "invalid name")
+
+	// リポジトリが呼び出されないことを確認
+	mockTagRepo.AssertNotCalled(t, "RenameTag")
+}
+
+func TestService_RenameTag_RepositoryError(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoErr error
+		errMsg  string
+	}{
+		{"存在しないタグ", pgx.ErrNoRows, "tag not found"},
+		{"名前の重複", repositories_tags.ErrTagConflict, "tag already exists"},
+		{"その他のエラー", errors.New("db error"), "failed to rename tag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリの生成
+			mockTagRepo := new(repositories_tags.MockTagRepository)
+			tagService := NewTagService(mockTagRepo, newTagManagerRepository(), nil)
+
+			// モックの設定
+			mockTagRepo.On("RenameTag", testTagId, "Go").Return(nil, tt.repoErr)
+
+			// テスト対象メソッドの呼び出し
+			tag, err := tagService.RenameTag(context.Background(), testTagId, testUserId, "Go")
+
+			// アサーション
+			assert.Nil(t, tag)
+			assert.EqualError(t, err, tt.errMsg)
+
+			// モックの期待通りの呼び出しを検証
+			mockTagRepo.AssertExpectations(t)
+		})
+	}
+}
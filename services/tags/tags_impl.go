@@ -0,0 +1,39 @@
+package services_tags
+
+import (
+	"backend/models"
+	repositories_tags "backend/repositories/tags"
+	repositories_users "backend/repositories/users"
+	utils_cache "backend/utils/cache"
+	"context"
+)
+
+// TagServiceインターフェース
+type TagService interface {
+	FetchTags(ctx context.Context) ([]models.TagData, error)
+
+	RenameTag(ctx context.Context, id, userId, name string) (*models.TagData, error)
+	MergeTags(ctx context.Context, userId, sourceId, targetId string) (*models.TagData, error)
+
+	CreateTagAlias(ctx context.Context, tagId, userId, alias string) (*models.TagData, error)
+	DeleteTagAlias(ctx context.Context, alias, userId string) error
+}
+
+type TagServiceImpl struct {
+	TagRepository  repositories_tags.TagRepository
+	UserRepository repositories_users.UserRepository
+	Cache          *utils_cache.Cache
+}
+
+// TagServiceインターフェースを実装したTagServiceImplのポインタを返す
+func NewTagService(
+	tagRepository repositories_tags.TagRepository,
+	userRepository repositories_users.UserRepository,
+	cache *utils_cache.Cache,
+) TagService {
+	return &TagServiceImpl{
+		TagRepository:  tagRepository,
+		UserRepository: userRepository,
+		Cache:          cache,
+	}
+}
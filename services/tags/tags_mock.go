@@ -0,0 +1,49 @@
+package services_tags
+
+import (
+	"backend/models"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTagService struct {
+	mock.Mock
+}
+
+func (m *MockTagService) FetchTags(ctx context.Context) ([]models.TagData, error) {
+	args := m.Called()
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.TagData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTagService) RenameTag(ctx context.Context, id, userId, name string) (*models.TagData, error) {
+	args := m.Called(id, userId, name)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.TagData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTagService) MergeTags(ctx context.Context, userId, sourceId, targetId string) (*models.TagData, error) {
+	args := m.Called(userId, sourceId, targetId)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.TagData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTagService) CreateTagAlias(ctx context.Context, tagId, userId, alias string) (*models.TagData, error) {
+	args := m.Called(tagId, userId, alias)
+	if args.Get(0) != nil {
+		return args.Get(0).(*models.TagData), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTagService) DeleteTagAlias(ctx context.Context, alias, userId string) error {
+	args := m.Called(alias, userId)
+	return args.Error(0)
+}
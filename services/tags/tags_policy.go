@@ -0,0 +1,34 @@
+package services_tags
+
+import (
+	"backend/models"
+	utils_timeout "backend/utils/timeout"
+	"context"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// ログイン中のユーザーがタグを管理できるか確認する
+// admin・editor 以外の場合とユーザーがない場合は "forbidden"、それ以外の失敗は failure のエラーを返す。
+// 権限は変更が即時に反映されるよう、トークンではなくユーザー情報から取得する。
+func (s *TagServiceImpl) checkCanManage(ctx context.Context, userId, failure string) error {
+	user, err := s.UserRepository.FetchUserById(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to fetch user: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("forbidden")
+		}
+		return errors.New(failure)
+	}
+
+	if !models.CanManageSite(user.Role) {
+		log.Printf("User %s (%s) is not allowed to manage tags", userId, user.Role)
+		return errors.New("forbidden")
+	}
+	return nil
+}
@@ -2,15 +2,23 @@ package services_users
 
 import (
 	"backend/models"
+	utils_password "backend/utils/password"
+	utils_timeout "backend/utils/timeout"
+	"context"
 	"database/sql"
 	"errors"
 	"log"
 	"net/mail"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 )
 
 // 指定されたメールアドレスとパスワードでユーザーを取得する。
-// ユーザーが見つからない場合、エラーを返す。
-func (s *UserServiceImpl) FetchUserByEmailAndPassword(email, password string) (*models.UserData, error) {
+// ユーザーが見つからない場合、パスワードが一致しない場合は、どちらも "user not found" エラーを返す。
+func (s *UserServiceImpl) FetchUserByEmailAndPassword(ctx context.Context, email, password string) (*models.UserData, error) {
+	email = models.NormalizeEmail(email)
+
 	// バリデーション：emailとpasswordが空でないことを確認
 	if email == "" || password == "" {
 		log.Printf("Email and password are required")
@@ -25,9 +33,11 @@ func (s *UserServiceImpl) FetchUserByEmailAndPassword(email, password string) (*
 
 	log.Println("Email and password are valid")
 
-	user, err := s.UserRepository.FetchUserByEmailAndPassword(email, password)
+	credentials, err := s.UserRepository.FetchUserCredentialsByEmail(ctx, email)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, pgx.ErrNoRows) {
+			// 存在しないメールアドレスでも検証と同じ時間をかける
+			utils_password.VerifyDummy(password)
 			log.Printf("User not found for email: %s", email)
 			return nil, errors.New("user not found")
 		}
@@ -35,11 +45,44 @@ func (s *UserServiceImpl) FetchUserByEmailAndPassword(email, password string) (*
 		return nil, err
 	}
 
-	return user, nil
+	// パスワードを検証(誤りの場合もユーザーが見つからない場合と同じエラーを返す)
+	match, needsRehash, err := utils_password.Verify(credentials.PasswordHash, password)
+	if err != nil {
+		log.Printf("Failed to verify password: %v", err)
+		return nil, errors.New("failed to verify password")
+	}
+	if !match {
+		log.Printf("Invalid password for email: %s", email)
+		return nil, errors.New("user not found")
+	}
+
+	// 平文・古い形式で保存されたパスワードは、ログインに成功した時点でハッシュ化し直す
+	if needsRehash {
+		s.rehashPassword(ctx, credentials.User.ID, password)
+	}
+
+	// 資格情報は返却せず、ユーザー情報のみを返す
+	user := credentials.User
+	return &user, nil
+}
+
+// パスワードを現在の形式でハッシュ化して保存し直す
+// 失敗してもログインは成功させ、次回のログイン時に再度試みる。
+func (s *UserServiceImpl) rehashPassword(ctx context.Context, id, password string) {
+	hash, err := utils_password.Hash(password)
+	if err != nil {
+		log.Printf("Failed to hash password: %v", err)
+		return
+	}
+	if err := s.UserRepository.UpdateUserPassword(ctx, id, hash); err != nil {
+		log.Printf("Failed to rehash password: %v", err)
+		return
+	}
+	log.Println("Rehashed password successfully")
 }
 
 // 指定されたIDに一致するユーザーを取得する
-func (s *UserServiceImpl) FetchUserById(id string) (*models.UserData, error) {
+func (s *UserServiceImpl) FetchUserById(ctx context.Context, id string) (*models.UserData, error) {
 	log.Println("Fetching user by id")
 
 	// バリデーション：IDが空でないことを確認
@@ -50,9 +93,15 @@ func (s *UserServiceImpl) FetchUserById(id string) (*models.UserData, error) {
 
 	log.Println("id is valid")
 
-	user, err := s.UserRepository.FetchUserById(id)
+	user, err := s.UserRepository.FetchUserById(ctx, id)
 	if err != nil {
 		log.Printf("Failed to fetch user: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("user not found")
+		}
 		return nil, errors.New("failed to fetch user")
 	}
 
@@ -60,61 +109,30 @@ func (s *UserServiceImpl) FetchUserById(id string) (*models.UserData, error) {
 	return user, nil
 }
 
-// 指定されたIDに一致するユーザーを更新する
-func (s *UserServiceImpl) UpdateUser(id, name, email, password, newPassword string) (*models.UserData, error) {
-	log.Println("Updating user")
+// 指定されたIDに一致するユーザーの公開用プロフィールを取得する
+// 存在しない場合・IDの形式が不正な場合は "user not found" エラーを返す。
+func (s *UserServiceImpl) FetchAuthorProfile(ctx context.Context, id string) (*models.AuthorProfile, error) {
+	log.Println("Fetching author profile")
 
-	// バリデーション：IDが空でないことを確認
-	if id == "" {
-		log.Printf("id is required")
-		return nil, errors.New("id is required")
-	}
-	// バリデーション：nameが空でないことを確認
-	if name == "" {
-		log.Printf("Name is required")
-		return nil, errors.New("name is required")
-	}
-	// バリデーション：emailが空でないことを確認
-	if email == "" {
-		log.Printf("Email is required")
-		return nil, errors.New("email is required")
-	}
-	// バリデーション：passwordが空でないことを確認
-	if password == "" {
-		log.Printf("Password is required")
-		return nil, errors.New("password is required")
+	// バリデーション：IDが有効なUUIDであることを確認
+	if _, err := uuid.Parse(id); err != nil {
+		log.Printf("Invalid id format: %v", err)
+		return nil, errors.New("user not found")
 	}
-	// バリデーション：newPasswordが空でないことを確認
-	if newPassword == "" {
-		log.Printf("New password is required")
-		return nil, errors.New("new password is required")
-	}
-	// バリデーション：emailが有効な形式であることを確認
-	if email != "" {
-		if _, err := mail.ParseAddress(email); err != nil {
-			log.Printf("Invalid email format: %v", err)
-			return nil, errors.New("invalid email format")
-		}
-	}
-	// バリデーション：email,passwordを取得し、ユーザーと一致することを確認
-	currentUser, err := s.UserRepository.FetchUserById(id)
-	if err != nil {
-		log.Printf("Failed to validate user: %v", err)
-		return nil, errors.New("failed to validate user")
-	}
-	if currentUser.Password != password {
-		log.Printf("Invalid current password")
-		return nil, errors.New("invalid current password")
-	}
-
-	log.Println("ID and email are valid")
 
-	user, err := s.UserRepository.UpdateUser(id, name, email, newPassword)
+	user, err := s.UserRepository.FetchUserById(ctx, id)
 	if err != nil {
-		log.Printf("Failed to update user: %v", err)
-		return nil, errors.New("failed to update user")
+		log.Printf("Failed to fetch user: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("user not found")
+		}
+		return nil, errors.New("failed to fetch user")
 	}
 
-	log.Println("Updated user successfully")
-	return user, nil
+	// 公開してよい項目のみに変換する
+	log.Println("Fetched author profile successfully")
+	return models.NewAuthorProfile(user), nil
 }
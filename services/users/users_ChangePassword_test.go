@@ -0,0 +1,78 @@
+package services_users
+
+import (
+	"backend/models"
+	repositories_users "backend/repositories/users"
+	utils_password "backend/utils/password"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_ChangePassword(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	userService := NewUserService(mockUserRepository, nil, nil)
+
+	// 現在のパスワードはハッシュで保存されている
+	hash, _ := utils_password.Hash("password123")
+	mockUserRepository.On("FetchUserCredentialsById", "1").Return(&models.UserCredentials{User: models.UserData{ID: "1"}, PasswordHash: hash}, nil)
+	// 新しいパスワードはハッシュ化して渡す
+	mockUserRepository.On("UpdateUserPassword", "1", mock.MatchedBy(func(hash string) bool {
+		match, _, err := utils_password.Verify(hash, "newpassword123")
+		return err == nil && utils_password.IsHashed(hash) && match
+	})).Return(nil)
+
+	err := userService.ChangePassword(context.Background(), "1", "password123", "newpassword123")
+
+	assert.NoError(t, err)
+	mockUserRepository.AssertExpectations(t)
+}
+
+func TestService_ChangePassword_Error(t *testing.T) {
+	hash, _ := utils_password.Hash("password123")
+
+	tests := []struct {
+		name        string
+		id          string
+		password    string
+		newPassword string
+		fetchErr    error
+		updateErr   error
+		expectedErr string
+	}{
+		{name: "IDが空", id: "", password: "password123", newPassword: "newpassword123", expectedErr: "id is required"},
+		{name: "現在のパスワードが空", id: "1", password: "", newPassword: "newpassword123", expectedErr: "password is required"},
+		{name: "新しいパスワードが空", id: "1", password: "password123", newPassword: "", expectedErr: "new password is required"},
+		{name: "新しいパスワードが短い", id: "1", password: "password123", newPassword: "short", expectedErr: "invalid password length"},
+		{name: "現在のパスワードが誤り", id: "1", password: "wrongpassword", newPassword: "newpassword123", expectedErr: "invalid current password"},
+		{name: "ユーザーが存在しない", id: "1", password: "password123", newPassword: "newpassword123", fetchErr: pgx.ErrNoRows, expectedErr: "user not found"},
+		{name: "ユーザーの取得に失敗", id: "1", password: "password123", newPassword: "newpassword123", fetchErr: errors.New("db error"), expectedErr: "failed to change password"},
+		{name: "更新に失敗", id: "1", password: "password123", newPassword: "newpassword123", updateErr: errors.New("db error"), expectedErr: "failed to change password"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			userService := NewUserService(mockUserRepository, nil, nil)
+
+			if tt.fetchErr != nil {
+				mockUserRepository.On("FetchUserCredentialsById", tt.id).Return(nil, tt.fetchErr)
+			} else {
+				mockUserRepository.On("FetchUserCredentialsById", tt.id).Return(&models.UserCredentials{User: models.UserData{ID: tt.id}, PasswordHash: hash}, nil)
+			}
+			mockUserRepository.On("UpdateUserPassword", tt.id, mock.Anything).Return(tt.updateErr)
+
+			err := userService.ChangePassword(context.Background(), tt.id, tt.password, tt.newPassword)
+
+			assert.EqualError(t, err, tt.expectedErr)
+			if tt.updateErr == nil {
+				mockUserRepository.AssertNotCalled(t, "UpdateUserPassword", tt.id, mock.Anything)
+			}
+		})
+	}
+}
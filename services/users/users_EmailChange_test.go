@@ -0,0 +1,130 @@
+package services_users
+
+import (
+	"backend/mailer"
+	"backend/models"
+	repositories_users "backend/repositories/users"
+	utils_password "backend/utils/password"
+	utils_token "backend/utils/token"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_RequestEmailChange(t *testing.T) {
+	// モックリポジトリと送信箱をインスタンス化
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	outbox := mailer.NewOutbox("", "no-reply@example.com")
+	userService := NewUserService(mockUserRepository, outbox, nil)
+
+	hash, _ := utils_password.Hash("password123")
+	mockUserRepository.On("FetchUserCredentialsById", "1").Return(&models.UserCredentials{User: models.UserData{ID: "1", Email: "john@example.com"}, PasswordHash: hash}, nil)
+	mockUserRepository.On("FetchUserCredentialsByEmail", "new@example.com").Return(nil, pgx.ErrNoRows)
+	var storedHash string
+	mockUserRepository.On("CreateEmailChange", "1", "new@example.com", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { storedHash = args.String(2) }).
+		Return(nil)
+
+	err := userService.RequestEmailChange(context.Background(), "1", " New@Example.com ", "password123")
+
+	assert.NoError(t, err)
+
+	// 確認メールは新しいメールアドレスに送り、データベースにはハッシュのみを保存する
+	messages := outbox.Messages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "new@example.com", messages[0].To)
+	token := mailToken(t, messages[0], "/confirm-email")
+	assert.Equal(t, utils_token.Hash(token), storedHash)
+	mockUserRepository.AssertExpectations(t)
+}
+
+func TestService_RequestEmailChange_Error(t *testing.T) {
+	hash, _ := utils_password.Hash("password123")
+	current := &models.UserCredentials{User: models.UserData{ID: "1", Email: "john@example.com"}, PasswordHash: hash}
+
+	tests := []struct {
+		name        string
+		newEmail    string
+		password    string
+		existing    *models.UserCredentials
+		existingErr error
+		createErr   error
+		mailer      mailer.Mailer
+		expectedErr string
+	}{
+		{name: "メールアドレスが空", newEmail: "", password: "password123", expectedErr: "email is required"},
+		{name: "パスワードが空", newEmail: "new@example.com", password: "", expectedErr: "password is required"},
+		{name: "メールアドレスの形式が不正", newEmail: "new", password: "password123", expectedErr: "invalid email format"},
+		{name: "パスワードが誤り", newEmail: "new@example.com", password: "wrongpassword", expectedErr: "invalid current password"},
+		{name: "現在と同じメールアドレス", newEmail: "john@example.com", password: "password123", existing: current, expectedErr: "email unchanged"},
+		{name: "他のユーザーが登録済み", newEmail: "new@example.com", password: "password123", existing: &models.UserCredentials{User: models.UserData{ID: "2"}}, expectedErr: "email already registered"},
+		{name: "重複の確認に失敗", newEmail: "new@example.com", password: "password123", existingErr: errors.New("db error"), expectedErr: "failed to request email change"},
+		{name: "トークンの登録に失敗", newEmail: "new@example.com", password: "password123", existingErr: pgx.ErrNoRows, createErr: errors.New("db error"), expectedErr: "failed to request email change"},
+		{name: "送信に失敗", newEmail: "new@example.com", password: "password123", existingErr: pgx.ErrNoRows, mailer: failingMailer{}, expectedErr: "failed to send confirmation mail"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			outbox := mailer.NewOutbox("", "no-reply@example.com")
+			var m mailer.Mailer = outbox
+			if tt.mailer != nil {
+				m = tt.mailer
+			}
+			userService := NewUserService(mockUserRepository, m, nil)
+
+			mockUserRepository.On("FetchUserCredentialsById", "1").Return(current, nil)
+			mockUserRepository.On("FetchUserCredentialsByEmail", tt.newEmail).Return(tt.existing, tt.existingErr)
+			mockUserRepository.On("CreateEmailChange", "1", tt.newEmail, mock.Anything, mock.Anything).Return(tt.createErr)
+
+			err := userService.RequestEmailChange(context.Background(), "1", tt.newEmail, tt.password)
+
+			assert.EqualError(t, err, tt.expectedErr)
+			assert.Empty(t, outbox.Messages())
+		})
+	}
+}
+
+func TestService_ConfirmEmailChange(t *testing.T) {
+	verifiedAt := time.Now()
+	changed := &models.UserData{ID: "1", Email: "new@example.com", EmailVerifiedAt: &verifiedAt}
+
+	tests := []struct {
+		name        string
+		token       string
+		repoUser    *models.UserData
+		repoErr     error
+		expectedErr string
+	}{
+		{name: "変更成功", token: "token", repoUser: changed},
+		{name: "トークンが空", token: "", expectedErr: "token is required"},
+		{name: "無効なトークン", token: "token", repoErr: repositories_users.ErrEmailChangeInvalid, expectedErr: "invalid email change token"},
+		{name: "他のユーザーが登録済み", token: "token", repoErr: repositories_users.ErrUserEmailConflict, expectedErr: "email already registered"},
+		{name: "リポジトリのエラー", token: "token", repoErr: errors.New("db error"), expectedErr: "failed to change email"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			userService := NewUserService(mockUserRepository, nil, nil)
+
+			// トークンはハッシュで照合する
+			mockUserRepository.On("ConfirmEmailChange", utils_token.Hash(tt.token)).Return(tt.repoUser, tt.repoErr)
+
+			user, err := userService.ConfirmEmailChange(context.Background(), tt.token)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, user)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "new@example.com", user.Email)
+		})
+	}
+}
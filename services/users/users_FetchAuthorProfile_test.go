@@ -0,0 +1,67 @@
+package services_users
+
+import (
+	"backend/models"
+	repositories_users "backend/repositories/users"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_FetchAuthorProfile(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	userService := NewUserService(mockUserRepository, nil, nil)
+
+	// モックの挙動を設定
+	id := "11111111-1111-1111-1111-111111111111"
+	mockUserRepository.On("FetchUserById", id).Return(&models.UserData{
+		ID:    id,
+		Name:  "John Doe",
+		Email: "john@example.com",
+	}, nil)
+
+	// サービス層メソッドの実行
+	author, err := userService.FetchAuthorProfile(context.Background(), id)
+
+	// 公開してよい項目のみを返す
+	assert.NoError(t, err)
+	assert.Equal(t, &models.AuthorProfile{ID: id, Name: "John Doe"}, author)
+	mockUserRepository.AssertExpectations(t)
+}
+
+func TestService_FetchAuthorProfile_ErrorCases(t *testing.T) {
+	id := "11111111-1111-1111-1111-111111111111"
+	tests := []struct {
+		name        string
+		id          string
+		repoErr     error
+		expectedErr string
+	}{
+		{"IDが空", "", nil, "user not found"},
+		{"IDの形式が不正", "invalid", nil, "user not found"},
+		{"存在しないユーザー", id, pgx.ErrNoRows, "user not found"},
+		{"リポジトリエラー", id, errors.New("db error"), "failed to fetch user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			userService := NewUserService(mockUserRepository, nil, nil)
+			if tt.repoErr != nil {
+				mockUserRepository.On("FetchUserById", tt.id).Return(nil, tt.repoErr)
+			}
+
+			// サービス層メソッドの実行
+			author, err := userService.FetchAuthorProfile(context.Background(), tt.id)
+
+			assert.Nil(t, author)
+			assert.EqualError(t, err, tt.expectedErr)
+			mockUserRepository.AssertExpectations(t)
+		})
+	}
+}
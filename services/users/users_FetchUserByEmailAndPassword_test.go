@@ -2,29 +2,37 @@ package services_users
 
 import (
 	repositories_users "backend/repositories/users"
+	utils_password "backend/utils/password"
+	"context"
+	"errors"
 
 	"backend/models"
 	"database/sql"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestService_FetchUserByEmailAndPassword(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockUserRepository := new(repositories_users.MockUserRepository)
-	userService := NewUserService(mockUserRepository)
+	userService := NewUserService(mockUserRepository, nil, nil)
 
 	// モックの挙動を設定
-	mockUser := &models.UserData{
-		ID:    "1",
-		Name:  "John Doe",
-		Email: "john@example.com",
+	hash, _ := utils_password.Hash("password123")
+	mockCredentials := &models.UserCredentials{
+		User: models.UserData{
+			ID:    "1",
+			Name:  "John Doe",
+			Email: "john@example.com",
+		},
+		PasswordHash: hash,
 	}
-	mockUserRepository.On("FetchUserByEmailAndPassword", "john@example.com", "password123").Return(mockUser, nil)
+	mockUserRepository.On("FetchUserCredentialsByEmail", "john@example.com").Return(mockCredentials, nil)
 
-	// サービス層メソッドの実行
-	user, err := userService.FetchUserByEmailAndPassword("john@example.com", "password123")
+	// サービス層メソッドの実行(メールアドレスは大文字・小文字を区別しない)
+	user, err := userService.FetchUserByEmailAndPassword(context.Background(), " John@Example.COM ", "password123")
 
 	// エラーチェック
 	assert.NoError(t, err)
@@ -33,6 +41,62 @@ func TestService_FetchUserByEmailAndPassword(t *testing.T) {
 	assert.NotNil(t, user)
 	assert.Equal(t, "John Doe", user.Name)
 
+	// モックが期待通りに呼び出されたかを確認
+	mockUserRepository.AssertExpectations(t)
+	mockUserRepository.AssertNotCalled(t, "UpdateUserPassword", mock.Anything, mock.Anything)
+}
+
+func TestService_FetchUserByEmailAndPassword_RehashPlaintext(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	userService := NewUserService(mockUserRepository, nil, nil)
+
+	// 平文で保存されたパスワードは、ログインに成功した時点でハッシュ化して保存し直す
+	mockCredentials := &models.UserCredentials{
+		User: models.UserData{
+			ID:    "1",
+			Name:  "John Doe",
+			Email: "john@example.com",
+		},
+		PasswordHash: "password123",
+	}
+	mockUserRepository.On("FetchUserCredentialsByEmail", "john@example.com").Return(mockCredentials, nil)
+	mockUserRepository.On("UpdateUserPassword", "1", mock.MatchedBy(func(hash string) bool {
+		match, needsRehash, err := utils_password.Verify(hash, "password123")
+		return err == nil && match && !needsRehash
+	})).Return(nil)
+
+	// サービス層メソッドの実行
+	user, err := userService.FetchUserByEmailAndPassword(context.Background(), "john@example.com", "password123")
+
+	// エラーチェック
+	assert.NoError(t, err)
+	assert.Equal(t, "1", user.ID)
+
+	// モックが期待通りに呼び出されたかを確認
+	mockUserRepository.AssertExpectations(t)
+}
+
+func TestService_FetchUserByEmailAndPassword_RehashFailed(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	userService := NewUserService(mockUserRepository, nil, nil)
+
+	// 保存し直せなくてもログインは成功する
+	mockCredentials := &models.UserCredentials{
+		User:         models.UserData{ID: "1", Email: "john@example.com"},
+		PasswordHash: "password123",
+	}
+	mockUserRepository.On("FetchUserCredentialsByEmail", "john@example.com").Return(mockCredentials, nil)
+	mockUserRepository.On("UpdateUserPassword", "1", mock.Anything).Return(errors.New("failed"))
+
+	// サービス層メソッドの実行
+	user, err := userService.FetchUserByEmailAndPassword(context.Background(), "john@example.com", "password123")
+
+	// エラーチェック
+	assert.NoError(t, err)
+	assert.NotNil(t, user)
+
 	// モックが期待通りに呼び出されたかを確認
 	mockUserRepository.AssertExpectations(t)
 }
@@ -40,25 +104,40 @@ func TestService_FetchUserByEmailAndPassword(t *testing.T) {
 func TestService_FetchUserByEmailAndPassword_InvalidCases(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockUserRepository := new(repositories_users.MockUserRepository)
-	userService := NewUserService(mockUserRepository)
+	userService := NewUserService(mockUserRepository, nil, nil)
 
 	// 1. メールアドレスとパスワードが空の場合
-	_, err := userService.FetchUserByEmailAndPassword("", "")
+	_, err := userService.FetchUserByEmailAndPassword(context.Background(), "", "")
 	assert.Error(t, err)
 	assert.Equal(t, "email and password are required", err.Error())
 
 	// 2. メールアドレスの形式が無効な場合
-	_, err = userService.FetchUserByEmailAndPassword("invalid-email", "password123")
+	_, err = userService.FetchUserByEmailAndPassword(context.Background(), "invalid-email", "password123")
 	assert.Error(t, err)
 	assert.Equal(t, "invalid email format", err.Error())
 
 	// 3. ユーザーが見つからない場合
-	mockUserRepository.On("FetchUserByEmailAndPassword", "john@example.com", "password123").Return(nil, sql.ErrNoRows)
+	mockUserRepository.On("FetchUserCredentialsByEmail", "john@example.com").Return(nil, sql.ErrNoRows)
 
-	_, err = userService.FetchUserByEmailAndPassword("john@example.com", "password123")
+	_, err = userService.FetchUserByEmailAndPassword(context.Background(), "john@example.com", "password123")
 	assert.Error(t, err)
 	assert.Equal(t, "user not found", err.Error())
 
+	// 4. パスワードが一致しない場合(ユーザーが見つからない場合と同じエラー)
+	hash, _ := utils_password.Hash("password123")
+	mockUserRepository.On("FetchUserCredentialsByEmail", "jane@example.com").Return(&models.UserCredentials{User: models.UserData{ID: "2"}, PasswordHash: hash}, nil)
+
+	_, err = userService.FetchUserByEmailAndPassword(context.Background(), "jane@example.com", "wrong")
+	assert.Error(t, err)
+	assert.Equal(t, "user not found", err.Error())
+
+	// 5. 保存されたハッシュが不正な場合
+	mockUserRepository.On("FetchUserCredentialsByEmail", "broken@example.com").Return(&models.UserCredentials{User: models.UserData{ID: "3"}, PasswordHash: "$argon2id$broken"}, nil)
+
+	_, err = userService.FetchUserByEmailAndPassword(context.Background(), "broken@example.com", "password123")
+	assert.Error(t, err)
+	assert.Equal(t, "failed to verify password", err.Error())
+
 	// モックが期待通りに呼び出されたかを確認
 	mockUserRepository.AssertExpectations(t)
 }
@@ -3,6 +3,7 @@ package services_users
 import (
 	"backend/models"
 	repositories_users "backend/repositories/users"
+	"context"
 	"errors"
 	"testing"
 
@@ -12,7 +13,7 @@ import (
 func TestService_FetchUserById(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockUserRepository := new(repositories_users.MockUserRepository)
-	userService := NewUserService(mockUserRepository)
+	userService := NewUserService(mockUserRepository, nil, nil)
 
 	// モックの挙動を設定
 	mockUser := &models.UserData{
@@ -23,7 +24,7 @@ func TestService_FetchUserById(t *testing.T) {
 	mockUserRepository.On("FetchUserById", "1").Return(mockUser, nil)
 
 	// サービス層メソッドの実行
-	user, err := userService.FetchUserById("1")
+	user, err := userService.FetchUserById(context.Background(), "1")
 
 	// エラーチェック
 	assert.NoError(t, err)
@@ -39,13 +40,13 @@ func TestService_FetchUserById(t *testing.T) {
 func TestService_FetchUserById_InvalidId(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockUserRepository := new(repositories_users.MockUserRepository)
-	userService := NewUserService(mockUserRepository)
+	userService := NewUserService(mockUserRepository, nil, nil)
 
 	// モックの挙動を設定
 	mockUserRepository.On("FetchUserById", "").Return(nil, errors.New("user not found"))
 
 	// サービス層メソッドの実行
-	user, err := userService.FetchUserById("")
+	user, err := userService.FetchUserById(context.Background(), "")
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -61,13 +62,13 @@ func TestService_FetchUserById_InvalidId(t *testing.T) {
 func TestService_FetchUserById_NotUser(t *testing.T) {
 	// モックリポジトリをインスタンス化
 	mockUserRepository := new(repositories_users.MockUserRepository)
-	userService := NewUserService(mockUserRepository)
+	userService := NewUserService(mockUserRepository, nil, nil)
 
 	// モックの挙動を設定
 	mockUserRepository.On("FetchUserById", "123").Return(nil, errors.New("failed to fetch user"))
 
 	// サービス層メソッドの実行
-	user, err := userService.FetchUserById("123")
+	user, err := userService.FetchUserById(context.Background(), "123")
 
 	// エラーチェック
 	assert.Error(t, err)
@@ -0,0 +1,285 @@
+package services_users
+
+import (
+	"backend/mailer"
+	"backend/models"
+	repositories_login_failures "backend/repositories/login_failures"
+	repositories_users "backend/repositories/users"
+	utils_password "backend/utils/password"
+	utils_token "backend/utils/token"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// 申請の記録が上限に達していない状態のモックリポジトリを作成する
+func newResetRequestRepository(requests int) *repositories_login_failures.MockLoginFailureRepository {
+	mockLoginFailureRepository := new(repositories_login_failures.MockLoginFailureRepository)
+	recorded := make([]time.Time, requests)
+	for i := range recorded {
+		recorded[i] = time.Now()
+	}
+	mockLoginFailureRepository.On("RecordLoginFailure", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+	mockLoginFailureRepository.On("FetchLoginFailures", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(recorded, nil)
+	mockLoginFailureRepository.On("DeleteLoginFailuresBefore", mock.AnythingOfType("time.Time")).Return(int64(0), nil)
+	return mockLoginFailureRepository
+}
+
+// バックグラウンドでの送信が終わるまで待つ
+func waitSending(userService UserService) {
+	userService.(*UserServiceImpl).sending.Wait()
+}
+
+func TestService_ForgotPassword(t *testing.T) {
+	// モックリポジトリと送信箱をインスタンス化
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	mockLoginFailureRepository := newResetRequestRepository(1)
+	outbox := mailer.NewOutbox("", "no-reply@example.com")
+	userService := NewUserService(mockUserRepository, outbox, mockLoginFailureRepository)
+
+	mockUserRepository.On("FetchUserCredentialsByEmail", "john@example.com").
+		Return(&models.UserCredentials{User: models.UserData{ID: "1", Name: "John Doe", Email: "john@example.com"}, PasswordHash: "hash"}, nil)
+	var storedHash string
+	var expiresAt time.Time
+	mockUserRepository.On("CreatePasswordReset", "1", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) {
+			storedHash = args.String(1)
+			expiresAt = args.Get(2).(time.Time)
+		}).
+		Return(nil)
+
+	retryAfter, err := userService.ForgotPassword(context.Background(), "192.0.2.1", " John@Example.com ")
+	waitSending(userService)
+
+	assert.NoError(t, err)
+	assert.Zero(t, retryAfter)
+
+	// メールにはトークンを、データベースにはハッシュのみを保存する
+	messages := outbox.Messages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "john@example.com", messages[0].To)
+	token := mailToken(t, messages[0], "/reset-password")
+	assert.NotEmpty(t, token)
+	assert.Equal(t, utils_token.Hash(token), storedHash)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+	mockUserRepository.AssertExpectations(t)
+
+	// 申請はメールアドレスとIPアドレスのそれぞれで、記録してから数える
+	mockLoginFailureRepository.AssertCalled(t, "RecordLoginFailure", "reset-email:"+utils_token.Hash("john@example.com"), mock.Anything)
+	mockLoginFailureRepository.AssertCalled(t, "RecordLoginFailure", "reset-ip:"+utils_token.Hash("192.0.2.1"), mock.Anything)
+}
+
+// 送信が終わるのを待たずに応答する
+func TestService_ForgotPassword_Async(t *testing.T) {
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	sender := &blockingMailer{release: make(chan struct{})}
+	userService := NewUserService(mockUserRepository, sender, newResetRequestRepository(1))
+
+	mockUserRepository.On("FetchUserCredentialsByEmail", "john@example.com").
+		Return(&models.UserCredentials{User: models.UserData{ID: "1", Email: "john@example.com"}}, nil)
+	mockUserRepository.On("CreatePasswordReset", "1", mock.Anything, mock.Anything).Return(nil)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := userService.ForgotPassword(context.Background(), "192.0.2.1", "john@example.com")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("ForgotPassword waited for the mail to be sent")
+	}
+
+	close(sender.release)
+	waitSending(userService)
+	mockUserRepository.AssertExpectations(t)
+}
+
+// 送信が許可されるまで待つ送信先
+type blockingMailer struct {
+	release chan struct{}
+}
+
+func (m *blockingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	select {
+	case <-m.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// 登録済みかどうかを判別できないよう、未登録・送信の失敗でも同じ結果を返す
+func TestService_ForgotPassword_Uniform(t *testing.T) {
+	credentials := &models.UserCredentials{User: models.UserData{ID: "1", Email: "john@example.com"}}
+
+	tests := []struct {
+		name        string
+		credentials *models.UserCredentials
+		userErr     error
+		resetErr    error
+		mailer      mailer.Mailer
+	}{
+		{name: "未登録のメールアドレス", userErr: pgx.ErrNoRows, mailer: mailer.NewOutbox("", "no-reply@example.com")},
+		{name: "トークンの登録に失敗", credentials: credentials, resetErr: errors.New("db error"), mailer: mailer.NewOutbox("", "no-reply@example.com")},
+		{name: "送信に失敗", credentials: credentials, mailer: failingMailer{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			userService := NewUserService(mockUserRepository, tt.mailer, newResetRequestRepository(1))
+
+			mockUserRepository.On("FetchUserCredentialsByEmail", "john@example.com").Return(tt.credentials, tt.userErr)
+			mockUserRepository.On("CreatePasswordReset", "1", mock.Anything, mock.Anything).Return(tt.resetErr)
+
+			retryAfter, err := userService.ForgotPassword(context.Background(), "192.0.2.1", "john@example.com")
+			waitSending(userService)
+
+			assert.NoError(t, err)
+			assert.Zero(t, retryAfter)
+			if outbox, ok := tt.mailer.(*mailer.Outbox); ok {
+				assert.Empty(t, outbox.Messages())
+			}
+		})
+	}
+}
+
+// 上限を超えた申請は、登録の有無にかかわらずユーザーを取得せずに拒否する
+func TestService_ForgotPassword_Throttle(t *testing.T) {
+	t.Setenv("PASSWORD_RESET_MAX_PER_EMAIL", "3")
+	t.Setenv("PASSWORD_RESET_MAX_PER_IP", "20")
+	t.Setenv("LOGIN_THROTTLE_WINDOW", "15m")
+
+	tests := []struct {
+		name     string
+		requests map[string]int
+		throttle bool
+	}{
+		{name: "上限以内", requests: map[string]int{"email": 3, "ip": 20}},
+		{name: "メールアドレスごとの上限を超過", requests: map[string]int{"email": 4, "ip": 4}, throttle: true},
+		{name: "IPアドレスごとの上限を超過", requests: map[string]int{"email": 1, "ip": 21}, throttle: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			mockLoginFailureRepository := new(repositories_login_failures.MockLoginFailureRepository)
+			outbox := mailer.NewOutbox("", "no-reply@example.com")
+			userService := NewUserService(mockUserRepository, outbox, mockLoginFailureRepository)
+
+			now := time.Now()
+			recorded := func(n int) []time.Time {
+				requests := make([]time.Time, n)
+				for i := range requests {
+					requests[i] = now.Add(time.Duration(i-n) * time.Second)
+				}
+				return requests
+			}
+			emailKey := "reset-email:" + utils_token.Hash("unknown@example.com")
+			ipKey := "reset-ip:" + utils_token.Hash("192.0.2.1")
+			mockLoginFailureRepository.On("RecordLoginFailure", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+			mockLoginFailureRepository.On("FetchLoginFailures", emailKey, mock.AnythingOfType("time.Time")).Return(recorded(tt.requests["email"]), nil)
+			mockLoginFailureRepository.On("FetchLoginFailures", ipKey, mock.AnythingOfType("time.Time")).Return(recorded(tt.requests["ip"]), nil)
+			mockLoginFailureRepository.On("DeleteLoginFailuresBefore", mock.AnythingOfType("time.Time")).Return(int64(0), nil)
+			mockUserRepository.On("FetchUserCredentialsByEmail", "unknown@example.com").Return(nil, pgx.ErrNoRows)
+
+			retryAfter, err := userService.ForgotPassword(context.Background(), "192.0.2.1", "Unknown@Example.com")
+
+			if !tt.throttle {
+				assert.NoError(t, err)
+				assert.Zero(t, retryAfter)
+				return
+			}
+			assert.EqualError(t, err, "too many requests")
+			assert.Greater(t, retryAfter, time.Duration(0))
+			assert.LessOrEqual(t, retryAfter, 15*time.Minute)
+			mockUserRepository.AssertNotCalled(t, "FetchUserCredentialsByEmail", mock.Anything)
+		})
+	}
+}
+
+func TestService_ForgotPassword_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		email       string
+		userErr     error
+		expectedErr string
+	}{
+		{name: "メールアドレスが空", email: " ", expectedErr: "email is required"},
+		{name: "メールアドレスの形式が不正", email: "John <john@example.com>", expectedErr: "invalid email format"},
+		{name: "ユーザーの取得に失敗", email: "john@example.com", userErr: errors.New("db error"), expectedErr: "failed to request password reset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			userService := NewUserService(mockUserRepository, mailer.NewOutbox("", "no-reply@example.com"), newResetRequestRepository(1))
+
+			mockUserRepository.On("FetchUserCredentialsByEmail", tt.email).Return(nil, tt.userErr)
+
+			_, err := userService.ForgotPassword(context.Background(), "192.0.2.1", tt.email)
+
+			assert.EqualError(t, err, tt.expectedErr)
+			mockUserRepository.AssertNotCalled(t, "CreatePasswordReset", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_ResetPassword(t *testing.T) {
+	// モックリポジトリをインスタンス化
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	userService := NewUserService(mockUserRepository, nil, nil)
+
+	// トークンはハッシュで照合し、パスワードはハッシュ化して渡す
+	mockUserRepository.On("ResetPassword", utils_token.Hash("token"), mock.MatchedBy(func(hash string) bool {
+		match, _, err := utils_password.Verify(hash, "newpassword123")
+		return err == nil && utils_password.IsHashed(hash) && match
+	})).Return(&models.UserData{ID: "1"}, nil)
+
+	err := userService.ResetPassword(context.Background(), "token", "newpassword123")
+
+	assert.NoError(t, err)
+	mockUserRepository.AssertExpectations(t)
+}
+
+func TestService_ResetPassword_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		password    string
+		repoErr     error
+		expectedErr string
+	}{
+		{name: "トークンが空", token: "", password: "newpassword123", expectedErr: "token is required"},
+		{name: "パスワードが空", token: "token", password: "", expectedErr: "password is required"},
+		{name: "パスワードが短い", token: "token", password: "short", expectedErr: "invalid password length"},
+		{name: "パスワードが長い", token: "token", password: strings.Repeat("a", 129), expectedErr: "invalid password length"},
+		{name: "無効なトークン", token: "token", password: "newpassword123", repoErr: repositories_users.ErrPasswordResetInvalid, expectedErr: "invalid reset token"},
+		{name: "リポジトリのエラー", token: "token", password: "newpassword123", repoErr: errors.New("db error"), expectedErr: "failed to reset password"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			userService := NewUserService(mockUserRepository, nil, nil)
+
+			mockUserRepository.On("ResetPassword", utils_token.Hash(tt.token), mock.Anything).Return(nil, tt.repoErr)
+
+			err := userService.ResetPassword(context.Background(), tt.token, tt.password)
+
+			assert.EqualError(t, err, tt.expectedErr)
+			if tt.repoErr == nil {
+				mockUserRepository.AssertNotCalled(t, "ResetPassword", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package services_users
+
+import (
+	"backend/mailer"
+	"backend/models"
+	repositories_users "backend/repositories/users"
+	utils_password "backend/utils/password"
+	utils_token "backend/utils/token"
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// メールの本文のリンクからトークンを取り出す
+func mailToken(t *testing.T, msg mailer.Message, path string) string {
+	link := regexp.MustCompile(`https?://\S+`).FindString(msg.Body)
+	u, err := url.Parse(link)
+	assert.NoError(t, err)
+	assert.Equal(t, path, u.Path)
+	return u.Query().Get("token")
+}
+
+// 送信に失敗する Mailer
+type failingMailer struct{}
+
+func (failingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	return errors.New("smtp error")
+}
+
+func TestService_Register(t *testing.T) {
+	// モックリポジトリと送信箱をインスタンス化
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	outbox := mailer.NewOutbox("", "no-reply@example.com")
+	userService := NewUserService(mockUserRepository, outbox, nil)
+
+	created := &models.UserData{ID: "1", Name: "John Doe", Email: "john@example.com", Role: models.UserRoleAuthor}
+
+	// パスワードはハッシュ化して渡す
+	mockUserRepository.On("CreateUser", "John Doe", "john@example.com", mock.MatchedBy(func(hash string) bool {
+		match, _, err := utils_password.Verify(hash, "password123")
+		return err == nil && utils_password.IsHashed(hash) && match
+	})).Return(created, nil)
+	var storedHash string
+	var expiresAt time.Time
+	mockUserRepository.On("CreateEmailVerification", "1", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) {
+			storedHash = args.String(1)
+			expiresAt = args.Get(2).(time.Time)
+		}).
+		Return(nil)
+
+	// 前後の空白は取り除き、メールアドレスは小文字に揃える
+	user, err := userService.Register(context.Background(), " John Doe ", " John@Example.com ", "password123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, created, user)
+
+	// 確認メールにはトークンを、データベースにはハッシュのみを保存する
+	messages := outbox.Messages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "john@example.com", messages[0].To)
+	token := mailToken(t, messages[0], "/verify-email")
+	assert.NotEmpty(t, token)
+	assert.NotEqual(t, token, storedHash)
+	assert.Equal(t, utils_token.Hash(token), storedHash)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), expiresAt, time.Minute)
+	mockUserRepository.AssertExpectations(t)
+}
+
+func TestService_Register_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name        string
+		userName    string
+		email       string
+		password    string
+		expectedErr string
+	}{
+		{name: "ユーザー名が空", userName: " ", email: "john@example.com", password: "password123", expectedErr: "name is required"},
+		{name: "ユーザー名が長すぎる", userName: strings.Repeat("あ", 51), email: "john@example.com", password: "password123", expectedErr: "name is too long"},
+		{name: "メールアドレスが空", userName: "John", email: "", password: "password123", expectedErr: "email is required"},
+		{name: "パスワードが空", userName: "John", email: "john@example.com", password: "", expectedErr: "password is required"},
+		{name: "メールアドレスの形式が不正", userName: "John", email: "john", password: "password123", expectedErr: "invalid email format"},
+		{name: "表示名付きのメールアドレス", userName: "John", email: "John <john@example.com>", password: "password123", expectedErr: "invalid email format"},
+		{name: "パスワードが短すぎる", userName: "John", email: "john@example.com", password: "short", expectedErr: "invalid password length"},
+		{name: "パスワードが長すぎる", userName: "John", email: "john@example.com", password: strings.Repeat("a", 129), expectedErr: "invalid password length"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			userService := NewUserService(mockUserRepository, mailer.NewOutbox("", "no-reply@example.com"), nil)
+
+			user, err := userService.Register(context.Background(), tt.userName, tt.email, tt.password)
+
+			assert.EqualError(t, err, tt.expectedErr)
+			assert.Nil(t, user)
+			mockUserRepository.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_Register_RepositoryError(t *testing.T) {
+	tests := []struct {
+		name        string
+		createErr   error
+		expectedErr string
+	}{
+		{name: "メールアドレスが登録済み", createErr: repositories_users.ErrUserEmailConflict, expectedErr: "email already registered"},
+		{name: "リポジトリのエラー", createErr: errors.New("db error"), expectedErr: "failed to register user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			outbox := mailer.NewOutbox("", "no-reply@example.com")
+			userService := NewUserService(mockUserRepository, outbox, nil)
+
+			mockUserRepository.On("CreateUser", "John", "john@example.com", mock.Anything).Return(nil, tt.createErr)
+
+			user, err := userService.Register(context.Background(), "John", "john@example.com", "password123")
+
+			assert.EqualError(t, err, tt.expectedErr)
+			assert.Nil(t, user)
+			// 確認メールは送信しない
+			assert.Empty(t, outbox.Messages())
+		})
+	}
+}
+
+func TestService_Register_MailFailure(t *testing.T) {
+	// 確認メールの送信に失敗しても登録は成功とする(再送できるため)
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	userService := NewUserService(mockUserRepository, failingMailer{}, nil)
+
+	created := &models.UserData{ID: "1", Name: "John", Email: "john@example.com"}
+	mockUserRepository.On("CreateUser", "John", "john@example.com", mock.Anything).Return(created, nil)
+	mockUserRepository.On("CreateEmailVerification", "1", mock.Anything, mock.Anything).Return(nil)
+
+	user, err := userService.Register(context.Background(), "John", "john@example.com", "password123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, created, user)
+}
+
+func TestService_VerifyEmail(t *testing.T) {
+	verifiedAt := time.Now()
+	verified := &models.UserData{ID: "1", EmailVerifiedAt: &verifiedAt}
+
+	tests := []struct {
+		name        string
+		token       string
+		repoUser    *models.UserData
+		repoErr     error
+		expectedErr string
+	}{
+		{name: "確認成功", token: "token", repoUser: verified},
+		{name: "トークンが空", token: "", expectedErr: "token is required"},
+		{name: "無効なトークン", token: "token", repoErr: repositories_users.ErrEmailVerificationInvalid, expectedErr: "invalid verification token"},
+		{name: "リポジトリのエラー", token: "token", repoErr: errors.New("db error"), expectedErr: "failed to verify email"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			userService := NewUserService(mockUserRepository, nil, nil)
+
+			// トークンはハッシュで照合する
+			mockUserRepository.On("VerifyEmail", utils_token.Hash(tt.token)).Return(tt.repoUser, tt.repoErr)
+
+			user, err := userService.VerifyEmail(context.Background(), tt.token)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, user)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, user.EmailVerified())
+		})
+	}
+}
+
+func TestService_ResendVerification(t *testing.T) {
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name        string
+		userId      string
+		user        *models.UserData
+		userErr     error
+		expectedErr string
+		sent        bool
+	}{
+		{name: "再送成功", userId: "1", user: &models.UserData{ID: "1", Email: "john@example.com"}, sent: true},
+		{name: "IDが空", userId: "", expectedErr: "id is required"},
+		{name: "確認済み", userId: "1", user: &models.UserData{ID: "1", EmailVerifiedAt: &verifiedAt}, expectedErr: "email already verified"},
+		{name: "ユーザーが存在しない", userId: "1", userErr: pgx.ErrNoRows, expectedErr: "user not found"},
+		{name: "ユーザーの取得に失敗", userId: "1", userErr: errors.New("db error"), expectedErr: "failed to send verification mail"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			outbox := mailer.NewOutbox("", "no-reply@example.com")
+			userService := NewUserService(mockUserRepository, outbox, nil)
+
+			mockUserRepository.On("FetchUserById", tt.userId).Return(tt.user, tt.userErr)
+			mockUserRepository.On("CreateEmailVerification", tt.userId, mock.Anything, mock.Anything).Return(nil)
+
+			err := userService.ResendVerification(context.Background(), tt.userId)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.sent {
+				assert.Len(t, outbox.Messages(), 1)
+			} else {
+				assert.Empty(t, outbox.Messages())
+			}
+		})
+	}
+}
+
+func TestService_ResendVerification_MailFailure(t *testing.T) {
+	// 再送の場合は送信の失敗をエラーとして返す
+	mockUserRepository := new(repositories_users.MockUserRepository)
+	userService := NewUserService(mockUserRepository, failingMailer{}, nil)
+
+	mockUserRepository.On("FetchUserById", "1").Return(&models.UserData{ID: "1", Email: "john@example.com"}, nil)
+	mockUserRepository.On("CreateEmailVerification", "1", mock.Anything, mock.Anything).Return(nil)
+
+	err := userService.ResendVerification(context.Background(), "1")
+
+	assert.EqualError(t, err, "failed to send verification mail")
+}
@@ -0,0 +1,99 @@
+package services_users
+
+import (
+	"backend/models"
+	repositories_users "backend/repositories/users"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_UpdateProfile(t *testing.T) {
+	name := func(s string) *string { return &s }
+
+	tests := []struct {
+		name      string
+		newName   *string
+		bio       *string
+		avatarURL *string
+		expected  [3]string // 保存する名前・自己紹介・アバター画像のURL
+	}{
+		{name: "すべて更新", newName: name(" Jane Doe "), bio: name(" Gopher "), avatarURL: name("https://example.com/new.png"), expected: [3]string{"Jane Doe", "Gopher", "https://example.com/new.png"}},
+		{name: "名前のみ更新", newName: name("Jane Doe"), expected: [3]string{"Jane Doe", "Hello", "https://example.com/avatar.png"}},
+		{name: "自己紹介・アバターを削除", bio: name(""), avatarURL: name(""), expected: [3]string{"John Doe", "", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// モックリポジトリをインスタンス化
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			userService := NewUserService(mockUserRepository, nil, nil)
+
+			// 指定されていない項目は現在の値のまま保存する
+			current := &models.UserData{ID: "1", Name: "John Doe", Email: "john@example.com", Bio: "Hello", AvatarURL: "https://example.com/avatar.png"}
+			updated := &models.UserData{ID: "1", Name: tt.expected[0], Email: "john@example.com", Bio: tt.expected[1], AvatarURL: tt.expected[2]}
+			mockUserRepository.On("FetchUserById", "1").Return(current, nil)
+			mockUserRepository.On("UpdateUserProfile", "1", tt.expected[0], tt.expected[1], tt.expected[2]).Return(updated, nil)
+
+			user, err := userService.UpdateProfile(context.Background(), "1", tt.newName, tt.bio, tt.avatarURL)
+
+			assert.NoError(t, err)
+			assert.Equal(t, updated, user)
+			mockUserRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_UpdateProfile_Error(t *testing.T) {
+	name := func(s string) *string { return &s }
+
+	tests := []struct {
+		name        string
+		id          string
+		newName     *string
+		bio         *string
+		avatarURL   *string
+		fetchErr    error
+		updateErr   error
+		expectedErr string
+	}{
+		{name: "IDが空", id: "", newName: name("Jane"), expectedErr: "id is required"},
+		{name: "項目の指定がない", id: "1", expectedErr: "no fields to update"},
+		{name: "名前が空", id: "1", newName: name(" "), expectedErr: "name is required"},
+		{name: "名前が長い", id: "1", newName: name(strings.Repeat("あ", 51)), expectedErr: "name is too long"},
+		{name: "自己紹介が長い", id: "1", bio: name(strings.Repeat("あ", 501)), expectedErr: "bio is too long"},
+		{name: "アバターのURLが相対パス", id: "1", avatarURL: name("/avatar.png"), expectedErr: "invalid avatar url"},
+		{name: "アバターのURLのスキームが不正", id: "1", avatarURL: name("javascript:alert(1)"), expectedErr: "invalid avatar url"},
+		{name: "アバターのURLが長い", id: "1", avatarURL: name("https://example.com/" + strings.Repeat("a", 2048)), expectedErr: "invalid avatar url"},
+		{name: "ユーザーが存在しない", id: "1", newName: name("Jane"), fetchErr: pgx.ErrNoRows, expectedErr: "user not found"},
+		{name: "ユーザーの取得に失敗", id: "1", newName: name("Jane"), fetchErr: errors.New("db error"), expectedErr: "failed to update profile"},
+		{name: "更新に失敗", id: "1", newName: name("Jane"), updateErr: errors.New("db error"), expectedErr: "failed to update profile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(repositories_users.MockUserRepository)
+			userService := NewUserService(mockUserRepository, nil, nil)
+
+			if tt.fetchErr != nil {
+				mockUserRepository.On("FetchUserById", tt.id).Return(nil, tt.fetchErr)
+			} else {
+				mockUserRepository.On("FetchUserById", tt.id).Return(&models.UserData{ID: tt.id, Name: "John Doe"}, nil)
+			}
+			mockUserRepository.On("UpdateUserProfile", tt.id, mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.updateErr)
+
+			user, err := userService.UpdateProfile(context.Background(), tt.id, tt.newName, tt.bio, tt.avatarURL)
+
+			assert.EqualError(t, err, tt.expectedErr)
+			assert.Nil(t, user)
+			if tt.updateErr == nil {
+				mockUserRepository.AssertNotCalled(t, "UpdateUserProfile", tt.id, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package services_users
+
+import (
+	"backend/config"
+	"backend/mailer"
+	"backend/models"
+	repositories_users "backend/repositories/users"
+	utils_timeout "backend/utils/timeout"
+	utils_token "backend/utils/token"
+	"context"
+	"errors"
+	"log"
+	"net/mail"
+	"net/url"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// 現在のパスワードを確認し、新しいメールアドレスに変更の確認メールを送信する
+// メールアドレスはリンクを開くまで変更しない。申請し直した場合は以前のリンクは使えなくなる。
+func (s *UserServiceImpl) RequestEmailChange(ctx context.Context, id, newEmail, password string) error {
+	log.Println("Requesting email change")
+
+	newEmail = models.NormalizeEmail(newEmail)
+
+	// バリデーション
+	if id == "" {
+		log.Printf("id is required")
+		return errors.New("id is required")
+	}
+	if newEmail == "" {
+		log.Printf("Email is required")
+		return errors.New("email is required")
+	}
+	if password == "" {
+		log.Printf("Password is required")
+		return errors.New("password is required")
+	}
+	if address, err := mail.ParseAddress(newEmail); err != nil || address.Address != newEmail {
+		log.Printf("Invalid email format: %v", err)
+		return errors.New("invalid email format")
+	}
+
+	if err := s.verifyCurrentPassword(ctx, id, password, "failed to request email change"); err != nil {
+		return err
+	}
+
+	// 変更先が既に登録されていないか確認する(確定時にも一意制約で確認する)
+	existing, err := s.UserRepository.FetchUserCredentialsByEmail(ctx, newEmail)
+	switch {
+	case err == nil && existing.User.ID == id:
+		log.Printf("Email unchanged: %s", newEmail)
+		return errors.New("email unchanged")
+	case err == nil:
+		log.Printf("Email already registered: %s", newEmail)
+		return errors.New("email already registered")
+	case !errors.Is(err, pgx.ErrNoRows):
+		log.Printf("Failed to fetch user: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		return errors.New("failed to request email change")
+	}
+
+	token, err := utils_token.New()
+	if err != nil {
+		log.Printf("Failed to generate token: %v", err)
+		return errors.New("failed to request email change")
+	}
+	ttl := config.EmailVerificationTTL()
+	if err := s.UserRepository.CreateEmailChange(ctx, id, newEmail, utils_token.Hash(token), time.Now().Add(ttl)); err != nil {
+		log.Printf("Failed to create email change: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		return errors.New("failed to request email change")
+	}
+
+	if err := s.Mailer.Send(ctx, emailChangeMessage(newEmail, token, ttl)); err != nil {
+		log.Printf("Failed to send email change mail: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		return errors.New("failed to send confirmation mail")
+	}
+
+	log.Println("Sent email change mail successfully")
+	return nil
+}
+
+// メールアドレス変更のトークンでメールアドレスを変更する
+// 未登録・使用済み・期限切れのトークンは "invalid email change token" エラーを返す。
+func (s *UserServiceImpl) ConfirmEmailChange(ctx context.Context, token string) (*models.UserData, error) {
+	log.Println("Confirming email change")
+
+	if token == "" {
+		log.Printf("Token is required")
+		return nil, errors.New("token is required")
+	}
+
+	user, err := s.UserRepository.ConfirmEmailChange(ctx, utils_token.Hash(token))
+	if err != nil {
+		log.Printf("Failed to confirm email change: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, repositories_users.ErrEmailChangeInvalid) {
+			return nil, errors.New("invalid email change token")
+		}
+		if errors.Is(err, repositories_users.ErrUserEmailConflict) {
+			return nil, errors.New("email already registered")
+		}
+		return nil, errors.New("failed to change email")
+	}
+
+	log.Println("Confirmed email change successfully")
+	return user, nil
+}
+
+// メールアドレス変更の確認メールの内容を作成する
+func emailChangeMessage(newEmail, token string, ttl time.Duration) mailer.Message {
+	link := config.AppBaseURL() + "/confirm-email?token=" + url.QueryEscape(token)
+	return mailer.Message{
+		To:      newEmail,
+		Subject: "メールアドレス変更の確認",
+		Body: "メールアドレスの変更を受け付けました。以下のリンクを開くと、このメールアドレスに変更されます。\n\n" +
+			link + "\n\n" +
+			"リンクの有効期間は" + formatTTL(ttl) + "です。\n" +
+			"このメールに心当たりがない場合は、破棄してください。メールアドレスは変更されません。\n",
+	}
+}
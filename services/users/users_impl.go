@@ -1,25 +1,48 @@
 package services_users
 
 import (
+	"backend/mailer"
 	"backend/models"
+	repositories_login_failures "backend/repositories/login_failures"
 	repositories_users "backend/repositories/users"
+	"context"
+	"sync"
+	"time"
 )
 
 // UserServiceインターフェース
 type UserService interface {
-	FetchUserByEmailAndPassword(email, password string) (*models.UserData, error)
-	FetchUserById(id string) (*models.UserData, error)
-	UpdateUser(id, name, email, password, newPassword string) (*models.UserData, error)
+	FetchUserByEmailAndPassword(ctx context.Context, email, password string) (*models.UserData, error)
+	FetchUserById(ctx context.Context, id string) (*models.UserData, error)
+	FetchAuthorProfile(ctx context.Context, id string) (*models.AuthorProfile, error)
+	UpdateProfile(ctx context.Context, id string, name, bio, avatarURL *string) (*models.UserData, error)
+	ChangePassword(ctx context.Context, id, password, newPassword string) error
+	RequestEmailChange(ctx context.Context, id, newEmail, password string) error
+	ConfirmEmailChange(ctx context.Context, token string) (*models.UserData, error)
+	Register(ctx context.Context, name, email, password string) (*models.UserData, error)
+	VerifyEmail(ctx context.Context, token string) (*models.UserData, error)
+	ResendVerification(ctx context.Context, userId string) error
+	ForgotPassword(ctx context.Context, ip, email string) (time.Duration, error)
+	ResetPassword(ctx context.Context, token, newPassword string) error
 }
 type UserServiceImpl struct {
-	UserRepository repositories_users.UserRepository
+	UserRepository         repositories_users.UserRepository
+	Mailer                 mailer.Mailer
+	LoginFailureRepository repositories_login_failures.LoginFailureRepository // パスワード再設定の申請回数の記録先
+
+	// バックグラウンドで送信中のメール
+	sending sync.WaitGroup
 }
 
 // UserServiceインターフェースを実装したUserServiceImplのポインタを返す
 func NewUserService(
 	userRepository repositories_users.UserRepository,
+	mailer mailer.Mailer,
+	loginFailureRepository repositories_login_failures.LoginFailureRepository,
 ) UserService {
 	return &UserServiceImpl{
-		UserRepository: userRepository,
+		UserRepository:         userRepository,
+		Mailer:                 mailer,
+		LoginFailureRepository: loginFailureRepository,
 	}
 }
@@ -2,6 +2,8 @@ package services_users
 
 import (
 	"backend/models"
+	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,7 +12,7 @@ type MockUserService struct {
 	mock.Mock
 }
 
-func (m *MockUserService) FetchUserByEmailAndPassword(email, password string) (*models.UserData, error) {
+func (m *MockUserService) FetchUserByEmailAndPassword(ctx context.Context, email, password string) (*models.UserData, error) {
 	args := m.Called(email, password)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -18,7 +20,7 @@ func (m *MockUserService) FetchUserByEmailAndPassword(email, password string) (*
 	return args.Get(0).(*models.UserData), args.Error(1)
 }
 
-func (m *MockUserService) FetchUserById(id string) (*models.UserData, error) {
+func (m *MockUserService) FetchUserById(ctx context.Context, id string) (*models.UserData, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -26,10 +28,67 @@ func (m *MockUserService) FetchUserById(id string) (*models.UserData, error) {
 	return args.Get(0).(*models.UserData), args.Error(1)
 }
 
-func (m *MockUserService) UpdateUser(id, name, email, password, newPassword string) (*models.UserData, error) {
-	args := m.Called(id, name, email, password, newPassword)
+func (m *MockUserService) FetchAuthorProfile(ctx context.Context, id string) (*models.AuthorProfile, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AuthorProfile), args.Error(1)
+}
+
+func (m *MockUserService) UpdateProfile(ctx context.Context, id string, name, bio, avatarURL *string) (*models.UserData, error) {
+	args := m.Called(id, name, bio, avatarURL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserData), args.Error(1)
+}
+
+func (m *MockUserService) ChangePassword(ctx context.Context, id, password, newPassword string) error {
+	args := m.Called(id, password, newPassword)
+	return args.Error(0)
+}
+
+func (m *MockUserService) RequestEmailChange(ctx context.Context, id, newEmail, password string) error {
+	args := m.Called(id, newEmail, password)
+	return args.Error(0)
+}
+
+func (m *MockUserService) ConfirmEmailChange(ctx context.Context, token string) (*models.UserData, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserData), args.Error(1)
+}
+
+func (m *MockUserService) Register(ctx context.Context, name, email, password string) (*models.UserData, error) {
+	args := m.Called(name, email, password)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.UserData), args.Error(1)
 }
+
+func (m *MockUserService) VerifyEmail(ctx context.Context, token string) (*models.UserData, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserData), args.Error(1)
+}
+
+func (m *MockUserService) ResendVerification(ctx context.Context, userId string) error {
+	args := m.Called(userId)
+	return args.Error(0)
+}
+
+func (m *MockUserService) ForgotPassword(ctx context.Context, ip, email string) (time.Duration, error) {
+	args := m.Called(ip, email)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
+
+func (m *MockUserService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	args := m.Called(token, newPassword)
+	return args.Error(0)
+}
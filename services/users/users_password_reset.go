@@ -0,0 +1,213 @@
+package services_users
+
+import (
+	"backend/config"
+	"backend/mailer"
+	"backend/models"
+	repositories_users "backend/repositories/users"
+	utils_password "backend/utils/password"
+	utils_timeout "backend/utils/timeout"
+	utils_token "backend/utils/token"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"net/mail"
+	"net/url"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// バックグラウンドでのトークンの発行・メールの送信の時間の上限
+const passwordResetSendTimeout = 30 * time.Second
+
+// パスワード再設定のリンクをメールで送信する
+// 登録済みのメールアドレスかどうかを判別できないよう、未登録の場合やトークンの発行・送信に失敗した場合もエラーを返さない。
+// 応答時間からも判別できないよう、トークンの発行・送信は応答を待たずにバックグラウンドで行う。
+// 申請が続いている場合は "too many requests" エラーと、次に申請できるまでの時間を返す。
+// それ以外のエラーを返すのは入力が不正な場合と、ユーザーの取得に失敗した場合のみ。
+func (s *UserServiceImpl) ForgotPassword(ctx context.Context, ip, email string) (time.Duration, error) {
+	log.Println("Requesting password reset")
+
+	email = models.NormalizeEmail(email)
+
+	// バリデーション
+	if email == "" {
+		log.Printf("Email is required")
+		return 0, errors.New("email is required")
+	}
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		log.Printf("Invalid email format: %v", err)
+		return 0, errors.New("invalid email format")
+	}
+
+	// 未登録のメールアドレスも同じように数え、制限の有無から登録の有無がわからないようにする
+	if retryAfter, err := s.checkPasswordResetThrottle(ctx, ip, email); err != nil {
+		return retryAfter, err
+	}
+
+	credentials, err := s.UserRepository.FetchUserCredentialsByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("User not found for email: %s", email)
+			return 0, nil
+		}
+		log.Printf("Failed to fetch user: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return 0, err
+		}
+		return 0, errors.New("failed to request password reset")
+	}
+
+	// リクエストの終了後も送信を続けるため、リクエストのコンテキストは使用しない
+	user := credentials.User
+	s.sending.Add(1)
+	go func() {
+		defer s.sending.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), passwordResetSendTimeout)
+		defer cancel()
+		if err := s.sendPasswordReset(ctx, &user); err != nil {
+			log.Printf("Failed to send password reset mail: %v", err)
+			return
+		}
+		log.Println("Sent password reset mail successfully")
+	}()
+
+	log.Println("Accepted password reset request")
+	return 0, nil
+}
+
+// パスワード再設定の申請を制限する単位ごとの上限
+// 申請の記録はログインの失敗と同じ記録先に、キーの接頭辞で区別して保存する。
+type passwordResetLimit struct {
+	key string
+	max int
+}
+
+// パスワード再設定の申請をメールアドレスとIPアドレスごとに記録し、上限を超えていないか確認する
+// 同時に申請された場合も上限を超えないよう、記録してから期間内の申請を数える。
+// 上限を超えた場合は "too many requests" エラーと、次に申請できるまでの時間を返す。
+// 記録を保存・取得できない場合は、申請を妨げないよう制限しない。
+func (s *UserServiceImpl) checkPasswordResetThrottle(ctx context.Context, ip, email string) (time.Duration, error) {
+	now := time.Now()
+	window := config.LoginThrottleWindow()
+
+	limits := []passwordResetLimit{{
+		key: "reset-email:" + utils_token.Hash(email),
+		max: config.PasswordResetMaxRequestsPerEmail(),
+	}}
+	if ip != "" {
+		limits = append(limits, passwordResetLimit{
+			key: "reset-ip:" + utils_token.Hash(ip),
+			max: config.PasswordResetMaxRequestsPerIP(),
+		})
+	}
+
+	var retryAfter time.Duration
+	for _, limit := range limits {
+		if err := s.LoginFailureRepository.RecordLoginFailure(ctx, limit.key, now); err != nil {
+			log.Printf("Failed to record password reset request: %v", err)
+			if utils_timeout.IsTimeout(err) {
+				return 0, err
+			}
+			continue
+		}
+		requests, err := s.LoginFailureRepository.FetchLoginFailures(ctx, limit.key, now.Add(-window))
+		if err != nil {
+			log.Printf("Failed to fetch password reset requests: %v", err)
+			if utils_timeout.IsTimeout(err) {
+				return 0, err
+			}
+			continue
+		}
+		// 古い申請から期間を過ぎ、上限を下回るまで待つ
+		if n := len(requests); n > limit.max {
+			if wait := requests[n-limit.max].Add(window).Sub(now); wait > retryAfter {
+				retryAfter = wait
+			}
+		}
+	}
+
+	// 期間を過ぎた記録を削除する
+	if _, err := s.LoginFailureRepository.DeleteLoginFailuresBefore(ctx, now.Add(-window)); err != nil {
+		log.Printf("Failed to delete expired password reset requests: %v", err)
+	}
+
+	if retryAfter > 0 {
+		log.Printf("Password reset throttled for %s", retryAfter)
+		return retryAfter, errors.New("too many requests")
+	}
+	return 0, nil
+}
+
+// パスワード再設定のトークンで新しいパスワードを設定する
+// 同じユーザーの他のトークンは無効になり、ログインセッションはすべて失効する。
+// 未登録・使用済み・期限切れのトークンは "invalid reset token" エラーを返す。
+func (s *UserServiceImpl) ResetPassword(ctx context.Context, token, newPassword string) error {
+	log.Println("Resetting password")
+
+	// バリデーション
+	if token == "" {
+		log.Printf("Token is required")
+		return errors.New("token is required")
+	}
+	if newPassword == "" {
+		log.Printf("Password is required")
+		return errors.New("password is required")
+	}
+	if length := utf8.RuneCountInString(newPassword); length < minPasswordLength || length > maxPasswordLength {
+		log.Printf("Invalid password length: %d", length)
+		return errors.New("invalid password length")
+	}
+
+	hash, err := utils_password.Hash(newPassword)
+	if err != nil {
+		log.Printf("Failed to hash password: %v", err)
+		return errors.New("failed to reset password")
+	}
+
+	user, err := s.UserRepository.ResetPassword(ctx, utils_token.Hash(token), hash)
+	if err != nil {
+		log.Printf("Failed to reset password: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		if errors.Is(err, repositories_users.ErrPasswordResetInvalid) {
+			return errors.New("invalid reset token")
+		}
+		return errors.New("failed to reset password")
+	}
+
+	log.Printf("Reset password successfully: %s", user.ID)
+	return nil
+}
+
+// パスワード再設定のトークンを発行し、再設定用のリンクをメールで送信する
+// トークンはハッシュのみを保存し、平文はメールにのみ記載する。
+func (s *UserServiceImpl) sendPasswordReset(ctx context.Context, user *models.UserData) error {
+	token, err := utils_token.New()
+	if err != nil {
+		return err
+	}
+	ttl := config.PasswordResetTTL()
+	if err := s.UserRepository.CreatePasswordReset(ctx, user.ID, utils_token.Hash(token), time.Now().Add(ttl)); err != nil {
+		return err
+	}
+	return s.Mailer.Send(ctx, passwordResetMessage(user, token, ttl))
+}
+
+// パスワード再設定のメールの内容を作成する
+func passwordResetMessage(user *models.UserData, token string, ttl time.Duration) mailer.Message {
+	link := config.AppBaseURL() + "/reset-password?token=" + url.QueryEscape(token)
+	return mailer.Message{
+		To:      user.Email,
+		Subject: "パスワードの再設定",
+		Body: user.Name + " 様\n\n" +
+			"パスワードの再設定を受け付けました。以下のリンクから新しいパスワードを設定してください。\n\n" +
+			link + "\n\n" +
+			"リンクの有効期間は" + formatTTL(ttl) + "で、一度だけ使用できます。再設定すると、すべての端末でログアウトします。\n" +
+			"このメールに心当たりがない場合は、破棄してください。パスワードは変更されません。\n",
+	}
+}
@@ -0,0 +1,186 @@
+package services_users
+
+import (
+	"backend/models"
+	utils_password "backend/utils/password"
+	utils_timeout "backend/utils/timeout"
+	"context"
+	"errors"
+	"log"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// プロフィールの自己紹介・アバター画像のURLの長さ(文字数)
+const (
+	maxBioLength       = 500
+	maxAvatarURLLength = 2048
+)
+
+// ログイン中のユーザーのプロフィール(名前・自己紹介・アバター画像のURL)を更新する
+// nil の項目は変更しない。自己紹介・アバター画像のURLは空文字で削除できる。
+func (s *UserServiceImpl) UpdateProfile(ctx context.Context, id string, name, bio, avatarURL *string) (*models.UserData, error) {
+	log.Println("Updating user profile")
+
+	// バリデーション
+	if id == "" {
+		log.Printf("id is required")
+		return nil, errors.New("id is required")
+	}
+	if name == nil && bio == nil && avatarURL == nil {
+		log.Printf("No fields to update")
+		return nil, errors.New("no fields to update")
+	}
+	if name != nil {
+		trimmed := strings.TrimSpace(*name)
+		if trimmed == "" {
+			log.Printf("Name is required")
+			return nil, errors.New("name is required")
+		}
+		if utf8.RuneCountInString(trimmed) > maxNameLength {
+			log.Printf("Name is too long")
+			return nil, errors.New("name is too long")
+		}
+		name = &trimmed
+	}
+	if bio != nil {
+		trimmed := strings.TrimSpace(*bio)
+		if utf8.RuneCountInString(trimmed) > maxBioLength {
+			log.Printf("Bio is too long")
+			return nil, errors.New("bio is too long")
+		}
+		bio = &trimmed
+	}
+	if avatarURL != nil {
+		trimmed := strings.TrimSpace(*avatarURL)
+		if trimmed != "" && !validAvatarURL(trimmed) {
+			log.Printf("Invalid avatar url: %s", trimmed)
+			return nil, errors.New("invalid avatar url")
+		}
+		avatarURL = &trimmed
+	}
+
+	user, err := s.UserRepository.FetchUserById(ctx, id)
+	if err != nil {
+		log.Printf("Failed to fetch user: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("user not found")
+		}
+		return nil, errors.New("failed to update profile")
+	}
+
+	// 指定された項目のみを現在の値に上書きする
+	if name != nil {
+		user.Name = *name
+	}
+	if bio != nil {
+		user.Bio = *bio
+	}
+	if avatarURL != nil {
+		user.AvatarURL = *avatarURL
+	}
+
+	updated, err := s.UserRepository.UpdateUserProfile(ctx, id, user.Name, user.Bio, user.AvatarURL)
+	if err != nil {
+		log.Printf("Failed to update user profile: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("user not found")
+		}
+		return nil, errors.New("failed to update profile")
+	}
+
+	log.Println("Updated user profile successfully")
+	return updated, nil
+}
+
+// アバター画像のURLが http(s) の絶対URLか判定する
+func validAvatarURL(raw string) bool {
+	if len(raw) > maxAvatarURLLength {
+		return false
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// 現在のパスワードを確認し、ログイン中のユーザーのパスワードを変更する
+// 現在のパスワードが誤っている場合は "invalid current password" エラーを返す。
+func (s *UserServiceImpl) ChangePassword(ctx context.Context, id, password, newPassword string) error {
+	log.Println("Changing password")
+
+	// バリデーション
+	if id == "" {
+		log.Printf("id is required")
+		return errors.New("id is required")
+	}
+	if password == "" {
+		log.Printf("Password is required")
+		return errors.New("password is required")
+	}
+	if newPassword == "" {
+		log.Printf("New password is required")
+		return errors.New("new password is required")
+	}
+	if length := utf8.RuneCountInString(newPassword); length < minPasswordLength || length > maxPasswordLength {
+		log.Printf("Invalid password length: %d", length)
+		return errors.New("invalid password length")
+	}
+
+	if err := s.verifyCurrentPassword(ctx, id, password, "failed to change password"); err != nil {
+		return err
+	}
+
+	hash, err := utils_password.Hash(newPassword)
+	if err != nil {
+		log.Printf("Failed to hash password: %v", err)
+		return errors.New("failed to change password")
+	}
+
+	if err := s.UserRepository.UpdateUserPassword(ctx, id, hash); err != nil {
+		log.Printf("Failed to update user password: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("user not found")
+		}
+		return errors.New("failed to change password")
+	}
+
+	log.Println("Changed password successfully")
+	return nil
+}
+
+// ユーザーの現在のパスワードを検証する
+// ユーザーがない場合は "user not found"、誤っている場合は "invalid current password"、それ以外の失敗は failure のエラーを返す。
+func (s *UserServiceImpl) verifyCurrentPassword(ctx context.Context, id, password, failure string) error {
+	credentials, err := s.UserRepository.FetchUserCredentialsById(ctx, id)
+	if err != nil {
+		log.Printf("Failed to fetch user credentials: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("user not found")
+		}
+		return errors.New(failure)
+	}
+
+	match, _, err := utils_password.Verify(credentials.PasswordHash, password)
+	if err != nil || !match {
+		log.Printf("Invalid current password")
+		return errors.New("invalid current password")
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package services_users
+
+import (
+	"backend/config"
+	"backend/mailer"
+	"backend/models"
+	repositories_users "backend/repositories/users"
+	utils_password "backend/utils/password"
+	utils_timeout "backend/utils/timeout"
+	utils_token "backend/utils/token"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// 登録時のユーザー名・パスワードの長さ(文字数)
+const (
+	maxNameLength     = 50
+	minPasswordLength = 8
+	maxPasswordLength = 128
+)
+
+// ユーザーを登録し、メールアドレスの確認メールを送信する
+// 登録直後のユーザーは author 権限で、メールアドレスを確認するまでブログを投稿できない。
+// 確認メールの送信に失敗しても登録は成功とし、ResendVerification で再送できる。
+func (s *UserServiceImpl) Register(ctx context.Context, name, email, password string) (*models.UserData, error) {
+	log.Println("Registering user")
+
+	name = strings.TrimSpace(name)
+	email = models.NormalizeEmail(email)
+
+	// バリデーション
+	if name == "" {
+		log.Printf("Name is required")
+		return nil, errors.New("name is required")
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		log.Printf("Name is too long")
+		return nil, errors.New("name is too long")
+	}
+	if email == "" {
+		log.Printf("Email is required")
+		return nil, errors.New("email is required")
+	}
+	if password == "" {
+		log.Printf("Password is required")
+		return nil, errors.New("password is required")
+	}
+	// 表示名付きの形式("Name <a@example.com>")は受け付けない
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		log.Printf("Invalid email format: %v", err)
+		return nil, errors.New("invalid email format")
+	}
+	if length := utf8.RuneCountInString(password); length < minPasswordLength || length > maxPasswordLength {
+		log.Printf("Invalid password length: %d", length)
+		return nil, errors.New("invalid password length")
+	}
+
+	log.Println("Name, email and password are valid")
+
+	hash, err := utils_password.Hash(password)
+	if err != nil {
+		log.Printf("Failed to hash password: %v", err)
+		return nil, errors.New("failed to register user")
+	}
+
+	user, err := s.UserRepository.CreateUser(ctx, name, email, hash)
+	if err != nil {
+		log.Printf("Failed to create user: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, repositories_users.ErrUserEmailConflict) {
+			return nil, errors.New("email already registered")
+		}
+		return nil, errors.New("failed to register user")
+	}
+
+	if err := s.sendVerification(ctx, user); err != nil {
+		log.Printf("Failed to send verification mail: %v", err)
+	}
+
+	log.Println("Registered user successfully")
+	return user, nil
+}
+
+// 確認メールのトークンでメールアドレスを確認済みにする
+// 未登録・使用済み・期限切れのトークンは "invalid verification token" エラーを返す。
+func (s *UserServiceImpl) VerifyEmail(ctx context.Context, token string) (*models.UserData, error) {
+	log.Println("Verifying email")
+
+	if token == "" {
+		log.Printf("Token is required")
+		return nil, errors.New("token is required")
+	}
+
+	user, err := s.UserRepository.VerifyEmail(ctx, utils_token.Hash(token))
+	if err != nil {
+		log.Printf("Failed to verify email: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return nil, err
+		}
+		if errors.Is(err, repositories_users.ErrEmailVerificationInvalid) {
+			return nil, errors.New("invalid verification token")
+		}
+		return nil, errors.New("failed to verify email")
+	}
+
+	log.Println("Verified email successfully")
+	return user, nil
+}
+
+// ログイン中のユーザーに確認メールを再送する
+// 確認済みの場合は "email already verified" エラーを返す。
+func (s *UserServiceImpl) ResendVerification(ctx context.Context, userId string) error {
+	log.Println("Resending verification mail")
+
+	if userId == "" {
+		log.Printf("id is required")
+		return errors.New("id is required")
+	}
+
+	user, err := s.UserRepository.FetchUserById(ctx, userId)
+	if err != nil {
+		log.Printf("Failed to fetch user: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("user not found")
+		}
+		return errors.New("failed to send verification mail")
+	}
+	if user.EmailVerified() {
+		log.Printf("Email already verified: %s", userId)
+		return errors.New("email already verified")
+	}
+
+	if err := s.sendVerification(ctx, user); err != nil {
+		log.Printf("Failed to send verification mail: %v", err)
+		if utils_timeout.IsTimeout(err) {
+			return err
+		}
+		return errors.New("failed to send verification mail")
+	}
+
+	log.Println("Resent verification mail successfully")
+	return nil
+}
+
+// 確認メールのトークンを発行し、確認用のリンクをメールで送信する
+// トークンはハッシュのみを保存し、平文はメールにのみ記載する。
+func (s *UserServiceImpl) sendVerification(ctx context.Context, user *models.UserData) error {
+	token, err := utils_token.New()
+	if err != nil {
+		return err
+	}
+	ttl := config.EmailVerificationTTL()
+	if err := s.UserRepository.CreateEmailVerification(ctx, user.ID, utils_token.Hash(token), time.Now().Add(ttl)); err != nil {
+		return err
+	}
+	return s.Mailer.Send(ctx, verificationMessage(user, token, ttl))
+}
+
+// 確認メールの内容を作成する
+func verificationMessage(user *models.UserData, token string, ttl time.Duration) mailer.Message {
+	link := config.AppBaseURL() + "/verify-email?token=" + url.QueryEscape(token)
+	return mailer.Message{
+		To:      user.Email,
+		Subject: "メールアドレスの確認",
+		Body: user.Name + " 様\n\n" +
+			"ご登録ありがとうございます。以下のリンクからメールアドレスを確認してください。\n\n" +
+			link + "\n\n" +
+			"リンクの有効期間は" + formatTTL(ttl) + "です。期限が切れた場合は、ログイン後に確認メールを再送してください。\n" +
+			"このメールに心当たりがない場合は、破棄してください。\n",
+	}
+}
+
+// 有効期間をメールに記載する形式(「24時間」「30分」)に変換する
+func formatTTL(ttl time.Duration) string {
+	if ttl >= time.Hour && ttl%time.Hour == 0 {
+		return fmt.Sprintf("%d時間", int(ttl.Hours()))
+	}
+	return fmt.Sprintf("%d分", int(ttl.Minutes()))
+}
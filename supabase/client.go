@@ -13,9 +13,8 @@ import (
 )
 
 var (
-	// Supabaseとのやり取りに使用するグローバルなコンテキスト。
-	Ctx = context.Background()
 	// Supabaseとの接続プールです。クエリ実行時に使用。
+	// リクエスト処理中のクエリには、リクエストのコンテキストを渡すこと。
 	Pool *pgxpool.Pool
 )
 
@@ -25,9 +24,7 @@ var (
 // 成功時にはnilを返し、接続に失敗した場合はエラーメッセージを返す。
 func InitSupabase() error {
 	logger.InfoLog.Println("Initializing Supabase client...")
-	supabaseURL := os.Getenv("SUPABASE_URL") + "?sslmode=require"
-
-	config, err := pgxpool.ParseConfig(supabaseURL)
+	config, err := pgxpool.ParseConfig(databaseURL())
 	if err != nil {
 		log.Printf("Unable to parse database URL: %v", err)
 		return fmt.Errorf("unable to parse database URL: %v", err)
@@ -39,8 +36,12 @@ func InitSupabase() error {
 	// Prepared Statementの競合を防ぐためにSimple Protocolを優先
 	config.ConnConfig.PreferSimpleProtocol = true
 
+	// 起動時の接続・疎通確認にはタイムアウトを設定する
+	ctx, cancel := WithQueryTimeout(context.Background())
+	defer cancel()
+
 	logger.InfoLog.Println("Connecting supabase database...")
-	Pool, err = pgxpool.ConnectConfig(Ctx, config)
+	Pool, err = pgxpool.ConnectConfig(ctx, config)
 	if err != nil {
 		logger.ErrorLog.Printf("Unable to connect to Supabase: %v", err)
 		return fmt.Errorf("unable to connect to Supabase: %v", err)
@@ -48,7 +49,7 @@ func InitSupabase() error {
 
 	// 接続の確認
 	logger.InfoLog.Println("Pinging supabase database...")
-	err = Pool.Ping(Ctx)
+	err = Pool.Ping(ctx)
 	if err != nil {
 		logger.ErrorLog.Printf("Unable to ping Supabase: %v", err)
 		return fmt.Errorf("unable to ping Supabase: %v", err)
@@ -58,6 +59,11 @@ func InitSupabase() error {
 	return nil
 }
 
+// Supabaseの接続URLを環境変数 SUPABASE_URL から取得する
+func databaseURL() string {
+	return os.Getenv("SUPABASE_URL") + "?sslmode=require"
+}
+
 // Supabaseのコネクションプールをクローズ。
 // この関数はアプリケーションのシャットダウン時に呼び出されることを想定する。
 func ClosePool() {
@@ -73,7 +79,12 @@ func ClosePool() {
 func TestQuery() error {
 	logger.InfoLog.Println("Testing query...")
 	query := `SELECT 1`
-	rows, err := Pool.Query(Ctx, query)
+
+	// クエリのタイムアウトを設定
+	ctx, cancel := WithQueryTimeout(context.Background())
+	defer cancel()
+
+	rows, err := Pool.Query(ctx, query)
 	if err != nil {
 		logger.ErrorLog.Printf("Failed to test query: %v", err)
 		return err
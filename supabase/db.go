@@ -0,0 +1,21 @@
+package supabase
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// リポジトリが利用するデータベース操作のインターフェース
+// *pgxpool.Pool はこのインターフェースを満たすため、そのままリポジトリへ注入できる。
+type DB interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// *pgxpool.Pool が DB インターフェースを満たすことをコンパイル時に確認
+var _ DB = (*pgxpool.Pool)(nil)
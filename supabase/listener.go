@@ -0,0 +1,132 @@
+package supabase
+
+import (
+	"backend/logger"
+	"context"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// 再接続までの待ち時間(失敗するたびに2倍にし、上限で止める)
+const (
+	minListenBackoff = time.Second
+	maxListenBackoff = 30 * time.Second
+)
+
+// 変更通知の受信を処理する
+type ChangeHandler interface {
+	// 通知を受信した(table は書き込まれたテーブル)
+	OnChange(table string)
+	// LISTEN を開始した(切断中の通知は取りこぼしている)
+	OnConnected()
+	// 接続が切れた
+	OnDisconnected()
+}
+
+// LISTEN に使うコネクション
+// *pgx.Conn はこのインターフェースを満たす。
+type listenConn interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	WaitForNotification(ctx context.Context) (*pgconn.Notification, error)
+	Close(ctx context.Context) error
+}
+
+// 変更通知のリスナー
+type ChangeListener struct {
+	connect    func(ctx context.Context) (listenConn, error)
+	handler    ChangeHandler
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// 変更通知のリスナーを生成する
+// 通知の待ち受けでコネクションを占有するため、コネクションプールとは別の専用コネクションを使用する。
+func NewChangeListener(handler ChangeHandler) *ChangeListener {
+	return &ChangeListener{
+		connect:    connectListenConn,
+		handler:    handler,
+		minBackoff: minListenBackoff,
+		maxBackoff: maxListenBackoff,
+	}
+}
+
+// LISTEN 用のコネクションを接続する
+func connectListenConn(ctx context.Context) (listenConn, error) {
+	config, err := pgx.ParseConfig(databaseURL())
+	if err != nil {
+		return nil, err
+	}
+	config.PreferSimpleProtocol = true
+
+	// 接続にはタイムアウトを設定する
+	ctx, cancel := WithQueryTimeout(ctx)
+	defer cancel()
+	return pgx.ConnectConfig(ctx, config)
+}
+
+// ctx がキャンセルされるまで変更通知を受信する
+// 接続が切れた場合は待ち時間を延ばしながら再接続する。goroutine として起動すること。
+func (l *ChangeListener) Run(ctx context.Context) {
+	logger.InfoLog.Printf("Change listener started (channel: %s)", ChangeChannel)
+
+	backoff := l.minBackoff
+	for {
+		connected, err := l.listen(ctx)
+		if ctx.Err() != nil {
+			logger.InfoLog.Println("Change listener stopped")
+			return
+		}
+		if connected {
+			// 一度 LISTEN できた場合は待ち時間を戻す
+			l.handler.OnDisconnected()
+			backoff = l.minBackoff
+		}
+		logger.ErrorLog.Printf("Change listener disconnected: %v (reconnecting in %s)", err, backoff)
+
+		select {
+		case <-ctx.Done():
+			logger.InfoLog.Println("Change listener stopped")
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > l.maxBackoff {
+			backoff = l.maxBackoff
+		}
+	}
+}
+
+// 接続して LISTEN を開始し、切断されるまで通知を処理する
+// LISTEN を開始できたかどうかと、切断の原因のエラーを返す。
+func (l *ChangeListener) listen(ctx context.Context) (bool, error) {
+	conn, err := l.connect(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		closeCtx, cancel := WithQueryTimeout(context.Background())
+		defer cancel()
+		conn.Close(closeCtx)
+	}()
+
+	listenCtx, cancel := WithQueryTimeout(ctx)
+	_, err = conn.Exec(listenCtx, "LISTEN "+ChangeChannel)
+	cancel()
+	if err != nil {
+		return false, err
+	}
+
+	logger.InfoLog.Printf("Listening on %s", ChangeChannel)
+	l.handler.OnConnected()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+		l.handler.OnChange(notification.Payload)
+	}
+}
@@ -0,0 +1,128 @@
+package supabase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+// 指定された通知を返した後に切断されるコネクション
+type fakeListenConn struct {
+	listenErr     error
+	notifications []string
+}
+
+func (c *fakeListenConn) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return nil, c.listenErr
+}
+
+func (c *fakeListenConn) WaitForNotification(ctx context.Context) (*pgconn.Notification, error) {
+	if len(c.notifications) == 0 {
+		return nil, errors.New("connection closed")
+	}
+	payload := c.notifications[0]
+	c.notifications = c.notifications[1:]
+	return &pgconn.Notification{Channel: ChangeChannel, Payload: payload}, nil
+}
+
+func (c *fakeListenConn) Close(ctx context.Context) error {
+	return nil
+}
+
+// 受け取ったイベントを記録するハンドラー
+type recordingHandler struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (h *recordingHandler) record(event string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+}
+
+func (h *recordingHandler) OnChange(table string) { h.record("change:" + table) }
+func (h *recordingHandler) OnConnected()          { h.record("connected") }
+func (h *recordingHandler) OnDisconnected()       { h.record("disconnected") }
+
+func TestChangeListener_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 接続失敗 → LISTEN 失敗 → 通知を受信して切断 → 通知を受信して切断 の順に接続する
+	conns := []struct {
+		conn *fakeListenConn
+		err  error
+	}{
+		{err: errors.New("connection refused")},
+		{conn: &fakeListenConn{listenErr: errors.New("listen failed")}},
+		{conn: &fakeListenConn{notifications: []string{ChangeBlogs, ChangeComments}}},
+		{conn: &fakeListenConn{notifications: []string{ChangeBlogLikes}}},
+	}
+	var backoffs []time.Time
+	handler := &recordingHandler{}
+	listener := &ChangeListener{
+		connect: func(ctx context.Context) (listenConn, error) {
+			backoffs = append(backoffs, time.Now())
+			if len(conns) == 0 {
+				// すべて使い切ったら停止する
+				cancel()
+				return nil, ctx.Err()
+			}
+			next := conns[0]
+			conns = conns[1:]
+			if next.err != nil {
+				return nil, next.err
+			}
+			return next.conn, nil
+		},
+		handler:    handler,
+		minBackoff: time.Millisecond,
+		maxBackoff: 4 * time.Millisecond,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		listener.Run(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("listener did not stop")
+	}
+
+	assert.Equal(t, []string{
+		"connected",
+		"change:" + ChangeBlogs,
+		"change:" + ChangeComments,
+		"disconnected",
+		"connected",
+		"change:" + ChangeBlogLikes,
+		"disconnected",
+	}, handler.events)
+	assert.Len(t, backoffs, 5)
+}
+
+func TestChangeListener_Run_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// 停止済みの場合は再接続を待たずに終了する
+	handler := &recordingHandler{}
+	listener := &ChangeListener{
+		connect: func(ctx context.Context) (listenConn, error) {
+			return nil, ctx.Err()
+		},
+		handler:    handler,
+		minBackoff: time.Hour,
+		maxBackoff: time.Hour,
+	}
+	listener.Run(ctx)
+	assert.Empty(t, handler.events)
+}
@@ -0,0 +1,54 @@
+package supabase
+
+import (
+	"backend/logger"
+	"context"
+	"time"
+
+	"github.com/jackc/pgconn"
+)
+
+// 書き込みを他のインスタンスへ通知するチャネル
+const ChangeChannel = "cache_invalidation"
+
+// 通知のペイロード(書き込んだテーブル)
+const (
+	ChangeBlogs      = "blogs"
+	ChangeBlogLikes  = "blogs_likes"
+	ChangeComments   = "comments"
+	ChangeCategories = "categories"
+	ChangeTags       = "tags"
+)
+
+// 通知の発行に使うデータベース操作
+// DB(コネクションプール)と pgx.Tx のどちらも満たす。
+type Execer interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+}
+
+// テーブルへの書き込みを ChangeChannel に通知する
+// トランザクション内で呼び出した場合、通知はコミット時に送られる(ロールバック時は送られない)。
+// 通知に失敗しても書き込み自体は成功しているため、エラーは返さずログのみ出力する。
+// 受信側は通知を取りこぼした場合に備え、キャッシュの有効期間で整合性を保つ。
+// 書き込み後にリクエストが切断・タイムアウトしても通知は送るよう、呼び出し元のキャンセルは引き継がず、クエリのタイムアウトのみを設定する。
+func NotifyChange(ctx context.Context, db Execer, table string) {
+	ctx, cancel := WithQueryTimeout(withoutCancel{ctx})
+	defer cancel()
+
+	if _, err := db.Exec(ctx, `SELECT pg_notify($1, $2)`, ChangeChannel, table); err != nil {
+		logger.ErrorLog.Printf("Failed to notify change of %s: %v", table, err)
+	}
+}
+
+// 呼び出し元の値のみを引き継ぎ、キャンセル・期限は引き継がないコンテキスト(context.WithoutCancel 相当)
+type withoutCancel struct {
+	parent context.Context
+}
+
+func (withoutCancel) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (withoutCancel) Done() <-chan struct{} { return nil }
+
+func (withoutCancel) Err() error { return nil }
+
+func (c withoutCancel) Value(key interface{}) interface{} { return c.parent.Value(key) }
@@ -0,0 +1,37 @@
+package supabase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+// 実行されたクエリのコンテキストを記録するデータベース操作
+type recordingExecer struct {
+	ctxErr      error
+	hasDeadline bool
+	args        []interface{}
+}
+
+func (e *recordingExecer) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	e.ctxErr = ctx.Err()
+	_, e.hasDeadline = ctx.Deadline()
+	e.args = args
+	return nil, nil
+}
+
+func TestNotifyChange_CanceledRequest(t *testing.T) {
+	// 書き込み後にリクエストがキャンセルされた場合
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	db := &recordingExecer{}
+	NotifyChange(ctx, db, ChangeBlogs)
+
+	// キャンセルを引き継がず、クエリのタイムアウトを設定して通知する
+	assert.NoError(t, db.ctxErr)
+	assert.True(t, db.hasDeadline)
+	assert.Equal(t, []interface{}{ChangeChannel, ChangeBlogs}, db.args)
+}
@@ -0,0 +1,12 @@
+package supabase
+
+import (
+	"backend/config"
+	"context"
+)
+
+// クエリ単位のタイムアウトを設定したコンテキストを返す
+// 呼び出し元のコンテキスト(リクエストのコンテキスト)がキャンセルされた場合もクエリは中断される。
+func WithQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, config.QueryTimeout())
+}
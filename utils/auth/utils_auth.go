@@ -0,0 +1,90 @@
+package utils_auth
+
+import (
+	"backend/config"
+	"backend/models"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+)
+
+// アクセストークンを保存するクッキーの名前
+const TokenCookieName = "token"
+
+// 検証済みのクレームを保存するコンテキストのキー
+const principalKey = "auth.principal"
+
+// Authorization ヘッダーのスキーム
+const bearerPrefix = "Bearer "
+
+// トークンが不正または期限切れの場合のエラー
+var ErrInvalidToken = errors.New("token expired or invalid")
+
+// アクセストークンを検証し、クレームを返す
+// HMAC以外の署名アルゴリズム・期限切れのトークンは不正とする。
+func ParseToken(tokenString string) (*models.Claims, error) {
+	claims := &models.Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return config.JwtKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// トークンの有効期限をチェック (Unixタイムスタンプとして比較)
+	expirationTime := time.Unix(claims.ExpiresAt, 0)
+	if !token.Valid || claims.UserID == "" || expirationTime.Before(time.Now()) {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// リクエストからアクセストークンを取得する
+// Authorization: Bearer ヘッダーを優先し、なければクッキーから取得する。見つからない場合は空文字を返す。
+func TokenFromRequest(c echo.Context) string {
+	if header := c.Request().Header.Get(echo.HeaderAuthorization); strings.HasPrefix(header, bearerPrefix) {
+		return strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix))
+	}
+	if cookie, err := c.Cookie(TokenCookieName); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// 検証済みのクレームをリクエストのコンテキストに保存する
+func SetPrincipal(c echo.Context, claims *models.Claims) {
+	c.Set(principalKey, claims)
+}
+
+// ログイン中のユーザーのクレームを取得する
+// 認証ミドルウェアを通過していない場合は false を返す。
+func Principal(c echo.Context) (*models.Claims, bool) {
+	claims, ok := c.Get(principalKey).(*models.Claims)
+	return claims, ok && claims != nil
+}
+
+// ログイン中のユーザーIDを取得する
+func UserId(c echo.Context) (string, bool) {
+	claims, ok := Principal(c)
+	if !ok {
+		return "", false
+	}
+	return claims.UserID, true
+}
+
+// 未認証のレスポンスを返す
+// トークンがない・不正・期限切れのいずれの場合も同じ内容とする。
+func UnauthorizedResponse(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+	return c.JSON(http.StatusUnauthorized, map[string]string{
+		"error": "Unauthorized",
+	})
+}
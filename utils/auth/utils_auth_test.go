@@ -0,0 +1,112 @@
+package utils_auth
+
+import (
+	"backend/config"
+	"backend/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// テスト用のアクセストークンを作成する
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, userId string, expiresAt time.Time) string {
+	claims := &models.Claims{
+		UserID: userId,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: expiresAt.Unix(),
+		},
+	}
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestParseToken(t *testing.T) {
+	valid := signToken(t, jwt.SigningMethodHS256, config.JwtKey, "user-1", time.Now().Add(time.Minute))
+	claims, err := ParseToken(valid)
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", claims.UserID)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"期限切れ", signToken(t, jwt.SigningMethodHS256, config.JwtKey, "user-1", time.Now().Add(-time.Minute))},
+		{"異なる鍵で署名", signToken(t, jwt.SigningMethodHS256, []byte("other-key"), "user-1", time.Now().Add(time.Minute))},
+		{"署名なし", signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "user-1", time.Now().Add(time.Minute))},
+		{"ユーザーIDなし", signToken(t, jwt.SigningMethodHS256, config.JwtKey, "", time.Now().Add(time.Minute))},
+		{"不正な形式", "not-a-token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := ParseToken(tt.token)
+			assert.Error(t, err)
+			assert.Nil(t, claims)
+		})
+	}
+}
+
+func TestTokenFromRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		cookie   string
+		expected string
+	}{
+		{"Bearerヘッダー", "Bearer header-token", "", "header-token"},
+		{"クッキー", "", "cookie-token", "cookie-token"},
+		{"ヘッダーを優先", "Bearer header-token", "cookie-token", "header-token"},
+		{"Bearer以外のスキームは無視", "Basic dXNlcjpwYXNz", "cookie-token", "cookie-token"},
+		{"どちらもない", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.header)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: TokenCookieName, Value: tt.cookie})
+			}
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			assert.Equal(t, tt.expected, TokenFromRequest(c))
+		})
+	}
+}
+
+func TestPrincipal(t *testing.T) {
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+
+	// 認証ミドルウェアを通過していない場合
+	_, ok := Principal(c)
+	assert.False(t, ok)
+	userId, ok := UserId(c)
+	assert.False(t, ok)
+	assert.Empty(t, userId)
+
+	SetPrincipal(c, &models.Claims{UserID: "user-1"})
+	claims, ok := Principal(c)
+	assert.True(t, ok)
+	assert.Equal(t, "user-1", claims.UserID)
+	userId, ok = UserId(c)
+	assert.True(t, ok)
+	assert.Equal(t, "user-1", userId)
+}
+
+func TestUnauthorizedResponse(t *testing.T) {
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+	assert.NoError(t, UnauthorizedResponse(c))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "Bearer", rec.Header().Get(echo.HeaderWWWAuthenticate))
+	assert.JSONEq(t, `{"error":"Unauthorized"}`, rec.Body.String())
+}
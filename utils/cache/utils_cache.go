@@ -0,0 +1,239 @@
+package utils_cache
+
+import (
+	"backend/models"
+	"sync"
+	"time"
+)
+
+// キャッシュの名前空間
+const (
+	NamespaceBlogs      = "blogs"      // ブログ一覧
+	NamespacePopular    = "popular"    // 人気のブログ
+	NamespaceTags       = "tags"       // タグ一覧
+	NamespaceCategories = "categories" // カテゴリ一覧
+)
+
+// キャッシュする件数の上限(全名前空間の合計)
+const maxEntries = 1024
+
+// 読み取り結果のインメモリキャッシュ
+// エントリは名前空間ごとに管理し、書き込み時に名前空間単位で無効化する。
+// nil の場合はキャッシュせず、毎回読み込む。
+type Cache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	now        func() time.Time
+	namespaces map[string]*namespace
+	size       int
+}
+
+// 名前空間ごとのエントリと統計
+type namespace struct {
+	entries      map[string]entry
+	generation   uint64    // 無効化のたびに増やす
+	lastModified time.Time // 最後に無効化した日時
+	stats        models.CacheStats
+}
+
+type entry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// エントリの有効期間を指定してキャッシュを生成する
+func New(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:        ttl,
+		now:        time.Now,
+		namespaces: make(map[string]*namespace),
+	}
+}
+
+// キャッシュから値を取得し、ない場合は load で読み込んでキャッシュする
+// load がエラーを返した場合はキャッシュしない。
+// 読み込み中に名前空間が無効化された場合は、古い値の可能性があるためキャッシュしない。
+func (c *Cache) Load(name, key string, load func() (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return load()
+	}
+
+	c.mu.Lock()
+	ns := c.namespace(name)
+	if e, ok := ns.entries[key]; ok {
+		if c.now().Before(e.expiresAt) {
+			ns.stats.Hits++
+			c.mu.Unlock()
+			return e.value, nil
+		}
+		c.remove(ns, key)
+	}
+	ns.stats.Misses++
+	generation := ns.generation
+	c.mu.Unlock()
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ns.generation == generation {
+		c.put(ns, key, value)
+	}
+	return value, nil
+}
+
+// 指定された名前空間のエントリをすべて無効化する
+func (c *Cache) Invalidate(names ...string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for _, name := range names {
+		ns := c.namespace(name)
+		for key := range ns.entries {
+			c.remove(ns, key)
+		}
+		ns.generation++
+		ns.lastModified = now
+		ns.stats.Invalidations++
+	}
+}
+
+// すべての名前空間のエントリを無効化する
+func (c *Cache) InvalidateAll() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	names := make([]string, 0, len(c.namespaces))
+	for name := range c.namespaces {
+		names = append(names, name)
+	}
+	c.mu.Unlock()
+
+	c.Invalidate(names...)
+}
+
+// 以降に追加するエントリの有効期間を変更する
+func (c *Cache) SetTTL(ttl time.Duration) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// 指定された名前空間(省略時はすべて)を最後に無効化した日時を返す
+// 起動後に一度も無効化していない場合は、キャッシュの生成日時を返す。
+func (c *Cache) LastModified(names ...string) time.Time {
+	if c == nil {
+		return time.Time{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(names) == 0 {
+		for name := range c.namespaces {
+			names = append(names, name)
+		}
+	}
+	var lastModified time.Time
+	for _, name := range names {
+		if t := c.namespace(name).lastModified; t.After(lastModified) {
+			lastModified = t
+		}
+	}
+	return lastModified
+}
+
+// 名前空間ごとのヒット・ミスなどの統計を返す
+func (c *Cache) Stats() map[string]models.CacheStats {
+	stats := map[string]models.CacheStats{}
+	if c == nil {
+		return stats
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name, ns := range c.namespaces {
+		s := ns.stats
+		s.Entries = len(ns.entries)
+		if total := s.Hits + s.Misses; total > 0 {
+			s.HitRate = float64(s.Hits) / float64(total)
+		}
+		stats[name] = s
+	}
+	return stats
+}
+
+// 名前空間を取得し、ない場合は作成する（呼び出し側でロックを取得すること）
+func (c *Cache) namespace(name string) *namespace {
+	ns, ok := c.namespaces[name]
+	if !ok {
+		ns = &namespace{
+			entries:      make(map[string]entry),
+			lastModified: c.now(),
+		}
+		c.namespaces[name] = ns
+	}
+	return ns
+}
+
+// エントリを追加する（呼び出し側でロックを取得すること）
+// 上限に達している場合は期限切れのエントリを削除し、それでも空きがない場合は任意のエントリを1件削除する。
+func (c *Cache) put(ns *namespace, key string, value interface{}) {
+	now := c.now()
+	if _, ok := ns.entries[key]; !ok {
+		if c.size >= maxEntries {
+			c.evictExpired(now)
+		}
+		if c.size >= maxEntries {
+			c.evictAny()
+		}
+		c.size++
+	}
+	ns.entries[key] = entry{value: value, expiresAt: now.Add(c.ttl)}
+}
+
+// 期限切れのエントリをすべて削除する（呼び出し側でロックを取得すること）
+func (c *Cache) evictExpired(now time.Time) {
+	for _, ns := range c.namespaces {
+		for key, e := range ns.entries {
+			if !now.Before(e.expiresAt) {
+				c.remove(ns, key)
+				ns.stats.Evictions++
+			}
+		}
+	}
+}
+
+// 任意のエントリを1件削除する（呼び出し側でロックを取得すること）
+func (c *Cache) evictAny() {
+	for _, ns := range c.namespaces {
+		for key := range ns.entries {
+			c.remove(ns, key)
+			ns.stats.Evictions++
+			return
+		}
+	}
+}
+
+// エントリを削除する（呼び出し側でロックを取得すること）
+func (c *Cache) remove(ns *namespace, key string) {
+	if _, ok := ns.entries[key]; ok {
+		delete(ns.entries, key)
+		c.size--
+	}
+}
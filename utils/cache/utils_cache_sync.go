@@ -0,0 +1,62 @@
+package utils_cache
+
+import (
+	"backend/logger"
+	"backend/supabase"
+	"time"
+)
+
+// 書き込まれたテーブルごとに無効化する名前空間
+// 各サービスが書き込み時に無効化する名前空間と揃えること。
+var changeNamespaces = map[string][]string{
+	supabase.ChangeBlogs:      {NamespaceBlogs, NamespacePopular, NamespaceTags, NamespaceCategories},
+	supabase.ChangeBlogLikes:  {NamespaceBlogs, NamespacePopular},
+	supabase.ChangeComments:   {NamespaceBlogs},
+	supabase.ChangeCategories: {NamespaceCategories, NamespaceBlogs},
+	supabase.ChangeTags:       {NamespaceTags, NamespaceBlogs},
+}
+
+// 他のインスタンスの書き込み通知を受けてキャッシュを無効化する
+// supabase.ChangeHandler を実装する。
+// 通知を受信できない間は書き込みを検知できないため、短い有効期間に切り替える。
+type Sync struct {
+	cache       *Cache
+	ttl         time.Duration
+	fallbackTTL time.Duration
+}
+
+// 通知を受信するまでは短い有効期間を使用する Sync を生成する
+func NewSync(cache *Cache, ttl, fallbackTTL time.Duration) *Sync {
+	cache.SetTTL(fallbackTTL)
+	return &Sync{
+		cache:       cache,
+		ttl:         ttl,
+		fallbackTTL: fallbackTTL,
+	}
+}
+
+// 書き込まれたテーブルに対応する名前空間を無効化する
+// 未知のテーブルの場合は、すべての名前空間を無効化する。
+func (s *Sync) OnChange(table string) {
+	names, ok := changeNamespaces[table]
+	if !ok {
+		logger.ErrorLog.Printf("Unknown change notification: %q", table)
+		s.cache.InvalidateAll()
+		return
+	}
+	s.cache.Invalidate(names...)
+}
+
+// 受信を開始したら通常の有効期間に戻す
+// 切断中に書き込まれた可能性があるため、すべての名前空間を無効化する。
+func (s *Sync) OnConnected() {
+	s.cache.SetTTL(s.ttl)
+	s.cache.InvalidateAll()
+}
+
+// 受信が止まったら短い有効期間に切り替える
+// 既存のエントリは通常の有効期間で追加されているため、すべての名前空間を無効化する。
+func (s *Sync) OnDisconnected() {
+	s.cache.SetTTL(s.fallbackTTL)
+	s.cache.InvalidateAll()
+}
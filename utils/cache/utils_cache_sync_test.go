@@ -0,0 +1,84 @@
+package utils_cache
+
+import (
+	"backend/supabase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSync_OnChange(t *testing.T) {
+	tests := []struct {
+		table       string
+		invalidated []string
+	}{
+		{supabase.ChangeBlogs, []string{NamespaceBlogs, NamespacePopular, NamespaceTags, NamespaceCategories}},
+		{supabase.ChangeBlogLikes, []string{NamespaceBlogs, NamespacePopular}},
+		{supabase.ChangeComments, []string{NamespaceBlogs}},
+		{supabase.ChangeCategories, []string{NamespaceBlogs, NamespaceCategories}},
+		{supabase.ChangeTags, []string{NamespaceBlogs, NamespaceTags}},
+		{"unknown", []string{NamespaceBlogs, NamespacePopular, NamespaceTags, NamespaceCategories}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			c := New(time.Minute)
+			s := NewSync(c, time.Minute, time.Second)
+			for _, name := range []string{NamespaceBlogs, NamespacePopular, NamespaceTags, NamespaceCategories} {
+				_, _ = c.Load(name, "", func() (interface{}, error) { return "value", nil })
+			}
+
+			s.OnChange(tt.table)
+
+			for name, stats := range c.Stats() {
+				if contains(tt.invalidated, name) {
+					assert.Equal(t, uint64(1), stats.Invalidations, name)
+					assert.Equal(t, 0, stats.Entries, name)
+				} else {
+					assert.Equal(t, uint64(0), stats.Invalidations, name)
+					assert.Equal(t, 1, stats.Entries, name)
+				}
+			}
+		})
+	}
+}
+
+func TestSync_Connection(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New(time.Minute)
+	c.now = func() time.Time { return now }
+	load, calls := counter("value")
+
+	// 受信を開始するまでは短い有効期間を使用する
+	s := NewSync(c, time.Minute, time.Second)
+	_, _ = c.Load(NamespaceBlogs, "key", load)
+	now = now.Add(time.Second)
+	_, _ = c.Load(NamespaceBlogs, "key", load)
+	assert.Equal(t, 2, *calls)
+
+	// 受信を開始したらすべて無効化し、通常の有効期間に戻す
+	s.OnConnected()
+	_, _ = c.Load(NamespaceBlogs, "key", load)
+	assert.Equal(t, 3, *calls)
+	now = now.Add(59 * time.Second)
+	_, _ = c.Load(NamespaceBlogs, "key", load)
+	assert.Equal(t, 3, *calls)
+
+	// 切断されたらすべて無効化し、短い有効期間に切り替える
+	s.OnDisconnected()
+	_, _ = c.Load(NamespaceBlogs, "key", load)
+	assert.Equal(t, 4, *calls)
+	now = now.Add(time.Second)
+	_, _ = c.Load(NamespaceBlogs, "key", load)
+	assert.Equal(t, 5, *calls)
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package utils_cache
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 呼び出し回数を数える読み込み関数を返す
+func counter(value interface{}) (func() (interface{}, error), *int) {
+	calls := 0
+	return func() (interface{}, error) {
+		calls++
+		return value, nil
+	}, &calls
+}
+
+func TestCache_Load(t *testing.T) {
+	c := New(time.Minute)
+	load, calls := counter("value")
+
+	// 1回目は読み込み、2回目はキャッシュから返す
+	for i := 0; i < 2; i++ {
+		v, err := c.Load(NamespaceTags, "", load)
+		assert.NoError(t, err)
+		assert.Equal(t, "value", v)
+	}
+	assert.Equal(t, 1, *calls)
+
+	// 別のキーは別に読み込む
+	_, err := c.Load(NamespaceTags, "other", load)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *calls)
+
+	stats := c.Stats()[NamespaceTags]
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(2), stats.Misses)
+	assert.Equal(t, 2, stats.Entries)
+	assert.InDelta(t, 1.0/3.0, stats.HitRate, 1e-9)
+}
+
+func TestCache_Load_Expired(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New(time.Minute)
+	c.now = func() time.Time { return now }
+	load, calls := counter("value")
+
+	_, _ = c.Load(NamespaceBlogs, "key", load)
+	now = now.Add(59 * time.Second)
+	_, _ = c.Load(NamespaceBlogs, "key", load)
+	assert.Equal(t, 1, *calls)
+
+	// 有効期間を過ぎた場合は読み込み直す
+	now = now.Add(time.Second)
+	_, _ = c.Load(NamespaceBlogs, "key", load)
+	assert.Equal(t, 2, *calls)
+}
+
+func TestCache_Load_Error(t *testing.T) {
+	c := New(time.Minute)
+	calls := 0
+	load := func() (interface{}, error) {
+		calls++
+		return nil, errors.New("failed")
+	}
+
+	// エラーはキャッシュしない
+	for i := 0; i < 2; i++ {
+		v, err := c.Load(NamespaceBlogs, "key", load)
+		assert.EqualError(t, err, "failed")
+		assert.Nil(t, v)
+	}
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 0, c.Stats()[NamespaceBlogs].Entries)
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New(time.Minute)
+	c.now = func() time.Time { return now }
+	blogs, blogCalls := counter("blogs")
+	tags, tagCalls := counter("tags")
+
+	_, _ = c.Load(NamespaceBlogs, "key", blogs)
+	_, _ = c.Load(NamespaceTags, "", tags)
+	assert.Equal(t, now, c.LastModified())
+
+	// 指定した名前空間のみ無効化する
+	now = now.Add(time.Second)
+	c.Invalidate(NamespaceBlogs)
+	_, _ = c.Load(NamespaceBlogs, "key", blogs)
+	_, _ = c.Load(NamespaceTags, "", tags)
+	assert.Equal(t, 2, *blogCalls)
+	assert.Equal(t, 1, *tagCalls)
+
+	assert.Equal(t, now, c.LastModified())
+	assert.Equal(t, now, c.LastModified(NamespaceBlogs))
+	assert.Equal(t, now.Add(-time.Second), c.LastModified(NamespaceTags))
+	assert.Equal(t, uint64(1), c.Stats()[NamespaceBlogs].Invalidations)
+}
+
+func TestCache_Invalidate_DuringLoad(t *testing.T) {
+	c := New(time.Minute)
+
+	// 読み込み中に無効化された場合、読み込んだ値は返すがキャッシュしない
+	v, err := c.Load(NamespaceBlogs, "key", func() (interface{}, error) {
+		c.Invalidate(NamespaceBlogs)
+		return "stale", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "stale", v)
+
+	load, calls := counter("fresh")
+	v, _ = c.Load(NamespaceBlogs, "key", load)
+	assert.Equal(t, "fresh", v)
+	assert.Equal(t, 1, *calls)
+}
+
+func TestCache_MaxEntries(t *testing.T) {
+	c := New(time.Minute)
+	load, _ := counter("value")
+
+	// 上限を超えても件数は増えない
+	for i := 0; i < maxEntries+10; i++ {
+		_, _ = c.Load(NamespaceBlogs, fmt.Sprint(i), load)
+	}
+	stats := c.Stats()[NamespaceBlogs]
+	assert.Equal(t, maxEntries, stats.Entries)
+	assert.Equal(t, uint64(10), stats.Evictions)
+	assert.Equal(t, maxEntries, c.size)
+}
+
+func TestCache_Nil(t *testing.T) {
+	var c *Cache
+	load, calls := counter("value")
+
+	// nil の場合はキャッシュせず毎回読み込む
+	for i := 0; i < 2; i++ {
+		v, err := c.Load(NamespaceBlogs, "key", load)
+		assert.NoError(t, err)
+		assert.Equal(t, "value", v)
+	}
+	assert.Equal(t, 2, *calls)
+
+	c.Invalidate(NamespaceBlogs)
+	assert.True(t, c.LastModified().IsZero())
+	assert.Empty(t, c.Stats())
+}
@@ -3,11 +3,10 @@ package utils_cookie
 import (
 	"backend/config"
 	"backend/models"
-	"errors"
+	utils_auth "backend/utils/auth"
 	"net/http"
 	"time"
 
-	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
 )
 
@@ -31,7 +30,7 @@ func (u *CookieUtilsImpl) GetAuthCookieValue(c echo.Context, tokenName string) (
 
 // GetAuthCookieExpirationTime - 認証用のCookieの有効期限を取得
 func (u *CookieUtilsImpl) GetAuthCookieExpirationTime() time.Time {
-	return time.Now().Add(1 * time.Hour)
+	return time.Now().Add(config.AccessTokenTTL())
 }
 
 // ExistsAuthCookie - 認証用のCookieが存在するか確認
@@ -42,20 +41,5 @@ func (u *CookieUtilsImpl) ExistsAuthCookie(c echo.Context, tokenName string) boo
 
 // VerifyToken - JWTトークンを検証
 func (u *CookieUtilsImpl) VerifyToken(c echo.Context, tokenString string) (*models.Claims, error) {
-	claims := &models.Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return config.JwtKey, nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	// トークンの有効期限をチェック (Unixタイムスタンプとして比較)
-	expirationTime := time.Unix(claims.ExpiresAt, 0)
-	if !token.Valid || expirationTime.Before(time.Now()) {
-		return nil, errors.New("token expired or invalid")
-	}
-
-	return claims, nil
+	return utils_auth.ParseToken(tokenString)
 }
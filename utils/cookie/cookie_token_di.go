@@ -13,7 +13,7 @@ import (
 
 // CreateToken - JWTトークンを作成
 func (u *CookieUtilsImpl) CreateToken(user *models.UserData) (string, error) {
-	// トークンの有効期限を1時間に設定
+	// アクセストークンの有効期限を設定
 	expirationTime := u.GetAuthCookieExpirationTime()
 	// JWTトークンの作成
 	claims := &models.Claims{
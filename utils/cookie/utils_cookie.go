@@ -8,6 +8,12 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// リフレッシュトークン用のCookie
+const (
+	RefreshCookieName = "refresh_token"
+	refreshCookiePath = "/api/users"
+)
+
 // 認証用のCookieを追加
 func AddAuthCookie(c echo.Context, tokenString string, expirationTime time.Time) {
 	cookie := new(http.Cookie)
@@ -43,3 +49,40 @@ func DelAuthCookie(c echo.Context) {
 	}
 	c.SetCookie(cookie)
 }
+
+// リフレッシュトークン用のCookieを追加
+// トークンの更新・ログアウトでのみ使用するため、パスを /api/users に限定する。
+func AddRefreshCookie(c echo.Context, tokenString string, expirationTime time.Time) {
+	cookie := new(http.Cookie)
+	cookie.Name = RefreshCookieName
+	cookie.Value = tokenString
+	cookie.Expires = expirationTime
+	cookie.HttpOnly = true
+	cookie.Path = refreshCookiePath
+	if config.IsProduction {
+		cookie.Secure = true
+		cookie.SameSite = http.SameSiteNoneMode
+	} else {
+		cookie.Secure = false
+		cookie.SameSite = http.SameSiteLaxMode
+	}
+	c.SetCookie(cookie)
+}
+
+// リフレッシュトークン用のCookieを削除
+func DelRefreshCookie(c echo.Context) {
+	cookie := new(http.Cookie)
+	cookie.Name = RefreshCookieName
+	cookie.Value = ""
+	cookie.Expires = time.Unix(0, 0) // 有効期限を過去に設定して削除
+	cookie.HttpOnly = true
+	cookie.Path = refreshCookiePath
+	if config.IsProduction {
+		cookie.Secure = true
+		cookie.SameSite = http.SameSiteNoneMode
+	} else {
+		cookie.Secure = false
+		cookie.SameSite = http.SameSiteLaxMode
+	}
+	c.SetCookie(cookie)
+}
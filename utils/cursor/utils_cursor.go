@@ -0,0 +1,28 @@
+package utils_cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// カーソルを不透明な文字列にエンコードする
+func Encode(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Encodeで生成した文字列をデコードしてvに格納する
+func Decode(s string, v interface{}) error {
+	if s == "" {
+		return errors.New("empty cursor")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
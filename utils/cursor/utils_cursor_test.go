@@ -0,0 +1,42 @@
+package utils_cursor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+func TestEncodeDecode(t *testing.T) {
+	in := testCursor{
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC),
+		ID:        "e3b0c442-98fc-1c14-9afb-f4c8996fb924",
+	}
+
+	// エンコードした値がデコードで元に戻ること
+	s, err := Encode(in)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, s)
+
+	var out testCursor
+	err = Decode(s, &out)
+	assert.NoError(t, err)
+	assert.True(t, in.CreatedAt.Equal(out.CreatedAt))
+	assert.Equal(t, in.ID, out.ID)
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	var out testCursor
+
+	// 空文字
+	assert.Error(t, Decode("", &out))
+	// base64として不正
+	assert.Error(t, Decode("!!!", &out))
+	// JSONとして不正
+	assert.Error(t, Decode("bm90LWpzb24", &out))
+}
@@ -0,0 +1,64 @@
+package utils_etag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// レスポンスをJSONで返し、ETag・Last-Modified ヘッダーを付与する
+// ETag はレスポンスボディのハッシュから生成する。lastModified がゼロ値の場合は Last-Modified を付与しない。
+// リクエストの If-None-Match (未指定の場合は If-Modified-Since) から変更がないと判断できる場合は 304 を返す。
+func JSON(c echo.Context, status int, body interface{}, lastModified time.Time) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	header := c.Response().Header()
+	header.Set("ETag", etag)
+	header.Set("Cache-Control", "no-cache")
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if status == http.StatusOK && notModified(c.Request(), etag, lastModified) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.JSONBlob(status, data)
+}
+
+// 条件付きリクエストのヘッダーから、クライアントのキャッシュが最新か判定する
+func notModified(req *http.Request, etag string, lastModified time.Time) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+
+	// If-None-Match がある場合は If-Modified-Since を無視する
+	if ifNoneMatch := req.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if lastModified.IsZero() {
+		return false
+	}
+	ifModifiedSince, err := http.ParseTime(req.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	// HTTPの日時は秒単位のため、秒未満を切り捨てて比較する
+	return !lastModified.Truncate(time.Second).After(ifModifiedSince)
+}
@@ -0,0 +1,120 @@
+package utils_etag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSON(t *testing.T) {
+	lastModified := time.Date(2025, 1, 2, 3, 4, 5, 600, time.UTC)
+	body := map[string]string{"name": "go"}
+
+	// ETagを取得するため、条件なしで一度リクエストする
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+	assert.NoError(t, JSON(c, http.StatusOK, body, lastModified))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"name":"go"}`, rec.Body.String())
+	assert.Equal(t, "Thu, 02 Jan 2025 03:04:05 GMT", rec.Header().Get("Last-Modified"))
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+	etag := rec.Header().Get("ETag")
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+
+	tests := []struct {
+		name           string
+		method         string
+		header         map[string]string
+		body           interface{}
+		expectedStatus int
+	}{
+		{
+			name:           "If-None-Match が一致する場合は 304",
+			method:         http.MethodGet,
+			header:         map[string]string{"If-None-Match": etag},
+			body:           body,
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:           "弱いETag・複数指定でも一致すれば 304",
+			method:         http.MethodGet,
+			header:         map[string]string{"If-None-Match": `"other", W/` + etag},
+			body:           body,
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:           "内容が変わった場合は 200",
+			method:         http.MethodGet,
+			header:         map[string]string{"If-None-Match": etag},
+			body:           map[string]string{"name": "rust"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "If-Modified-Since が最終更新日時以降の場合は 304",
+			method:         http.MethodGet,
+			header:         map[string]string{"If-Modified-Since": "Thu, 02 Jan 2025 03:04:05 GMT"},
+			body:           body,
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:           "If-Modified-Since が最終更新日時より前の場合は 200",
+			method:         http.MethodGet,
+			header:         map[string]string{"If-Modified-Since": "Thu, 02 Jan 2025 03:04:04 GMT"},
+			body:           body,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "If-None-Match がある場合は If-Modified-Since を無視する",
+			method: http.MethodGet,
+			header: map[string]string{
+				"If-None-Match":     `"other"`,
+				"If-Modified-Since": "Thu, 02 Jan 2025 03:04:05 GMT",
+			},
+			body:           body,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "GET 以外は 304 を返さない",
+			method:         http.MethodPost,
+			header:         map[string]string{"If-None-Match": etag},
+			body:           body,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", nil)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, JSON(c, http.StatusOK, tt.body, lastModified))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+				assert.Equal(t, etag, rec.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestJSON_WithoutLastModified(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("If-Modified-Since", "Thu, 02 Jan 2025 03:04:05 GMT")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// 最終更新日時がない場合は Last-Modified を付与せず、If-Modified-Since では判定しない
+	assert.NoError(t, JSON(c, http.StatusOK, []string{}, time.Time{}))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Last-Modified"))
+}
@@ -0,0 +1,254 @@
+package utils_markdown
+
+import (
+	"backend/models"
+	utils_slug "backend/utils/slug"
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// 読了時間の算出に使う1分あたりの読書量
+const (
+	wordsPerMinute = 200 // 英単語
+	charsPerMinute = 500 // 日本語の文字
+)
+
+// レンダリング結果をキャッシュする件数
+const cacheSize = 512
+
+// Markdownを変換した結果
+type Document struct {
+	HTML        string                // サニタイズ済みのHTML
+	Toc         []models.BlogTocEntry // 見出しの目次
+	WordCount   int                   // 単語数(日本語は1文字を1語とする)
+	ReadingTime int                   // 読了時間(分)
+}
+
+// GFM(表・打ち消し線・自動リンク・タスクリスト)と脚注に対応したMarkdownパーサー
+// 生のHTMLは出力しない。表の配置はstyle属性ではサニタイズで除去されるため、align属性で出力する。
+var markdown = goldmark.New(
+	goldmark.WithExtensions(
+		extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+		extension.Strikethrough,
+		extension.Linkify,
+		extension.TaskList,
+		extension.Footnote,
+	),
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+)
+
+// 出力するHTMLの許可リスト
+// ユーザー投稿向けの既定のポリシーに、コードの言語・脚注・タスクリストの属性を加える。
+var policy = func() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^footnote-(ref|backref)$`)).OnElements("a")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^footnotes$`)).OnElements("div")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	return p
+}()
+
+// Markdownの本文をHTMLに変換し、目次・単語数・読了時間を求める
+// 同じ本文の変換結果はキャッシュし、再利用する。
+func Render(source string) Document {
+	if source == "" {
+		return Document{Toc: []models.BlogTocEntry{}}
+	}
+
+	key := sha256.Sum256([]byte(source))
+	if doc, ok := cache.get(key); ok {
+		return doc
+	}
+
+	doc := render([]byte(source))
+	cache.put(key, doc)
+	return doc
+}
+
+// キャッシュを使わずに変換する
+func render(source []byte) Document {
+	ctx := parser.NewContext(parser.WithIDs(&headingIDs{used: map[string]bool{}}))
+	root := markdown.Parser().Parse(text.NewReader(source), parser.WithContext(ctx))
+
+	var buf bytes.Buffer
+	if err := markdown.Renderer().Render(&buf, source, root); err != nil {
+		// メモリ上のバッファへの書き込みは失敗しないため、到達しない
+		buf.Reset()
+	}
+
+	doc := Document{
+		HTML: policy.Sanitize(buf.String()),
+		Toc:  []models.BlogTocEntry{},
+	}
+
+	var plain strings.Builder
+	_ = ast.Walk(root, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.Heading:
+			id, _ := node.AttributeString("id")
+			idBytes, _ := id.([]byte)
+			doc.Toc = append(doc.Toc, models.BlogTocEntry{
+				Level: node.Level,
+				ID:    string(idBytes),
+				Text:  nodeText(node, source),
+			})
+		case *ast.Text:
+			plain.Write(node.Segment.Value(source))
+			plain.WriteByte(' ')
+		case *ast.String:
+			plain.Write(node.Value)
+			plain.WriteByte(' ')
+		}
+		return ast.WalkContinue, nil
+	})
+
+	words, chars := countWords(plain.String())
+	doc.WordCount = words + chars
+	doc.ReadingTime = readingTime(words, chars)
+	return doc
+}
+
+// ノード配下のテキストを連結する
+func nodeText(n ast.Node, source []byte) string {
+	var b strings.Builder
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch node := c.(type) {
+		case *ast.Text:
+			b.Write(node.Segment.Value(source))
+			if node.SoftLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(node.Value)
+		default:
+			b.WriteString(nodeText(c, source))
+		}
+	}
+	return b.String()
+}
+
+// 英数字の単語数と、日本語(漢字・ひらがな・カタカナ)の文字数を数える
+// コードブロックは含めない。
+func countWords(s string) (words, chars int) {
+	inWord := false
+	for _, r := range s {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+			chars++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				words++
+			}
+			inWord = true
+		case r == '\'' || r == '-':
+			// 単語内のアポストロフィ・ハイフンは区切りにしない
+		default:
+			inWord = false
+		}
+	}
+	return words, chars
+}
+
+// 読了時間を分単位で求める(本文がある場合は最低1分)
+func readingTime(words, chars int) int {
+	if words == 0 && chars == 0 {
+		return 0
+	}
+	seconds := words*60/wordsPerMinute + chars*60/charsPerMinute
+	minutes := (seconds + 59) / 60
+	if minutes < 1 {
+		minutes = 1
+	}
+	return minutes
+}
+
+// 見出しのIDをスラッグと同じ規則で生成する
+// 同じ本文内で重複する場合は連番を付ける。
+type headingIDs struct {
+	used map[string]bool
+}
+
+func (s *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	base := utils_slug.Generate(string(value))
+	if base == utils_slug.Fallback {
+		base = "section"
+	}
+	id := base
+	for n := 2; s.used[id]; n++ {
+		id = utils_slug.WithSuffix(base, n)
+	}
+	s.used[id] = true
+	return []byte(id)
+}
+
+func (s *headingIDs) Put(value []byte) {
+	s.used[string(value)] = true
+}
+
+// 変換結果のLRUキャッシュ
+type renderCache struct {
+	mu    sync.Mutex
+	items map[[sha256.Size]byte]*list.Element
+	order *list.List
+}
+
+type cacheEntry struct {
+	key [sha256.Size]byte
+	doc Document
+}
+
+var cache = &renderCache{
+	items: map[[sha256.Size]byte]*list.Element{},
+	order: list.New(),
+}
+
+// キャッシュから変換結果を取得する
+// 呼び出し側で目次を変更してもキャッシュに影響しないよう、複製を返す。
+func (c *renderCache) get(key [sha256.Size]byte) (Document, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return Document{}, false
+	}
+	c.order.MoveToFront(e)
+	doc := e.Value.(*cacheEntry).doc
+	doc.Toc = append([]models.BlogTocEntry{}, doc.Toc...)
+	return doc, true
+}
+
+// 変換結果をキャッシュに追加し、上限を超えた場合は最も古いものを削除する
+func (c *renderCache) put(key [sha256.Size]byte, doc Document) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.items[key]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	doc.Toc = append([]models.BlogTocEntry{}, doc.Toc...)
+	c.items[key] = c.order.PushFront(&cacheEntry{key: key, doc: doc})
+	if c.order.Len() > cacheSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).key)
+	}
+}
@@ -0,0 +1,134 @@
+package utils_markdown
+
+import (
+	"backend/models"
+	"container/list"
+	"crypto/sha256"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender_HTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "見出しにIDを付ける",
+			source:   "# はじめに\n\n## Setup\n\n## Setup",
+			contains: []string{`<h1 id="hajimeni">はじめに</h1>`, `<h2 id="setup">`, `<h2 id="setup-2">`},
+		},
+		{
+			name:     "表",
+			source:   "| a | b |\n|:--|--:|\n| 1 | 2 |",
+			contains: []string{"<table>", `<th align="left">a</th>`, `<td align="right">2</td>`},
+		},
+		{
+			name:     "コードブロック",
+			source:   "```go\nfmt.Println(1)\n```",
+			contains: []string{`<pre><code class="language-go">fmt.Println(1)`},
+		},
+		{
+			name:     "脚注",
+			source:   "本文[^1]\n\n[^1]: 脚注",
+			contains: []string{`<a href="#fn:1" class="footnote-ref"`, `<div class="footnotes">`, `<li id="fn:1">`},
+		},
+		{
+			name:     "タスクリスト",
+			source:   "- [x] done",
+			contains: []string{`<input checked="" disabled="" type="checkbox">`},
+		},
+		{
+			name:     "生のHTMLは出力しない",
+			source:   "<script>alert(1)</script>\n\n<img src=x onerror=alert(1)>",
+			excludes: []string{"<script", "onerror", "<img"},
+		},
+		{
+			name:     "危険なURLは除去する",
+			source:   "[link](javascript:alert(1))",
+			excludes: []string{"javascript:"},
+		},
+		{
+			name:     "リンクにnofollowを付ける",
+			source:   "https://example.com",
+			contains: []string{`<a href="https://example.com" rel="nofollow">`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := Render(tt.source)
+			for _, s := range tt.contains {
+				assert.Contains(t, doc.HTML, s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, doc.HTML, s)
+			}
+		})
+	}
+}
+
+func TestRender_Toc(t *testing.T) {
+	doc := Render("# Go `入門`\n\n本文\n\n## インストール\n\n### 日本語\n")
+
+	assert.Equal(t, []models.BlogTocEntry{
+		{Level: 1, ID: "go", Text: "Go 入門"},
+		{Level: 2, ID: "insutooru", Text: "インストール"},
+		{Level: 3, ID: "section", Text: "日本語"},
+	}, doc.Toc)
+}
+
+func TestRender_WordCountAndReadingTime(t *testing.T) {
+	tests := []struct {
+		name            string
+		source          string
+		wantWordCount   int
+		wantReadingTime int
+	}{
+		{"空の本文", "", 0, 0},
+		{"英語", "Hello, world! It's a well-known example.", 6, 1},
+		{"日本語は1文字を1語とする", "日本語の本文", 6, 1},
+		{"コードブロックは含めない", "word\n\n```\nignored code here\n```", 1, 1},
+		{"英語400語は2分", strings.Repeat("word ", 400), 400, 2},
+		{"日本語1000文字は2分", strings.Repeat("あ", 1000), 1000, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := Render(tt.source)
+			assert.Equal(t, tt.wantWordCount, doc.WordCount)
+			assert.Equal(t, tt.wantReadingTime, doc.ReadingTime)
+		})
+	}
+}
+
+func TestRender_Cache(t *testing.T) {
+	source := "# Cached\n\n本文"
+	first := Render(source)
+
+	// 返された目次を変更してもキャッシュに影響しないこと
+	first.Toc[0].Text = "changed"
+	second := Render(source)
+	assert.Equal(t, "Cached", second.Toc[0].Text)
+	assert.Equal(t, first.HTML, second.HTML)
+}
+
+func TestRenderCache_Evict(t *testing.T) {
+	c := &renderCache{items: map[[sha256.Size]byte]*list.Element{}, order: list.New()}
+	key := func(i int) [sha256.Size]byte { return sha256.Sum256([]byte(strconv.Itoa(i))) }
+
+	// 上限を超えた場合は最も古いものから削除すること
+	for i := 0; i <= cacheSize; i++ {
+		c.put(key(i), Document{})
+	}
+	_, ok := c.get(key(0))
+	assert.False(t, ok)
+	_, ok = c.get(key(cacheSize))
+	assert.True(t, ok)
+	assert.Equal(t, cacheSize, c.order.Len())
+}
@@ -0,0 +1,145 @@
+package utils_password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 新しく生成するハッシュの Argon2id のパラメータ(OWASP の推奨値)
+const (
+	argon2Memory  = 19 * 1024 // KiB
+	argon2Time    = 2
+	argon2Threads = 1
+	argon2KeyLen  = 32
+	saltLen       = 16
+)
+
+// ハッシュ文字列の形式が不正な場合のエラー
+var ErrInvalidHash = errors.New("invalid password hash")
+
+// Argon2id のパラメータ
+type argon2Params struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+}
+
+var currentParams = argon2Params{memory: argon2Memory, time: argon2Time, threads: argon2Threads}
+
+// パスワードをユーザーごとにランダムなソルトを付けて Argon2id でハッシュ化する
+// パラメータ・ソルトはハッシュ文字列に含める($argon2id$v=19$m=...,t=...,p=...$<salt>$<hash>)。
+func Hash(password string) (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	return encode(currentParams, salt, derive(currentParams, password, salt, argon2KeyLen)), nil
+}
+
+// パスワードがハッシュ文字列と一致するか定数時間で検証する
+// Argon2id・bcrypt のハッシュに加え、移行前の平文で保存されたパスワードも検証できる。
+// 一致し、かつ現在のパラメータの Argon2id で保存し直すべき場合は needsRehash を true で返す。
+func Verify(encoded, password string) (match bool, needsRehash bool, err error) {
+	switch {
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		params, salt, key, err := decode(encoded)
+		if err != nil {
+			return false, false, err
+		}
+		if subtle.ConstantTimeCompare(key, derive(params, password, salt, uint32(len(key)))) != 1 {
+			return false, false, nil
+		}
+		return true, params != currentParams, nil
+
+	case strings.HasPrefix(encoded, "$2a$"), strings.HasPrefix(encoded, "$2b$"), strings.HasPrefix(encoded, "$2y$"):
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, false, nil
+		}
+		if err != nil {
+			return false, false, ErrInvalidHash
+		}
+		return true, true, nil
+
+	default:
+		// 平文(ハッシュ化の導入前に登録されたパスワード)
+		if encoded == "" || subtle.ConstantTimeCompare([]byte(encoded), []byte(password)) != 1 {
+			return false, false, nil
+		}
+		return true, true, nil
+	}
+}
+
+// 平文ではなくハッシュ化されたパスワードか判定する
+func IsHashed(encoded string) bool {
+	return strings.HasPrefix(encoded, "$argon2id$") ||
+		strings.HasPrefix(encoded, "$2a$") ||
+		strings.HasPrefix(encoded, "$2b$") ||
+		strings.HasPrefix(encoded, "$2y$")
+}
+
+var (
+	dummyOnce sync.Once
+	dummyHash string
+)
+
+// ダミーのハッシュに対してパスワードを検証する(結果は常に不一致)
+// ユーザーが存在しない場合も検証と同じ時間をかけ、応答時間からメールアドレスの登録有無を推測されないようにする。
+func VerifyDummy(password string) {
+	dummyOnce.Do(func() {
+		dummyHash, _ = Hash("dummy password")
+	})
+	_, _, _ = Verify(dummyHash, password)
+}
+
+// Argon2id で鍵を導出する
+func derive(params argon2Params, password string, salt []byte, keyLen uint32) []byte {
+	return argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, keyLen)
+}
+
+// パラメータ・ソルト・鍵をハッシュ文字列にする
+func encode(params argon2Params, salt, key []byte) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.memory, params.time, params.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+// ハッシュ文字列からパラメータ・ソルト・鍵を取り出す
+func decode(encoded string) (argon2Params, []byte, []byte, error) {
+	var params argon2Params
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrInvalidHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return params, nil, nil, ErrInvalidHash
+	}
+	if params.memory == 0 || params.time == 0 || params.threads == 0 {
+		return params, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(salt) == 0 {
+		return params, nil, nil, ErrInvalidHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrInvalidHash
+	}
+	return params, salt, key, nil
+}
@@ -0,0 +1,64 @@
+package utils_password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHash(t *testing.T) {
+	hash, err := Hash("password123")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=19456,t=2,p=1$"))
+	assert.True(t, IsHashed(hash))
+
+	// ソルトが異なるため、同じパスワードでも異なるハッシュになる
+	other, err := Hash("password123")
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+}
+
+func TestVerify(t *testing.T) {
+	argon2Hash, _ := Hash("password123")
+	// 古いパラメータで生成したハッシュ
+	oldArgon2Hash := encode(argon2Params{memory: 8 * 1024, time: 1, threads: 1}, []byte("saltsaltsaltsalt"),
+		derive(argon2Params{memory: 8 * 1024, time: 1, threads: 1}, "password123", []byte("saltsaltsaltsalt"), argon2KeyLen))
+	bcryptHash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+
+	tests := []struct {
+		name        string
+		encoded     string
+		password    string
+		match       bool
+		needsRehash bool
+		wantErr     error
+	}{
+		{"Argon2id 一致", argon2Hash, "password123", true, false, nil},
+		{"Argon2id 不一致", argon2Hash, "wrong", false, false, nil},
+		{"古いパラメータの Argon2id は再ハッシュする", oldArgon2Hash, "password123", true, true, nil},
+		{"bcrypt 一致は再ハッシュする", string(bcryptHash), "password123", true, true, nil},
+		{"bcrypt 不一致", string(bcryptHash), "wrong", false, false, nil},
+		{"平文 一致は再ハッシュする", "password123", "password123", true, true, nil},
+		{"平文 不一致", "password123", "wrong", false, false, nil},
+		{"空のパスワードは一致しない", "", "", false, false, nil},
+		{"不正な Argon2id", "$argon2id$v=19$m=0,t=2,p=1$c2FsdA$a2V5", "password123", false, false, ErrInvalidHash},
+		{"区切りが足りない Argon2id", "$argon2id$v=19$c2FsdA$a2V5", "password123", false, false, ErrInvalidHash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, needsRehash, err := Verify(tt.encoded, tt.password)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.match, match)
+			assert.Equal(t, tt.needsRehash, needsRehash)
+		})
+	}
+}
+
+func TestIsHashed(t *testing.T) {
+	assert.True(t, IsHashed("$2b$10$abcdefghijklmnopqrstuv"))
+	assert.False(t, IsHashed("password123"))
+	assert.False(t, IsHashed(""))
+}
@@ -0,0 +1,190 @@
+package utils_slug
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	MaxLength = 100    // スラッグの最大長
+	Fallback  = "post" // タイトルから生成できない場合のスラッグ
+)
+
+// 英小文字・数字をハイフンで区切った形式
+var pattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// スラッグとして有効な形式か判定する
+func Valid(slug string) bool {
+	return len(slug) <= MaxLength && pattern.MatchString(slug)
+}
+
+// 文字の種類(種類が変わる位置で単語を区切る)
+type charClass int
+
+const (
+	classOther charClass = iota
+	classAlnum
+	classHiragana
+	classKatakana
+)
+
+// ひらがなのローマ字表記(ヘボン式)
+var kanaRomaji = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n",
+	'ゔ': "vu",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+	'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo", 'ゎ': "wa",
+}
+
+// 拗音を作る小書きの「ゃ」「ゅ」「ょ」
+var smallYa = map[rune]string{'ゃ': "a", 'ゅ': "u", 'ょ': "o"}
+
+// 外来語の表記に使う小書きの母音
+var smallVowel = map[rune]string{'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o"}
+
+// タイトルからスラッグを生成する
+// 英数字は小文字に、ひらがな・カタカナはローマ字に変換し、それ以外の文字(漢字・記号など)は区切りとして扱う。
+// 変換できる文字がない場合は Fallback を返す。一意にするための連番は呼び出し側で付与すること。
+func Generate(title string) string {
+	// 全角英数字・半角カタカナを正規化する
+	runes := []rune(strings.ToLower(norm.NFKC.String(title)))
+
+	var words []string
+	var word strings.Builder
+	class := classOther
+	sokuon := false
+
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+		sokuon = false
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		c := classify(r)
+
+		// 長音符は直前の母音を伸ばす
+		if r == 'ー' && (class == classHiragana || class == classKatakana) {
+			if s := word.String(); s != "" && strings.ContainsRune("aiueo", rune(s[len(s)-1])) {
+				word.WriteByte(s[len(s)-1])
+			}
+			continue
+		}
+
+		if c != class {
+			flush()
+			class = c
+		}
+
+		switch c {
+		case classAlnum:
+			word.WriteRune(r)
+		case classHiragana, classKatakana:
+			h := toHiragana(r)
+			if h == 'っ' {
+				// 促音は次の音の子音を重ねる
+				sokuon = true
+				continue
+			}
+			romaji := kanaRomaji[h]
+
+			// 拗音(きゃ→kya)・外来語の表記(ふぁ→fa)は次の小書き文字と組み合わせる
+			if i+1 < len(runes) && classify(runes[i+1]) == c {
+				next := toHiragana(runes[i+1])
+				if v, ok := smallYa[next]; ok && len(romaji) > 1 && strings.HasSuffix(romaji, "i") {
+					romaji = yoon(strings.TrimSuffix(romaji, "i"), v)
+					i++
+				} else if v, ok := smallVowel[next]; ok && romaji != "" {
+					base := romaji[:len(romaji)-1]
+					if base == "" {
+						base = "w"
+					}
+					romaji = base + v
+					i++
+				}
+			}
+
+			if sokuon && romaji != "" && !strings.ContainsRune("aiueon", rune(romaji[0])) {
+				if strings.HasPrefix(romaji, "ch") {
+					word.WriteByte('t')
+				} else {
+					word.WriteByte(romaji[0])
+				}
+			}
+			sokuon = false
+			word.WriteString(romaji)
+		}
+	}
+	flush()
+
+	slug := truncate(strings.Join(words, "-"), MaxLength)
+	if slug == "" {
+		return Fallback
+	}
+	return slug
+}
+
+// base に連番を付けたスラッグを返す(例: "hello" → "hello-2")
+// 最大長を超える場合は base を切り詰める。
+func WithSuffix(base string, n int) string {
+	suffix := "-" + strconv.Itoa(n)
+	return truncate(base, MaxLength-len(suffix)) + suffix
+}
+
+// 拗音のローマ字表記を返す(し+ゃ→sha、き+ゃ→kya)
+func yoon(consonant, vowel string) string {
+	switch consonant {
+	case "sh", "ch", "j":
+		return consonant + vowel
+	}
+	return consonant + "y" + vowel
+}
+
+// スラッグを最大長で切り詰め、末尾のハイフンを取り除く
+func truncate(slug string, max int) string {
+	if len(slug) > max {
+		slug = slug[:max]
+	}
+	return strings.TrimRight(slug, "-")
+}
+
+// 文字の種類を返す
+func classify(r rune) charClass {
+	switch {
+	case 'a' <= r && r <= 'z', '0' <= r && r <= '9':
+		return classAlnum
+	case 'ぁ' <= r && r <= 'ゔ':
+		return classHiragana
+	case 'ァ' <= r && r <= 'ヴ':
+		return classKatakana
+	}
+	return classOther
+}
+
+// カタカナをひらがなに変換する
+func toHiragana(r rune) rune {
+	if 'ァ' <= r && r <= 'ヴ' {
+		return r - ('ァ' - 'ぁ')
+	}
+	return r
+}
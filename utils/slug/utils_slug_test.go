@@ -0,0 +1,66 @@
+package utils_slug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{"英語のタイトル", "Hello, World!", "hello-world"},
+		{"連続する記号", "  Go --- Echo  ", "go-echo"},
+		{"全角英数字", "Ｇｏ１２３", "go123"},
+		{"ひらがな", "はじめまして", "hajimemashite"},
+		{"カタカナと長音", "サーバー", "saabaa"},
+		{"半角カタカナ", "ｻｰﾊﾞｰ", "saabaa"},
+		{"拗音", "きょうのしゅくだい", "kyounoshukudai"},
+		{"促音", "ちょっと", "chotto"},
+		{"促音とち", "マッチ", "matchi"},
+		{"外来語の表記", "ファイル", "fairu"},
+		{"ひらがなとカタカナの境界で区切る", "はじめてのブログ", "hajimeteno-burogu"},
+		{"漢字は区切りとして扱う", "Go言語でブログを作る", "go-de-burogu-o-ru"},
+		{"変換できない場合は既定値", "日本語", Fallback},
+		{"空のタイトル", "", Fallback},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Generate(tt.title)
+			assert.Equal(t, tt.want, got)
+			assert.True(t, Valid(got))
+		})
+	}
+}
+
+func TestGenerate_MaxLength(t *testing.T) {
+	// 最大長で切り詰め、末尾にハイフンを残さないこと
+	got := Generate(strings.Repeat("a", MaxLength-1) + " b")
+	assert.Equal(t, strings.Repeat("a", MaxLength-1), got)
+	assert.True(t, Valid(got))
+}
+
+func TestWithSuffix(t *testing.T) {
+	assert.Equal(t, "hello-2", WithSuffix("hello", 2))
+
+	// 連番を付けても最大長を超えないこと
+	got := WithSuffix(strings.Repeat("a", MaxLength), 10)
+	assert.Len(t, got, MaxLength)
+	assert.True(t, strings.HasSuffix(got, "-10"))
+	assert.True(t, Valid(got))
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, Valid("hello-world-2"))
+	assert.False(t, Valid(""))
+	assert.False(t, Valid("Hello"))
+	assert.False(t, Valid("hello--world"))
+	assert.False(t, Valid("-hello"))
+	assert.False(t, Valid("ブログ"))
+	assert.False(t, Valid(strings.Repeat("a", MaxLength+1)))
+}
@@ -0,0 +1,37 @@
+package utils_timeout
+
+import (
+	utils "backend/utils/log"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgconn"
+	"github.com/labstack/echo/v4"
+)
+
+// タイムアウトまたはキャンセルによるエラーか判定する
+func IsTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, context.Canceled) ||
+		pgconn.Timeout(err)
+}
+
+// タイムアウトエラーをレスポンスに変換する
+// 期限切れの場合は504、クライアントの切断などでキャンセルされた場合は503を返す。
+func TimeoutResponse(c echo.Context, err error) error {
+	if errors.Is(err, context.Canceled) {
+		utils.LogError(c, "Request canceled: "+err.Error())
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{
+			"error": "Request canceled",
+		})
+	}
+
+	utils.LogError(c, "Request timed out: "+err.Error())
+	return c.JSON(http.StatusGatewayTimeout, map[string]string{
+		"error": "Request timed out",
+	})
+}
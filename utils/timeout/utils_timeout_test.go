@@ -0,0 +1,49 @@
+package utils_timeout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTimeout(t *testing.T) {
+	assert.True(t, IsTimeout(context.DeadlineExceeded))
+	assert.True(t, IsTimeout(context.Canceled))
+	assert.True(t, IsTimeout(fmt.Errorf("query failed: %w", context.DeadlineExceeded)))
+	assert.False(t, IsTimeout(errors.New("some error")))
+	assert.False(t, IsTimeout(nil))
+}
+
+func TestTimeoutResponse_DeadlineExceeded(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// 期限切れの場合は504を返す
+	err := TimeoutResponse(c, context.DeadlineExceeded)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	assert.JSONEq(t, `{"error":"Request timed out"}`, rec.Body.String())
+}
+
+func TestTimeoutResponse_Canceled(t *testing.T) {
+	// Echoのセットアップ
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// キャンセルされた場合は503を返す
+	err := TimeoutResponse(c, context.Canceled)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.JSONEq(t, `{"error":"Request canceled"}`, rec.Body.String())
+}
@@ -0,0 +1,27 @@
+package utils_token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// 生成するトークンのバイト数
+const tokenBytes = 32
+
+// URLやクッキーにそのまま使える、推測できないランダムなトークンを生成する
+func New() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// トークンを保存用にハッシュ化する
+// トークン自体が十分なエントロピーを持つため、ソルトなしのSHA-256とする。
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
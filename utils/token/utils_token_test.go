@@ -0,0 +1,26 @@
+package utils_token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	token, err := New()
+	assert.NoError(t, err)
+	// 32バイトをパディングなしのBase64URLで表した長さ
+	assert.Len(t, token, 43)
+
+	// 毎回異なるトークンを生成すること
+	other, err := New()
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestHash(t *testing.T) {
+	hash := Hash("token")
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, Hash("token"))
+	assert.NotEqual(t, hash, Hash("other"))
+}
@@ -0,0 +1,144 @@
+package utils_totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RFC 6238 のパラメータ(認証アプリの既定値に合わせる)
+const (
+	Period = 30 // コードが切り替わる間隔(秒)
+	Digits = 6  // コードの桁数
+)
+
+// 生成するシークレットのバイト数(HMAC-SHA1の出力長)
+const secretBytes = 20
+
+// 前後に許容する時間ステップの数(端末の時計のずれを考慮する)
+const skewSteps = 1
+
+// 生成するリカバリーコードのバイト数(Base32で16文字)
+const recoveryCodeBytes = 10
+
+// シークレットはパディングなしのBase32(認証アプリへの入力・otpauth URIの形式)で扱う
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// 推測できないランダムなシークレットを生成する
+func NewSecret() (string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// 認証アプリに登録するための otpauth URI を返す
+// ラベルは「発行者:アカウント名」とし、QRコードにしてそのまま読み取れる形式とする。
+func URI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(Digits))
+	query.Set("period", strconv.Itoa(Period))
+	return "otpauth://totp/" + url.PathEscape(issuer+":"+account) + "?" + query.Encode()
+}
+
+// 指定された日時の時間ステップを返す
+func Step(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// 指定された日時のコードを返す
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+	return code(key, Step(t)), nil
+}
+
+// コードを検証し、一致した時間ステップを返す
+// 時計のずれを考慮して前後のステップも受け付ける。同じコードの再利用を防ぐため、
+// 呼び出し側で一致したステップを記録し、それ以前のステップを拒否すること。
+func Validate(secret, input string, t time.Time) (int64, bool) {
+	if len(input) != Digits {
+		return 0, false
+	}
+	if _, err := strconv.Atoi(input); err != nil {
+		return 0, false
+	}
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false
+	}
+
+	current := Step(t)
+	for step := current - skewSteps; step <= current+skewSteps; step++ {
+		if subtle.ConstantTimeCompare([]byte(code(key, step)), []byte(input)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// シークレットをデコードする(空白・小文字を含む入力も受け付ける)
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	key, err := encoding.DecodeString(strings.TrimRight(secret, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid totp secret: %w", err)
+	}
+	return key, nil
+}
+
+// RFC 4226 (HOTP) の手順でコードを計算する
+func code(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// 動的切り捨て
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
+
+// リカバリーコードを生成する
+// 認証アプリを使えない場合に一度だけ使用できるコードで、"xxxx-xxxx-xxxx-xxxx" の形式とする。
+func NewRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	s := strings.ToLower(encoding.EncodeToString(b))
+	return s[0:4] + "-" + s[4:8] + "-" + s[8:12] + "-" + s[12:16], nil
+}
+
+// 入力されたリカバリーコードを保存時と同じ形式にする
+// 大文字・小文字、区切りのハイフン・空白は区別しない。
+func NormalizeRecoveryCode(input string) string {
+	s := strings.ToLower(input)
+	s = strings.ReplaceAll(s, "-", "")
+	s = strings.ReplaceAll(s, " ", "")
+	if len(s) != 16 {
+		return s
+	}
+	return s[0:4] + "-" + s[4:8] + "-" + s[8:12] + "-" + s[12:16]
+}
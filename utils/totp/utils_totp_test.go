@@ -0,0 +1,104 @@
+package utils_totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 6238 付録Bのシークレット("12345678901234567890")をBase32で表したもの
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	// RFC 6238 付録Bのテストベクター(SHA1、8桁の下6桁)
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{unix: 59, expected: "287082"},
+		{unix: 1111111109, expected: "081804"},
+		{unix: 1111111111, expected: "050471"},
+		{unix: 1234567890, expected: "005924"},
+		{unix: 2000000000, expected: "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := Code(rfcSecret, time.Unix(tt.unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, code)
+	}
+
+	_, err := Code("not base32!", time.Now())
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	// 現在のステップ
+	step, ok := Validate(rfcSecret, "081804", now)
+	assert.True(t, ok)
+	assert.Equal(t, Step(now), step)
+
+	// 時計のずれ(前後1ステップ)は許容する
+	step, ok = Validate(rfcSecret, "081804", now.Add(Period*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, Step(now), step)
+	_, ok = Validate(rfcSecret, "081804", now.Add(-Period*time.Second))
+	assert.True(t, ok)
+
+	// それ以上ずれたコード・形式が不正なコードは拒否する
+	_, ok = Validate(rfcSecret, "081804", now.Add(2*Period*time.Second))
+	assert.False(t, ok)
+	_, ok = Validate(rfcSecret, "000000", now)
+	assert.False(t, ok)
+	_, ok = Validate(rfcSecret, "81804", now)
+	assert.False(t, ok)
+	_, ok = Validate(rfcSecret, "08180a", now)
+	assert.False(t, ok)
+}
+
+func TestNewSecret(t *testing.T) {
+	secret, err := NewSecret()
+	assert.NoError(t, err)
+	// 20バイトをパディングなしのBase32で表した長さ
+	assert.Len(t, secret, 32)
+
+	// 生成したシークレットでコードを計算・検証できる
+	now := time.Now()
+	code, err := Code(secret, now)
+	assert.NoError(t, err)
+	_, ok := Validate(secret, code, now)
+	assert.True(t, ok)
+
+	other, err := NewSecret()
+	assert.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Blog", "user@example.com", rfcSecret)
+
+	parsed, err := url.Parse(uri)
+	assert.NoError(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.Equal(t, "/Blog:user@example.com", parsed.Path)
+	assert.Equal(t, rfcSecret, parsed.Query().Get("secret"))
+	assert.Equal(t, "Blog", parsed.Query().Get("issuer"))
+	assert.Equal(t, "6", parsed.Query().Get("digits"))
+	assert.Equal(t, "30", parsed.Query().Get("period"))
+}
+
+func TestRecoveryCode(t *testing.T) {
+	code, err := NewRecoveryCode()
+	assert.NoError(t, err)
+	assert.Regexp(t, `^[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}$`, code)
+
+	// 大文字・区切りの違いは同じコードとして扱う
+	assert.Equal(t, "abcd-efgh-ijkl-mnop", NormalizeRecoveryCode("ABCD EFGH-IJKL MNOP"))
+	assert.Equal(t, "abcd-efgh-ijkl-mnop", NormalizeRecoveryCode("abcdefghijklmnop"))
+	assert.Equal(t, code, NormalizeRecoveryCode(code))
+}